@@ -83,6 +83,11 @@ const (
 	ClusterLastInstallationPreparationPending           string                             = "Cluster preparation has never been performed for this cluster"
 	ClusterLastInstallationPreparationFailedCondition   hivev1.ClusterInstallConditionType = "LastInstallationPreparationFailed"
 
+	ClusterSpokeHealthyCondition  hivev1.ClusterInstallConditionType = "SpokeHealthy"
+	ClusterSpokeHealthyReason     string                             = "ClusterHealthy"
+	ClusterSpokeDegradedReason    string                             = "ClusterDegraded"
+	ClusterSpokeUnavailableReason string                             = "ClusterUnavailable"
+
 	ClusterConsumerLabel string = "agentclusterinstalls.agent-install.openshift.io/consumer"
 )
 
@@ -312,6 +317,51 @@ type AgentClusterInstallStatus struct {
 	// ValidationsInfo is a JSON-formatted string containing the validation results for each validation id grouped by category (network, hosts-data, etc.)
 	// +optional
 	ValidationsInfo common.ValidationsStatus `json:"validationsInfo,omitempty"`
+
+	// SpokeHealth is the health of the installed cluster, collected periodically after the installation
+	// when the spoke health monitoring is enabled.
+	// +optional
+	SpokeHealth *SpokeHealth `json:"spokeHealth,omitempty"`
+}
+
+// SpokeHealth is a summary of the health of an installed cluster.
+type SpokeHealth struct {
+	// Status is healthy when the cluster version, the cluster operators and the nodes are healthy,
+	// degraded when one of them is not, and unavailable when the cluster can't be reached.
+	// +kubebuilder:validation:Enum=healthy;degraded;unavailable
+	Status string `json:"status"`
+
+	// ClusterVersion is the current version of the cluster.
+	// +optional
+	ClusterVersion string `json:"clusterVersion,omitempty"`
+
+	// DegradedOperators are the cluster operators that are degraded.
+	// +optional
+	DegradedOperators []string `json:"degradedOperators,omitempty"`
+
+	// UnavailableOperators are the cluster operators that aren't available.
+	// +optional
+	UnavailableOperators []string `json:"unavailableOperators,omitempty"`
+
+	// ReadyNodes is the number of nodes that are ready.
+	// +optional
+	ReadyNodes int `json:"readyNodes,omitempty"`
+
+	// TotalNodes is the number of nodes of the cluster.
+	// +optional
+	TotalNodes int `json:"totalNodes,omitempty"`
+
+	// NotReadyNodes are the nodes that aren't ready.
+	// +optional
+	NotReadyNodes []string `json:"notReadyNodes,omitempty"`
+
+	// LastCheckedTime is the time that the health was collected.
+	// +optional
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
+
+	// MonitoringEndTime is the time after which the health of the cluster is no longer collected.
+	// +optional
+	MonitoringEndTime *metav1.Time `json:"monitoringEndTime,omitempty"`
 }
 
 type DebugInfo struct {
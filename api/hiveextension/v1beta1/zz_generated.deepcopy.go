@@ -227,6 +227,11 @@ func (in *AgentClusterInstallStatus) DeepCopyInto(out *AgentClusterInstallStatus
 			(*out)[key] = outVal
 		}
 	}
+	if in.SpokeHealth != nil {
+		in, out := &in.SpokeHealth, &out.SpokeHealth
+		*out = new(SpokeHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentClusterInstallStatus.
@@ -498,3 +503,41 @@ func (in *Proxy) DeepCopy() *Proxy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpokeHealth) DeepCopyInto(out *SpokeHealth) {
+	*out = *in
+	if in.DegradedOperators != nil {
+		in, out := &in.DegradedOperators, &out.DegradedOperators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnavailableOperators != nil {
+		in, out := &in.UnavailableOperators, &out.UnavailableOperators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotReadyNodes != nil {
+		in, out := &in.NotReadyNodes, &out.NotReadyNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastCheckedTime != nil {
+		in, out := &in.LastCheckedTime, &out.LastCheckedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.MonitoringEndTime != nil {
+		in, out := &in.MonitoringEndTime, &out.MonitoringEndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpokeHealth.
+func (in *SpokeHealth) DeepCopy() *SpokeHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(SpokeHealth)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -89,6 +89,8 @@ const (
 	UnbindingMsg                     string                     = "The agent is currently unbinding from a cluster deployment"
 	UnbindingPendingUserActionReason string                     = "UnbindingPendingUserAction"
 	UnbindingPendingUserActionMsg    string                     = "The agent is currently unbinding; Pending host reboot from infraenv image"
+	PendingAttestationReason         string                     = "PendingAttestation"
+	PendingAttestationMsg            string                     = "The agent is waiting for the host to attest its TPM:"
 
 	CleanupCondition    conditionsv1.ConditionType = "Cleanup"
 	CleanupFailedReason string                     = "CleanupFailed"
@@ -34,16 +34,58 @@ type AgentClassificationSpec struct {
 	// +immutable
 	LabelKey string `json:"labelKey"`
 
-	// LabelValue specifies the label value to apply to matched Agents
+	// LabelValue specifies the label value to apply to matched Agents. It's required with Query.
 	//
 	// +immutable
-	LabelValue string `json:"labelValue"`
+	// +optional
+	LabelValue string `json:"labelValue,omitempty"`
 
 	// Query is in gojq format (https://github.com/itchyny/gojq#difference-to-jq)
 	// and will be invoked on each Agent's inventory. The query should return a
 	// boolean. The operator will apply the label to any Agent for which "true"
-	// is returned.
+	// is returned. Either Query or Rules must be set.
+	// +optional
+	Query string `json:"query,omitempty"`
+
+	// Rules compute the label value of each Agent from its inventory. The rule with the highest
+	// priority that matches an Agent decides its label value, the label is removed from the Agents
+	// that no rule matches.
+	// +optional
+	Rules []AgentClassificationRule `json:"rules,omitempty"`
+}
+
+// AgentClassificationRule computes a label value from the inventory of an Agent
+type AgentClassificationRule struct {
+	// Name identifies the rule
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Priority orders the rules, the rules with a higher priority are evaluated first. The rules
+	// with the same priority are evaluated in order.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// Query is in gojq format (https://github.com/itchyny/gojq#difference-to-jq) and will be
+	// invoked on each Agent's inventory. The rule matches the Agent according to the result:
+	// a boolean applies LabelValue when true, a string is applied as the label value, and a
+	// number is a score that selects the label value of Tiers. False, null and empty strings
+	// don't match.
 	Query string `json:"query"`
+
+	// LabelValue is the label value applied when the query returns true
+	// +optional
+	LabelValue string `json:"labelValue,omitempty"`
+
+	// Tiers map the score returned by the query to label values, the tier with the highest
+	// minimum score that the score reaches is applied. A score below all the tiers doesn't match.
+	// +optional
+	Tiers []AgentClassificationTier `json:"tiers,omitempty"`
+}
+
+// AgentClassificationTier is a label value for the scores that reach its minimum score
+type AgentClassificationTier struct {
+	LabelValue string `json:"labelValue"`
+	MinScore   int64  `json:"minScore"`
 }
 
 // AgentClassificationStatus defines the observed state of AgentClassification
@@ -54,6 +96,11 @@ type AgentClassificationStatus struct {
 	// ErrorCount shows how many Agents encountered errors when matching the classification
 	ErrorCount int `json:"errorCount,omitempty"`
 
+	// MatchedCountByValue shows how many Agents currently match the classification with each
+	// label value
+	// +optional
+	MatchedCountByValue map[string]int `json:"matchedCountByValue,omitempty"`
+
 	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
 }
 
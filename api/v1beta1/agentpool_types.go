@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openshift/assisted-service/models"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AgentPoolSatisfiedCondition    conditionsv1.ConditionType = "Satisfied"
+	AgentPoolSatisfiedReason       string                     = "AgentPoolSatisfied"
+	AgentPoolMissingAgentsReason   string                     = "MissingAgents"
+	AgentPoolInstallStartedReason  string                     = "InstallationStarted"
+	AgentPoolInvalidReason         string                     = "InvalidAgentPool"
+	AgentPoolClusterNotFoundReason string                     = "AgentClusterInstallNotFound"
+)
+
+// AgentPoolSpec defines the desired state of AgentPool
+type AgentPoolSpec struct {
+	// AgentClusterInstallRef is the AgentClusterInstall of the cluster that the Agents of the pool
+	// are bound to
+	AgentClusterInstallRef ClusterReference `json:"agentClusterInstallRef"`
+
+	// ControlPlaneAgents is the number of Agents bound with the control plane role. When not set,
+	// the control plane Agents of the provision requirements of the AgentClusterInstall.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ControlPlaneAgents *int `json:"controlPlaneAgents,omitempty"`
+
+	// WorkerAgents is the number of Agents bound with the worker role. When not set, the worker
+	// Agents of the provision requirements of the AgentClusterInstall.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WorkerAgents *int `json:"workerAgents,omitempty"`
+
+	// AgentSelector selects the Agents of the namespace of the pool that can be bound, for both
+	// roles. The labels applied by AgentClassifications can be selected.
+	// +optional
+	AgentSelector *metav1.LabelSelector `json:"agentSelector,omitempty"`
+
+	// ControlPlaneSelector further selects the Agents that can be bound with the control plane role
+	// +optional
+	ControlPlaneSelector *metav1.LabelSelector `json:"controlPlaneSelector,omitempty"`
+
+	// WorkerSelector further selects the Agents that can be bound with the worker role
+	// +optional
+	WorkerSelector *metav1.LabelSelector `json:"workerSelector,omitempty"`
+}
+
+// AgentPoolMember is an Agent bound by the pool
+type AgentPoolMember struct {
+	Name string          `json:"name"`
+	Role models.HostRole `json:"role"`
+}
+
+// AgentPoolStatus defines the observed state of AgentPool
+type AgentPoolStatus struct {
+	// ControlPlaneAgents is the number of Agents bound with the control plane role
+	ControlPlaneAgents int `json:"controlPlaneAgents,omitempty"`
+
+	// WorkerAgents is the number of Agents bound with the worker role
+	WorkerAgents int `json:"workerAgents,omitempty"`
+
+	// Members are the Agents bound by the pool
+	// +optional
+	Members []AgentPoolMember `json:"members,omitempty"`
+
+	// ReplacedAgents are the Agents that were released because they failed their validations, they
+	// aren't bound again by the pool
+	// +optional
+	ReplacedAgents []string `json:"replacedAgents,omitempty"`
+
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.agentClusterInstallRef.name"
+//+kubebuilder:printcolumn:name="Control Plane",type="integer",JSONPath=".status.controlPlaneAgents"
+//+kubebuilder:printcolumn:name="Workers",type="integer",JSONPath=".status.workerAgents"
+
+// AgentPool binds approved Agents to a cluster until it has the desired number of Agents of each
+// role, and replaces the Agents that fail their validations before the installation starts
+type AgentPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AgentPoolSpec   `json:"spec,omitempty"`
+	Status AgentPoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AgentPoolList contains a list of AgentPool
+type AgentPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AgentPool `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &AgentPool{}, &AgentPoolList{})
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AgentRemovalCordonedCondition      conditionsv1.ConditionType = "Cordoned"
+	AgentRemovalDrainedCondition       conditionsv1.ConditionType = "Drained"
+	AgentRemovalBareMetalHostCondition conditionsv1.ConditionType = "BareMetalHostHandled"
+	AgentRemovalUnboundCondition       conditionsv1.ConditionType = "Unbound"
+	AgentRemovalNodeRemovedCondition   conditionsv1.ConditionType = "NodeRemoved"
+	AgentRemovalCompletedCondition     conditionsv1.ConditionType = "Completed"
+
+	AgentRemovalNodeCordonedReason            string = "NodeCordoned"
+	AgentRemovalNodeDrainedReason             string = "NodeDrained"
+	AgentRemovalDrainInProgressReason         string = "DrainInProgress"
+	AgentRemovalDrainTimedOutReason           string = "DrainTimedOut"
+	AgentRemovalNodeNotFoundReason            string = "NodeNotFound"
+	AgentRemovalNotInstalledReason            string = "AgentNotInstalled"
+	AgentRemovalClusterNotFoundReason         string = "ClusterNotFound"
+	AgentRemovalAgentUnboundReason            string = "AgentUnbound"
+	AgentRemovalNodeRemovedReason             string = "NodeRemoved"
+	AgentRemovalWaitingForHostReason          string = "WaitingForHost"
+	AgentRemovalWaitingForNodeRemovalReason   string = "WaitingForNodeRemoval"
+	AgentRemovalNoBareMetalHostReason         string = "NoBareMetalHost"
+	AgentRemovalBareMetalHostReclaimReason    string = "BareMetalHostReclaiming"
+	AgentRemovalBareMetalHostPoweredOffReason string = "BareMetalHostPoweredOff"
+	AgentRemovalStepFailedReason              string = "StepFailed"
+	AgentRemovalAgentNotFoundReason           string = "AgentNotFound"
+	AgentRemovalAgentNotBoundReason           string = "AgentNotBound"
+	AgentRemovalAgentNotWorkerReason          string = "AgentNotWorker"
+	AgentRemovalInProgressReason              string = "RemovalInProgress"
+	AgentRemovalPendingUserActionReason       string = "PendingUserAction"
+	AgentRemovalHostReclaimedReason           string = "HostReclaimed"
+	AgentRemovalHostPoweredOffReason          string = "HostPoweredOff"
+)
+
+// AgentRemovalBareMetalHostAction is what is done with the BareMetalHost of the Agent once the
+// Agent is unbound
+// +kubebuilder:validation:Enum=Reclaim;PowerOff
+type AgentRemovalBareMetalHostAction string
+
+const (
+	// AgentRemovalBareMetalHostActionReclaim deprovisions the host and boots it with the discovery
+	// ISO of its InfraEnv, so the host returns to its InfraEnv
+	AgentRemovalBareMetalHostActionReclaim AgentRemovalBareMetalHostAction = "Reclaim"
+	// AgentRemovalBareMetalHostActionPowerOff powers the host off once the Agent is unbound, the
+	// host stays powered off until the power-off annotation is removed from the BareMetalHost
+	AgentRemovalBareMetalHostActionPowerOff AgentRemovalBareMetalHostAction = "PowerOff"
+)
+
+// AgentRemovalSpec defines the desired state of AgentRemoval
+type AgentRemovalSpec struct {
+	// AgentName is the name of the Agent to remove from its cluster, in the namespace of the
+	// AgentRemoval
+	AgentName string `json:"agentName"`
+
+	// DrainTimeout is how long the node is drained before the removal continues without it. When
+	// not set, the drain waits for as long as the pod disruption budgets don't allow the pods of
+	// the node to be evicted.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// BareMetalHostAction is what is done with the BareMetalHost of the Agent once the Agent is
+	// unbound, it's ignored when the Agent has no BareMetalHost. Without a BareMetalHost the host
+	// is reclaimed back into its InfraEnv when possible.
+	// +kubebuilder:default=Reclaim
+	// +optional
+	BareMetalHostAction AgentRemovalBareMetalHostAction `json:"bareMetalHostAction,omitempty"`
+}
+
+// AgentRemovalStatus defines the observed state of AgentRemoval
+type AgentRemovalStatus struct {
+	// ClusterDeploymentName is the cluster that the Agent was bound to when the removal started
+	// +optional
+	ClusterDeploymentName *ClusterReference `json:"clusterDeploymentName,omitempty"`
+
+	// NodeName is the name of the node of the Agent in the cluster
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// DrainStartTime is when the drain of the node started
+	// +optional
+	DrainStartTime *metav1.Time `json:"drainStartTime,omitempty"`
+
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Agent",type="string",JSONPath=".spec.agentName"
+//+kubebuilder:printcolumn:name="Node",type="string",JSONPath=".status.nodeName"
+//+kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.conditions[?(@.type=='Completed')].status"
+
+// AgentRemoval removes an installed Agent from its cluster: it cordons and drains the node of the
+// Agent, unbinds the Agent, removes the Node and the Machine of the Agent from the cluster, and
+// returns the host to its InfraEnv or powers it off
+type AgentRemoval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AgentRemovalSpec   `json:"spec,omitempty"`
+	Status AgentRemovalStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AgentRemovalList contains a list of AgentRemoval
+type AgentRemovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AgentRemoval `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &AgentRemoval{}, &AgentRemovalList{})
+}
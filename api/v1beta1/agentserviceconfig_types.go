@@ -131,6 +131,40 @@ type AgentServiceConfigSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress"
 	// +optional
 	Ingress *Ingress `json:"ingress,omitempty"`
+
+	// EventRateLimits overrides the rate limits applied by the assisted-service to
+	// the events with the given names. Changes are applied without restarting the
+	// assisted-service.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Event rate limits"
+	// +optional
+	EventRateLimits []EventRateLimit `json:"eventRateLimits,omitempty"`
+}
+
+// EventRateLimit defines how often the assisted-service stores the events with a
+// given name for the same cluster, host or infra-env.
+type EventRateLimit struct {
+	// EventName is the name of the event the limit applies to.
+	EventName string `json:"eventName"`
+	// Interval is the minimum time between two events with the same name for the
+	// same resource.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// RatePerMinute is the number of events per minute allowed for the same
+	// resource once the burst is consumed.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RatePerMinute *int32 `json:"ratePerMinute,omitempty"`
+	// Burst is the number of events that can be stored at once before
+	// RatePerMinute applies.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+	// SamplePercentage is the percentage of the informational events that are
+	// stored.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplePercentage *int32 `json:"samplePercentage,omitempty"`
 }
 
 type Ingress struct {
@@ -0,0 +1,151 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterReprovisionHealthyCondition   conditionsv1.ConditionType = "ClusterHealthy"
+	ClusterReprovisionCompletedCondition conditionsv1.ConditionType = "Completed"
+
+	ClusterReprovisionNodesReadyReason         string = "NodesReady"
+	ClusterReprovisionNodesNotReadyReason      string = "NodesNotReady"
+	ClusterReprovisionClusterUnreachableReason string = "ClusterUnreachable"
+	ClusterReprovisionClusterNotFoundReason    string = "ClusterNotFound"
+	ClusterReprovisionNoAgentsSelectedReason   string = "NoAgentsSelected"
+	ClusterReprovisionInProgressReason         string = "ReprovisionInProgress"
+	ClusterReprovisionStoppingReason           string = "ReprovisionStopping"
+	ClusterReprovisionStoppedReason            string = "ReprovisionStopped"
+	ClusterReprovisionCompletedReason          string = "NodesReprovisioned"
+)
+
+// ReprovisionedAgentState is the state of the reprovisioning of an Agent
+// +kubebuilder:validation:Enum=Pending;Removing;Reinstalling;Verifying;Reprovisioned;Failed;RolledBack
+type ReprovisionedAgentState string
+
+const (
+	// ReprovisionedAgentPending means that the Agent waits for its turn
+	ReprovisionedAgentPending ReprovisionedAgentState = "Pending"
+	// ReprovisionedAgentRemoving means that the node of the Agent is drained and removed from the
+	// cluster, and that the BareMetalHost is deprovisioned and booted with the discovery ISO
+	ReprovisionedAgentRemoving ReprovisionedAgentState = "Removing"
+	// ReprovisionedAgentReinstalling means that the Agent was bound to the cluster again and is
+	// installed as a day-2 node
+	ReprovisionedAgentReinstalling ReprovisionedAgentState = "Reinstalling"
+	// ReprovisionedAgentVerifying means that the Agent was installed, the node must become ready
+	ReprovisionedAgentVerifying ReprovisionedAgentState = "Verifying"
+	// ReprovisionedAgentReprovisioned means that the node of the Agent was reinstalled and is ready
+	ReprovisionedAgentReprovisioned ReprovisionedAgentState = "Reprovisioned"
+	// ReprovisionedAgentFailed means that the Agent couldn't be reprovisioned, the reprovisioning
+	// stopped
+	ReprovisionedAgentFailed ReprovisionedAgentState = "Failed"
+	// ReprovisionedAgentRolledBack means that the reprovisioning stopped before the Agent was
+	// unbound, its node was uncordoned
+	ReprovisionedAgentRolledBack ReprovisionedAgentState = "RolledBack"
+)
+
+// ClusterReprovisionSpec defines the desired state of ClusterReprovision
+type ClusterReprovisionSpec struct {
+	// ClusterDeploymentName is the cluster whose worker nodes are reprovisioned
+	ClusterDeploymentName ClusterReference `json:"clusterDeploymentName"`
+
+	// AgentSelector selects the Agents reprovisioned among the installed worker Agents of the
+	// cluster that have a BareMetalHost, in the namespace of the ClusterReprovision. When not set,
+	// all of them are reprovisioned.
+	// +optional
+	AgentSelector *metav1.LabelSelector `json:"agentSelector,omitempty"`
+
+	// MaxUnavailable is the number of nodes reprovisioned at the same time
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxUnavailable int `json:"maxUnavailable,omitempty"`
+
+	// DrainTimeout is how long each node is drained before its removal continues without it. When
+	// not set, the drain waits for as long as the pod disruption budgets don't allow the pods of
+	// the node to be evicted.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// NodeTimeout is how long each node has to be removed, reinstalled and ready before the
+	// reprovisioning stops. Defaults to 2 hours.
+	// +optional
+	NodeTimeout *metav1.Duration `json:"nodeTimeout,omitempty"`
+}
+
+// ReprovisionedAgent is the reprovisioning of an Agent
+type ReprovisionedAgent struct {
+	// Name is the name of the Agent
+	Name string `json:"name"`
+
+	// NodeName is the name of the node of the Agent in the cluster
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	State ReprovisionedAgentState `json:"state"`
+
+	// StartTime is when the reprovisioning of the Agent started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Message describes the state of the reprovisioning
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterReprovisionStatus defines the observed state of ClusterReprovision
+type ClusterReprovisionStatus struct {
+	// Agents are the Agents selected when the reprovisioning started, in the order they're
+	// reprovisioned
+	// +optional
+	Agents []ReprovisionedAgent `json:"agents,omitempty"`
+
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterDeploymentName.name"
+//+kubebuilder:printcolumn:name="Healthy",type="string",JSONPath=".status.conditions[?(@.type=='ClusterHealthy')].status"
+//+kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.conditions[?(@.type=='Completed')].status"
+
+// ClusterReprovision reinstalls the worker nodes of an installed cluster one batch at a time: the
+// node is drained and removed, its BareMetalHost is deprovisioned and booted with the discovery ISO,
+// and the Agent is installed again as a day-2 node. The reprovisioning stops when a node fails.
+type ClusterReprovision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterReprovisionSpec   `json:"spec,omitempty"`
+	Status ClusterReprovisionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterReprovisionList contains a list of ClusterReprovision
+type ClusterReprovisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterReprovision `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &ClusterReprovision{}, &ClusterReprovisionList{})
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	HardwareProfileAppliedCondition conditionsv1.ConditionType = "Applied"
+	HardwareProfileFailedCondition  conditionsv1.ConditionType = "Failed"
+
+	HardwareProfileAppliedReason     string = "HostsApplied"
+	HardwareProfileApplyingReason    string = "HostsApplying"
+	HardwareProfileNoHostsReason     string = "NoHosts"
+	HardwareProfileHostsFailedReason string = "HostsFailed"
+	HardwareProfileNoFailureReason   string = "NoFailure"
+)
+
+// HardwareProfileHostState is the state of a hardware profile on a BareMetalHost
+// +kubebuilder:validation:Enum=Pending;Applying;Applied;Failed;Skipped
+type HardwareProfileHostState string
+
+const (
+	// HardwareProfileHostPending means that the BareMetalHost wasn't reconciled with the profile yet
+	HardwareProfileHostPending HardwareProfileHostState = "Pending"
+	// HardwareProfileHostApplying means that the profile was set on the BareMetalHost, the
+	// baremetal-operator is applying it
+	HardwareProfileHostApplying HardwareProfileHostState = "Applying"
+	// HardwareProfileHostApplied means that the profile was applied, the BareMetalHost was booted
+	// with the discovery image
+	HardwareProfileHostApplied HardwareProfileHostState = "Applied"
+	// HardwareProfileHostFailed means that the profile couldn't be applied, the BareMetalHost isn't
+	// booted with the discovery image
+	HardwareProfileHostFailed HardwareProfileHostState = "Failed"
+	// HardwareProfileHostSkipped means that the BareMetalHost was booted with the discovery image
+	// before the InfraEnv referenced the profile, the profile isn't applied to running hosts
+	HardwareProfileHostSkipped HardwareProfileHostState = "Skipped"
+)
+
+// HardwareProfileSpec defines the desired state of HardwareProfile
+type HardwareProfileSpec struct {
+	// BootMode is the boot mode of the BareMetalHosts.
+	// +kubebuilder:validation:Enum=UEFI;UEFISecureBoot;legacy
+	// +optional
+	BootMode string `json:"bootMode,omitempty"`
+
+	// FirmwareSettings are set in the HostFirmwareSettings of the BareMetalHosts, by name.
+	// +optional
+	FirmwareSettings map[string]intstr.IntOrString `json:"firmwareSettings,omitempty"`
+
+	// RAID is the RAID configuration of the BareMetalHosts.
+	// +optional
+	RAID *HardwareProfileRAID `json:"raid,omitempty"`
+}
+
+// HardwareProfileRAID is the RAID configuration of a hardware profile, the hardware RAID volumes
+// are used when both are set
+type HardwareProfileRAID struct {
+	// HardwareRAIDVolumes are the volumes of the hardware RAID, the first volume is the root volume.
+	// +optional
+	HardwareRAIDVolumes []HardwareProfileRAIDVolume `json:"hardwareRAIDVolumes,omitempty"`
+
+	// SoftwareRAIDVolumes are the volumes of the software RAID, the first one must be a RAID-1.
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	SoftwareRAIDVolumes []HardwareProfileRAIDVolume `json:"softwareRAIDVolumes,omitempty"`
+}
+
+// HardwareProfileRAIDVolume is a RAID volume of a hardware profile
+type HardwareProfileRAIDVolume struct {
+	Level RAIDLevel `json:"level"`
+
+	// SizeGibibytes is the size of the volume, the whole capacity of the disks when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SizeGibibytes *int `json:"sizeGibibytes,omitempty"`
+
+	// NumberOfPhysicalDisks is the number of disks of a hardware RAID volume, the minimum for its
+	// level when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumberOfPhysicalDisks *int `json:"numberOfPhysicalDisks,omitempty"`
+}
+
+// HardwareProfileHost is the state of the profile on a BareMetalHost
+type HardwareProfileHost struct {
+	// Name is the name of the BareMetalHost
+	Name string `json:"name"`
+
+	// InfraEnv is the InfraEnv of the BareMetalHost that references the profile
+	InfraEnv string `json:"infraEnv"`
+
+	State HardwareProfileHostState `json:"state"`
+
+	// Message describes the state of the profile on the BareMetalHost
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HardwareProfileStatus defines the observed state of HardwareProfile
+type HardwareProfileStatus struct {
+	// Hosts are the BareMetalHosts of the InfraEnvs that reference the profile
+	// +optional
+	Hosts []HardwareProfileHost `json:"hosts,omitempty"`
+
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Applied",type="string",JSONPath=".status.conditions[?(@.type=='Applied')].status"
+//+kubebuilder:printcolumn:name="Failed",type="string",JSONPath=".status.conditions[?(@.type=='Failed')].status"
+
+// HardwareProfile is the firmware settings, RAID configuration and boot mode applied to the
+// BareMetalHosts of the InfraEnvs that reference it, before they are booted with the discovery image.
+type HardwareProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HardwareProfileSpec   `json:"spec,omitempty"`
+	Status HardwareProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// HardwareProfileList contains a list of HardwareProfile
+type HardwareProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HardwareProfile `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &HardwareProfile{}, &HardwareProfileList{})
+}
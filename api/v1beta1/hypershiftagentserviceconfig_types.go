@@ -19,6 +19,7 @@ package v1beta1
 import (
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -29,12 +30,186 @@ type HypershiftAgentServiceConfigSpec struct {
 	// KubeconfigSecretRef is a reference to the secret containing the kubeconfig for the destination Hypershift instance.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hypershift kubeconfig reference"
 	KubeconfigSecretRef corev1.LocalObjectReference `json:"kubeconfigSecretRef"`
+
+	// UpgradeStrategy defines how the deployments of the service are upgraded in place when
+	// their images change.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Upgrade strategy"
+	// +optional
+	UpgradeStrategy *HypershiftUpgradeStrategy `json:"upgradeStrategy,omitempty"`
 }
 
+// HypershiftUpgradeStrategy defines how the deployments of the service are upgraded in place.
+type HypershiftUpgradeStrategy struct {
+	// RolloutType is the strategy used to replace the pods of the webhook deployment, the
+	// assisted-service deployment is always recreated since its pod owns the database volume.
+	// Defaults to Recreate.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +optional
+	RolloutType HypershiftRolloutType `json:"rolloutType,omitempty"`
+
+	// ReadinessTimeout is how long the upgraded deployments have to become ready before the
+	// upgrade is considered failed. Defaults to 10 minutes.
+	// +optional
+	ReadinessTimeout *metav1.Duration `json:"readinessTimeout,omitempty"`
+
+	// AutoRollback rolls the deployments back to the images that were ready before the upgrade
+	// when the upgrade fails. The database image is never rolled back, since the database may
+	// have already been upgraded. Only the images are rolled back, the previous images run with
+	// the env, args and configuration of the current version.
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
+}
+
+// HypershiftRolloutType is the strategy used to replace the pods of a deployment.
+type HypershiftRolloutType string
+
+const (
+	// HypershiftRolloutRecreate kills all the existing pods before creating the new ones.
+	HypershiftRolloutRecreate HypershiftRolloutType = "Recreate"
+	// HypershiftRolloutRollingUpdate replaces the pods one by one.
+	HypershiftRolloutRollingUpdate HypershiftRolloutType = "RollingUpdate"
+)
+
 type HypershiftAgentServiceConfigStatus struct {
 	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+
+	// Components is the readiness of the deployed components of the service.
+	// +optional
+	Components []HypershiftComponentStatus `json:"components,omitempty"`
+
+	// CRDVersionSkew lists the agent-install CRDs that differ between the management cluster
+	// and the hosted cluster.
+	// +optional
+	CRDVersionSkew []HypershiftCRDVersionSkew `json:"crdVersionSkew,omitempty"`
+
+	// Storage is the usage of the persistent volume claims of the service.
+	// +optional
+	Storage []HypershiftStorageStatus `json:"storage,omitempty"`
+
+	// DeployedImages are the images of the deployments the last time they were all ready, by
+	// deployment and container name.
+	// +optional
+	DeployedImages map[string]string `json:"deployedImages,omitempty"`
+
+	// Upgrade is the state of the last in-place upgrade of the service.
+	// +optional
+	Upgrade *HypershiftUpgradeStatus `json:"upgrade,omitempty"`
 }
 
+// HypershiftComponentStatus is the readiness of a deployed component of the service.
+type HypershiftComponentStatus struct {
+	// Name is the name of the workload of the component.
+	Name string `json:"name"`
+	// Kind is the kind of the workload of the component, Deployment or StatefulSet.
+	Kind string `json:"kind"`
+	// Ready is true when all the replicas of the component are updated and ready.
+	Ready bool `json:"ready"`
+	// Replicas is the desired number of replicas of the component.
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of ready replicas of the component.
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// UpdatedReplicas is the number of replicas of the component running its latest definition.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// Message describes why the component isn't ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HypershiftCRDVersionSkew describes an agent-install CRD that differs between the management
+// cluster and the hosted cluster.
+type HypershiftCRDVersionSkew struct {
+	// Name is the name of the CRD.
+	Name string `json:"name"`
+	// HubVersions are the versions served by the CRD on the management cluster, empty when
+	// the CRD doesn't exist there.
+	// +optional
+	HubVersions []string `json:"hubVersions,omitempty"`
+	// SpokeVersions are the versions served by the CRD on the hosted cluster, empty when
+	// the CRD doesn't exist there.
+	// +optional
+	SpokeVersions []string `json:"spokeVersions,omitempty"`
+	// Message describes the difference.
+	Message string `json:"message"`
+}
+
+// HypershiftStorageStatus is the usage of a persistent volume claim of the service.
+type HypershiftStorageStatus struct {
+	// Name is the name of the persistent volume claim.
+	Name string `json:"name"`
+	// Phase is the phase of the persistent volume claim.
+	Phase corev1.PersistentVolumeClaimPhase `json:"phase"`
+	// Requested is the storage requested by the persistent volume claim.
+	// +optional
+	Requested *resource.Quantity `json:"requested,omitempty"`
+	// Capacity is the storage provisioned for the persistent volume claim.
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+}
+
+// HypershiftUpgradeState is the state of an in-place upgrade of the service.
+type HypershiftUpgradeState string
+
+const (
+	// HypershiftUpgradeProgressing when the upgraded deployments aren't ready yet.
+	HypershiftUpgradeProgressing HypershiftUpgradeState = "Progressing"
+	// HypershiftUpgradeCompleted when the upgraded deployments are ready.
+	HypershiftUpgradeCompleted HypershiftUpgradeState = "Completed"
+	// HypershiftUpgradeFailed when the upgraded deployments weren't ready in time.
+	HypershiftUpgradeFailed HypershiftUpgradeState = "Failed"
+	// HypershiftUpgradeRolledBack when the upgrade failed and the deployments were rolled back.
+	HypershiftUpgradeRolledBack HypershiftUpgradeState = "RolledBack"
+)
+
+// HypershiftUpgradeStatus is the state of an in-place upgrade of the service.
+type HypershiftUpgradeStatus struct {
+	// State is the state of the upgrade.
+	// +kubebuilder:validation:Enum=Progressing;Completed;Failed;RolledBack
+	State HypershiftUpgradeState `json:"state"`
+	// FromImages are the images of the deployments before the upgrade.
+	// +optional
+	FromImages map[string]string `json:"fromImages,omitempty"`
+	// ToImages are the images of the deployments after the upgrade.
+	// +optional
+	ToImages map[string]string `json:"toImages,omitempty"`
+	// StartTime is when the upgrade started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the upgrade completed, failed or was rolled back.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Message describes the state of the upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Conditions of the HypershiftAgentServiceConfig in addition to the ones of the AgentServiceConfig.
+const (
+	// ConditionSpokeCRDsInSync reports whether the agent-install CRDs of the hosted cluster match
+	// the ones of the management cluster.
+	ConditionSpokeCRDsInSync conditionsv1.ConditionType = "SpokeCRDsInSync"
+	// ConditionStorageReady reports whether the persistent volume claims of the service are bound
+	// with the requested capacity.
+	ConditionStorageReady conditionsv1.ConditionType = "StorageReady"
+	// ConditionUpgradeCompleted reports whether the last in-place upgrade of the service completed.
+	ConditionUpgradeCompleted conditionsv1.ConditionType = "UpgradeCompleted"
+	// ReasonSpokeCRDsInSync when the agent-install CRDs of the hosted cluster match the management cluster.
+	ReasonSpokeCRDsInSync string = "SpokeCRDsInSync"
+	// ReasonSpokeCRDsVersionSkew when agent-install CRDs differ between the hosted and the management cluster.
+	ReasonSpokeCRDsVersionSkew string = "SpokeCRDsVersionSkew"
+	// ReasonStorageReady when all the persistent volume claims are bound with the requested capacity.
+	ReasonStorageReady string = "StorageReady"
+	// ReasonStorageNotReady when a persistent volume claim is missing, not bound or smaller than requested.
+	ReasonStorageNotReady string = "StorageNotReady"
+	// ReasonUpgradeProgressing when the upgraded deployments aren't ready yet.
+	ReasonUpgradeProgressing string = "UpgradeProgressing"
+	// ReasonUpgradeSucceeded when the upgraded deployments are ready.
+	ReasonUpgradeSucceeded string = "UpgradeSucceeded"
+	// ReasonUpgradeFailed when the upgraded deployments weren't ready within the readiness timeout.
+	ReasonUpgradeFailed string = "UpgradeFailed"
+	// ReasonUpgradeRolledBack when the deployments were rolled back after a failed upgrade.
+	ReasonUpgradeRolledBack string = "UpgradeRolledBack"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced
@@ -159,8 +159,44 @@ type InfraEnvSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	NetworkDiscoveryDelaySeconds *int64 `json:"networkDiscoveryDelaySeconds,omitempty"`
+
+	// FirmwareRequirements are checked against the hardware details and the firmware settings
+	// reported by the BareMetalHosts of the Agents, the results are part of the Validated
+	// condition of the Agents.
+	// +optional
+	FirmwareRequirements *FirmwareRequirements `json:"firmwareRequirements,omitempty"`
+
+	// HardwareProfileRef is a HardwareProfile in the namespace of the InfraEnv. The profile is
+	// applied to the BareMetalHosts of the InfraEnv before they are booted with the discovery image.
+	// +optional
+	HardwareProfileRef *corev1.LocalObjectReference `json:"hardwareProfileRef,omitempty"`
+}
+
+// FirmwareRequirements are the firmware and RAID configuration required from the Agents
+// discovered by this InfraEnv.
+type FirmwareRequirements struct {
+	// SecureBoot requires secure boot to be enabled when true, disabled when false.
+	// +optional
+	SecureBoot *bool `json:"secureBoot,omitempty"`
+
+	// BIOSVersions are shell patterns, the BIOS version of the Agents must match one of them.
+	// +optional
+	BIOSVersions []string `json:"biosVersions,omitempty"`
+
+	// BIOSSettings are the firmware settings required from the Agents, by name. The values are
+	// compared ignoring the case.
+	// +optional
+	BIOSSettings map[string]string `json:"biosSettings,omitempty"`
+
+	// RAIDLevels are the levels of the RAID volumes required from the Agents, one volume per item.
+	// +optional
+	RAIDLevels []RAIDLevel `json:"raidLevels,omitempty"`
 }
 
+// RAIDLevel is the level of a RAID volume
+// +kubebuilder:validation:Enum="0";"1";"2";"5";"6";"1+0";"5+0";"6+0"
+type RAIDLevel string
+
 // AgentApproval defines configuration for automatic approval of Agents
 // discovered by this InfraEnv.
 type AgentApproval struct {
@@ -171,6 +207,62 @@ type AgentApproval struct {
 	// +optional
 	// +kubebuilder:default=false
 	AutoApprove bool `json:"autoApprove,omitempty"`
+
+	// Rules approve or quarantine the Agents that match them, the first rule that matches an Agent
+	// decides. A quarantined Agent is never approved automatically, even with AutoApprove, and
+	// can't be installed.
+	// +optional
+	Rules []AgentApprovalRule `json:"rules,omitempty"`
+
+	// DefaultAction decides what happens to the Agents that don't match any rule. Manual leaves
+	// them to AutoApprove or to the user.
+	// +kubebuilder:validation:Enum=approve;quarantine;manual
+	// +optional
+	DefaultAction string `json:"defaultAction,omitempty"`
+}
+
+// AgentApprovalRule approves or quarantines the Agents that match all its criteria, a rule
+// without criteria matches all the Agents.
+type AgentApprovalRule struct {
+	// Name of the rule, reported with the decisions it makes.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Action is what happens to the Agents that match the rule.
+	// +kubebuilder:validation:Enum=approve;quarantine
+	Action string `json:"action"`
+
+	// MACRanges match the Agents with a MAC address in one of the ranges: a single MAC
+	// address, two MAC addresses separated by a dash, or a prefix of up to five octets like an OUI.
+	// +optional
+	MACRanges []string `json:"macRanges,omitempty"`
+
+	// SerialNumbers are shell patterns matching the serial number of the Agent.
+	// +optional
+	SerialNumbers []string `json:"serialNumbers,omitempty"`
+
+	// Manufacturers are shell patterns matching the system manufacturer of the Agent reported by
+	// its SMBIOS, e.g. Dell Inc.
+	// +optional
+	Manufacturers []string `json:"manufacturers,omitempty"`
+
+	// ProductNames are shell patterns matching the product name of the Agent.
+	// +optional
+	ProductNames []string `json:"productNames,omitempty"`
+
+	// MinCPUCores is the minimal number of CPU cores of the Agent.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCPUCores int64 `json:"minCPUCores,omitempty"`
+
+	// MinMemoryMiB is the minimal amount of physical memory of the Agent, in MiB.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinMemoryMiB int64 `json:"minMemoryMiB,omitempty"`
+
+	// Virtual matches the virtual machines when true, the physical machines when false.
+	// +optional
+	Virtual *bool `json:"virtual,omitempty"`
 }
 
 type KernelArgument struct {
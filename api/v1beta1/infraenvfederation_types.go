@@ -0,0 +1,142 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	InfraEnvFederationExportedCondition       conditionsv1.ConditionType = "InfraEnvExported"
+	InfraEnvFederationAgentsMigratedCondition conditionsv1.ConditionType = "AgentsMigrated"
+
+	InfraEnvFederationExportedReason            string = "InfraEnvExported"
+	InfraEnvFederationInfraEnvNotFoundReason    string = "InfraEnvNotFound"
+	InfraEnvFederationPeerUnreachableReason     string = "PeerHubUnreachable"
+	InfraEnvFederationExportFailedReason        string = "ExportFailed"
+	InfraEnvFederationNoAgentsSelectedReason    string = "NoAgentsSelected"
+	InfraEnvFederationMigrationInProgressReason string = "MigrationInProgress"
+	InfraEnvFederationAgentsMigratedReason      string = "AgentsMigrated"
+	InfraEnvFederationMigrationFailedReason     string = "MigrationFailed"
+)
+
+// FederatedAgentState is the state of the migration of an Agent to the peer hub
+// +kubebuilder:validation:Enum=Importing;Migrating;Migrated;Failed
+type FederatedAgentState string
+
+const (
+	// FederatedAgentImporting means that the Agent was copied to the peer hub, which is restoring its
+	// host and issuing its agent token
+	FederatedAgentImporting FederatedAgentState = "Importing"
+	// FederatedAgentMigrating means that the agent of the host is being configured to reach the
+	// service of the peer hub
+	FederatedAgentMigrating FederatedAgentState = "Migrating"
+	// FederatedAgentMigrated means that the agent reaches the peer hub, the Agent was deleted from
+	// this hub
+	FederatedAgentMigrated FederatedAgentState = "Migrated"
+	// FederatedAgentFailed means that the agent of the host couldn't be configured to reach the
+	// peer hub, the host is still managed by this hub
+	FederatedAgentFailed FederatedAgentState = "Failed"
+)
+
+// PeerHub is a hub cluster that InfraEnvs and Agents are federated to
+type PeerHub struct {
+	// KubeconfigSecretRef is the Secret holding the kubeconfig of the peer hub in its 'kubeconfig'
+	// key, in the namespace of the InfraEnvFederation
+	KubeconfigSecretRef corev1.LocalObjectReference `json:"kubeconfigSecretRef"`
+
+	// Namespace is the namespace of the peer hub that the InfraEnv and its Agents are copied to.
+	// Defaults to the namespace of the InfraEnvFederation.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ServiceURL is the URL of the assisted-service of the peer hub, as reached by the agents
+	ServiceURL string `json:"serviceURL"`
+}
+
+// InfraEnvFederationSpec defines the desired state of InfraEnvFederation
+type InfraEnvFederationSpec struct {
+	// InfraEnvName is the name of the InfraEnv exported to the peer hub, in the namespace of the
+	// InfraEnvFederation
+	InfraEnvName string `json:"infraEnvName"`
+
+	// PeerHub is the hub that the InfraEnv is exported to
+	PeerHub PeerHub `json:"peerHub"`
+
+	// AgentSelector selects the discovered Agents of the InfraEnv that are migrated to the peer
+	// hub. Only the Agents that aren't bound to a cluster and that aren't managed by a
+	// BareMetalHost are migrated. When not set, the InfraEnv is exported without its Agents.
+	// +optional
+	AgentSelector *metav1.LabelSelector `json:"agentSelector,omitempty"`
+}
+
+// FederatedAgent is the migration of an Agent to the peer hub
+type FederatedAgent struct {
+	// Name is the name of the Agent, the ID of its host
+	Name string `json:"name"`
+
+	State FederatedAgentState `json:"state"`
+
+	// Message describes the state of the migration
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// InfraEnvFederationStatus defines the observed state of InfraEnvFederation
+type InfraEnvFederationStatus struct {
+	// PeerISODownloadURL is the URL of the discovery ISO of the InfraEnv on the peer hub
+	// +optional
+	PeerISODownloadURL string `json:"peerISODownloadURL,omitempty"`
+
+	// Agents are the Agents migrated to the peer hub
+	// +optional
+	Agents []FederatedAgent `json:"agents,omitempty"`
+
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="InfraEnv",type="string",JSONPath=".spec.infraEnvName"
+//+kubebuilder:printcolumn:name="Exported",type="string",JSONPath=".status.conditions[?(@.type=='InfraEnvExported')].status"
+//+kubebuilder:printcolumn:name="Agents Migrated",type="string",JSONPath=".status.conditions[?(@.type=='AgentsMigrated')].status"
+
+// InfraEnvFederation exports an InfraEnv to a peer hub and migrates its discovered Agents there:
+// the hosts keep running the discovery image, their agents are reconfigured to reach the service of
+// the peer hub without a reboot
+type InfraEnvFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InfraEnvFederationSpec   `json:"spec,omitempty"`
+	Status InfraEnvFederationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// InfraEnvFederationList contains a list of InfraEnvFederation
+type InfraEnvFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InfraEnvFederation `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &InfraEnvFederation{}, &InfraEnvFederationList{})
+}
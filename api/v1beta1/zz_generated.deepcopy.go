@@ -26,7 +26,9 @@ import (
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -59,6 +61,13 @@ func (in *Agent) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentApproval) DeepCopyInto(out *AgentApproval) {
 	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AgentApprovalRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentApproval.
@@ -71,12 +80,52 @@ func (in *AgentApproval) DeepCopy() *AgentApproval {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentApprovalRule) DeepCopyInto(out *AgentApprovalRule) {
+	*out = *in
+	if in.MACRanges != nil {
+		in, out := &in.MACRanges, &out.MACRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SerialNumbers != nil {
+		in, out := &in.SerialNumbers, &out.SerialNumbers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Manufacturers != nil {
+		in, out := &in.Manufacturers, &out.Manufacturers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProductNames != nil {
+		in, out := &in.ProductNames, &out.ProductNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Virtual != nil {
+		in, out := &in.Virtual, &out.Virtual
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentApprovalRule.
+func (in *AgentApprovalRule) DeepCopy() *AgentApprovalRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentApprovalRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentClassification) DeepCopyInto(out *AgentClassification) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -130,9 +179,36 @@ func (in *AgentClassificationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentClassificationRule) DeepCopyInto(out *AgentClassificationRule) {
+	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]AgentClassificationTier, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentClassificationRule.
+func (in *AgentClassificationRule) DeepCopy() *AgentClassificationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentClassificationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentClassificationSpec) DeepCopyInto(out *AgentClassificationSpec) {
 	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AgentClassificationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentClassificationSpec.
@@ -148,6 +224,13 @@ func (in *AgentClassificationSpec) DeepCopy() *AgentClassificationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentClassificationStatus) DeepCopyInto(out *AgentClassificationStatus) {
 	*out = *in
+	if in.MatchedCountByValue != nil {
+		in, out := &in.MatchedCountByValue, &out.MatchedCountByValue
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -167,6 +250,21 @@ func (in *AgentClassificationStatus) DeepCopy() *AgentClassificationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentClassificationTier) DeepCopyInto(out *AgentClassificationTier) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentClassificationTier.
+func (in *AgentClassificationTier) DeepCopy() *AgentClassificationTier {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentClassificationTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentDeprovisionInfo) DeepCopyInto(out *AgentDeprovisionInfo) {
 	*out = *in
@@ -214,6 +312,263 @@ func (in *AgentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPool) DeepCopyInto(out *AgentPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPool.
+func (in *AgentPool) DeepCopy() *AgentPool {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPoolList) DeepCopyInto(out *AgentPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AgentPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPoolList.
+func (in *AgentPoolList) DeepCopy() *AgentPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPoolMember) DeepCopyInto(out *AgentPoolMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPoolMember.
+func (in *AgentPoolMember) DeepCopy() *AgentPoolMember {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPoolMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPoolSpec) DeepCopyInto(out *AgentPoolSpec) {
+	*out = *in
+	out.AgentClusterInstallRef = in.AgentClusterInstallRef
+	if in.ControlPlaneAgents != nil {
+		in, out := &in.ControlPlaneAgents, &out.ControlPlaneAgents
+		*out = new(int)
+		**out = **in
+	}
+	if in.WorkerAgents != nil {
+		in, out := &in.WorkerAgents, &out.WorkerAgents
+		*out = new(int)
+		**out = **in
+	}
+	if in.AgentSelector != nil {
+		in, out := &in.AgentSelector, &out.AgentSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneSelector != nil {
+		in, out := &in.ControlPlaneSelector, &out.ControlPlaneSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkerSelector != nil {
+		in, out := &in.WorkerSelector, &out.WorkerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPoolSpec.
+func (in *AgentPoolSpec) DeepCopy() *AgentPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPoolStatus) DeepCopyInto(out *AgentPoolStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]AgentPoolMember, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplacedAgents != nil {
+		in, out := &in.ReplacedAgents, &out.ReplacedAgents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPoolStatus.
+func (in *AgentPoolStatus) DeepCopy() *AgentPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentRemoval) DeepCopyInto(out *AgentRemoval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRemoval.
+func (in *AgentRemoval) DeepCopy() *AgentRemoval {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentRemoval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentRemoval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentRemovalList) DeepCopyInto(out *AgentRemovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AgentRemoval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRemovalList.
+func (in *AgentRemovalList) DeepCopy() *AgentRemovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentRemovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentRemovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentRemovalSpec) DeepCopyInto(out *AgentRemovalSpec) {
+	*out = *in
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRemovalSpec.
+func (in *AgentRemovalSpec) DeepCopy() *AgentRemovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentRemovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentRemovalStatus) DeepCopyInto(out *AgentRemovalStatus) {
+	*out = *in
+	if in.ClusterDeploymentName != nil {
+		in, out := &in.ClusterDeploymentName, &out.ClusterDeploymentName
+		*out = new(ClusterReference)
+		**out = **in
+	}
+	if in.DrainStartTime != nil {
+		in, out := &in.DrainStartTime, &out.DrainStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRemovalStatus.
+func (in *AgentRemovalStatus) DeepCopy() *AgentRemovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentRemovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentServiceConfig) DeepCopyInto(out *AgentServiceConfig) {
 	*out = *in
@@ -318,6 +673,13 @@ func (in *AgentServiceConfigSpec) DeepCopyInto(out *AgentServiceConfigSpec) {
 		*out = new(Ingress)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EventRateLimits != nil {
+		in, out := &in.EventRateLimits, &out.EventRateLimits
+		*out = make([]EventRateLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentServiceConfigSpec.
@@ -471,65 +833,453 @@ func (in *CSRInfo) DeepCopyInto(out *CSRInfo) {
 	in.ApprovedAt.DeepCopyInto(&out.ApprovedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRInfo.
-func (in *CSRInfo) DeepCopy() *CSRInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRInfo.
+func (in *CSRInfo) DeepCopy() *CSRInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSRStatus) DeepCopyInto(out *CSRStatus) {
+	*out = *in
+	if in.ApprovedCSRs != nil {
+		in, out := &in.ApprovedCSRs, &out.ApprovedCSRs
+		*out = make([]CSRInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastApprovalAttempt.DeepCopyInto(&out.LastApprovalAttempt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRStatus.
+func (in *CSRStatus) DeepCopy() *CSRStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReprovision) DeepCopyInto(out *ClusterReprovision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReprovision.
+func (in *ClusterReprovision) DeepCopy() *ClusterReprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReprovision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReprovisionList) DeepCopyInto(out *ClusterReprovisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterReprovision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReprovisionList.
+func (in *ClusterReprovisionList) DeepCopy() *ClusterReprovisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReprovisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReprovisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReprovisionSpec) DeepCopyInto(out *ClusterReprovisionSpec) {
+	*out = *in
+	out.ClusterDeploymentName = in.ClusterDeploymentName
+	if in.AgentSelector != nil {
+		in, out := &in.AgentSelector, &out.AgentSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.NodeTimeout != nil {
+		in, out := &in.NodeTimeout, &out.NodeTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReprovisionSpec.
+func (in *ClusterReprovisionSpec) DeepCopy() *ClusterReprovisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReprovisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReprovisionStatus) DeepCopyInto(out *ClusterReprovisionStatus) {
+	*out = *in
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = make([]ReprovisionedAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReprovisionStatus.
+func (in *ClusterReprovisionStatus) DeepCopy() *ClusterReprovisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReprovisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugInfo) DeepCopyInto(out *DebugInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugInfo.
+func (in *DebugInfo) DeepCopy() *DebugInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRateLimit) DeepCopyInto(out *EventRateLimit) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RatePerMinute != nil {
+		in, out := &in.RatePerMinute, &out.RatePerMinute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SamplePercentage != nil {
+		in, out := &in.SamplePercentage, &out.SamplePercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRateLimit.
+func (in *EventRateLimit) DeepCopy() *EventRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FederatedAgent) DeepCopyInto(out *FederatedAgent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedAgent.
+func (in *FederatedAgent) DeepCopy() *FederatedAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(FederatedAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareRequirements) DeepCopyInto(out *FirmwareRequirements) {
+	*out = *in
+	if in.SecureBoot != nil {
+		in, out := &in.SecureBoot, &out.SecureBoot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BIOSVersions != nil {
+		in, out := &in.BIOSVersions, &out.BIOSVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BIOSSettings != nil {
+		in, out := &in.BIOSSettings, &out.BIOSSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RAIDLevels != nil {
+		in, out := &in.RAIDLevels, &out.RAIDLevels
+		*out = make([]RAIDLevel, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareRequirements.
+func (in *FirmwareRequirements) DeepCopy() *FirmwareRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareProfile) DeepCopyInto(out *HardwareProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfile.
+func (in *HardwareProfile) DeepCopy() *HardwareProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HardwareProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareProfileHost) DeepCopyInto(out *HardwareProfileHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfileHost.
+func (in *HardwareProfileHost) DeepCopy() *HardwareProfileHost {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareProfileHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareProfileList) DeepCopyInto(out *HardwareProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HardwareProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfileList.
+func (in *HardwareProfileList) DeepCopy() *HardwareProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HardwareProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareProfileRAID) DeepCopyInto(out *HardwareProfileRAID) {
+	*out = *in
+	if in.HardwareRAIDVolumes != nil {
+		in, out := &in.HardwareRAIDVolumes, &out.HardwareRAIDVolumes
+		*out = make([]HardwareProfileRAIDVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SoftwareRAIDVolumes != nil {
+		in, out := &in.SoftwareRAIDVolumes, &out.SoftwareRAIDVolumes
+		*out = make([]HardwareProfileRAIDVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfileRAID.
+func (in *HardwareProfileRAID) DeepCopy() *HardwareProfileRAID {
 	if in == nil {
 		return nil
 	}
-	out := new(CSRInfo)
+	out := new(HardwareProfileRAID)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CSRStatus) DeepCopyInto(out *CSRStatus) {
+func (in *HardwareProfileRAIDVolume) DeepCopyInto(out *HardwareProfileRAIDVolume) {
 	*out = *in
-	if in.ApprovedCSRs != nil {
-		in, out := &in.ApprovedCSRs, &out.ApprovedCSRs
-		*out = make([]CSRInfo, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SizeGibibytes != nil {
+		in, out := &in.SizeGibibytes, &out.SizeGibibytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.NumberOfPhysicalDisks != nil {
+		in, out := &in.NumberOfPhysicalDisks, &out.NumberOfPhysicalDisks
+		*out = new(int)
+		**out = **in
 	}
-	in.LastApprovalAttempt.DeepCopyInto(&out.LastApprovalAttempt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRStatus.
-func (in *CSRStatus) DeepCopy() *CSRStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfileRAIDVolume.
+func (in *HardwareProfileRAIDVolume) DeepCopy() *HardwareProfileRAIDVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(CSRStatus)
+	out := new(HardwareProfileRAIDVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
+func (in *HardwareProfileSpec) DeepCopyInto(out *HardwareProfileSpec) {
 	*out = *in
+	if in.FirmwareSettings != nil {
+		in, out := &in.FirmwareSettings, &out.FirmwareSettings
+		*out = make(map[string]intstr.IntOrString, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RAID != nil {
+		in, out := &in.RAID, &out.RAID
+		*out = new(HardwareProfileRAID)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
-func (in *ClusterReference) DeepCopy() *ClusterReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfileSpec.
+func (in *HardwareProfileSpec) DeepCopy() *HardwareProfileSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterReference)
+	out := new(HardwareProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DebugInfo) DeepCopyInto(out *DebugInfo) {
+func (in *HardwareProfileStatus) DeepCopyInto(out *HardwareProfileStatus) {
 	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HardwareProfileHost, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugInfo.
-func (in *DebugInfo) DeepCopy() *DebugInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareProfileStatus.
+func (in *HardwareProfileStatus) DeepCopy() *HardwareProfileStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DebugInfo)
+	out := new(HardwareProfileStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -845,6 +1595,11 @@ func (in *HypershiftAgentServiceConfigSpec) DeepCopyInto(out *HypershiftAgentSer
 	*out = *in
 	in.AgentServiceConfigSpec.DeepCopyInto(&out.AgentServiceConfigSpec)
 	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(HypershiftUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypershiftAgentServiceConfigSpec.
@@ -867,6 +1622,37 @@ func (in *HypershiftAgentServiceConfigStatus) DeepCopyInto(out *HypershiftAgentS
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]HypershiftComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CRDVersionSkew != nil {
+		in, out := &in.CRDVersionSkew, &out.CRDVersionSkew
+		*out = make([]HypershiftCRDVersionSkew, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = make([]HypershiftStorageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeployedImages != nil {
+		in, out := &in.DeployedImages, &out.DeployedImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(HypershiftUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypershiftAgentServiceConfigStatus.
@@ -879,6 +1665,128 @@ func (in *HypershiftAgentServiceConfigStatus) DeepCopy() *HypershiftAgentService
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypershiftCRDVersionSkew) DeepCopyInto(out *HypershiftCRDVersionSkew) {
+	*out = *in
+	if in.HubVersions != nil {
+		in, out := &in.HubVersions, &out.HubVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SpokeVersions != nil {
+		in, out := &in.SpokeVersions, &out.SpokeVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypershiftCRDVersionSkew.
+func (in *HypershiftCRDVersionSkew) DeepCopy() *HypershiftCRDVersionSkew {
+	if in == nil {
+		return nil
+	}
+	out := new(HypershiftCRDVersionSkew)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypershiftComponentStatus) DeepCopyInto(out *HypershiftComponentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypershiftComponentStatus.
+func (in *HypershiftComponentStatus) DeepCopy() *HypershiftComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HypershiftComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypershiftStorageStatus) DeepCopyInto(out *HypershiftStorageStatus) {
+	*out = *in
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypershiftStorageStatus.
+func (in *HypershiftStorageStatus) DeepCopy() *HypershiftStorageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HypershiftStorageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypershiftUpgradeStatus) DeepCopyInto(out *HypershiftUpgradeStatus) {
+	*out = *in
+	if in.FromImages != nil {
+		in, out := &in.FromImages, &out.FromImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ToImages != nil {
+		in, out := &in.ToImages, &out.ToImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypershiftUpgradeStatus.
+func (in *HypershiftUpgradeStatus) DeepCopy() *HypershiftUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HypershiftUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypershiftUpgradeStrategy) DeepCopyInto(out *HypershiftUpgradeStrategy) {
+	*out = *in
+	if in.ReadinessTimeout != nil {
+		in, out := &in.ReadinessTimeout, &out.ReadinessTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypershiftUpgradeStrategy.
+func (in *HypershiftUpgradeStrategy) DeepCopy() *HypershiftUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(HypershiftUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IgnitionEndpointTokenReference) DeepCopyInto(out *IgnitionEndpointTokenReference) {
 	*out = *in
@@ -936,6 +1844,113 @@ func (in *InfraEnvDebugInfo) DeepCopy() *InfraEnvDebugInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraEnvFederation) DeepCopyInto(out *InfraEnvFederation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraEnvFederation.
+func (in *InfraEnvFederation) DeepCopy() *InfraEnvFederation {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraEnvFederation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InfraEnvFederation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraEnvFederationList) DeepCopyInto(out *InfraEnvFederationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InfraEnvFederation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraEnvFederationList.
+func (in *InfraEnvFederationList) DeepCopy() *InfraEnvFederationList {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraEnvFederationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InfraEnvFederationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraEnvFederationSpec) DeepCopyInto(out *InfraEnvFederationSpec) {
+	*out = *in
+	out.PeerHub = in.PeerHub
+	if in.AgentSelector != nil {
+		in, out := &in.AgentSelector, &out.AgentSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraEnvFederationSpec.
+func (in *InfraEnvFederationSpec) DeepCopy() *InfraEnvFederationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraEnvFederationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraEnvFederationStatus) DeepCopyInto(out *InfraEnvFederationStatus) {
+	*out = *in
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = make([]FederatedAgent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraEnvFederationStatus.
+func (in *InfraEnvFederationStatus) DeepCopy() *InfraEnvFederationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraEnvFederationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InfraEnvList) DeepCopyInto(out *InfraEnvList) {
 	*out = *in
@@ -1012,13 +2027,23 @@ func (in *InfraEnvSpec) DeepCopyInto(out *InfraEnvSpec) {
 	if in.AgentApproval != nil {
 		in, out := &in.AgentApproval, &out.AgentApproval
 		*out = new(AgentApproval)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.NetworkDiscoveryDelaySeconds != nil {
 		in, out := &in.NetworkDiscoveryDelaySeconds, &out.NetworkDiscoveryDelaySeconds
 		*out = new(int64)
 		**out = **in
 	}
+	if in.FirmwareRequirements != nil {
+		in, out := &in.FirmwareRequirements, &out.FirmwareRequirements
+		*out = new(FirmwareRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HardwareProfileRef != nil {
+		in, out := &in.HardwareProfileRef, &out.HardwareProfileRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraEnvSpec.
@@ -1245,6 +2270,22 @@ func (in *OSImage) DeepCopy() *OSImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerHub) DeepCopyInto(out *PeerHub) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerHub.
+func (in *PeerHub) DeepCopy() *PeerHub {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerHub)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Proxy) DeepCopyInto(out *Proxy) {
 	*out = *in
@@ -1278,3 +2319,22 @@ func (in RawNetConfig) DeepCopy() RawNetConfig {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReprovisionedAgent) DeepCopyInto(out *ReprovisionedAgent) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReprovisionedAgent.
+func (in *ReprovisionedAgent) DeepCopy() *ReprovisionedAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(ReprovisionedAgent)
+	in.DeepCopyInto(out)
+	return out
+}
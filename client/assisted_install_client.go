@@ -13,11 +13,16 @@ import (
 	rtclient "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 
+	"github.com/openshift/assisted-service/client/agent_tokens"
+	"github.com/openshift/assisted-service/client/audit"
+	"github.com/openshift/assisted-service/client/authz"
 	"github.com/openshift/assisted-service/client/events"
 	"github.com/openshift/assisted-service/client/installer"
 	"github.com/openshift/assisted-service/client/managed_domains"
 	"github.com/openshift/assisted-service/client/manifests"
 	"github.com/openshift/assisted-service/client/operators"
+	"github.com/openshift/assisted-service/client/quotas"
+	"github.com/openshift/assisted-service/client/tokens"
 	"github.com/openshift/assisted-service/client/versions"
 )
 
@@ -63,22 +68,32 @@ func New(c Config) *AssistedInstall {
 
 	cli := new(AssistedInstall)
 	cli.Transport = transport
+	cli.AgentTokens = agent_tokens.New(transport, strfmt.Default, c.AuthInfo)
+	cli.Audit = audit.New(transport, strfmt.Default, c.AuthInfo)
+	cli.Authz = authz.New(transport, strfmt.Default, c.AuthInfo)
 	cli.Events = events.New(transport, strfmt.Default, c.AuthInfo)
 	cli.Installer = installer.New(transport, strfmt.Default, c.AuthInfo)
 	cli.ManagedDomains = managed_domains.New(transport, strfmt.Default, c.AuthInfo)
 	cli.Manifests = manifests.New(transport, strfmt.Default, c.AuthInfo)
 	cli.Operators = operators.New(transport, strfmt.Default, c.AuthInfo)
+	cli.Quotas = quotas.New(transport, strfmt.Default, c.AuthInfo)
+	cli.Tokens = tokens.New(transport, strfmt.Default, c.AuthInfo)
 	cli.Versions = versions.New(transport, strfmt.Default, c.AuthInfo)
 	return cli
 }
 
 // AssistedInstall is a client for assisted install
 type AssistedInstall struct {
+	AgentTokens    *agent_tokens.Client
+	Audit          *audit.Client
+	Authz          *authz.Client
 	Events         *events.Client
 	Installer      *installer.Client
 	ManagedDomains *managed_domains.Client
 	Manifests      *manifests.Client
 	Operators      *operators.Client
+	Quotas         *quotas.Client
+	Tokens         *tokens.Client
 	Versions       *versions.Client
 	Transport      runtime.ClientTransport
 }
@@ -17,12 +17,21 @@ import (
 
 // API is the interface of the events client
 type API interface {
+	/*
+	   V2AggregateEvents Counts the events of a cluster or an infra-env per time bucket, optionally grouped by name and severity.*/
+	V2AggregateEvents(ctx context.Context, params *V2AggregateEventsParams) (*V2AggregateEventsOK, error)
+	/*
+	   V2GetEventRateLimits Lists the effective event rate limits.*/
+	V2GetEventRateLimits(ctx context.Context, params *V2GetEventRateLimitsParams) (*V2GetEventRateLimitsOK, error)
 	/*
 	   V2ListEvents Lists events for a cluster.*/
 	V2ListEvents(ctx context.Context, params *V2ListEventsParams) (*V2ListEventsOK, error)
 	/*
 	   V2TriggerEvent Add new assisted installer event.*/
 	V2TriggerEvent(ctx context.Context, params *V2TriggerEventParams) (*V2TriggerEventCreated, error)
+	/*
+	   V2UpdateEventRateLimits Replaces the event rate limits overridden through the API.*/
+	V2UpdateEventRateLimits(ctx context.Context, params *V2UpdateEventRateLimitsParams) (*V2UpdateEventRateLimitsOK, error)
 }
 
 // New creates a new events API client.
@@ -43,6 +52,56 @@ type Client struct {
 	authInfo  runtime.ClientAuthInfoWriter
 }
 
+/*
+V2AggregateEvents Counts the events of a cluster or an infra-env per time bucket, optionally grouped by name and severity.
+*/
+func (a *Client) V2AggregateEvents(ctx context.Context, params *V2AggregateEventsParams) (*V2AggregateEventsOK, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "v2AggregateEvents",
+		Method:             "GET",
+		PathPattern:        "/v2/events/aggregations",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &V2AggregateEventsReader{formats: a.formats},
+		AuthInfo:           a.authInfo,
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*V2AggregateEventsOK), nil
+
+}
+
+/*
+V2GetEventRateLimits Lists the effective event rate limits.
+*/
+func (a *Client) V2GetEventRateLimits(ctx context.Context, params *V2GetEventRateLimitsParams) (*V2GetEventRateLimitsOK, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "v2GetEventRateLimits",
+		Method:             "GET",
+		PathPattern:        "/v2/events/rate-limits",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &V2GetEventRateLimitsReader{formats: a.formats},
+		AuthInfo:           a.authInfo,
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*V2GetEventRateLimitsOK), nil
+
+}
+
 /*
 V2ListEvents Lists events for a cluster.
 */
@@ -92,3 +151,28 @@ func (a *Client) V2TriggerEvent(ctx context.Context, params *V2TriggerEventParam
 	return result.(*V2TriggerEventCreated), nil
 
 }
+
+/*
+V2UpdateEventRateLimits Replaces the event rate limits overridden through the API.
+*/
+func (a *Client) V2UpdateEventRateLimits(ctx context.Context, params *V2UpdateEventRateLimitsParams) (*V2UpdateEventRateLimitsOK, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "v2UpdateEventRateLimits",
+		Method:             "PUT",
+		PathPattern:        "/v2/events/rate-limits",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &V2UpdateEventRateLimitsReader{formats: a.formats},
+		AuthInfo:           a.authInfo,
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*V2UpdateEventRateLimitsOK), nil
+
+}
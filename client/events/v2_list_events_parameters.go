@@ -82,12 +82,26 @@ type V2ListEventsParams struct {
 	*/
 	ClusterLevel *bool
 
+	/* Cursor.
+
+	   Cursor returned in the Next-Cursor header of the previous page. Used instead of offset, it can't be combined with include_archived.
+	*/
+	Cursor *string
+
 	/* DeletedHosts.
 
 	   Deleted hosts flag.
 	*/
 	DeletedHosts *bool
 
+	/* EndTime.
+
+	   Only retrieve the events that occurred before this time.
+
+	   Format: date-time
+	*/
+	EndTime *strfmt.DateTime
+
 	/* HostID.
 
 	   A host in the specified cluster to return events for (DEPRECATED. Use `host_ids` instead).
@@ -102,6 +116,12 @@ type V2ListEventsParams struct {
 	*/
 	HostIds []strfmt.UUID
 
+	/* IncludeArchived.
+
+	   Also return events that were archived to object storage by the events retention policy.
+	*/
+	IncludeArchived *bool
+
 	/* InfraEnvID.
 
 	   The infra-env to return events for.
@@ -122,6 +142,12 @@ type V2ListEventsParams struct {
 	*/
 	Message *string
 
+	/* Names.
+
+	   Retrieved events names.
+	*/
+	Names []string
+
 	/* Offset.
 
 	   Number of records to skip before starting to return the records.
@@ -136,12 +162,40 @@ type V2ListEventsParams struct {
 	*/
 	Order *string
 
+	/* Props.
+
+	   A JSON object that the properties of the retrieved events must contain.
+	*/
+	Props *string
+
+	/* RequestID.
+
+	   Only retrieve the events caused by this request.
+
+	   Format: uuid
+	*/
+	RequestID *strfmt.UUID
+
+	/* Search.
+
+	   Full-text search over the messages of the retrieved events.
+	*/
+	Search *string
+
 	/* Severities.
 
 	   Retrieved events severities.
 	*/
 	Severities []string
 
+	/* StartTime.
+
+	   Only retrieve the events that occurred at or after this time.
+
+	   Format: date-time
+	*/
+	StartTime *strfmt.DateTime
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -239,6 +293,17 @@ func (o *V2ListEventsParams) SetClusterLevel(clusterLevel *bool) {
 	o.ClusterLevel = clusterLevel
 }
 
+// WithCursor adds the cursor to the v2 list events params
+func (o *V2ListEventsParams) WithCursor(cursor *string) *V2ListEventsParams {
+	o.SetCursor(cursor)
+	return o
+}
+
+// SetCursor adds the cursor to the v2 list events params
+func (o *V2ListEventsParams) SetCursor(cursor *string) {
+	o.Cursor = cursor
+}
+
 // WithDeletedHosts adds the deletedHosts to the v2 list events params
 func (o *V2ListEventsParams) WithDeletedHosts(deletedHosts *bool) *V2ListEventsParams {
 	o.SetDeletedHosts(deletedHosts)
@@ -250,6 +315,17 @@ func (o *V2ListEventsParams) SetDeletedHosts(deletedHosts *bool) {
 	o.DeletedHosts = deletedHosts
 }
 
+// WithEndTime adds the endTime to the v2 list events params
+func (o *V2ListEventsParams) WithEndTime(endTime *strfmt.DateTime) *V2ListEventsParams {
+	o.SetEndTime(endTime)
+	return o
+}
+
+// SetEndTime adds the endTime to the v2 list events params
+func (o *V2ListEventsParams) SetEndTime(endTime *strfmt.DateTime) {
+	o.EndTime = endTime
+}
+
 // WithHostID adds the hostID to the v2 list events params
 func (o *V2ListEventsParams) WithHostID(hostID *strfmt.UUID) *V2ListEventsParams {
 	o.SetHostID(hostID)
@@ -272,6 +348,17 @@ func (o *V2ListEventsParams) SetHostIds(hostIds []strfmt.UUID) {
 	o.HostIds = hostIds
 }
 
+// WithIncludeArchived adds the includeArchived to the v2 list events params
+func (o *V2ListEventsParams) WithIncludeArchived(includeArchived *bool) *V2ListEventsParams {
+	o.SetIncludeArchived(includeArchived)
+	return o
+}
+
+// SetIncludeArchived adds the includeArchived to the v2 list events params
+func (o *V2ListEventsParams) SetIncludeArchived(includeArchived *bool) {
+	o.IncludeArchived = includeArchived
+}
+
 // WithInfraEnvID adds the infraEnvID to the v2 list events params
 func (o *V2ListEventsParams) WithInfraEnvID(infraEnvID *strfmt.UUID) *V2ListEventsParams {
 	o.SetInfraEnvID(infraEnvID)
@@ -305,6 +392,17 @@ func (o *V2ListEventsParams) SetMessage(message *string) {
 	o.Message = message
 }
 
+// WithNames adds the names to the v2 list events params
+func (o *V2ListEventsParams) WithNames(names []string) *V2ListEventsParams {
+	o.SetNames(names)
+	return o
+}
+
+// SetNames adds the names to the v2 list events params
+func (o *V2ListEventsParams) SetNames(names []string) {
+	o.Names = names
+}
+
 // WithOffset adds the offset to the v2 list events params
 func (o *V2ListEventsParams) WithOffset(offset *int64) *V2ListEventsParams {
 	o.SetOffset(offset)
@@ -327,6 +425,39 @@ func (o *V2ListEventsParams) SetOrder(order *string) {
 	o.Order = order
 }
 
+// WithProps adds the props to the v2 list events params
+func (o *V2ListEventsParams) WithProps(props *string) *V2ListEventsParams {
+	o.SetProps(props)
+	return o
+}
+
+// SetProps adds the props to the v2 list events params
+func (o *V2ListEventsParams) SetProps(props *string) {
+	o.Props = props
+}
+
+// WithRequestID adds the requestID to the v2 list events params
+func (o *V2ListEventsParams) WithRequestID(requestID *strfmt.UUID) *V2ListEventsParams {
+	o.SetRequestID(requestID)
+	return o
+}
+
+// SetRequestID adds the requestId to the v2 list events params
+func (o *V2ListEventsParams) SetRequestID(requestID *strfmt.UUID) {
+	o.RequestID = requestID
+}
+
+// WithSearch adds the search to the v2 list events params
+func (o *V2ListEventsParams) WithSearch(search *string) *V2ListEventsParams {
+	o.SetSearch(search)
+	return o
+}
+
+// SetSearch adds the search to the v2 list events params
+func (o *V2ListEventsParams) SetSearch(search *string) {
+	o.Search = search
+}
+
 // WithSeverities adds the severities to the v2 list events params
 func (o *V2ListEventsParams) WithSeverities(severities []string) *V2ListEventsParams {
 	o.SetSeverities(severities)
@@ -338,6 +469,17 @@ func (o *V2ListEventsParams) SetSeverities(severities []string) {
 	o.Severities = severities
 }
 
+// WithStartTime adds the startTime to the v2 list events params
+func (o *V2ListEventsParams) WithStartTime(startTime *strfmt.DateTime) *V2ListEventsParams {
+	o.SetStartTime(startTime)
+	return o
+}
+
+// SetStartTime adds the startTime to the v2 list events params
+func (o *V2ListEventsParams) SetStartTime(startTime *strfmt.DateTime) {
+	o.StartTime = startTime
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *V2ListEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -391,6 +533,23 @@ func (o *V2ListEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 		}
 	}
 
+	if o.Cursor != nil {
+
+		// query param cursor
+		var qrCursor string
+
+		if o.Cursor != nil {
+			qrCursor = *o.Cursor
+		}
+		qCursor := qrCursor
+		if qCursor != "" {
+
+			if err := r.SetQueryParam("cursor", qCursor); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.DeletedHosts != nil {
 
 		// query param deleted_hosts
@@ -408,6 +567,23 @@ func (o *V2ListEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 		}
 	}
 
+	if o.EndTime != nil {
+
+		// query param end_time
+		var qrEndTime strfmt.DateTime
+
+		if o.EndTime != nil {
+			qrEndTime = *o.EndTime
+		}
+		qEndTime := qrEndTime.String()
+		if qEndTime != "" {
+
+			if err := r.SetQueryParam("end_time", qEndTime); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.HostID != nil {
 
 		// query param host_id
@@ -436,6 +612,23 @@ func (o *V2ListEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 		}
 	}
 
+	if o.IncludeArchived != nil {
+
+		// query param include_archived
+		var qrIncludeArchived bool
+
+		if o.IncludeArchived != nil {
+			qrIncludeArchived = *o.IncludeArchived
+		}
+		qIncludeArchived := swag.FormatBool(qrIncludeArchived)
+		if qIncludeArchived != "" {
+
+			if err := r.SetQueryParam("include_archived", qIncludeArchived); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.InfraEnvID != nil {
 
 		// query param infra_env_id
@@ -487,6 +680,17 @@ func (o *V2ListEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 		}
 	}
 
+	if o.Names != nil {
+
+		// binding items for names
+		joinedNames := o.bindParamNames(reg)
+
+		// query array param names
+		if err := r.SetQueryParam("names", joinedNames...); err != nil {
+			return err
+		}
+	}
+
 	if o.Offset != nil {
 
 		// query param offset
@@ -521,6 +725,57 @@ func (o *V2ListEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 		}
 	}
 
+	if o.Props != nil {
+
+		// query param props
+		var qrProps string
+
+		if o.Props != nil {
+			qrProps = *o.Props
+		}
+		qProps := qrProps
+		if qProps != "" {
+
+			if err := r.SetQueryParam("props", qProps); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.RequestID != nil {
+
+		// query param request_id
+		var qrRequestID strfmt.UUID
+
+		if o.RequestID != nil {
+			qrRequestID = *o.RequestID
+		}
+		qRequestID := qrRequestID.String()
+		if qRequestID != "" {
+
+			if err := r.SetQueryParam("request_id", qRequestID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Search != nil {
+
+		// query param search
+		var qrSearch string
+
+		if o.Search != nil {
+			qrSearch = *o.Search
+		}
+		qSearch := qrSearch
+		if qSearch != "" {
+
+			if err := r.SetQueryParam("search", qSearch); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.Severities != nil {
 
 		// binding items for severities
@@ -532,6 +787,23 @@ func (o *V2ListEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 		}
 	}
 
+	if o.StartTime != nil {
+
+		// query param start_time
+		var qrStartTime strfmt.DateTime
+
+		if o.StartTime != nil {
+			qrStartTime = *o.StartTime
+		}
+		qStartTime := qrStartTime.String()
+		if qStartTime != "" {
+
+			if err := r.SetQueryParam("start_time", qStartTime); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -572,6 +844,23 @@ func (o *V2ListEventsParams) bindParamHostIds(formats strfmt.Registry) []string
 	return hostIdsIS
 }
 
+// bindParamV2ListEvents binds the parameter names
+func (o *V2ListEventsParams) bindParamNames(formats strfmt.Registry) []string {
+	namesIR := o.Names
+
+	var namesIC []string
+	for _, namesIIR := range namesIR { // explode []string
+
+		namesIIV := namesIIR // string as string
+		namesIC = append(namesIC, namesIIV)
+	}
+
+	// items.CollectionFormat: ""
+	namesIS := swag.JoinByFormat(namesIC, "")
+
+	return namesIS
+}
+
 // bindParamV2ListEvents binds the parameter severities
 func (o *V2ListEventsParams) bindParamSeverities(formats strfmt.Registry) []string {
 	severitiesIR := o.Severities
@@ -82,6 +82,10 @@ type V2ListEventsOK struct {
 	 */
 	EventCount int64
 
+	/* Cursor to pass to retrieve the next page of events, empty when there are no more events.
+	 */
+	NextCursor string
+
 	/* Count of events with severity 'critical'.
 	 */
 	SeverityCountCritical int64
@@ -151,6 +155,13 @@ func (o *V2ListEventsOK) readResponse(response runtime.ClientResponse, consumer
 		o.EventCount = valeventCount
 	}
 
+	// hydrates response header Next-Cursor
+	hdrNextCursor := response.GetHeader("Next-Cursor")
+
+	if hdrNextCursor != "" {
+		o.NextCursor = hdrNextCursor
+	}
+
 	// hydrates response header Severity-Count-Critical
 	hdrSeverityCountCritical := response.GetHeader("Severity-Count-Critical")
 
@@ -59,6 +59,12 @@ func (o *GetInfraEnvDownloadURLReader) ReadResponse(response runtime.ClientRespo
 			return nil, err
 		}
 		return nil, result
+	case 429:
+		result := NewGetInfraEnvDownloadURLTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewGetInfraEnvDownloadURLInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -460,6 +466,69 @@ func (o *GetInfraEnvDownloadURLMethodNotAllowed) readResponse(response runtime.C
 	return nil
 }
 
+// NewGetInfraEnvDownloadURLTooManyRequests creates a GetInfraEnvDownloadURLTooManyRequests with default headers values
+func NewGetInfraEnvDownloadURLTooManyRequests() *GetInfraEnvDownloadURLTooManyRequests {
+	return &GetInfraEnvDownloadURLTooManyRequests{}
+}
+
+/*
+GetInfraEnvDownloadURLTooManyRequests describes a response with status code 429, with default header values.
+
+Too many requests.
+*/
+type GetInfraEnvDownloadURLTooManyRequests struct {
+	Payload *models.Error
+}
+
+// IsSuccess returns true when this get infra env download Url too many requests response has a 2xx status code
+func (o *GetInfraEnvDownloadURLTooManyRequests) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this get infra env download Url too many requests response has a 3xx status code
+func (o *GetInfraEnvDownloadURLTooManyRequests) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this get infra env download Url too many requests response has a 4xx status code
+func (o *GetInfraEnvDownloadURLTooManyRequests) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this get infra env download Url too many requests response has a 5xx status code
+func (o *GetInfraEnvDownloadURLTooManyRequests) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this get infra env download Url too many requests response a status code equal to that given
+func (o *GetInfraEnvDownloadURLTooManyRequests) IsCode(code int) bool {
+	return code == 429
+}
+
+func (o *GetInfraEnvDownloadURLTooManyRequests) Error() string {
+	return fmt.Sprintf("[GET /v2/infra-envs/{infra_env_id}/downloads/image-url][%d] getInfraEnvDownloadUrlTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *GetInfraEnvDownloadURLTooManyRequests) String() string {
+	return fmt.Sprintf("[GET /v2/infra-envs/{infra_env_id}/downloads/image-url][%d] getInfraEnvDownloadUrlTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *GetInfraEnvDownloadURLTooManyRequests) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetInfraEnvDownloadURLTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewGetInfraEnvDownloadURLInternalServerError creates a GetInfraEnvDownloadURLInternalServerError with default headers values
 func NewGetInfraEnvDownloadURLInternalServerError() *GetInfraEnvDownloadURLInternalServerError {
 	return &GetInfraEnvDownloadURLInternalServerError{}
@@ -21,6 +21,7 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	metal3_v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	"github.com/openshift/assisted-image-service/pkg/servers"
+	"github.com/openshift/assisted-service/internal/audit"
 	"github.com/openshift/assisted-service/internal/bminventory"
 	"github.com/openshift/assisted-service/internal/cluster"
 	"github.com/openshift/assisted-service/internal/cluster/validations"
@@ -50,9 +51,13 @@ import (
 	"github.com/openshift/assisted-service/internal/operators"
 	"github.com/openshift/assisted-service/internal/operators/handler"
 	"github.com/openshift/assisted-service/internal/provider/registry"
+	"github.com/openshift/assisted-service/internal/quota"
+	"github.com/openshift/assisted-service/internal/ratelimit"
 	"github.com/openshift/assisted-service/internal/releasesources"
+	"github.com/openshift/assisted-service/internal/secrets"
 	"github.com/openshift/assisted-service/internal/spec"
 	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/internal/spokehealth"
 	"github.com/openshift/assisted-service/internal/stream"
 	"github.com/openshift/assisted-service/internal/system"
 	"github.com/openshift/assisted-service/internal/uploader"
@@ -75,6 +80,7 @@ import (
 	"github.com/openshift/assisted-service/pkg/s3wrapper"
 	"github.com/openshift/assisted-service/pkg/staticnetworkconfig"
 	"github.com/openshift/assisted-service/pkg/thread"
+	"github.com/openshift/assisted-service/pkg/tracing"
 	"github.com/openshift/assisted-service/restapi"
 	osclientset "github.com/openshift/client-go/config/clientset/versioned"
 	"github.com/pkg/errors"
@@ -113,6 +119,9 @@ var Options struct {
 	feature.Flags
 
 	Auth                                 auth.Config
+	AuditConfig                          audit.Config
+	QuotaConfig                          quota.Config
+	RateLimitConfig                      ratelimit.Config
 	BMConfig                             bminventory.Config
 	DBConfig                             dbPkg.Config
 	HWValidatorConfig                    hardware.ValidatorCfg
@@ -122,10 +131,14 @@ var Options struct {
 	GCConfig                             garbagecollector.Config
 	ReleaseSourcesConfig                 releasesources.Config
 	StaticNetworkConfig                  staticnetworkconfig.Config
+	EventsRetentionConfig                events.RetentionConfig
+	TracingConfig                        tracing.Config
+	SecretsConfig                        secrets.Config
 	IgnoredOpenshiftVersions             string        `envconfig:"IGNORED_OPENSHIFT_VERSIONS" default:""`
 	ClusterStateMonitorInterval          time.Duration `envconfig:"CLUSTER_MONITOR_INTERVAL" default:"10s"`
 	ClusterEventsUploaderInterval        time.Duration `envconfig:"CLUSTER_EVENTS_UPLOADER_INTERVAL" default:"15m"`
 	EventRateLimits                      string        `envconfig:"EVENT_RATE_LIMITS" default:""`
+	EventRateLimitsConfig                events.RateLimitsConfig
 	S3Config                             s3wrapper.Config
 	HostStateMonitorInterval             time.Duration `envconfig:"HOST_MONITOR_INTERVAL" default:"8s"`
 	Versions                             versions.Versions
@@ -152,6 +165,7 @@ var Options struct {
 	DeletionWorkerInterval               time.Duration `envconfig:"DELETION_WORKER_INTERVAL" default:"1h"`
 	InfraEnvDeletionWorkerInterval       time.Duration `envconfig:"INFRAENV_DELETION_WORKER_INTERVAL" default:"1h"`
 	DeregisterWorkerInterval             time.Duration `envconfig:"DEREGISTER_WORKER_INTERVAL" default:"1h"`
+	EventsRetentionWorkerInterval        time.Duration `envconfig:"EVENTS_RETENTION_WORKER_INTERVAL" default:"1h"`
 	EnableDeletedUnregisteredGC          bool          `envconfig:"ENABLE_DELETE_UNREGISTER_GC" default:"true"`
 	EnableDeregisterInactiveGC           bool          `envconfig:"ENABLE_DEREGISTER_INACTIVE_GC" default:"true"`
 	ServeHTTPS                           bool          `envconfig:"SERVE_HTTPS" default:"false"`
@@ -177,6 +191,8 @@ var Options struct {
 	PreprovisioningImageControllerConfig controllers.PreprovisioningImageControllerConfig
 	BMACConfig                           controllers.BMACConfig
 	InstallerCacheConfig                 installercache.Config
+	SpokeHealthConfig                    spokehealth.Config
+	SpokeKubeconfigConfig                controllers.SpokeKubeconfigConfig
 
 	// EnableSoftTimeouts is a boolean flag to enable Soft timeouts by assisted installer
 	EnableSoftTimeouts bool `envconfig:"ENABLE_SOFT_TIMEOUTS" default:"false"`
@@ -318,6 +334,7 @@ func startKubeAPIControllers(
 
 	spokeClientFactory, err := spoke_k8s_client.NewFactory(log, nil, sys)
 	failOnError(err, "unable to create spoke client factory")
+	spokeClientCache := controllers.NewSpokeClientCache(spokeClientFactory)
 
 	cluster_client := ctrlMgr.GetClient()
 	cluster_reader := ctrlMgr.GetAPIReader()
@@ -369,6 +386,11 @@ func startKubeAPIControllers(
 			Drainer:               &controllers.KubectlDrainer{},
 			Config:                &Options.BMACConfig,
 		}).SetupWithManager(ctrlMgr), "unable to create controller BMH")
+		failOnError((&controllers.HardwareProfileReconciler{
+			Client: ctrlMgr.GetClient(),
+			Log:    log,
+			Scheme: ctrlMgr.GetScheme(),
+		}).SetupWithManager(ctrlMgr), "unable to create controller HardwareProfile")
 	}
 	failOnError((&controllers.AgentClusterInstallReconciler{
 		Client:           ctrlMgr.GetClient(),
@@ -381,11 +403,65 @@ func startKubeAPIControllers(
 		Log:    log,
 	}).SetupWithManager(ctrlMgr), "unable to create controller AgentClassification")
 
+	failOnError((&controllers.AgentPoolReconciler{
+		Client: ctrlMgr.GetClient(),
+		Log:    log,
+	}).SetupWithManager(ctrlMgr), "unable to create controller AgentPool")
+
+	failOnError((&controllers.AgentRemovalReconciler{
+		Client:                ctrlMgr.GetClient(),
+		APIReader:             ctrlMgr.GetAPIReader(),
+		Log:                   log,
+		SpokeK8sClientFactory: spokeClientFactory,
+		Drainer:               &controllers.KubectlDrainer{},
+		EnableMetal3:          Options.EnableMetal3,
+	}).SetupWithManager(ctrlMgr), "unable to create controller AgentRemoval")
+
+	failOnError((&controllers.InfraEnvFederationReconciler{
+		Client:                ctrlMgr.GetClient(),
+		APIReader:             ctrlMgr.GetAPIReader(),
+		Log:                   log,
+		Installer:             bm,
+		SpokeK8sClientFactory: spokeClientFactory,
+		AuthType:              Options.Auth.AuthType,
+	}).SetupWithManager(ctrlMgr), "unable to create controller InfraEnvFederation")
+
+	failOnError((&controllers.ClusterReprovisionReconciler{
+		Client:                ctrlMgr.GetClient(),
+		APIReader:             ctrlMgr.GetAPIReader(),
+		Log:                   log,
+		Scheme:                ctrlMgr.GetScheme(),
+		SpokeK8sClientFactory: spokeClientFactory,
+		Drainer:               &controllers.KubectlDrainer{},
+	}).SetupWithManager(ctrlMgr), "unable to create controller ClusterReprovision")
+
 	failOnError((&controllers.AgentLabelReconciler{
 		Client: ctrlMgr.GetClient(),
 		Log:    log,
 	}).SetupWithManager(ctrlMgr), "unable to create controller AgentLabel")
 
+	if Options.SpokeHealthConfig.Duration > 0 {
+		failOnError((&controllers.SpokeHealthReconciler{
+			Client:           ctrlMgr.GetClient(),
+			APIReader:        ctrlMgr.GetAPIReader(),
+			Log:              log,
+			Installer:        bm,
+			SpokeClientCache: spokeClientCache,
+			Config:           Options.SpokeHealthConfig,
+		}).SetupWithManager(ctrlMgr), "unable to create controller SpokeHealth")
+	}
+
+	if Options.SpokeKubeconfigConfig.RotationInterval > 0 {
+		failOnError((&controllers.SpokeKubeconfigReconciler{
+			Client:           ctrlMgr.GetClient(),
+			APIReader:        ctrlMgr.GetAPIReader(),
+			Log:              log,
+			Scheme:           ctrlMgr.GetScheme(),
+			SpokeClientCache: spokeClientCache,
+			Config:           Options.SpokeKubeconfigConfig,
+		}).SetupWithManager(ctrlMgr), "unable to create controller SpokeKubeconfig")
+	}
+
 	if Options.EnableMetal3 && Options.EnableImageService && useConvergedFlow {
 		failOnError((&controllers.PreprovisioningImageReconciler{
 			Client:           ctrlMgr.GetClient(),
@@ -447,6 +523,16 @@ func main() {
 	err = events.InitializeEventLimits(Options.EventRateLimits, log)
 	failOnError(err, "Failed to initialize event rate limits")
 
+	shutdownTracing, err := tracing.Init(context.Background(), Options.TracingConfig)
+	failOnError(err, "Failed to initialize tracing")
+	if Options.TracingConfig.Enabled {
+		log.WithFields(logrus.Fields{
+			"endpoint":      Options.TracingConfig.Endpoint,
+			"sampler":       Options.TracingConfig.Sampler,
+			"sampler_ratio": Options.TracingConfig.SamplerRatio,
+		}).Info("OpenTelemetry tracing enabled")
+	}
+
 	log.Println(fmt.Sprintf("Started service with OS Images %v, Release Images %v, Release Sources %v, Ignored OpenShift Versions %v",
 		Options.OsImages, Options.ReleaseImages, Options.ReleaseSourcesConfig.ReleaseSources, Options.IgnoredOpenshiftVersions))
 
@@ -473,16 +559,28 @@ func main() {
 
 	notificationStream := getNotificationStream(log)
 	defer notificationStream.Close()
+	auditor, auditWriter := getAuditor(db, log)
+	if auditWriter != nil {
+		defer auditWriter.Close()
+	}
 
 	usageManager := usage.NewManager(log, notificationStream)
+	quotaManager, err := quota.NewManager(Options.QuotaConfig, db, log.WithField("pkg", "quotas"))
+	failOnError(err, "failed to create quota manager")
 	ocmClient := getOCMClient(log)
 
 	authHandler, err := auth.NewAuthenticator(&Options.Auth, ocmClient, log.WithField("pkg", "auth"), db)
 	failOnError(err, "failed to create authenticator")
 	authzHandler := auth.NewAuthzHandler(&Options.Auth, ocmClient, log.WithField("pkg", "authz"), db)
+	authzHandler = auth.NewAPITokenAuthzHandler(authzHandler, log.WithField("pkg", "api-tokens"), db)
+	authzHandler = auth.NewAgentTokenAuthzHandler(authzHandler, log.WithField("pkg", "agent-tokens"), db)
 
 	crdEventsHandler := createCRDEventsHandler()
-	eventsHandler := createEventsHandler(crdEventsHandler, db, authzHandler, notificationStream, log)
+	eventsArchive := events.NewArchive(nil, log.WithField("pkg", "events-archive"))
+	eventRateLimiter := events.NewRateLimiter(Options.EventRateLimitsConfig, db, log.WithField("pkg", "event-rate-limits"))
+	eventsHandler := createEventsHandler(crdEventsHandler, db, authzHandler, notificationStream,
+		events.Options{Archive: eventsArchive, RateLimiter: eventRateLimiter}, log)
+	authHandler = auth.NewAPITokenAuthenticator(authHandler, eventsHandler, log.WithField("pkg", "api-tokens"), db)
 
 	prometheusRegistry := prometheus.DefaultRegisterer
 	metricsManagerConfig := &metrics.MetricsManagerConfig{
@@ -500,6 +598,13 @@ func main() {
 		//in the constructor due to a cyclic dependency with the event handler
 		ocmClient.SetMetrics(metricsManager)
 	}
+	//inject the metric server to the event rate limiter to count the discarded
+	//events. This could not be done in the constructor due to a cyclic dependency
+	//with the event handler
+	eventRateLimiter.SetMetrics(metricsManager)
+
+	apiRateLimiter, err := ratelimit.NewLimiter(Options.RateLimitConfig, db, metricsManager, log.WithField("pkg", "api-rate-limits"))
+	failOnError(err, "failed to create the API rate limiter")
 
 	Options.InstructionConfig.ReleaseImageMirror = Options.ReleaseImageMirror
 	Options.InstructionConfig.CheckClusterVersion = Options.CheckClusterVersion
@@ -549,7 +654,16 @@ func main() {
 
 	var objectHandler = createStorageClient(Options.DeployTarget, Options.Storage, &Options.S3Config,
 		Options.WorkDir, log, metricsManager, Options.FileSystemUsageThreshold, xattrClient)
+	if Options.TracingConfig.Enabled {
+		objectHandler = s3wrapper.NewTracingClient(objectHandler)
+	}
 	createS3Bucket(objectHandler, log)
+	if Options.EventsRetentionConfig.ArchiveEnabled {
+		//inject the object storage to the events archive. This could not be done
+		//in the constructor because the storage client depends on the metrics
+		//manager, which depends on the events handler
+		eventsArchive.SetObjectHandler(objectHandler)
+	}
 
 	manifestsApi := manifests.NewManifestsAPI(db, log.WithField("pkg", "manifests"), objectHandler, usageManager)
 	operatorsManager := operators.NewManager(log, manifestsApi, Options.OperatorsConfig, objectHandler)
@@ -561,8 +675,8 @@ func main() {
 
 	publicRegistries := map[string]bool{}
 	validations.ParsePublicRegistries(publicRegistries, Options.ValidationsConfig.PublicRegistries)
+	var mirrorRegistries []mirrorregistries.RegistriesConf
 	if mirrorRegistriesBuilder.IsMirrorRegistriesConfigured() {
-		var mirrorRegistries []mirrorregistries.RegistriesConf
 		if mirrorRegistries, err = mirrorRegistriesBuilder.ExtractLocationMirrorDataFromRegistries(); err != nil {
 			log.WithError(err).Warnf("failed to parse mirror registries provided to assisted-service, check your mirror registry config map")
 		} else {
@@ -581,6 +695,21 @@ func main() {
 	)
 	failOnError(err, "failed to create pull secret validator")
 
+	var registryCredentialsChecker validations.RegistryCredentialsChecker
+	if Options.ValidationsConfig.DeepPullSecretValidation {
+		var mirrorCA []byte
+		var insecureRegistries []string
+		if mirrorRegistriesBuilder.IsMirrorRegistriesConfigured() {
+			mirrorCA, _ = mirrorRegistriesBuilder.GetMirrorCA()
+			if contents, readErr := mirrorRegistriesBuilder.GetMirrorRegistries(); readErr == nil {
+				_, _, insecureRegistries, _ = mirrorregistries.GetImageRegistries(string(contents))
+			}
+		}
+		registryCredentialsChecker, err = validations.NewRegistryCredentialsChecker(Options.ValidationsConfig, mirrorRegistries, insecureRegistries,
+			mirrorCA, log.WithField("pkg", "registry-credentials-checker"))
+		failOnError(err, "failed to create registry credentials checker")
+	}
+
 	log.Println("DeployTarget: " + Options.DeployTarget)
 
 	var ocpClient k8sclient.K8SClient = nil
@@ -634,7 +763,7 @@ func main() {
 	manifestsGenerator := network.NewManifestsGenerator(manifestsApi, Options.ManifestsGeneratorConfig, db)
 	clusterApi := cluster.NewManager(Options.ClusterConfig, log.WithField("pkg", "cluster-state"), db,
 		notificationStream, eventsHandler, uploadClient, hostApi, metricsManager, manifestsGenerator, lead, operatorsManager,
-		ocmClient, objectHandler, dnsApi, authHandler, manifestsApi, Options.EnableSoftTimeouts, usageManager)
+		ocmClient, objectHandler, dnsApi, authHandler, manifestsApi, Options.EnableSoftTimeouts, usageManager, registryCredentialsChecker)
 	infraEnvApi := infraenv.NewManager(log.WithField("pkg", "host-state"), db, objectHandler)
 
 	clusterEventsUploader := thread.New(
@@ -652,6 +781,29 @@ func main() {
 	hostStateMonitor.Start()
 	defer hostStateMonitor.Stop()
 
+	eventsRetention := events.NewRetention(Options.EventsRetentionConfig, db, eventsArchive, lead, log.WithField("pkg", "events-retention"))
+	eventsRetentionWorker := thread.New(
+		log.WithField("pkg", "events-retention"), "Events Retention Worker", Options.EventsRetentionWorkerInterval, eventsRetention.EnforceRetention)
+	eventsRetentionWorker.Start()
+	defer eventsRetentionWorker.Stop()
+
+	eventRateLimiter.Refresh()
+	eventRateLimitsRefresher := thread.New(
+		log.WithField("pkg", "event-rate-limits"), "Event Rate Limits Refresh", Options.EventRateLimitsConfig.RefreshInterval, eventRateLimiter.Refresh)
+	eventRateLimitsRefresher.Start()
+	defer eventRateLimitsRefresher.Stop()
+
+	if apiRateLimiter.Enabled() {
+		apiRateLimitsCleaner := thread.New(
+			log.WithField("pkg", "api-rate-limits"), "API Rate Limits Cleanup", apiRateLimiter.CleanupInterval(), apiRateLimiter.Cleanup)
+		apiRateLimitsCleaner.Start()
+		defer apiRateLimitsCleaner.Stop()
+		apiRateLimitsSyncer := thread.New(
+			log.WithField("pkg", "api-rate-limits"), "API Rate Limits Sync", apiRateLimiter.SyncInterval(), apiRateLimiter.Sync)
+		apiRateLimitsSyncer.Start()
+		defer apiRateLimitsSyncer.Stop()
+	}
+
 	failOnError(
 		versions.AddReleaseImagesToDBIfNeeded(db, releaseImagesArray, startupLeader, log, Options.EnableKubeAPI, Options.ReleaseSourcesConfig.ReleaseSources),
 		"error occurred while adding configuration release images to the DB if needed",
@@ -734,10 +886,10 @@ func main() {
 	)
 
 	bm := bminventory.NewBareMetalInventory(db, notificationStream, log.WithField("pkg", "Inventory"), hostApi, clusterApi, infraEnvApi, Options.BMConfig,
-		generator, eventsHandler, objectHandler, metricsManager, usageManager, operatorsManager, authHandler, authzHandler, ocpClient, ocmClient,
+		generator, eventsHandler, objectHandler, metricsManager, usageManager, quotaManager, operatorsManager, authHandler, authzHandler, ocpClient, ocmClient,
 		lead, pullSecretValidator, versionHandler, osImages, crdUtils, ignitionBuilder, hwValidator, dnsApi, installConfigBuilder, staticNetworkConfig,
 		Options.GCConfig, providerRegistry, generateInsecureIPXEURLs, Options.GeneratorConfig.InstallInvoker, disconnectedIgnitionGenerator)
-	events := events.NewApi(eventsHandler, logrus.WithField("pkg", "eventsApi"))
+	events := events.NewApi(eventsHandler, eventRateLimiter, logrus.WithField("pkg", "eventsApi"))
 
 	//Set inner handler chain. Inner handlers requires access to the Route
 	innerHandler := func() func(http.Handler) http.Handler {
@@ -745,6 +897,8 @@ func main() {
 			wrapped := metrics.WithMatchedRoute(log.WithField("pkg", "matched-h"), prometheusRegistry)(h)
 
 			wrapped = slowquery.Middleware(slowQueryConfig)(wrapped)
+			wrapped = tracing.RouteMiddleware(wrapped)
+			wrapped = audit.RouteMiddleware(wrapped)
 			wrapped = paramctx.ContextHandler()(wrapped)
 			return wrapped
 		}
@@ -757,16 +911,21 @@ func main() {
 
 	operatorsHandler := handler.NewHandler(operatorsManager, log.WithField("pkg", "operators"), db, eventsHandler, clusterApi)
 	h, api, err := restapi.HandlerAPI(restapi.Config{
-		AuthAgentAuth:       authHandler.AuthAgentAuth,
+		AuthAgentAuth:       auth.AgentAuth(authHandler.AuthAgentAuth),
 		AuthUserAuth:        authHandler.AuthUserAuth,
 		AuthWatcherAuth:     authHandler.AuthWatcherAuth,
 		AuthURLAuth:         authHandler.AuthURLAuth,
 		AuthImageAuth:       authHandler.AuthImageAuth,
 		AuthImageURLAuth:    authHandler.AuthImageAuth,
 		APIKeyAuthenticator: authHandler.CreateAuthenticator(),
-		Authorizer:          authzHandler.CreateAuthorizer(),
+		Authorizer:          audit.Authorizer(ratelimit.Authorizer(apiRateLimiter, authzHandler.CreateAuthorizer())),
 		InstallerAPI:        bm,
 		EventsAPI:           events,
+		AuthzAPI:            auth.NewRoleBindingsAPI(authzHandler, log.WithField("pkg", "role-bindings"), db),
+		TokensAPI:           auth.NewAPITokensAPI(&Options.Auth, authzHandler, eventsHandler, log.WithField("pkg", "api-tokens"), db),
+		AgentTokensAPI:      auth.NewAgentTokensAPI(&Options.Auth, authzHandler, eventsHandler, log.WithField("pkg", "agent-tokens"), db),
+		AuditAPI:            audit.NewAPI(db, log.WithField("pkg", "audit")),
+		QuotasAPI:           quota.NewQuotasAPI(quotaManager, authzHandler, log.WithField("pkg", "quotas")),
 		Logger:              log.Printf,
 		VersionsAPI:         versionsAPIHandler,
 		ManagedDomainsAPI:   domainHandler,
@@ -778,6 +937,8 @@ func main() {
 	api.ServeError = app.WrapServeError()
 	failOnError(err, "Failed to init rest handler")
 
+	h = app.WithAuditMiddleware(h, auditor)
+	h = app.WithRateLimitMiddleware(h, apiRateLimiter)
 	if Options.Auth.AllowedDomains != "" {
 		allowedDomains := strings.Split(strings.ReplaceAll(Options.Auth.AllowedDomains, " ", ""), ",")
 		log.Infof("AllowedDomains were provided, enabling CORS with %s as domain list", allowedDomains)
@@ -786,6 +947,7 @@ func main() {
 	}
 
 	h = gziphandler.GzipHandler(h)
+	h = tracing.Middleware(h)
 	h = app.WithMetricsResponderMiddleware(h)
 	h = app.WithHealthMiddleware(h, []*thread.Thread{hostStateMonitor, clusterStateMonitor},
 		log.WithField("pkg", "healthcheck"), Options.LivenessValidationTimeout)
@@ -805,6 +967,9 @@ func main() {
 	serverInfo.ListenAndServe()
 	<-stop
 	serverInfo.Shutdown()
+	if err = shutdownTracing(context.Background()); err != nil {
+		log.WithError(err).Warn("Failed to flush the pending spans")
+	}
 }
 
 // Run listen on http and https ports if iPXE artifacts need to be exposed via HTTP
@@ -856,9 +1021,39 @@ func setupDB(log logrus.FieldLogger, slowQueryConfig slowquery.Config) *gorm.DB
 		log.WithError(ctx.Err()).Fatal("Timed out connecting to DB")
 	}
 	log.Info("Connected to DB")
+	if Options.TracingConfig.Enabled {
+		if err = db.Use(tracing.NewGormPlugin()); err != nil {
+			log.WithError(err).Fatal("Failed to enable the tracing of the DB calls")
+		}
+	}
+	useSecretsBackend(db, log)
 	return db
 }
 
+// useSecretsBackend keeps the pull secrets and the credentials of the DB records in the configured
+// secrets backend, the DB only keeps references to them
+func useSecretsBackend(db *gorm.DB, log logrus.FieldLogger) {
+	var kubeClient client.Client
+	if Options.SecretsConfig.Backend == secrets.BackendKubernetes {
+		var err error
+		kubeClient, err = client.New(ctrl.GetConfigOrDie(), client.Options{})
+		if err != nil {
+			log.WithError(err).Fatal("Failed to create the client of the kubernetes secrets backend")
+		}
+	}
+	manager, err := secrets.NewManager(Options.SecretsConfig, kubeClient, log.WithField("pkg", "secrets"))
+	if err != nil {
+		log.WithError(err).Fatal("Failed to create the secrets backend")
+	}
+	if !manager.Enabled() {
+		return
+	}
+	if err = db.Use(secrets.NewGormPlugin(manager)); err != nil {
+		log.WithError(err).Fatal("Failed to keep the secrets in the secrets backend")
+	}
+	log.Infof("Keeping the secrets in the %s secrets backend", Options.SecretsConfig.Backend)
+}
+
 func getOCMClient(logger *logrus.Logger) *ocm.Client {
 	var ocmClient *ocm.Client
 	var err error
@@ -939,8 +1134,9 @@ func autoMigrationWithLeader(migrationLeader leader.ElectorInterface, db *gorm.D
 	})
 }
 
-func createEventsHandler(crdEventsHandler controllers.CRDEventsHandler, db *gorm.DB, authzHandler auth.Authorizer, notificationStream stream.Notifier, log logrus.FieldLogger) eventsapi.Handler {
-	eventsHandler := events.New(db, authzHandler, notificationStream, log.WithField("pkg", "events"))
+func createEventsHandler(crdEventsHandler controllers.CRDEventsHandler, db *gorm.DB, authzHandler auth.Authorizer, notificationStream stream.Notifier,
+	eventsOptions events.Options, log logrus.FieldLogger) eventsapi.Handler {
+	eventsHandler := events.NewWithOptions(db, authzHandler, notificationStream, eventsOptions, log.WithField("pkg", "events"))
 
 	if crdEventsHandler != nil {
 		return controllers.NewControllerEventsWrapper(crdEventsHandler, eventsHandler, db, log)
@@ -1055,6 +1251,23 @@ func getNotificationStream(log *logrus.Logger) *stream.NotificationStream {
 	return stream.NewNotificationStream(writer, log, metadata)
 }
 
+// getAuditor returns the auditor of the calls to the API, and the stream writer of the audit records
+// if they are streamed
+func getAuditor(db *gorm.DB, log *logrus.Logger) (*audit.Auditor, stream.StreamWriter) {
+	var auditDB *gorm.DB
+	if Options.AuditConfig.DatabaseEnabled {
+		auditDB = db
+	}
+	if !Options.AuditConfig.StreamEnabled {
+		return audit.NewAuditor(Options.AuditConfig, auditDB, nil, log.WithField("pkg", "audit")), nil
+	}
+	writer, err := stream.NewWriter(log, true)
+	if err != nil {
+		log.WithError(err).Fatal("audit log kafka writer failed to initialize")
+	}
+	return audit.NewAuditor(Options.AuditConfig, auditDB, writer, log.WithField("pkg", "audit")), writer
+}
+
 func getBMOUtils(ctrlMgr manager.Manager, log *logrus.Logger) (controllers.BMOUtils, bool) {
 	if !Options.EnableMetal3 {
 		log.Info("Metal3 integration is disabled (ENABLE_METAL3=false)")
@@ -0,0 +1,478 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChallengeTimeout is how long the agent has to answer an attestation challenge
+const ChallengeTimeout = 10 * time.Minute
+
+// The PCRs quoted when the policy doesn't check any, the measurements of the firmware and of the
+// boot loader
+var defaultPCRSelection = []int{0, 1, 2, 3, 4, 5, 6, 7}
+
+// Result is the result of the verification of the answer of a host to its challenge
+type Result struct {
+	// Reason is set when the host failed the attestation
+	Reason string
+	// Repeated is set when the host already failed the attestation for the same reason
+	Repeated bool
+	// PCRPolicy is the name of the PCR policy that the host matched
+	PCRPolicy string
+}
+
+// PolicyFromInfraEnv returns the attestation policy of the infra-env, or nil when the hosts don't
+// attest
+func PolicyFromInfraEnv(infraEnv *common.InfraEnv) (*models.TpmAttestationPolicy, error) {
+	if infraEnv.TpmAttestationPolicy == nil || *infraEnv.TpmAttestationPolicy == "" {
+		return nil, nil
+	}
+	var policy models.TpmAttestationPolicy
+	if err := json.Unmarshal([]byte(*infraEnv.TpmAttestationPolicy), &policy); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the TPM attestation policy of infra-env %s", infraEnv.ID)
+	}
+	return &policy, nil
+}
+
+// ValidatePolicy checks the attestation policy set by the user
+func ValidatePolicy(policy *models.TpmAttestationPolicy) error {
+	certificates, err := parseCertificates(policy.EkCertificates)
+	if err != nil {
+		return err
+	}
+	if len(certificates) == 0 {
+		return errors.New("the EK certificates of the TPM attestation policy must contain at least one certificate")
+	}
+	for i, pcrPolicy := range policy.PcrPolicies {
+		if pcrPolicy == nil || len(pcrPolicy.Pcrs) == 0 {
+			return errors.Errorf("the PCR policy %d doesn't have any PCR", i)
+		}
+		if _, err = parsePCRs(pcrPolicy.Pcrs); err != nil {
+			return errors.Wrapf(err, "invalid PCR policy %d", i)
+		}
+	}
+	return nil
+}
+
+func parseCertificates(bundle string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse the EK certificate")
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, errors.New("the EK certificates must be PEM encoded")
+	}
+	return certificates, nil
+}
+
+// parsePCRs parses the hex encoded SHA-256 values of the PCRs, by PCR index
+func parsePCRs(pcrs map[string]string) (map[int][]byte, error) {
+	values := map[int][]byte{}
+	for key, value := range pcrs {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= pcrCount {
+			return nil, errors.Errorf("invalid PCR index %q", key)
+		}
+		digest, err := hex.DecodeString(value)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, errors.Errorf("the value of PCR %d must be a hex encoded SHA-256 digest", index)
+		}
+		values[index] = digest
+	}
+	return values, nil
+}
+
+// pcrSelection returns the PCRs that the hosts quote, the ones checked by the PCR policies
+func pcrSelection(policy *models.TpmAttestationPolicy) []int {
+	selected := map[int]bool{}
+	for _, pcrPolicy := range policy.PcrPolicies {
+		values, _ := parsePCRs(pcrPolicy.Pcrs)
+		for index := range values {
+			selected[index] = true
+		}
+	}
+	if len(selected) == 0 {
+		return defaultPCRSelection
+	}
+	pcrs := make([]int, 0, len(selected))
+	for index := range selected {
+		pcrs = append(pcrs, index)
+	}
+	sort.Ints(pcrs)
+	return pcrs
+}
+
+// verifyEndorsement checks that the endorsement key certificate is allowed by the policy, and that
+// the attestation key is a restricted signing key of a TPM
+func verifyEndorsement(policy *models.TpmAttestationPolicy, record *common.HostAttestation) (*rsa.PublicKey, *publicArea, error) {
+	if record.EKCertificate == "" || record.AKPublic == "" {
+		return nil, nil, errors.New("the agent didn't send the TPM endorsement of the host")
+	}
+	certificates, err := parseCertificates(record.EKCertificate)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(certificates) != 1 {
+		return nil, nil, errors.New("the agent must send exactly one EK certificate")
+	}
+	ekCertificate := certificates[0]
+	allowed, err := parseCertificates(policy.EkCertificates)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ekCertificateAllowed(ekCertificate, allowed) {
+		return nil, nil, errors.Errorf("the EK certificate %q isn't allowed by the TPM attestation policy", ekCertificate.Subject)
+	}
+	ek, ok := ekCertificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, nil, errors.New("only the RSA endorsement keys are supported")
+	}
+
+	akPublic, err := base64.StdEncoding.DecodeString(record.AKPublic)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "the public area of the attestation key must be base64 encoded")
+	}
+	ak, err := decodePublic(akPublic)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid public area of the attestation key")
+	}
+	if ak.attributes&attestationKeyAttributes != attestationKeyAttributes || ak.attributes&attrDecrypt != 0 {
+		return nil, nil, errors.New("the attestation key must be a restricted signing key generated by the TPM")
+	}
+	return ek, ak, nil
+}
+
+func ekCertificateAllowed(ekCertificate *x509.Certificate, allowed []*x509.Certificate) bool {
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	for _, certificate := range allowed {
+		if certificate.Equal(ekCertificate) {
+			return true
+		}
+		if certificate.IsCA {
+			if bytes.Equal(certificate.RawIssuer, certificate.RawSubject) {
+				roots.AddCert(certificate)
+			} else {
+				intermediates.AddCert(certificate)
+			}
+		}
+	}
+	// The EK certificates usually have critical extensions that the x509 package doesn't handle,
+	// like the TPM manufacturer in the subject alternative name, they don't restrict the chain
+	verified := *ekCertificate
+	verified.UnhandledCriticalExtensions = nil
+	_, err := verified.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+// RegisterHost records the TPM endorsement that a host sent when it registered to an infra-env that
+// requires the attestation, the host is then pending until it attests. The previous attestation of
+// the host is discarded, the host attests every time it registers. The returned reason is set when
+// the endorsement is rejected.
+func RegisterHost(db *gorm.DB, infraEnv *common.InfraEnv, hostID strfmt.UUID, endorsement *models.TpmEndorsement) (string, error) {
+	policy, err := PolicyFromInfraEnv(infraEnv)
+	if err != nil {
+		return "", err
+	}
+	if policy == nil {
+		return "", db.Where("host_id = ? AND infra_env_id = ?", hostID.String(), infraEnv.ID.String()).
+			Delete(&common.HostAttestation{}).Error
+	}
+
+	record := common.HostAttestation{HostID: hostID, InfraEnvID: *infraEnv.ID}
+	if endorsement != nil {
+		record.EKCertificate = endorsement.EkCertificate
+		record.AKPublic = endorsement.AkPublic
+	}
+	if _, _, err = verifyEndorsement(policy, &record); err != nil {
+		record.Error = err.Error()
+	}
+	err = db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&record).Error
+	return record.Error, err
+}
+
+// IsPending returns true when the host registered to an infra-env that requires the attestation and
+// didn't attest yet
+func IsPending(db *gorm.DB, infraEnvID, hostID strfmt.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&common.HostAttestation{}).
+		Where("host_id = ? AND infra_env_id = ? AND attested_at IS NULL", hostID.String(), infraEnvID.String()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FailureReason returns the reason why the pending host failed the attestation, if it did
+func FailureReason(db *gorm.DB, infraEnvID, hostID strfmt.UUID) (string, error) {
+	record, err := pendingRecord(db, infraEnvID, hostID)
+	if err != nil || record == nil {
+		return "", err
+	}
+	return record.Error, nil
+}
+
+// DeletePending releases the hosts of the infra-env that are pending attestation, when the
+// attestation is no longer required
+func DeletePending(db *gorm.DB, infraEnvID strfmt.UUID) error {
+	return db.Where("infra_env_id = ? AND attested_at IS NULL", infraEnvID.String()).Delete(&common.HostAttestation{}).Error
+}
+
+func pendingRecord(db *gorm.DB, infraEnvID, hostID strfmt.UUID) (*common.HostAttestation, error) {
+	var record common.HostAttestation
+	err := db.Where("host_id = ? AND infra_env_id = ? AND attested_at IS NULL", hostID.String(), infraEnvID.String()).
+		Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// RecordFailure records the reason why the host failed the attestation, and returns true when the
+// host already failed for the same reason
+func RecordFailure(db *gorm.DB, infraEnvID, hostID strfmt.UUID, reason string) (bool, error) {
+	record, err := pendingRecord(db, infraEnvID, hostID)
+	if err != nil || record == nil {
+		return false, err
+	}
+	if record.Error == reason {
+		return true, nil
+	}
+	return false, db.Model(record).Update("error", reason).Error
+}
+
+// NewChallenge returns the challenge that the pending host must answer to attest, nil when the
+// host isn't pending or when its endorsement is rejected. The challenge is returned again until the
+// agent answers it or it times out.
+func NewChallenge(db *gorm.DB, infraEnv *common.InfraEnv, host *models.Host) (*models.TpmAttestationRequest, error) {
+	record, err := pendingRecord(db, host.InfraEnvID, *host.ID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	policy, err := PolicyFromInfraEnv(infraEnv)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	// The policy may have changed since the host registered
+	ek, ak, err := verifyEndorsement(policy, record)
+	if err != nil {
+		if record.Error != err.Error() {
+			return nil, db.Model(record).Update("error", err.Error()).Error
+		}
+		return nil, nil
+	}
+
+	selection := pcrSelection(policy)
+	request := &models.TpmAttestationRequest{PcrSelection: make([]int64, 0, len(selection))}
+	for _, pcr := range selection {
+		request.PcrSelection = append(request.PcrSelection, int64(pcr))
+	}
+	if record.ChallengedAt != nil && time.Since(*record.ChallengedAt) < ChallengeTimeout {
+		request.Nonce = record.Nonce
+		request.CredentialBlob = record.CredentialBlob
+		request.EncryptedSecret = record.EncryptedSecret
+		return request, nil
+	}
+
+	nonce := make([]byte, 32)
+	secret := make([]byte, 32)
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	if _, err = rand.Read(secret); err != nil {
+		return nil, err
+	}
+	credentialBlob, encryptedSecret, err := makeCredential(ek, ak.name(), secret)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(secret)
+	request.Nonce = base64.StdEncoding.EncodeToString(nonce)
+	request.CredentialBlob = base64.StdEncoding.EncodeToString(credentialBlob)
+	request.EncryptedSecret = base64.StdEncoding.EncodeToString(encryptedSecret)
+	err = db.Model(record).Updates(map[string]interface{}{
+		"nonce":            request.Nonce,
+		"credential_blob":  request.CredentialBlob,
+		"encrypted_secret": request.EncryptedSecret,
+		"secret_digest":    hex.EncodeToString(digest[:]),
+		"challenged_at":    time.Now(),
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// Verify verifies the answer of the host to its challenge against the policy of the infra-env, the
+// host is attested when it passes. The challenge can't be answered again. The result is nil when
+// the host isn't pending attestation.
+func Verify(db *gorm.DB, infraEnv *common.InfraEnv, host *models.Host, response *models.TpmAttestationResponse) (*Result, error) {
+	record, err := pendingRecord(db, host.InfraEnvID, *host.ID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	policy, err := PolicyFromInfraEnv(infraEnv)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if record.ChallengedAt == nil || time.Since(*record.ChallengedAt) >= ChallengeTimeout {
+		result.Reason = "the agent didn't answer the attestation challenge in time"
+	} else {
+		result.PCRPolicy, err = verifyResponse(policy, record, response)
+		if err != nil {
+			result.Reason = err.Error()
+		}
+	}
+
+	updates := map[string]interface{}{
+		"nonce":            "",
+		"credential_blob":  "",
+		"encrypted_secret": "",
+		"secret_digest":    "",
+		"challenged_at":    nil,
+	}
+	if result.Reason == "" {
+		updates["attested_at"] = time.Now()
+		updates["error"] = ""
+	} else {
+		result.Repeated = record.Error == result.Reason
+		updates["error"] = result.Reason
+	}
+	if err = db.Model(record).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// verifyResponse returns the name of the PCR policy matched by the host, or the reason why the
+// host failed the attestation
+func verifyResponse(policy *models.TpmAttestationPolicy, record *common.HostAttestation, response *models.TpmAttestationResponse) (string, error) {
+	_, ak, err := verifyEndorsement(policy, record)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(response.Secret)
+	if err != nil {
+		return "", errors.New("the secret of the credential must be base64 encoded")
+	}
+	digest := sha256.Sum256(secret)
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(digest[:])), []byte(record.SecretDigest)) != 1 {
+		return "", errors.New("the credential wasn't activated by the TPM of the endorsement key")
+	}
+
+	attest, err := base64.StdEncoding.DecodeString(response.Quote)
+	if err != nil {
+		return "", errors.New("the quote must be base64 encoded")
+	}
+	signature, err := base64.StdEncoding.DecodeString(response.Signature)
+	if err != nil {
+		return "", errors.New("the signature of the quote must be base64 encoded")
+	}
+	if err = verifySignature(ak.key, attest, signature); err != nil {
+		return "", errors.Wrap(err, "the quote isn't signed by the attestation key")
+	}
+	q, err := decodeQuote(attest)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid quote")
+	}
+	nonce, err := base64.StdEncoding.DecodeString(record.Nonce)
+	if err != nil || subtle.ConstantTimeCompare(q.extraData, nonce) != 1 {
+		return "", errors.New("the quote doesn't include the nonce of the challenge")
+	}
+
+	values, err := parsePCRs(response.Pcrs)
+	if err != nil {
+		return "", err
+	}
+	quoted := map[int][]byte{}
+	for _, index := range q.pcrs[algSHA256] {
+		value, ok := values[index]
+		if !ok {
+			return "", errors.Errorf("the agent didn't send the value of the quoted PCR %d", index)
+		}
+		quoted[index] = value
+	}
+	for _, index := range pcrSelection(policy) {
+		if _, ok := quoted[index]; !ok {
+			return "", errors.Errorf("the quote doesn't include PCR %d of the SHA-256 bank", index)
+		}
+	}
+	if !bytes.Equal(pcrDigest(crypto.SHA256, quoted), q.pcrDigest) {
+		return "", errors.New("the PCR values don't match the quote")
+	}
+	return matchPCRPolicy(policy, quoted)
+}
+
+func matchPCRPolicy(policy *models.TpmAttestationPolicy, quoted map[int][]byte) (string, error) {
+	if len(policy.PcrPolicies) == 0 {
+		return "", nil
+	}
+	for i, pcrPolicy := range policy.PcrPolicies {
+		expected, err := parsePCRs(pcrPolicy.Pcrs)
+		if err != nil {
+			return "", err
+		}
+		matches := true
+		for index, value := range expected {
+			if !bytes.Equal(quoted[index], value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			if pcrPolicy.Name != "" {
+				return pcrPolicy.Name, nil
+			}
+			return fmt.Sprintf("%d", i), nil
+		}
+	}
+	mismatched := make([]string, 0, len(quoted))
+	for index := range quoted {
+		mismatched = append(mismatched, strconv.Itoa(index))
+	}
+	sort.Strings(mismatched)
+	return "", errors.Errorf("the values of the PCRs %s don't match any PCR policy", strings.Join(mismatched, ", "))
+}
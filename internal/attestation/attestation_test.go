@@ -0,0 +1,250 @@
+package attestation
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"gorm.io/gorm"
+)
+
+func newPolicy(sim *Simulator, pcrPolicies ...*models.TpmPcrPolicy) *models.TpmAttestationPolicy {
+	return &models.TpmAttestationPolicy{
+		EkCertificates: sim.CACertificatePEM(),
+		PcrPolicies:    pcrPolicies,
+	}
+}
+
+// challenge records the endorsement of the simulator and a challenge, like NewChallenge does
+func challenge(sim *Simulator) (*common.HostAttestation, *models.TpmAttestationRequest) {
+	endorsement := sim.Endorsement()
+	record := &common.HostAttestation{
+		EKCertificate: endorsement.EkCertificate,
+		AKPublic:      endorsement.AkPublic,
+	}
+	ak, err := decodePublic(sim.akPublic)
+	Expect(err).ToNot(HaveOccurred())
+	nonce := []byte(strings.Repeat("n", 32))
+	secret := []byte(strings.Repeat("s", 32))
+	credentialBlob, encryptedSecret, err := makeCredential(&sim.ek.PublicKey, ak.name(), secret)
+	Expect(err).ToNot(HaveOccurred())
+	digest := sha256.Sum256(secret)
+	record.Nonce = base64.StdEncoding.EncodeToString(nonce)
+	record.SecretDigest = hex.EncodeToString(digest[:])
+	return record, &models.TpmAttestationRequest{
+		Nonce:           record.Nonce,
+		CredentialBlob:  base64.StdEncoding.EncodeToString(credentialBlob),
+		EncryptedSecret: base64.StdEncoding.EncodeToString(encryptedSecret),
+		PcrSelection:    []int64{0, 1, 2, 3, 4, 5, 6, 7},
+	}
+}
+
+var _ = Describe("ValidatePolicy", func() {
+	var sim *Simulator
+
+	BeforeEach(func() {
+		var err error
+		sim, err = NewSimulator()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("accepts a bundle of EK certificates with PCR policies", func() {
+		Expect(ValidatePolicy(newPolicy(sim, &models.TpmPcrPolicy{Name: "rhcos", Pcrs: map[string]string{"7": sim.PCR(7)}}))).To(Succeed())
+	})
+
+	It("rejects a policy without certificates", func() {
+		Expect(ValidatePolicy(&models.TpmAttestationPolicy{EkCertificates: "not a certificate"})).ToNot(Succeed())
+	})
+
+	It("rejects invalid PCR policies", func() {
+		Expect(ValidatePolicy(newPolicy(sim, &models.TpmPcrPolicy{Name: "empty"}))).ToNot(Succeed())
+		Expect(ValidatePolicy(newPolicy(sim, &models.TpmPcrPolicy{Pcrs: map[string]string{"24": sim.PCR(0)}}))).ToNot(Succeed())
+		Expect(ValidatePolicy(newPolicy(sim, &models.TpmPcrPolicy{Pcrs: map[string]string{"0": "abcd"}}))).ToNot(Succeed())
+	})
+})
+
+var _ = Describe("verifyResponse", func() {
+	var sim *Simulator
+
+	BeforeEach(func() {
+		var err error
+		sim, err = NewSimulator()
+		Expect(err).ToNot(HaveOccurred())
+		sim.ExtendPCR(7, []byte("secure boot enabled"))
+	})
+
+	It("accepts the quote of the simulator", func() {
+		policy := newPolicy(sim, &models.TpmPcrPolicy{Name: "secure-boot", Pcrs: map[string]string{"7": sim.PCR(7)}})
+		record, request := challenge(sim)
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verifyResponse(policy, record, response)).To(Equal("secure-boot"))
+	})
+
+	It("accepts any PCR values without PCR policies", func() {
+		record, request := challenge(sim)
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verifyResponse(newPolicy(sim), record, response)).To(Equal(""))
+	})
+
+	It("rejects a quote with another nonce", func() {
+		record, request := challenge(sim)
+		request.Nonce = base64.StdEncoding.EncodeToString([]byte("replayed"))
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = verifyResponse(newPolicy(sim), record, response)
+		Expect(err).To(MatchError(ContainSubstring("nonce")))
+	})
+
+	It("rejects PCR values that don't match the policy", func() {
+		policy := newPolicy(sim, &models.TpmPcrPolicy{Name: "secure-boot", Pcrs: map[string]string{"7": sim.PCR(0)}})
+		record, request := challenge(sim)
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = verifyResponse(policy, record, response)
+		Expect(err).To(MatchError(ContainSubstring("don't match any PCR policy")))
+	})
+
+	It("rejects PCR values that don't match the quote", func() {
+		record, request := challenge(sim)
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		response.Pcrs["7"] = sim.PCR(0)
+		_, err = verifyResponse(newPolicy(sim), record, response)
+		Expect(err).To(MatchError(ContainSubstring("don't match the quote")))
+	})
+
+	It("rejects the EK of another TPM", func() {
+		other, err := NewSimulator()
+		Expect(err).ToNot(HaveOccurred())
+		record, request := challenge(sim)
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = verifyResponse(newPolicy(other), record, response)
+		Expect(err).To(MatchError(ContainSubstring("isn't allowed")))
+	})
+
+	It("rejects a secret that wasn't activated", func() {
+		record, request := challenge(sim)
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		response.Secret = base64.StdEncoding.EncodeToString([]byte("guessed"))
+		_, err = verifyResponse(newPolicy(sim), record, response)
+		Expect(err).To(MatchError(ContainSubstring("credential")))
+	})
+})
+
+var _ = Describe("Attestation", func() {
+	var (
+		db       *gorm.DB
+		dbName   string
+		sim      *Simulator
+		infraEnv *common.InfraEnv
+		host     *models.Host
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		var err error
+		sim, err = NewSimulator()
+		Expect(err).ToNot(HaveOccurred())
+		sim.ExtendPCR(7, []byte("secure boot enabled"))
+		policy, err := json.Marshal(newPolicy(sim, &models.TpmPcrPolicy{Name: "secure-boot", Pcrs: map[string]string{"7": sim.PCR(7)}}))
+		Expect(err).ToNot(HaveOccurred())
+		infraEnvID := strfmt.UUID(uuid.New().String())
+		infraEnv = &common.InfraEnv{InfraEnv: models.InfraEnv{ID: &infraEnvID, TpmAttestationPolicy: swag.String(string(policy))}}
+		Expect(db.Create(infraEnv).Error).ToNot(HaveOccurred())
+		hostID := strfmt.UUID(uuid.New().String())
+		host = &models.Host{ID: &hostID, InfraEnvID: infraEnvID}
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	It("attests the host that answers its challenge", func() {
+		reason, err := RegisterHost(db, infraEnv, *host.ID, sim.Endorsement())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+		Expect(IsPending(db, host.InfraEnvID, *host.ID)).To(BeTrue())
+
+		request, err := NewChallenge(db, infraEnv, host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(request.PcrSelection).To(Equal([]int64{7}))
+		again, err := NewChallenge(db, infraEnv, host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(again).To(Equal(request))
+
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+		result, err := Verify(db, infraEnv, host, response)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Reason).To(BeEmpty())
+		Expect(result.PCRPolicy).To(Equal("secure-boot"))
+		Expect(IsPending(db, host.InfraEnvID, *host.ID)).To(BeFalse())
+	})
+
+	It("doesn't accept the same answer twice", func() {
+		_, err := RegisterHost(db, infraEnv, *host.ID, sim.Endorsement())
+		Expect(err).ToNot(HaveOccurred())
+		request, err := NewChallenge(db, infraEnv, host)
+		Expect(err).ToNot(HaveOccurred())
+		sim.ExtendPCR(7, []byte("tampered"))
+		response, err := sim.Attest(request)
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := Verify(db, infraEnv, host, response)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Reason).To(ContainSubstring("don't match any PCR policy"))
+		Expect(result.Repeated).To(BeFalse())
+		Expect(FailureReason(db, host.InfraEnvID, *host.ID)).To(Equal(result.Reason))
+
+		result, err = Verify(db, infraEnv, host, response)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Reason).To(ContainSubstring("in time"))
+		Expect(IsPending(db, host.InfraEnvID, *host.ID)).To(BeTrue())
+	})
+
+	It("renews the challenge when it times out", func() {
+		_, err := RegisterHost(db, infraEnv, *host.ID, sim.Endorsement())
+		Expect(err).ToNot(HaveOccurred())
+		request, err := NewChallenge(db, infraEnv, host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(db.Model(&common.HostAttestation{}).Where("host_id = ?", host.ID.String()).
+			Update("challenged_at", time.Now().Add(-ChallengeTimeout)).Error).ToNot(HaveOccurred())
+		renewed, err := NewChallenge(db, infraEnv, host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renewed.Nonce).ToNot(Equal(request.Nonce))
+	})
+
+	It("holds the host without endorsement", func() {
+		reason, err := RegisterHost(db, infraEnv, *host.ID, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(ContainSubstring("didn't send the TPM endorsement"))
+		Expect(IsPending(db, host.InfraEnvID, *host.ID)).To(BeTrue())
+		Expect(NewChallenge(db, infraEnv, host)).To(BeNil())
+	})
+
+	It("releases the hosts when the attestation is disabled", func() {
+		_, err := RegisterHost(db, infraEnv, *host.ID, sim.Endorsement())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(DeletePending(db, *infraEnv.ID)).To(Succeed())
+		Expect(IsPending(db, host.InfraEnvID, *host.ID)).To(BeFalse())
+
+		infraEnv.TpmAttestationPolicy = nil
+		_, err = RegisterHost(db, infraEnv, *host.ID, sim.Endorsement())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsPending(db, host.InfraEnvID, *host.ID)).To(BeFalse())
+	})
+})
@@ -0,0 +1,179 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+)
+
+// Simulator is a software TPM that answers the attestation challenges like the agent does with a
+// real TPM. It has an RSA endorsement key certified by its own certificate authority, and an ECDSA
+// P-256 attestation key.
+type Simulator struct {
+	caCertificate []byte
+	ekCertificate []byte
+	ek            *rsa.PrivateKey
+	ak            *ecdsa.PrivateKey
+	akPublic      []byte
+	pcrs          [pcrCount][]byte
+}
+
+func NewSimulator() (*Simulator, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "TPM simulator EK CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Simulator{caCertificate: caDER}
+	if s.ek, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		return nil, err
+	}
+	ekTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "TPM simulator EK"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+	if s.ekCertificate, err = x509.CreateCertificate(rand.Reader, ekTemplate, ca, &s.ek.PublicKey, caKey); err != nil {
+		return nil, err
+	}
+
+	if s.ak, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		return nil, err
+	}
+	s.akPublic = encodeECCPublic(&s.ak.PublicKey, attestationKeyAttributes|attrUserWithAuth)
+	for i := range s.pcrs {
+		s.pcrs[i] = make([]byte, sha256.Size)
+	}
+	return s, nil
+}
+
+// encodeECCPublic encodes the TPMT_PUBLIC of an ECDSA P-256 signing key
+func encodeECCPublic(key *ecdsa.PublicKey, attributes uint32) []byte {
+	var b []byte
+	b = append(b, u16Bytes(algECC)...)
+	b = append(b, u16Bytes(algSHA256)...)
+	b = binary.BigEndian.AppendUint32(b, attributes)
+	b = append(b, sized(nil)...) // authPolicy
+	b = append(b, u16Bytes(algNull)...)
+	b = append(b, u16Bytes(algECDSA)...)
+	b = append(b, u16Bytes(algSHA256)...)
+	b = append(b, u16Bytes(eccNistP256)...)
+	b = append(b, u16Bytes(algNull)...)
+	b = append(b, sized(key.X.FillBytes(make([]byte, 32)))...)
+	b = append(b, sized(key.Y.FillBytes(make([]byte, 32)))...)
+	return b
+}
+
+// CACertificatePEM returns the certificate of the authority that issued the endorsement key
+// certificate
+func (s *Simulator) CACertificatePEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCertificate}))
+}
+
+func (s *Simulator) EKCertificatePEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.ekCertificate}))
+}
+
+// Endorsement returns the keys that the agent sends when it registers the host
+func (s *Simulator) Endorsement() *models.TpmEndorsement {
+	return &models.TpmEndorsement{
+		EkCertificate: s.EKCertificatePEM(),
+		AkPublic:      base64.StdEncoding.EncodeToString(s.akPublic),
+	}
+}
+
+// ExtendPCR extends the SHA-256 PCR with the digest of the data, like the measurements of the boot
+func (s *Simulator) ExtendPCR(index int, data []byte) {
+	digest := sha256.Sum256(data)
+	extended := sha256.Sum256(append(s.pcrs[index], digest[:]...))
+	s.pcrs[index] = extended[:]
+}
+
+// PCR returns the hex encoded value of the SHA-256 PCR
+func (s *Simulator) PCR(index int) string {
+	return hex.EncodeToString(s.pcrs[index])
+}
+
+// Attest answers the challenge: it activates the credential and quotes the selected PCRs with the
+// nonce of the challenge
+func (s *Simulator) Attest(request *models.TpmAttestationRequest) (*models.TpmAttestationResponse, error) {
+	credentialBlob, err := base64.StdEncoding.DecodeString(request.CredentialBlob)
+	if err != nil {
+		return nil, err
+	}
+	encryptedSecret, err := base64.StdEncoding.DecodeString(request.EncryptedSecret)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := base64.StdEncoding.DecodeString(request.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	ak, err := decodePublic(s.akPublic)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := activateCredential(s.ek, ak.name(), credentialBlob, encryptedSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.TpmAttestationResponse{
+		Secret: base64.StdEncoding.EncodeToString(secret),
+		Pcrs:   map[string]string{},
+	}
+	values := map[int][]byte{}
+	pcrs := make([]int, 0, len(request.PcrSelection))
+	for _, pcr := range request.PcrSelection {
+		if pcr < 0 || pcr >= pcrCount {
+			return nil, errors.Errorf("invalid PCR %d", pcr)
+		}
+		pcrs = append(pcrs, int(pcr))
+		values[int(pcr)] = s.pcrs[pcr]
+		response.Pcrs[strconv.Itoa(int(pcr))] = s.PCR(int(pcr))
+	}
+	attest := encodeQuote(nonce, pcrs, pcrDigest(crypto.SHA256, values))
+	digest := sha256.Sum256(attest)
+	r, sigS, err := ecdsa.Sign(rand.Reader, s.ak, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	var signature []byte
+	signature = append(signature, u16Bytes(algECDSA)...)
+	signature = append(signature, u16Bytes(algSHA256)...)
+	signature = append(signature, sized(r.Bytes())...)
+	signature = append(signature, sized(sigS.Bytes())...)
+	response.Quote = base64.StdEncoding.EncodeToString(attest)
+	response.Signature = base64.StdEncoding.EncodeToString(signature)
+	return response, nil
+}
@@ -0,0 +1,449 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"sort"
+
+	// Register the hashes of the TPM algorithms
+	_ "crypto/sha1"
+	_ "crypto/sha512"
+
+	"github.com/pkg/errors"
+)
+
+// The TPM 2.0 structures used by the attestation, see the part 2 of the TPM 2.0 library
+// specification. All the integers are big endian.
+
+const (
+	algRSA    uint16 = 0x0001
+	algSHA1   uint16 = 0x0004
+	algSHA256 uint16 = 0x000B
+	algSHA384 uint16 = 0x000C
+	algSHA512 uint16 = 0x000D
+	algNull   uint16 = 0x0010
+	algRSASSA uint16 = 0x0014
+	algRSAPSS uint16 = 0x0016
+	algECDSA  uint16 = 0x0018
+	algECDAA  uint16 = 0x001A
+	algECC    uint16 = 0x0023
+
+	eccNistP256 uint16 = 0x0003
+	eccNistP384 uint16 = 0x0004
+
+	// TPM_GENERATED_VALUE, the TPM only signs the structures that start with it when they're
+	// generated by the TPM itself
+	generatedValue uint32 = 0xff544347
+	stAttestQuote  uint16 = 0x8018
+
+	attrFixedTPM            uint32 = 0x00000002
+	attrFixedParent         uint32 = 0x00000010
+	attrSensitiveDataOrigin uint32 = 0x00000020
+	attrUserWithAuth        uint32 = 0x00000040
+	attrRestricted          uint32 = 0x00010000
+	attrDecrypt             uint32 = 0x00020000
+	attrSign                uint32 = 0x00040000
+
+	// The attributes of an attestation key: a signing key generated by the TPM that can't leave it
+	// and only signs the structures generated by the TPM
+	attestationKeyAttributes = attrFixedTPM | attrFixedParent | attrSensitiveDataOrigin | attrRestricted | attrSign
+
+	pcrCount = 24
+)
+
+var hashes = map[uint16]crypto.Hash{
+	algSHA1:   crypto.SHA1,
+	algSHA256: crypto.SHA256,
+	algSHA384: crypto.SHA384,
+	algSHA512: crypto.SHA512,
+}
+
+// tpmReader decodes the TPM structures, the first error is kept and the following reads return
+// zero values
+type tpmReader struct {
+	b   []byte
+	err error
+}
+
+func (r *tpmReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.b) {
+		r.err = errors.New("unexpected end of the structure")
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *tpmReader) u8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *tpmReader) u16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *tpmReader) u32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *tpmReader) u64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// sized reads a TPM2B structure, the size of the content followed by the content
+func (r *tpmReader) sized() []byte {
+	return r.bytes(int(r.u16()))
+}
+
+func (r *tpmReader) done() error {
+	if r.err == nil && len(r.b) > 0 {
+		r.err = errors.New("unexpected data at the end of the structure")
+	}
+	return r.err
+}
+
+func u16Bytes(v uint16) []byte {
+	return binary.BigEndian.AppendUint16(nil, v)
+}
+
+func sized(b []byte) []byte {
+	return append(u16Bytes(uint16(len(b))), b...)
+}
+
+// publicArea is a TPMT_PUBLIC, the public part of a TPM object
+type publicArea struct {
+	nameAlg    uint16
+	attributes uint32
+	key        crypto.PublicKey
+	raw        []byte
+}
+
+func decodePublic(b []byte) (*publicArea, error) {
+	r := &tpmReader{b: b}
+	keyType := r.u16()
+	public := &publicArea{raw: b}
+	public.nameAlg = r.u16()
+	public.attributes = r.u32()
+	r.sized() // authPolicy
+	skipSymmetric := func() {
+		if alg := r.u16(); alg != algNull {
+			r.u16() // keyBits
+			r.u16() // mode
+		}
+	}
+	switch keyType {
+	case algRSA:
+		skipSymmetric()
+		if scheme := r.u16(); scheme != algNull {
+			r.u16() // hashAlg
+		}
+		r.u16() // keyBits
+		exponent := r.u32()
+		if exponent == 0 {
+			exponent = 65537
+		}
+		modulus := r.sized()
+		public.key = &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(exponent)}
+	case algECC:
+		skipSymmetric()
+		if scheme := r.u16(); scheme != algNull {
+			r.u16() // hashAlg
+			if scheme == algECDAA {
+				r.u16() // count
+			}
+		}
+		curveID := r.u16()
+		if kdf := r.u16(); kdf != algNull {
+			r.u16() // hashAlg
+		}
+		x := r.sized()
+		y := r.sized()
+		var curve elliptic.Curve
+		switch curveID {
+		case eccNistP256:
+			curve = elliptic.P256()
+		case eccNistP384:
+			curve = elliptic.P384()
+		default:
+			if r.err == nil {
+				return nil, errors.Errorf("unsupported ECC curve 0x%04x", curveID)
+			}
+		}
+		public.key = &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	default:
+		return nil, errors.Errorf("unsupported key type 0x%04x", keyType)
+	}
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	if _, ok := hashes[public.nameAlg]; !ok {
+		return nil, errors.Errorf("unsupported name algorithm 0x%04x", public.nameAlg)
+	}
+	return public, nil
+}
+
+// name returns the name of the object, the digest of its public area prefixed by the algorithm of
+// the digest
+func (p *publicArea) name() []byte {
+	h := hashes[p.nameAlg].New()
+	h.Write(p.raw)
+	return append(u16Bytes(p.nameAlg), h.Sum(nil)...)
+}
+
+// kdfa is the key derivation function of the TPM, see the section 11.4.10.2 of the part 1 of the
+// specification
+func kdfa(hash crypto.Hash, key []byte, label string, contextU, contextV []byte, bits int) []byte {
+	var out []byte
+	for counter := uint32(1); len(out)*8 < bits; counter++ {
+		mac := hmac.New(hash.New, key)
+		mac.Write(binary.BigEndian.AppendUint32(nil, counter))
+		mac.Write([]byte(label))
+		mac.Write([]byte{0})
+		mac.Write(contextU)
+		mac.Write(contextV)
+		mac.Write(binary.BigEndian.AppendUint32(nil, uint32(bits)))
+		out = mac.Sum(out)
+	}
+	return out[:bits/8]
+}
+
+// The endorsement keys of the default template of the TCG EK credential profile use SHA-256 and
+// AES-128, the credential is protected with the same algorithms
+const (
+	credentialSeedSize = 16
+	identityLabel      = "IDENTITY\x00"
+)
+
+// makeCredential protects the secret so that only the TPM of the endorsement key can recover it,
+// and only if it holds the attestation key of the given name. See TPM2_MakeCredential in the part 3
+// of the specification, the returned blobs are the TPM2B_ID_OBJECT and TPM2B_ENCRYPTED_SECRET that
+// TPM2_ActivateCredential takes.
+func makeCredential(ek *rsa.PublicKey, akName []byte, secret []byte) ([]byte, []byte, error) {
+	seed := make([]byte, credentialSeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, nil, err
+	}
+	encryptedSeed, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, ek, seed, []byte(identityLabel))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to encrypt the credential seed with the endorsement key")
+	}
+
+	encryptedIdentity, err := cfb(seed, akName, sized(secret), true)
+	if err != nil {
+		return nil, nil, err
+	}
+	idObject := append(sized(credentialHMAC(seed, akName, encryptedIdentity)), encryptedIdentity...)
+	return sized(idObject), sized(encryptedSeed), nil
+}
+
+// activateCredential recovers the secret protected by makeCredential, like TPM2_ActivateCredential
+func activateCredential(ek *rsa.PrivateKey, akName []byte, credentialBlob, encryptedSecret []byte) ([]byte, error) {
+	r := &tpmReader{b: encryptedSecret}
+	encryptedSeed := r.sized()
+	if err := r.done(); err != nil {
+		return nil, errors.Wrap(err, "invalid encrypted secret")
+	}
+	seed, err := rsa.DecryptOAEP(sha256.New(), nil, ek, encryptedSeed, []byte(identityLabel))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt the credential seed")
+	}
+
+	r = &tpmReader{b: credentialBlob}
+	idObject := &tpmReader{b: r.sized()}
+	if err = r.done(); err != nil {
+		return nil, errors.Wrap(err, "invalid credential blob")
+	}
+	integrity := idObject.sized()
+	if idObject.err != nil {
+		return nil, errors.Wrap(idObject.err, "invalid credential blob")
+	}
+	encryptedIdentity := idObject.b
+	if !hmac.Equal(integrity, credentialHMAC(seed, akName, encryptedIdentity)) {
+		return nil, errors.New("the credential doesn't match the attestation key")
+	}
+	identity, err := cfb(seed, akName, encryptedIdentity, false)
+	if err != nil {
+		return nil, err
+	}
+	r = &tpmReader{b: identity}
+	secret := r.sized()
+	if err = r.done(); err != nil {
+		return nil, errors.Wrap(err, "invalid credential")
+	}
+	return secret, nil
+}
+
+func credentialHMAC(seed, akName, encryptedIdentity []byte) []byte {
+	mac := hmac.New(sha256.New, kdfa(crypto.SHA256, seed, "INTEGRITY", nil, nil, sha256.Size*8))
+	mac.Write(encryptedIdentity)
+	mac.Write(akName)
+	return mac.Sum(nil)
+}
+
+func cfb(seed, akName, in []byte, encrypt bool) ([]byte, error) {
+	block, err := aes.NewCipher(kdfa(crypto.SHA256, seed, "STORAGE", akName, nil, len(seed)*8))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	iv := make([]byte, aes.BlockSize)
+	// CFB is deprecated for new protocols, but it's the mode that the TPM uses for the credentials
+	if encrypt {
+		cipher.NewCFBEncrypter(block, iv).XORKeyStream(out, in) //nolint:staticcheck
+	} else {
+		cipher.NewCFBDecrypter(block, iv).XORKeyStream(out, in) //nolint:staticcheck
+	}
+	return out, nil
+}
+
+// quote is the TPMS_ATTEST of a quote, with the TPMS_QUOTE_INFO
+type quote struct {
+	extraData []byte
+	// The selected PCRs, by hash algorithm
+	pcrs      map[uint16][]int
+	pcrDigest []byte
+}
+
+func decodeQuote(b []byte) (*quote, error) {
+	r := &tpmReader{b: b}
+	if magic := r.u32(); r.err == nil && magic != generatedValue {
+		return nil, errors.New("the quote wasn't generated by a TPM")
+	}
+	if tag := r.u16(); r.err == nil && tag != stAttestQuote {
+		return nil, errors.Errorf("unexpected attestation type 0x%04x", tag)
+	}
+	r.sized() // qualifiedSigner
+	q := &quote{extraData: r.sized(), pcrs: map[uint16][]int{}}
+	r.u64() // clockInfo.clock
+	r.u32() // clockInfo.resetCount
+	r.u32() // clockInfo.restartCount
+	r.u8()  // clockInfo.safe
+	r.u64() // firmwareVersion
+	count := r.u32()
+	if count > 16 {
+		return nil, errors.Errorf("unexpected number of PCR selections %d", count)
+	}
+	for i := uint32(0); i < count; i++ {
+		hashAlg := r.u16()
+		bitmap := r.bytes(int(r.u8()))
+		for j, b := range bitmap {
+			for bit := 0; bit < 8; bit++ {
+				if b&(1<<bit) != 0 {
+					q.pcrs[hashAlg] = append(q.pcrs[hashAlg], j*8+bit)
+				}
+			}
+		}
+	}
+	q.pcrDigest = r.sized()
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func encodeQuote(nonce []byte, pcrs []int, pcrDigest []byte) []byte {
+	var b bytes.Buffer
+	b.Write(binary.BigEndian.AppendUint32(nil, generatedValue))
+	b.Write(u16Bytes(stAttestQuote))
+	b.Write(sized(nil)) // qualifiedSigner
+	b.Write(sized(nonce))
+	b.Write(make([]byte, 8+4+4+1)) // clockInfo
+	b.Write(make([]byte, 8))       // firmwareVersion
+	b.Write(binary.BigEndian.AppendUint32(nil, 1))
+	b.Write(u16Bytes(algSHA256))
+	bitmap := make([]byte, pcrCount/8)
+	for _, pcr := range pcrs {
+		bitmap[pcr/8] |= 1 << (pcr % 8)
+	}
+	b.WriteByte(byte(len(bitmap)))
+	b.Write(bitmap)
+	b.Write(sized(pcrDigest))
+	return b.Bytes()
+}
+
+// pcrDigest is the digest of the concatenation of the values of the PCRs, in the order of their
+// indexes
+func pcrDigest(hash crypto.Hash, values map[int][]byte) []byte {
+	indexes := make([]int, 0, len(values))
+	for index := range values {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	h := hash.New()
+	for _, index := range indexes {
+		h.Write(values[index])
+	}
+	return h.Sum(nil)
+}
+
+// verifySignature verifies the TPMT_SIGNATURE of the data by the key
+func verifySignature(key crypto.PublicKey, data, signature []byte) error {
+	r := &tpmReader{b: signature}
+	sigAlg := r.u16()
+	hash, ok := hashes[r.u16()]
+	if r.err == nil && !ok {
+		return errors.New("unsupported signature hash algorithm")
+	}
+	var sig, sigR, sigS []byte
+	switch sigAlg {
+	case algRSASSA, algRSAPSS:
+		sig = r.sized()
+	case algECDSA:
+		sigR = r.sized()
+		sigS = r.sized()
+	default:
+		if r.err == nil {
+			return errors.Errorf("unsupported signature algorithm 0x%04x", sigAlg)
+		}
+	}
+	if err := r.done(); err != nil {
+		return err
+	}
+
+	h := hash.New()
+	h.Write(data)
+	digest := h.Sum(nil)
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if sigAlg == algRSASSA {
+			return rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+		}
+		if sigAlg == algRSAPSS {
+			return rsa.VerifyPSS(pub, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		}
+	case *ecdsa.PublicKey:
+		if sigAlg == algECDSA {
+			if !ecdsa.Verify(pub, digest, new(big.Int).SetBytes(sigR), new(big.Int).SetBytes(sigS)) {
+				return errors.New("invalid ECDSA signature")
+			}
+			return nil
+		}
+	}
+	return errors.New("the signature algorithm doesn't match the key")
+}
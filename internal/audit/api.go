@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/audit"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const defaultListLimit = 1000
+
+var _ restapi.AuditAPI = &API{}
+
+// API serves the audit records to the admins, only the admins can call it
+type API struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+func NewAPI(db *gorm.DB, log logrus.FieldLogger) *API {
+	return &API{
+		db:  db,
+		log: log,
+	}
+}
+
+func (a *API) V2ListAuditRecords(ctx context.Context, params operations.V2ListAuditRecordsParams) middleware.Responder {
+	log := logutil.FromContext(ctx, a.log)
+
+	query := a.db.WithContext(ctx).Order("time DESC").Order("id")
+	if params.StartTime != nil {
+		query = query.Where("time >= ?", time.Time(*params.StartTime))
+	}
+	if params.EndTime != nil {
+		query = query.Where("time < ?", time.Time(*params.EndTime))
+	}
+	if params.Actor != nil {
+		query = query.Where("actor = ?", *params.Actor)
+	}
+	if params.ClusterID != nil {
+		query = query.Where("cluster_id = ?", params.ClusterID.String())
+	}
+	if params.InfraEnvID != nil {
+		query = query.Where("infra_env_id = ?", params.InfraEnvID.String())
+	}
+	limit := swag.Int64Value(params.Limit)
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	query = query.Limit(int(limit)).Offset(int(swag.Int64Value(params.Offset)))
+
+	records := models.AuditRecords{}
+	if err := query.Find(&records).Error; err != nil {
+		log.WithError(err).Error("failed to list audit records")
+		return common.GenerateErrorResponder(err)
+	}
+	return operations.NewV2ListAuditRecordsOK().WithPayload(records)
+}
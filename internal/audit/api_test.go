@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	operations "github.com/openshift/assisted-service/restapi/operations/audit"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("V2ListAuditRecords", func() {
+	const (
+		clusterID  = "0b7c9a5c-36b3-4a5d-9e6e-1a0a7c6c7a01"
+		infraEnvID = "5d2c4f8e-2f0e-4c4b-8f59-3c7b9a1d6e02"
+	)
+
+	var (
+		db     *gorm.DB
+		dbName string
+		api    *API
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		api = NewAPI(db, logrus.New())
+		now = time.Now().Truncate(time.Second)
+
+		auditor := NewAuditor(Config{ExcludedOperations: []string{"v2PostStepReply"}}, db, nil, logrus.New())
+		for _, record := range []*models.AuditRecord{
+			{Operation: "v2RegisterCluster", Actor: "alice", ClusterID: clusterID, Time: strfmt.DateTime(now.Add(-2 * time.Hour))},
+			{Operation: "v2RegisterInfraEnv", Actor: "alice", InfraEnvID: infraEnvID, Time: strfmt.DateTime(now.Add(-time.Hour))},
+			{Operation: "v2DeregisterCluster", Actor: "bob", ClusterID: clusterID, Time: strfmt.DateTime(now)},
+			{Operation: "v2PostStepReply", Actor: "agent", ClusterID: clusterID, Time: strfmt.DateTime(now)},
+		} {
+			record.ID = strfmt.UUID(uuid.New().String())
+			auditor.Write(context.Background(), record)
+		}
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	list := func(params operations.V2ListAuditRecordsParams) []string {
+		reply := api.V2ListAuditRecords(context.Background(), params)
+		Expect(reply).To(BeAssignableToTypeOf(operations.NewV2ListAuditRecordsOK()))
+		operationNames := []string{}
+		for _, record := range reply.(*operations.V2ListAuditRecordsOK).Payload {
+			operationNames = append(operationNames, record.Operation)
+		}
+		return operationNames
+	}
+
+	It("lists the records, the most recent first", func() {
+		Expect(list(operations.V2ListAuditRecordsParams{})).To(Equal([]string{"v2DeregisterCluster", "v2RegisterInfraEnv", "v2RegisterCluster"}))
+	})
+
+	It("filters the records by time", func() {
+		startTime := strfmt.DateTime(now.Add(-90 * time.Minute))
+		endTime := strfmt.DateTime(now)
+		Expect(list(operations.V2ListAuditRecordsParams{StartTime: &startTime, EndTime: &endTime})).To(Equal([]string{"v2RegisterInfraEnv"}))
+	})
+
+	It("filters the records by actor and resource", func() {
+		Expect(list(operations.V2ListAuditRecordsParams{Actor: swag.String("alice")})).To(Equal([]string{"v2RegisterInfraEnv", "v2RegisterCluster"}))
+		cluster := strfmt.UUID(clusterID)
+		Expect(list(operations.V2ListAuditRecordsParams{ClusterID: &cluster})).To(Equal([]string{"v2DeregisterCluster", "v2RegisterCluster"}))
+		infraEnv := strfmt.UUID(infraEnvID)
+		Expect(list(operations.V2ListAuditRecordsParams{InfraEnvID: &infraEnv})).To(Equal([]string{"v2RegisterInfraEnv"}))
+	})
+
+	It("pages the records", func() {
+		Expect(list(operations.V2ListAuditRecordsParams{Limit: swag.Int64(1), Offset: swag.Int64(1)})).To(Equal([]string{"v2RegisterInfraEnv"}))
+	})
+})
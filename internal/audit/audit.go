@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/openshift/assisted-service/internal/stream"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/requestid"
+	"github.com/openshift/assisted-service/pkg/secretdump"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
+)
+
+// RecordStreamName is the name of the envelopes of the audit records in the notification stream
+const RecordStreamName = "AuditRecord"
+
+type Config struct {
+	DatabaseEnabled bool `envconfig:"AUDIT_LOG_DATABASE_ENABLED" default:"true"`
+	StreamEnabled   bool `envconfig:"AUDIT_LOG_STREAM_ENABLED" default:"false"`
+	// The bodies of the requests and the responses are only parsed up to this size
+	MaxBodySize int64 `envconfig:"AUDIT_LOG_MAX_BODY_SIZE" default:"1048576"`
+	// The agents report their progress very often, these calls would flood the audit log
+	ExcludedOperations []string `envconfig:"AUDIT_LOG_EXCLUDED_OPERATIONS" default:"v2PostStepReply,v2UpdateHostInstallProgress,v2UpdateHostLogsProgress,v2UpdateClusterLogsProgress,V2UploadLogs"`
+	// The number of proxies in front of the service that append the address of their client to the
+	// X-Forwarded-For header, the addresses added by the clients themselves can't be trusted
+	TrustedProxies int `envconfig:"AUDIT_LOG_TRUSTED_PROXIES" default:"0"`
+}
+
+type recordKey struct{}
+
+// Auditor writes the audit records of the calls that modify the resources of the service to the
+// audit_records table and/or to the notification stream. The table is append-only, the database
+// rejects the updates and the deletions of its rows.
+type Auditor struct {
+	cfg    Config
+	db     *gorm.DB
+	writer stream.StreamWriter
+	log    logrus.FieldLogger
+}
+
+// NewAuditor returns an auditor that writes the records to the database when db isn't nil, and to
+// the writer when it isn't nil
+func NewAuditor(cfg Config, db *gorm.DB, writer stream.StreamWriter, log logrus.FieldLogger) *Auditor {
+	return &Auditor{
+		cfg:    cfg,
+		db:     db,
+		writer: writer,
+		log:    log,
+	}
+}
+
+// Enabled returns true if the records are written anywhere
+func (a *Auditor) Enabled() bool {
+	return a.db != nil || a.writer != nil
+}
+
+func (a *Auditor) MaxBodySize() int64 {
+	return a.cfg.MaxBodySize
+}
+
+// IsAudited returns true if the calls with the method are audited, only the calls that may modify
+// resources are
+func IsAudited(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// NewRecord starts the audit record of a request, the rest of the record is filled while the
+// request is served
+func (a *Auditor) NewRecord(r *http.Request) *models.AuditRecord {
+	return &models.AuditRecord{
+		ID:        strfmt.UUID(uuid.New().String()),
+		Time:      strfmt.DateTime(time.Now()),
+		SourceIP:  sourceIP(r, a.cfg.TrustedProxies),
+		UserAgent: r.UserAgent(),
+		RequestID: requestid.FromContext(r.Context()),
+		Method:    r.Method,
+		Path:      r.URL.Path,
+	}
+}
+
+// sourceIP returns the address of the client. Behind the given number of trusted proxies, it is the
+// address that the outermost proxy appended to the X-Forwarded-For header, the addresses before it
+// may have been forged by the client.
+func sourceIP(r *http.Request, trustedProxies int) string {
+	if trustedProxies > 0 {
+		var forwardedFor []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			forwardedFor = append(forwardedFor, strings.Split(header, ",")...)
+		}
+		if len(forwardedFor) >= trustedProxies {
+			return strings.TrimSpace(forwardedFor[len(forwardedFor)-trustedProxies])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func WithRecord(ctx context.Context, record *models.AuditRecord) context.Context {
+	return context.WithValue(ctx, recordKey{}, record)
+}
+
+// RecordFromContext returns the audit record of the request, or nil if the request isn't audited
+func RecordFromContext(ctx context.Context) *models.AuditRecord {
+	record, _ := ctx.Value(recordKey{}).(*models.AuditRecord)
+	return record
+}
+
+// RedactParams returns the query parameters and the body of the call in JSON format, without their
+// secrets. The path parameters are part of the path of the record.
+func RedactParams(query map[string][]string, body interface{}) string {
+	params := map[string]interface{}{}
+	if len(query) > 0 {
+		queryParams := map[string]interface{}{}
+		for name, values := range query {
+			if len(values) == 1 {
+				queryParams[name] = values[0]
+			} else {
+				list := make([]interface{}, 0, len(values))
+				for _, value := range values {
+					list = append(list, value)
+				}
+				queryParams[name] = list
+			}
+		}
+		params["query"] = queryParams
+	}
+	if body != nil {
+		params["body"] = body
+	}
+	redacted, err := secretdump.MarshalRedacted(params)
+	if err != nil {
+		return ""
+	}
+	return redacted
+}
+
+// Write stores the record of a call, unless its operation is excluded from the audit log
+func (a *Auditor) Write(ctx context.Context, record *models.AuditRecord) {
+	if funk.ContainsString(a.cfg.ExcludedOperations, record.Operation) {
+		return
+	}
+	log := a.log.WithFields(logrus.Fields{
+		"request_id": record.RequestID,
+		"operation":  record.Operation,
+		"actor":      record.Actor,
+	})
+	// The client may be gone already, the record must be written anyway
+	ctx = context.WithoutCancel(ctx)
+	if a.db != nil {
+		if err := a.db.WithContext(ctx).Create(record).Error; err != nil {
+			log.WithError(err).Errorf("failed to store audit record of %s %s", record.Method, record.Path)
+		}
+	}
+	if a.writer != nil {
+		envelope := &stream.Envelope{
+			Name:    RecordStreamName,
+			Payload: record,
+		}
+		if err := a.writer.Write(ctx, []byte(record.ClusterID), envelope); err != nil {
+			log.WithError(err).Errorf("failed to stream audit record of %s %s", record.Method, record.Path)
+		}
+	}
+}
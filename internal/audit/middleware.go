@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"net/http"
+
+	rmiddleware "github.com/go-openapi/runtime/middleware"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+)
+
+// RouteMiddleware adds the operation matched by the API router and the resources of the call to
+// the audit record of the request. It must be an inner middleware, the route is unknown before the
+// router runs.
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record := RecordFromContext(r.Context())
+		if record == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if mr := rmiddleware.MatchedRouteFrom(r); mr != nil {
+			if mr.Operation != nil {
+				record.Operation = mr.Operation.ID
+			}
+			for _, param := range mr.Params {
+				switch param.Name {
+				case params.ClusterId:
+					record.ClusterID = param.Value
+				case params.InfraEnvId:
+					record.InfraEnvID = param.Value
+				case params.HostId:
+					record.HostID = param.Value
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Authorizer adds the user that made the call to the audit record of the request before
+// authorizing it. The authorizer is only called for the authenticated requests.
+func Authorizer(authorize func(*http.Request) error) func(*http.Request) error {
+	return func(request *http.Request) error {
+		if record := RecordFromContext(request.Context()); record != nil {
+			payload := ocm.PayloadFromContext(request.Context())
+			record.Actor = payload.Username
+			record.OrgID = payload.Organization
+			record.Role = string(payload.Role)
+			if payload.APIToken != nil {
+				record.APITokenID = payload.APIToken.ID
+			}
+		}
+		return authorize(request)
+	}
+}
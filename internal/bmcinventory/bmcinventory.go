@@ -0,0 +1,240 @@
+package bmcinventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+)
+
+// BootModeUEFISecureBoot is the boot mode of the BareMetalHosts that boot with secure boot
+const BootModeUEFISecureBoot = "UEFISecureBoot"
+
+// raidLevels are the RAID levels supported by the BareMetalHosts
+var raidLevels = []string{"0", "1", "2", "5", "6", "1+0", "5+0", "6+0"}
+
+// Status is the outcome of a firmware requirement for a host
+type Status int
+
+const (
+	// NotRequired means that the infra-env doesn't have the requirement
+	NotRequired Status = iota
+	// Pending means that the details of the host needed to check the requirement aren't known yet
+	Pending
+	Satisfied
+	NotSatisfied
+)
+
+// Result is the outcome of a firmware requirement for a host, the message explains it to the user
+type Result struct {
+	Status  Status
+	Message string
+}
+
+// RequirementsFromInfraEnv returns the firmware requirements of the infra-env, or nil when it doesn't have any
+func RequirementsFromInfraEnv(infraEnv *common.InfraEnv) (*models.FirmwareRequirements, error) {
+	if infraEnv == nil || infraEnv.FirmwareRequirements == nil || *infraEnv.FirmwareRequirements == "" {
+		return nil, nil
+	}
+	var requirements models.FirmwareRequirements
+	if err := json.Unmarshal([]byte(*infraEnv.FirmwareRequirements), &requirements); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the firmware requirements of infra-env %s", infraEnv.ID)
+	}
+	return &requirements, nil
+}
+
+// FromHost returns the BMC inventory of the host, or nil when its BareMetalHost didn't report any
+func FromHost(host *models.Host) (*models.BmcInventory, error) {
+	if host.BmcInventory == "" {
+		return nil, nil
+	}
+	var inventory models.BmcInventory
+	if err := json.Unmarshal([]byte(host.BmcInventory), &inventory); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the BMC inventory of host %s", host.ID)
+	}
+	return &inventory, nil
+}
+
+// IsEmpty returns true when the requirements don't require anything
+func IsEmpty(requirements *models.FirmwareRequirements) bool {
+	return requirements == nil || (requirements.SecureBoot == nil && len(requirements.BiosVersions) == 0 &&
+		len(requirements.BiosSettings) == 0 && len(requirements.RaidLevels) == 0)
+}
+
+// IsEmptyInventory returns true when the BareMetalHost didn't report any of the details used by the
+// requirements
+func IsEmptyInventory(inventory *models.BmcInventory) bool {
+	return inventory == nil || (inventory.BiosVendor == "" && inventory.BiosVersion == "" && inventory.BiosDate == "" &&
+		inventory.BootMode == "" && len(inventory.FirmwareSettings) == 0 && len(inventory.RaidVolumes) == 0)
+}
+
+// Format validates the requirements set by the user and returns them in the format stored in the
+// infra-env, an empty string for empty requirements
+func Format(requirements *models.FirmwareRequirements) (string, error) {
+	if IsEmpty(requirements) {
+		return "", nil
+	}
+	if err := ValidateRequirements(requirements); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(requirements)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ValidateRequirements checks the firmware requirements set by the user
+func ValidateRequirements(requirements *models.FirmwareRequirements) error {
+	for _, pattern := range requirements.BiosVersions {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Errorf("invalid BIOS version pattern %q of the firmware requirements", pattern)
+		}
+	}
+	for name := range requirements.BiosSettings {
+		if name == "" {
+			return errors.New("the firmware requirements have a BIOS setting without a name")
+		}
+	}
+	for _, level := range requirements.RaidLevels {
+		if !isRAIDLevel(level) {
+			return errors.Errorf("invalid RAID level %q of the firmware requirements, supported levels are %s",
+				level, strings.Join(raidLevels, ", "))
+		}
+	}
+	return nil
+}
+
+func isRAIDLevel(level string) bool {
+	for _, l := range raidLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSecureBoot checks the secure boot state of the host. The state reported by the host itself
+// is preferred, the boot mode of the BareMetalHost is used when the host doesn't know it.
+func CheckSecureBoot(requirements *models.FirmwareRequirements, bmcInventory *models.BmcInventory, inventory *models.Inventory) Result {
+	if requirements == nil || requirements.SecureBoot == nil {
+		return Result{Status: NotRequired}
+	}
+	var enabled bool
+	switch {
+	case inventory != nil && inventory.Boot != nil && inventory.Boot.SecureBootState == models.SecureBootStateEnabled:
+		enabled = true
+	case inventory != nil && inventory.Boot != nil && inventory.Boot.SecureBootState == models.SecureBootStateDisabled:
+		enabled = false
+	case bmcInventory != nil && bmcInventory.BootMode != "":
+		enabled = bmcInventory.BootMode == BootModeUEFISecureBoot
+	default:
+		return Result{Status: Pending, Message: "Waiting for the secure boot state of the host"}
+	}
+	state, required := enabledString(enabled), enabledString(*requirements.SecureBoot)
+	if enabled != *requirements.SecureBoot {
+		return Result{Status: NotSatisfied, Message: fmt.Sprintf("Secure boot is %s on the host, the infra-env requires it to be %s", state, required)}
+	}
+	return Result{Status: Satisfied, Message: fmt.Sprintf("Secure boot is %s on the host as required", state)}
+}
+
+func enabledString(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// CheckFirmware checks the BIOS version and the firmware settings of the host
+func CheckFirmware(requirements *models.FirmwareRequirements, bmcInventory *models.BmcInventory) Result {
+	if requirements == nil || (len(requirements.BiosVersions) == 0 && len(requirements.BiosSettings) == 0) {
+		return Result{Status: NotRequired}
+	}
+	if bmcInventory == nil {
+		return Result{Status: Pending, Message: "Waiting for the BareMetalHost of the host to report its firmware"}
+	}
+
+	var failures []string
+	if len(requirements.BiosVersions) > 0 {
+		if bmcInventory.BiosVersion == "" {
+			return Result{Status: Pending, Message: "Waiting for the BareMetalHost of the host to report its BIOS version"}
+		}
+		if !matchesAny(requirements.BiosVersions, bmcInventory.BiosVersion) {
+			failures = append(failures, fmt.Sprintf("BIOS version %s doesn't match the required versions %s",
+				bmcInventory.BiosVersion, strings.Join(requirements.BiosVersions, ", ")))
+		}
+	}
+	if len(requirements.BiosSettings) > 0 {
+		if bmcInventory.FirmwareSettings == nil {
+			return Result{Status: Pending, Message: "Waiting for the BareMetalHost of the host to report its firmware settings"}
+		}
+		names := make([]string, 0, len(requirements.BiosSettings))
+		for name := range requirements.BiosSettings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value, ok := bmcInventory.FirmwareSettings[name]
+			switch {
+			case !ok:
+				failures = append(failures, fmt.Sprintf("firmware setting %s is missing", name))
+			case !strings.EqualFold(value, requirements.BiosSettings[name]):
+				failures = append(failures, fmt.Sprintf("firmware setting %s is %s instead of %s", name, value, requirements.BiosSettings[name]))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return Result{Status: NotSatisfied, Message: fmt.Sprintf("The firmware of the host doesn't match the requirements of the infra-env: %s", strings.Join(failures, ", "))}
+	}
+	return Result{Status: Satisfied, Message: "The firmware of the host matches the requirements of the infra-env"}
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRAID checks that the host has a RAID volume for each required level
+func CheckRAID(requirements *models.FirmwareRequirements, bmcInventory *models.BmcInventory) Result {
+	if requirements == nil || len(requirements.RaidLevels) == 0 {
+		return Result{Status: NotRequired}
+	}
+	if bmcInventory == nil {
+		return Result{Status: Pending, Message: "Waiting for the BareMetalHost of the host to report its RAID configuration"}
+	}
+
+	available := make(map[string]int)
+	var levels []string
+	for _, volume := range bmcInventory.RaidVolumes {
+		if volume != nil {
+			available[volume.Level]++
+			levels = append(levels, volume.Level)
+		}
+	}
+	var missing []string
+	for _, level := range requirements.RaidLevels {
+		if available[level] == 0 {
+			missing = append(missing, level)
+			continue
+		}
+		available[level]--
+	}
+	if len(missing) > 0 {
+		has := "no RAID volume"
+		if len(levels) > 0 {
+			has = fmt.Sprintf("RAID volumes of levels %s", strings.Join(levels, ", "))
+		}
+		return Result{Status: NotSatisfied, Message: fmt.Sprintf("The host is missing RAID volumes of levels %s required by the infra-env, it has %s",
+			strings.Join(missing, ", "), has)}
+	}
+	return Result{Status: Satisfied, Message: "The RAID configuration of the host matches the requirements of the infra-env"}
+}
@@ -0,0 +1,13 @@
+package bmcinventory
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBMCInventory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BMC Inventory Suite")
+}
@@ -0,0 +1,137 @@
+package bmcinventory
+
+import (
+	"github.com/go-openapi/swag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+)
+
+func newBMCInventory() *models.BmcInventory {
+	return &models.BmcInventory{
+		BiosVendor:  "Dell Inc.",
+		BiosVersion: "2.19.1",
+		BootMode:    "UEFI",
+		FirmwareSettings: map[string]string{
+			"ProcVirtualization": "Enabled",
+			"SriovGlobalEnable":  "Disabled",
+		},
+		RaidVolumes: []*models.BmcRaidVolume{
+			{Level: "1", PhysicalDisks: 2},
+			{Level: "5", PhysicalDisks: 4},
+		},
+	}
+}
+
+var _ = Describe("CheckSecureBoot", func() {
+	It("is not required without a secure boot requirement", func() {
+		Expect(CheckSecureBoot(&models.FirmwareRequirements{}, newBMCInventory(), nil).Status).To(Equal(NotRequired))
+	})
+
+	It("prefers the state reported by the host", func() {
+		inventory := &models.Inventory{Boot: &models.Boot{SecureBootState: models.SecureBootStateEnabled}}
+		result := CheckSecureBoot(&models.FirmwareRequirements{SecureBoot: swag.Bool(true)}, newBMCInventory(), inventory)
+		Expect(result.Status).To(Equal(Satisfied))
+		Expect(result.Message).To(Equal("Secure boot is enabled on the host as required"))
+	})
+
+	It("uses the boot mode of the BareMetalHost when the host doesn't know the state", func() {
+		inventory := &models.Inventory{Boot: &models.Boot{SecureBootState: models.SecureBootStateUnknown}}
+		result := CheckSecureBoot(&models.FirmwareRequirements{SecureBoot: swag.Bool(true)}, newBMCInventory(), inventory)
+		Expect(result.Status).To(Equal(NotSatisfied))
+		Expect(result.Message).To(Equal("Secure boot is disabled on the host, the infra-env requires it to be enabled"))
+	})
+
+	It("waits without any state", func() {
+		Expect(CheckSecureBoot(&models.FirmwareRequirements{SecureBoot: swag.Bool(false)}, nil, nil).Status).To(Equal(Pending))
+	})
+})
+
+var _ = Describe("CheckFirmware", func() {
+	It("is satisfied by a matching BIOS version and settings", func() {
+		result := CheckFirmware(&models.FirmwareRequirements{
+			BiosVersions: []string{"1.*", "2.19.*"},
+			BiosSettings: map[string]string{"ProcVirtualization": "enabled"},
+		}, newBMCInventory())
+		Expect(result.Status).To(Equal(Satisfied))
+	})
+
+	It("reports the BIOS version and the settings that don't match", func() {
+		result := CheckFirmware(&models.FirmwareRequirements{
+			BiosVersions: []string{"2.20.*"},
+			BiosSettings: map[string]string{"SriovGlobalEnable": "Enabled", "BootMode": "Uefi"},
+		}, newBMCInventory())
+		Expect(result.Status).To(Equal(NotSatisfied))
+		Expect(result.Message).To(Equal("The firmware of the host doesn't match the requirements of the infra-env: " +
+			"BIOS version 2.19.1 doesn't match the required versions 2.20.*, firmware setting BootMode is missing, " +
+			"firmware setting SriovGlobalEnable is Disabled instead of Enabled"))
+	})
+
+	It("waits for the firmware settings", func() {
+		bmcInventory := newBMCInventory()
+		bmcInventory.FirmwareSettings = nil
+		result := CheckFirmware(&models.FirmwareRequirements{BiosSettings: map[string]string{"ProcVirtualization": "Enabled"}}, bmcInventory)
+		Expect(result.Status).To(Equal(Pending))
+	})
+
+	It("waits for the BareMetalHost", func() {
+		Expect(CheckFirmware(&models.FirmwareRequirements{BiosVersions: []string{"*"}}, nil).Status).To(Equal(Pending))
+	})
+})
+
+var _ = Describe("CheckRAID", func() {
+	DescribeTable("RAID levels",
+		func(levels []string, expected Status) {
+			Expect(CheckRAID(&models.FirmwareRequirements{RaidLevels: levels}, newBMCInventory()).Status).To(Equal(expected))
+		},
+		Entry("no levels", nil, NotRequired),
+		Entry("one matching level", []string{"1"}, Satisfied),
+		Entry("all the levels", []string{"5", "1"}, Satisfied),
+		Entry("one volume per level", []string{"1", "1"}, NotSatisfied),
+		Entry("missing level", []string{"1+0"}, NotSatisfied),
+	)
+
+	It("reports the missing levels", func() {
+		bmcInventory := newBMCInventory()
+		bmcInventory.RaidVolumes = nil
+		result := CheckRAID(&models.FirmwareRequirements{RaidLevels: []string{"1"}}, bmcInventory)
+		Expect(result.Message).To(Equal("The host is missing RAID volumes of levels 1 required by the infra-env, it has no RAID volume"))
+	})
+})
+
+var _ = Describe("Format", func() {
+	It("formats empty requirements as an empty string", func() {
+		formatted, err := Format(&models.FirmwareRequirements{BiosSettings: map[string]string{}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(formatted).To(BeEmpty())
+	})
+
+	It("rejects invalid requirements", func() {
+		_, err := Format(&models.FirmwareRequirements{RaidLevels: []string{"10"}})
+		Expect(err).To(HaveOccurred())
+		_, err = Format(&models.FirmwareRequirements{BiosVersions: []string{"[1"}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("round-trips through the infra-env", func() {
+		requirements := &models.FirmwareRequirements{SecureBoot: swag.Bool(true), RaidLevels: []string{"1"}}
+		formatted, err := Format(requirements)
+		Expect(err).ToNot(HaveOccurred())
+		parsed, err := RequirementsFromInfraEnv(&common.InfraEnv{InfraEnv: models.InfraEnv{FirmwareRequirements: &formatted}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed).To(Equal(requirements))
+	})
+})
+
+var _ = Describe("IsEmptyInventory", func() {
+	It("is empty without any detail", func() {
+		Expect(IsEmptyInventory(nil)).To(BeTrue())
+		Expect(IsEmptyInventory(&models.BmcInventory{FirmwareSettings: map[string]string{}})).To(BeTrue())
+	})
+
+	It("isn't empty with a boot mode", func() {
+		Expect(IsEmptyInventory(&models.BmcInventory{BootMode: "UEFI"})).To(BeFalse())
+	})
+})
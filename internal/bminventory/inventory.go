@@ -23,6 +23,8 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/kennygrant/sanitize"
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/assisted-service/internal/attestation"
+	"github.com/openshift/assisted-service/internal/bmcinventory"
 	clusterPkg "github.com/openshift/assisted-service/internal/cluster"
 	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
@@ -32,12 +34,14 @@ import (
 	"github.com/openshift/assisted-service/internal/dns"
 	eventsapi "github.com/openshift/assisted-service/internal/events/api"
 	"github.com/openshift/assisted-service/internal/featuresupport"
+	"github.com/openshift/assisted-service/internal/federation"
 	"github.com/openshift/assisted-service/internal/garbagecollector"
 	"github.com/openshift/assisted-service/internal/gencrypto"
 	"github.com/openshift/assisted-service/internal/hardware"
 	"github.com/openshift/assisted-service/internal/host"
 	"github.com/openshift/assisted-service/internal/host/hostcommands"
 	"github.com/openshift/assisted-service/internal/host/hostutil"
+	"github.com/openshift/assisted-service/internal/hostapproval"
 	"github.com/openshift/assisted-service/internal/ignition"
 	"github.com/openshift/assisted-service/internal/imageservice"
 	"github.com/openshift/assisted-service/internal/infraenv"
@@ -51,6 +55,8 @@ import (
 	"github.com/openshift/assisted-service/internal/operators/lvm"
 	"github.com/openshift/assisted-service/internal/provider"
 	"github.com/openshift/assisted-service/internal/provider/registry"
+	"github.com/openshift/assisted-service/internal/quota"
+	"github.com/openshift/assisted-service/internal/spokehealth"
 	"github.com/openshift/assisted-service/internal/stream"
 	"github.com/openshift/assisted-service/internal/usage"
 	"github.com/openshift/assisted-service/internal/versions"
@@ -165,6 +171,8 @@ type InstallerInternals interface {
 	V2DeregisterHostInternal(ctx context.Context, params installer.V2DeregisterHostParams, interactivity Interactivity) error
 	GetCommonHostInternal(ctx context.Context, infraEnvId string, hostId string) (*common.Host, error)
 	UpdateHostApprovedInternal(ctx context.Context, infraEnvId string, hostId string, approved bool) error
+	UpdateHostBmcInventoryInternal(ctx context.Context, infraEnvId string, hostId string, bmcInventory *models.BmcInventory) error
+	UpdateClusterSpokeHealthInternal(ctx context.Context, clusterID strfmt.UUID, health *models.SpokeHealth) error
 	V2UpdateHostInstallerArgsInternal(ctx context.Context, params installer.V2UpdateHostInstallerArgsParams) (*models.Host, error)
 	V2UpdateHostIgnitionInternal(ctx context.Context, params installer.V2UpdateHostIgnitionParams) (*models.Host, error)
 	GetCredentialsInternal(ctx context.Context, params installer.V2GetCredentialsParams) (*models.Credentials, error)
@@ -193,6 +201,10 @@ type InstallerInternals interface {
 	CreateHostInKubeKeyNamespace(ctx context.Context, kubeKey types.NamespacedName, host *models.Host) error
 	GetHostByIdInternal(ctx context.Context, hostId string) (*common.Host, error)
 	SetIgnoredValidationsInternal(ctx context.Context, clusterID strfmt.UUID, ignoredClusterValidations, ignoredHostValidations string) error
+	IssueHostAgentTokenInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID) (string, error)
+	StartHostMigrationInternal(ctx context.Context, migration *common.HostMigration) error
+	GetHostMigrationInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID) (*common.HostMigration, error)
+	FailHostMigrationInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID, reason string) error
 }
 
 //go:generate mockgen --build_flags=--mod=mod -package bminventory -destination mock_crd_utils.go . CRDUtils
@@ -212,6 +224,7 @@ type bareMetalInventory struct {
 	objectHandler                 s3wrapper.API
 	metricApi                     metrics.API
 	usageApi                      usage.API
+	quotaApi                      quota.API
 	operatorManagerApi            operators.API
 	generator                     generator.InstallConfigGenerator
 	authHandler                   auth.Authenticator
@@ -247,6 +260,7 @@ func NewBareMetalInventory(
 	objectHandler s3wrapper.API,
 	metricApi metrics.API,
 	usageApi usage.API,
+	quotaApi quota.API,
 	operatorManagerApi operators.API,
 	authHandler auth.Authenticator,
 	authzHandler auth.Authorizer,
@@ -282,6 +296,7 @@ func NewBareMetalInventory(
 		objectHandler:                 objectHandler,
 		metricApi:                     metricApi,
 		usageApi:                      usageApi,
+		quotaApi:                      quotaApi,
 		operatorManagerApi:            operatorManagerApi,
 		authHandler:                   authHandler,
 		authzHandler:                  authzHandler,
@@ -712,6 +727,11 @@ func (b *bareMetalInventory) RegisterClusterInternal(ctx context.Context, kubeKe
 		}
 	}()
 
+	// Fail early, the quota is checked again under its lock by the transaction that creates the cluster
+	if err = b.quotaApi.CheckClusterQuota(ctx, b.db); err != nil {
+		return nil, err
+	}
+
 	// initial computation of PrimaryIPStack (needed for validations, will be recomputed later)
 	primaryIPStack, err := b.getPrimaryIPStack(params.NewClusterParams.MachineNetworks, params.NewClusterParams.APIVips, params.NewClusterParams.IngressVips, params.NewClusterParams.ServiceNetworks, params.NewClusterParams.ClusterNetworks)
 	if err != nil {
@@ -873,9 +893,8 @@ func (b *bareMetalInventory) RegisterClusterInternal(ctx context.Context, kubeKe
 		return nil, common.NewApiError(http.StatusBadRequest, err)
 	}
 
-	err = b.clusterApi.RegisterCluster(ctx, cluster)
-	if err != nil {
-		return nil, common.NewApiError(http.StatusInternalServerError, err)
+	if err = b.registerClusterWithinQuota(ctx, cluster); err != nil {
+		return nil, err
 	}
 
 	if b.ocmClient != nil {
@@ -1127,6 +1146,11 @@ func (b *bareMetalInventory) V2ImportClusterInternal(ctx context.Context, kubeKe
 		return nil, common.NewApiError(http.StatusBadRequest, fmt.Errorf("AddHostsCluster for AI cluster %s already exists", id))
 	}
 
+	// Fail early, the quota is checked again under its lock by the transaction that creates the cluster
+	if err := b.quotaApi.CheckClusterQuota(ctx, b.db); err != nil {
+		return nil, err
+	}
+
 	if kubeKey == nil {
 		kubeKey = &types.NamespacedName{}
 	}
@@ -1172,16 +1196,29 @@ func (b *bareMetalInventory) V2ImportClusterInternal(ctx context.Context, kubeKe
 	}
 
 	// After registering the cluster, its status should be 'ClusterStatusAddingHosts'
-	err = b.clusterApi.RegisterCluster(ctx, &newCluster)
-	if err != nil {
+	if err = b.registerClusterWithinQuota(ctx, &newCluster); err != nil {
 		log.Errorf("failed to register cluster %s ", clusterName)
-		return nil, common.NewApiError(http.StatusInternalServerError, err)
+		return nil, err
 	}
 
 	b.metricApi.ClusterRegistered()
 	return &newCluster, nil
 }
 
+// registerClusterWithinQuota creates the cluster in the transaction that locks the quota of its
+// owners, so that concurrent registrations can't exceed it
+func (b *bareMetalInventory) registerClusterWithinQuota(ctx context.Context, cluster *common.Cluster) error {
+	return b.db.Transaction(func(tx *gorm.DB) error {
+		if err := b.quotaApi.CheckClusterQuota(ctx, tx); err != nil {
+			return err
+		}
+		if err := b.clusterApi.RegisterCluster(ctx, cluster, tx); err != nil {
+			return common.NewApiError(http.StatusInternalServerError, err)
+		}
+		return nil
+	})
+}
+
 func (b *bareMetalInventory) createAndUploadDay2NodeIgnition(ctx context.Context, cluster *common.Cluster, host *models.Host, ignitionEndpointToken, ignitionEndpointHTTPHeaders string) error {
 	log := logutil.FromContext(ctx, b.log)
 	log.Infof("Starting createAndUploadDay2NodeIgnition for cluster %s, host %s", cluster.ID, host.ID)
@@ -3874,6 +3911,25 @@ func (b *bareMetalInventory) handleReplyError(params installer.V2PostStepReplyPa
 	case models.StepTypeTangConnectivityCheck:
 		return b.hostApi.UpdateTangConnectivityReport(ctx, h, params.Reply.Error)
 
+	case models.StepTypeRotateAgentToken:
+		// Also the reply of the agents that don't know the step, the token is sent again
+		eventgen.SendAgentTokenRotationFailedEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID)
+		return nil
+
+	case models.StepTypeMigrateAgent:
+		return b.recordHostMigrationResult(ctx, h, fmt.Sprintf("the agent failed to migrate: %s", params.Reply.Error))
+
+	case models.StepTypeTpmAttestation:
+		reason := fmt.Sprintf("the agent failed to answer the attestation challenge: %s", params.Reply.Error)
+		repeated, err := attestation.RecordFailure(b.db, h.InfraEnvID, *h.ID, reason)
+		if err != nil {
+			return err
+		}
+		if !repeated {
+			eventgen.SendHostAttestationFailedEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID, reason)
+		}
+		return b.hostApi.RefreshStatus(ctx, h, b.db)
+
 	case models.StepTypeDownloadBootArtifacts:
 		log.Errorf("Failed to download boot artifacts to reclaim host %s, output: %s, error: %s", h.ID, params.Reply.Output, params.Reply.Error)
 		return b.hostApi.HandleReclaimFailure(ctx, h)
@@ -4063,6 +4119,110 @@ func (b *bareMetalInventory) processUpgradeAgentResponse(ctx context.Context, h
 	return nil
 }
 
+// processRotateAgentTokenResponse records that the agent uses the agent token delivered by the
+// step, the ID of the step identifies the token
+func (b *bareMetalInventory) processRotateAgentTokenResponse(ctx context.Context, h *models.Host, stepID string,
+	responseJSON string) error {
+	log := logutil.FromContext(ctx, b.log)
+
+	var response models.RotateAgentTokenResponse
+	if err := json.Unmarshal([]byte(responseJSON), &response); err != nil {
+		log.WithError(err).Errorf("failed to unmarshal rotate agent token response from host '%s'", h.ID.String())
+		return err
+	}
+	if response.Result != models.RotateAgentTokenResultSuccess {
+		eventgen.SendAgentTokenRotationFailedEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID)
+		return nil
+	}
+
+	tokenID, ok := hostcommands.AgentTokenIDFromStepID(stepID)
+	if !ok {
+		return errors.Errorf("step %s doesn't deliver an agent token", stepID)
+	}
+	result := b.db.Model(&common.AgentToken{}).
+		Where("id = ? AND host_id = ? AND delivered_at IS NULL", tokenID, h.ID.String()).
+		Update("delivered_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Infof("Host %s uses agent token %s", h.ID.String(), tokenID)
+		eventgen.SendAgentTokenRotatedEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID)
+	}
+	return nil
+}
+
+// processMigrateAgentResponse records the migration of the host to the peer hub, once the agent is
+// configured to reach the peer service
+func (b *bareMetalInventory) processMigrateAgentResponse(ctx context.Context, h *models.Host, responseJSON string) error {
+	log := logutil.FromContext(ctx, b.log)
+
+	var response models.MigrateAgentResponse
+	if err := json.Unmarshal([]byte(responseJSON), &response); err != nil {
+		log.WithError(err).Errorf("failed to unmarshal migrate agent response from host '%s'", h.ID.String())
+		return err
+	}
+	if response.Result != models.MigrateAgentResultSuccess {
+		return b.recordHostMigrationResult(ctx, h, "the agent failed to update its configuration")
+	}
+	return b.recordHostMigrationResult(ctx, h, "")
+}
+
+// recordHostMigrationResult records the reply of the agent to its migration, the host was migrated
+// when the reason is empty
+func (b *bareMetalInventory) recordHostMigrationResult(ctx context.Context, h *models.Host, reason string) error {
+	log := logutil.FromContext(ctx, b.log)
+
+	migration, err := federation.Pending(b.db, h.InfraEnvID, *h.ID)
+	if err != nil || migration == nil {
+		return err
+	}
+	recorded, err := federation.RecordResult(b.db, h.InfraEnvID, *h.ID, reason)
+	if err != nil || !recorded {
+		return err
+	}
+	if reason != "" {
+		log.Warnf("Failed to migrate host %s to %s: %s", h.ID.String(), migration.ServiceBaseURL, reason)
+		eventgen.SendHostMigrationFailedEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID,
+			migration.ServiceBaseURL, reason)
+		return nil
+	}
+	log.Infof("Migrated host %s to infra-env %s of %s", h.ID.String(), migration.PeerInfraEnvID, migration.ServiceBaseURL)
+	eventgen.SendHostMigratedEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID,
+		migration.PeerInfraEnvID.String(), migration.ServiceBaseURL)
+	return nil
+}
+
+func (b *bareMetalInventory) processTpmAttestationResponse(ctx context.Context, h *models.Host, responseJSON string) error {
+	log := logutil.FromContext(ctx, b.log)
+
+	var response models.TpmAttestationResponse
+	if err := json.Unmarshal([]byte(responseJSON), &response); err != nil {
+		log.WithError(err).Errorf("failed to unmarshal TPM attestation response from host '%s'", h.ID.String())
+		return err
+	}
+	infraEnv, err := common.GetInfraEnvFromDB(b.db, h.InfraEnvID)
+	if err != nil {
+		return err
+	}
+	result, err := attestation.Verify(b.db, infraEnv, h, &response)
+	if err != nil || result == nil {
+		return err
+	}
+	if result.Reason == "" {
+		log.Infof("Host %s attested its TPM", h.ID.String())
+		pcrPolicy := result.PCRPolicy
+		if pcrPolicy == "" {
+			pcrPolicy = "none"
+		}
+		eventgen.SendHostAttestationSucceededEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID, pcrPolicy)
+	} else if !result.Repeated {
+		log.Warnf("Host %s failed the TPM attestation: %s", h.ID.String(), result.Reason)
+		eventgen.SendHostAttestationFailedEvent(ctx, b.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID, result.Reason)
+	}
+	return b.hostApi.RefreshStatus(ctx, h, b.db)
+}
+
 func (b *bareMetalInventory) getInstallationDiskSpeedThresholdMs(ctx context.Context, h *models.Host) (int64, error) {
 	cluster, err := common.GetClusterFromDB(b.db, *h.ClusterID, common.SkipEagerLoading)
 	if err != nil {
@@ -4116,6 +4276,12 @@ func handleReplyByType(params installer.V2PostStepReplyParams, b *bareMetalInven
 		err = b.updateDomainNameResolutionResponse(ctx, &host, stepReply)
 	case models.StepTypeUpgradeAgent:
 		err = b.processUpgradeAgentResponse(ctx, &host, stepReply)
+	case models.StepTypeRotateAgentToken:
+		err = b.processRotateAgentTokenResponse(ctx, &host, params.Reply.StepID, stepReply)
+	case models.StepTypeTpmAttestation:
+		err = b.processTpmAttestationResponse(ctx, &host, stepReply)
+	case models.StepTypeMigrateAgent:
+		err = b.processMigrateAgentResponse(ctx, &host, stepReply)
 	case models.StepTypeDownloadBootArtifacts:
 		err = b.hostApi.HandleReclaimBootArtifactDownload(ctx, &host)
 	case models.StepTypeVerifyVips:
@@ -4180,6 +4346,12 @@ func filterReplyByType(params installer.V2PostStepReplyParams) (string, error) {
 		stepReply, err = filterReply(&models.DomainResolutionResponse{}, params.Reply.Output)
 	case models.StepTypeUpgradeAgent:
 		stepReply, err = filterReply(&models.UpgradeAgentResponse{}, params.Reply.Output)
+	case models.StepTypeRotateAgentToken:
+		stepReply, err = filterReply(&models.RotateAgentTokenResponse{}, params.Reply.Output)
+	case models.StepTypeTpmAttestation:
+		stepReply, err = filterReply(&models.TpmAttestationResponse{}, params.Reply.Output)
+	case models.StepTypeMigrateAgent:
+		stepReply, err = filterReply(&models.MigrateAgentResponse{}, params.Reply.Output)
 	case models.StepTypeVerifyVips:
 		stepReply, err = filterReply(&models.VerifyVipsResponse{}, params.Reply.Output)
 	}
@@ -4801,6 +4973,57 @@ func (b *bareMetalInventory) UpdateHostApprovedInternal(ctx context.Context, inf
 	return nil
 }
 
+// Updates the hardware details reported by the BareMetalHost of the host, a nil inventory clears them.
+// Used exclusively by kube-api.
+func (b *bareMetalInventory) UpdateHostBmcInventoryInternal(ctx context.Context, infraEnvId, hostId string, bmcInventory *models.BmcInventory) error {
+	log := logutil.FromContext(ctx, b.log)
+	var value string
+	if bmcInventory != nil {
+		data, err := json.Marshal(bmcInventory)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the BMC inventory of host %s", hostId)
+		}
+		value = string(data)
+	}
+	if err := b.db.Model(&common.Host{}).Where("id = ? and infra_env_id = ?", hostId, infraEnvId).Update("bmc_inventory", value).Error; err != nil {
+		log.WithError(err).Errorf("failed to update 'bmc_inventory' in host: %s", hostId)
+		return err
+	}
+	return nil
+}
+
+// UpdateClusterSpokeHealthInternal stores the health collected from the installed cluster, and sends
+// an event when its status changes
+func (b *bareMetalInventory) UpdateClusterSpokeHealthInternal(ctx context.Context, clusterID strfmt.UUID, health *models.SpokeHealth) error {
+	log := logutil.FromContext(ctx, b.log)
+	cluster, err := common.GetClusterFromDB(b.db, clusterID, common.SkipEagerLoading)
+	if err != nil {
+		log.WithError(err).Errorf("failed to find cluster %s", clusterID)
+		return err
+	}
+	previous, err := spokehealth.FromCluster(cluster)
+	if err != nil {
+		log.WithError(err).Warnf("ignoring the previous spoke health of cluster %s", clusterID)
+	}
+	data, err := json.Marshal(health)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the spoke health of cluster %s", clusterID)
+	}
+	if err = b.db.Model(&common.Cluster{}).Where("id = ?", clusterID).Update("spoke_health", string(data)).Error; err != nil {
+		log.WithError(err).Errorf("failed to update 'spoke_health' in cluster: %s", clusterID)
+		return err
+	}
+	if previous != nil && previous.Status == health.Status {
+		return nil
+	}
+	if health.Status == models.SpokeHealthStatusHealthy {
+		eventgen.SendSpokeClusterHealthyEvent(ctx, b.eventsHandler, clusterID)
+	} else {
+		eventgen.SendSpokeClusterUnhealthyEvent(ctx, b.eventsHandler, clusterID, health.Status, health.Message)
+	}
+	return nil
+}
+
 func (b *bareMetalInventory) getClusterInfraenvs(c *common.Cluster) ([]*common.InfraEnv, error) {
 	// Cluster hosts usually originate from the same infraenv, keep track
 	// of which ones we've already seen so we don't pull them twice
@@ -5164,6 +5387,10 @@ func (b *bareMetalInventory) RegisterInfraEnvInternal(ctx context.Context, kubeK
 
 	var err error
 	err = b.db.Transaction(func(tx *gorm.DB) error {
+		if err = b.quotaApi.CheckInfraEnvQuota(ctx, tx); err != nil {
+			return err
+		}
+
 		params = b.setDefaultRegisterInfraEnvParams(ctx, params)
 
 		var cluster *common.Cluster
@@ -5224,6 +5451,21 @@ func (b *bareMetalInventory) RegisterInfraEnvInternal(ctx context.Context, kubeK
 			return common.NewApiError(http.StatusBadRequest, err)
 		}
 
+		var tpmAttestationPolicy *string
+		if tpmAttestationPolicy, err = formatTpmAttestationPolicy(params.InfraenvCreateParams.TpmAttestationPolicy); err != nil {
+			return err
+		}
+
+		var hostApprovalPolicy *string
+		if hostApprovalPolicy, err = formatHostApprovalPolicy(params.InfraenvCreateParams.HostApprovalPolicy); err != nil {
+			return err
+		}
+
+		var firmwareRequirements *string
+		if firmwareRequirements, err = formatFirmwareRequirements(params.InfraenvCreateParams.FirmwareRequirements, kubeKey.Namespace != ""); err != nil {
+			return err
+		}
+
 		var kernelArguments *string
 		if len(params.InfraenvCreateParams.KernelArguments) > 0 {
 			var b []byte
@@ -5256,6 +5498,9 @@ func (b *bareMetalInventory) RegisterInfraEnvInternal(ctx context.Context, kubeK
 				KernelArguments:              kernelArguments,
 				AdditionalTrustBundle:        params.InfraenvCreateParams.AdditionalTrustBundle,
 				NetworkDiscoveryDelaySeconds: params.InfraenvCreateParams.NetworkDiscoveryDelaySeconds,
+				TpmAttestationPolicy:         tpmAttestationPolicy,
+				HostApprovalPolicy:           hostApprovalPolicy,
+				FirmwareRequirements:         firmwareRequirements,
 			},
 			KubeKeyNamespace: kubeKey.Namespace,
 			ImageTokenKey:    imageTokenKey,
@@ -5710,6 +5955,18 @@ func (b *bareMetalInventory) updateInfraEnvData(infraEnv *common.InfraEnv, param
 		return err
 	}
 
+	if err := b.updateInfraEnvTpmAttestationPolicy(params, infraEnv, db); err != nil {
+		return err
+	}
+
+	if err := b.updateInfraEnvHostApprovalPolicy(params, infraEnv, db); err != nil {
+		return err
+	}
+
+	if err := b.updateInfraEnvFirmwareRequirements(params, infraEnv, db); err != nil {
+		return err
+	}
+
 	inputSSHKey := swag.StringValue(params.InfraEnvUpdateParams.SSHAuthorizedKey)
 	if inputSSHKey != "" && inputSSHKey != infraEnv.SSHAuthorizedKey {
 		updates["ssh_authorized_key"] = inputSSHKey
@@ -5995,6 +6252,117 @@ func (b *bareMetalInventory) updateInfraEnvKernelArguments(params installer.Upda
 	return nil
 }
 
+// formatTpmAttestationPolicy validates the TPM attestation policy of the infra-env and formats it
+// for the DB, an empty policy disables the attestation
+func formatTpmAttestationPolicy(policy *models.TpmAttestationPolicy) (*string, error) {
+	if policy == nil || policy.EkCertificates == "" {
+		return nil, nil
+	}
+	if err := attestation.ValidatePolicy(policy); err != nil {
+		return nil, common.NewApiError(http.StatusBadRequest, err)
+	}
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return nil, common.NewApiError(http.StatusBadRequest, errors.Wrap(err, "failed to format the TPM attestation policy as json"))
+	}
+	return swag.String(string(b)), nil
+}
+
+// updateInfraEnvTpmAttestationPolicy updates the TPM attestation policy of the infra-env, it doesn't
+// change the discovery image. The hosts pending attestation are released when the attestation is
+// disabled, the other policy changes apply when the hosts register again.
+func (b *bareMetalInventory) updateInfraEnvTpmAttestationPolicy(params installer.UpdateInfraEnvParams, infraEnv *common.InfraEnv, db *gorm.DB) error {
+	if params.InfraEnvUpdateParams.TpmAttestationPolicy == nil {
+		return nil
+	}
+	policy, err := formatTpmAttestationPolicy(params.InfraEnvUpdateParams.TpmAttestationPolicy)
+	if err != nil {
+		return err
+	}
+	var value interface{} = gorm.Expr("NULL")
+	if policy != nil {
+		value = *policy
+	} else if err = attestation.DeletePending(db, *infraEnv.ID); err != nil {
+		return common.NewApiError(http.StatusInternalServerError, errors.Wrapf(err, "failed to release the hosts pending attestation of infraEnv %s", infraEnv.ID))
+	}
+	if err = db.Model(&common.InfraEnv{}).Where("id = ?", infraEnv.ID.String()).Update("tpm_attestation_policy", value).Error; err != nil {
+		return common.NewApiError(http.StatusInternalServerError, errors.Wrapf(err, "failed to update the TPM attestation policy of infraEnv %s", infraEnv.ID))
+	}
+	return nil
+}
+
+// formatHostApprovalPolicy validates the host approval policy of the infra-env and formats it for
+// the DB, a policy without rules that leaves the hosts to the user is no policy
+func formatHostApprovalPolicy(policy *models.HostApprovalPolicy) (*string, error) {
+	formatted, err := hostapproval.Format(policy)
+	if err != nil {
+		return nil, common.NewApiError(http.StatusBadRequest, err)
+	}
+	if formatted == "" {
+		return nil, nil
+	}
+	return swag.String(formatted), nil
+}
+
+// updateInfraEnvHostApprovalPolicy updates the host approval policy of the infra-env, it doesn't
+// change the discovery image. The hosts are evaluated against the new policy on their next refresh.
+func (b *bareMetalInventory) updateInfraEnvHostApprovalPolicy(params installer.UpdateInfraEnvParams, infraEnv *common.InfraEnv, db *gorm.DB) error {
+	if params.InfraEnvUpdateParams.HostApprovalPolicy == nil {
+		return nil
+	}
+	policy, err := formatHostApprovalPolicy(params.InfraEnvUpdateParams.HostApprovalPolicy)
+	if err != nil {
+		return err
+	}
+	var value interface{} = gorm.Expr("NULL")
+	if policy != nil {
+		value = *policy
+	}
+	if err = db.Model(&common.InfraEnv{}).Where("id = ?", infraEnv.ID.String()).Update("host_approval_policy", value).Error; err != nil {
+		return common.NewApiError(http.StatusInternalServerError, errors.Wrapf(err, "failed to update the host approval policy of infraEnv %s", infraEnv.ID))
+	}
+	return nil
+}
+
+// formatFirmwareRequirements validates the firmware requirements of the infra-env and formats them
+// for the DB, nil when there are no requirements. Only the BareMetalHosts report the BMC inventory
+// that the requirements are checked against, so they are refused for the infra-envs that aren't
+// managed by the kube API.
+func formatFirmwareRequirements(requirements *models.FirmwareRequirements, kubeAPI bool) (*string, error) {
+	formatted, err := bmcinventory.Format(requirements)
+	if err != nil {
+		return nil, common.NewApiError(http.StatusBadRequest, err)
+	}
+	if formatted == "" {
+		return nil, nil
+	}
+	if !kubeAPI {
+		return nil, common.NewApiError(http.StatusBadRequest,
+			errors.New("firmware requirements are only supported for the infra-envs managed by the kube API, the BMC inventory is reported by their BareMetalHosts"))
+	}
+	return swag.String(formatted), nil
+}
+
+// updateInfraEnvFirmwareRequirements updates the firmware requirements of the infra-env, it doesn't
+// change the discovery image. The hosts are checked against the new requirements on their next refresh.
+func (b *bareMetalInventory) updateInfraEnvFirmwareRequirements(params installer.UpdateInfraEnvParams, infraEnv *common.InfraEnv, db *gorm.DB) error {
+	if params.InfraEnvUpdateParams.FirmwareRequirements == nil {
+		return nil
+	}
+	requirements, err := formatFirmwareRequirements(params.InfraEnvUpdateParams.FirmwareRequirements, infraEnv.KubeKeyNamespace != "")
+	if err != nil {
+		return err
+	}
+	var value interface{} = gorm.Expr("NULL")
+	if requirements != nil {
+		value = *requirements
+	}
+	if err = db.Model(&common.InfraEnv{}).Where("id = ?", infraEnv.ID.String()).Update("firmware_requirements", value).Error; err != nil {
+		return common.NewApiError(http.StatusInternalServerError, errors.Wrapf(err, "failed to update the firmware requirements of infraEnv %s", infraEnv.ID))
+	}
+	return nil
+}
+
 func (b *bareMetalInventory) GetInfraEnvByKubeKey(key types.NamespacedName) (*common.InfraEnv, error) {
 	infraEnv, err := common.GetInfraEnvFromDBWhere(b.db, "name = ? and kube_key_namespace = ?", key.Name, key.Namespace)
 	if err != nil {
@@ -6026,6 +6394,28 @@ func (b *bareMetalInventory) CreateHostInKubeKeyNamespace(ctx context.Context, k
 	return nil
 }
 
+// checkAgentTokenOfHost rejects the registration of a host with the local agent token of another
+// host, or with the tokens of the infra-env once the tokens of the host were revoked. The host is in
+// the body of the registration, so the authorizer doesn't know it.
+func checkAgentTokenOfHost(ctx context.Context, tx *gorm.DB, hostID strfmt.UUID) error {
+	agentToken := ocm.AgentTokenFromContext(ctx)
+	if agentToken == nil {
+		return nil
+	}
+	if agentToken.HostID != "" && agentToken.HostID != hostID.String() {
+		return common.NewInfraError(http.StatusForbidden,
+			errors.Errorf("the agent token of host %s can't register host %s", agentToken.HostID, hostID))
+	}
+	var revoked int64
+	if err := tx.Model(&common.AgentToken{}).Where("host_id = ? AND revoked_at IS NOT NULL", hostID.String()).Count(&revoked).Error; err != nil {
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+	if revoked > 0 {
+		return common.NewInfraError(http.StatusUnauthorized, errors.Errorf("the agent tokens of host %s were revoked", hostID))
+	}
+	return nil
+}
+
 func (b *bareMetalInventory) V2RegisterHost(ctx context.Context, params installer.V2RegisterHostParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
 	log.Infof("Register host: %+v", params)
@@ -6047,6 +6437,11 @@ func (b *bareMetalInventory) V2RegisterHost(ctx context.Context, params installe
 			return common.NewApiError(400, errors.Errorf("Cannot register a host to an InfraEnv with disconnected-iso type"))
 		}
 
+		if err = checkAgentTokenOfHost(ctx, tx, *params.NewHostParams.HostID); err != nil {
+			log.WithError(err).Errorf("host %s can't register with the agent token", *params.NewHostParams.HostID)
+			return err
+		}
+
 		// The query for cluster must appear before the host query to avoid potential deadlock
 		cluster, err = b.getBoundClusterForUpdate(tx, infraEnv, params.InfraEnvID, *params.NewHostParams.HostID)
 		if err != nil {
@@ -6064,6 +6459,13 @@ func (b *bareMetalInventory) V2RegisterHost(ctx context.Context, params installe
 		// In case host doesn't exists check if the cluster accept new hosts registration
 		newRecord := err != nil && errors.Is(err, gorm.ErrRecordNotFound)
 
+		if newRecord {
+			if err = b.quotaApi.CheckHostQuota(ctx, tx, infraEnv); err != nil {
+				eventgen.SendHostRegistrationFailedEvent(ctx, b.eventsHandler, *params.NewHostParams.HostID, params.InfraEnvID, common.StrFmtUUIDPtr(infraEnv.ClusterID), err.Error())
+				return err
+			}
+		}
+
 		url := installer.V2GetHostURL{InfraEnvID: params.InfraEnvID, HostID: *params.NewHostParams.HostID}
 		kind := swag.String(models.HostKindHost)
 
@@ -6120,6 +6522,17 @@ func (b *bareMetalInventory) V2RegisterHost(ctx context.Context, params installe
 
 		host.SuggestedRole = host.Role
 
+		// The host is held pending attestation when the infra-env requires the hosts to attest their TPM
+		var attestationFailure string
+		if attestationFailure, err = attestation.RegisterHost(tx, infraEnv, *host.ID, params.NewHostParams.TpmEndorsement); err != nil {
+			log.WithError(err).Errorf("failed to record the TPM endorsement of host <%s> infra-env <%s>",
+				params.NewHostParams.HostID.String(), params.InfraEnvID.String())
+			return common.NewApiError(http.StatusInternalServerError, err)
+		}
+		if attestationFailure != "" {
+			eventgen.SendHostAttestationFailedEvent(ctx, b.eventsHandler, *host.ID, hostutil.GetHostnameForMsg(host), params.InfraEnvID, host.ClusterID, attestationFailure)
+		}
+
 		if err = b.hostApi.RegisterHost(ctx, host, tx); err != nil {
 			log.WithError(err).Errorf("failed to register host <%s> infra-env <%s>",
 				params.NewHostParams.HostID.String(), params.InfraEnvID.String())
@@ -7464,3 +7877,44 @@ func extractMirroredRegistriesFromConfig(log logrus.FieldLogger, mirrorRegistryC
 func (b *bareMetalInventory) GetHostByIdInternal(ctx context.Context, hostId string) (*common.Host, error) {
 	return common.GetHostFromDBbyHostId(b.db, strfmt.UUID(hostId))
 }
+
+// IssueHostAgentTokenInternal issues the agent token of a host imported from a peer hub, the peer
+// hub delivers it to the agent when it migrates the host
+func (b *bareMetalInventory) IssueHostAgentTokenInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID) (string, error) {
+	return federation.IssueAgentToken(b.db, infraEnvID, hostID)
+}
+
+// StartHostMigrationInternal configures the agent of an unbound host to reach a peer hub with its
+// next steps
+func (b *bareMetalInventory) StartHostMigrationInternal(ctx context.Context, migration *common.HostMigration) error {
+	host, err := common.GetHostFromDB(b.db, migration.InfraEnvID.String(), migration.HostID.String())
+	if err != nil {
+		return err
+	}
+	if host.ClusterID != nil {
+		return common.NewApiError(http.StatusConflict,
+			errors.Errorf("host %s is bound to cluster %s, it can't be migrated", migration.HostID, host.ClusterID))
+	}
+	if migration.Token == "" {
+		return common.NewApiError(http.StatusConflict,
+			errors.Errorf("the peer hub didn't issue an agent token for host %s, the hosts can only be migrated with the %s authentication",
+				migration.HostID, auth.TypeLocal))
+	}
+	return federation.Start(b.db, migration)
+}
+
+// FailHostMigrationInternal records the failure of the pending migration of the host, the agent
+// is no longer given the step to migrate
+func (b *bareMetalInventory) FailHostMigrationInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID, reason string) error {
+	host, err := common.GetHostFromDB(b.db, infraEnvID.String(), hostID.String())
+	if err != nil {
+		return err
+	}
+	return b.recordHostMigrationResult(ctx, &host.Host, reason)
+}
+
+// GetHostMigrationInternal returns the last migration of the host to a peer hub, nil when the host
+// was never migrated
+func (b *bareMetalInventory) GetHostMigrationInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID) (*common.HostMigration, error) {
+	return federation.Get(b.db, infraEnvID, hostID)
+}
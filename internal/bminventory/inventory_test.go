@@ -38,6 +38,7 @@ import (
 	gomega_format "github.com/onsi/gomega/format"
 	amgmtv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/assisted-service/internal/attestation"
 	"github.com/openshift/assisted-service/internal/cluster"
 	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
@@ -48,10 +49,12 @@ import (
 	eventsapi "github.com/openshift/assisted-service/internal/events/api"
 	"github.com/openshift/assisted-service/internal/events/eventstest"
 	"github.com/openshift/assisted-service/internal/featuresupport"
+	"github.com/openshift/assisted-service/internal/federation"
 	"github.com/openshift/assisted-service/internal/garbagecollector"
 	"github.com/openshift/assisted-service/internal/gencrypto"
 	"github.com/openshift/assisted-service/internal/hardware"
 	"github.com/openshift/assisted-service/internal/host"
+	"github.com/openshift/assisted-service/internal/host/hostcommands"
 	"github.com/openshift/assisted-service/internal/host/hostutil"
 	"github.com/openshift/assisted-service/internal/ignition"
 	"github.com/openshift/assisted-service/internal/infraenv"
@@ -63,12 +66,14 @@ import (
 	"github.com/openshift/assisted-service/internal/operators"
 	"github.com/openshift/assisted-service/internal/provider/registry"
 	"github.com/openshift/assisted-service/internal/provider/vsphere"
+	"github.com/openshift/assisted-service/internal/quota"
 	"github.com/openshift/assisted-service/internal/stream"
 	testutils "github.com/openshift/assisted-service/internal/testing"
 	"github.com/openshift/assisted-service/internal/usage"
 	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/auth"
+	errorutil "github.com/openshift/assisted-service/pkg/error"
 	"github.com/openshift/assisted-service/pkg/executer"
 	"github.com/openshift/assisted-service/pkg/filemiddleware"
 	"github.com/openshift/assisted-service/pkg/generator"
@@ -408,7 +413,7 @@ var _ = Describe("RegisterDisconnectedCluster", func() {
 		// Avoid AMS subscription side effects during registration in this test
 		//bm.ocmClient = nil
 		// Use real cluster manager so RegisterCluster persists to DB
-		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 		mockUsageReports()
 	})
 
@@ -1060,6 +1065,130 @@ var _ = Describe("v2PostStepReply", func() {
 		})
 	})
 
+	Context("rotate agent token", func() {
+		var (
+			hostId     strfmt.UUID
+			infraEnvId strfmt.UUID
+			token      common.AgentToken
+		)
+
+		BeforeEach(func() {
+			hostId = strfmt.UUID(uuid.New().String())
+			infraEnvId = strfmt.UUID(uuid.New().String())
+			host := hostutil.GenerateTestHost(hostId, infraEnvId, "", models.HostStatusKnownUnbound)
+			Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+			token = common.AgentToken{ID: uuid.New().String(), InfraEnvID: infraEnvId, HostID: hostId, CreatedAt: time.Now()}
+			Expect(db.Create(&token).Error).ShouldNot(HaveOccurred())
+		})
+
+		makeStepReply := func(exitCode int64, result models.RotateAgentTokenResult) installer.V2PostStepReplyParams {
+			b, _ := json.Marshal(&models.RotateAgentTokenResponse{Result: result})
+			return installer.V2PostStepReplyParams{
+				InfraEnvID: infraEnvId,
+				HostID:     hostId,
+				Reply: &models.StepReply{
+					ExitCode: exitCode,
+					Output:   string(b),
+					StepID:   hostcommands.RotateAgentTokenStepID(token.ID),
+					StepType: models.StepTypeRotateAgentToken,
+				},
+			}
+		}
+
+		deliveredAt := func() *time.Time {
+			var t common.AgentToken
+			Expect(db.Take(&t, "id = ?", token.ID).Error).ShouldNot(HaveOccurred())
+			return t.DeliveredAt
+		}
+
+		It("records the delivery of the token", func() {
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(eventgen.AgentTokenRotatedEventName),
+				eventstest.WithHostIdMatcher(hostId.String()))).Times(1)
+			reply := bm.V2PostStepReply(ctx, makeStepReply(0, models.RotateAgentTokenResultSuccess))
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewV2PostStepReplyNoContent()))
+			Expect(deliveredAt()).ToNot(BeNil())
+		})
+
+		It("keeps delivering the token when the agent fails to switch to it", func() {
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(eventgen.AgentTokenRotationFailedEventName),
+				eventstest.WithHostIdMatcher(hostId.String()))).Times(2)
+			reply := bm.V2PostStepReply(ctx, makeStepReply(0, models.RotateAgentTokenResultFailure))
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewV2PostStepReplyNoContent()))
+			reply = bm.V2PostStepReply(ctx, makeStepReply(255, ""))
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewV2PostStepReplyNoContent()))
+			Expect(deliveredAt()).To(BeNil())
+		})
+	})
+
+	Context("migrate agent", func() {
+		var (
+			hostId     strfmt.UUID
+			infraEnvId strfmt.UUID
+		)
+
+		BeforeEach(func() {
+			hostId = strfmt.UUID(uuid.New().String())
+			infraEnvId = strfmt.UUID(uuid.New().String())
+			host := hostutil.GenerateUnassignedTestHost(hostId, infraEnvId, models.HostStatusKnownUnbound)
+			Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+			Expect(federation.Start(db, &common.HostMigration{
+				HostID:         hostId,
+				InfraEnvID:     infraEnvId,
+				ServiceBaseURL: "https://peer.example.com",
+				PeerInfraEnvID: strfmt.UUID(uuid.New().String()),
+				Token:          "peer-token",
+			})).To(Succeed())
+		})
+
+		makeStepReply := func(exitCode int64, result models.MigrateAgentResult) installer.V2PostStepReplyParams {
+			b, _ := json.Marshal(&models.MigrateAgentResponse{Result: result})
+			return installer.V2PostStepReplyParams{
+				InfraEnvID: infraEnvId,
+				HostID:     hostId,
+				Reply: &models.StepReply{
+					ExitCode: exitCode,
+					Output:   string(b),
+					StepID:   string(models.StepTypeMigrateAgent),
+					StepType: models.StepTypeMigrateAgent,
+				},
+			}
+		}
+
+		getMigration := func() *common.HostMigration {
+			migration, err := federation.Get(db, infraEnvId, hostId)
+			Expect(err).ToNot(HaveOccurred())
+			return migration
+		}
+
+		It("records the migration of the host", func() {
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(eventgen.HostMigratedEventName),
+				eventstest.WithHostIdMatcher(hostId.String()))).Times(1)
+			reply := bm.V2PostStepReply(ctx, makeStepReply(0, models.MigrateAgentResultSuccess))
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewV2PostStepReplyNoContent()))
+			migration := getMigration()
+			Expect(migration.MigratedAt).ToNot(BeNil())
+			Expect(migration.Token).To(BeEmpty())
+
+			By("ignoring the repeated replies")
+			reply = bm.V2PostStepReply(ctx, makeStepReply(0, models.MigrateAgentResultSuccess))
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewV2PostStepReplyNoContent()))
+		})
+
+		It("records the failure of the agent", func() {
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(eventgen.HostMigrationFailedEventName),
+				eventstest.WithHostIdMatcher(hostId.String()))).Times(1)
+			reply := bm.V2PostStepReply(ctx, makeStepReply(255, ""))
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewV2PostStepReplyNoContent()))
+			migration := getMigration()
+			Expect(migration.MigratedAt).To(BeNil())
+			Expect(migration.Error).To(ContainSubstring("the agent failed to migrate"))
+		})
+	})
+
 	Context("verify vips", func() {
 		var (
 			hostId    strfmt.UUID
@@ -2078,7 +2207,7 @@ var _ = Describe("cluster", func() {
 		})
 		It("happy flow", func() {
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, mockStream, mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, mockStream, mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			mockClusterRegisterSuccessWithVersion(models.ClusterCPUArchitectureX8664, "4.8")
 
 			MinimalOpenShiftVersionForNoneHA := "4.8.0-fc.0"
@@ -2095,7 +2224,7 @@ var _ = Describe("cluster", func() {
 		})
 		It("create non ha cluster fail, release version is lower than minimal", func() {
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			insufficientOpenShiftVersionForNoneHA := "4.7"
 			clusterParams.OpenshiftVersion = swag.String(insufficientOpenShiftVersionForNoneHA)
 			clusterParams.ControlPlaneCount = swag.Int64(1)
@@ -2106,7 +2235,7 @@ var _ = Describe("cluster", func() {
 		})
 		It("create non ha cluster fail, release version is pre-release and lower than minimal", func() {
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			insufficientOpenShiftVersionForNoneHA := "4.7.0-fc.1"
 			clusterParams.OpenshiftVersion = swag.String(insufficientOpenShiftVersionForNoneHA)
 			clusterParams.ControlPlaneCount = swag.Int64(1)
@@ -2117,7 +2246,7 @@ var _ = Describe("cluster", func() {
 		})
 		It("create non ha cluster success, release version is greater than minimal", func() {
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 
 			mockClusterRegisterSuccessWithVersion(models.ClusterCPUArchitectureX8664, "4.8")
 			openShiftVersionForNoneHA := "4.8.0"
@@ -2134,7 +2263,7 @@ var _ = Describe("cluster", func() {
 		})
 		It("create non ha cluster success, release version is pre-release and greater than minimal", func() {
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 
 			mockClusterRegisterSuccessWithVersion(models.ClusterCPUArchitectureX8664, "4.8")
 			openShiftVersionForNoneHA := "4.8.0-fc.2"
@@ -2152,7 +2281,7 @@ var _ = Describe("cluster", func() {
 		It("create non ha cluster fail, explicitly disabled UserManagedNetworking", func() {
 			errStr := "Can't set none platform with user-managed-networking disabled"
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			openShiftVersionForNoneHA := "4.8.0-fc.2"
 			clusterParams.OpenshiftVersion = swag.String(openShiftVersionForNoneHA)
 			clusterParams.ControlPlaneCount = swag.Int64(1)
@@ -2164,7 +2293,7 @@ var _ = Describe("cluster", func() {
 		})
 		It("create non ha cluster fail, explicitly enabled VipDhcpAllocation", func() {
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			openShiftVersionForNoneHA := "4.8.0-fc.2"
 			clusterParams.OpenshiftVersion = swag.String(openShiftVersionForNoneHA)
 			clusterParams.ControlPlaneCount = swag.Int64(1)
@@ -2178,7 +2307,7 @@ var _ = Describe("cluster", func() {
 	})
 	It("create non ha cluster success, release version is ci-release and greater than minimal", func() {
 		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 
 		mockClusterRegisterSuccessWithVersion(models.ClusterCPUArchitectureX8664, "4.8")
 		openShiftVersionForNoneHA := "4.8.0-0.ci.test-2021-05-20-000749-ci-op-7xrzwgwy-latest"
@@ -2305,7 +2434,7 @@ var _ = Describe("cluster", func() {
 		It("update cluster day1 with APIVipDNSName failed", func() {
 			mockOperators := operators.NewMockAPI(ctrl)
 			mockNoChangeInOperatorDependencies(mockOperators)
-			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 
 			mockClusterRegisterSuccess(true)
 
@@ -2335,7 +2464,7 @@ var _ = Describe("cluster", func() {
 				cpuArchitecture := "irrelevant"
 				mockOperators := operators.NewMockAPI(ctrl)
 				mockNoChangeInOperatorDependencies(mockOperators)
-				bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+				bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 
 				mockClusterRegisterSuccessWithVersion(cpuArchitecture, openshiftVersion)
 
@@ -2384,7 +2513,7 @@ var _ = Describe("cluster", func() {
 				cpuArchitecture := "irrelevant"
 				mockOperators := operators.NewMockAPI(ctrl)
 				mockNoChangeInOperatorDependencies(mockOperators)
-				bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+				bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 
 				mockClusterRegisterSuccessWithVersion(cpuArchitecture, openshiftVersion)
 				clusterCreateParams := &models.ClusterCreateParams{
@@ -2479,7 +2608,7 @@ var _ = Describe("cluster", func() {
 				BeforeEach(func() {
 					openshiftVersion = "4.12.0"
 					bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-						db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+						db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 				})
 				Context("RegisterCluster - Multiple-VIPs Support", func() {
 
@@ -11998,7 +12127,7 @@ var _ = Describe("V2UploadClusterIngressCert test", func() {
 		mockOperators := operators.NewMockAPI(ctrl)
 		mockNoChangeInOperatorDependencies(mockOperators)
 		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-			db, commontesting.GetDummyNotificationStream(ctrl), nil, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+			db, commontesting.GetDummyNotificationStream(ctrl), nil, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 		c = common.Cluster{Cluster: models.Cluster{
 			ID:               &clusterID,
 			OpenshiftVersion: common.TestDefaultConfig.OpenShiftVersion,
@@ -13936,7 +14065,7 @@ var _ = Describe("GetSupportedPlatformsFromInventory", func() {
 		db, dbName = common.PrepareTestDB()
 		bm = createInventory(db, cfg)
 		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 		mockUsageReports()
 		mockClusterRegisterSuccess(true)
 		mockAMSSubscription(ctx)
@@ -14199,7 +14328,7 @@ var _ = Describe("Register AddHostsCluster test", func() {
 				OpenshiftClusterID: &openshiftClusterID,
 			},
 		}
-		mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any()).Return(nil).Times(1)
+		mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockMetric.EXPECT().ClusterRegistered().Times(1)
 		res := bm.V2ImportCluster(ctx, params)
 		actual := res.(*installer.V2ImportClusterCreated)
@@ -14226,7 +14355,7 @@ var _ = Describe("Register AddHostsCluster test", func() {
 				OpenshiftClusterID: &openshiftClusterID,
 			},
 		}
-		mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any()).Return(nil).Times(1)
+		mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockMetric.EXPECT().ClusterRegistered().Times(1)
 		res := bm.V2ImportCluster(ctx, params)
 		actual := res.(*installer.V2ImportClusterCreated)
@@ -14655,7 +14784,7 @@ var _ = Describe("RegisterCluster with image service disabled", func() {
 		Expect(cfg.DiskEncryptionSupport).Should(BeTrue())
 		bm = createInventoryWithImageService(db, cfg, false)
 		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperatorManager, nil, nil, nil, nil, nil, false, nil)
+			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperatorManager, nil, nil, nil, nil, nil, false, nil, nil)
 		mockUsageReports()
 	})
 
@@ -14711,7 +14840,7 @@ var _ = Describe("RegisterCluster", func() {
 		Expect(cfg.DiskEncryptionSupport).Should(BeTrue())
 		bm = createInventory(db, cfg)
 		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperatorManager, nil, nil, nil, nil, nil, false, nil)
+			db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperatorManager, nil, nil, nil, nil, nil, false, nil, nil)
 		mockUsageReports()
 	})
 
@@ -14720,6 +14849,23 @@ var _ = Describe("RegisterCluster", func() {
 		ctrl.Finish()
 	})
 
+	It("fails when the quota of clusters is exceeded", func() {
+		mockQuota := quota.NewMockAPI(ctrl)
+		bm.quotaApi = mockQuota
+		mockQuota.EXPECT().CheckClusterQuota(ctx, gomock.Any()).Return(&errorutil.QuotaExceededError{
+			Scope: models.QuotaUsageScopeOrg, Tenant: "org", Resource: models.QuotaUsageResourceClusters, Limit: 2, Usage: 2,
+		}).Times(1)
+
+		reply := bm.V2RegisterCluster(ctx, installer.V2RegisterClusterParams{
+			NewClusterParams: getDefaultClusterCreateParams(),
+		})
+		Expect(reply).Should(BeAssignableToTypeOf(&common.QuotaExceededResponse{}))
+		Expect(reply.(*common.QuotaExceededResponse).StatusCode()).To(Equal(int32(http.StatusForbidden)))
+		var count int64
+		Expect(db.Model(&common.Cluster{}).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(BeZero())
+	})
+
 	DescribeTable(
 		"NetworkType/OpenShift version successful registration",
 		func(openshiftVersion, networkType string) {
@@ -16083,7 +16229,7 @@ var _ = Describe("RegisterCluster", func() {
 			var c *models.Cluster
 			diskEncryptionBm := createInventory(db, cfg)
 			diskEncryptionBm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperatorManager, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperatorManager, nil, nil, nil, nil, nil, false, nil, nil)
 
 			By("Register cluster", func() {
 
@@ -16187,7 +16333,7 @@ var _ = Describe("RegisterCluster", func() {
 			cfg.DiskEncryptionSupport = false
 			bm = createInventory(db, cfg)
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			mockUsageReports()
 		})
 
@@ -16414,7 +16560,7 @@ var _ = Describe("RegisterCluster", func() {
 			bm.Config.DefaultClusterNetworkCidr = "1.2.3.0/24"
 			bm.Config.DefaultServiceNetworkCidr = "1.2.4.0/24"
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			mockUsageReports()
 		})
 
@@ -16458,7 +16604,7 @@ var _ = Describe("RegisterCluster", func() {
 			cfg.DiskEncryptionSupport = false
 			bm = createInventory(db, cfg)
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			mockUsageReports()
 		})
 
@@ -16651,7 +16797,7 @@ var _ = Describe("RegisterCluster", func() {
 
 	It("cluster api failed to register", func() {
 		bm.clusterApi = mockClusterApi
-		mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any()).Return(errors.Errorf("error")).Times(1)
+		mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any(), gomock.Any()).Return(errors.Errorf("error")).Times(1)
 		mockClusterRegisterSteps(false)
 
 		reply := bm.V2RegisterCluster(ctx, installer.V2RegisterClusterParams{
@@ -16961,7 +17107,7 @@ var _ = Describe("RegisterCluster", func() {
 			db, dbName = common.PrepareTestDB()
 			bm = createInventory(db, Config{})
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			// Default Config for CIDRs are required to be set
 			bm.Config.DefaultClusterNetworkCidr = "10.0.0.0/16"
 			bm.Config.DefaultServiceNetworkCidr = "172.30.0.0/16"
@@ -17020,7 +17166,7 @@ var _ = Describe("RegisterCluster", func() {
 			db, dbName = common.PrepareTestDB()
 			bm = createInventory(db, Config{})
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 			// Default Config for CIDRs are required to be set
 			bm.Config.DefaultClusterNetworkCidr = "10.0.0.0/16"
 			bm.Config.DefaultServiceNetworkCidr = "172.30.0.0/16"
@@ -17460,7 +17606,7 @@ var _ = Describe("RegisterCluster", func() {
 			db, dbName = common.PrepareTestDB()
 			bm = createInventory(db, cfg)
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 		})
 
 		AfterEach(func() {
@@ -17808,7 +17954,7 @@ var _ = Describe("AMS subscriptions", func() {
 		Expect(envconfig.Process("test", &cfg)).ShouldNot(HaveOccurred())
 		db, dbName = common.PrepareTestDB()
 		bm = createInventory(db, cfg)
-		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 		mockUsageReports()
 	})
 
@@ -17836,7 +17982,7 @@ var _ = Describe("AMS subscriptions", func() {
 
 		It("register cluster - deregister if we failed to create AMS subscription", func() {
 			bm.clusterApi = mockClusterApi
-			mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any()).Return(nil)
+			mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any(), gomock.Any()).Return(nil)
 			mockClusterRegisterSteps(false)
 			mockAccountsMgmt.EXPECT().CreateSubscription(ctx, gomock.Any(), clusterName).Return(nil, errors.New("dummy"))
 			mockClusterApi.EXPECT().DeregisterCluster(ctx, gomock.Any())
@@ -17851,7 +17997,7 @@ var _ = Describe("AMS subscriptions", func() {
 
 		It("register cluster - delete AMS subscription if we failed to patch DB with ams_subscription_id", func() {
 			bm.clusterApi = mockClusterApi
-			mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any()).Return(nil)
+			mockClusterApi.EXPECT().RegisterCluster(ctx, gomock.Any(), gomock.Any()).Return(nil)
 			mockClusterRegisterSteps(false)
 			mockAMSSubscription(ctx)
 			mockClusterApi.EXPECT().UpdateAmsSubscriptionID(ctx, gomock.Any(), strfmt.UUID("")).Return(common.NewApiError(http.StatusInternalServerError, errors.New("dummy")))
@@ -17869,7 +18015,7 @@ var _ = Describe("AMS subscriptions", func() {
 		It("deregister cluster that don't have 'Reserved' subscriptions", func() {
 			mockS3Client = s3wrapper.NewMockAPI(ctrl)
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, mockS3Client, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, mockS3Client, nil, nil, nil, false, nil, nil)
 			mockClusterRegisterSuccess(true)
 			mockAMSSubscription(ctx)
 
@@ -17892,7 +18038,7 @@ var _ = Describe("AMS subscriptions", func() {
 		It("update cluster name happy flow", func() {
 			mockOperators := operators.NewMockAPI(ctrl)
 			mockNoChangeInOperatorDependencies(mockOperators)
-			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 
 			mockClusterRegisterSuccess(true)
 			mockAMSSubscription(ctx)
@@ -17926,7 +18072,7 @@ var _ = Describe("AMS subscriptions", func() {
 		It("update cluster name with same name", func() {
 			mockOperators := operators.NewMockAPI(ctrl)
 			mockNoChangeInOperatorDependencies(mockOperators)
-			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 
 			mockClusterRegisterSuccess(true)
 			mockAMSSubscription(ctx)
@@ -17957,7 +18103,7 @@ var _ = Describe("AMS subscriptions", func() {
 		It("update cluster without name field", func() {
 			mockOperators := operators.NewMockAPI(ctrl)
 			mockNoChangeInOperatorDependencies(mockOperators)
-			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 
 			mockClusterRegisterSuccess(true)
 			mockAMSSubscription(ctx)
@@ -18068,7 +18214,7 @@ var _ = Describe("AMS subscriptions", func() {
 		It("register and deregister cluster happy flow - nil OCM client", func() {
 			mockS3Client = s3wrapper.NewMockAPI(ctrl)
 			bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, mockS3Client, nil, nil, nil, false, nil)
+				db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, mockS3Client, nil, nil, nil, false, nil, nil)
 			bm.ocmClient = nil
 			mockClusterRegisterSuccess(true)
 
@@ -19691,6 +19837,39 @@ var _ = Describe("UpdateHostApproved", func() {
 		Expect(h.Approved).To(Equal(true))
 	})
 
+	It("updates and clears the BMC inventory", func() {
+		bmcInventory := &models.BmcInventory{BiosVersion: "2.19.1", BootMode: "UEFISecureBoot"}
+		Expect(bm.UpdateHostBmcInventoryInternal(ctx, infraEnvID.String(), hostID.String(), bmcInventory)).To(Succeed())
+		h, err := bm.GetCommonHostInternal(ctx, infraEnvID.String(), hostID.String())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(h.BmcInventory).To(MatchJSON(`{"bios_version":"2.19.1","boot_mode":"UEFISecureBoot"}`))
+
+		Expect(bm.UpdateHostBmcInventoryInternal(ctx, infraEnvID.String(), hostID.String(), nil)).To(Succeed())
+		h, err = bm.GetCommonHostInternal(ctx, infraEnvID.String(), hostID.String())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(h.BmcInventory).To(BeEmpty())
+	})
+
+	It("stores the spoke health and sends an event when its status changes", func() {
+		mockEvents.EXPECT().SendClusterEvent(gomock.Any(), eventstest.NewEventMatcher(
+			eventstest.WithNameMatcher(eventgen.SpokeClusterHealthyEventName),
+			eventstest.WithClusterIdMatcher(clusterID.String()))).Times(1)
+		healthy := &models.SpokeHealth{Status: models.SpokeHealthStatusHealthy, ClusterVersion: "4.16.0", TotalNodes: 3, ReadyNodes: 3}
+		Expect(bm.UpdateClusterSpokeHealthInternal(ctx, clusterID, healthy)).To(Succeed())
+		Expect(bm.UpdateClusterSpokeHealthInternal(ctx, clusterID, healthy)).To(Succeed())
+
+		mockEvents.EXPECT().SendClusterEvent(gomock.Any(), eventstest.NewEventMatcher(
+			eventstest.WithNameMatcher(eventgen.SpokeClusterUnhealthyEventName),
+			eventstest.WithClusterIdMatcher(clusterID.String()),
+			eventstest.WithMessageContainsMatcher("nodes not ready: worker-0"))).Times(1)
+		degraded := &models.SpokeHealth{Status: models.SpokeHealthStatusDegraded, Message: "nodes not ready: worker-0"}
+		Expect(bm.UpdateClusterSpokeHealthInternal(ctx, clusterID, degraded)).To(Succeed())
+
+		cluster, err := common.GetClusterFromDB(db, clusterID, common.SkipEagerLoading)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cluster.SpokeHealth).To(MatchJSON(`{"status":"degraded","message":"nodes not ready: worker-0","degraded_operators":null,"not_ready_nodes":null,"unavailable_operators":null}`))
+	})
+
 })
 
 var _ = Describe("Calculate host networks", func() {
@@ -19945,8 +20124,11 @@ func createInventoryWithImageService(db *gorm.DB, cfg Config, enableImageService
 		"/tmp",
 	)
 
+	quotaManager, err := quota.NewManager(quota.Config{}, db, common.GetTestLog())
+	Expect(err).ToNot(HaveOccurred())
+
 	bm := NewBareMetalInventory(db, mockStream, common.GetTestLog(), mockHostApi, mockClusterApi, mockInfraEnvApi, cfg,
-		mockGenerator, mockEvents, mockS3Client, mockMetric, mockUsage, mockOperatorManager,
+		mockGenerator, mockEvents, mockS3Client, mockMetric, mockUsage, quotaManager, mockOperatorManager,
 		getTestAuthHandler(), getTestAuthzHandler(), mockK8sClient, ocmClient, nil, mockSecretValidator, mockVersions,
 		mockOSImages, mockCRDUtils, mockIgnitionBuilder, mockHwValidator, dnsApi, mockInstallConfigBuilder,
 		mockStaticNetworkConfig, gcConfig, mockProviderRegistry, true, "", disconnectedIgnitionGenerator)
@@ -20233,7 +20415,7 @@ var _ = Describe("Dual-stack cluster", func() {
 				cfg.DiskEncryptionSupport = false
 				bm = createInventory(db, cfg)
 				bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog().WithField("pkg", "cluster-monitor"),
-					db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil)
+					db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false, nil, nil)
 				mockUsageReports()
 			})
 			AfterEach(func() {
@@ -20727,7 +20909,7 @@ var _ = Describe("Platform tests", func() {
 		bm = createInventory(db, cfg)
 		mockOperators := operators.NewMockAPI(ctrl)
 		mockNoChangeInOperatorDependencies(mockOperators)
-		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil)
+		bm.clusterApi = cluster.NewManager(cluster.Config{}, common.GetTestLog(), db, commontesting.GetDummyNotificationStream(ctrl), mockEvents, nil, nil, nil, nil, nil, mockOperators, nil, nil, nil, nil, nil, false, nil, nil)
 		bm.ocmClient = nil
 		clusterParams := getDefaultClusterCreateParams()
 		clusterParams.Name = swag.String("cluster")
@@ -21767,6 +21949,36 @@ var _ = Describe("GetInfraEnvDownloadURL", func() {
 		})
 	})
 
+	Context("with an ISO downloads quota", func() {
+		var userCtx context.Context
+
+		BeforeEach(func() {
+			var err error
+			bm.quotaApi, err = quota.NewManager(quota.Config{
+				Limits:             `{"user":{"iso_downloads":1}}`,
+				ISODownloadsPeriod: time.Hour,
+			}, db, common.GetTestLog())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(db.Model(&common.InfraEnv{}).Where("id = ?", infraEnvID.String()).Update("user_name", "jdoe").Error).ToNot(HaveOccurred())
+			userCtx = context.WithValue(ctx, restapi.AuthKey, &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole})
+		})
+
+		It("rejects the downloads above the quota", func() {
+			mockOSImages.EXPECT().GetOsImageOrLatest(common.TestDefaultConfig.OpenShiftVersion, gomock.Any()).Return(common.TestDefaultConfig.OsImage, nil).Times(1)
+			params := installer.GetInfraEnvDownloadURLParams{InfraEnvID: infraEnvID}
+			Expect(bm.GetInfraEnvDownloadURL(userCtx, params)).To(BeAssignableToTypeOf(&installer.GetInfraEnvDownloadURLOK{}))
+
+			resp := bm.GetInfraEnvDownloadURL(userCtx, params)
+			Expect(resp).To(BeAssignableToTypeOf(&common.QuotaExceededResponse{}))
+			Expect(resp.(*common.QuotaExceededResponse).StatusCode()).To(Equal(int32(http.StatusTooManyRequests)))
+		})
+
+		It("doesn't limit the admins", func() {
+			getNewURL()
+			getNewURL()
+		})
+	})
+
 	Context("with local auth", func() {
 		BeforeEach(func() {
 			// Use a local auth handler
@@ -22869,3 +23081,144 @@ var _ = Describe("CreateHostInKubeKeyNamespace", func() {
 		Expect(count).To(Equal(int64(0)))
 	})
 })
+
+var _ = Describe("formatTpmAttestationPolicy", func() {
+	It("disables the attestation without EK certificates", func() {
+		Expect(formatTpmAttestationPolicy(nil)).To(BeNil())
+		Expect(formatTpmAttestationPolicy(&models.TpmAttestationPolicy{})).To(BeNil())
+	})
+
+	It("formats the policy as json", func() {
+		sim, err := attestation.NewSimulator()
+		Expect(err).ToNot(HaveOccurred())
+		policy := &models.TpmAttestationPolicy{
+			EkCertificates: sim.CACertificatePEM(),
+			PcrPolicies:    []*models.TpmPcrPolicy{{Name: "firmware", Pcrs: map[string]string{"0": sim.PCR(0)}}},
+		}
+		formatted, err := formatTpmAttestationPolicy(policy)
+		Expect(err).ToNot(HaveOccurred())
+		var parsed models.TpmAttestationPolicy
+		Expect(json.Unmarshal([]byte(swag.StringValue(formatted)), &parsed)).To(Succeed())
+		Expect(&parsed).To(Equal(policy))
+	})
+
+	It("rejects an invalid policy", func() {
+		_, err := formatTpmAttestationPolicy(&models.TpmAttestationPolicy{EkCertificates: "not a certificate"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusBadRequest)))
+	})
+})
+
+var _ = Describe("formatHostApprovalPolicy", func() {
+	It("clears a policy that leaves the hosts to the user", func() {
+		Expect(formatHostApprovalPolicy(nil)).To(BeNil())
+		Expect(formatHostApprovalPolicy(&models.HostApprovalPolicy{DefaultAction: "manual"})).To(BeNil())
+	})
+
+	It("formats the policy as json", func() {
+		policy := &models.HostApprovalPolicy{
+			Rules: []*models.HostApprovalRule{{
+				Name:        "lab",
+				Action:      swag.String("approve"),
+				MACRanges:   []string{"52:54:00"},
+				MinCPUCores: 4,
+			}},
+			DefaultAction: "quarantine",
+		}
+		formatted, err := formatHostApprovalPolicy(policy)
+		Expect(err).ToNot(HaveOccurred())
+		var parsed models.HostApprovalPolicy
+		Expect(json.Unmarshal([]byte(swag.StringValue(formatted)), &parsed)).To(Succeed())
+		Expect(&parsed).To(Equal(policy))
+	})
+
+	It("rejects an invalid policy", func() {
+		_, err := formatHostApprovalPolicy(&models.HostApprovalPolicy{
+			Rules: []*models.HostApprovalRule{{Action: swag.String("approve"), MACRanges: []string{"not a MAC"}}},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusBadRequest)))
+	})
+})
+
+var _ = Describe("formatFirmwareRequirements", func() {
+	It("clears empty requirements", func() {
+		Expect(formatFirmwareRequirements(nil, true)).To(BeNil())
+		Expect(formatFirmwareRequirements(&models.FirmwareRequirements{}, false)).To(BeNil())
+	})
+
+	It("formats the requirements as json", func() {
+		requirements := &models.FirmwareRequirements{
+			SecureBoot:   swag.Bool(true),
+			BiosVersions: []string{"2.19.*"},
+			BiosSettings: map[string]string{"ProcVirtualization": "Enabled"},
+			RaidLevels:   []string{"1"},
+		}
+		formatted, err := formatFirmwareRequirements(requirements, true)
+		Expect(err).ToNot(HaveOccurred())
+		var parsed models.FirmwareRequirements
+		Expect(json.Unmarshal([]byte(swag.StringValue(formatted)), &parsed)).To(Succeed())
+		Expect(&parsed).To(Equal(requirements))
+	})
+
+	It("rejects invalid requirements", func() {
+		_, err := formatFirmwareRequirements(&models.FirmwareRequirements{RaidLevels: []string{"4"}}, true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusBadRequest)))
+	})
+
+	It("rejects the requirements of the infra-envs that aren't managed by the kube API", func() {
+		_, err := formatFirmwareRequirements(&models.FirmwareRequirements{SecureBoot: swag.Bool(true)}, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusBadRequest)))
+	})
+})
+
+var _ = Describe("checkAgentTokenOfHost", func() {
+	var (
+		db         *gorm.DB
+		dbName     string
+		infraEnvID strfmt.UUID
+		hostID     strfmt.UUID
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		infraEnvID = strfmt.UUID(uuid.New().String())
+		hostID = strfmt.UUID(uuid.New().String())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	agentContext := func(scope *ocm.AgentTokenScope) context.Context {
+		payload := ocm.AdminPayload()
+		payload.AgentToken = scope
+		return context.WithValue(context.Background(), restapi.AuthKey, payload)
+	}
+
+	expectStatus := func(err error, statusCode int32) {
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.InfraErrorResponse).StatusCode()).To(Equal(statusCode))
+	}
+
+	It("accepts the tokens of the infra-env and of the host", func() {
+		Expect(checkAgentTokenOfHost(context.Background(), db, hostID)).To(Succeed())
+		Expect(checkAgentTokenOfHost(agentContext(&ocm.AgentTokenScope{InfraEnvID: infraEnvID.String()}), db, hostID)).To(Succeed())
+		Expect(checkAgentTokenOfHost(agentContext(&ocm.AgentTokenScope{ID: "token", InfraEnvID: infraEnvID.String(), HostID: hostID.String()}),
+			db, hostID)).To(Succeed())
+	})
+
+	It("rejects the token of another host", func() {
+		expectStatus(checkAgentTokenOfHost(agentContext(&ocm.AgentTokenScope{ID: "token", InfraEnvID: infraEnvID.String(), HostID: uuid.New().String()}),
+			db, hostID), http.StatusForbidden)
+	})
+
+	It("rejects the infra-env tokens once the tokens of the host were revoked", func() {
+		now := time.Now()
+		Expect(db.Create(&common.AgentToken{ID: uuid.New().String(), InfraEnvID: infraEnvID, HostID: hostID, CreatedAt: now, RevokedAt: &now}).Error).
+			ToNot(HaveOccurred())
+		expectStatus(checkAgentTokenOfHost(agentContext(&ocm.AgentTokenScope{InfraEnvID: infraEnvID.String()}), db, hostID), http.StatusUnauthorized)
+	})
+})
@@ -86,7 +86,7 @@ func (b *bareMetalInventory) V2RegisterDisconnectedCluster(ctx context.Context,
 		},
 	}
 
-	err := b.clusterApi.RegisterCluster(ctx, cluster)
+	err := b.clusterApi.RegisterCluster(ctx, cluster, b.db)
 	if err != nil {
 		return common.GenerateErrorResponder(common.NewApiError(http.StatusInternalServerError, err))
 	}
@@ -894,6 +894,10 @@ func (b *bareMetalInventory) GetInfraEnvDownloadURL(ctx context.Context, params
 		return common.GenerateErrorResponder(errors.Errorf("OS image entry '%+v' missing OpenshiftVersion field", osImage))
 	}
 
+	if err = b.quotaApi.RecordISODownload(ctx, infraEnv); err != nil {
+		return common.GenerateErrorResponder(err)
+	}
+
 	newURL, expiresAt, err := b.generateShortImageDownloadURL(infraEnv.ID.String(), string(*infraEnv.Type), *osImage.OpenshiftVersion, infraEnv.CPUArchitecture, infraEnv.ImageTokenKey)
 	if err != nil {
 		return common.GenerateErrorResponder(err)
@@ -914,7 +918,7 @@ func (b *bareMetalInventory) GetInfraEnvDownloadURL(ctx context.Context, params
 
 func (b *bareMetalInventory) generateShortImageDownloadURL(infraEnvID, imageType, version, arch, imageTokenKey string) (string, *strfmt.DateTime, error) {
 	switch b.authHandler.AuthType() {
-	case auth.TypeLocal:
+	case auth.TypeLocal, auth.TypeOIDC:
 		return b.generateShortImageDownloadURLByAPIKey(infraEnvID, imageType, version, arch)
 	case auth.TypeRHSSO:
 		return b.generateShortImageDownloadURLByToken(infraEnvID, imageType, version, arch, imageTokenKey)
@@ -961,7 +965,7 @@ func (b *bareMetalInventory) generateShortImageDownloadURLByID(infraEnvID, image
 func (b *bareMetalInventory) signURL(ctx context.Context, infraEnvID, urlString, imageTokenKey string) (string, error) {
 	log := logutil.FromContext(ctx, b.log)
 
-	if b.authHandler.AuthType() == auth.TypeLocal {
+	if b.authHandler.AuthType() == auth.TypeLocal || b.authHandler.AuthType() == auth.TypeOIDC {
 		var err error
 		urlString, err = gencrypto.SignURL(urlString, infraEnvID, gencrypto.InfraEnvKey)
 		if err != nil {
@@ -118,6 +118,20 @@ func (mr *MockInstallerInternalsMockRecorder) DeregisterInfraEnvInternal(ctx, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterInfraEnvInternal", reflect.TypeOf((*MockInstallerInternals)(nil).DeregisterInfraEnvInternal), ctx, params)
 }
 
+// FailHostMigrationInternal mocks base method.
+func (m *MockInstallerInternals) FailHostMigrationInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailHostMigrationInternal", ctx, infraEnvID, hostID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailHostMigrationInternal indicates an expected call of FailHostMigrationInternal.
+func (mr *MockInstallerInternalsMockRecorder) FailHostMigrationInternal(ctx, infraEnvID, hostID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailHostMigrationInternal", reflect.TypeOf((*MockInstallerInternals)(nil).FailHostMigrationInternal), ctx, infraEnvID, hostID, reason)
+}
+
 // GetClusterByKubeKey mocks base method.
 func (m *MockInstallerInternals) GetClusterByKubeKey(key types.NamespacedName) (*common.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -208,6 +222,21 @@ func (mr *MockInstallerInternalsMockRecorder) GetHostByIdInternal(ctx, hostId an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostByIdInternal", reflect.TypeOf((*MockInstallerInternals)(nil).GetHostByIdInternal), ctx, hostId)
 }
 
+// GetHostMigrationInternal mocks base method.
+func (m *MockInstallerInternals) GetHostMigrationInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID) (*common.HostMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostMigrationInternal", ctx, infraEnvID, hostID)
+	ret0, _ := ret[0].(*common.HostMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostMigrationInternal indicates an expected call of GetHostMigrationInternal.
+func (mr *MockInstallerInternalsMockRecorder) GetHostMigrationInternal(ctx, infraEnvID, hostID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostMigrationInternal", reflect.TypeOf((*MockInstallerInternals)(nil).GetHostMigrationInternal), ctx, infraEnvID, hostID)
+}
+
 // GetHostByKubeKey mocks base method.
 func (m *MockInstallerInternals) GetHostByKubeKey(key types.NamespacedName) (*common.Host, error) {
 	m.ctrl.T.Helper()
@@ -343,6 +372,21 @@ func (mr *MockInstallerInternalsMockRecorder) InstallSingleDay2HostInternal(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallSingleDay2HostInternal", reflect.TypeOf((*MockInstallerInternals)(nil).InstallSingleDay2HostInternal), ctx, clusterId, infraEnvId, hostId)
 }
 
+// IssueHostAgentTokenInternal mocks base method.
+func (m *MockInstallerInternals) IssueHostAgentTokenInternal(ctx context.Context, infraEnvID, hostID strfmt.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueHostAgentTokenInternal", ctx, infraEnvID, hostID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueHostAgentTokenInternal indicates an expected call of IssueHostAgentTokenInternal.
+func (mr *MockInstallerInternalsMockRecorder) IssueHostAgentTokenInternal(ctx, infraEnvID, hostID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueHostAgentTokenInternal", reflect.TypeOf((*MockInstallerInternals)(nil).IssueHostAgentTokenInternal), ctx, infraEnvID, hostID)
+}
+
 // RegisterClusterInternal mocks base method.
 func (m *MockInstallerInternals) RegisterClusterInternal(ctx context.Context, kubeKey *types.NamespacedName, mirrorRegistryConfiguration *common.MirrorRegistryConfiguration, params installer.V2RegisterClusterParams) (*common.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -387,6 +431,20 @@ func (mr *MockInstallerInternalsMockRecorder) SetIgnoredValidationsInternal(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIgnoredValidationsInternal", reflect.TypeOf((*MockInstallerInternals)(nil).SetIgnoredValidationsInternal), ctx, clusterID, ignoredClusterValidations, ignoredHostValidations)
 }
 
+// StartHostMigrationInternal mocks base method.
+func (m *MockInstallerInternals) StartHostMigrationInternal(ctx context.Context, migration *common.HostMigration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartHostMigrationInternal", ctx, migration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartHostMigrationInternal indicates an expected call of StartHostMigrationInternal.
+func (mr *MockInstallerInternalsMockRecorder) StartHostMigrationInternal(ctx, migration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartHostMigrationInternal", reflect.TypeOf((*MockInstallerInternals)(nil).StartHostMigrationInternal), ctx, migration)
+}
+
 // TransformClusterToDay2Internal mocks base method.
 func (m *MockInstallerInternals) TransformClusterToDay2Internal(ctx context.Context, clusterID strfmt.UUID) (*common.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -447,6 +505,20 @@ func (mr *MockInstallerInternalsMockRecorder) UpdateClusterNonInteractive(ctx, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterNonInteractive", reflect.TypeOf((*MockInstallerInternals)(nil).UpdateClusterNonInteractive), ctx, params, mirrorRegistryConfiguration)
 }
 
+// UpdateClusterSpokeHealthInternal mocks base method.
+func (m *MockInstallerInternals) UpdateClusterSpokeHealthInternal(ctx context.Context, clusterID strfmt.UUID, health *models.SpokeHealth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClusterSpokeHealthInternal", ctx, clusterID, health)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateClusterSpokeHealthInternal indicates an expected call of UpdateClusterSpokeHealthInternal.
+func (mr *MockInstallerInternalsMockRecorder) UpdateClusterSpokeHealthInternal(ctx, clusterID, health any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterSpokeHealthInternal", reflect.TypeOf((*MockInstallerInternals)(nil).UpdateClusterSpokeHealthInternal), ctx, clusterID, health)
+}
+
 // UpdateHostApprovedInternal mocks base method.
 func (m *MockInstallerInternals) UpdateHostApprovedInternal(ctx context.Context, infraEnvId, hostId string, approved bool) error {
 	m.ctrl.T.Helper()
@@ -461,6 +533,20 @@ func (mr *MockInstallerInternalsMockRecorder) UpdateHostApprovedInternal(ctx, in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHostApprovedInternal", reflect.TypeOf((*MockInstallerInternals)(nil).UpdateHostApprovedInternal), ctx, infraEnvId, hostId, approved)
 }
 
+// UpdateHostBmcInventoryInternal mocks base method.
+func (m *MockInstallerInternals) UpdateHostBmcInventoryInternal(ctx context.Context, infraEnvId, hostId string, bmcInventory *models.BmcInventory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHostBmcInventoryInternal", ctx, infraEnvId, hostId, bmcInventory)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHostBmcInventoryInternal indicates an expected call of UpdateHostBmcInventoryInternal.
+func (mr *MockInstallerInternalsMockRecorder) UpdateHostBmcInventoryInternal(ctx, infraEnvId, hostId, bmcInventory any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHostBmcInventoryInternal", reflect.TypeOf((*MockInstallerInternals)(nil).UpdateHostBmcInventoryInternal), ctx, infraEnvId, hostId, bmcInventory)
+}
+
 // UpdateInfraEnvInternal mocks base method.
 func (m *MockInstallerInternals) UpdateInfraEnvInternal(ctx context.Context, params installer.UpdateInfraEnvParams, internalIgnitionConfig *string, mirrorRegistryConfiguration *common.MirrorRegistryConfiguration) (*common.InfraEnv, error) {
 	m.ctrl.T.Helper()
@@ -20,6 +20,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/kennygrant/sanitize"
+	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
 	eventgen "github.com/openshift/assisted-service/internal/common/events"
 	"github.com/openshift/assisted-service/internal/constants"
@@ -78,8 +79,9 @@ var ClusterOwnerFileNames = []string{
 //go:generate mockgen -source=cluster.go -package=cluster -destination=mock_cluster_api.go
 
 type RegistrationAPI interface {
-	// Register a new cluster (handles all cluster types based on Kind field)
-	RegisterCluster(ctx context.Context, c *common.Cluster) error
+	// Register a new cluster (handles all cluster types based on Kind field) in the given DB, e.g. in
+	// the transaction that checked the quota of its owner
+	RegisterCluster(ctx context.Context, c *common.Cluster, db *gorm.DB) error
 	//deregister cluster
 	DeregisterCluster(ctx context.Context, c *common.Cluster) error
 }
@@ -220,7 +222,7 @@ func NewManager(cfg Config, log logrus.FieldLogger, db *gorm.DB, stream stream.N
 	uploadClient uploader.Client, hostAPI host.API, metricApi metrics.API, manifestsGeneratorAPI network.ManifestsGeneratorAPI,
 	leaderElector leader.Leader, operatorsApi operators.API, ocmClient *ocm.Client, objectHandler s3wrapper.API,
 	dnsApi dns.DNSApi, authHandler auth.Authenticator, manifestApi manifestsapi.ManifestsAPI, softTimeoutsEnabled bool,
-	usageApi usage.API) *Manager {
+	usageApi usage.API, pullSecretChecker validations.RegistryCredentialsChecker) *Manager {
 	th := &transitionHandler{
 		log:                 log,
 		db:                  db,
@@ -243,7 +245,7 @@ func NewManager(cfg Config, log logrus.FieldLogger, db *gorm.DB, stream stream.N
 		metricAPI:             metricApi,
 		manifestsGeneratorAPI: manifestsGeneratorAPI,
 		hostAPI:               hostAPI,
-		rp:                    newRefreshPreprocessor(log, hostAPI, operatorsApi, usageApi, eventsHandler, cfg.DisabledClusterValidations),
+		rp:                    newRefreshPreprocessor(log, hostAPI, operatorsApi, usageApi, eventsHandler, cfg.DisabledClusterValidations, pullSecretChecker),
 		leaderElector:         leaderElector,
 		prevMonitorInvokedAt:  time.Time{},
 		ocmClient:             ocmClient,
@@ -256,8 +258,8 @@ func NewManager(cfg Config, log logrus.FieldLogger, db *gorm.DB, stream stream.N
 	}
 }
 
-func (m *Manager) RegisterCluster(ctx context.Context, c *common.Cluster) error {
-	err := m.registrationAPI.RegisterCluster(ctx, c)
+func (m *Manager) RegisterCluster(ctx context.Context, c *common.Cluster, db *gorm.DB) error {
+	err := m.registrationAPI.RegisterCluster(ctx, c, db)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
 	eventsapi "github.com/openshift/assisted-service/internal/events/api"
 	"github.com/openshift/assisted-service/internal/host"
@@ -42,10 +43,12 @@ type refreshPreprocessor struct {
 }
 
 func newRefreshPreprocessor(log logrus.FieldLogger, hostAPI host.API, operatorsAPI operators.API, usageAPI usage.API,
-	eventsHandler eventsapi.Handler, disabledClusterValidations DisabledClusterValidations) *refreshPreprocessor {
+	eventsHandler eventsapi.Handler, disabledClusterValidations DisabledClusterValidations,
+	pullSecretChecker validations.RegistryCredentialsChecker) *refreshPreprocessor {
 	v := clusterValidator{
-		log:     log,
-		hostAPI: hostAPI,
+		log:               log,
+		hostAPI:           hostAPI,
+		pullSecretChecker: pullSecretChecker,
 	}
 
 	return &refreshPreprocessor{
@@ -205,6 +208,10 @@ func newValidations(v *clusterValidator) []validation {
 			id:        IsPullSecretSet,
 			condition: v.isPullSecretSet,
 		},
+		{
+			id:        IsPullSecretValid,
+			condition: v.isPullSecretValid,
+		},
 		{
 			id:        isClusterCidrDefined,
 			condition: v.isClusterCidrDefined,
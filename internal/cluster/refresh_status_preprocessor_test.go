@@ -50,6 +50,7 @@ var _ = Describe("Cluster Refresh Status Preprocessor", func() {
 			mockUsageApi,
 			nil,
 			DisabledClusterValidations{},
+			nil,
 		)
 	})
 
@@ -220,6 +221,7 @@ var _ = Describe("Cluster Refresh Status Preprocessor", func() {
 				mockUsageApi,
 				nil,
 				disabledValidations,
+				nil,
 			)
 
 			mockOperatorValidationsSuccess()
@@ -254,6 +256,7 @@ var _ = Describe("Cluster Refresh Status Preprocessor", func() {
 				mockUsageApi,
 				nil,
 				disabledValidations,
+				nil,
 			)
 
 			mockOperatorValidationsSuccess()
@@ -278,6 +281,7 @@ var _ = Describe("Cluster Refresh Status Preprocessor", func() {
 				mockUsageApi,
 				nil,
 				DisabledClusterValidations{},
+				nil,
 			)
 
 			mockOperatorValidationsSuccess()
@@ -305,6 +309,7 @@ var _ = Describe("Cluster Refresh Status Preprocessor", func() {
 				mockUsageApi,
 				nil,
 				disabledValidations,
+				nil,
 			)
 
 			mockOperatorValidationsSuccess()
@@ -339,6 +344,7 @@ var _ = Describe("Cluster Refresh Status Preprocessor", func() {
 				mockUsageApi,
 				nil,
 				disabledValidations,
+				nil,
 			)
 
 			mockOperatorValidationsSuccess()
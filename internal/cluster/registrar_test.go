@@ -38,7 +38,7 @@ var _ = Describe("registrar", func() {
 				Status: swag.String(models.ClusterStatusInsufficient),
 			}}
 
-			updateErr = registerManager.RegisterCluster(ctx, &cluster)
+			updateErr = registerManager.RegisterCluster(ctx, &cluster, db)
 			Expect(updateErr).Should(BeNil())
 			Expect(swag.StringValue(cluster.Status)).Should(Equal(models.ClusterStatusInsufficient))
 			cluster = getClusterFromDB(*cluster.ID, db)
@@ -46,7 +46,7 @@ var _ = Describe("registrar", func() {
 		})
 
 		It("register a registered cluster", func() {
-			updateErr = registerManager.RegisterCluster(ctx, &cluster)
+			updateErr = registerManager.RegisterCluster(ctx, &cluster, db)
 			Expect(updateErr).Should(HaveOccurred())
 
 			cluster = getClusterFromDB(*cluster.ID, db)
@@ -55,7 +55,7 @@ var _ = Describe("registrar", func() {
 
 		It("register a (soft) deleted cluster", func() {
 			Expect(db.Unscoped().Delete(&cluster).Error).ShouldNot(HaveOccurred())
-			updateErr = registerManager.RegisterCluster(ctx, &cluster)
+			updateErr = registerManager.RegisterCluster(ctx, &cluster, db)
 			Expect(updateErr).ShouldNot(HaveOccurred())
 
 			cluster = getClusterFromDB(*cluster.ID, db)
@@ -66,7 +66,7 @@ var _ = Describe("registrar", func() {
 			Expect(db.First(&common.Cluster{}, "id = ?", cluster.ID).RowsAffected).Should(Equal(int64(0)))
 			Expect(db.Unscoped().First(&common.Cluster{}, "id = ?", cluster.ID).RowsAffected).Should(Equal(int64(1)))
 
-			updateErr = registerManager.RegisterCluster(ctx, &cluster)
+			updateErr = registerManager.RegisterCluster(ctx, &cluster, db)
 			Expect(updateErr).ShouldNot(HaveOccurred())
 
 			cluster = getClusterFromDB(*cluster.ID, db)
@@ -82,7 +82,7 @@ var _ = Describe("registrar", func() {
 				Kind:   swag.String(models.ClusterKindDisconnectedCluster),
 			}}
 
-			updateErr = registerManager.RegisterCluster(ctx, &disconnectedCluster)
+			updateErr = registerManager.RegisterCluster(ctx, &disconnectedCluster, db)
 			Expect(updateErr).Should(BeNil())
 			Expect(swag.StringValue(disconnectedCluster.Status)).Should(Equal(models.ClusterStatusUnmonitored))
 
@@ -98,7 +98,7 @@ var _ = Describe("registrar", func() {
 				Kind:   swag.String(models.ClusterKindAddHostsCluster),
 			}}
 
-			updateErr = registerManager.RegisterCluster(ctx, &addHostsCluster)
+			updateErr = registerManager.RegisterCluster(ctx, &addHostsCluster, db)
 			Expect(updateErr).Should(BeNil())
 			Expect(swag.StringValue(addHostsCluster.Status)).Should(Equal(models.ClusterStatusAddingHosts))
 
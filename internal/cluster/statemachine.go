@@ -128,6 +128,7 @@ func NewClusterStateMachine(th TransitionHandler) stateswitch.StateMachine {
 		If(isNetworkTypeValid),
 		If(IsCustomManifestsRequirementsSatisfied),
 		If(NetworksSameAddressFamilies),
+		If(IsPullSecretValid),
 		If(IsNodeFeatureDiscoveryRequirementsSatisfied),
 		If(IsNvidiaGPURequirementsSatisfied),
 		If(IsPipelinesRequirementsSatisfied),
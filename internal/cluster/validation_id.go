@@ -28,6 +28,7 @@ const (
 	SufficientMastersCount                         = ValidationID(models.ClusterValidationIDSufficientMastersCount)
 	IsDNSDomainDefined                             = ValidationID(models.ClusterValidationIDDNSDomainDefined)
 	IsPullSecretSet                                = ValidationID(models.ClusterValidationIDPullSecretSet)
+	IsPullSecretValid                              = ValidationID(models.ClusterValidationIDPullSecretValid)
 	IsNtpServerConfigured                          = ValidationID(models.ClusterValidationIDNtpServerConfigured)
 	IsOdfRequirementsSatisfied                     = ValidationID(models.ClusterValidationIDOdfRequirementsSatisfied)
 	IsLsoRequirementsSatisfied                     = ValidationID(models.ClusterValidationIDLsoRequirementsSatisfied)
@@ -69,7 +70,7 @@ func (v ValidationID) Category() (string, error) {
 		return "network", nil
 	case AllHostsAreReadyToInstall, SufficientMastersCount:
 		return "hosts-data", nil
-	case IsPullSecretSet, PlatformRequirementsSatisfied:
+	case IsPullSecretSet, IsPullSecretValid, PlatformRequirementsSatisfied:
 		return "configuration", nil
 	case IsOdfRequirementsSatisfied,
 		IsLsoRequirementsSatisfied,
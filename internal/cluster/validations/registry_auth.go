@@ -0,0 +1,454 @@
+package validations
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/assisted-service/pkg/mirrorregistries"
+	"github.com/sirupsen/logrus"
+)
+
+// The statuses of the check of the credentials of a registry
+const (
+	RegistryAuthValid   = "valid"
+	RegistryAuthInvalid = "invalid"
+	// RegistryAuthMissing is the status of the mirror registries that require credentials that are
+	// missing from the pull secret
+	RegistryAuthMissing = "missing"
+	RegistryUnreachable = "unreachable"
+)
+
+const dockerHubAPIRegistry = "registry-1.docker.io"
+
+// RegistryCheckResult is the result of the authentication of the credentials of the pull secret
+// against one registry
+type RegistryCheckResult struct {
+	Registry string
+	Status   string
+	Message  string
+}
+
+// RegistryCredentialsChecker authenticates each credential of the pull secrets against its registry,
+// and the mirror registries with the credentials of the pull secret for them
+//
+//go:generate mockgen -source=registry_auth.go -package=validations -destination=mock_registry_auth.go
+type RegistryCredentialsChecker interface {
+	// CheckPullSecret authenticates the credentials of the pull secret, or returns the cached results
+	// of their last check
+	CheckPullSecret(ctx context.Context, pullSecret string) ([]RegistryCheckResult, error)
+	// CachedPullSecretResults returns the cached results of the last check of the credentials of the
+	// pull secret, or the error of the check, and starts a check in the background when they are
+	// missing or expired. It returns false while the first check is running.
+	CachedPullSecretResults(pullSecret string) ([]RegistryCheckResult, bool, error)
+}
+
+type registryCheckEntry struct {
+	results   []RegistryCheckResult
+	err       error
+	checkedAt time.Time
+	checking  bool
+}
+
+type registryCredentialsChecker struct {
+	cfg              Config
+	mirrorRegistries []mirrorregistries.RegistriesConf
+	// insecureRegistries are the mirror registries marked as insecure in registries.conf, they are
+	// reached with plain HTTP or without verifying their certificates
+	insecureRegistries map[string]bool
+	client             *http.Client
+	insecureClient     *http.Client
+	// publicOnly restricts the checks to the registries with public addresses, except for the mirror
+	// registries, so that the pull secrets can't be used to probe the network of the service
+	publicOnly bool
+	lookupIP   func(ctx context.Context, host string) ([]net.IPAddr, error)
+	log        logrus.FieldLogger
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*registryCheckEntry
+}
+
+// NewRegistryCredentialsChecker creates a checker that trusts the system certificate authorities and
+// the certificate authority of the mirror registries, if any. Only the mirror registries may have
+// private addresses.
+func NewRegistryCredentialsChecker(cfg Config, mirrorRegistries []mirrorregistries.RegistriesConf, insecureRegistries []string,
+	mirrorCA []byte, log logrus.FieldLogger) (RegistryCredentialsChecker, error) {
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if len(mirrorCA) > 0 && !rootCAs.AppendCertsFromPEM(mirrorCA) {
+		return nil, fmt.Errorf("failed to parse the certificate authority of the mirror registries")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	checker := newRegistryCredentialsChecker(cfg, mirrorRegistries, insecureRegistries, &http.Client{Transport: transport}, log)
+	checker.publicOnly = true
+	return checker, nil
+}
+
+func newRegistryCredentialsChecker(cfg Config, mirrorRegistries []mirrorregistries.RegistriesConf, insecureRegistries []string,
+	client *http.Client, log logrus.FieldLogger) *registryCredentialsChecker {
+	// The checks are bounded by their context, the redirections are followed to the token servers
+	client.Timeout = 0
+	insecureClient := &http.Client{Transport: client.Transport, CheckRedirect: client.CheckRedirect, Jar: client.Jar}
+	if transport, ok := client.Transport.(*http.Transport); ok {
+		insecureTransport := transport.Clone()
+		if insecureTransport.TLSClientConfig == nil {
+			insecureTransport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		insecureTransport.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // registries.conf marks the registry as insecure
+		insecureClient.Transport = insecureTransport
+	}
+	insecure := make(map[string]bool, len(insecureRegistries))
+	for _, registry := range insecureRegistries {
+		insecure[registryHost(registry)] = true
+	}
+	return &registryCredentialsChecker{
+		cfg:                cfg,
+		mirrorRegistries:   mirrorRegistries,
+		insecureRegistries: insecure,
+		client:             client,
+		insecureClient:     insecureClient,
+		lookupIP:           net.DefaultResolver.LookupIPAddr,
+		log:                log,
+		now:                time.Now,
+		cache:              make(map[string]*registryCheckEntry),
+	}
+}
+
+func pullSecretCacheKey(pullSecret string) string {
+	sum := sha256.Sum256([]byte(pullSecret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *registryCredentialsChecker) CheckPullSecret(ctx context.Context, pullSecret string) ([]RegistryCheckResult, error) {
+	key := pullSecretCacheKey(pullSecret)
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	if ok && !entry.checking && c.now().Sub(entry.checkedAt) < c.cfg.PullSecretValidationCacheTTL {
+		c.mu.Unlock()
+		return entry.results, entry.err
+	}
+	c.mu.Unlock()
+
+	results, err := c.check(ctx, pullSecret)
+	c.store(key, results, err)
+	return results, err
+}
+
+func (c *registryCredentialsChecker) CachedPullSecretResults(pullSecret string) ([]RegistryCheckResult, bool, error) {
+	key := pullSecretCacheKey(pullSecret)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeExpired()
+	entry, ok := c.cache[key]
+	if !ok {
+		entry = &registryCheckEntry{}
+		c.cache[key] = entry
+	}
+	if !entry.checking && (entry.checkedAt.IsZero() || c.now().Sub(entry.checkedAt) >= c.cfg.PullSecretValidationCacheTTL) {
+		entry.checking = true
+		go func() {
+			results, err := c.check(context.Background(), pullSecret)
+			if err != nil {
+				c.log.WithError(err).Warn("Failed to check the credentials of a pull secret")
+			}
+			c.store(key, results, err)
+		}()
+	}
+	if entry.checkedAt.IsZero() {
+		return nil, false, nil
+	}
+	return entry.results, true, entry.err
+}
+
+func (c *registryCredentialsChecker) store(key string, results []RegistryCheckResult, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache[key] = &registryCheckEntry{results: results, err: err, checkedAt: c.now()}
+}
+
+// removeExpired removes the results of the pull secrets that weren't checked for a while, e.g. of
+// the deleted clusters. It must be called with the lock held.
+func (c *registryCredentialsChecker) removeExpired() {
+	for key, entry := range c.cache {
+		if !entry.checking && !entry.checkedAt.IsZero() && c.now().Sub(entry.checkedAt) > 2*c.cfg.PullSecretValidationCacheTTL {
+			delete(c.cache, key)
+		}
+	}
+}
+
+// registryHost returns the host of a registry of the pull secret, e.g. quay.io for quay.io/org
+func registryHost(registry string) string {
+	if registry == dockerHubLegacyAuth || registry == dockerHubRegistry {
+		return dockerHubAPIRegistry
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	if slash := strings.Index(host, "/"); slash >= 0 {
+		host = host[:slash]
+	}
+	return host
+}
+
+// mirrorCredentials returns the credentials of the pull secret for the mirror, the credentials of
+// the longest matching path of the mirror take precedence
+func mirrorCredentials(mirror string, creds map[string]PullSecretCreds) (PullSecretCreds, bool) {
+	for registry := mirror; registry != ""; {
+		if cred, ok := creds[registry]; ok {
+			return cred, true
+		}
+		slash := strings.LastIndex(registry, "/")
+		if slash < 0 {
+			break
+		}
+		registry = registry[:slash]
+	}
+	return PullSecretCreds{}, false
+}
+
+func (c *registryCredentialsChecker) check(ctx context.Context, pullSecret string) ([]RegistryCheckResult, error) {
+	creds, err := ParsePullSecret(pullSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	type registryCheck struct {
+		registry string
+		cred     PullSecretCreds
+		hasCred  bool
+	}
+	mirrorHosts := map[string]bool{}
+	for _, mirrorRegistry := range c.mirrorRegistries {
+		for _, mirror := range mirrorRegistry.Mirror {
+			mirrorHosts[registryHost(mirror)] = true
+		}
+	}
+	checks := map[string]registryCheck{}
+	for registry, cred := range creds {
+		// The token of cloud.openshift.com authenticates to OCM, not to a registry
+		if registry == CloudOpenShiftCom {
+			continue
+		}
+		checks[registry] = registryCheck{registry: registry, cred: cred, hasCred: cred.AuthRaw != ""}
+	}
+	for _, mirrorRegistry := range c.mirrorRegistries {
+		for _, mirror := range mirrorRegistry.Mirror {
+			cred, hasCred := mirrorCredentials(mirror, creds)
+			if hasCred {
+				if _, ok := checks[cred.Registry]; ok {
+					continue
+				}
+			}
+			checks[mirror] = registryCheck{registry: mirror, cred: cred, hasCred: hasCred && cred.AuthRaw != ""}
+		}
+	}
+
+	results := make([]RegistryCheckResult, 0, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check registryCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.cfg.PullSecretValidationTimeout)
+			defer cancel()
+			host := registryHost(check.registry)
+			status, message := c.authenticate(checkCtx, host, check.cred, check.hasCred, mirrorHosts[host])
+			mu.Lock()
+			results = append(results, RegistryCheckResult{Registry: check.registry, Status: status, Message: message})
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Registry < results[j].Registry
+	})
+	return results, nil
+}
+
+// errPrivateAddress is returned for the registries and the token servers that aren't reachable at a
+// public address
+var errPrivateAddress = fmt.Errorf("the address of the host is not public, only the mirror registries may be private")
+
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// checkPublic fails unless all the addresses of the host of the URL are public
+func (c *registryCredentialsChecker) checkPublic(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	addresses, err := c.lookupIP(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		if !isPublicIP(address.IP) {
+			return errPrivateAddress
+		}
+	}
+	return nil
+}
+
+func (c *registryCredentialsChecker) get(ctx context.Context, client *http.Client, url string, cred PullSecretCreds, hasCred, trusted bool) (*http.Response, error) {
+	if c.publicOnly && !trusted {
+		if err := c.checkPublic(ctx, url); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hasCred {
+		req.SetBasicAuth(cred.Username, cred.Password)
+	}
+	// The token servers are reached by redirection as well
+	if c.publicOnly && !trusted {
+		client = c.publicOnlyClient(client)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// publicOnlyClient returns a client that doesn't follow the redirections to private addresses
+func (c *registryCredentialsChecker) publicOnlyClient(client *http.Client) *http.Client {
+	restricted := *client
+	restricted.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := c.checkPublic(req.Context(), req.URL.String()); err != nil {
+			return err
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &restricted
+}
+
+// ping returns the response of the registry API version check. The insecure registries are reached
+// like the container runtimes do: with HTTPS without verifying their certificate, or with plain HTTP.
+func (c *registryCredentialsChecker) ping(ctx context.Context, host string, trusted bool) (*http.Client, string, *http.Response, error) {
+	pingURL := fmt.Sprintf("https://%s/v2/", host)
+	if !c.insecureRegistries[host] {
+		resp, err := c.get(ctx, c.client, pingURL, PullSecretCreds{}, false, trusted)
+		return c.client, pingURL, resp, err
+	}
+	resp, err := c.get(ctx, c.insecureClient, pingURL, PullSecretCreds{}, false, trusted)
+	if err != nil && ctx.Err() == nil {
+		pingURL = fmt.Sprintf("http://%s/v2/", host)
+		resp, err = c.get(ctx, c.insecureClient, pingURL, PullSecretCreds{}, false, trusted)
+	}
+	return c.insecureClient, pingURL, resp, err
+}
+
+// authenticate authenticates the credentials against the registry like the container runtimes do:
+// the registry API version check returns the authentication challenge of the registry, either the
+// basic authentication or a token server. The token servers of the trusted registries, i.e. of the
+// mirror registries, may have private addresses as well.
+func (c *registryCredentialsChecker) authenticate(ctx context.Context, host string, cred PullSecretCreds, hasCred, trusted bool) (string, string) {
+	client, pingURL, resp, err := c.ping(ctx, host, trusted)
+	if errors.Is(err, errPrivateAddress) {
+		return RegistryUnreachable, errPrivateAddress.Error()
+	}
+	if err != nil {
+		return RegistryUnreachable, fmt.Sprintf("failed to reach the registry: %s", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return RegistryAuthValid, "the registry doesn't require authentication"
+	case http.StatusUnauthorized:
+	default:
+		return RegistryUnreachable, fmt.Sprintf("unexpected status %d of the registry", resp.StatusCode)
+	}
+	if !hasCred {
+		return RegistryAuthMissing, "the registry requires credentials that are missing from the pull secret"
+	}
+
+	scheme, params := parseChallenge(resp.Header.Get("WWW-Authenticate"))
+	authURL := pingURL
+	if scheme == "bearer" {
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return RegistryUnreachable, "the registry returned an invalid authentication challenge"
+		}
+		if service, ok := params["service"]; ok {
+			query := realm.Query()
+			query.Set("service", service)
+			realm.RawQuery = query.Encode()
+		}
+		authURL = realm.String()
+	}
+	resp, err = c.get(ctx, client, authURL, cred, true, trusted)
+	if errors.Is(err, errPrivateAddress) {
+		return RegistryUnreachable, fmt.Sprintf("the authentication server of the registry is not reachable: %s", errPrivateAddress)
+	}
+	if err != nil {
+		return RegistryUnreachable, fmt.Sprintf("failed to reach the authentication server of the registry: %s", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return RegistryAuthValid, "the credentials are valid"
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return RegistryAuthInvalid, fmt.Sprintf("the registry rejected the credentials of user %s", cred.Username)
+	}
+	return RegistryUnreachable, fmt.Sprintf("unexpected status %d of the authentication server of the registry", resp.StatusCode)
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth.example.com/token",service="registry"
+func parseChallenge(header string) (string, map[string]string) {
+	params := map[string]string{}
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		name, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[name] = value[1:]
+				break
+			}
+			params[name] = value[1 : end+1]
+			rest = strings.TrimPrefix(strings.TrimSpace(value[end+2:]), ",")
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[name] = strings.TrimSpace(value)
+		}
+	}
+	return strings.ToLower(scheme), params
+}
+
+// FormatRegistryCheckResults describes the results of the checks of the registries
+func FormatRegistryCheckResults(results []RegistryCheckResult) string {
+	descriptions := make([]string, 0, len(results))
+	for _, result := range results {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s (%s)", result.Registry, result.Status, result.Message))
+	}
+	return strings.Join(descriptions, "; ")
+}
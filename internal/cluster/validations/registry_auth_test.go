@@ -0,0 +1,287 @@
+package validations
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/pkg/mirrorregistries"
+	"github.com/sirupsen/logrus"
+)
+
+// testRegistry is a registry that authenticates a single user, either with the basic authentication
+// or with a token server
+type testRegistry struct {
+	server   *httptest.Server
+	bearer   bool
+	username string
+	password string
+	pings    int32
+}
+
+func newTestRegistry(bearer bool) *testRegistry {
+	return newTestRegistryServer(bearer, true)
+}
+
+func newTestRegistryServer(bearer, secure bool) *testRegistry {
+	r := &testRegistry{bearer: bearer, username: "registry-user", password: "registry-password"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&r.pings, 1)
+		if !r.bearer {
+			if username, password, ok := req.BasicAuth(); ok && username == r.username && password == r.password {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+		} else {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry"`, r.server.URL))
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		username, password, ok := req.BasicAuth()
+		if req.URL.Query().Get("service") != "test-registry" || !ok || username != r.username || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"token"}`))
+	})
+	if secure {
+		r.server = httptest.NewTLSServer(mux)
+	} else {
+		r.server = httptest.NewServer(mux)
+	}
+	return r
+}
+
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(strings.TrimPrefix(r.server.URL, "https://"), "http://")
+}
+
+func registryAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+func pullSecretOf(auths map[string]string) string {
+	entries := make([]string, 0, len(auths))
+	for registry, auth := range auths {
+		entries = append(entries, fmt.Sprintf(`"%s":{"auth":"%s","email":"r@r.com"}`, registry, auth))
+	}
+	return fmt.Sprintf(`{"auths":{%s}}`, strings.Join(entries, ","))
+}
+
+var _ = Describe("RegistryCredentialsChecker", func() {
+	var (
+		basicRegistry  *testRegistry
+		bearerRegistry *testRegistry
+		cfg            Config
+	)
+
+	BeforeEach(func() {
+		basicRegistry = newTestRegistry(false)
+		bearerRegistry = newTestRegistry(true)
+		cfg = Config{
+			DeepPullSecretValidation:     true,
+			PullSecretValidationTimeout:  5 * time.Second,
+			PullSecretValidationCacheTTL: time.Minute,
+		}
+	})
+
+	AfterEach(func() {
+		basicRegistry.server.Close()
+		bearerRegistry.server.Close()
+	})
+
+	newChecker := func(mirrors []mirrorregistries.RegistriesConf) *registryCredentialsChecker {
+		return newRegistryCredentialsChecker(cfg, mirrors, nil, basicRegistry.server.Client(), logrus.New())
+	}
+
+	It("authenticates the credentials with the basic authentication and with a token server", func() {
+		pullSecret := pullSecretOf(map[string]string{
+			basicRegistry.host():  registryAuth("registry-user", "registry-password"),
+			bearerRegistry.host(): registryAuth("registry-user", "registry-password"),
+			CloudOpenShiftCom:     registryAuth("ocm", "token"),
+		})
+		results, err := newChecker(nil).CheckPullSecret(context.Background(), pullSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		for _, result := range results {
+			Expect(result.Status).To(Equal(RegistryAuthValid), result.Message)
+		}
+	})
+
+	It("reports the rejected credentials", func() {
+		pullSecret := pullSecretOf(map[string]string{
+			basicRegistry.host():  registryAuth("registry-user", "wrong"),
+			bearerRegistry.host(): registryAuth("someone-else", "registry-password"),
+		})
+		results, err := newChecker(nil).CheckPullSecret(context.Background(), pullSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		for _, result := range results {
+			Expect(result.Status).To(Equal(RegistryAuthInvalid))
+		}
+	})
+
+	It("reports the unreachable registries", func() {
+		unreachable := newTestRegistry(false)
+		unreachable.server.Close()
+		cfg.PullSecretValidationTimeout = time.Second
+		results, err := newChecker(nil).CheckPullSecret(context.Background(), pullSecretOf(map[string]string{
+			unreachable.host(): registryAuth("registry-user", "registry-password"),
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(ConsistOf(HaveField("Status", RegistryUnreachable)))
+	})
+
+	It("checks the mirror registries with the credentials of their longest matching path", func() {
+		mirrors := []mirrorregistries.RegistriesConf{
+			{Location: "quay.io/openshift-release-dev", Mirror: []string{basicRegistry.host() + "/ocp/release"}},
+			{Location: "registry.redhat.io", Mirror: []string{bearerRegistry.host() + "/redhat"}},
+		}
+		pullSecret := pullSecretOf(map[string]string{
+			basicRegistry.host():          registryAuth("registry-user", "wrong"),
+			basicRegistry.host() + "/ocp": registryAuth("registry-user", "registry-password"),
+		})
+		cred, ok := mirrorCredentials(basicRegistry.host()+"/ocp/release", mustParsePullSecret(pullSecret))
+		Expect(ok).To(BeTrue())
+		Expect(cred.Registry).To(Equal(basicRegistry.host() + "/ocp"))
+
+		results, err := newChecker(mirrors).CheckPullSecret(context.Background(), pullSecretOf(map[string]string{
+			basicRegistry.host() + "/ocp": registryAuth("registry-user", "registry-password"),
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(ConsistOf(
+			RegistryCheckResult{Registry: basicRegistry.host() + "/ocp", Status: RegistryAuthValid, Message: "the credentials are valid"},
+			And(HaveField("Registry", bearerRegistry.host()+"/redhat"), HaveField("Status", RegistryAuthMissing)),
+		))
+	})
+
+	It("caches the results of the checks", func() {
+		checker := newChecker(nil)
+		now := time.Now()
+		checker.now = func() time.Time { return now }
+		pullSecret := pullSecretOf(map[string]string{
+			basicRegistry.host(): registryAuth("registry-user", "registry-password"),
+		})
+
+		_, checked, err := checker.CachedPullSecretResults(pullSecret)
+		Expect(checked).To(BeFalse())
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			_, checked, err = checker.CachedPullSecretResults(pullSecret)
+			return checked
+		}).Should(BeTrue())
+		Expect(err).NotTo(HaveOccurred())
+		pings := atomic.LoadInt32(&basicRegistry.pings)
+		Expect(pings).To(BeEquivalentTo(2))
+
+		results, err := checker.CheckPullSecret(context.Background(), pullSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(ConsistOf(HaveField("Status", RegistryAuthValid)))
+		Expect(atomic.LoadInt32(&basicRegistry.pings)).To(Equal(pings))
+
+		now = now.Add(2 * time.Minute)
+		_, err = checker.CheckPullSecret(context.Background(), pullSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(atomic.LoadInt32(&basicRegistry.pings)).To(Equal(pings + 2))
+	})
+
+	It("fails on an invalid pull secret", func() {
+		_, err := newChecker(nil).CheckPullSecret(context.Background(), "not a pull secret")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns the error of the cached check", func() {
+		checker := newChecker(nil)
+		var checked bool
+		var err error
+		Eventually(func() bool {
+			_, checked, err = checker.CachedPullSecretResults("not a pull secret")
+			return checked
+		}).Should(BeTrue())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("only checks the registries with a public address, except for the mirror registries", func() {
+		checker := newChecker([]mirrorregistries.RegistriesConf{
+			{Location: "registry.redhat.io", Mirror: []string{bearerRegistry.host() + "/redhat"}},
+		})
+		checker.publicOnly = true
+		results, err := checker.CheckPullSecret(context.Background(), pullSecretOf(map[string]string{
+			basicRegistry.host():  registryAuth("registry-user", "registry-password"),
+			bearerRegistry.host(): registryAuth("registry-user", "registry-password"),
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(ConsistOf(
+			RegistryCheckResult{Registry: basicRegistry.host(), Status: RegistryUnreachable, Message: errPrivateAddress.Error()},
+			RegistryCheckResult{Registry: bearerRegistry.host(), Status: RegistryAuthValid, Message: "the credentials are valid"},
+		))
+		Expect(atomic.LoadInt32(&basicRegistry.pings)).To(BeZero())
+	})
+
+	It("reaches the insecure registries with plain HTTP or without verifying their certificate", func() {
+		plainRegistry := newTestRegistryServer(false, false)
+		defer plainRegistry.server.Close()
+		mirrors := []mirrorregistries.RegistriesConf{
+			{Location: "quay.io/openshift-release-dev", Mirror: []string{plainRegistry.host() + "/ocp"}},
+			{Location: "registry.redhat.io", Mirror: []string{bearerRegistry.host() + "/redhat"}},
+		}
+		pullSecret := pullSecretOf(map[string]string{
+			plainRegistry.host():  registryAuth("registry-user", "registry-password"),
+			bearerRegistry.host(): registryAuth("registry-user", "registry-password"),
+		})
+		// The certificate of the test registries isn't trusted
+		client := &http.Client{Transport: &http.Transport{}}
+
+		results, err := newRegistryCredentialsChecker(cfg, mirrors, nil, client, logrus.New()).CheckPullSecret(context.Background(), pullSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(ConsistOf(HaveField("Status", RegistryUnreachable), HaveField("Status", RegistryUnreachable)))
+
+		insecure := []string{plainRegistry.host() + "/ocp", bearerRegistry.host() + "/redhat"}
+		results, err = newRegistryCredentialsChecker(cfg, mirrors, insecure, client, logrus.New()).CheckPullSecret(context.Background(), pullSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(ConsistOf(HaveField("Status", RegistryAuthValid), HaveField("Status", RegistryAuthValid)))
+	})
+})
+
+func mustParsePullSecret(pullSecret string) map[string]PullSecretCreds {
+	creds, err := ParsePullSecret(pullSecret)
+	Expect(err).NotTo(HaveOccurred())
+	return creds
+}
+
+var _ = Describe("parseChallenge", func() {
+	It("parses the bearer challenges", func() {
+		scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull"`)
+		Expect(scheme).To(Equal("bearer"))
+		Expect(params).To(Equal(map[string]string{
+			"realm":   "https://auth.example.com/token",
+			"service": "registry.example.com",
+			"scope":   "repository:a/b:pull",
+		}))
+	})
+
+	It("parses the basic challenges", func() {
+		scheme, params := parseChallenge(`Basic realm=registry`)
+		Expect(scheme).To(Equal("basic"))
+		Expect(params).To(Equal(map[string]string{"realm": "registry"}))
+	})
+})
+
+var _ = Describe("registryHost", func() {
+	It("returns the API host of the registries", func() {
+		Expect(registryHost("quay.io/org/repo")).To(Equal("quay.io"))
+		Expect(registryHost("docker.io")).To(Equal(dockerHubAPIRegistry))
+		Expect(registryHost(dockerHubLegacyAuth)).To(Equal(dockerHubAPIRegistry))
+		Expect(registryHost("https://registry.example.com:5000/v1/")).To(Equal("registry.example.com:5000"))
+	})
+})
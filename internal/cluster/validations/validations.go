@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/distribution/reference"
 	"github.com/go-openapi/strfmt"
@@ -29,6 +30,11 @@ import (
 
 type Config struct {
 	PublicRegistries string `envconfig:"PUBLIC_CONTAINER_REGISTRIES" default:""`
+	// DeepPullSecretValidation enables the authentication of the credentials of the pull secrets
+	// against their registries, and against the mirror registries
+	DeepPullSecretValidation     bool          `envconfig:"PULL_SECRET_DEEP_VALIDATION" default:"false"`
+	PullSecretValidationTimeout  time.Duration `envconfig:"PULL_SECRET_VALIDATION_TIMEOUT" default:"10s"`
+	PullSecretValidationCacheTTL time.Duration `envconfig:"PULL_SECRET_VALIDATION_CACHE_TTL" default:"30m"`
 }
 
 const (
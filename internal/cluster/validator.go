@@ -10,6 +10,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/hashicorp/go-multierror"
+	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/host"
 	"github.com/openshift/assisted-service/internal/installcfg"
@@ -82,6 +83,9 @@ func validationStatusToBool(v ValidationStatus) bool {
 type clusterValidator struct {
 	log     logrus.FieldLogger
 	hostAPI host.API
+	// pullSecretChecker authenticates the credentials of the pull secrets, it's nil unless the deep
+	// validation of the pull secrets is enabled
+	pullSecretChecker validations.RegistryCredentialsChecker
 }
 
 func (v *clusterValidator) isMachineCidrDefined(c *clusterPreprocessContext) (ValidationStatus, string) {
@@ -651,6 +655,41 @@ func (v *clusterValidator) isPullSecretSet(c *clusterPreprocessContext) (Validat
 	return ValidationFailure, "The pull secret is not set."
 }
 
+func (v *clusterValidator) isPullSecretValid(c *clusterPreprocessContext) (ValidationStatus, string) {
+	if v.pullSecretChecker == nil {
+		return ValidationSuccess, "The deep validation of the pull secret is disabled."
+	}
+	if !c.cluster.PullSecretSet || c.cluster.PullSecret == "" {
+		return ValidationPending, "The pull secret is not set."
+	}
+	results, checked, err := v.pullSecretChecker.CachedPullSecretResults(c.cluster.PullSecret)
+	if !checked {
+		return ValidationPending, "The credentials of the pull secret are being checked against their registries."
+	}
+	if err != nil {
+		return ValidationFailure, fmt.Sprintf("The credentials of the pull secret couldn't be checked: %s.", err)
+	}
+	var failed, unreachable []validations.RegistryCheckResult
+	for _, result := range results {
+		switch result.Status {
+		case validations.RegistryAuthInvalid, validations.RegistryAuthMissing:
+			failed = append(failed, result)
+		case validations.RegistryUnreachable:
+			unreachable = append(unreachable, result)
+		}
+	}
+	if len(failed) > 0 {
+		return ValidationFailure, fmt.Sprintf("The pull secret failed to authenticate to some of the registries: %s.",
+			validations.FormatRegistryCheckResults(failed))
+	}
+	// The service may not reach registries that the hosts can reach, the hosts will report them
+	if len(unreachable) > 0 {
+		return ValidationSuccess, fmt.Sprintf("The pull secret authenticated to the reachable registries, these registries couldn't be reached by the service: %s.",
+			validations.FormatRegistryCheckResults(unreachable))
+	}
+	return ValidationSuccess, "The pull secret authenticated to all the registries."
+}
+
 func (v *clusterValidator) networkPrefixValid(c *clusterPreprocessContext) (ValidationStatus, string) {
 	var clusterCidrDefined ValidationStatus
 	clusterCidrDefined, _ = v.isClusterCidrDefined(c)
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/host"
 	"github.com/openshift/assisted-service/internal/testing"
@@ -1532,3 +1533,92 @@ var _ = Describe("isMachineCidrEqualsToCalculatedCidr", func() {
 		})
 	})
 })
+
+var _ = Describe("isPullSecretValid", func() {
+	var (
+		validator         clusterValidator
+		mockChecker       *validations.MockRegistryCredentialsChecker
+		ctrl              *gomock.Controller
+		preprocessContext *clusterPreprocessContext
+	)
+
+	const pullSecret = `{"auths":{"registry.example.com":{"auth":"dXNlcjpwYXNzd29yZA==","email":"r@r.com"}}}`
+
+	BeforeEach(func() {
+		clusterID := strfmt.UUID(uuid.New().String())
+		ctrl = gomock.NewController(GinkgoT())
+		mockChecker = validations.NewMockRegistryCredentialsChecker(ctrl)
+		validator = clusterValidator{log: logrus.New(), pullSecretChecker: mockChecker}
+		preprocessContext = &clusterPreprocessContext{
+			clusterId: clusterID,
+			cluster: &common.Cluster{
+				Cluster:    models.Cluster{ID: &clusterID, PullSecretSet: true},
+				PullSecret: pullSecret,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("succeeds when the deep validation is disabled", func() {
+		validator.pullSecretChecker = nil
+		status, message := validator.isPullSecretValid(preprocessContext)
+		Expect(status).To(Equal(ValidationSuccess))
+		Expect(message).To(Equal("The deep validation of the pull secret is disabled."))
+	})
+
+	It("is pending until the pull secret is set", func() {
+		preprocessContext.cluster.PullSecretSet = false
+		preprocessContext.cluster.PullSecret = ""
+		status, _ := validator.isPullSecretValid(preprocessContext)
+		Expect(status).To(Equal(ValidationPending))
+	})
+
+	It("is pending while the credentials are checked", func() {
+		mockChecker.EXPECT().CachedPullSecretResults(pullSecret).Return(nil, false, nil)
+		status, message := validator.isPullSecretValid(preprocessContext)
+		Expect(status).To(Equal(ValidationPending))
+		Expect(message).To(ContainSubstring("being checked"))
+	})
+
+	It("fails when the credentials couldn't be checked", func() {
+		mockChecker.EXPECT().CachedPullSecretResults(pullSecret).Return(nil, true, fmt.Errorf("invalid pull secret"))
+		status, message := validator.isPullSecretValid(preprocessContext)
+		Expect(status).To(Equal(ValidationFailure))
+		Expect(message).To(ContainSubstring("invalid pull secret"))
+	})
+
+	It("fails on the rejected and the missing credentials", func() {
+		mockChecker.EXPECT().CachedPullSecretResults(pullSecret).Return([]validations.RegistryCheckResult{
+			{Registry: "registry.example.com", Status: validations.RegistryAuthInvalid, Message: "the registry rejected the credentials of user user"},
+			{Registry: "mirror.example.com/ocp", Status: validations.RegistryAuthMissing, Message: "the registry requires credentials that are missing from the pull secret"},
+			{Registry: "quay.io", Status: validations.RegistryAuthValid, Message: "the credentials are valid"},
+		}, true, nil)
+		status, message := validator.isPullSecretValid(preprocessContext)
+		Expect(status).To(Equal(ValidationFailure))
+		Expect(message).To(ContainSubstring("registry.example.com: invalid"))
+		Expect(message).To(ContainSubstring("mirror.example.com/ocp: missing"))
+		Expect(message).NotTo(ContainSubstring("quay.io"))
+	})
+
+	It("reports the unreachable registries without failing", func() {
+		mockChecker.EXPECT().CachedPullSecretResults(pullSecret).Return([]validations.RegistryCheckResult{
+			{Registry: "registry.example.com", Status: validations.RegistryUnreachable, Message: "failed to reach the registry"},
+			{Registry: "quay.io", Status: validations.RegistryAuthValid, Message: "the credentials are valid"},
+		}, true, nil)
+		status, message := validator.isPullSecretValid(preprocessContext)
+		Expect(status).To(Equal(ValidationSuccess))
+		Expect(message).To(ContainSubstring("registry.example.com: unreachable"))
+	})
+
+	It("succeeds when all the credentials are valid", func() {
+		mockChecker.EXPECT().CachedPullSecretResults(pullSecret).Return([]validations.RegistryCheckResult{
+			{Registry: "quay.io", Status: validations.RegistryAuthValid, Message: "the credentials are valid"},
+		}, true, nil)
+		status, message := validator.isPullSecretValid(preprocessContext)
+		Expect(status).To(Equal(ValidationSuccess))
+		Expect(message).To(Equal("The pull secret authenticated to all the registries."))
+	})
+})
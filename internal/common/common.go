@@ -584,14 +584,26 @@ type V2GetEventsParams struct {
 	   Cluster level events flag.
 	*/
 	ClusterLevel *bool
+	/*
+	   Cursor returned with the previous page, used instead of the offset.
+	*/
+	Cursor *string
 	/*
 	   Deleted hosts flag.
 	*/
 	DeletedHosts *bool
+	/*
+	   Only retrieve the events that occurred before this time.
+	*/
+	EndTime *strfmt.DateTime
 	/*
 	   Hosts in the specified cluster to return events for.
 	*/
 	HostIds []strfmt.UUID
+	/*
+	   Also return the events that were archived by the retention policy.
+	*/
+	IncludeArchived *bool
 	/*
 	   The infra-env to return events for.
 	   Format: uuid
@@ -605,6 +617,10 @@ type V2GetEventsParams struct {
 	   Retrieved events message pattern.
 	*/
 	Message *string
+	/*
+	   Retrieved events names.
+	*/
+	Names []string
 	/*
 	   Number of records to skip before starting to return the records.
 	*/
@@ -615,10 +631,26 @@ type V2GetEventsParams struct {
 	   Default: "ascending"
 	*/
 	Order *string
+	/*
+	   A JSON object that the properties of the retrieved events must contain.
+	*/
+	Props *string
+	/*
+	   Only retrieve the events caused by this request.
+	*/
+	RequestID *strfmt.UUID
+	/*
+	   Full-text search over the messages of the retrieved events.
+	*/
+	Search *string
 	/*
 	   Retrieved events severities.
 	*/
 	Severities []string
+	/*
+	   Only retrieve the events that occurred at or after this time.
+	*/
+	StartTime *strfmt.DateTime
 }
 
 type V2GetEventsResponse struct {
@@ -626,6 +658,8 @@ type V2GetEventsResponse struct {
 	Events             []*Event
 	EventSeverityCount *EventSeverityCount
 	EventCount         *int64
+	/*Cursor of the next page, empty when there are no more events*/
+	NextCursor string
 }
 
 func (r V2GetEventsResponse) GetEvents() []*Event {
@@ -640,6 +674,50 @@ func (r V2GetEventsResponse) GetEventCount() *int64 {
 	return r.EventCount
 }
 
+func (r V2GetEventsResponse) GetNextCursor() string {
+	return r.NextCursor
+}
+
+type V2AggregateEventsParams struct {
+	/*
+	   A comma-separated list of event categories.
+	*/
+	Categories []string
+	/*
+	   The cluster to aggregate events for.
+	*/
+	ClusterID *strfmt.UUID
+	/*
+	   Only count the events that occurred before this time.
+	*/
+	EndTime *strfmt.DateTime
+	/*
+	   Fields to group the events by in each time bucket.
+	*/
+	GroupBy []string
+	/*
+	   The infra-env to aggregate events for.
+	*/
+	InfraEnvID *strfmt.UUID
+	/*
+	   Size of the time buckets.
+	   Default: "hour"
+	*/
+	Interval *string
+	/*
+	   Only count the events with these names.
+	*/
+	Names []string
+	/*
+	   Only count the events with these severities.
+	*/
+	Severities []string
+	/*
+	   Only count the events that occurred at or after this time.
+	*/
+	StartTime *strfmt.DateTime
+}
+
 func GetDefaultV2GetEventsParams(clusterID *strfmt.UUID, hostIds []strfmt.UUID, infraEnvID *strfmt.UUID, categories ...string) *V2GetEventsParams {
 	selectedCategories := make([]string, 0)
 	if len(categories) > 0 {
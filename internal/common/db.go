@@ -239,6 +239,12 @@ type InfraEnv struct {
 
 	// A JSON blob in which holds a mirror registry configurations if set
 	MirrorRegistryConfiguration string `gorm:"type:TEXT"`
+
+	// The agent tokens issued before the last rotation are only accepted until the end of its overlap,
+	// and the ones issued before the previous rotation are no longer accepted
+	AgentTokensRotatedAt         *time.Time `json:"-"`
+	AgentTokensPreviousRotatedAt *time.Time `json:"-"`
+	AgentTokensOverlapEndsAt     *time.Time `json:"-"`
 }
 
 func (i *InfraEnv) GetClusterID() *strfmt.UUID {
@@ -282,6 +288,80 @@ func (i *InfraEnv) SetMirrorRegistryConfiguration(configuration *MirrorRegistryC
 	return nil
 }
 
+type APIToken struct {
+	models.APIToken
+	// SHA-256 hash of the secret of the token, the secret itself is only returned when the token is created
+	TokenHash string `gorm:"uniqueIndex"`
+}
+
+// ISODownload is a download URL of the ISO of an infra-env issued to a tenant, they are only kept for
+// the period of the ISO downloads quota
+type ISODownload struct {
+	ID         uint        `gorm:"primaryKey"`
+	InfraEnvID strfmt.UUID `gorm:"type:varchar(36)"`
+	OrgID      string      `gorm:"index"`
+	UserName   string      `gorm:"index"`
+	CreatedAt  time.Time   `gorm:"type:timestamp with time zone;index"`
+}
+
+// AgentToken is an agent token issued to a host by the local authentication. The token itself isn't
+// kept, the ID is its jti claim.
+type AgentToken struct {
+	ID         string      `gorm:"primaryKey"`
+	InfraEnvID strfmt.UUID `gorm:"type:varchar(36);index"`
+	HostID     strfmt.UUID `gorm:"type:varchar(36);index"`
+	CreatedAt  time.Time
+	// Set when the token is superseded by a newer one, to the end of the overlap of the rotation
+	ExpiresAt *time.Time
+	// Set when the agent confirmed that it uses the token
+	DeliveredAt *time.Time
+	RevokedAt   *time.Time
+}
+
+// HostAttestation is the TPM attestation of a host registered to an infra-env with an attestation
+// policy. The host is pending until it's attested, the challenge is cleared once the agent answered it.
+type HostAttestation struct {
+	HostID        strfmt.UUID `gorm:"primaryKey;type:varchar(36)"`
+	InfraEnvID    strfmt.UUID `gorm:"primaryKey;type:varchar(36)"`
+	EKCertificate string      `gorm:"type:text"`
+	// Base64 encoded TPMT_PUBLIC of the attestation key
+	AKPublic        string `gorm:"type:text"`
+	Nonce           string
+	CredentialBlob  string `gorm:"type:text"`
+	EncryptedSecret string `gorm:"type:text"`
+	// The secret of the credential is only kept as its SHA-256 digest
+	SecretDigest string
+	ChallengedAt *time.Time
+	AttestedAt   *time.Time
+	// The reason of the last failed attestation
+	Error     string `gorm:"type:text"`
+	UpdatedAt time.Time
+}
+
+// HostMigration is the migration of an unbound host to the infra-env of a peer hub. The agent is
+// configured to reach the peer service with its next steps, the token is cleared once the agent
+// replied.
+type HostMigration struct {
+	HostID         strfmt.UUID `gorm:"primaryKey;type:varchar(36)"`
+	InfraEnvID     strfmt.UUID `gorm:"primaryKey;type:varchar(36)"`
+	ServiceBaseURL string
+	PeerInfraEnvID strfmt.UUID `gorm:"type:varchar(36)"`
+	// The agent token issued by the peer hub, kept in the secrets backend when one is configured. It's
+	// cleared once the agent replied to its migration.
+	Token      string `gorm:"type:text"`
+	CreatedAt  time.Time
+	MigratedAt *time.Time
+	// The reason of the failed migration
+	Error string `gorm:"type:text"`
+}
+
+// APIRateLimitBucket is the state of a bucket of the API rate limits, shared by the replicas. Tat is
+// the theoretical arrival time of the next request, in microseconds since the epoch.
+type APIRateLimitBucket struct {
+	Key string `gorm:"primaryKey"`
+	Tat int64
+}
+
 type EagerLoadingState bool
 
 const (
@@ -316,7 +396,24 @@ var ClusterSubTables = [...]string{
 	IngressVIPsTable,
 }
 
+// EventsPropsJSONBFunction converts the props of an event to jsonb, or to null when they aren't valid JSON,
+// so that filtering the events by props doesn't fail because of a single malformed row
+const EventsPropsJSONBFunction = "events_props_jsonb"
+
 func AutoMigrate(db *gorm.DB) error {
+	if err := autoMigrateModels(db); err != nil {
+		return err
+	}
+	return db.Exec(`CREATE OR REPLACE FUNCTION ` + EventsPropsJSONBFunction + `(props text) RETURNS jsonb AS $$
+BEGIN
+	RETURN NULLIF(props, '')::jsonb;
+EXCEPTION WHEN others THEN
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE`).Error
+}
+
+func autoMigrateModels(db *gorm.DB) error {
 	return db.AutoMigrate(&models.MonitoredOperator{},
 		&Host{},
 		&Cluster{},
@@ -328,6 +425,15 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.MachineNetwork{},
 		&models.APIVip{},
 		&models.IngressVip{},
+		&models.EventRateLimit{},
+		&models.RoleBinding{},
+		&APIToken{},
+		&models.AuditRecord{},
+		&ISODownload{},
+		&APIRateLimitBucket{},
+		&AgentToken{},
+		&HostAttestation{},
+		&HostMigration{},
 	)
 }
 
@@ -3,14 +3,17 @@ package common
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	oAPIErrors "github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/openshift/assisted-service/models"
+	errorutil "github.com/openshift/assisted-service/pkg/error"
 	"gorm.io/gorm"
 )
 
@@ -108,6 +111,37 @@ func NewInfraError(statusCode int32, err error) *InfraErrorResponse {
 	}
 }
 
+// QuotaExceededResponse reports a quota exceeded by a tenant, its kind lets the clients tell it from
+// the other errors
+type QuotaExceededResponse struct {
+	*ApiErrorResponse
+	retryAfter time.Duration
+}
+
+var _ oAPIErrors.Error = &QuotaExceededResponse{}
+
+func (q *QuotaExceededResponse) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+	if q.retryAfter > 0 {
+		rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(q.retryAfter.Seconds()))))
+	}
+	rw.WriteHeader(int(q.statusCode))
+	payload := GenerateError(q.statusCode, q.err)
+	payload.Kind = swag.String(errorutil.QuotaExceededKind)
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+func NewQuotaExceededError(err *errorutil.QuotaExceededError) *QuotaExceededResponse {
+	return &QuotaExceededResponse{
+		ApiErrorResponse: &ApiErrorResponse{
+			statusCode: err.StatusCode(),
+			err:        err,
+		},
+		retryAfter: err.RetryAfter,
+	}
+}
+
 func IsNotFoundError(err error) bool {
 	switch err.(type) {
 	case *ApiErrorResponse:
@@ -129,6 +163,8 @@ func IsKnownError(err error) bool {
 		return true
 	case *InfraErrorResponse:
 		return true
+	case *QuotaExceededResponse:
+		return true
 	default:
 		return false
 	}
@@ -144,6 +180,10 @@ func GenerateErrorResponder(err error) middleware.Responder {
 		return errValue
 	case *InfraErrorResponse:
 		return errValue
+	case *QuotaExceededResponse:
+		return errValue
+	case *errorutil.QuotaExceededError:
+		return NewQuotaExceededError(errValue)
 	case NotFound:
 		return NewApiError(http.StatusNotFound, err)
 	default:
@@ -157,6 +197,10 @@ func GenerateErrorResponderWithDefault(err error, defaultCode int32) middleware.
 		return errValue
 	case *InfraErrorResponse:
 		return errValue
+	case *QuotaExceededResponse:
+		return errValue
+	case *errorutil.QuotaExceededError:
+		return NewQuotaExceededError(errValue)
 	default:
 		return NewApiError(defaultCode, err)
 	}
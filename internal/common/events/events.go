@@ -6782,3 +6782,1144 @@ func (e *RebootsForNodeEvent) FormatMessage() string {
     return e.format(&s)
 }
 
+//
+// Event agent_tokens_rotated
+//
+type AgentTokensRotatedEvent struct {
+    eventName string
+    InfraEnvId strfmt.UUID
+    PreviousTokensExpireAt string
+}
+
+var AgentTokensRotatedEventName string = "agent_tokens_rotated"
+
+func NewAgentTokensRotatedEvent(
+    infraEnvId strfmt.UUID,
+    previousTokensExpireAt string,
+) *AgentTokensRotatedEvent {
+    return &AgentTokensRotatedEvent{
+        eventName: AgentTokensRotatedEventName,
+        InfraEnvId: infraEnvId,
+        PreviousTokensExpireAt: previousTokensExpireAt,
+    }
+}
+
+func SendAgentTokensRotatedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    infraEnvId strfmt.UUID,
+    previousTokensExpireAt string,) {
+    ev := NewAgentTokensRotatedEvent(
+        infraEnvId,
+        previousTokensExpireAt,
+    )
+    eventsHandler.SendInfraEnvEvent(ctx, ev)
+}
+
+func SendAgentTokensRotatedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    infraEnvId strfmt.UUID,
+    previousTokensExpireAt string,
+    eventTime time.Time) {
+    ev := NewAgentTokensRotatedEvent(
+        infraEnvId,
+        previousTokensExpireAt,
+    )
+    eventsHandler.SendInfraEnvEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *AgentTokensRotatedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *AgentTokensRotatedEvent) GetSeverity() string {
+    return "info"
+}
+func (e *AgentTokensRotatedEvent) GetClusterId() *strfmt.UUID {
+    return nil
+}
+func (e *AgentTokensRotatedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *AgentTokensRotatedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{previous_tokens_expire_at}", fmt.Sprint(e.PreviousTokensExpireAt),
+    )
+    return r.Replace(*message)
+}
+
+func (e *AgentTokensRotatedEvent) FormatMessage() string {
+    s := "Rotated the agent tokens, the previous tokens are accepted until {previous_tokens_expire_at}"
+    return e.format(&s)
+}
+
+//
+// Event agent_token_rotated
+//
+type AgentTokenRotatedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+}
+
+var AgentTokenRotatedEventName string = "agent_token_rotated"
+
+func NewAgentTokenRotatedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+) *AgentTokenRotatedEvent {
+    return &AgentTokenRotatedEvent{
+        eventName: AgentTokenRotatedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+    }
+}
+
+func SendAgentTokenRotatedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,) {
+    ev := NewAgentTokenRotatedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendAgentTokenRotatedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    eventTime time.Time) {
+    ev := NewAgentTokenRotatedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *AgentTokenRotatedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *AgentTokenRotatedEvent) GetSeverity() string {
+    return "info"
+}
+func (e *AgentTokenRotatedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *AgentTokenRotatedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *AgentTokenRotatedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *AgentTokenRotatedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+    )
+    return r.Replace(*message)
+}
+
+func (e *AgentTokenRotatedEvent) FormatMessage() string {
+    s := "Host {host_name}: Agent switched to a new authentication token"
+    return e.format(&s)
+}
+
+//
+// Event agent_token_rotation_failed
+//
+type AgentTokenRotationFailedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+}
+
+var AgentTokenRotationFailedEventName string = "agent_token_rotation_failed"
+
+func NewAgentTokenRotationFailedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+) *AgentTokenRotationFailedEvent {
+    return &AgentTokenRotationFailedEvent{
+        eventName: AgentTokenRotationFailedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+    }
+}
+
+func SendAgentTokenRotationFailedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,) {
+    ev := NewAgentTokenRotationFailedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendAgentTokenRotationFailedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    eventTime time.Time) {
+    ev := NewAgentTokenRotationFailedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *AgentTokenRotationFailedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *AgentTokenRotationFailedEvent) GetSeverity() string {
+    return "warning"
+}
+func (e *AgentTokenRotationFailedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *AgentTokenRotationFailedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *AgentTokenRotationFailedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *AgentTokenRotationFailedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+    )
+    return r.Replace(*message)
+}
+
+func (e *AgentTokenRotationFailedEvent) FormatMessage() string {
+    s := "Host {host_name}: Agent failed to switch to a new authentication token, will try again"
+    return e.format(&s)
+}
+
+//
+// Event agent_token_revoked
+//
+type AgentTokenRevokedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+}
+
+var AgentTokenRevokedEventName string = "agent_token_revoked"
+
+func NewAgentTokenRevokedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+) *AgentTokenRevokedEvent {
+    return &AgentTokenRevokedEvent{
+        eventName: AgentTokenRevokedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+    }
+}
+
+func SendAgentTokenRevokedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,) {
+    ev := NewAgentTokenRevokedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendAgentTokenRevokedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    eventTime time.Time) {
+    ev := NewAgentTokenRevokedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *AgentTokenRevokedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *AgentTokenRevokedEvent) GetSeverity() string {
+    return "warning"
+}
+func (e *AgentTokenRevokedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *AgentTokenRevokedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *AgentTokenRevokedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *AgentTokenRevokedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+    )
+    return r.Replace(*message)
+}
+
+func (e *AgentTokenRevokedEvent) FormatMessage() string {
+    s := "Host {host_name}: Revoked the agent tokens of the host, its agent can no longer reach the service"
+    return e.format(&s)
+}
+
+//
+// Event host_attestation_succeeded
+//
+type HostAttestationSucceededEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+    PcrPolicy string
+}
+
+var HostAttestationSucceededEventName string = "host_attestation_succeeded"
+
+func NewHostAttestationSucceededEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    pcrPolicy string,
+) *HostAttestationSucceededEvent {
+    return &HostAttestationSucceededEvent{
+        eventName: HostAttestationSucceededEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+        PcrPolicy: pcrPolicy,
+    }
+}
+
+func SendHostAttestationSucceededEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    pcrPolicy string,) {
+    ev := NewHostAttestationSucceededEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        pcrPolicy,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendHostAttestationSucceededEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    pcrPolicy string,
+    eventTime time.Time) {
+    ev := NewHostAttestationSucceededEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        pcrPolicy,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *HostAttestationSucceededEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *HostAttestationSucceededEvent) GetSeverity() string {
+    return "info"
+}
+func (e *HostAttestationSucceededEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *HostAttestationSucceededEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *HostAttestationSucceededEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *HostAttestationSucceededEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+        "{pcr_policy}", fmt.Sprint(e.PcrPolicy),
+    )
+    return r.Replace(*message)
+}
+
+func (e *HostAttestationSucceededEvent) FormatMessage() string {
+    s := "Host {host_name}: Attested its TPM, PCR policy: {pcr_policy}"
+    return e.format(&s)
+}
+
+//
+// Event host_attestation_failed
+//
+type HostAttestationFailedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+    Reason string
+}
+
+var HostAttestationFailedEventName string = "host_attestation_failed"
+
+func NewHostAttestationFailedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    reason string,
+) *HostAttestationFailedEvent {
+    return &HostAttestationFailedEvent{
+        eventName: HostAttestationFailedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+        Reason: reason,
+    }
+}
+
+func SendHostAttestationFailedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    reason string,) {
+    ev := NewHostAttestationFailedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        reason,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendHostAttestationFailedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    reason string,
+    eventTime time.Time) {
+    ev := NewHostAttestationFailedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        reason,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *HostAttestationFailedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *HostAttestationFailedEvent) GetSeverity() string {
+    return "warning"
+}
+func (e *HostAttestationFailedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *HostAttestationFailedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *HostAttestationFailedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *HostAttestationFailedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+        "{reason}", fmt.Sprint(e.Reason),
+    )
+    return r.Replace(*message)
+}
+
+func (e *HostAttestationFailedEvent) FormatMessage() string {
+    s := "Host {host_name}: Failed the TPM attestation: {reason}"
+    return e.format(&s)
+}
+
+//
+// Event host_approval_decided
+//
+type HostApprovalDecidedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+    Decision string
+}
+
+var HostApprovalDecidedEventName string = "host_approval_decided"
+
+func NewHostApprovalDecidedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    decision string,
+) *HostApprovalDecidedEvent {
+    return &HostApprovalDecidedEvent{
+        eventName: HostApprovalDecidedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+        Decision: decision,
+    }
+}
+
+func SendHostApprovalDecidedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    decision string,) {
+    ev := NewHostApprovalDecidedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        decision,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendHostApprovalDecidedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    decision string,
+    eventTime time.Time) {
+    ev := NewHostApprovalDecidedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        decision,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *HostApprovalDecidedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *HostApprovalDecidedEvent) GetSeverity() string {
+    return "info"
+}
+func (e *HostApprovalDecidedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *HostApprovalDecidedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *HostApprovalDecidedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *HostApprovalDecidedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+        "{decision}", fmt.Sprint(e.Decision),
+    )
+    return r.Replace(*message)
+}
+
+func (e *HostApprovalDecidedEvent) FormatMessage() string {
+    s := "Host {host_name}: {decision}"
+    return e.format(&s)
+}
+
+//
+// Event host_quarantined
+//
+type HostQuarantinedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+    Reason string
+}
+
+var HostQuarantinedEventName string = "host_quarantined"
+
+func NewHostQuarantinedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    reason string,
+) *HostQuarantinedEvent {
+    return &HostQuarantinedEvent{
+        eventName: HostQuarantinedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+        Reason: reason,
+    }
+}
+
+func SendHostQuarantinedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    reason string,) {
+    ev := NewHostQuarantinedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        reason,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendHostQuarantinedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    reason string,
+    eventTime time.Time) {
+    ev := NewHostQuarantinedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        reason,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *HostQuarantinedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *HostQuarantinedEvent) GetSeverity() string {
+    return "warning"
+}
+func (e *HostQuarantinedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *HostQuarantinedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *HostQuarantinedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *HostQuarantinedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+        "{reason}", fmt.Sprint(e.Reason),
+    )
+    return r.Replace(*message)
+}
+
+func (e *HostQuarantinedEvent) FormatMessage() string {
+    s := "Host {host_name}: {reason}, it can't be installed until the approval policy of its infra-env approves it"
+    return e.format(&s)
+}
+
+//
+// Event host_migrated
+//
+type HostMigratedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+    PeerInfraEnvId string
+    ServiceBaseUrl string
+}
+
+var HostMigratedEventName string = "host_migrated"
+
+func NewHostMigratedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    peerInfraEnvId string,
+    serviceBaseUrl string,
+) *HostMigratedEvent {
+    return &HostMigratedEvent{
+        eventName: HostMigratedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+        PeerInfraEnvId: peerInfraEnvId,
+        ServiceBaseUrl: serviceBaseUrl,
+    }
+}
+
+func SendHostMigratedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    peerInfraEnvId string,
+    serviceBaseUrl string,) {
+    ev := NewHostMigratedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        peerInfraEnvId,
+        serviceBaseUrl,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendHostMigratedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    peerInfraEnvId string,
+    serviceBaseUrl string,
+    eventTime time.Time) {
+    ev := NewHostMigratedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        peerInfraEnvId,
+        serviceBaseUrl,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *HostMigratedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *HostMigratedEvent) GetSeverity() string {
+    return "info"
+}
+func (e *HostMigratedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *HostMigratedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *HostMigratedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *HostMigratedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+        "{peer_infra_env_id}", fmt.Sprint(e.PeerInfraEnvId),
+        "{service_base_url}", fmt.Sprint(e.ServiceBaseUrl),
+    )
+    return r.Replace(*message)
+}
+
+func (e *HostMigratedEvent) FormatMessage() string {
+    s := "Host {host_name}: Migrated to infra-env {peer_infra_env_id} of the peer service {service_base_url}, the host is no longer managed by this service"
+    return e.format(&s)
+}
+
+//
+// Event host_migration_failed
+//
+type HostMigrationFailedEvent struct {
+    eventName string
+    HostId strfmt.UUID
+    HostName string
+    InfraEnvId strfmt.UUID
+    ClusterId *strfmt.UUID
+    ServiceBaseUrl string
+    Reason string
+}
+
+var HostMigrationFailedEventName string = "host_migration_failed"
+
+func NewHostMigrationFailedEvent(
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    serviceBaseUrl string,
+    reason string,
+) *HostMigrationFailedEvent {
+    return &HostMigrationFailedEvent{
+        eventName: HostMigrationFailedEventName,
+        HostId: hostId,
+        HostName: hostName,
+        InfraEnvId: infraEnvId,
+        ClusterId: clusterId,
+        ServiceBaseUrl: serviceBaseUrl,
+        Reason: reason,
+    }
+}
+
+func SendHostMigrationFailedEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    serviceBaseUrl string,
+    reason string,) {
+    ev := NewHostMigrationFailedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        serviceBaseUrl,
+        reason,
+    )
+    eventsHandler.SendHostEvent(ctx, ev)
+}
+
+func SendHostMigrationFailedEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    hostId strfmt.UUID,
+    hostName string,
+    infraEnvId strfmt.UUID,
+    clusterId *strfmt.UUID,
+    serviceBaseUrl string,
+    reason string,
+    eventTime time.Time) {
+    ev := NewHostMigrationFailedEvent(
+        hostId,
+        hostName,
+        infraEnvId,
+        clusterId,
+        serviceBaseUrl,
+        reason,
+    )
+    eventsHandler.SendHostEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *HostMigrationFailedEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *HostMigrationFailedEvent) GetSeverity() string {
+    return "warning"
+}
+func (e *HostMigrationFailedEvent) GetClusterId() *strfmt.UUID {
+    return e.ClusterId
+}
+func (e *HostMigrationFailedEvent) GetHostId() strfmt.UUID {
+    return e.HostId
+}
+func (e *HostMigrationFailedEvent) GetInfraEnvId() strfmt.UUID {
+    return e.InfraEnvId
+}
+
+
+
+func (e *HostMigrationFailedEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{host_id}", fmt.Sprint(e.HostId),
+        "{host_name}", fmt.Sprint(e.HostName),
+        "{infra_env_id}", fmt.Sprint(e.InfraEnvId),
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+        "{service_base_url}", fmt.Sprint(e.ServiceBaseUrl),
+        "{reason}", fmt.Sprint(e.Reason),
+    )
+    return r.Replace(*message)
+}
+
+func (e *HostMigrationFailedEvent) FormatMessage() string {
+    s := "Host {host_name}: Failed to migrate to the peer service {service_base_url}: {reason}"
+    return e.format(&s)
+}
+
+//
+// Event spoke_cluster_healthy
+//
+type SpokeClusterHealthyEvent struct {
+    eventName string
+    ClusterId strfmt.UUID
+}
+
+var SpokeClusterHealthyEventName string = "spoke_cluster_healthy"
+
+func NewSpokeClusterHealthyEvent(
+    clusterId strfmt.UUID,
+) *SpokeClusterHealthyEvent {
+    return &SpokeClusterHealthyEvent{
+        eventName: SpokeClusterHealthyEventName,
+        ClusterId: clusterId,
+    }
+}
+
+func SendSpokeClusterHealthyEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    clusterId strfmt.UUID,) {
+    ev := NewSpokeClusterHealthyEvent(
+        clusterId,
+    )
+    eventsHandler.SendClusterEvent(ctx, ev)
+}
+
+func SendSpokeClusterHealthyEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    clusterId strfmt.UUID,
+    eventTime time.Time) {
+    ev := NewSpokeClusterHealthyEvent(
+        clusterId,
+    )
+    eventsHandler.SendClusterEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *SpokeClusterHealthyEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *SpokeClusterHealthyEvent) GetSeverity() string {
+    return "info"
+}
+func (e *SpokeClusterHealthyEvent) GetClusterId() strfmt.UUID {
+    return e.ClusterId
+}
+
+
+
+func (e *SpokeClusterHealthyEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+    )
+    return r.Replace(*message)
+}
+
+func (e *SpokeClusterHealthyEvent) FormatMessage() string {
+    s := "The installed cluster is healthy"
+    return e.format(&s)
+}
+
+//
+// Event spoke_cluster_unhealthy
+//
+type SpokeClusterUnhealthyEvent struct {
+    eventName string
+    ClusterId strfmt.UUID
+    HealthStatus string
+    Reason string
+}
+
+var SpokeClusterUnhealthyEventName string = "spoke_cluster_unhealthy"
+
+func NewSpokeClusterUnhealthyEvent(
+    clusterId strfmt.UUID,
+    healthStatus string,
+    reason string,
+) *SpokeClusterUnhealthyEvent {
+    return &SpokeClusterUnhealthyEvent{
+        eventName: SpokeClusterUnhealthyEventName,
+        ClusterId: clusterId,
+        HealthStatus: healthStatus,
+        Reason: reason,
+    }
+}
+
+func SendSpokeClusterUnhealthyEvent(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    clusterId strfmt.UUID,
+    healthStatus string,
+    reason string,) {
+    ev := NewSpokeClusterUnhealthyEvent(
+        clusterId,
+        healthStatus,
+        reason,
+    )
+    eventsHandler.SendClusterEvent(ctx, ev)
+}
+
+func SendSpokeClusterUnhealthyEventAtTime(
+    ctx context.Context,
+    eventsHandler eventsapi.Sender,
+    clusterId strfmt.UUID,
+    healthStatus string,
+    reason string,
+    eventTime time.Time) {
+    ev := NewSpokeClusterUnhealthyEvent(
+        clusterId,
+        healthStatus,
+        reason,
+    )
+    eventsHandler.SendClusterEventAtTime(ctx, ev, eventTime)
+}
+
+func (e *SpokeClusterUnhealthyEvent) GetName() string {
+    return e.eventName
+}
+
+func (e *SpokeClusterUnhealthyEvent) GetSeverity() string {
+    return "warning"
+}
+func (e *SpokeClusterUnhealthyEvent) GetClusterId() strfmt.UUID {
+    return e.ClusterId
+}
+
+
+
+func (e *SpokeClusterUnhealthyEvent) format(message *string) string {
+    r := strings.NewReplacer(
+        "{cluster_id}", fmt.Sprint(e.ClusterId),
+        "{health_status}", fmt.Sprint(e.HealthStatus),
+        "{reason}", fmt.Sprint(e.Reason),
+    )
+    return r.Replace(*message)
+}
+
+func (e *SpokeClusterUnhealthyEvent) FormatMessage() string {
+    s := "The installed cluster is {health_status}: {reason}"
+    return e.format(&s)
+}
+
@@ -40,6 +40,7 @@ import (
 	"github.com/openshift/assisted-service/internal/gencrypto"
 	"github.com/openshift/assisted-service/internal/host"
 	"github.com/openshift/assisted-service/internal/host/hostutil"
+	"github.com/openshift/assisted-service/internal/hostapproval"
 	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/auth"
@@ -78,6 +79,9 @@ const (
 	AgentCurrentStageAnnotation          = "agent." + aiv1beta1.Group + "/current-stage"
 	AgentRoleAnnotation                  = "agent." + aiv1beta1.Group + "/role"
 	AgentValidationsInfoAnnotation       = "agent." + aiv1beta1.Group + "/validations-info"
+	AgentImportedFromAnnotation          = "agent." + aiv1beta1.Group + "/imported-from"
+	AgentMigrationTokenIssuedAnnotation  = "agent." + aiv1beta1.Group + "/migration-token-issued"
+	AgentBMCInventoryAnnotation          = "agent." + aiv1beta1.Group + "/bmc-inventory"
 	AgentLabelHostManufacturer           = InventoryLabelPrefix + "host-manufacturer"
 	AgentLabelHostProductName            = InventoryLabelPrefix + "host-productname"
 	AgentLabelHostIsVirtual              = InventoryLabelPrefix + "host-isvirtual"
@@ -109,7 +113,7 @@ type AgentReconciler struct {
 // +kubebuilder:rbac:groups=agent-install.openshift.io,resources=agents,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=agent-install.openshift.io,resources=agents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=agent-install.openshift.io,resources=agents/ai-deprovision,verbs=update
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=agent-install.openshift.io,resources=infraenvs,verbs=get
 
 func (r *AgentReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -193,6 +197,11 @@ func (r *AgentReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (
 		}
 	}
 
+	if err = r.issueMigrationToken(ctx, log, agent, &h.Host); err != nil {
+		log.WithError(err).Errorf("failed to issue the migration token of agent %s/%s", agent.Namespace, agent.Name)
+		return r.updateStatus(ctx, log, agent, origAgent, &h.Host, h.ClusterID, err, true)
+	}
+
 	if agent.Spec.ClusterDeploymentName == nil && h.ClusterID != nil {
 		log.Debugf("ClusterDeploymentName is unset in Agent %s.", agent.Name)
 		if funk.ContainsString(host.HostInstallingStatuses, *h.Status) && swag.StringValue(h.Kind) != models.HostKindAddToExistingClusterHost {
@@ -686,6 +695,11 @@ func (r *AgentReconciler) getBMH(ctx context.Context, agent *aiv1beta1.Agent) (*
 	if !r.EnableMetal3 {
 		return nil, nil
 	}
+	return getAgentBMH(ctx, r.Client, agent)
+}
+
+// getAgentBMH returns the BMH that the agent is labelled with, nil if there is none
+func getAgentBMH(ctx context.Context, c client.Client, agent *aiv1beta1.Agent) (*bmh_v1alpha1.BareMetalHost, error) {
 	bmhName, ok := agent.ObjectMeta.Labels[AGENT_BMH_LABEL]
 	if !ok {
 		return nil, nil
@@ -695,7 +709,7 @@ func (r *AgentReconciler) getBMH(ctx context.Context, agent *aiv1beta1.Agent) (*
 		Namespace: agent.Namespace,
 	}
 	bmh := &bmh_v1alpha1.BareMetalHost{}
-	if err := r.Client.Get(ctx, bmhKey, bmh); err != nil {
+	if err := c.Get(ctx, bmhKey, bmh); err != nil {
 		return nil, client.IgnoreNotFound(err)
 	}
 	return bmh, nil
@@ -1346,7 +1360,7 @@ func installed(agent *aiv1beta1.Agent, status, statusInfo string) {
 		models.HostStatusDisconnected, models.HostStatusDisconnectedUnbound,
 		models.HostStatusDiscovering, models.HostStatusDiscoveringUnbound,
 		models.HostStatusKnown, models.HostStatusKnownUnbound,
-		models.HostStatusPendingForInput:
+		models.HostStatusPendingForInput, models.HostStatusPendingAttestation:
 		condStatus = corev1.ConditionFalse
 		reason = aiv1beta1.InstallationNotStartedReason
 		msg = aiv1beta1.InstallationNotStartedMsg
@@ -1407,6 +1421,10 @@ func validated(agent *aiv1beta1.Agent, status string, h *models.Host) {
 		condStatus = corev1.ConditionFalse
 		reason = aiv1beta1.ValidationsFailingReason
 		msg = fmt.Sprintf("%s %s", aiv1beta1.AgentValidationsFailingMsg, failedValidationInfo)
+	case models.HostStatusPendingAttestation == status:
+		condStatus = corev1.ConditionFalse
+		reason = aiv1beta1.PendingAttestationReason
+		msg = fmt.Sprintf("%s %s", aiv1beta1.PendingAttestationMsg, swag.StringValue(h.StatusInfo))
 	case models.HostStatusPendingForInput == status:
 		condStatus = corev1.ConditionFalse
 		reason = aiv1beta1.ValidationsUserPendingReason
@@ -1489,6 +1507,10 @@ func requirementsMet(agent *aiv1beta1.Agent, status string) {
 		condStatus = corev1.ConditionFalse
 		reason = aiv1beta1.UnbindingReason
 		msg = aiv1beta1.UnbindingMsg
+	case models.HostStatusPendingAttestation:
+		condStatus = corev1.ConditionFalse
+		reason = aiv1beta1.PendingAttestationReason
+		msg = aiv1beta1.AgentNotReadyMsg
 	default:
 		condStatus = corev1.ConditionUnknown
 		reason = aiv1beta1.UnknownStatusReason
@@ -1524,6 +1546,17 @@ func bound(agent *aiv1beta1.Agent, status string) {
 		condStatus = corev1.ConditionFalse
 		reason = aiv1beta1.UnboundReason
 		msg = aiv1beta1.UnboundMsg
+	case models.HostStatusPendingAttestation:
+		// The host is held pending attestation whether it's bound or not
+		if agent.Spec.ClusterDeploymentName == nil {
+			condStatus = corev1.ConditionFalse
+			reason = aiv1beta1.UnboundReason
+			msg = aiv1beta1.UnboundMsg
+		} else {
+			condStatus = corev1.ConditionTrue
+			reason = aiv1beta1.BoundReason
+			msg = aiv1beta1.BoundMsg
+		}
 	default:
 		condStatus = corev1.ConditionTrue
 		reason = aiv1beta1.BoundReason
@@ -1870,6 +1903,27 @@ func (r *AgentReconciler) updateHostIgnitionEndpointToken(ctx context.Context, l
 	return false, nil
 }
 
+// updateBmcInventory passes the hardware details copied from the BMH of the Agent to the host
+func (r *AgentReconciler) updateBmcInventory(ctx context.Context, log logrus.FieldLogger, host *common.Host, agent *aiv1beta1.Agent) error {
+	value := agent.GetAnnotations()[AgentBMCInventoryAnnotation]
+	if value == host.BmcInventory {
+		return nil
+	}
+	var bmcInventory *models.BmcInventory
+	if value != "" {
+		bmcInventory = &models.BmcInventory{}
+		if err := json.Unmarshal([]byte(value), bmcInventory); err != nil {
+			log.WithError(err).Errorf("failed to parse the %s annotation of agent %s/%s", AgentBMCInventoryAnnotation, agent.Namespace, agent.Name)
+			return nil
+		}
+	}
+	if err := r.Installer.UpdateHostBmcInventoryInternal(ctx, host.InfraEnvID.String(), host.ID.String(), bmcInventory); err != nil {
+		log.WithError(err).Errorf("Failed to update the BMC inventory of agent %s/%s", agent.Namespace, agent.Name)
+		return err
+	}
+	return nil
+}
+
 func (r *AgentReconciler) updateIfNeeded(ctx context.Context, log logrus.FieldLogger, agent *aiv1beta1.Agent, internalHost *common.Host) (*common.Host, error) {
 	spec := agent.Spec
 	var err error
@@ -2003,6 +2057,9 @@ func (r *AgentReconciler) updateIfNeeded(ctx context.Context, log logrus.FieldLo
 		}
 		log.Infof("Updated Agent Approve %s %s", agent.Name, agent.Namespace)
 	}
+	if err = r.updateBmcInventory(ctx, log, internalHost, agent); err != nil {
+		return returnedHost, err
+	}
 	log.Debugf("Updated Agent spec %s %s", agent.Name, agent.Namespace)
 
 	return returnedHost, nil
@@ -2120,6 +2177,27 @@ func (r *AgentReconciler) applyAutoApprovalIfNeeded(ctx context.Context, log log
 		return false, errors.Wrapf(err, "failed to get InfraEnv %s/%s", infraEnvKey.Namespace, infraEnvKey.Name)
 	}
 
+	if policy := internalHostApprovalPolicy(infraEnv.Spec.AgentApproval); !hostapproval.IsEmpty(policy) {
+		if h.Inventory == "" {
+			log.Debugf("Agent %s/%s has no inventory yet, skipping the approval rules", agent.Namespace, agent.Name)
+			return false, nil
+		}
+		var inventory models.Inventory
+		if err := json.Unmarshal([]byte(h.Inventory), &inventory); err != nil {
+			return false, errors.Wrapf(err, "failed to unmarshal the inventory of agent %s/%s", agent.Namespace, agent.Name)
+		}
+		decision := hostapproval.Evaluate(policy, &inventory)
+		switch decision.Action {
+		case hostapproval.ActionApprove:
+			log.Infof("Auto-approving agent %s/%s based on InfraEnv %s approval rules: %s", agent.Namespace, agent.Name, infraEnvKey.Name, decision.Reason)
+			agent.Spec.Approved = true
+			return true, nil
+		case hostapproval.ActionQuarantine:
+			log.Infof("Not approving agent %s/%s based on InfraEnv %s approval rules: %s", agent.Namespace, agent.Name, infraEnvKey.Name, decision.Reason)
+			return false, nil
+		}
+	}
+
 	if infraEnv.Spec.AgentApproval != nil && infraEnv.Spec.AgentApproval.AutoApprove {
 		log.Infof("Auto-approving agent %s/%s based on InfraEnv %s configuration", agent.Namespace, agent.Name, infraEnvKey.Name)
 		agent.Spec.Approved = true
@@ -2253,6 +2331,53 @@ func (r *AgentReconciler) restoreHostByAgent(ctx context.Context, log logrus.Fie
 	return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeue}, nil
 }
 
+func agentMigrationTokenSecretName(agentName string) string {
+	return agentName + "-migration-token"
+}
+
+// issueMigrationToken issues the agent token of a host imported from a peer hub by an
+// InfraEnvFederation. The token is stored in a Secret next to the Agent, where the peer hub reads it
+// to reconfigure the agent.
+func (r *AgentReconciler) issueMigrationToken(ctx context.Context, log logrus.FieldLogger, agent *aiv1beta1.Agent, h *models.Host) error {
+	if _, imported := agent.GetAnnotations()[AgentImportedFromAnnotation]; !imported {
+		return nil
+	}
+	if _, issued := agent.GetAnnotations()[AgentMigrationTokenIssuedAnnotation]; issued {
+		return nil
+	}
+
+	token := ""
+	if r.AuthType == auth.TypeLocal {
+		var err error
+		token, err = r.Installer.IssueHostAgentTokenInternal(ctx, h.InfraEnvID, *h.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to issue the agent token of host %s", h.ID.String())
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentMigrationTokenSecretName(agent.Name),
+			Namespace: agent.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"token":      []byte(token),
+			"infraEnvID": []byte(h.InfraEnvID.String()),
+		},
+	}
+	if err := controllerutil.SetControllerReference(agent, secret, r.Scheme); err != nil {
+		return errors.Wrapf(err, "failed to set the owner of secret %s/%s", secret.Namespace, secret.Name)
+	}
+	if err := r.Create(ctx, secret); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "failed to create secret %s/%s", secret.Namespace, secret.Name)
+	}
+	log.Infof("Issued the migration token of agent %s/%s", agent.Namespace, agent.Name)
+
+	setAgentAnnotation(log, agent, AgentMigrationTokenIssuedAnnotation, "true")
+	return r.updateAndReplaceAgent(ctx, agent)
+}
+
 func createNewHost(agent *v1beta1.Agent, clusterID *strfmt.UUID, infraEnvID strfmt.UUID) (*models.Host, error) {
 	// Retrieve host inventory and role
 	hostrole := agent.Annotations[AgentRoleAnnotation]
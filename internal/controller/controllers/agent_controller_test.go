@@ -172,6 +172,57 @@ var _ = Describe("agent reconcile", func() {
 		Expect(agent.GetLabels()[BaseLabelPrefix+"clusterdeployment-namespace"]).To(Equal(""))
 	})
 
+	Context("imported agent", func() {
+		var hostId, infraEnvId strfmt.UUID
+
+		BeforeEach(func() {
+			hostId = strfmt.UUID(uuid.New().String())
+			infraEnvId = strfmt.UUID(uuid.New().String())
+			hr.AuthType = auth.TypeLocal
+			mockInstallerInternal.EXPECT().GetHostByKubeKey(gomock.Any()).Return(&common.Host{Host: models.Host{ID: &hostId, InfraEnvID: infraEnvId}}, nil).AnyTimes()
+			allowGetInfraEnvInternal(mockInstallerInternal, infraEnvId, "infraEnvName")
+		})
+
+		It("issues the migration token of an imported agent", func() {
+			host := newAgent("host", testNamespace, v1beta1.AgentSpec{})
+			host.ObjectMeta.Annotations = map[string]string{AgentImportedFromAnnotation: "source-hub"}
+			Expect(c.Create(ctx, host)).To(BeNil())
+			mockInstallerInternal.EXPECT().IssueHostAgentTokenInternal(gomock.Any(), infraEnvId, hostId).Return("agent-token", nil).Times(1)
+
+			result, err := hr.Reconcile(ctx, newHostRequest(host))
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(ctrl.Result{}))
+
+			secret := &corev1.Secret{}
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: agentMigrationTokenSecretName("host")}, secret)).To(Succeed())
+			Expect(string(secret.Data["token"])).To(Equal("agent-token"))
+			Expect(string(secret.Data["infraEnvID"])).To(Equal(infraEnvId.String()))
+			Expect(metav1.IsControlledBy(secret, host)).To(BeTrue())
+
+			agent := &v1beta1.Agent{}
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "host"}, agent)).To(Succeed())
+			Expect(agent.GetAnnotations()).To(HaveKey(AgentMigrationTokenIssuedAnnotation))
+
+			By("not issuing the token twice")
+			result, err = hr.Reconcile(ctx, newHostRequest(host))
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(ctrl.Result{}))
+		})
+
+		It("doesn't issue a migration token for an agent that wasn't imported", func() {
+			host := newAgent("host", testNamespace, v1beta1.AgentSpec{})
+			Expect(c.Create(ctx, host)).To(BeNil())
+
+			result, err := hr.Reconcile(ctx, newHostRequest(host))
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(ctrl.Result{}))
+
+			secret := &corev1.Secret{}
+			err = c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: agentMigrationTokenSecretName("host")}, secret)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
 	It("cluster deployment not found", func() {
 		hostId := strfmt.UUID(uuid.New().String())
 		infraEnvId := strfmt.UUID(uuid.New().String())
@@ -1250,6 +1301,48 @@ var _ = Describe("agent reconcile", func() {
 		Expect(conditionsv1.FindStatusCondition(agent.Status.Conditions, v1beta1.ConnectedCondition).Reason).To(Equal(v1beta1.AgentConnectedReason))
 	})
 
+	It("Agent update BMC inventory", func() {
+		hostId := strfmt.UUID(uuid.New().String())
+		infraEnvId := strfmt.UUID(uuid.New().String())
+		commonHost := &common.Host{
+			Host: models.Host{
+				ID:         &hostId,
+				InfraEnvID: infraEnvId,
+				ClusterID:  &sId,
+				Inventory:  common.GenerateTestDefaultInventory(),
+				Status:     swag.String(models.HostStatusKnown),
+				StatusInfo: swag.String("Some status info"),
+			},
+		}
+		backEndCluster = &common.Cluster{Cluster: models.Cluster{
+			ID: &sId,
+			Hosts: []*models.Host{
+				&commonHost.Host,
+			}}}
+
+		host := newAgent(hostId.String(), testNamespace, v1beta1.AgentSpec{ClusterDeploymentName: &v1beta1.ClusterReference{Name: "clusterDeployment", Namespace: testNamespace}})
+		host.Annotations = map[string]string{AgentBMCInventoryAnnotation: `{"bios_version":"2.19.1","boot_mode":"UEFISecureBoot"}`}
+		clusterDeployment := newClusterDeployment("clusterDeployment", testNamespace, getDefaultClusterDeploymentSpec("clusterDeployment-test", "test-cluster-aci", "pull-secret"))
+		Expect(c.Create(ctx, clusterDeployment)).To(BeNil())
+		mockInstallerInternal.EXPECT().GetHostByKubeKey(gomock.Any()).Return(commonHost, nil).AnyTimes()
+		mockInstallerInternal.EXPECT().GetClusterByKubeKey(gomock.Any()).Return(backEndCluster, nil).Times(1)
+		mockInstallerInternal.EXPECT().UpdateHostBmcInventoryInternal(gomock.Any(), infraEnvId.String(), hostId.String(),
+			&models.BmcInventory{BiosVersion: "2.19.1", BootMode: "UEFISecureBoot"}).Return(nil)
+		allowGetInfraEnvInternal(mockInstallerInternal, infraEnvId, "infraEnvName")
+		Expect(c.Create(ctx, host)).To(BeNil())
+		result, err := hr.Reconcile(ctx, newHostRequest(host))
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(ctrl.Result{}))
+		agent := &v1beta1.Agent{}
+
+		key := types.NamespacedName{
+			Namespace: testNamespace,
+			Name:      hostId.String(),
+		}
+		Expect(c.Get(ctx, key, agent)).To(BeNil())
+		Expect(conditionsv1.FindStatusCondition(agent.Status.Conditions, v1beta1.SpecSyncedCondition).Reason).To(Equal(v1beta1.SyncedOkReason))
+	})
+
 	It("Agent update approved", func() {
 		hostId := strfmt.UUID(uuid.New().String())
 		infraEnvId := strfmt.UUID(uuid.New().String())
@@ -1410,6 +1503,86 @@ var _ = Describe("agent reconcile", func() {
 		Expect(agent.Spec.Approved).To(BeFalse())
 	})
 
+	Context("Agent approval rules", func() {
+		reconcileWithApproval := func(approval *v1beta1.AgentApproval, expectApproval bool) *v1beta1.Agent {
+			hostId := strfmt.UUID(uuid.New().String())
+			infraEnvId := strfmt.UUID(uuid.New().String())
+			infraEnvName := "test-infra-env"
+			commonHost := &common.Host{
+				Host: models.Host{
+					ID:         &hostId,
+					InfraEnvID: infraEnvId,
+					ClusterID:  &sId,
+					Inventory:  common.GenerateTestDefaultInventory(),
+					Status:     swag.String(models.HostStatusKnown),
+					StatusInfo: swag.String("Some status info"),
+				},
+			}
+			backEndCluster = &common.Cluster{Cluster: models.Cluster{
+				ID: &sId,
+				Hosts: []*models.Host{
+					&commonHost.Host,
+				}}}
+
+			infraEnv := &v1beta1.InfraEnv{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      infraEnvName,
+					Namespace: testNamespace,
+				},
+				Spec: v1beta1.InfraEnvSpec{
+					AgentApproval: approval,
+				},
+			}
+			Expect(c.Create(ctx, infraEnv)).To(BeNil())
+
+			host := newAgent(hostId.String(), testNamespace, v1beta1.AgentSpec{
+				ClusterDeploymentName: &v1beta1.ClusterReference{Name: "clusterDeployment", Namespace: testNamespace},
+			})
+			host.ObjectMeta.Labels = map[string]string{
+				v1beta1.InfraEnvNameLabel: infraEnvName,
+			}
+			clusterDeployment := newClusterDeployment("clusterDeployment", testNamespace, getDefaultClusterDeploymentSpec("clusterDeployment-test", "test-cluster-aci", "pull-secret"))
+			Expect(c.Create(ctx, clusterDeployment)).To(BeNil())
+			mockInstallerInternal.EXPECT().GetHostByKubeKey(gomock.Any()).Return(commonHost, nil).AnyTimes()
+			mockInstallerInternal.EXPECT().GetClusterByKubeKey(gomock.Any()).Return(backEndCluster, nil).Times(1)
+			if expectApproval {
+				mockInstallerInternal.EXPECT().UpdateHostApprovedInternal(gomock.Any(), gomock.Any(), gomock.Any(), true).Return(nil)
+			}
+			allowGetInfraEnvInternal(mockInstallerInternal, infraEnvId, infraEnvName)
+			Expect(c.Create(ctx, host)).To(BeNil())
+			result, err := hr.Reconcile(ctx, newHostRequest(host))
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(ctrl.Result{}))
+
+			agent := &v1beta1.Agent{}
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: hostId.String()}, agent)).To(BeNil())
+			return agent
+		}
+
+		It("approves the agent matching an approve rule", func() {
+			agent := reconcileWithApproval(&v1beta1.AgentApproval{
+				Rules: []v1beta1.AgentApprovalRule{{Name: "all", Action: "approve"}},
+			}, true)
+			Expect(agent.Spec.Approved).To(BeTrue())
+		})
+
+		It("doesn't approve the agent matching a quarantine rule, even with auto-approval", func() {
+			agent := reconcileWithApproval(&v1beta1.AgentApproval{
+				AutoApprove: true,
+				Rules:       []v1beta1.AgentApprovalRule{{Name: "all", Action: "quarantine"}},
+			}, false)
+			Expect(agent.Spec.Approved).To(BeFalse())
+		})
+
+		It("falls back to auto-approval when no rule matches", func() {
+			agent := reconcileWithApproval(&v1beta1.AgentApproval{
+				AutoApprove: true,
+				Rules:       []v1beta1.AgentApprovalRule{{Name: "lab", Action: "approve", SerialNumbers: []string{"LAB-*"}}},
+			}, true)
+			Expect(agent.Spec.Approved).To(BeTrue())
+		})
+	})
+
 	Context("host reclaim", func() {
 		var (
 			commonHost            *common.Host
@@ -5439,6 +5612,11 @@ var _ = Describe("handleAgentFinalizer", func() {
 				infraEnvKey := types.NamespacedName{Name: "infraenvName", Namespace: testNamespace}
 				mockClient.EXPECT().Get(ctx, infraEnvKey, gomock.AssignableToTypeOf(&v1beta1.InfraEnv{})).Return(nil).AnyTimes()
 
+				// no service account kubeconfig was minted for the cluster
+				saSecretKey := types.NamespacedName{Name: agent.Spec.ClusterDeploymentName.Name + "-service-account-kubeconfig", Namespace: testNamespace}
+				mockClient.EXPECT().Get(ctx, saSecretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).
+					Return(k8serrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, saSecretKey.Name)).AnyTimes()
+
 				secretKey := types.NamespacedName{Name: "clusterKubeConfig", Namespace: testNamespace}
 				mockClient.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(
 					func(_ context.Context, key client.ObjectKey, secret *corev1.Secret, _ ...client.GetOption) error {
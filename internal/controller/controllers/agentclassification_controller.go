@@ -80,9 +80,6 @@ func (r *AgentClassificationReconciler) Reconcile(origCtx context.Context, req c
 		}
 	}
 
-	matchedCount := 0
-	errorCount := 0
-
 	agents := aiv1beta1.AgentList{}
 	opts := &client.ListOptions{
 		Namespace: classification.Namespace,
@@ -90,11 +87,12 @@ func (r *AgentClassificationReconciler) Reconcile(origCtx context.Context, req c
 	if err := r.List(ctx, &agents, opts); err != nil {
 		return ctrl.Result{}, err
 	}
-	matchedCount, errorCount = countAgentsByClassification(log, &agents, classification)
+	matchedCount, errorCount, matchedCountByValue := countAgentsByClassification(log, &agents, classification)
 
 	setErrorCountCondition(classification, errorCount)
 	classification.Status.MatchedCount = matchedCount
 	classification.Status.ErrorCount = errorCount
+	classification.Status.MatchedCountByValue = matchedCountByValue
 
 	if !classification.DeletionTimestamp.IsZero() {
 		if matchedCount > 0 {
@@ -117,25 +115,44 @@ func (r *AgentClassificationReconciler) Reconcile(origCtx context.Context, req c
 	return ctrl.Result{}, nil
 }
 
-func countAgentsByClassification(log logrus.FieldLogger, agents *aiv1beta1.AgentList, classification *aiv1beta1.AgentClassification) (matchedCount, errorCount int) {
-	for _, agent := range agents.Items {
+// countAgentsByClassification returns how many Agents match the classification, in total and with
+// each label value, and how many failed to apply it. The classifications with rules own their label
+// key, an Agent matches them only when its label has the value that the rules give its inventory.
+func countAgentsByClassification(log logrus.FieldLogger, agents *aiv1beta1.AgentList, classification *aiv1beta1.AgentClassification) (matchedCount, errorCount int, matchedCountByValue map[string]int) {
+	labelKey := ClassificationLabelPrefix + classification.Spec.LabelKey
+	for i := range agents.Items {
+		agent := &agents.Items[i]
 		labels := agent.GetLabels()
 		if labels == nil {
 			continue
 		}
-		if _, ok := labels[ClassificationLabelPrefix+classification.Spec.LabelKey]; !ok {
+		value, ok := labels[labelKey]
+		if !ok {
 			continue
 		}
-		if labels[ClassificationLabelPrefix+classification.Spec.LabelKey] == classification.Spec.LabelValue {
-			matchedCount++
-		} else if strings.HasPrefix(labels[ClassificationLabelPrefix+classification.Spec.LabelKey], "QUERYERROR") {
+		switch {
+		case strings.HasPrefix(value, "QUERYERROR"):
 			errorCount++
+		case agentMatchesClassification(agent, classification, value):
+			matchedCount++
+			if matchedCountByValue == nil {
+				matchedCountByValue = map[string]int{}
+			}
+			matchedCountByValue[value]++
 		}
 	}
 
 	return
 }
 
+func agentMatchesClassification(agent *aiv1beta1.Agent, classification *aiv1beta1.AgentClassification, value string) bool {
+	if len(classification.Spec.Rules) == 0 {
+		return value == classification.Spec.LabelValue
+	}
+	expected, err := classifyAgent(classification, agentInventoryInterface(agent))
+	return err == nil && expected != "" && value == expected
+}
+
 func setErrorCountCondition(classification *aiv1beta1.AgentClassification, errorCount int) {
 	if errorCount != 0 {
 		conditionsv1.SetStatusConditionNoHeartbeat(&classification.Status.Conditions, conditionsv1.Condition{
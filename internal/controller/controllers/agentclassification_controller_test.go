@@ -140,4 +140,47 @@ var _ = Describe("AgentClassification reconcile", func() {
 		classification = getTestClassification()
 		Expect(classification.GetFinalizers()).ToNot(ContainElement(AgentClassificationFinalizer))
 	})
+
+	It("AgentClassification counts the Agents by label value", func() {
+		classificationSpec := v1beta1.AgentClassificationSpec{
+			LabelKey: "size",
+			Rules: []v1beta1.AgentClassificationRule{
+				{
+					Name:  "size",
+					Query: ".cpu.count",
+					Tiers: []v1beta1.AgentClassificationTier{
+						{LabelValue: "small", MinScore: 0},
+						{LabelValue: "large", MinScore: 8},
+					},
+				},
+			},
+		}
+		classification := newAgentClassification(defaultClassificationName, testNamespace, classificationSpec, true)
+		Expect(c.Create(ctx, classification)).ShouldNot(HaveOccurred())
+
+		for _, a := range []struct {
+			name  string
+			value string
+			cpus  int64
+		}{
+			{"agent1", "small", 2},
+			{"agent2", "small", 4},
+			{"agent3", "large", 16},
+			{"agent4", queryErrorValue(defaultClassificationName), 0},
+			// Not the value of the rules for the inventory of the Agent
+			{"agent6", "large", 2},
+		} {
+			agent := newAgentWithLabel(a.name, testNamespace, classificationSpec.LabelKey, a.value)
+			agent.Status.Inventory.Cpu.Count = a.cpus
+			Expect(c.Create(ctx, agent)).ShouldNot(HaveOccurred())
+		}
+		Expect(c.Create(ctx, newAgentWithLabel("agent5", testNamespace, "differentkey", "small"))).ShouldNot(HaveOccurred())
+
+		reconcileClassification(classification)
+
+		classification = getTestClassification()
+		Expect(classification.Status.MatchedCount).To(Equal(3))
+		Expect(classification.Status.ErrorCount).To(Equal(1))
+		Expect(classification.Status.MatchedCountByValue).To(Equal(map[string]int{"small": 2, "large": 1}))
+	})
 })
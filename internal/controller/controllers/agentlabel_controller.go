@@ -20,6 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
 
 	"github.com/itchyny/gojq"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
@@ -27,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -63,10 +68,7 @@ func (r *AgentLabelReconciler) Reconcile(origCtx context.Context, req ctrl.Reque
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// Get the inventory into interfaces by way of json marshal/unmarshal
-	var inventoryInterface interface{}
-	jsonInventory, _ := json.Marshal(agent.Status.Inventory)
-	_ = json.Unmarshal(jsonInventory, &inventoryInterface)
+	inventoryInterface := agentInventoryInterface(agent)
 
 	classifications := aiv1beta1.AgentClassificationList{}
 	opts := &client.ListOptions{
@@ -78,28 +80,23 @@ func (r *AgentLabelReconciler) Reconcile(origCtx context.Context, req ctrl.Reque
 	}
 
 	changed := false
-	for _, classification := range classifications.Items {
+	for i := range classifications.Items {
+		classification := &classifications.Items[i]
+		labelKey := ClassificationLabelPrefix + classification.Spec.LabelKey
 		if !classification.DeletionTimestamp.IsZero() {
 			log.Infof("classification %s is being deleted", classification.Name)
-			changed = deleteAgentLabel(log, agent, ClassificationLabelPrefix+classification.Spec.LabelKey, classification.Spec.LabelValue) || changed
-			continue
-		}
-
-		query, err := gojq.Parse(classification.Spec.Query)
-		if err != nil {
-			// Should not happen - validated via webhook
-			log.Errorf("Failed to parse query: %s\n", query)
-			changed = setAgentLabel(log, agent, ClassificationLabelPrefix+classification.Spec.LabelKey, queryErrorValue(classification.Spec.LabelValue)) || changed
+			changed = deleteClassificationLabel(log, agent, classification) || changed
 			continue
 		}
 
-		matched, err := checkMatch(log, query, inventoryInterface)
+		value, err := classifyAgent(classification, inventoryInterface)
 		if err != nil {
-			changed = setAgentLabel(log, agent, ClassificationLabelPrefix+classification.Spec.LabelKey, queryErrorValue(classification.Spec.LabelValue)) || changed
-		} else if !matched {
-			changed = deleteAgentLabel(log, agent, ClassificationLabelPrefix+classification.Spec.LabelKey, classification.Spec.LabelValue) || changed
+			log.WithError(err).Debugf("Failed to apply classification %s", classification.Name)
+			changed = setAgentLabel(log, agent, labelKey, classificationErrorLabel(classification)) || changed
+		} else if value == "" {
+			changed = deleteClassificationLabel(log, agent, classification) || changed
 		} else {
-			changed = setAgentLabel(log, agent, ClassificationLabelPrefix+classification.Spec.LabelKey, classification.Spec.LabelValue) || changed
+			changed = setAgentLabel(log, agent, labelKey, value) || changed
 		}
 	}
 
@@ -113,11 +110,138 @@ func (r *AgentLabelReconciler) Reconcile(origCtx context.Context, req ctrl.Reque
 	return ctrl.Result{}, nil
 }
 
+// agentInventoryInterface returns the inventory of the Agent as the queries see it, by way of
+// json marshal/unmarshal
+func agentInventoryInterface(agent *aiv1beta1.Agent) interface{} {
+	var inventoryInterface interface{}
+	jsonInventory, _ := json.Marshal(agent.Status.Inventory)
+	_ = json.Unmarshal(jsonInventory, &inventoryInterface)
+	return inventoryInterface
+}
+
 func queryErrorValue(originalValue string) string {
 	return fmt.Sprintf("QUERYERROR-%s", originalValue)
 }
 
-func checkMatch(log *logrus.Entry, query *gojq.Query, inventoryInterface interface{}) (bool, error) {
+// classificationErrorLabel returns the label value applied when the classification fails, the
+// bare QUERYERROR when the suffixed value isn't a valid label value
+func classificationErrorLabel(classification *aiv1beta1.AgentClassification) string {
+	value := queryErrorValue(classificationErrorValue(classification))
+	if len(validation.IsValidLabelValue(value)) > 0 {
+		return "QUERYERROR"
+	}
+	return value
+}
+
+// classificationErrorValue is the value that the error label of the classification is based on,
+// the classifications with rules don't have a single label value
+func classificationErrorValue(classification *aiv1beta1.AgentClassification) string {
+	if len(classification.Spec.Rules) > 0 {
+		return classification.Name
+	}
+	return classification.Spec.LabelValue
+}
+
+// classifyAgent returns the label value of the classification for the inventory, an empty value
+// when the Agent doesn't match the classification
+func classifyAgent(classification *aiv1beta1.AgentClassification, inventoryInterface interface{}) (string, error) {
+	if len(classification.Spec.Rules) == 0 {
+		query, err := gojq.Parse(classification.Spec.Query)
+		if err != nil {
+			// Should not happen - validated via webhook
+			return "", errors.Wrapf(err, "failed to parse query %s", classification.Spec.Query)
+		}
+		matched, err := checkMatch(query, inventoryInterface)
+		if err != nil || !matched {
+			return "", err
+		}
+		return classification.Spec.LabelValue, nil
+	}
+
+	rules := make([]aiv1beta1.AgentClassificationRule, len(classification.Spec.Rules))
+	copy(rules, classification.Spec.Rules)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority > rules[j].Priority
+	})
+	for _, rule := range rules {
+		query, err := gojq.Parse(rule.Query)
+		if err != nil {
+			// Should not happen - validated via webhook
+			return "", errors.Wrapf(err, "failed to parse query %s", rule.Query)
+		}
+		result, err := runQuery(query, inventoryInterface)
+		if err != nil {
+			return "", err
+		}
+		value, err := ruleValue(&rule, result)
+		if err != nil {
+			return "", errors.Wrapf(err, "rule %s", rule.Name)
+		}
+		if value != "" {
+			return value, nil
+		}
+	}
+	return "", nil
+}
+
+// ruleValue returns the label value that the result of the query of the rule applies
+func ruleValue(rule *aiv1beta1.AgentClassificationRule, result interface{}) (string, error) {
+	switch v := result.(type) {
+	case nil:
+		return "", nil
+	case bool:
+		if !v {
+			return "", nil
+		}
+		if rule.LabelValue == "" {
+			return "", errors.New("the query returned true but the rule has no label value")
+		}
+		return rule.LabelValue, nil
+	case string:
+		if msgs := validation.IsValidLabelValue(v); len(msgs) > 0 {
+			return "", errors.Errorf("the query returned an invalid label value %q: %s", v, strings.Join(msgs, ", "))
+		}
+		return v, nil
+	case int, float64, *big.Int:
+		if len(rule.Tiers) == 0 {
+			return "", errors.New("the query returned a score but the rule has no tiers")
+		}
+		score := scoreOf(v)
+		if score == nil {
+			return "", nil
+		}
+		value := ""
+		var reached *int64
+		for i := range rule.Tiers {
+			tier := &rule.Tiers[i]
+			if score.Cmp(new(big.Float).SetInt64(tier.MinScore)) >= 0 && (reached == nil || tier.MinScore > *reached) {
+				value, reached = tier.LabelValue, &tier.MinScore
+			}
+		}
+		return value, nil
+	default:
+		return "", errors.Errorf("unexpected query result %v", result)
+	}
+}
+
+// scoreOf returns the number returned by a query, nil when it isn't a number
+func scoreOf(number interface{}) *big.Float {
+	switch n := number.(type) {
+	case int:
+		return new(big.Float).SetInt64(int64(n))
+	case float64:
+		if math.IsNaN(n) {
+			return nil
+		}
+		return big.NewFloat(n)
+	case *big.Int:
+		return new(big.Float).SetInt(n)
+	}
+	return nil
+}
+
+// runQuery returns the single value returned by the query
+func runQuery(query *gojq.Query, inventoryInterface interface{}) (interface{}, error) {
 	iter := query.Run(inventoryInterface)
 	values := []interface{}{}
 	for {
@@ -126,23 +250,43 @@ func checkMatch(log *logrus.Entry, query *gojq.Query, inventoryInterface interfa
 			break
 		}
 		if err, ok := v.(error); ok {
-			return false, err
+			return nil, err
 		}
 		values = append(values, v)
 	}
 	if len(values) == 0 {
-		return false, errors.New("Expected boolean, found no values")
+		return nil, errors.New("Expected a single value, found no values")
 	}
 	if len(values) > 1 {
-		return false, errors.New("Expected boolean, found multiple values")
+		return nil, errors.New("Expected a single value, found multiple values")
 	}
-	value := values[0]
-	if res, ok := value.(bool); ok {
-		if res {
-			return true, nil
-		}
+	return values[0], nil
+}
+
+func checkMatch(query *gojq.Query, inventoryInterface interface{}) (bool, error) {
+	value, err := runQuery(query, inventoryInterface)
+	if err != nil {
+		return false, err
+	}
+	res, ok := value.(bool)
+	return ok && res, nil
+}
+
+// deleteClassificationLabel removes the label of the classification from the Agent. The label
+// of a classification with rules is removed whatever its value, the classification owns its key.
+func deleteClassificationLabel(log *logrus.Entry, agent *aiv1beta1.Agent, classification *aiv1beta1.AgentClassification) bool {
+	labelKey := ClassificationLabelPrefix + classification.Spec.LabelKey
+	if len(classification.Spec.Rules) == 0 {
+		return deleteAgentLabel(log, agent, labelKey, classification.Spec.LabelValue)
 	}
-	return false, nil
+	labels := agent.GetLabels()
+	if _, ok := labels[labelKey]; !ok {
+		return false
+	}
+	delete(labels, labelKey)
+	agent.SetLabels(labels)
+	log.Infof("Deleted label %s from agent %s/%s", labelKey, agent.Namespace, agent.Name)
+	return true
 }
 
 func deleteAgentLabel(log *logrus.Entry, agent *aiv1beta1.Agent, labelKey, labelValue string) bool {
@@ -130,4 +130,90 @@ var _ = Describe("AgentLabel reconcile", func() {
 		Expect(len(agent.GetLabels())).To(Equal(1))
 		Expect(agent.GetLabels()[ClassificationLabelPrefix+"size"]).To(Equal("xlarge"))
 	})
+
+	It("AgentLabel with rules", func() {
+		classificationSpec := v1beta1.AgentClassificationSpec{
+			LabelKey: "capacity",
+			Rules: []v1beta1.AgentClassificationRule{
+				{
+					Name:  "size",
+					Query: ".cpu.count",
+					Tiers: []v1beta1.AgentClassificationTier{
+						{LabelValue: "large", MinScore: 8},
+						{LabelValue: "medium", MinScore: 4},
+					},
+				},
+				{
+					Name:       "compute",
+					Priority:   10,
+					Query:      ".cpu.count >= 16 and .memory.physicalBytes >= 68719476736",
+					LabelValue: "compute",
+				},
+				{
+					Name:     "memory",
+					Priority: 5,
+					Query:    `if .memory.physicalBytes >= 137438953472 then "memory" else null end`,
+				},
+			},
+		}
+		classification := newAgentClassification("capacity", testNamespace, classificationSpec, true)
+		Expect(c.Create(ctx, classification)).ShouldNot(HaveOccurred())
+
+		agent := newAgentWithInventory(agentName, testNamespace, 2, 4294967296)
+		Expect(c.Create(ctx, agent)).ShouldNot(HaveOccurred())
+
+		// No rule matches an Agent with 2 CPUs
+		reconcileAgent(agent)
+		agent = getTestAgent()
+		Expect(agent.GetLabels()).ToNot(HaveKey(ClassificationLabelPrefix + "capacity"))
+
+		for _, tc := range []struct {
+			cpu      int64
+			ram      int64
+			expected string
+		}{
+			{cpu: 4, ram: 17179869184, expected: "medium"},
+			{cpu: 12, ram: 17179869184, expected: "large"},
+			{cpu: 12, ram: 137438953472, expected: "memory"},
+			{cpu: 16, ram: 137438953472, expected: "compute"},
+		} {
+			agent.Status.Inventory.Cpu.Count = tc.cpu
+			agent.Status.Inventory.Memory.PhysicalBytes = tc.ram
+			Expect(c.Update(ctx, agent)).ShouldNot(HaveOccurred())
+			reconcileAgent(agent)
+			agent = getTestAgent()
+			Expect(agent.GetLabels()[ClassificationLabelPrefix+"capacity"]).To(Equal(tc.expected))
+		}
+
+		// The label is removed when no rule matches anymore
+		agent.Status.Inventory.Cpu.Count = 2
+		agent.Status.Inventory.Memory.PhysicalBytes = 4294967296
+		Expect(c.Update(ctx, agent)).ShouldNot(HaveOccurred())
+		reconcileAgent(agent)
+		agent = getTestAgent()
+		Expect(agent.GetLabels()).ToNot(HaveKey(ClassificationLabelPrefix + "capacity"))
+
+		// A rule that returns something that isn't a label value or a score is an error
+		classification.Spec.Rules = append(classification.Spec.Rules, v1beta1.AgentClassificationRule{
+			Name:     "object",
+			Priority: 20,
+			Query:    ".cpu",
+		})
+		Expect(c.Update(ctx, classification)).ShouldNot(HaveOccurred())
+		reconcileAgent(agent)
+		agent = getTestAgent()
+		Expect(agent.GetLabels()[ClassificationLabelPrefix+"capacity"]).To(Equal(queryErrorValue("capacity")))
+
+		// So is a rule that returns a string that isn't a valid label value
+		classification.Spec.Rules[len(classification.Spec.Rules)-1].Query = `"object"`
+		Expect(c.Update(ctx, classification)).ShouldNot(HaveOccurred())
+		reconcileAgent(agent)
+		agent = getTestAgent()
+		Expect(agent.GetLabels()[ClassificationLabelPrefix+"capacity"]).To(Equal("object"))
+		classification.Spec.Rules[len(classification.Spec.Rules)-1].Query = `"not a label value"`
+		Expect(c.Update(ctx, classification)).ShouldNot(HaveOccurred())
+		reconcileAgent(agent)
+		agent = getTestAgent()
+		Expect(agent.GetLabels()[ClassificationLabelPrefix+"capacity"]).To(Equal(queryErrorValue("capacity")))
+	})
 })
@@ -0,0 +1,500 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// AgentPoolLabel is set on the Agents bound by a pool, its value is the name of the pool
+	AgentPoolLabel = "agentpool." + aiv1beta1.Group + "/name"
+	// AgentPoolReplacedAnnotation is set on the Agents released by a pool because they failed their
+	// validations, its value is the name of the pool that won't bind them again until their
+	// validations pass
+	AgentPoolReplacedAnnotation = "agentpool." + aiv1beta1.Group + "/replaced-by"
+	// AgentPoolBoundAtAnnotation is set on the Agents bound by a pool, its value is the time of the
+	// binding
+	AgentPoolBoundAtAnnotation = "agentpool." + aiv1beta1.Group + "/bound-at"
+	// AgentPoolFinalizerName releases the Agents of a deleted pool
+	AgentPoolFinalizerName = "agentpool." + aiv1beta1.Group + "/ai-deprovision"
+)
+
+// agentPoolManagedStates are the states of the cluster in which the pool changes its Agents, once
+// the installation starts the Agents of the cluster don't change
+var agentPoolManagedStates = []string{
+	"",
+	models.ClusterStatusPendingForInput,
+	models.ClusterStatusInsufficient,
+	models.ClusterStatusReady,
+}
+
+// agentPoolReplaceGracePeriod is how long the validations of a bound Agent can fail before the pool
+// replaces it, the validations of the cluster are run again once the Agent is bound and some only
+// pass once the other Agents are bound
+const agentPoolReplaceGracePeriod = 10 * time.Minute
+
+// AgentPoolReconciler reconciles a AgentPool object
+type AgentPoolReconciler struct {
+	client.Client
+	Log logrus.FieldLogger
+}
+
+// agentPoolSelectors are the selectors of the Agents of each role
+type agentPoolSelectors struct {
+	controlPlane labels.Selector
+	worker       labels.Selector
+}
+
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agentpools,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agentpools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agentpools/finalizers,verbs=update
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agents,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=extensions.hive.openshift.io,resources=agentclusterinstalls,verbs=get;list;watch
+
+func (r *AgentPoolReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx := addRequestIdIfNeeded(origCtx)
+	log := logutil.FromContext(ctx, r.Log).WithFields(
+		logrus.Fields{
+			"agent_pool":           req.Name,
+			"agent_pool_namespace": req.Namespace,
+		})
+
+	defer func() {
+		log.Debug("AgentPool Reconcile ended")
+	}()
+
+	log.Debug("AgentPool Reconcile started")
+
+	pool := &aiv1beta1.AgentPool{}
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		log.WithError(err).Errorf("Failed to get AgentPool %s", req.NamespacedName)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !pool.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.deleteAgentPool(ctx, log, pool)
+	}
+	if !controllerutil.ContainsFinalizer(pool, AgentPoolFinalizerName) {
+		controllerutil.AddFinalizer(pool, AgentPoolFinalizerName)
+		if err := r.Update(ctx, pool); err != nil {
+			log.WithError(err).Errorf("failed to add finalizer %s to resource %s %s", AgentPoolFinalizerName, pool.Name, pool.Namespace)
+			return ctrl.Result{}, err
+		}
+	}
+
+	aci := &hiveext.AgentClusterInstall{}
+	if err := r.Get(ctx, agentPoolClusterInstallKey(pool), aci); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		setAgentPoolCondition(pool, corev1.ConditionFalse, aiv1beta1.AgentPoolClusterNotFoundReason,
+			fmt.Sprintf("AgentClusterInstall %s not found", agentPoolClusterInstallKey(pool)))
+		return ctrl.Result{}, r.updateAgentPoolStatus(ctx, log, pool, nil)
+	}
+
+	selectors, err := newAgentPoolSelectors(pool)
+	if err != nil {
+		setAgentPoolCondition(pool, corev1.ConditionFalse, aiv1beta1.AgentPoolInvalidReason, err.Error())
+		return ctrl.Result{}, r.updateAgentPoolStatus(ctx, log, pool, nil)
+	}
+
+	agents := &aiv1beta1.AgentList{}
+	if err = r.List(ctx, agents, client.InNamespace(pool.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	cluster := &aiv1beta1.ClusterReference{Name: aci.Spec.ClusterDeploymentRef.Name, Namespace: aci.Namespace}
+	members := agentPoolMembers(pool, cluster, agents.Items)
+	controlPlaneAgents, workerAgents := agentPoolDesiredAgents(pool, aci)
+
+	if !funk.ContainsString(agentPoolManagedStates, aci.Status.DebugInfo.State) {
+		setAgentPoolCondition(pool, corev1.ConditionTrue, aiv1beta1.AgentPoolInstallStartedReason,
+			fmt.Sprintf("The installation of the cluster started with %d control plane and %d worker Agents of the pool",
+				len(members[models.HostRoleMaster]), len(members[models.HostRoleWorker])))
+		return ctrl.Result{}, r.updateAgentPoolStatus(ctx, log, pool, members)
+	}
+
+	// Release the Agents that fail their validations past the grace period, and the Agents that the
+	// pool no longer needs. The released Agents aren't bound again by this reconcile, even to another
+	// role.
+	released := map[string]bool{}
+	var requeueAfter time.Duration
+	now := time.Now()
+	for _, role := range []models.HostRole{models.HostRoleMaster, models.HostRoleWorker} {
+		desired := controlPlaneAgents
+		if role == models.HostRoleWorker {
+			desired = workerAgents
+		}
+		var kept []*aiv1beta1.Agent
+		for _, agent := range members[role] {
+			if remaining, failing := agentValidationsFailingFor(agent, now); failing {
+				if remaining > 0 {
+					log.Infof("The validations of Agent %s of the pool are failing, it's replaced in %s unless they pass", agent.Name, remaining)
+					if requeueAfter == 0 || remaining < requeueAfter {
+						requeueAfter = remaining
+					}
+					kept = append(kept, agent)
+					continue
+				}
+				log.Infof("Replacing Agent %s of the pool, its validations are failing", agent.Name)
+				if err = r.releaseAgent(ctx, log, agent, pool.Name); err != nil {
+					return ctrl.Result{}, err
+				}
+				released[agent.Name] = true
+				if !funk.ContainsString(pool.Status.ReplacedAgents, agent.Name) {
+					pool.Status.ReplacedAgents = append(pool.Status.ReplacedAgents, agent.Name)
+				}
+				continue
+			}
+			kept = append(kept, agent)
+		}
+		for len(kept) > desired {
+			agent := kept[len(kept)-1]
+			log.Infof("Releasing Agent %s, the pool has more %s Agents than desired", agent.Name, role)
+			if err = r.releaseAgent(ctx, log, agent, ""); err != nil {
+				return ctrl.Result{}, err
+			}
+			released[agent.Name] = true
+			kept = kept[:len(kept)-1]
+		}
+		members[role] = kept
+	}
+
+	// Bind the best fitting Agents to the missing roles
+	candidates := agentPoolCandidates(pool, agents.Items, released)
+	for _, role := range []models.HostRole{models.HostRoleMaster, models.HostRoleWorker} {
+		desired, selector := controlPlaneAgents, selectors.controlPlane
+		if role == models.HostRoleWorker {
+			desired, selector = workerAgents, selectors.worker
+		}
+		for i := 0; i < len(candidates) && len(members[role]) < desired; {
+			agent := candidates[i]
+			if !selector.Matches(labels.Set(agent.GetLabels())) {
+				i++
+				continue
+			}
+			log.Infof("Binding Agent %s to cluster %s/%s with role %s", agent.Name, cluster.Namespace, cluster.Name, role)
+			if err = r.bindAgent(ctx, log, agent, pool.Name, cluster, role); err != nil {
+				return ctrl.Result{}, err
+			}
+			members[role] = append(members[role], agent)
+			candidates = append(candidates[:i], candidates[i+1:]...)
+		}
+	}
+
+	missingControlPlane := controlPlaneAgents - len(members[models.HostRoleMaster])
+	missingWorkers := workerAgents - len(members[models.HostRoleWorker])
+	if missingControlPlane > 0 || missingWorkers > 0 {
+		setAgentPoolCondition(pool, corev1.ConditionFalse, aiv1beta1.AgentPoolMissingAgentsReason,
+			fmt.Sprintf("The pool is missing %d control plane and %d worker Agents, no other approved Agent matches the pool",
+				max(missingControlPlane, 0), max(missingWorkers, 0)))
+	} else {
+		setAgentPoolCondition(pool, corev1.ConditionTrue, aiv1beta1.AgentPoolSatisfiedReason,
+			fmt.Sprintf("The pool has %d control plane and %d worker Agents", controlPlaneAgents, workerAgents))
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, r.updateAgentPoolStatus(ctx, log, pool, members)
+}
+
+func agentPoolClusterInstallKey(pool *aiv1beta1.AgentPool) types.NamespacedName {
+	namespace := pool.Spec.AgentClusterInstallRef.Namespace
+	if namespace == "" {
+		namespace = pool.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: pool.Spec.AgentClusterInstallRef.Name}
+}
+
+// agentPoolDesiredAgents returns the desired number of control plane and worker Agents, the
+// provision requirements of the cluster when the pool doesn't set them
+func agentPoolDesiredAgents(pool *aiv1beta1.AgentPool, aci *hiveext.AgentClusterInstall) (controlPlane, workers int) {
+	controlPlane = aci.Spec.ProvisionRequirements.ControlPlaneAgents
+	if pool.Spec.ControlPlaneAgents != nil {
+		controlPlane = *pool.Spec.ControlPlaneAgents
+	}
+	workers = aci.Spec.ProvisionRequirements.WorkerAgents
+	if pool.Spec.WorkerAgents != nil {
+		workers = *pool.Spec.WorkerAgents
+	}
+	return
+}
+
+func newAgentPoolSelectors(pool *aiv1beta1.AgentPool) (*agentPoolSelectors, error) {
+	toSelector := func(name string, labelSelector *metav1.LabelSelector) (labels.Selector, error) {
+		if labelSelector == nil {
+			return labels.Everything(), nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		return selector, nil
+	}
+	controlPlane, err := toSelector("controlPlaneSelector", pool.Spec.ControlPlaneSelector)
+	if err != nil {
+		return nil, err
+	}
+	worker, err := toSelector("workerSelector", pool.Spec.WorkerSelector)
+	if err != nil {
+		return nil, err
+	}
+	// The agent selector is validated with the candidates
+	if _, err = toSelector("agentSelector", pool.Spec.AgentSelector); err != nil {
+		return nil, err
+	}
+	return &agentPoolSelectors{controlPlane: controlPlane, worker: worker}, nil
+}
+
+// agentPoolMembers returns the Agents bound by the pool to its cluster, by role
+func agentPoolMembers(pool *aiv1beta1.AgentPool, cluster *aiv1beta1.ClusterReference, agents []aiv1beta1.Agent) map[models.HostRole][]*aiv1beta1.Agent {
+	members := map[models.HostRole][]*aiv1beta1.Agent{}
+	for i := range agents {
+		agent := &agents[i]
+		if agent.GetLabels()[AgentPoolLabel] != pool.Name || agent.Spec.ClusterDeploymentName == nil ||
+			*agent.Spec.ClusterDeploymentName != *cluster {
+			continue
+		}
+		members[agent.Spec.Role] = append(members[agent.Spec.Role], agent)
+	}
+	for role := range members {
+		sortAgentsByFit(members[role])
+	}
+	return members
+}
+
+// agentPoolCandidates returns the Agents that the pool can bind, the best fitting Agents first,
+// except the Agents that it just released and the Agents that it replaced until their validations
+// pass
+func agentPoolCandidates(pool *aiv1beta1.AgentPool, agents []aiv1beta1.Agent, released map[string]bool) []*aiv1beta1.Agent {
+	selector := labels.Everything()
+	if pool.Spec.AgentSelector != nil {
+		selector, _ = metav1.LabelSelectorAsSelector(pool.Spec.AgentSelector)
+	}
+	var candidates []*aiv1beta1.Agent
+	for i := range agents {
+		agent := &agents[i]
+		if released[agent.Name] || agent.Spec.ClusterDeploymentName != nil || !agent.Spec.Approved || !agent.DeletionTimestamp.IsZero() ||
+			agent.Status.Inventory.Cpu.Count == 0 || agentValidationsFailing(agent) ||
+			(agent.GetAnnotations()[AgentPoolReplacedAnnotation] == pool.Name && !agentValidationsPassing(agent)) ||
+			!selector.Matches(labels.Set(agent.GetLabels())) {
+			continue
+		}
+		candidates = append(candidates, agent)
+	}
+	sortAgentsByFit(candidates)
+	return candidates
+}
+
+// sortAgentsByFit sorts the Agents from the smallest to the largest, so the pool binds the
+// smallest Agents that match it and leaves the larger ones to the pools that need them
+func sortAgentsByFit(agents []*aiv1beta1.Agent) {
+	sort.SliceStable(agents, func(i, j int) bool {
+		a, b := agents[i].Status.Inventory, agents[j].Status.Inventory
+		if a.Cpu.Count != b.Cpu.Count {
+			return a.Cpu.Count < b.Cpu.Count
+		}
+		if a.Memory.PhysicalBytes != b.Memory.PhysicalBytes {
+			return a.Memory.PhysicalBytes < b.Memory.PhysicalBytes
+		}
+		return agents[i].Name < agents[j].Name
+	})
+}
+
+func agentValidationsFailing(agent *aiv1beta1.Agent) bool {
+	condition := conditionsv1.FindStatusCondition(agent.Status.Conditions, aiv1beta1.ValidatedCondition)
+	return condition != nil && condition.Reason == aiv1beta1.ValidationsFailingReason
+}
+
+func agentValidationsPassing(agent *aiv1beta1.Agent) bool {
+	condition := conditionsv1.FindStatusCondition(agent.Status.Conditions, aiv1beta1.ValidatedCondition)
+	return condition != nil && condition.Reason == aiv1beta1.ValidationsPassingReason
+}
+
+// agentValidationsFailingFor checks if the validations of a bound Agent are failing, and returns how
+// long they can still fail before the grace period ends. The grace period starts when the Agent is
+// bound, or when its validations started failing if they did afterwards.
+func agentValidationsFailingFor(agent *aiv1beta1.Agent, now time.Time) (time.Duration, bool) {
+	condition := conditionsv1.FindStatusCondition(agent.Status.Conditions, aiv1beta1.ValidatedCondition)
+	if condition == nil || condition.Reason != aiv1beta1.ValidationsFailingReason {
+		return 0, false
+	}
+	since := condition.LastTransitionTime.Time
+	if boundAt, err := time.Parse(time.RFC3339, agent.GetAnnotations()[AgentPoolBoundAtAnnotation]); err == nil && boundAt.After(since) {
+		since = boundAt
+	}
+	return max(since.Add(agentPoolReplaceGracePeriod).Sub(now), 0), true
+}
+
+func (r *AgentPoolReconciler) bindAgent(ctx context.Context, log logrus.FieldLogger, agent *aiv1beta1.Agent, poolName string, cluster *aiv1beta1.ClusterReference, role models.HostRole) error {
+	agent.Spec.ClusterDeploymentName = &aiv1beta1.ClusterReference{Name: cluster.Name, Namespace: cluster.Namespace}
+	agent.Spec.Role = role
+	setAgentLabel(log, agent, AgentPoolLabel, poolName)
+	setAgentAnnotation(log, agent, AgentPoolBoundAtAnnotation, time.Now().UTC().Format(time.RFC3339))
+	delete(agent.Annotations, AgentPoolReplacedAnnotation)
+	return r.Update(ctx, agent)
+}
+
+// releaseAgent unbinds the Agent from the cluster of the pool, the Agents released because they
+// failed their validations are marked so the pool doesn't bind them again
+func (r *AgentPoolReconciler) releaseAgent(ctx context.Context, log logrus.FieldLogger, agent *aiv1beta1.Agent, replacedBy string) error {
+	agent.Spec.ClusterDeploymentName = nil
+	agent.Spec.Role = ""
+	delete(agent.Labels, AgentPoolLabel)
+	delete(agent.Annotations, AgentPoolBoundAtAnnotation)
+	if replacedBy != "" {
+		setAgentAnnotation(log, agent, AgentPoolReplacedAnnotation, replacedBy)
+	}
+	return r.Update(ctx, agent)
+}
+
+// deleteAgentPool releases the Agents of the pool before removing its finalizer. Once the
+// installation of the cluster started the Agents stay bound, they only lose the label of the pool.
+func (r *AgentPoolReconciler) deleteAgentPool(ctx context.Context, log logrus.FieldLogger, pool *aiv1beta1.AgentPool) error {
+	if !controllerutil.ContainsFinalizer(pool, AgentPoolFinalizerName) {
+		return nil
+	}
+	installing := false
+	aci := &hiveext.AgentClusterInstall{}
+	if err := r.Get(ctx, agentPoolClusterInstallKey(pool), aci); err == nil {
+		installing = !funk.ContainsString(agentPoolManagedStates, aci.Status.DebugInfo.State)
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	agents := &aiv1beta1.AgentList{}
+	if err := r.List(ctx, agents, client.InNamespace(pool.Namespace), client.MatchingLabels{AgentPoolLabel: pool.Name}); err != nil {
+		return err
+	}
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		if installing {
+			delete(agent.Labels, AgentPoolLabel)
+			if err := r.Update(ctx, agent); err != nil {
+				return err
+			}
+			continue
+		}
+		log.Infof("Releasing Agent %s, its pool is deleted", agent.Name)
+		if err := r.releaseAgent(ctx, log, agent, ""); err != nil {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(pool, AgentPoolFinalizerName)
+	if err := r.Update(ctx, pool); err != nil {
+		log.WithError(err).Errorf("failed to remove finalizer %s from resource %s %s", AgentPoolFinalizerName, pool.Name, pool.Namespace)
+		return err
+	}
+	return nil
+}
+
+func setAgentPoolCondition(pool *aiv1beta1.AgentPool, status corev1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusConditionNoHeartbeat(&pool.Status.Conditions, conditionsv1.Condition{
+		Type:    aiv1beta1.AgentPoolSatisfiedCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func (r *AgentPoolReconciler) updateAgentPoolStatus(ctx context.Context, log logrus.FieldLogger, pool *aiv1beta1.AgentPool, members map[models.HostRole][]*aiv1beta1.Agent) error {
+	pool.Status.ControlPlaneAgents = len(members[models.HostRoleMaster])
+	pool.Status.WorkerAgents = len(members[models.HostRoleWorker])
+	pool.Status.Members = nil
+	for _, role := range []models.HostRole{models.HostRoleMaster, models.HostRoleWorker} {
+		for _, agent := range members[role] {
+			pool.Status.Members = append(pool.Status.Members, aiv1beta1.AgentPoolMember{Name: agent.Name, Role: role})
+		}
+	}
+	if err := r.Status().Update(ctx, pool); err != nil {
+		log.WithError(err).Error("failed to update agent pool status")
+		return err
+	}
+	return nil
+}
+
+func (r *AgentPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	mapAgentToAgentPools := func(ctx context.Context, agent client.Object) []reconcile.Request {
+		log := logutil.FromContext(ctx, r.Log).WithFields(
+			logrus.Fields{
+				"agent":           agent.GetName(),
+				"agent_namespace": agent.GetNamespace(),
+			})
+		poolList := &aiv1beta1.AgentPoolList{}
+		if err := r.List(ctx, poolList, client.InNamespace(agent.GetNamespace())); err != nil {
+			log.Debugf("failed to list agent pools")
+			return []reconcile.Request{}
+		}
+		reply := make([]reconcile.Request, 0, len(poolList.Items))
+		for _, pool := range poolList.Items {
+			reply = append(reply, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: pool.Namespace,
+				Name:      pool.Name,
+			}})
+		}
+		return reply
+	}
+
+	mapClusterInstallToAgentPools := func(ctx context.Context, aci client.Object) []reconcile.Request {
+		log := logutil.FromContext(ctx, r.Log).WithFields(
+			logrus.Fields{
+				"agent_cluster_install":           aci.GetName(),
+				"agent_cluster_install_namespace": aci.GetNamespace(),
+			})
+		poolList := &aiv1beta1.AgentPoolList{}
+		if err := r.List(ctx, poolList); err != nil {
+			log.Debugf("failed to list agent pools")
+			return []reconcile.Request{}
+		}
+		reply := []reconcile.Request{}
+		for i := range poolList.Items {
+			pool := &poolList.Items[i]
+			if agentPoolClusterInstallKey(pool) == (types.NamespacedName{Namespace: aci.GetNamespace(), Name: aci.GetName()}) {
+				reply = append(reply, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: pool.Namespace,
+					Name:      pool.Name,
+				}})
+			}
+		}
+		return reply
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&aiv1beta1.AgentPool{}).
+		Watches(&aiv1beta1.Agent{}, handler.EnqueueRequestsFromMapFunc(mapAgentToAgentPools)).
+		Watches(&hiveext.AgentClusterInstall{}, handler.EnqueueRequestsFromMapFunc(mapClusterInstallToAgentPools)).
+		Complete(r)
+}
@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	"github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPoolAgent(name string, cpus int64, memoryGiB int64, agentLabels map[string]string) *v1beta1.Agent {
+	return &v1beta1.Agent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    agentLabels,
+		},
+		Spec: v1beta1.AgentSpec{Approved: true},
+		Status: v1beta1.AgentStatus{
+			Inventory: v1beta1.HostInventory{
+				Cpu:    v1beta1.HostCPU{Count: cpus},
+				Memory: v1beta1.HostMemory{PhysicalBytes: memoryGiB * 1024 * 1024 * 1024},
+			},
+		},
+	}
+}
+
+var _ = Describe("AgentPool reconcile", func() {
+	var (
+		c       client.Client
+		r       *AgentPoolReconciler
+		ctx     = context.Background()
+		aci     *hiveext.AgentClusterInstall
+		pool    *v1beta1.AgentPool
+		cluster = v1beta1.ClusterReference{Name: "test-cluster", Namespace: testNamespace}
+	)
+
+	BeforeEach(func() {
+		c = fakeclient.NewClientBuilder().WithStatusSubresource(&v1beta1.AgentPool{}, &v1beta1.Agent{}).Build()
+		r = &AgentPoolReconciler{
+			Client: c,
+			Log:    common.GetTestLog(),
+		}
+		aci = &hiveext.AgentClusterInstall{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-aci", Namespace: testNamespace},
+			Spec: hiveext.AgentClusterInstallSpec{
+				ClusterDeploymentRef:  corev1.LocalObjectReference{Name: cluster.Name},
+				ProvisionRequirements: hiveext.ProvisionRequirements{ControlPlaneAgents: 1, WorkerAgents: 1},
+			},
+		}
+		Expect(c.Create(ctx, aci)).To(Succeed())
+		pool = &v1beta1.AgentPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: testNamespace},
+			Spec: v1beta1.AgentPoolSpec{
+				AgentClusterInstallRef: v1beta1.ClusterReference{Name: aci.Name},
+			},
+		}
+	})
+
+	reconcilePoolResult := func() (ctrl.Result, *v1beta1.AgentPool) {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}})
+		Expect(err).ToNot(HaveOccurred())
+		updated := &v1beta1.AgentPool{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}, updated)).To(Succeed())
+		return result, updated
+	}
+
+	reconcilePool := func() *v1beta1.AgentPool {
+		result, updated := reconcilePoolResult()
+		Expect(result).To(Equal(ctrl.Result{}))
+		return updated
+	}
+
+	getAgent := func(name string) *v1beta1.Agent {
+		agent := &v1beta1.Agent{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: name}, agent)).To(Succeed())
+		return agent
+	}
+
+	expectBound := func(name string, role models.HostRole) {
+		agent := getAgent(name)
+		Expect(agent.Spec.ClusterDeploymentName).To(Equal(&cluster))
+		Expect(agent.Spec.Role).To(Equal(role))
+		Expect(agent.Labels).To(HaveKeyWithValue(AgentPoolLabel, pool.Name))
+	}
+
+	expectUnbound := func(name string) {
+		agent := getAgent(name)
+		Expect(agent.Spec.ClusterDeploymentName).To(BeNil())
+		Expect(agent.Labels).ToNot(HaveKey(AgentPoolLabel))
+	}
+
+	It("binds the best fitting agents of each role", func() {
+		pool.Spec.ControlPlaneSelector = &metav1.LabelSelector{MatchLabels: map[string]string{ClassificationLabelPrefix + "size": "large"}}
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		for _, agent := range []*v1beta1.Agent{
+			newPoolAgent("small", 4, 16, nil),
+			newPoolAgent("medium", 8, 32, nil),
+			newPoolAgent("large", 16, 64, map[string]string{ClassificationLabelPrefix + "size": "large"}),
+			newPoolAgent("huge", 32, 128, map[string]string{ClassificationLabelPrefix + "size": "large"}),
+		} {
+			Expect(c.Create(ctx, agent)).To(Succeed())
+		}
+
+		updated := reconcilePool()
+		expectBound("large", models.HostRoleMaster)
+		expectBound("small", models.HostRoleWorker)
+		expectUnbound("medium")
+		expectUnbound("huge")
+		Expect(updated.Status.ControlPlaneAgents).To(Equal(1))
+		Expect(updated.Status.WorkerAgents).To(Equal(1))
+		Expect(updated.Status.Members).To(Equal([]v1beta1.AgentPoolMember{
+			{Name: "large", Role: models.HostRoleMaster},
+			{Name: "small", Role: models.HostRoleWorker},
+		}))
+		condition := conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentPoolSatisfiedCondition)
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(v1beta1.AgentPoolSatisfiedReason))
+	})
+
+	It("skips the agents that aren't approved, selected or already bound", func() {
+		pool.Spec.AgentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "3"}}
+		pool.Spec.WorkerAgents = ptr.To(2)
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		notApproved := newPoolAgent("not-approved", 4, 16, map[string]string{"rack": "3"})
+		notApproved.Spec.Approved = false
+		bound := newPoolAgent("bound", 4, 16, map[string]string{"rack": "3"})
+		bound.Spec.ClusterDeploymentName = &v1beta1.ClusterReference{Name: "other", Namespace: testNamespace}
+		noInventory := newPoolAgent("no-inventory", 0, 0, map[string]string{"rack": "3"})
+		failing := newPoolAgent("failing", 4, 16, map[string]string{"rack": "3"})
+		failing.Status.Conditions = []conditionsv1.Condition{{Type: v1beta1.ValidatedCondition, Status: corev1.ConditionFalse, Reason: v1beta1.ValidationsFailingReason}}
+		for _, agent := range []*v1beta1.Agent{
+			notApproved, bound, noInventory, failing,
+			newPoolAgent("other-rack", 4, 16, map[string]string{"rack": "4"}),
+			newPoolAgent("selected", 4, 16, map[string]string{"rack": "3"}),
+		} {
+			Expect(c.Create(ctx, agent)).To(Succeed())
+		}
+
+		updated := reconcilePool()
+		expectBound("selected", models.HostRoleMaster)
+		for _, name := range []string{"not-approved", "no-inventory", "failing", "other-rack"} {
+			expectUnbound(name)
+		}
+		Expect(getAgent("bound").Spec.ClusterDeploymentName.Name).To(Equal("other"))
+		condition := conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentPoolSatisfiedCondition)
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(v1beta1.AgentPoolMissingAgentsReason))
+		Expect(condition.Message).To(ContainSubstring("missing 0 control plane and 2 worker Agents"))
+	})
+
+	It("replaces the agents that fail their validations", func() {
+		pool.Spec.WorkerAgents = ptr.To(0)
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		Expect(c.Create(ctx, newPoolAgent("first", 4, 16, nil))).To(Succeed())
+		Expect(c.Create(ctx, newPoolAgent("second", 8, 32, nil))).To(Succeed())
+		reconcilePool()
+		expectBound("first", models.HostRoleMaster)
+
+		first := getAgent("first")
+		Expect(first.Annotations).To(HaveKey(AgentPoolBoundAtAnnotation))
+		first.Status.Conditions = []conditionsv1.Condition{{Type: v1beta1.ValidatedCondition, Status: corev1.ConditionFalse,
+			Reason: v1beta1.ValidationsFailingReason, LastTransitionTime: metav1.Now()}}
+		Expect(c.Status().Update(ctx, first)).To(Succeed())
+
+		By("keeping the agent during the grace period")
+		result, _ := reconcilePoolResult()
+		Expect(result.RequeueAfter).To(BeNumerically("~", agentPoolReplaceGracePeriod, time.Minute))
+		expectBound("first", models.HostRoleMaster)
+
+		By("measuring the grace period from the binding")
+		first = getAgent("first")
+		first.Annotations[AgentPoolBoundAtAnnotation] = time.Now().Add(-agentPoolReplaceGracePeriod / 2).UTC().Format(time.RFC3339)
+		Expect(c.Update(ctx, first)).To(Succeed())
+		first.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * agentPoolReplaceGracePeriod))
+		Expect(c.Status().Update(ctx, first)).To(Succeed())
+		result, _ = reconcilePoolResult()
+		Expect(result.RequeueAfter).To(BeNumerically("~", agentPoolReplaceGracePeriod/2, time.Minute))
+		expectBound("first", models.HostRoleMaster)
+
+		By("replacing the agent once the grace period ends")
+		first = getAgent("first")
+		first.Annotations[AgentPoolBoundAtAnnotation] = time.Now().Add(-agentPoolReplaceGracePeriod).UTC().Format(time.RFC3339)
+		Expect(c.Update(ctx, first)).To(Succeed())
+		updated := reconcilePool()
+		expectUnbound("first")
+		Expect(getAgent("first").Annotations).To(HaveKeyWithValue(AgentPoolReplacedAnnotation, pool.Name))
+		expectBound("second", models.HostRoleMaster)
+		Expect(updated.Status.ReplacedAgents).To(Equal([]string{"first"}))
+
+		By("not binding the replaced agent again until its validations pass")
+		first = getAgent("first")
+		first.Status.Conditions = nil
+		Expect(c.Status().Update(ctx, first)).To(Succeed())
+		reconcilePool()
+		expectUnbound("first")
+	})
+
+	It("binds the replaced agents again once their validations pass", func() {
+		pool.Spec.WorkerAgents = ptr.To(0)
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		replaced := newPoolAgent("replaced", 4, 16, nil)
+		replaced.Annotations = map[string]string{AgentPoolReplacedAnnotation: pool.Name}
+		Expect(c.Create(ctx, replaced)).To(Succeed())
+		reconcilePool()
+		expectUnbound("replaced")
+
+		replaced = getAgent("replaced")
+		replaced.Status.Conditions = []conditionsv1.Condition{{Type: v1beta1.ValidatedCondition, Status: corev1.ConditionTrue, Reason: v1beta1.ValidationsPassingReason}}
+		Expect(c.Status().Update(ctx, replaced)).To(Succeed())
+		reconcilePool()
+		expectBound("replaced", models.HostRoleMaster)
+		Expect(getAgent("replaced").Annotations).ToNot(HaveKey(AgentPoolReplacedAnnotation))
+	})
+
+	It("releases the agents that the pool no longer needs", func() {
+		pool.Spec.WorkerAgents = ptr.To(2)
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		for _, name := range []string{"a", "b", "c"} {
+			Expect(c.Create(ctx, newPoolAgent(name, 4, 16, nil))).To(Succeed())
+		}
+		reconcilePool()
+		expectBound("b", models.HostRoleWorker)
+		expectBound("c", models.HostRoleWorker)
+
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}, pool)).To(Succeed())
+		pool.Spec.WorkerAgents = ptr.To(1)
+		Expect(c.Update(ctx, pool)).To(Succeed())
+		updated := reconcilePool()
+		expectBound("b", models.HostRoleWorker)
+		expectUnbound("c")
+		Expect(updated.Status.WorkerAgents).To(Equal(1))
+	})
+
+	It("doesn't bind the agents it released to another role", func() {
+		pool.Spec.ControlPlaneAgents = ptr.To(0)
+		pool.Spec.WorkerAgents = ptr.To(2)
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		for _, name := range []string{"a", "b"} {
+			Expect(c.Create(ctx, newPoolAgent(name, 4, 16, nil))).To(Succeed())
+		}
+		reconcilePool()
+		expectBound("a", models.HostRoleWorker)
+		expectBound("b", models.HostRoleWorker)
+
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}, pool)).To(Succeed())
+		pool.Spec.ControlPlaneAgents = ptr.To(1)
+		pool.Spec.WorkerAgents = ptr.To(1)
+		Expect(c.Update(ctx, pool)).To(Succeed())
+		updated := reconcilePool()
+		expectBound("a", models.HostRoleWorker)
+		expectUnbound("b")
+		Expect(updated.Status.ControlPlaneAgents).To(Equal(0))
+	})
+
+	It("releases the agents of a deleted pool", func() {
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		for _, name := range []string{"a", "b"} {
+			Expect(c.Create(ctx, newPoolAgent(name, 4, 16, nil))).To(Succeed())
+		}
+		updated := reconcilePool()
+		Expect(updated.Finalizers).To(ContainElement(AgentPoolFinalizerName))
+		expectBound("a", models.HostRoleMaster)
+		expectBound("b", models.HostRoleWorker)
+
+		Expect(c.Delete(ctx, updated)).To(Succeed())
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}})
+		Expect(err).ToNot(HaveOccurred())
+		expectUnbound("a")
+		expectUnbound("b")
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}, &v1beta1.AgentPool{})).ToNot(Succeed())
+	})
+
+	It("doesn't change the agents once the installation started", func() {
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		failing := newPoolAgent("failing", 4, 16, map[string]string{AgentPoolLabel: pool.Name})
+		failing.Spec.ClusterDeploymentName = &cluster
+		failing.Spec.Role = models.HostRoleMaster
+		failing.Status.Conditions = []conditionsv1.Condition{{Type: v1beta1.ValidatedCondition, Status: corev1.ConditionFalse, Reason: v1beta1.ValidationsFailingReason}}
+		Expect(c.Create(ctx, failing)).To(Succeed())
+		Expect(c.Create(ctx, newPoolAgent("worker", 4, 16, nil))).To(Succeed())
+		aci.Status.DebugInfo.State = models.ClusterStatusInstalling
+		Expect(c.Update(ctx, aci)).To(Succeed())
+
+		updated := reconcilePool()
+		expectBound("failing", models.HostRoleMaster)
+		expectUnbound("worker")
+		condition := conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentPoolSatisfiedCondition)
+		Expect(condition.Reason).To(Equal(v1beta1.AgentPoolInstallStartedReason))
+		Expect(updated.Status.ControlPlaneAgents).To(Equal(1))
+	})
+
+	It("reports a missing cluster", func() {
+		pool.Spec.AgentClusterInstallRef.Name = "missing"
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		updated := reconcilePool()
+		condition := conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentPoolSatisfiedCondition)
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(v1beta1.AgentPoolClusterNotFoundReason))
+	})
+
+	It("reports an invalid selector", func() {
+		pool.Spec.WorkerSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "size", Operator: "Bigger"}}}
+		Expect(c.Create(ctx, pool)).To(Succeed())
+		updated := reconcilePool()
+		condition := conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentPoolSatisfiedCondition)
+		Expect(condition.Reason).To(Equal(v1beta1.AgentPoolInvalidReason))
+		Expect(condition.Message).To(ContainSubstring("workerSelector"))
+	})
+})
@@ -0,0 +1,452 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// AgentRemovalPowerOffAnnotation is set on the BMH of an Agent removed with the PowerOff action,
+// its value is the name of the AgentRemoval. BMAC keeps the host powered off while it's set.
+const AgentRemovalPowerOffAnnotation = "agentremoval." + aiv1beta1.Group + "/power-off"
+
+const agentRemovalDrainRequeue = 20 * time.Second
+
+// agentRemovalInstalledStates are the states of the Agents whose node is part of the cluster
+var agentRemovalInstalledStates = []string{
+	models.HostStatusInstalled,
+	models.HostStatusAddedToExistingCluster,
+}
+
+// agentRemovalLeavingStates are the states of the unbound Agents whose host may still be running
+// the node, the node is removed once the host leaves them
+var agentRemovalLeavingStates = []string{
+	models.HostStatusUnbinding,
+	models.HostStatusReclaiming,
+	models.HostStatusReclaimingRebooting,
+}
+
+// agentRemovalReclaimedStates are the states of the Agents whose host is back in its InfraEnv
+var agentRemovalReclaimedStates = []string{
+	models.HostStatusKnownUnbound,
+	models.HostStatusInsufficientUnbound,
+	models.HostStatusDiscoveringUnbound,
+	models.HostStatusDisabledUnbound,
+}
+
+// AgentRemovalReconciler reconciles a AgentRemoval object
+type AgentRemovalReconciler struct {
+	client.Client
+	APIReader             client.Reader
+	Log                   logrus.FieldLogger
+	SpokeK8sClientFactory spoke_k8s_client.SpokeK8sClientFactory
+	Drainer               Drainer
+	EnableMetal3          bool
+}
+
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agentremovals,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agentremovals/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agents,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=hive.openshift.io,resources=clusterdeployments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch;update;patch
+
+func (r *AgentRemovalReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx := addRequestIdIfNeeded(origCtx)
+	log := logutil.FromContext(ctx, r.Log).WithFields(
+		logrus.Fields{
+			"agent_removal":           req.Name,
+			"agent_removal_namespace": req.Namespace,
+		})
+
+	defer func() {
+		log.Debug("AgentRemoval Reconcile ended")
+	}()
+
+	log.Debug("AgentRemoval Reconcile started")
+
+	removal := &aiv1beta1.AgentRemoval{}
+	if err := r.Get(ctx, req.NamespacedName, removal); err != nil {
+		log.WithError(err).Errorf("Failed to get AgentRemoval %s", req.NamespacedName)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if agentRemovalConditionIsTrue(removal, aiv1beta1.AgentRemovalCompletedCondition) {
+		return ctrl.Result{}, nil
+	}
+	log = log.WithField("agent", removal.Spec.AgentName)
+
+	agent := &aiv1beta1.Agent{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: removal.Namespace, Name: removal.Spec.AgentName}, agent); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCompletedCondition, corev1.ConditionFalse,
+			aiv1beta1.AgentRemovalAgentNotFoundReason, fmt.Sprintf("Agent %s not found", removal.Spec.AgentName))
+		return ctrl.Result{}, r.updateAgentRemovalStatus(ctx, log, removal)
+	}
+
+	if removal.Status.ClusterDeploymentName == nil {
+		if agent.Spec.ClusterDeploymentName == nil {
+			setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue,
+				aiv1beta1.AgentRemovalAgentNotBoundReason, fmt.Sprintf("Agent %s is not bound to a cluster, there is nothing to remove", agent.Name))
+			return ctrl.Result{}, r.updateAgentRemovalStatus(ctx, log, removal)
+		}
+		// Removing a control plane Agent would leave the cluster without quorum
+		if agent.Status.Role != models.HostRoleWorker {
+			setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCompletedCondition, corev1.ConditionFalse,
+				aiv1beta1.AgentRemovalAgentNotWorkerReason, fmt.Sprintf("Agent %s has the %s role, only the worker Agents can be removed", agent.Name, agent.Status.Role))
+			return ctrl.Result{}, r.updateAgentRemovalStatus(ctx, log, removal)
+		}
+		removal.Status.ClusterDeploymentName = &aiv1beta1.ClusterReference{
+			Name:      agent.Spec.ClusterDeploymentName.Name,
+			Namespace: agent.Spec.ClusterDeploymentName.Namespace,
+		}
+		removal.Status.NodeName = getAgentHostname(agent)
+	}
+
+	result, err := r.remove(ctx, log, removal, agent)
+	if err != nil {
+		log.WithError(err).Error("failed to remove agent")
+	}
+	if updateErr := r.updateAgentRemovalStatus(ctx, log, removal); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	return result, err
+}
+
+// remove runs the steps of the removal that aren't done yet, each step records its outcome in its
+// condition
+func (r *AgentRemovalReconciler) remove(ctx context.Context, log logrus.FieldLogger, removal *aiv1beta1.AgentRemoval, agent *aiv1beta1.Agent) (ctrl.Result, error) {
+	clusterRef := removal.Status.ClusterDeploymentName
+	bound := agent.Spec.ClusterDeploymentName != nil && *agent.Spec.ClusterDeploymentName == *clusterRef
+
+	if bound && !agentRemovalConditionIsTrue(removal, aiv1beta1.AgentRemovalDrainedCondition) {
+		if !funk.ContainsString(agentRemovalInstalledStates, agent.Status.DebugInfo.State) {
+			message := fmt.Sprintf("Agent %s is not installed, the cluster has no node to drain", agent.Name)
+			setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCordonedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNotInstalledReason, message)
+			setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNotInstalledReason, message)
+		} else if result, err := r.drainNode(ctx, log, removal); err != nil || !result.IsZero() {
+			return result, err
+		}
+	}
+
+	if !agentRemovalConditionIsTrue(removal, aiv1beta1.AgentRemovalUnboundCondition) {
+		if bound {
+			log.Infof("Unbinding agent from cluster %s/%s", clusterRef.Namespace, clusterRef.Name)
+			agent.Spec.ClusterDeploymentName = nil
+			if err := r.Update(ctx, agent); err != nil {
+				setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalUnboundCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalStepFailedReason, err.Error())
+				return ctrl.Result{}, err
+			}
+		}
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalUnboundCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalAgentUnboundReason,
+			fmt.Sprintf("Agent %s was unbound from cluster %s/%s", agent.Name, clusterRef.Namespace, clusterRef.Name))
+		if bound {
+			// The status of the Agent is updated by the Agent controller once it handles the unbind
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// The host is powered off once the Agent is unbound, so that the Agent controller handles the
+	// unbind of a host that is still running
+	if !agentRemovalConditionIsTrue(removal, aiv1beta1.AgentRemovalBareMetalHostCondition) {
+		if err := r.handleBMH(ctx, log, removal, agent); err != nil {
+			setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalBareMetalHostCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalStepFailedReason, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	if !agentRemovalConditionIsTrue(removal, aiv1beta1.AgentRemovalNodeRemovedCondition) {
+		if result, err := r.removeNode(ctx, log, removal, agent); err != nil || !result.IsZero() {
+			return result, err
+		}
+		if !agentRemovalConditionIsTrue(removal, aiv1beta1.AgentRemovalNodeRemovedCondition) {
+			return ctrl.Result{}, nil
+		}
+	}
+
+	state := agent.Status.DebugInfo.State
+	switch {
+	case removal.Spec.BareMetalHostAction == aiv1beta1.AgentRemovalBareMetalHostActionPowerOff &&
+		agentRemovalConditionReason(removal, aiv1beta1.AgentRemovalBareMetalHostCondition) == aiv1beta1.AgentRemovalBareMetalHostPoweredOffReason:
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalHostPoweredOffReason,
+			fmt.Sprintf("Agent %s was removed from cluster %s/%s and its host is powered off", agent.Name, clusterRef.Namespace, clusterRef.Name))
+	case funk.ContainsString(agentRemovalReclaimedStates, state):
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalHostReclaimedReason,
+			fmt.Sprintf("Agent %s was removed from cluster %s/%s and its host is back in its InfraEnv", agent.Name, clusterRef.Namespace, clusterRef.Name))
+	case state == models.HostStatusUnbindingPendingUserAction:
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCompletedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalPendingUserActionReason,
+			fmt.Sprintf("Agent %s was removed from cluster %s/%s, its host must be rebooted with the discovery ISO to return to its InfraEnv", agent.Name, clusterRef.Namespace, clusterRef.Name))
+	default:
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCompletedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalInProgressReason,
+			fmt.Sprintf("Waiting for the host of Agent %s to return to its InfraEnv, the Agent is %s", agent.Name, state))
+	}
+	return ctrl.Result{}, nil
+}
+
+// spokeClients returns the clients of the cluster that the Agent is removed from, nil when the
+// cluster no longer exists
+func (r *AgentRemovalReconciler) spokeClients(ctx context.Context, log logrus.FieldLogger, removal *aiv1beta1.AgentRemoval) (spoke_k8s_client.SpokeK8sClient, *kubernetes.Clientset, error) {
+	clusterRef := removal.Status.ClusterDeploymentName
+	clusterDeployment := &hivev1.ClusterDeployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: clusterRef.Namespace, Name: clusterRef.Name}, clusterDeployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	secret, err := spokeKubeconfigSecret(ctx, log, r.Client, r.APIReader, clusterRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.SpokeK8sClientFactory.ClientAndSetFromSecret(clusterDeployment, secret)
+}
+
+// drainNode cordons and drains the node of the Agent. The pods are evicted so the drain waits for
+// the pod disruption budgets, until the drain timeout of the removal if it has one.
+func (r *AgentRemovalReconciler) drainNode(ctx context.Context, log logrus.FieldLogger, removal *aiv1beta1.AgentRemoval) (ctrl.Result, error) {
+	nodeName := removal.Status.NodeName
+	log = log.WithField("node", nodeName)
+
+	spokeClient, clientset, err := r.spokeClients(ctx, log, removal)
+	if err != nil {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCordonedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalStepFailedReason,
+			fmt.Sprintf("Failed to connect to the cluster: %s", err.Error()))
+		return ctrl.Result{}, err
+	}
+	if spokeClient == nil {
+		message := fmt.Sprintf("ClusterDeployment %s/%s not found", removal.Status.ClusterDeploymentName.Namespace, removal.Status.ClusterDeploymentName.Name)
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCordonedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalClusterNotFoundReason, message)
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalClusterNotFoundReason, message)
+		return ctrl.Result{}, nil
+	}
+
+	node, err := spokeClient.GetNode(ctx, nodeName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCordonedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalStepFailedReason,
+				fmt.Sprintf("Failed to get node %s: %s", nodeName, err.Error()))
+			return ctrl.Result{}, err
+		}
+		message := fmt.Sprintf("Node %s not found", nodeName)
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCordonedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNodeNotFoundReason, message)
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNodeNotFoundReason, message)
+		return ctrl.Result{}, nil
+	}
+
+	drainHelper, out := newDrainHelper(ctx, log, clientset, node, nodeName)
+	if !agentRemovalConditionIsTrue(removal, aiv1beta1.AgentRemovalCordonedCondition) {
+		log.Info("Cordoning node")
+		if err = r.Drainer.RunCordonOrUncordon(drainHelper, node, true); err != nil {
+			log.WithError(err).Errorf("failed to cordon node: output: %s", out)
+			setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCordonedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalStepFailedReason,
+				fmt.Sprintf("Failed to cordon node %s: %s", nodeName, err.Error()))
+			return ctrl.Result{}, err
+		}
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalCordonedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNodeCordonedReason,
+			fmt.Sprintf("Node %s is cordoned", nodeName))
+	}
+
+	if removal.Status.DrainStartTime == nil {
+		now := metav1.Now()
+		removal.Status.DrainStartTime = &now
+	}
+	log.Info("Draining node")
+	if err = r.Drainer.RunNodeDrain(drainHelper, nodeName); err == nil {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNodeDrainedReason,
+			fmt.Sprintf("Node %s is drained", nodeName))
+		return ctrl.Result{}, nil
+	}
+	log.WithError(err).Infof("node is not drained yet")
+	log.Debugf("node drain output: %s", out)
+
+	if removal.Spec.DrainTimeout != nil && time.Since(removal.Status.DrainStartTime.Time) >= removal.Spec.DrainTimeout.Duration {
+		log.Warn("Timed out waiting to drain node, continuing with the removal")
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalDrainTimedOutReason,
+			fmt.Sprintf("Node %s was not drained within %s: %s", nodeName, removal.Spec.DrainTimeout.Duration, err.Error()))
+		return ctrl.Result{}, nil
+	}
+	setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalDrainedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalDrainInProgressReason,
+		fmt.Sprintf("Waiting for the pods of node %s to be evicted: %s", nodeName, err.Error()))
+	return ctrl.Result{RequeueAfter: agentRemovalDrainRequeue}, nil
+}
+
+// handleBMH handles the BMH of the unbound Agent. BMAC boots the BMH of an unbound Agent with the
+// discovery ISO, unless the removal powers the host off.
+func (r *AgentRemovalReconciler) handleBMH(ctx context.Context, log logrus.FieldLogger, removal *aiv1beta1.AgentRemoval, agent *aiv1beta1.Agent) error {
+	if !r.EnableMetal3 {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalBareMetalHostCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNoBareMetalHostReason,
+			fmt.Sprintf("Agent %s has no BareMetalHost", agent.Name))
+		return nil
+	}
+	bmh, err := getAgentBMH(ctx, r.Client, agent)
+	if err != nil {
+		return err
+	}
+	if bmh == nil {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalBareMetalHostCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNoBareMetalHostReason,
+			fmt.Sprintf("Agent %s has no BareMetalHost", agent.Name))
+		return nil
+	}
+
+	if removal.Spec.BareMetalHostAction != aiv1beta1.AgentRemovalBareMetalHostActionPowerOff {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalBareMetalHostCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalBareMetalHostReclaimReason,
+			fmt.Sprintf("BareMetalHost %s is booted with the discovery ISO to return to its InfraEnv", bmh.Name))
+		return nil
+	}
+
+	log.Infof("Powering off BMH %s", bmh.Name)
+	patch := client.MergeFrom(bmh.DeepCopy())
+	setAnnotation(&bmh.ObjectMeta, AgentRemovalPowerOffAnnotation, removal.Name)
+	bmh.Spec.Online = false
+	if err = r.Patch(ctx, bmh, patch); err != nil {
+		return err
+	}
+	setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalBareMetalHostCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalBareMetalHostPoweredOffReason,
+		fmt.Sprintf("BareMetalHost %s is powered off, remove the %s annotation to return it to its InfraEnv",
+			bmh.Name, AgentRemovalPowerOffAnnotation))
+	return nil
+}
+
+// removeNode removes the Node and the Machine of the Agent from the cluster, once the host no
+// longer runs the node
+func (r *AgentRemovalReconciler) removeNode(ctx context.Context, log logrus.FieldLogger, removal *aiv1beta1.AgentRemoval, agent *aiv1beta1.Agent) (ctrl.Result, error) {
+	nodeName := removal.Status.NodeName
+	log = log.WithField("node", nodeName)
+
+	if agentRemovalConditionReason(removal, aiv1beta1.AgentRemovalDrainedCondition) == aiv1beta1.AgentRemovalNotInstalledReason {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNotInstalledReason,
+			fmt.Sprintf("Agent %s was not installed, the cluster has no node to remove", agent.Name))
+		return ctrl.Result{}, nil
+	}
+	// The Agent is in an installed state until the Agent controller handles the unbind. A powered off
+	// host no longer runs the node, even when its Agent didn't leave the unbinding states.
+	poweredOff := agentRemovalConditionReason(removal, aiv1beta1.AgentRemovalBareMetalHostCondition) == aiv1beta1.AgentRemovalBareMetalHostPoweredOffReason
+	if state := agent.Status.DebugInfo.State; funk.ContainsString(agentRemovalInstalledStates, state) ||
+		(!poweredOff && funk.ContainsString(agentRemovalLeavingStates, state)) {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalWaitingForHostReason,
+			fmt.Sprintf("Waiting for the host of Agent %s to leave the cluster, the Agent is %s", agent.Name, state))
+		return ctrl.Result{}, nil
+	}
+
+	spokeClient, _, err := r.spokeClients(ctx, log, removal)
+	if err != nil {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalStepFailedReason,
+			fmt.Sprintf("Failed to connect to the cluster: %s", err.Error()))
+		return ctrl.Result{}, err
+	}
+	if spokeClient == nil {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalClusterNotFoundReason,
+			fmt.Sprintf("ClusterDeployment %s/%s not found", removal.Status.ClusterDeploymentName.Namespace, removal.Status.ClusterDeploymentName.Name))
+		return ctrl.Result{}, nil
+	}
+
+	if err = removeSpokeResources(ctx, log, spokeClient, nodeName); err != nil {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalStepFailedReason,
+			fmt.Sprintf("Failed to remove node %s: %s", nodeName, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// The node is removed along with its Machine when the Machine is part of a MachineSet
+	if err = spokeClient.Get(ctx, client.ObjectKey{Name: nodeName}, &corev1.Node{}); err == nil {
+		setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionFalse, aiv1beta1.AgentRemovalWaitingForNodeRemovalReason,
+			fmt.Sprintf("Waiting for node %s to be removed", nodeName))
+		return ctrl.Result{RequeueAfter: defaultRequeue}, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	setAgentRemovalCondition(removal, aiv1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionTrue, aiv1beta1.AgentRemovalNodeRemovedReason,
+		fmt.Sprintf("Node %s was removed from the cluster", nodeName))
+	return ctrl.Result{}, nil
+}
+
+func setAgentRemovalCondition(removal *aiv1beta1.AgentRemoval, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusConditionNoHeartbeat(&removal.Status.Conditions, conditionsv1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func agentRemovalConditionIsTrue(removal *aiv1beta1.AgentRemoval, conditionType conditionsv1.ConditionType) bool {
+	return conditionsv1.IsStatusConditionTrue(removal.Status.Conditions, conditionType)
+}
+
+func agentRemovalConditionReason(removal *aiv1beta1.AgentRemoval, conditionType conditionsv1.ConditionType) string {
+	if condition := conditionsv1.FindStatusCondition(removal.Status.Conditions, conditionType); condition != nil {
+		return condition.Reason
+	}
+	return ""
+}
+
+func (r *AgentRemovalReconciler) updateAgentRemovalStatus(ctx context.Context, log logrus.FieldLogger, removal *aiv1beta1.AgentRemoval) error {
+	if err := r.Status().Update(ctx, removal); err != nil {
+		log.WithError(err).Error("failed to update agent removal status")
+		return err
+	}
+	return nil
+}
+
+func (r *AgentRemovalReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	mapAgentToAgentRemovals := func(ctx context.Context, agent client.Object) []reconcile.Request {
+		log := logutil.FromContext(ctx, r.Log).WithFields(
+			logrus.Fields{
+				"agent":           agent.GetName(),
+				"agent_namespace": agent.GetNamespace(),
+			})
+		removalList := &aiv1beta1.AgentRemovalList{}
+		if err := r.List(ctx, removalList, client.InNamespace(agent.GetNamespace())); err != nil {
+			log.Debugf("failed to list agent removals")
+			return []reconcile.Request{}
+		}
+		reply := []reconcile.Request{}
+		for _, removal := range removalList.Items {
+			if removal.Spec.AgentName == agent.GetName() {
+				reply = append(reply, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: removal.Namespace,
+					Name:      removal.Name,
+				}})
+			}
+		}
+		return reply
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&aiv1beta1.AgentRemoval{}).
+		Watches(&aiv1beta1.Agent{}, handler.EnqueueRequestsFromMapFunc(mapAgentToAgentRemovals)).
+		Complete(r)
+}
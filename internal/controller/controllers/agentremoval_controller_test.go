@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bmh_v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/models"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/drain"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("AgentRemoval reconcile", func() {
+	var (
+		c                 client.Client
+		r                 *AgentRemovalReconciler
+		ctx               = context.Background()
+		mockCtrl          *gomock.Controller
+		mockDrainer       *MockDrainer
+		mockClientFactory *spoke_k8s_client.MockSpokeK8sClientFactory
+		mockSpokeClient   *spoke_k8s_client.MockSpokeK8sClient
+		agent             *v1beta1.Agent
+		removal           *v1beta1.AgentRemoval
+		node              *corev1.Node
+		cluster           = v1beta1.ClusterReference{Name: "test-cluster", Namespace: testNamespace}
+		nodeNotFound      = k8serrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, "agent.example.com")
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockDrainer = NewMockDrainer(mockCtrl)
+		mockClientFactory = spoke_k8s_client.NewMockSpokeK8sClientFactory(mockCtrl)
+		mockSpokeClient = spoke_k8s_client.NewMockSpokeK8sClient(mockCtrl)
+		c = fakeclient.NewClientBuilder().WithStatusSubresource(&v1beta1.AgentRemoval{}).Build()
+		r = &AgentRemovalReconciler{
+			Client:                c,
+			APIReader:             c,
+			Log:                   common.GetTestLog(),
+			SpokeK8sClientFactory: mockClientFactory,
+			Drainer:               mockDrainer,
+			EnableMetal3:          true,
+		}
+
+		cd := &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: cluster.Namespace},
+			Spec: hivev1.ClusterDeploymentSpec{
+				ClusterMetadata: &hivev1.ClusterMetadata{
+					AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "clusterKubeConfig"},
+				},
+			},
+		}
+		Expect(c.Create(ctx, cd)).To(Succeed())
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "clusterKubeConfig", Namespace: cluster.Namespace},
+			Data:       map[string][]byte{"kubeconfig": []byte("definitely_a_kubeconfig")},
+		}
+		Expect(c.Create(ctx, secret)).To(Succeed())
+		mockClientFactory.EXPECT().ClientAndSetFromSecret(gomock.Any(), gomock.AssignableToTypeOf(&corev1.Secret{})).
+			Return(mockSpokeClient, &kubernetes.Clientset{}, nil).AnyTimes()
+
+		agent = newAgent("test-agent", testNamespace, v1beta1.AgentSpec{
+			ClusterDeploymentName: &cluster,
+			Hostname:              "agent.example.com",
+			Approved:              true,
+		})
+		agent.Status.DebugInfo.State = models.HostStatusAddedToExistingCluster
+		agent.Status.Role = models.HostRoleWorker
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: agent.Spec.Hostname}}
+		removal = &v1beta1.AgentRemoval{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-removal", Namespace: testNamespace},
+			Spec:       v1beta1.AgentRemovalSpec{AgentName: agent.Name},
+		}
+		Expect(c.Create(ctx, removal)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	reconcileRemoval := func() (ctrl.Result, *v1beta1.AgentRemoval) {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: removal.Namespace, Name: removal.Name}})
+		Expect(err).ToNot(HaveOccurred())
+		updated := &v1beta1.AgentRemoval{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: removal.Namespace, Name: removal.Name}, updated)).To(Succeed())
+		return result, updated
+	}
+
+	getAgent := func() *v1beta1.Agent {
+		updated := &v1beta1.Agent{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, updated)).To(Succeed())
+		return updated
+	}
+
+	setAgentState := func(state string) {
+		updated := getAgent()
+		updated.Status.DebugInfo.State = state
+		Expect(c.Update(ctx, updated)).To(Succeed())
+	}
+
+	expectCondition := func(removal *v1beta1.AgentRemoval, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason string) {
+		condition := conditionsv1.FindStatusCondition(removal.Status.Conditions, conditionType)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(status))
+		Expect(condition.Reason).To(Equal(reason))
+	}
+
+	It("completes when the agent is not bound", func() {
+		agent.Spec.ClusterDeploymentName = nil
+		Expect(c.Create(ctx, agent)).To(Succeed())
+
+		_, updated := reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalAgentNotBoundReason)
+	})
+
+	It("rejects the agents that aren't workers", func() {
+		agent.Status.Role = models.HostRoleMaster
+		Expect(c.Create(ctx, agent)).To(Succeed())
+
+		_, updated := reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalCompletedCondition, corev1.ConditionFalse, v1beta1.AgentRemovalAgentNotWorkerReason)
+		Expect(updated.Status.ClusterDeploymentName).To(BeNil())
+		Expect(conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentRemovalCordonedCondition)).To(BeNil())
+		Expect(getAgent().Spec.ClusterDeploymentName).To(Equal(&cluster))
+	})
+
+	It("reports a missing agent", func() {
+		_, updated := reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalCompletedCondition, corev1.ConditionFalse, v1beta1.AgentRemovalAgentNotFoundReason)
+	})
+
+	It("drains the node, unbinds the agent, removes the node and waits for the host to be reclaimed", func() {
+		Expect(c.Create(ctx, agent)).To(Succeed())
+		mockSpokeClient.EXPECT().GetNode(gomock.Any(), agent.Spec.Hostname).Return(node, nil)
+		mockDrainer.EXPECT().RunCordonOrUncordon(gomock.AssignableToTypeOf(&drain.Helper{}), node, true).Return(nil)
+		mockDrainer.EXPECT().RunNodeDrain(gomock.AssignableToTypeOf(&drain.Helper{}), agent.Spec.Hostname).Return(nil)
+
+		_, updated := reconcileRemoval()
+		Expect(updated.Status.ClusterDeploymentName).To(Equal(&cluster))
+		Expect(updated.Status.NodeName).To(Equal(agent.Spec.Hostname))
+		Expect(updated.Status.DrainStartTime).ToNot(BeNil())
+		expectCondition(updated, v1beta1.AgentRemovalCordonedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNodeCordonedReason)
+		expectCondition(updated, v1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNodeDrainedReason)
+		expectCondition(updated, v1beta1.AgentRemovalUnboundCondition, corev1.ConditionTrue, v1beta1.AgentRemovalAgentUnboundReason)
+		Expect(getAgent().Spec.ClusterDeploymentName).To(BeNil())
+
+		By("waiting for the host to leave the cluster")
+		_, updated = reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalBareMetalHostCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNoBareMetalHostReason)
+		expectCondition(updated, v1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionFalse, v1beta1.AgentRemovalWaitingForHostReason)
+
+		By("removing the node once the host is rebooted into the discovery ISO")
+		setAgentState(models.HostStatusDiscoveringUnbound)
+		mockSpokeClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: agent.Spec.Hostname}, gomock.AssignableToTypeOf(&corev1.Node{})).
+			Return(nodeNotFound).Times(2)
+		_, updated = reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNodeRemovedReason)
+		expectCondition(updated, v1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalHostReclaimedReason)
+
+		By("ignoring the completed removal")
+		_, updated = reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalHostReclaimedReason)
+	})
+
+	It("requeues while the node is not drained", func() {
+		Expect(c.Create(ctx, agent)).To(Succeed())
+		mockSpokeClient.EXPECT().GetNode(gomock.Any(), agent.Spec.Hostname).Return(node, nil)
+		mockDrainer.EXPECT().RunCordonOrUncordon(gomock.AssignableToTypeOf(&drain.Helper{}), node, true).Return(nil)
+		mockDrainer.EXPECT().RunNodeDrain(gomock.AssignableToTypeOf(&drain.Helper{}), agent.Spec.Hostname).Return(fmt.Errorf("cannot evict pod as it would violate the pod's disruption budget"))
+
+		result, updated := reconcileRemoval()
+		Expect(result.RequeueAfter).To(Equal(agentRemovalDrainRequeue))
+		expectCondition(updated, v1beta1.AgentRemovalCordonedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNodeCordonedReason)
+		expectCondition(updated, v1beta1.AgentRemovalDrainedCondition, corev1.ConditionFalse, v1beta1.AgentRemovalDrainInProgressReason)
+		Expect(getAgent().Spec.ClusterDeploymentName).To(Equal(&cluster))
+	})
+
+	It("continues the removal once the drain timed out", func() {
+		Expect(c.Create(ctx, agent)).To(Succeed())
+		removal.Spec.DrainTimeout = &metav1.Duration{Duration: time.Minute}
+		Expect(c.Update(ctx, removal)).To(Succeed())
+		removal.Status.ClusterDeploymentName = &cluster
+		removal.Status.NodeName = agent.Spec.Hostname
+		removal.Status.DrainStartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+		setAgentRemovalCondition(removal, v1beta1.AgentRemovalCordonedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNodeCordonedReason, "")
+		Expect(c.Status().Update(ctx, removal)).To(Succeed())
+
+		mockSpokeClient.EXPECT().GetNode(gomock.Any(), agent.Spec.Hostname).Return(node, nil)
+		mockDrainer.EXPECT().RunNodeDrain(gomock.AssignableToTypeOf(&drain.Helper{}), agent.Spec.Hostname).Return(fmt.Errorf("drain failed"))
+
+		_, updated := reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalDrainTimedOutReason)
+		expectCondition(updated, v1beta1.AgentRemovalUnboundCondition, corev1.ConditionTrue, v1beta1.AgentRemovalAgentUnboundReason)
+		Expect(getAgent().Spec.ClusterDeploymentName).To(BeNil())
+	})
+
+	It("skips the drain when the agent is not installed", func() {
+		agent.Status.DebugInfo.State = models.HostStatusKnown
+		Expect(c.Create(ctx, agent)).To(Succeed())
+
+		_, updated := reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalDrainedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNotInstalledReason)
+		Expect(getAgent().Spec.ClusterDeploymentName).To(BeNil())
+
+		setAgentState(models.HostStatusKnownUnbound)
+		_, updated = reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalNodeRemovedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalNotInstalledReason)
+		expectCondition(updated, v1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalHostReclaimedReason)
+	})
+
+	It("powers off the BareMetalHost of the agent", func() {
+		bmh := &bmh_v1alpha1.BareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bmh", Namespace: testNamespace},
+			Spec:       bmh_v1alpha1.BareMetalHostSpec{Online: true},
+		}
+		Expect(c.Create(ctx, bmh)).To(Succeed())
+		agent.Labels = map[string]string{AGENT_BMH_LABEL: bmh.Name}
+		Expect(c.Create(ctx, agent)).To(Succeed())
+		removal.Spec.BareMetalHostAction = v1beta1.AgentRemovalBareMetalHostActionPowerOff
+		Expect(c.Update(ctx, removal)).To(Succeed())
+
+		mockSpokeClient.EXPECT().GetNode(gomock.Any(), agent.Spec.Hostname).Return(node, nil)
+		mockDrainer.EXPECT().RunCordonOrUncordon(gomock.AssignableToTypeOf(&drain.Helper{}), node, true).Return(nil)
+		mockDrainer.EXPECT().RunNodeDrain(gomock.AssignableToTypeOf(&drain.Helper{}), agent.Spec.Hostname).Return(nil)
+
+		_, updated := reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalUnboundCondition, corev1.ConditionTrue, v1beta1.AgentRemovalAgentUnboundReason)
+		Expect(conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentRemovalBareMetalHostCondition)).To(BeNil())
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: bmh.Namespace, Name: bmh.Name}, bmh)).To(Succeed())
+		Expect(bmh.Spec.Online).To(BeTrue())
+
+		By("powering off the host once the agent is unbound")
+		_, updated = reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalBareMetalHostCondition, corev1.ConditionTrue, v1beta1.AgentRemovalBareMetalHostPoweredOffReason)
+		Expect(conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.AgentRemovalBareMetalHostCondition).Message).To(ContainSubstring("is powered off"))
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: bmh.Namespace, Name: bmh.Name}, bmh)).To(Succeed())
+		Expect(bmh.Spec.Online).To(BeFalse())
+		Expect(bmh.GetAnnotations()).To(HaveKeyWithValue(AgentRemovalPowerOffAnnotation, removal.Name))
+
+		By("removing the node without waiting for the powered off host to be reclaimed")
+		setAgentState(models.HostStatusUnbinding)
+		mockSpokeClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: agent.Spec.Hostname}, gomock.AssignableToTypeOf(&corev1.Node{})).
+			Return(nodeNotFound).Times(2)
+		_, updated = reconcileRemoval()
+		expectCondition(updated, v1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalHostPoweredOffReason)
+	})
+})
@@ -87,6 +87,9 @@ const (
 	defaultIngressCertCMNamespace string = "openshift-config-managed"
 	clusterCAConfigMapName        string = "cluster-trusted-ca-bundle"
 	assistedCAConfigMapName       string = "assisted-trusted-ca-bundle"
+	eventRateLimitsConfigMapName  string = serviceName + "-event-rate-limits"
+	eventRateLimitsKey            string = "event-rate-limits.json"
+	eventRateLimitsMountPath      string = "/etc/assisted-service/event-rate-limits"
 
 	configmapAnnotation                 = "unsupported.agent-install.openshift.io/assisted-service-configmap"
 	imageServiceSkipVerifyTLSAnnotation = "unsupported.agent-install.openshift.io/assisted-image-service-skip-verify-tls"
@@ -426,6 +429,8 @@ func getComponents(spec *aiv1beta1.AgentServiceConfigSpec, isOpenshift bool, ann
 	} else {
 		components = append(components, certManagerComponents()...)
 	}
+	components = append(components,
+		component{"EventRateLimitsConfigMap", aiv1beta1.ReasonConfigFailure, newEventRateLimitsCM})
 	// needs to be created after all routes to pull the hostnames into the configmap
 	components = append(components,
 		component{"AssistedServiceConfigMap", aiv1beta1.ReasonConfigFailure, newAssistedCM})
@@ -1290,6 +1295,52 @@ func getWaitingForControlPlaneHostStageTimeout() string {
 	return defaultWaitingForControlPlaneHostStageTimeout
 }
 
+// newEventRateLimitsCM renders the event rate limits of the spec in the format of EVENT_RATE_LIMITS.
+// The config map is mounted as a directory, so the service picks up the changes without a restart.
+func newEventRateLimitsCM(ctx context.Context, log logrus.FieldLogger, asc ASC) (client.Object, controllerutil.MutateFn, error) {
+	limits := map[string]map[string]interface{}{}
+	for _, limit := range asc.spec.EventRateLimits {
+		config := map[string]interface{}{}
+		if limit.Interval != nil {
+			config["interval"] = limit.Interval.Duration.String()
+		}
+		if limit.RatePerMinute != nil {
+			config["per_minute"] = *limit.RatePerMinute
+		}
+		if limit.Burst != nil {
+			config["burst"] = *limit.Burst
+		}
+		if limit.SamplePercentage != nil {
+			config["sample_rate"] = float64(*limit.SamplePercentage) / 100
+		}
+		limits[limit.EventName] = config
+	}
+	data, err := json.Marshal(limits)
+	if err != nil {
+		log.WithError(err).Error("Failed to marshal event rate limits")
+		return nil, nil, err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      eventRateLimitsConfigMapName,
+			Namespace: asc.namespace,
+		},
+	}
+
+	mutateFn := func() error {
+		if err := controllerutil.SetControllerReference(asc.Object, cm, asc.rec.Scheme); err != nil {
+			return err
+		}
+		cm.Data = map[string]string{
+			eventRateLimitsKey: string(data),
+		}
+		return nil
+	}
+
+	return cm, mutateFn, nil
+}
+
 func newAssistedCM(ctx context.Context, log logrus.FieldLogger, asc ASC) (client.Object, controllerutil.MutateFn, error) {
 	serviceURL, err := urlForRoute(ctx, asc, serviceName)
 	if err != nil {
@@ -1361,6 +1412,7 @@ func newAssistedCM(ctx context.Context, log logrus.FieldLogger, asc ASC) (client
 			"SKIP_CERT_VERIFICATION": "False",
 			"HOST_STAGE_WAITING_FOR_CONTROL_PLANE_TIMEOUT": getWaitingForControlPlaneHostStageTimeout(),
 			"EVENT_RATE_LIMITS":                            "",
+			"EVENT_RATE_LIMITS_FILE":                       filepath.Join(eventRateLimitsMountPath, eventRateLimitsKey),
 		}
 		// serve https only on OCP
 		if asc.rec.IsOpenShift {
@@ -1947,10 +1999,23 @@ func newAssistedServiceDeployment(ctx context.Context, log logrus.FieldLogger, a
 				},
 			},
 		},
+		{
+			Name: "event-rate-limits",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: eventRateLimitsConfigMapName,
+					},
+					Optional: swag.Bool(true),
+				},
+			},
+		},
 	}
 	volumeMounts := []corev1.VolumeMount{
 		{Name: "bucket-filesystem", MountPath: "/data"},
 		{Name: "ingress-cert", MountPath: "/etc/assisted-ingress-cert"},
+		// not mounted with a subPath, so that the updates of the config map reach the running service
+		{Name: "event-rate-limits", MountPath: eventRateLimitsMountPath},
 	}
 	var healthCheckScheme corev1.URIScheme
 	if asc.rec.IsOpenShift {
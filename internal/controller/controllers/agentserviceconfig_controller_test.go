@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	certtypes "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	certmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -1460,6 +1461,47 @@ var _ = Describe("newImageServiceConfigMap", func() {
 	})
 })
 
+var _ = Describe("newEventRateLimitsCM", func() {
+	var (
+		asc  *aiv1beta1.AgentServiceConfig
+		ascr *AgentServiceConfigReconciler
+		ascc ASC
+		ctx  = context.Background()
+		log  = logrus.New()
+	)
+
+	getLimits := func() string {
+		found := &corev1.ConfigMap{}
+		Expect(ascr.Client.Get(ctx, types.NamespacedName{Name: eventRateLimitsConfigMapName, Namespace: testNamespace}, found)).To(Succeed())
+		return found.Data[eventRateLimitsKey]
+	}
+
+	It("should create an empty configmap without event rate limits", func() {
+		asc = newASCDefault()
+		ascr = newTestReconciler(asc)
+		ascc = initASC(ascr, asc)
+
+		AssertReconcileSuccess(ctx, log, ascc, newEventRateLimitsCM)
+		Expect(getLimits()).To(Equal("{}"))
+	})
+
+	It("should render the event rate limits of the spec", func() {
+		asc = newASCDefault()
+		asc.Spec.EventRateLimits = []aiv1beta1.EventRateLimit{
+			{EventName: "host_status_updated", RatePerMinute: swag.Int32(10), Burst: swag.Int32(5), SamplePercentage: swag.Int32(25)},
+			{EventName: "upgrade_agent_failed", Interval: &metav1.Duration{Duration: 2 * time.Hour}},
+		}
+		ascr = newTestReconciler(asc)
+		ascc = initASC(ascr, asc)
+
+		AssertReconcileSuccess(ctx, log, ascc, newEventRateLimitsCM)
+		Expect(getLimits()).To(MatchJSON(`{
+			"host_status_updated": {"per_minute": 10, "burst": 5, "sample_rate": 0.25},
+			"upgrade_agent_failed": {"interval": "2h0m0s"}
+		}`))
+	})
+})
+
 var _ = Describe("reconcileImageServiceStatefulSet", func() {
 	var (
 		asc  *aiv1beta1.AgentServiceConfig
@@ -2022,7 +2064,7 @@ var _ = Describe("ensureAssistedServiceDeployment", func() {
 
 				found := &appsv1.Deployment{}
 				Expect(ascr.Client.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, found)).To(Succeed())
-				Expect(found.Spec.Template.Spec.Volumes).To(HaveLen(6))
+				Expect(found.Spec.Template.Spec.Volumes).To(HaveLen(7))
 				Expect(found.Spec.Template.Spec.Containers[0].VolumeMounts).Should(ContainElement(
 					corev1.VolumeMount{
 						Name:      mirrorRegistryConfigVolume,
@@ -2082,7 +2124,7 @@ var _ = Describe("ensureAssistedServiceDeployment", func() {
 
 				found := &appsv1.Deployment{}
 				Expect(ascr.Client.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, found)).To(Succeed())
-				Expect(found.Spec.Template.Spec.Volumes).To(HaveLen(7))
+				Expect(found.Spec.Template.Spec.Volumes).To(HaveLen(8))
 				Expect(found.Spec.Template.Spec.Volumes).To(ContainElement(
 					corev1.Volume{
 						Name: "trusted-ca-certs",
@@ -33,6 +33,7 @@ import (
 	bmh_v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/bmcinventory"
 	"github.com/openshift/assisted-service/internal/bminventory"
 	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common/ignition"
@@ -47,12 +48,12 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/thoas/go-funk"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/kubectl/pkg/drain"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -100,6 +101,7 @@ const (
 	BMH_CLUSTER_REFERENCE               = "bmac.agent-install.openshift.io/cluster-reference"
 	BMH_HOST_MANAGEMENT_ANNOTATION      = "bmac.agent-install.openshift.io/allow-provisioned-host-management"
 	BMH_SPOKE_CREATED_ANNOTATION        = "bmac.agent-install.openshift.io/spoke-bmh-machine-created"
+	BMH_HARDWARE_PROFILE_ANNOTATION     = "bmac.agent-install.openshift.io/hardware-profile"
 	MACHINE_ROLE                        = "machine.openshift.io/cluster-api-machine-role"
 	MACHINE_TYPE                        = "machine.openshift.io/cluster-api-machine-type"
 	MCS_CERT_NAME                       = "ca.crt"
@@ -206,6 +208,8 @@ func (r reconcileError) Stop(ctx context.Context) bool {
 }
 
 // +kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=metal3.io,resources=hostfirmwaresettings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=agent-install.openshift.io,resources=hardwareprofiles,verbs=get;list;watch
 
 func (r *BMACReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrlResult ctrl.Result, retErr error) {
 	ctx := addRequestIdIfNeeded(origCtx)
@@ -310,6 +314,11 @@ func (r *BMACReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (c
 		return
 	}
 
+	if res := r.reconcileAgentBMCInventory(ctx, log, bmh, agent); res.Stop(ctx) {
+		ctrlResult, retErr = res.Result()
+		return
+	}
+
 	// In the converged flow ironic will reconcile the BMH_HARDWARE_DETAILS_ANNOTATION
 	if !r.ConvergedFlowEnabled {
 		if res := r.reconcileAgentInventory(log, bmh, agent); res.Stop(ctx) {
@@ -843,6 +852,81 @@ func (r *BMACReconciler) reconcileAgentInventory(log logrus.FieldLogger, bmh *bm
 
 }
 
+// reconcileAgentBMCInventory copies the firmware, boot mode and RAID configuration known to the
+// BMH, and the firmware settings of its HostFirmwareSettings, to the Agent. The Agent controller
+// passes them to the host, where they are checked against the firmware requirements of the InfraEnv.
+func (r *BMACReconciler) reconcileAgentBMCInventory(ctx context.Context, log logrus.FieldLogger, bmh *bmh_v1alpha1.BareMetalHost, agent *aiv1beta1.Agent) reconcileResult {
+	settings := &bmh_v1alpha1.HostFirmwareSettings{}
+	if err := r.Get(ctx, types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}, settings); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.WithError(err).Errorf("failed to get the HostFirmwareSettings of BMH %s", bmh.Name)
+			return reconcileError{err: err}
+		}
+		settings = nil
+	}
+
+	bmcInventory := newBMCInventory(bmh, settings)
+	if bmcinventory.IsEmptyInventory(bmcInventory) {
+		delete(agent.Annotations, AgentBMCInventoryAnnotation)
+		return reconcileComplete{}
+	}
+	bytes, err := json.Marshal(bmcInventory)
+	if err != nil {
+		return reconcileError{err: err}
+	}
+	setAgentAnnotation(log, agent, AgentBMCInventoryAnnotation, string(bytes))
+	return reconcileComplete{}
+}
+
+// newBMCInventory returns the hardware details of the BMH used by the firmware requirements. The
+// provisioning status is preferred to the spec, it is what was applied to the host.
+func newBMCInventory(bmh *bmh_v1alpha1.BareMetalHost, settings *bmh_v1alpha1.HostFirmwareSettings) *models.BmcInventory {
+	bmcInventory := &models.BmcInventory{}
+	if bmh.Status.HardwareDetails != nil {
+		bmcInventory.BiosVendor = bmh.Status.HardwareDetails.Firmware.BIOS.Vendor
+		bmcInventory.BiosVersion = bmh.Status.HardwareDetails.Firmware.BIOS.Version
+		bmcInventory.BiosDate = bmh.Status.HardwareDetails.Firmware.BIOS.Date
+	}
+
+	bmcInventory.BootMode = string(bmh.Status.Provisioning.BootMode)
+	if bmcInventory.BootMode == "" {
+		bmcInventory.BootMode = string(bmh.Spec.BootMode)
+	}
+
+	raid := bmh.Status.Provisioning.RAID
+	if raid == nil {
+		raid = bmh.Spec.RAID
+	}
+	if raid != nil {
+		for _, volume := range raid.HardwareRAIDVolumes {
+			bmcInventory.RaidVolumes = append(bmcInventory.RaidVolumes, &models.BmcRaidVolume{
+				Level:         volume.Level,
+				SizeGibibytes: int64(swag.IntValue(volume.SizeGibibytes)),
+				PhysicalDisks: int64(swag.IntValue(volume.NumberOfPhysicalDisks)),
+			})
+		}
+		// The software RAID volumes are ignored when there are hardware RAID volumes
+		if len(raid.HardwareRAIDVolumes) == 0 {
+			for _, volume := range raid.SoftwareRAIDVolumes {
+				bmcInventory.RaidVolumes = append(bmcInventory.RaidVolumes, &models.BmcRaidVolume{
+					Level:         volume.Level,
+					Software:      true,
+					SizeGibibytes: int64(swag.IntValue(volume.SizeGibibytes)),
+					PhysicalDisks: int64(len(volume.PhysicalDisks)),
+				})
+			}
+		}
+	}
+
+	if settings != nil && len(settings.Status.Settings) > 0 {
+		bmcInventory.FirmwareSettings = make(map[string]string, len(settings.Status.Settings))
+		for name, value := range settings.Status.Settings {
+			bmcInventory.FirmwareSettings[name] = value
+		}
+	}
+	return bmcInventory
+}
+
 func agentIsUnbindingPendingUserAction(agent *aiv1beta1.Agent) bool {
 	boundCondition := conditionsv1.FindStatusCondition(agent.Status.Conditions, aiv1beta1.BoundCondition)
 	return boundCondition != nil && boundCondition.Reason == aiv1beta1.UnbindingPendingUserActionReason
@@ -1016,9 +1100,23 @@ func (r *BMACReconciler) reconcileBMH(ctx context.Context, log logrus.FieldLogge
 		return reconcileComplete{}
 	}
 
+	// A host powered off by an AgentRemoval is not booted with the
+	// discovery ISO, it stays powered off until the user removes
+	// the annotation.
+	if metav1.HasAnnotation(bmh.ObjectMeta, AgentRemovalPowerOffAnnotation) {
+		if bmh.Spec.Online {
+			log.Infof("Powering off BMH removed from its cluster by AgentRemoval %s", bmh.GetAnnotations()[AgentRemovalPowerOffAnnotation])
+			bmh.Spec.Online = false
+		}
+		return reconcileComplete{stop: true}
+	}
+
 	// in case of converged flow set the custom deploy instead of the annotations
 	if r.ConvergedFlowEnabled {
 		if bmh.Spec.CustomDeploy == nil || bmh.Spec.CustomDeploy.Method != ASSISTED_DEPLOY_METHOD {
+			if res := r.applyHardwareProfile(ctx, log, bmh, infraEnv); res.Stop(ctx) {
+				return res
+			}
 			// Before setting customDeploy, verify the PreprovisioningImage has been
 			// updated by the assisted-service PPI controller with the correct ISO.
 			// Without this check, BMO may use its own IPA image (previously set on
@@ -1085,6 +1183,10 @@ func (r *BMACReconciler) reconcileBMH(ctx context.Context, log logrus.FieldLogge
 		return reconcileComplete{stop: true}
 	}
 
+	if res := r.applyHardwareProfile(ctx, log, bmh, infraEnv); res.Stop(ctx) {
+		return res
+	}
+
 	log.Debugf("Setting attributes in BMH")
 	// We'll just overwrite this at this point
 	// since the nullness and emptyness checks
@@ -1099,6 +1201,150 @@ func (r *BMACReconciler) reconcileBMH(ctx context.Context, log logrus.FieldLogge
 	return reconcileComplete{stop: true}
 }
 
+// hardwareProfileState is the state of the HardwareProfile of the InfraEnv on a BMH, recorded in
+// the BMH_HARDWARE_PROFILE_ANNOTATION
+type hardwareProfileState struct {
+	Profile string                             `json:"profile"`
+	State   aiv1beta1.HardwareProfileHostState `json:"state"`
+	Message string                             `json:"message,omitempty"`
+}
+
+func setHardwareProfileState(log logrus.FieldLogger, bmh *bmh_v1alpha1.BareMetalHost, profile string, state aiv1beta1.HardwareProfileHostState, message string) {
+	bytes, err := json.Marshal(hardwareProfileState{Profile: profile, State: state, Message: message})
+	if err != nil {
+		log.WithError(err).Error("failed to marshal the hardware profile state")
+		return
+	}
+	if bmh.GetAnnotations()[BMH_HARDWARE_PROFILE_ANNOTATION] != string(bytes) {
+		log.Infof("HardwareProfile %s is %s on BMH: %s", profile, state, message)
+		setAnnotation(&bmh.ObjectMeta, BMH_HARDWARE_PROFILE_ANNOTATION, string(bytes))
+	}
+}
+
+// applyHardwareProfile applies the HardwareProfile referenced by the InfraEnv to the BMH: its boot
+// mode and RAID configuration are set in the BMH and its firmware settings in the HostFirmwareSettings
+// of the BMH. The baremetal-operator applies them while preparing the host, the reconcile stops
+// until it's done so that the host isn't booted with the discovery image before.
+func (r *BMACReconciler) applyHardwareProfile(ctx context.Context, log logrus.FieldLogger, bmh *bmh_v1alpha1.BareMetalHost, infraEnv *aiv1beta1.InfraEnv) reconcileResult {
+	if infraEnv.Spec.HardwareProfileRef == nil || infraEnv.Spec.HardwareProfileRef.Name == "" {
+		delete(bmh.Annotations, BMH_HARDWARE_PROFILE_ANNOTATION)
+		return reconcileComplete{}
+	}
+	name := infraEnv.Spec.HardwareProfileRef.Name
+	profile := &aiv1beta1.HardwareProfile{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: infraEnv.Namespace}, profile); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcileError{err: err}
+		}
+		setHardwareProfileState(log, bmh, name, aiv1beta1.HardwareProfileHostFailed, fmt.Sprintf("HardwareProfile %s not found", name))
+		return reconcileComplete{stop: true}
+	}
+	if bmh.Status.ErrorType == bmh_v1alpha1.PreparationError {
+		setHardwareProfileState(log, bmh, name, aiv1beta1.HardwareProfileHostFailed,
+			fmt.Sprintf("The baremetal-operator failed to prepare the host: %s", bmh.Status.ErrorMessage))
+		return reconcileComplete{stop: true}
+	}
+
+	changed := false
+	if profile.Spec.BootMode != "" && bmh.Spec.BootMode != bmh_v1alpha1.BootMode(profile.Spec.BootMode) {
+		bmh.Spec.BootMode = bmh_v1alpha1.BootMode(profile.Spec.BootMode)
+		changed = true
+	}
+	if profile.Spec.RAID != nil {
+		raid := bmhRAIDConfig(profile.Spec.RAID)
+		if !equality.Semantic.DeepEqual(bmh.Spec.RAID, raid) {
+			bmh.Spec.RAID = raid
+			changed = true
+		}
+	}
+	settingsApplied, failure, err := r.applyHardwareProfileFirmwareSettings(ctx, bmh, profile)
+	if err != nil {
+		log.WithError(err).Errorf("failed to apply the firmware settings of HardwareProfile %s", name)
+		return reconcileError{err: err}
+	}
+	if failure != "" {
+		setHardwareProfileState(log, bmh, name, aiv1beta1.HardwareProfileHostFailed, failure)
+		return reconcileComplete{stop: true}
+	}
+
+	raidApplied := profile.Spec.RAID == nil || equality.Semantic.DeepEqual(bmh.Status.Provisioning.RAID, bmh.Spec.RAID)
+	if changed || !raidApplied || !settingsApplied || bmh.Status.Provisioning.State == bmh_v1alpha1.StatePreparing {
+		setHardwareProfileState(log, bmh, name, aiv1beta1.HardwareProfileHostApplying,
+			"Waiting for the baremetal-operator to apply the profile before booting the discovery image")
+		return reconcileComplete{stop: true}
+	}
+	setHardwareProfileState(log, bmh, name, aiv1beta1.HardwareProfileHostApplied, "The profile was applied")
+	return reconcileComplete{}
+}
+
+// applyHardwareProfileFirmwareSettings sets the firmware settings of the profile in the
+// HostFirmwareSettings of the BMH. It returns true once the host has them, or why they can't be applied.
+func (r *BMACReconciler) applyHardwareProfileFirmwareSettings(ctx context.Context, bmh *bmh_v1alpha1.BareMetalHost, profile *aiv1beta1.HardwareProfile) (bool, string, error) {
+	if len(profile.Spec.FirmwareSettings) == 0 {
+		return true, "", nil
+	}
+	settings := &bmh_v1alpha1.HostFirmwareSettings{}
+	if err := r.Get(ctx, types.NamespacedName{Name: bmh.Name, Namespace: bmh.Namespace}, settings); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, "", err
+		}
+		settings = &bmh_v1alpha1.HostFirmwareSettings{
+			ObjectMeta: metav1.ObjectMeta{Name: bmh.Name, Namespace: bmh.Namespace},
+			Spec:       bmh_v1alpha1.HostFirmwareSettingsSpec{Settings: bmh_v1alpha1.DesiredSettingsMap{}},
+		}
+		for name, value := range profile.Spec.FirmwareSettings {
+			settings.Spec.Settings[name] = value
+		}
+		return false, "", r.Create(ctx, settings)
+	}
+
+	update := false
+	if settings.Spec.Settings == nil {
+		settings.Spec.Settings = bmh_v1alpha1.DesiredSettingsMap{}
+	}
+	for name, value := range profile.Spec.FirmwareSettings {
+		if current, ok := settings.Spec.Settings[name]; !ok || current != value {
+			settings.Spec.Settings[name] = value
+			update = true
+		}
+	}
+	if update {
+		return false, "", r.Update(ctx, settings)
+	}
+
+	valid := meta.FindStatusCondition(settings.Status.Conditions, string(bmh_v1alpha1.FirmwareSettingsValid))
+	if valid != nil && valid.ObservedGeneration == settings.Generation && valid.Status == metav1.ConditionFalse {
+		return false, fmt.Sprintf("The firmware settings are invalid: %s", valid.Message), nil
+	}
+	for name, value := range profile.Spec.FirmwareSettings {
+		if settings.Status.Settings[name] != value.String() {
+			return false, "", nil
+		}
+	}
+	return true, "", nil
+}
+
+func bmhRAIDConfig(raid *aiv1beta1.HardwareProfileRAID) *bmh_v1alpha1.RAIDConfig {
+	config := &bmh_v1alpha1.RAIDConfig{}
+	for _, volume := range raid.HardwareRAIDVolumes {
+		config.HardwareRAIDVolumes = append(config.HardwareRAIDVolumes, bmh_v1alpha1.HardwareRAIDVolume{
+			Level:                 string(volume.Level),
+			SizeGibibytes:         volume.SizeGibibytes,
+			NumberOfPhysicalDisks: volume.NumberOfPhysicalDisks,
+		})
+	}
+	// The software RAID volumes are ignored by the baremetal-operator when there are hardware RAID volumes
+	if len(config.HardwareRAIDVolumes) == 0 {
+		for _, volume := range raid.SoftwareRAIDVolumes {
+			config.SoftwareRAIDVolumes = append(config.SoftwareRAIDVolumes, bmh_v1alpha1.SoftwareRAIDVolume{
+				Level:         string(volume.Level),
+				SizeGibibytes: volume.SizeGibibytes,
+			})
+		}
+	}
+	return config
+}
+
 // Reconcile the `BareMetalHost` resource on the spoke cluster
 //
 // Baremetal-operator in the hub cluster creates a host using the live-iso feature. To add this host as a node
@@ -1122,10 +1368,7 @@ func (r *BMACReconciler) reconcileDay2SpokeBMH(ctx context.Context, log logrus.F
 		return reconcileComplete{}
 	}
 
-	key := types.NamespacedName{
-		Namespace: agent.Spec.ClusterDeploymentName.Namespace,
-		Name:      getClusterDeploymentAdminKubeConfigSecretName(cd),
-	}
+	key := spokeKubeconfigSecretKey(ctx, r.Client, r.APIReader, agent.Spec.ClusterDeploymentName.Namespace, cd)
 	isNonePlatform, propagateError, err := isNonePlatformCluster(ctx, r.Client, cd)
 	if err != nil {
 		log.WithError(err).Errorf("Failed to determine if platform is none for cluster deployment %s/%s", cd.Namespace, cd.Name)
@@ -1464,10 +1707,7 @@ func (r *BMACReconciler) ensureMCSCert(ctx context.Context, log logrus.FieldLogg
 		return reconcileComplete{}
 	}
 
-	key := types.NamespacedName{
-		Namespace: agent.Spec.ClusterDeploymentName.Namespace,
-		Name:      getClusterDeploymentAdminKubeConfigSecretName(cd),
-	}
+	key := spokeKubeconfigSecretKey(ctx, r.Client, r.APIReader, agent.Spec.ClusterDeploymentName.Namespace, cd)
 
 	secret, err := getSecret(ctx, r.Client, r.APIReader, key)
 	if err != nil {
@@ -1778,6 +2018,41 @@ func (r *BMACReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		}}
 	}
 
+	mapHardwareProfileToBMH := func(ctx context.Context, a client.Object) []reconcile.Request {
+		infraEnvs := &aiv1beta1.InfraEnvList{}
+		if err := r.List(ctx, infraEnvs, client.InNamespace(a.GetNamespace())); err != nil {
+			return []reconcile.Request{}
+		}
+		requests := []reconcile.Request{}
+		for i := range infraEnvs.Items {
+			infraEnv := &infraEnvs.Items[i]
+			if infraEnv.Spec.HardwareProfileRef == nil || infraEnv.Spec.HardwareProfileRef.Name != a.GetName() {
+				continue
+			}
+			bmhs, err := r.findBMHByInfraEnv(ctx, infraEnv)
+			if err != nil {
+				continue
+			}
+			for _, bmh := range bmhs {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: bmh.Namespace,
+					Name:      bmh.Name,
+				}})
+			}
+		}
+		return requests
+	}
+
+	mapHFStoBMH := func(ctx context.Context, a client.Object) []reconcile.Request {
+		// HostFirmwareSettings has the same name/namespace as its BMH
+		return []reconcile.Request{{
+			NamespacedName: types.NamespacedName{
+				Name:      a.GetName(),
+				Namespace: a.GetNamespace(),
+			},
+		}}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("baremetal-agent-controller").
 		WithOptions(controller.Options{MaxConcurrentReconciles: r.Config.MaxConcurrentReconciles}).
@@ -1786,6 +2061,8 @@ func (r *BMACReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&aiv1beta1.InfraEnv{}, handler.EnqueueRequestsFromMapFunc(mapInfraEnvToBMH)).
 		Watches(&hivev1.ClusterDeployment{}, handler.EnqueueRequestsFromMapFunc(mapClusterDeploymentToBMH)).
 		Watches(&bmh_v1alpha1.PreprovisioningImage{}, handler.EnqueueRequestsFromMapFunc(mapPPItoBMH)).
+		Watches(&bmh_v1alpha1.HostFirmwareSettings{}, handler.EnqueueRequestsFromMapFunc(mapHFStoBMH)).
+		Watches(&aiv1beta1.HardwareProfile{}, handler.EnqueueRequestsFromMapFunc(mapHardwareProfileToBMH)).
 		Complete(r)
 }
 
@@ -1933,18 +2210,6 @@ func nodeDrainTimedout(bmh *bmh_v1alpha1.BareMetalHost) (bool, error) {
 	return diff.Seconds() >= drainTimeout.Seconds(), nil
 }
 
-func nodeUnreachable(node *corev1.Node) bool {
-	if node == nil {
-		return false
-	}
-	for _, c := range node.Status.Conditions {
-		if c.Type == corev1.NodeReady {
-			return c.Status == corev1.ConditionUnknown
-		}
-	}
-	return false
-}
-
 // Mostly taken from https://github.com/kubernetes-sigs/cluster-api/blob/539760a/internal/controllers/machine/machine_controller.go#L586
 func (r *BMACReconciler) drainAgentNode(ctx context.Context, log logrus.FieldLogger, agent *aiv1beta1.Agent) (bool, error) {
 	log = log.WithFields(logrus.Fields{
@@ -1988,33 +2253,7 @@ func (r *BMACReconciler) drainAgentNode(ctx context.Context, log logrus.FieldLog
 		return false, err
 	}
 
-	out := new(bytes.Buffer)
-	drainHelper := &drain.Helper{
-		Client:              clientset,
-		Ctx:                 ctx,
-		Force:               true,
-		IgnoreAllDaemonSets: true,
-		DeleteEmptyDirData:  true,
-		GracePeriodSeconds:  -1,
-		Timeout:             20 * time.Second,
-		OnPodDeletionOrEvictionFinished: func(pod *corev1.Pod, usingEviction bool, err error) {
-			verbStr := "Deleted"
-			if usingEviction {
-				verbStr = "Evicted"
-			}
-			if err != nil {
-				log.Warnf("%s Pod %s/%s from Node %s, %s", verbStr, pod.Namespace, pod.Name, nodeName, err.Error())
-				return
-			}
-			log.Infof("%s Pod %s/%s from Node %s", verbStr, pod.Namespace, pod.Name, nodeName)
-		},
-		Out:    out,
-		ErrOut: out,
-	}
-	if nodeUnreachable(node) {
-		// When the node is unreachable and some pods are not evicted for as long as this timeout, we ignore them.
-		drainHelper.SkipWaitForDeleteTimeoutSeconds = 60 * 5 // 5 minutes
-	}
+	drainHelper, out := newDrainHelper(ctx, log, clientset, node, nodeName)
 	if err := r.Drainer.RunCordonOrUncordon(drainHelper, node, true); err != nil {
 		log.WithError(err).Errorf("failed to cordon node %s: output: %s", nodeName, out)
 		return false, fmt.Errorf("failed to cordon node %s: %w", nodeName, err)
@@ -32,7 +32,9 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/kubectl/pkg/drain"
@@ -424,6 +426,22 @@ var _ = Describe("bmac reconcile", func() {
 				Expect(updatedHost.Spec.AutomatedCleaningMode).To(Equal(bmh_v1alpha1.CleaningModeDisabled))
 			})
 
+			It("should keep the BMH powered off when it was powered off by an AgentRemoval", func() {
+				host.Spec.Online = true
+				setAnnotation(&host.ObjectMeta, AgentRemovalPowerOffAnnotation, "test-removal")
+				Expect(c.Update(ctx, host)).To(BeNil())
+
+				result, err := bmhr.Reconcile(ctx, newBMHRequest(host))
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal(ctrl.Result{}))
+
+				updatedHost := &bmh_v1alpha1.BareMetalHost{}
+				err = c.Get(ctx, types.NamespacedName{Name: "bmh-reconcile", Namespace: testNamespace}, updatedHost)
+				Expect(err).To(BeNil())
+				Expect(updatedHost.Spec.Online).To(Equal(false))
+				Expect(updatedHost.Spec.Image).To(BeNil())
+			})
+
 			It("should disable cleaning when set to metadata by user in the BMH", func() {
 				host.Spec.AutomatedCleaningMode = bmh_v1alpha1.CleaningModeMetadata
 				result, err := bmhr.Reconcile(ctx, newBMHRequest(host))
@@ -481,6 +499,135 @@ var _ = Describe("bmac reconcile", func() {
 				Expect(host.Spec.Image.URL).To(Equal(isoImageURL + ".new"))
 			})
 		})
+
+		Context("with an infraEnv referencing a hardware profile", func() {
+			var (
+				infraEnv *v1beta1.InfraEnv
+				profile  *v1beta1.HardwareProfile
+			)
+
+			hardwareProfileState := func() hardwareProfileState {
+				var state hardwareProfileState
+				Expect(json.Unmarshal([]byte(host.GetAnnotations()[BMH_HARDWARE_PROFILE_ANNOTATION]), &state)).To(Succeed())
+				return state
+			}
+
+			BeforeEach(func() {
+				infraEnv = newInfraEnvImage("testInfraEnv", testNamespace, v1beta1.InfraEnvSpec{
+					HardwareProfileRef: &corev1.LocalObjectReference{Name: "test-profile"},
+				})
+				infraEnv.Status = v1beta1.InfraEnvStatus{
+					ISODownloadURL: "http://buzz.lightyear.io/discovery-image.iso",
+					CreatedTime:    &metav1.Time{Time: time.Now().Add(-10 * time.Hour)},
+				}
+				Expect(c.Create(ctx, infraEnv)).To(BeNil())
+
+				sizeGibibytes := 100
+				profile = &v1beta1.HardwareProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Namespace: testNamespace},
+					Spec: v1beta1.HardwareProfileSpec{
+						BootMode:         "UEFISecureBoot",
+						FirmwareSettings: map[string]intstr.IntOrString{"ProcVirtualization": intstr.FromString("Enabled")},
+						RAID: &v1beta1.HardwareProfileRAID{
+							HardwareRAIDVolumes: []v1beta1.HardwareProfileRAIDVolume{{Level: "1", SizeGibibytes: &sizeGibibytes}},
+						},
+					},
+				}
+			})
+
+			AfterEach(func() {
+				Expect(c.Delete(ctx, infraEnv)).ShouldNot(HaveOccurred())
+			})
+
+			It("should not boot the host when the profile doesn't exist", func() {
+				result := bmhr.reconcileBMH(ctx, bmhr.Log, host, nil, infraEnv)
+				Expect(result).To(Equal(reconcileComplete{stop: true}))
+				Expect(host.Spec.Image).To(BeNil())
+				state := hardwareProfileState()
+				Expect(state.Profile).To(Equal("test-profile"))
+				Expect(state.State).To(Equal(v1beta1.HardwareProfileHostFailed))
+				Expect(state.Message).To(Equal("HardwareProfile test-profile not found"))
+			})
+
+			It("should apply the profile before booting the host", func() {
+				Expect(c.Create(ctx, profile)).To(Succeed())
+
+				result := bmhr.reconcileBMH(ctx, bmhr.Log, host, nil, infraEnv)
+				Expect(result).To(Equal(reconcileComplete{stop: true}))
+				Expect(host.Spec.Image).To(BeNil())
+				Expect(host.Spec.BootMode).To(Equal(bmh_v1alpha1.UEFISecureBoot))
+				Expect(host.Spec.RAID.HardwareRAIDVolumes).To(Equal([]bmh_v1alpha1.HardwareRAIDVolume{{Level: "1", SizeGibibytes: profile.Spec.RAID.HardwareRAIDVolumes[0].SizeGibibytes}}))
+				Expect(hardwareProfileState().State).To(Equal(v1beta1.HardwareProfileHostApplying))
+
+				settings := &bmh_v1alpha1.HostFirmwareSettings{}
+				Expect(c.Get(ctx, types.NamespacedName{Name: host.Name, Namespace: host.Namespace}, settings)).To(Succeed())
+				Expect(settings.Spec.Settings).To(HaveKeyWithValue("ProcVirtualization", intstr.FromString("Enabled")))
+
+				By("waiting for the baremetal-operator to apply the settings")
+				result = bmhr.reconcileBMH(ctx, bmhr.Log, host, nil, infraEnv)
+				Expect(result).To(Equal(reconcileComplete{stop: true}))
+				Expect(host.Spec.Image).To(BeNil())
+				Expect(hardwareProfileState().State).To(Equal(v1beta1.HardwareProfileHostApplying))
+
+				settings.Status.Settings = bmh_v1alpha1.SettingsMap{"ProcVirtualization": "Enabled"}
+				Expect(c.Update(ctx, settings)).To(Succeed())
+				host.Status.Provisioning.RAID = host.Spec.RAID.DeepCopy()
+
+				result = bmhr.reconcileBMH(ctx, bmhr.Log, host, nil, infraEnv)
+				Expect(result).To(Equal(reconcileComplete{stop: true}))
+				Expect(host.Spec.Image.URL).To(Equal(infraEnv.Status.ISODownloadURL))
+				Expect(hardwareProfileState().State).To(Equal(v1beta1.HardwareProfileHostApplied))
+			})
+
+			It("should fail when the firmware settings are invalid", func() {
+				Expect(c.Create(ctx, profile)).To(Succeed())
+				settings := &bmh_v1alpha1.HostFirmwareSettings{
+					ObjectMeta: metav1.ObjectMeta{Name: host.Name, Namespace: host.Namespace},
+					Spec: bmh_v1alpha1.HostFirmwareSettingsSpec{
+						Settings: bmh_v1alpha1.DesiredSettingsMap{"ProcVirtualization": intstr.FromString("Enabled")},
+					},
+					Status: bmh_v1alpha1.HostFirmwareSettingsStatus{
+						Conditions: []metav1.Condition{{
+							Type:    string(bmh_v1alpha1.FirmwareSettingsValid),
+							Status:  metav1.ConditionFalse,
+							Reason:  "ConfigurationError",
+							Message: "Invalid BIOS setting",
+						}},
+					},
+				}
+				Expect(c.Create(ctx, settings)).To(Succeed())
+
+				result := bmhr.reconcileBMH(ctx, bmhr.Log, host, nil, infraEnv)
+				Expect(result).To(Equal(reconcileComplete{stop: true}))
+				Expect(host.Spec.Image).To(BeNil())
+				state := hardwareProfileState()
+				Expect(state.State).To(Equal(v1beta1.HardwareProfileHostFailed))
+				Expect(state.Message).To(ContainSubstring("Invalid BIOS setting"))
+			})
+
+			It("should fail when the host can't be prepared", func() {
+				Expect(c.Create(ctx, profile)).To(Succeed())
+				host.Status.ErrorType = bmh_v1alpha1.PreparationError
+				host.Status.ErrorMessage = "RAID controller not found"
+
+				result := bmhr.reconcileBMH(ctx, bmhr.Log, host, nil, infraEnv)
+				Expect(result).To(Equal(reconcileComplete{stop: true}))
+				Expect(host.Spec.Image).To(BeNil())
+				state := hardwareProfileState()
+				Expect(state.State).To(Equal(v1beta1.HardwareProfileHostFailed))
+				Expect(state.Message).To(ContainSubstring("RAID controller not found"))
+			})
+
+			It("should remove the state when the infraEnv doesn't reference the profile anymore", func() {
+				setAnnotation(&host.ObjectMeta, BMH_HARDWARE_PROFILE_ANNOTATION, `{"profile":"test-profile","state":"Applying"}`)
+				infraEnv.Spec.HardwareProfileRef = nil
+
+				result := bmhr.reconcileBMH(ctx, bmhr.Log, host, nil, infraEnv)
+				Expect(result).To(Equal(reconcileComplete{stop: true}))
+				Expect(host.Spec.Image.URL).To(Equal(infraEnv.Status.ISODownloadURL))
+				Expect(host.GetAnnotations()).ToNot(HaveKey(BMH_HARDWARE_PROFILE_ANNOTATION))
+			})
+		})
 	})
 
 	Describe("Reconcile a BMH with a non-approved matching agent", func() {
@@ -768,6 +915,60 @@ var _ = Describe("bmac reconcile", func() {
 					expectToContainKeyValue(updatedAgent, "forth-label", "forth-label-value")
 				})
 			})
+			Context("reconcile BMC inventory", func() {
+				getBMCInventory := func() (*models.BmcInventory, bool) {
+					updatedAgent := &v1beta1.Agent{}
+					Expect(c.Get(ctx, types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}, updatedAgent)).To(Succeed())
+					value, ok := updatedAgent.GetAnnotations()[AgentBMCInventoryAnnotation]
+					if !ok {
+						return nil, false
+					}
+					bmcInventory := &models.BmcInventory{}
+					Expect(json.Unmarshal([]byte(value), bmcInventory)).To(Succeed())
+					return bmcInventory, true
+				}
+
+				It("doesn't annotate the agent when the BMH has no hardware details", func() {
+					_, err := bmhr.Reconcile(ctx, newBMHRequest(host))
+					Expect(err).To(BeNil())
+					_, ok := getBMCInventory()
+					Expect(ok).To(BeFalse())
+				})
+
+				It("copies the firmware, boot mode, RAID and firmware settings of the BMH", func() {
+					h := &bmh_v1alpha1.BareMetalHost{}
+					Expect(c.Get(ctx, types.NamespacedName{Name: host.Name, Namespace: host.Namespace}, h)).To(Succeed())
+					h.Spec.BootMode = bmh_v1alpha1.UEFI
+					h.Spec.RAID = &bmh_v1alpha1.RAIDConfig{
+						SoftwareRAIDVolumes: []bmh_v1alpha1.SoftwareRAIDVolume{{Level: "1"}},
+					}
+					h.Status.Provisioning.BootMode = bmh_v1alpha1.UEFISecureBoot
+					h.Status.HardwareDetails = &bmh_v1alpha1.HardwareDetails{
+						Firmware: bmh_v1alpha1.Firmware{BIOS: bmh_v1alpha1.BIOS{Vendor: "Dell Inc.", Version: "2.19.1", Date: "03/08/2023"}},
+					}
+					Expect(c.Update(ctx, h)).To(Succeed())
+					settings := &bmh_v1alpha1.HostFirmwareSettings{
+						ObjectMeta: metav1.ObjectMeta{Name: host.Name, Namespace: host.Namespace},
+						Status: bmh_v1alpha1.HostFirmwareSettingsStatus{
+							Settings: bmh_v1alpha1.SettingsMap{"ProcVirtualization": "Enabled"},
+						},
+					}
+					Expect(c.Create(ctx, settings)).To(Succeed())
+
+					_, err := bmhr.Reconcile(ctx, newBMHRequest(host))
+					Expect(err).To(BeNil())
+					bmcInventory, ok := getBMCInventory()
+					Expect(ok).To(BeTrue())
+					Expect(bmcInventory).To(Equal(&models.BmcInventory{
+						BiosVendor:       "Dell Inc.",
+						BiosVersion:      "2.19.1",
+						BiosDate:         "03/08/2023",
+						BootMode:         string(bmh_v1alpha1.UEFISecureBoot),
+						FirmwareSettings: map[string]string{"ProcVirtualization": "Enabled"},
+						RaidVolumes:      []*models.BmcRaidVolume{{Level: "1", Software: true}},
+					}))
+				})
+			})
 			Context("reconcile cluster reference", func() {
 				const (
 					clusterName              = "cluster-name"
@@ -1214,6 +1415,12 @@ var _ = Describe("bmac reconcile", func() {
 					},
 				).Times(2)
 
+				mockClient.EXPECT().Get(gomock.Any(), gomock.AssignableToTypeOf(types.NamespacedName{}), gomock.AssignableToTypeOf(&bmh_v1alpha1.HostFirmwareSettings{})).DoAndReturn(
+					func(ctx context.Context, name types.NamespacedName, settings *bmh_v1alpha1.HostFirmwareSettings, opts ...client.GetOption) error {
+						return c.Get(ctx, name, settings, opts...)
+					},
+				).Times(2)
+
 				mockClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v1beta1.AgentList{}), gomock.Any()).DoAndReturn(
 					func(ctx context.Context, agentList *v1beta1.AgentList, namespace client.InNamespace) error {
 						return c.List(ctx, agentList, namespace)
@@ -4191,6 +4398,7 @@ var _ = Describe("handleBMHFinalizer", func() {
 				cdKey := types.NamespacedName{Name: agent.Spec.ClusterDeploymentName.Name, Namespace: testNamespace}
 				mockClient.EXPECT().Get(ctx, cdKey, gomock.AssignableToTypeOf(&hivev1.ClusterDeployment{})).DoAndReturn(
 					func(_ context.Context, key client.ObjectKey, cd *hivev1.ClusterDeployment, _ ...client.GetOption) error {
+						cd.Name = key.Name
 						cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
 							AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "clusterKubeConfig"},
 						}
@@ -4198,6 +4406,11 @@ var _ = Describe("handleBMHFinalizer", func() {
 					},
 				).AnyTimes()
 
+				// no service account kubeconfig was minted for the cluster
+				saSecretKey := types.NamespacedName{Name: agent.Spec.ClusterDeploymentName.Name + "-service-account-kubeconfig", Namespace: testNamespace}
+				mockClient.EXPECT().Get(ctx, saSecretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).
+					Return(k8serrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, saSecretKey.Name)).Times(2)
+
 				// mock secret
 				secretKey := types.NamespacedName{Name: "clusterKubeConfig", Namespace: testNamespace}
 				mockClient.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(
@@ -82,6 +82,7 @@ import (
 const (
 	adminPasswordSecretStringTemplate   = "%s-admin-password"
 	adminKubeConfigStringTemplate       = "%s-admin-kubeconfig"
+	serviceAccountKubeConfigTemplate    = "%s-service-account-kubeconfig"
 	InstallConfigOverrides              = aiv1beta1.Group + "/install-config-overrides"
 	IgnoredClusterValidationsAnnotation = aiv1beta1.Group + "/ignored-cluster-validations"
 	IgnoredHostValidationsAnnotation    = aiv1beta1.Group + "/ignored-host-validations"
@@ -2704,6 +2705,12 @@ func getClusterDeploymentAdminKubeConfigSecretName(cd *hivev1.ClusterDeployment)
 	return fmt.Sprintf(adminKubeConfigStringTemplate, cd.Name)
 }
 
+// getClusterDeploymentServiceAccountKubeConfigSecretName returns the name of the secret with the
+// service account kubeconfig minted on the installed cluster by the SpokeKubeconfigReconciler
+func getClusterDeploymentServiceAccountKubeConfigSecretName(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf(serviceAccountKubeConfigTemplate, cd.Name)
+}
+
 // processMirrorRegistryConfig retrieves the mirror registry configuration from the referenced ConfigMap
 func (r *ClusterDeploymentsReconciler) processMirrorRegistryConfig(ctx context.Context, log logrus.FieldLogger, clusterInstall *hiveext.AgentClusterInstall) (*common.MirrorRegistryConfiguration, error) {
 	mirrorRegistryConfiguration, userTomlConfigMap, err := mirrorregistry.ProcessMirrorRegistryConfig(ctx, log, r.Client, clusterInstall.Spec.MirrorRegistryRef)
@@ -0,0 +1,556 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	clusterReprovisionRequeue            = 30 * time.Second
+	defaultClusterReprovisionNodeTimeout = 2 * time.Hour
+)
+
+// clusterReprovisionFailedStates are the states of the Agents whose installation failed
+var clusterReprovisionFailedStates = []string{
+	models.HostStatusError,
+	models.HostStatusCancelled,
+	models.HostStatusInstallingPendingUserAction,
+}
+
+// ClusterReprovisionReconciler reconciles a ClusterReprovision object
+type ClusterReprovisionReconciler struct {
+	client.Client
+	APIReader             client.Reader
+	Log                   logrus.FieldLogger
+	Scheme                *runtime.Scheme
+	SpokeK8sClientFactory spoke_k8s_client.SpokeK8sClientFactory
+	Drainer               Drainer
+}
+
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=clusterreprovisions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=clusterreprovisions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agentremovals,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agents,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=hive.openshift.io,resources=clusterdeployments,verbs=get;list;watch
+
+func (r *ClusterReprovisionReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx := addRequestIdIfNeeded(origCtx)
+	log := logutil.FromContext(ctx, r.Log).WithFields(
+		logrus.Fields{
+			"cluster_reprovision":           req.Name,
+			"cluster_reprovision_namespace": req.Namespace,
+		})
+
+	defer func() {
+		log.Debug("ClusterReprovision Reconcile ended")
+	}()
+
+	log.Debug("ClusterReprovision Reconcile started")
+
+	reprovision := &aiv1beta1.ClusterReprovision{}
+	if err := r.Get(ctx, req.NamespacedName, reprovision); err != nil {
+		log.WithError(err).Errorf("Failed to get ClusterReprovision %s", req.NamespacedName)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if clusterReprovisionIsDone(reprovision) {
+		return ctrl.Result{}, nil
+	}
+	clusterRef := reprovision.Spec.ClusterDeploymentName
+	log = log.WithField("cluster_deployment", fmt.Sprintf("%s/%s", clusterRef.Namespace, clusterRef.Name))
+
+	clusterDeployment := &hivev1.ClusterDeployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: clusterRef.Namespace, Name: clusterRef.Name}, clusterDeployment); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse,
+			aiv1beta1.ClusterReprovisionClusterNotFoundReason, fmt.Sprintf("ClusterDeployment %s/%s not found", clusterRef.Namespace, clusterRef.Name))
+		return ctrl.Result{}, r.updateClusterReprovisionStatus(ctx, log, reprovision)
+	}
+
+	// The Agents are selected once, when the reprovisioning starts
+	if conditionsv1.FindStatusCondition(reprovision.Status.Conditions, aiv1beta1.ClusterReprovisionCompletedCondition) == nil ||
+		clusterReprovisionConditionReason(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition) == aiv1beta1.ClusterReprovisionClusterNotFoundReason {
+		if err := r.selectAgents(ctx, reprovision); err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(reprovision.Status.Agents) == 0 {
+			setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionTrue,
+				aiv1beta1.ClusterReprovisionNoAgentsSelectedReason, "No installed worker Agent with a BareMetalHost matches the agent selector")
+			return ctrl.Result{}, r.updateClusterReprovisionStatus(ctx, log, reprovision)
+		}
+	}
+
+	result, err := r.reprovision(ctx, log, reprovision, clusterDeployment)
+	if err != nil {
+		log.WithError(err).Error("failed to reprovision cluster")
+	}
+	if updateErr := r.updateClusterReprovisionStatus(ctx, log, reprovision); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	return result, err
+}
+
+// selectAgents records the Agents to reprovision: the installed worker Agents of the cluster that
+// have a BareMetalHost and that match the selector
+func (r *ClusterReprovisionReconciler) selectAgents(ctx context.Context, reprovision *aiv1beta1.ClusterReprovision) error {
+	opts := []client.ListOption{client.InNamespace(reprovision.Namespace)}
+	if reprovision.Spec.AgentSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(reprovision.Spec.AgentSelector)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+	}
+	agentList := &aiv1beta1.AgentList{}
+	if err := r.List(ctx, agentList, opts...); err != nil {
+		return err
+	}
+
+	reprovision.Status.Agents = []aiv1beta1.ReprovisionedAgent{}
+	for i := range agentList.Items {
+		agent := &agentList.Items[i]
+		if agent.Spec.ClusterDeploymentName == nil || *agent.Spec.ClusterDeploymentName != reprovision.Spec.ClusterDeploymentName {
+			continue
+		}
+		if _, ok := agent.Labels[AGENT_BMH_LABEL]; !ok || agent.Status.Role != models.HostRoleWorker ||
+			!funk.ContainsString(agentRemovalInstalledStates, agent.Status.DebugInfo.State) {
+			continue
+		}
+		reprovision.Status.Agents = append(reprovision.Status.Agents, aiv1beta1.ReprovisionedAgent{
+			Name:     agent.Name,
+			NodeName: getAgentHostname(agent),
+			State:    aiv1beta1.ReprovisionedAgentPending,
+		})
+	}
+	sort.Slice(reprovision.Status.Agents, func(i, j int) bool {
+		return reprovision.Status.Agents[i].Name < reprovision.Status.Agents[j].Name
+	})
+	return nil
+}
+
+// reprovision moves the Agents being reprovisioned forward and starts the next ones while the
+// cluster is healthy. Once an Agent fails no other Agent is started, and the Agents that weren't
+// unbound yet are rolled back.
+func (r *ClusterReprovisionReconciler) reprovision(ctx context.Context, log logrus.FieldLogger, reprovision *aiv1beta1.ClusterReprovision, clusterDeployment *hivev1.ClusterDeployment) (ctrl.Result, error) {
+	spokeClient, clientset, err := r.spokeClients(ctx, log, reprovision, clusterDeployment)
+	if err != nil {
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionHealthyCondition, corev1.ConditionFalse,
+			aiv1beta1.ClusterReprovisionClusterUnreachableReason, fmt.Sprintf("Failed to connect to the cluster: %s", err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	for i := range reprovision.Status.Agents {
+		reprovisionedAgent := &reprovision.Status.Agents[i]
+		if !clusterReprovisionAgentInProgress(reprovisionedAgent) {
+			continue
+		}
+		if err = r.advance(ctx, log.WithField("agent", reprovisionedAgent.Name), reprovision, reprovisionedAgent, spokeClient); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	stopping := funk.Contains(reprovision.Status.Agents, func(a aiv1beta1.ReprovisionedAgent) bool {
+		return a.State == aiv1beta1.ReprovisionedAgentFailed
+	})
+	if stopping {
+		for i := range reprovision.Status.Agents {
+			reprovisionedAgent := &reprovision.Status.Agents[i]
+			switch reprovisionedAgent.State {
+			case aiv1beta1.ReprovisionedAgentRemoving:
+				if err = r.rollback(ctx, log.WithField("agent", reprovisionedAgent.Name), reprovision, reprovisionedAgent, spokeClient, clientset); err != nil {
+					return ctrl.Result{}, err
+				}
+			case aiv1beta1.ReprovisionedAgentFailed:
+				if err = r.rollbackFailed(ctx, log.WithField("agent", reprovisionedAgent.Name), reprovision, reprovisionedAgent, spokeClient, clientset); err != nil {
+					return ctrl.Result{}, err
+				}
+			case aiv1beta1.ReprovisionedAgentPending:
+				reprovisionedAgent.Message = "The reprovisioning stopped before the Agent was reprovisioned"
+			}
+		}
+	} else if healthy, err := r.checkHealth(ctx, reprovision, spokeClient); err != nil {
+		return ctrl.Result{}, err
+	} else if healthy {
+		if err = r.startNext(ctx, log, reprovision); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	var inProgress, pending, failed []string
+	for i := range reprovision.Status.Agents {
+		reprovisionedAgent := &reprovision.Status.Agents[i]
+		switch {
+		case clusterReprovisionAgentInProgress(reprovisionedAgent):
+			inProgress = append(inProgress, reprovisionedAgent.Name)
+		case reprovisionedAgent.State == aiv1beta1.ReprovisionedAgentPending:
+			pending = append(pending, reprovisionedAgent.Name)
+		case reprovisionedAgent.State == aiv1beta1.ReprovisionedAgentFailed:
+			failed = append(failed, reprovisionedAgent.Name)
+		}
+	}
+	switch {
+	case stopping && len(inProgress) > 0:
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, aiv1beta1.ClusterReprovisionStoppingReason,
+			fmt.Sprintf("Agents %s failed, waiting for Agents %s that were already unbound to be reinstalled", strings.Join(failed, ", "), strings.Join(inProgress, ", ")))
+	case stopping:
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, aiv1beta1.ClusterReprovisionStoppedReason,
+			fmt.Sprintf("The reprovisioning stopped because Agents %s failed", strings.Join(failed, ", ")))
+		return ctrl.Result{}, nil
+	case len(inProgress) == 0 && len(pending) == 0:
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionTrue, aiv1beta1.ClusterReprovisionCompletedReason,
+			fmt.Sprintf("The %d selected nodes were reprovisioned", len(reprovision.Status.Agents)))
+		return ctrl.Result{}, nil
+	default:
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, aiv1beta1.ClusterReprovisionInProgressReason,
+			fmt.Sprintf("Reprovisioning Agents %s, %d Agents pending", strings.Join(inProgress, ", "), len(pending)))
+	}
+	return ctrl.Result{RequeueAfter: clusterReprovisionRequeue}, nil
+}
+
+func (r *ClusterReprovisionReconciler) spokeClients(ctx context.Context, log logrus.FieldLogger, reprovision *aiv1beta1.ClusterReprovision, clusterDeployment *hivev1.ClusterDeployment) (spoke_k8s_client.SpokeK8sClient, *kubernetes.Clientset, error) {
+	secret, err := spokeKubeconfigSecret(ctx, log, r.Client, r.APIReader, &reprovision.Spec.ClusterDeploymentName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.SpokeK8sClientFactory.ClientAndSetFromSecret(clusterDeployment, secret)
+}
+
+func clusterReprovisionRemovalName(reprovision *aiv1beta1.ClusterReprovision, agentName string) string {
+	return fmt.Sprintf("%s-%s", reprovision.Name, agentName)
+}
+
+// checkHealth checks that the nodes of the cluster are ready before another node is reprovisioned,
+// the nodes being reprovisioned are ignored
+func (r *ClusterReprovisionReconciler) checkHealth(ctx context.Context, reprovision *aiv1beta1.ClusterReprovision, spokeClient spoke_k8s_client.SpokeK8sClient) (bool, error) {
+	nodes := &corev1.NodeList{}
+	if err := spokeClient.List(ctx, nodes); err != nil {
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionHealthyCondition, corev1.ConditionFalse,
+			aiv1beta1.ClusterReprovisionClusterUnreachableReason, fmt.Sprintf("Failed to list the nodes of the cluster: %s", err.Error()))
+		return false, err
+	}
+	reprovisioned := make(map[string]bool)
+	for i := range reprovision.Status.Agents {
+		if clusterReprovisionAgentInProgress(&reprovision.Status.Agents[i]) {
+			reprovisioned[reprovision.Status.Agents[i].NodeName] = true
+		}
+	}
+	var notReady []string
+	for i := range nodes.Items {
+		if !reprovisioned[nodes.Items[i].Name] && !isNodeReady(&nodes.Items[i]) {
+			notReady = append(notReady, nodes.Items[i].Name)
+		}
+	}
+	if len(notReady) > 0 {
+		setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionHealthyCondition, corev1.ConditionFalse,
+			aiv1beta1.ClusterReprovisionNodesNotReadyReason, fmt.Sprintf("Nodes %s are not ready, waiting for them before reprovisioning other nodes", strings.Join(notReady, ", ")))
+		return false, nil
+	}
+	setClusterReprovisionCondition(reprovision, aiv1beta1.ClusterReprovisionHealthyCondition, corev1.ConditionTrue,
+		aiv1beta1.ClusterReprovisionNodesReadyReason, "The nodes of the cluster are ready")
+	return true, nil
+}
+
+// startNext starts reprovisioning the pending Agents, as long as no more than MaxUnavailable nodes
+// are reprovisioned at the same time
+func (r *ClusterReprovisionReconciler) startNext(ctx context.Context, log logrus.FieldLogger, reprovision *aiv1beta1.ClusterReprovision) error {
+	maxUnavailable := reprovision.Spec.MaxUnavailable
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+	inProgress := 0
+	for i := range reprovision.Status.Agents {
+		if clusterReprovisionAgentInProgress(&reprovision.Status.Agents[i]) {
+			inProgress++
+		}
+	}
+
+	for i := range reprovision.Status.Agents {
+		if inProgress >= maxUnavailable {
+			return nil
+		}
+		reprovisionedAgent := &reprovision.Status.Agents[i]
+		if reprovisionedAgent.State != aiv1beta1.ReprovisionedAgentPending {
+			continue
+		}
+		removal := &aiv1beta1.AgentRemoval{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterReprovisionRemovalName(reprovision, reprovisionedAgent.Name),
+				Namespace: reprovision.Namespace,
+			},
+			Spec: aiv1beta1.AgentRemovalSpec{
+				AgentName:           reprovisionedAgent.Name,
+				DrainTimeout:        reprovision.Spec.DrainTimeout,
+				BareMetalHostAction: aiv1beta1.AgentRemovalBareMetalHostActionReclaim,
+			},
+		}
+		if err := controllerutil.SetControllerReference(reprovision, removal, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, removal); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+		log.Infof("Started reprovisioning agent %s, created AgentRemoval %s", reprovisionedAgent.Name, removal.Name)
+		now := metav1.Now()
+		reprovisionedAgent.StartTime = &now
+		reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentRemoving
+		reprovisionedAgent.Message = fmt.Sprintf("Removing node %s from the cluster", reprovisionedAgent.NodeName)
+		inProgress++
+	}
+	return nil
+}
+
+// advance moves the reprovisioning of an Agent forward: once its removal returned the host to its
+// InfraEnv, the Agent is bound to the cluster again and installed as a day-2 node, then its node
+// must become ready
+func (r *ClusterReprovisionReconciler) advance(ctx context.Context, log logrus.FieldLogger, reprovision *aiv1beta1.ClusterReprovision,
+	reprovisionedAgent *aiv1beta1.ReprovisionedAgent, spokeClient spoke_k8s_client.SpokeK8sClient) error {
+	agent := &aiv1beta1.Agent{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: reprovision.Namespace, Name: reprovisionedAgent.Name}, agent); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentFailed
+		reprovisionedAgent.Message = fmt.Sprintf("Agent %s not found", reprovisionedAgent.Name)
+		return nil
+	}
+
+	nodeTimeout := defaultClusterReprovisionNodeTimeout
+	if reprovision.Spec.NodeTimeout != nil {
+		nodeTimeout = reprovision.Spec.NodeTimeout.Duration
+	}
+	if reprovisionedAgent.StartTime != nil && time.Since(reprovisionedAgent.StartTime.Time) >= nodeTimeout {
+		log.Warnf("Agent was not reprovisioned within %s", nodeTimeout)
+		reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentFailed
+		reprovisionedAgent.Message = fmt.Sprintf("Node %s was not reprovisioned within %s: %s", reprovisionedAgent.NodeName, nodeTimeout, reprovisionedAgent.Message)
+		return nil
+	}
+
+	state := agent.Status.DebugInfo.State
+	switch reprovisionedAgent.State {
+	case aiv1beta1.ReprovisionedAgentRemoving:
+		removal := &aiv1beta1.AgentRemoval{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: reprovision.Namespace, Name: clusterReprovisionRemovalName(reprovision, agent.Name)}, removal); err != nil {
+			if k8serrors.IsNotFound(err) {
+				reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentFailed
+				reprovisionedAgent.Message = "The AgentRemoval of the Agent was deleted"
+				return nil
+			}
+			return err
+		}
+		completed := conditionsv1.FindStatusCondition(removal.Status.Conditions, aiv1beta1.AgentRemovalCompletedCondition)
+		switch {
+		case completed == nil:
+			return nil
+		case completed.Status == corev1.ConditionTrue:
+			log.Info("Host is back in its InfraEnv, binding the agent to the cluster again")
+			clusterRef := reprovision.Spec.ClusterDeploymentName
+			agent.Spec.ClusterDeploymentName = &clusterRef
+			if err := r.Update(ctx, agent); err != nil {
+				return err
+			}
+			reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentReinstalling
+			reprovisionedAgent.Message = "The Agent was bound to the cluster again, waiting for it to be installed"
+		case completed.Reason == aiv1beta1.AgentRemovalPendingUserActionReason:
+			reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentFailed
+			reprovisionedAgent.Message = completed.Message
+		default:
+			reprovisionedAgent.Message = completed.Message
+		}
+	case aiv1beta1.ReprovisionedAgentReinstalling:
+		switch {
+		case funk.ContainsString(agentRemovalInstalledStates, state):
+			reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentVerifying
+			reprovisionedAgent.Message = fmt.Sprintf("Waiting for node %s to be ready", reprovisionedAgent.NodeName)
+		case funk.ContainsString(clusterReprovisionFailedStates, state):
+			reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentFailed
+			reprovisionedAgent.Message = fmt.Sprintf("The installation of the Agent failed, the Agent is %s", state)
+		default:
+			reprovisionedAgent.Message = fmt.Sprintf("Waiting for the Agent to be installed, the Agent is %s", state)
+		}
+	case aiv1beta1.ReprovisionedAgentVerifying:
+		node, err := spokeClient.GetNode(ctx, reprovisionedAgent.NodeName)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && isNodeReady(node) {
+			log.Infof("Node %s was reprovisioned", reprovisionedAgent.NodeName)
+			reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentReprovisioned
+			reprovisionedAgent.Message = fmt.Sprintf("Node %s was reinstalled and is ready", reprovisionedAgent.NodeName)
+		}
+	}
+	return nil
+}
+
+// rollback stops the removal of an Agent that wasn't unbound yet and uncordons its node. An Agent
+// that was unbound can't be rolled back, it's reinstalled.
+func (r *ClusterReprovisionReconciler) rollback(ctx context.Context, log logrus.FieldLogger, reprovision *aiv1beta1.ClusterReprovision,
+	reprovisionedAgent *aiv1beta1.ReprovisionedAgent, spokeClient spoke_k8s_client.SpokeK8sClient, clientset kubernetes.Interface) error {
+	removal := &aiv1beta1.AgentRemoval{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: reprovision.Namespace, Name: clusterReprovisionRemovalName(reprovision, reprovisionedAgent.Name)}, removal); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		if conditionsv1.IsStatusConditionTrue(removal.Status.Conditions, aiv1beta1.AgentRemovalUnboundCondition) {
+			return nil
+		}
+		log.Infof("Rolling back, deleting AgentRemoval %s", removal.Name)
+		if err = r.Delete(ctx, removal); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if err := r.uncordonNode(ctx, log, reprovisionedAgent.NodeName, spokeClient, clientset); err != nil {
+		return err
+	}
+	reprovisionedAgent.State = aiv1beta1.ReprovisionedAgentRolledBack
+	reprovisionedAgent.Message = fmt.Sprintf("The reprovisioning stopped before the Agent was unbound, node %s was uncordoned", reprovisionedAgent.NodeName)
+	return nil
+}
+
+// rollbackFailed rolls back the removal of an Agent that failed before it was unbound: its node is
+// uncordoned and its AgentRemoval deleted, the Agent stays failed. The AgentRemoval is deleted last
+// so that the rollback is retried until the node is uncordoned.
+func (r *ClusterReprovisionReconciler) rollbackFailed(ctx context.Context, log logrus.FieldLogger, reprovision *aiv1beta1.ClusterReprovision,
+	reprovisionedAgent *aiv1beta1.ReprovisionedAgent, spokeClient spoke_k8s_client.SpokeK8sClient, clientset kubernetes.Interface) error {
+	removal := &aiv1beta1.AgentRemoval{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: reprovision.Namespace, Name: clusterReprovisionRemovalName(reprovision, reprovisionedAgent.Name)}, removal); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if conditionsv1.IsStatusConditionTrue(removal.Status.Conditions, aiv1beta1.AgentRemovalUnboundCondition) {
+		return nil
+	}
+	if err := r.uncordonNode(ctx, log, reprovisionedAgent.NodeName, spokeClient, clientset); err != nil {
+		return err
+	}
+	log.Infof("Rolling back the failed Agent, deleting AgentRemoval %s", removal.Name)
+	if err := r.Delete(ctx, removal); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	reprovisionedAgent.Message = fmt.Sprintf("%s, node %s was uncordoned", reprovisionedAgent.Message, reprovisionedAgent.NodeName)
+	return nil
+}
+
+func (r *ClusterReprovisionReconciler) uncordonNode(ctx context.Context, log logrus.FieldLogger, nodeName string,
+	spokeClient spoke_k8s_client.SpokeK8sClient, clientset kubernetes.Interface) error {
+	node, err := spokeClient.GetNode(ctx, nodeName)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && node.Spec.Unschedulable {
+		drainHelper, out := newDrainHelper(ctx, log, clientset, node, node.Name)
+		if err = r.Drainer.RunCordonOrUncordon(drainHelper, node, false); err != nil {
+			log.WithError(err).Errorf("failed to uncordon node: output: %s", out)
+			return err
+		}
+	}
+	return nil
+}
+
+func clusterReprovisionAgentInProgress(reprovisionedAgent *aiv1beta1.ReprovisionedAgent) bool {
+	switch reprovisionedAgent.State {
+	case aiv1beta1.ReprovisionedAgentRemoving, aiv1beta1.ReprovisionedAgentReinstalling, aiv1beta1.ReprovisionedAgentVerifying:
+		return true
+	}
+	return false
+}
+
+func clusterReprovisionIsDone(reprovision *aiv1beta1.ClusterReprovision) bool {
+	return conditionsv1.IsStatusConditionTrue(reprovision.Status.Conditions, aiv1beta1.ClusterReprovisionCompletedCondition) ||
+		clusterReprovisionConditionReason(reprovision, aiv1beta1.ClusterReprovisionCompletedCondition) == aiv1beta1.ClusterReprovisionStoppedReason
+}
+
+func setClusterReprovisionCondition(reprovision *aiv1beta1.ClusterReprovision, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusConditionNoHeartbeat(&reprovision.Status.Conditions, conditionsv1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func clusterReprovisionConditionReason(reprovision *aiv1beta1.ClusterReprovision, conditionType conditionsv1.ConditionType) string {
+	if condition := conditionsv1.FindStatusCondition(reprovision.Status.Conditions, conditionType); condition != nil {
+		return condition.Reason
+	}
+	return ""
+}
+
+func (r *ClusterReprovisionReconciler) updateClusterReprovisionStatus(ctx context.Context, log logrus.FieldLogger, reprovision *aiv1beta1.ClusterReprovision) error {
+	if err := r.Status().Update(ctx, reprovision); err != nil {
+		log.WithError(err).Error("failed to update cluster reprovision status")
+		return err
+	}
+	return nil
+}
+
+func (r *ClusterReprovisionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	mapAgentToClusterReprovisions := func(ctx context.Context, agent client.Object) []reconcile.Request {
+		log := logutil.FromContext(ctx, r.Log).WithFields(
+			logrus.Fields{
+				"agent":           agent.GetName(),
+				"agent_namespace": agent.GetNamespace(),
+			})
+		reprovisionList := &aiv1beta1.ClusterReprovisionList{}
+		if err := r.List(ctx, reprovisionList, client.InNamespace(agent.GetNamespace())); err != nil {
+			log.Debugf("failed to list cluster reprovisions")
+			return []reconcile.Request{}
+		}
+		reply := []reconcile.Request{}
+		for _, reprovision := range reprovisionList.Items {
+			if funk.Contains(reprovision.Status.Agents, func(a aiv1beta1.ReprovisionedAgent) bool { return a.Name == agent.GetName() }) {
+				reply = append(reply, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: reprovision.Namespace,
+					Name:      reprovision.Name,
+				}})
+			}
+		}
+		return reply
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&aiv1beta1.ClusterReprovision{}).
+		Owns(&aiv1beta1.AgentRemoval{}).
+		Watches(&aiv1beta1.Agent{}, handler.EnqueueRequestsFromMapFunc(mapAgentToClusterReprovisions)).
+		Complete(r)
+}
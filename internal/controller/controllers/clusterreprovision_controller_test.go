@@ -0,0 +1,368 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/models"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kubectl/pkg/drain"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ClusterReprovision reconcile", func() {
+	var (
+		c                 client.Client
+		r                 *ClusterReprovisionReconciler
+		ctx               = context.Background()
+		mockCtrl          *gomock.Controller
+		mockDrainer       *MockDrainer
+		mockClientFactory *spoke_k8s_client.MockSpokeK8sClientFactory
+		mockSpokeClient   *spoke_k8s_client.MockSpokeK8sClient
+		reprovision       *v1beta1.ClusterReprovision
+		nodes             map[string]*corev1.Node
+		cluster           = v1beta1.ClusterReference{Name: "test-cluster", Namespace: testNamespace}
+	)
+
+	newNode := func(name string, ready bool) *corev1.Node {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			},
+		}
+	}
+
+	createWorker := func(name string) *v1beta1.Agent {
+		agent := newAgent(name, testNamespace, v1beta1.AgentSpec{
+			ClusterDeploymentName: &cluster,
+			Hostname:              name + ".example.com",
+			Approved:              true,
+		})
+		agent.Labels = map[string]string{AGENT_BMH_LABEL: name}
+		agent.Status.Role = models.HostRoleWorker
+		agent.Status.DebugInfo.State = models.HostStatusAddedToExistingCluster
+		Expect(c.Create(ctx, agent)).To(Succeed())
+		nodes[agent.Spec.Hostname] = newNode(agent.Spec.Hostname, true)
+		return agent
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockDrainer = NewMockDrainer(mockCtrl)
+		mockClientFactory = spoke_k8s_client.NewMockSpokeK8sClientFactory(mockCtrl)
+		mockSpokeClient = spoke_k8s_client.NewMockSpokeK8sClient(mockCtrl)
+		c = fakeclient.NewClientBuilder().WithStatusSubresource(&v1beta1.ClusterReprovision{}, &v1beta1.AgentRemoval{}).Build()
+		r = &ClusterReprovisionReconciler{
+			Client:                c,
+			APIReader:             c,
+			Log:                   common.GetTestLog(),
+			Scheme:                scheme.Scheme,
+			SpokeK8sClientFactory: mockClientFactory,
+			Drainer:               mockDrainer,
+		}
+
+		cd := &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: cluster.Namespace},
+			Spec: hivev1.ClusterDeploymentSpec{
+				ClusterMetadata: &hivev1.ClusterMetadata{
+					AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "clusterKubeConfig"},
+				},
+			},
+		}
+		Expect(c.Create(ctx, cd)).To(Succeed())
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "clusterKubeConfig", Namespace: cluster.Namespace},
+			Data:       map[string][]byte{"kubeconfig": []byte("definitely_a_kubeconfig")},
+		}
+		Expect(c.Create(ctx, secret)).To(Succeed())
+		mockClientFactory.EXPECT().ClientAndSetFromSecret(gomock.Any(), gomock.AssignableToTypeOf(&corev1.Secret{})).
+			Return(mockSpokeClient, &kubernetes.Clientset{}, nil).AnyTimes()
+
+		nodes = map[string]*corev1.Node{}
+		mockSpokeClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&corev1.NodeList{})).DoAndReturn(
+			func(_ context.Context, list *corev1.NodeList, _ ...client.ListOption) error {
+				list.Items = nil
+				for _, node := range nodes {
+					list.Items = append(list.Items, *node)
+				}
+				return nil
+			}).AnyTimes()
+		mockSpokeClient.EXPECT().GetNode(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, name string) (*corev1.Node, error) {
+				if node, ok := nodes[name]; ok {
+					return node, nil
+				}
+				return nil, k8serrors.NewNotFound(corev1.Resource("nodes"), name)
+			}).AnyTimes()
+
+		reprovision = &v1beta1.ClusterReprovision{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-reprovision", Namespace: testNamespace},
+			Spec: v1beta1.ClusterReprovisionSpec{
+				ClusterDeploymentName: cluster,
+				MaxUnavailable:        1,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	reconcileReprovision := func() (ctrl.Result, *v1beta1.ClusterReprovision) {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: reprovision.Namespace, Name: reprovision.Name}})
+		Expect(err).ToNot(HaveOccurred())
+		updated := &v1beta1.ClusterReprovision{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: reprovision.Namespace, Name: reprovision.Name}, updated)).To(Succeed())
+		return result, updated
+	}
+
+	expectCondition := func(reprovision *v1beta1.ClusterReprovision, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason string) {
+		condition := conditionsv1.FindStatusCondition(reprovision.Status.Conditions, conditionType)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(status))
+		Expect(condition.Reason).To(Equal(reason))
+	}
+
+	agentStates := func(reprovision *v1beta1.ClusterReprovision) map[string]v1beta1.ReprovisionedAgentState {
+		states := map[string]v1beta1.ReprovisionedAgentState{}
+		for _, a := range reprovision.Status.Agents {
+			states[a.Name] = a.State
+		}
+		return states
+	}
+
+	getRemoval := func(agentName string) *v1beta1.AgentRemoval {
+		removal := &v1beta1.AgentRemoval{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: reprovision.Name + "-" + agentName}, removal)).To(Succeed())
+		return removal
+	}
+
+	setRemovalCondition := func(agentName string, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason string) {
+		removal := getRemoval(agentName)
+		conditionsv1.SetStatusConditionNoHeartbeat(&removal.Status.Conditions, conditionsv1.Condition{
+			Type:   conditionType,
+			Status: status,
+			Reason: reason,
+		})
+		Expect(c.Status().Update(ctx, removal)).To(Succeed())
+	}
+
+	setAgentState := func(agentName, state string) {
+		agent := &v1beta1.Agent{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: agentName}, agent)).To(Succeed())
+		agent.Status.DebugInfo.State = state
+		Expect(c.Update(ctx, agent)).To(Succeed())
+	}
+
+	It("completes when no agent is selected", func() {
+		agent := createWorker("worker-0")
+		agent.Labels = nil
+		Expect(c.Update(ctx, agent)).To(Succeed())
+		master := createWorker("master-0")
+		master.Status.Role = models.HostRoleMaster
+		Expect(c.Update(ctx, master)).To(Succeed())
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+
+		result, updated := reconcileReprovision()
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(updated.Status.Agents).To(BeEmpty())
+		expectCondition(updated, v1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionTrue, v1beta1.ClusterReprovisionNoAgentsSelectedReason)
+	})
+
+	It("reports a missing cluster", func() {
+		reprovision.Spec.ClusterDeploymentName.Name = "missing"
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+
+		_, updated := reconcileReprovision()
+		expectCondition(updated, v1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, v1beta1.ClusterReprovisionClusterNotFoundReason)
+	})
+
+	It("only selects the agents matching the selector", func() {
+		createWorker("worker-0")
+		selected := createWorker("worker-1")
+		selected.Labels["reprovision"] = "true"
+		Expect(c.Update(ctx, selected)).To(Succeed())
+		reprovision.Spec.AgentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"reprovision": "true"}}
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+
+		_, updated := reconcileReprovision()
+		Expect(agentStates(updated)).To(Equal(map[string]v1beta1.ReprovisionedAgentState{
+			"worker-1": v1beta1.ReprovisionedAgentRemoving,
+		}))
+	})
+
+	It("reprovisions the nodes one by one", func() {
+		createWorker("worker-0")
+		createWorker("worker-1")
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+
+		By("removing the first node")
+		result, updated := reconcileReprovision()
+		Expect(result.RequeueAfter).To(Equal(clusterReprovisionRequeue))
+		Expect(agentStates(updated)).To(Equal(map[string]v1beta1.ReprovisionedAgentState{
+			"worker-0": v1beta1.ReprovisionedAgentRemoving,
+			"worker-1": v1beta1.ReprovisionedAgentPending,
+		}))
+		Expect(updated.Status.Agents[0].NodeName).To(Equal("worker-0.example.com"))
+		expectCondition(updated, v1beta1.ClusterReprovisionHealthyCondition, corev1.ConditionTrue, v1beta1.ClusterReprovisionNodesReadyReason)
+		expectCondition(updated, v1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, v1beta1.ClusterReprovisionInProgressReason)
+		removal := getRemoval("worker-0")
+		Expect(removal.Spec.AgentName).To(Equal("worker-0"))
+		Expect(removal.Spec.BareMetalHostAction).To(Equal(v1beta1.AgentRemovalBareMetalHostActionReclaim))
+		Expect(metav1.IsControlledBy(removal, updated)).To(BeTrue())
+
+		By("binding the agent again once its host is back in the InfraEnv")
+		nodes["worker-0.example.com"].Status.Conditions[0].Status = corev1.ConditionFalse
+		setAgentState("worker-0", models.HostStatusKnownUnbound)
+		agent := &v1beta1.Agent{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "worker-0"}, agent)).To(Succeed())
+		agent.Spec.ClusterDeploymentName = nil
+		Expect(c.Update(ctx, agent)).To(Succeed())
+		setRemovalCondition("worker-0", v1beta1.AgentRemovalCompletedCondition, corev1.ConditionTrue, v1beta1.AgentRemovalHostReclaimedReason)
+		_, updated = reconcileReprovision()
+		Expect(agentStates(updated)["worker-0"]).To(Equal(v1beta1.ReprovisionedAgentReinstalling))
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "worker-0"}, agent)).To(Succeed())
+		Expect(agent.Spec.ClusterDeploymentName).To(Equal(&cluster))
+
+		By("waiting for the node once the agent is installed")
+		setAgentState("worker-0", models.HostStatusAddedToExistingCluster)
+		_, updated = reconcileReprovision()
+		Expect(agentStates(updated)).To(Equal(map[string]v1beta1.ReprovisionedAgentState{
+			"worker-0": v1beta1.ReprovisionedAgentVerifying,
+			"worker-1": v1beta1.ReprovisionedAgentPending,
+		}))
+
+		By("removing the next node once the first one is ready")
+		nodes["worker-0.example.com"].Status.Conditions[0].Status = corev1.ConditionTrue
+		_, updated = reconcileReprovision()
+		Expect(agentStates(updated)).To(Equal(map[string]v1beta1.ReprovisionedAgentState{
+			"worker-0": v1beta1.ReprovisionedAgentReprovisioned,
+			"worker-1": v1beta1.ReprovisionedAgentRemoving,
+		}))
+
+		By("completing once every node was reprovisioned")
+		for i := range updated.Status.Agents {
+			updated.Status.Agents[i].State = v1beta1.ReprovisionedAgentReprovisioned
+		}
+		Expect(c.Status().Update(ctx, updated)).To(Succeed())
+		result, updated = reconcileReprovision()
+		Expect(result).To(Equal(ctrl.Result{}))
+		expectCondition(updated, v1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionTrue, v1beta1.ClusterReprovisionCompletedReason)
+	})
+
+	It("reprovisions up to max unavailable nodes at the same time", func() {
+		createWorker("worker-0")
+		createWorker("worker-1")
+		createWorker("worker-2")
+		reprovision.Spec.MaxUnavailable = 2
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+
+		_, updated := reconcileReprovision()
+		Expect(agentStates(updated)).To(Equal(map[string]v1beta1.ReprovisionedAgentState{
+			"worker-0": v1beta1.ReprovisionedAgentRemoving,
+			"worker-1": v1beta1.ReprovisionedAgentRemoving,
+			"worker-2": v1beta1.ReprovisionedAgentPending,
+		}))
+	})
+
+	It("waits while a node that is not reprovisioned is not ready", func() {
+		createWorker("worker-0")
+		nodes["master-0"] = newNode("master-0", false)
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+
+		result, updated := reconcileReprovision()
+		Expect(result.RequeueAfter).To(Equal(clusterReprovisionRequeue))
+		Expect(agentStates(updated)["worker-0"]).To(Equal(v1beta1.ReprovisionedAgentPending))
+		expectCondition(updated, v1beta1.ClusterReprovisionHealthyCondition, corev1.ConditionFalse, v1beta1.ClusterReprovisionNodesNotReadyReason)
+	})
+
+	It("stops and rolls back the nodes that were not unbound when a node fails", func() {
+		createWorker("worker-0")
+		createWorker("worker-1")
+		createWorker("worker-2")
+		reprovision.Spec.MaxUnavailable = 2
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+		reconcileReprovision()
+
+		nodes["worker-0.example.com"].Spec.Unschedulable = true
+		nodes["worker-1.example.com"].Spec.Unschedulable = true
+		setRemovalCondition("worker-0", v1beta1.AgentRemovalCompletedCondition, corev1.ConditionFalse, v1beta1.AgentRemovalPendingUserActionReason)
+		mockDrainer.EXPECT().RunCordonOrUncordon(gomock.AssignableToTypeOf(&drain.Helper{}), nodes["worker-0.example.com"], false).Return(nil)
+		mockDrainer.EXPECT().RunCordonOrUncordon(gomock.AssignableToTypeOf(&drain.Helper{}), nodes["worker-1.example.com"], false).Return(nil)
+
+		result, updated := reconcileReprovision()
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(agentStates(updated)).To(Equal(map[string]v1beta1.ReprovisionedAgentState{
+			"worker-0": v1beta1.ReprovisionedAgentFailed,
+			"worker-1": v1beta1.ReprovisionedAgentRolledBack,
+			"worker-2": v1beta1.ReprovisionedAgentPending,
+		}))
+		expectCondition(updated, v1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, v1beta1.ClusterReprovisionStoppedReason)
+		err := c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: reprovision.Name + "-worker-1"}, &v1beta1.AgentRemoval{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		By("rolling back the removal of the failed node")
+		err = c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: reprovision.Name + "-worker-0"}, &v1beta1.AgentRemoval{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(updated.Status.Agents[0].Message).To(HaveSuffix("node worker-0.example.com was uncordoned"))
+	})
+
+	It("keeps reinstalling the nodes that were unbound when a node fails", func() {
+		createWorker("worker-0")
+		createWorker("worker-1")
+		reprovision.Spec.MaxUnavailable = 2
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+		reconcileReprovision()
+
+		setAgentState("worker-0", models.HostStatusError)
+		updated := &v1beta1.ClusterReprovision{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: reprovision.Namespace, Name: reprovision.Name}, updated)).To(Succeed())
+		updated.Status.Agents[0].State = v1beta1.ReprovisionedAgentReinstalling
+		Expect(c.Status().Update(ctx, updated)).To(Succeed())
+		setRemovalCondition("worker-1", v1beta1.AgentRemovalUnboundCondition, corev1.ConditionTrue, v1beta1.AgentRemovalAgentUnboundReason)
+
+		result, updated := reconcileReprovision()
+		Expect(result.RequeueAfter).To(Equal(clusterReprovisionRequeue))
+		Expect(agentStates(updated)).To(Equal(map[string]v1beta1.ReprovisionedAgentState{
+			"worker-0": v1beta1.ReprovisionedAgentFailed,
+			"worker-1": v1beta1.ReprovisionedAgentRemoving,
+		}))
+		expectCondition(updated, v1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, v1beta1.ClusterReprovisionStoppingReason)
+		getRemoval("worker-1")
+	})
+
+	It("fails a node that is not reprovisioned within the node timeout", func() {
+		createWorker("worker-0")
+		reprovision.Spec.NodeTimeout = &metav1.Duration{Duration: time.Minute}
+		Expect(c.Create(ctx, reprovision)).To(Succeed())
+		_, updated := reconcileReprovision()
+
+		startTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+		updated.Status.Agents[0].StartTime = &startTime
+		Expect(c.Status().Update(ctx, updated)).To(Succeed())
+
+		_, updated = reconcileReprovision()
+		Expect(agentStates(updated)["worker-0"]).To(Equal(v1beta1.ReprovisionedAgentFailed))
+		expectCondition(updated, v1beta1.ClusterReprovisionCompletedCondition, corev1.ConditionFalse, v1beta1.ClusterReprovisionStoppedReason)
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"time"
 
 	certtypes "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	bmh_v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
@@ -479,6 +480,21 @@ func setAnnotation(meta *metav1.ObjectMeta, key string, value string) {
 	meta.Annotations[key] = value
 }
 
+// spokeKubeconfigSecretKey returns the key of the secret used to access the installed cluster: the
+// service account kubeconfig minted on the cluster when it exists and its token didn't expire, the
+// admin kubeconfig otherwise
+func spokeKubeconfigSecretKey(ctx context.Context, c client.Client, r client.Reader, namespace string, cd *hivev1.ClusterDeployment) types.NamespacedName {
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      getClusterDeploymentServiceAccountKubeConfigSecretName(cd),
+	}
+	if secret, err := getSecret(ctx, c, r, key); err == nil && !serviceAccountKubeconfigExpired(secret, time.Now()) {
+		return key
+	}
+	key.Name = getClusterDeploymentAdminKubeConfigSecretName(cd)
+	return key
+}
+
 func spokeKubeconfigSecret(ctx context.Context, log logrus.FieldLogger, c client.Client, r client.Reader, clusterRef *aiv1beta1.ClusterReference) (*corev1.Secret, error) {
 	clusterDeployment := &hivev1.ClusterDeployment{}
 	cdKey := types.NamespacedName{
@@ -490,12 +506,7 @@ func spokeKubeconfigSecret(ctx context.Context, log logrus.FieldLogger, c client
 		// set this so it can be used by the following call
 		clusterDeployment.Name = cdKey.Name
 	}
-	adminKubeConfigSecretName := getClusterDeploymentAdminKubeConfigSecretName(clusterDeployment)
-
-	namespacedName := types.NamespacedName{
-		Namespace: clusterRef.Namespace,
-		Name:      adminKubeConfigSecretName,
-	}
+	namespacedName := spokeKubeconfigSecretKey(ctx, c, r, clusterRef.Namespace, clusterDeployment)
 
 	secret, err := getSecret(ctx, c, r, namespacedName)
 	if err != nil {
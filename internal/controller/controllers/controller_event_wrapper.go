@@ -8,6 +8,7 @@ import (
 	"github.com/go-openapi/swag"
 	"github.com/openshift/assisted-service/internal/common"
 	eventsapi "github.com/openshift/assisted-service/internal/events/api"
+	"github.com/openshift/assisted-service/models"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -56,6 +57,10 @@ func (c *controllerEventsWrapper) V2GetEvents(ctx context.Context, params *commo
 	)
 }
 
+func (c *controllerEventsWrapper) V2AggregateEvents(ctx context.Context, params *common.V2AggregateEventsParams) ([]*models.EventAggregation, error) {
+	return c.events.V2AggregateEvents(ctx, params)
+}
+
 func (c *controllerEventsWrapper) SendClusterEvent(ctx context.Context, event eventsapi.ClusterEvent) {
 	c.events.SendClusterEvent(ctx, event)
 
@@ -1,7 +1,13 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/kubectl/pkg/drain"
 )
 
@@ -22,3 +28,48 @@ func (d *KubectlDrainer) RunCordonOrUncordon(helper *drain.Helper, node *corev1.
 func (d *KubectlDrainer) RunNodeDrain(helper *drain.Helper, nodeName string) error {
 	return drain.RunNodeDrain(helper, nodeName)
 }
+
+// newDrainHelper returns the helper that cordons and drains the node, and the buffer that collects
+// its output. Each drain waits for a short time only, the callers retry it until the node is drained.
+func newDrainHelper(ctx context.Context, log logrus.FieldLogger, clientset kubernetes.Interface, node *corev1.Node, nodeName string) (*drain.Helper, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	drainHelper := &drain.Helper{
+		Client:              clientset,
+		Ctx:                 ctx,
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		Timeout:             20 * time.Second,
+		OnPodDeletionOrEvictionFinished: func(pod *corev1.Pod, usingEviction bool, err error) {
+			verbStr := "Deleted"
+			if usingEviction {
+				verbStr = "Evicted"
+			}
+			if err != nil {
+				log.Warnf("%s Pod %s/%s from Node %s, %s", verbStr, pod.Namespace, pod.Name, nodeName, err.Error())
+				return
+			}
+			log.Infof("%s Pod %s/%s from Node %s", verbStr, pod.Namespace, pod.Name, nodeName)
+		},
+		Out:    out,
+		ErrOut: out,
+	}
+	if nodeUnreachable(node) {
+		// When the node is unreachable and some pods are not evicted for as long as this timeout, we ignore them.
+		drainHelper.SkipWaitForDeleteTimeoutSeconds = 60 * 5 // 5 minutes
+	}
+	return drainHelper, out
+}
+
+func nodeUnreachable(node *corev1.Node) bool {
+	if node == nil {
+		return false
+	}
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionUnknown
+		}
+	}
+	return false
+}
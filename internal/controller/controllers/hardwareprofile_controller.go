@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	bmh_v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// HardwareProfileReconciler reports the state of a HardwareProfile on the BareMetalHosts of the
+// InfraEnvs that reference it. The profile is applied by the BMAC controller.
+type HardwareProfileReconciler struct {
+	client.Client
+	Log    logrus.FieldLogger
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=hardwareprofiles,verbs=get;list;watch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=hardwareprofiles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=infraenvs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch
+
+func (r *HardwareProfileReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx := addRequestIdIfNeeded(origCtx)
+	log := logutil.FromContext(ctx, r.Log).WithFields(
+		logrus.Fields{
+			"hardware_profile":           req.Name,
+			"hardware_profile_namespace": req.Namespace,
+		})
+
+	defer func() {
+		log.Debug("HardwareProfile Reconcile ended")
+	}()
+
+	log.Debug("HardwareProfile Reconcile started")
+
+	profile := &aiv1beta1.HardwareProfile{}
+	if err := r.Get(ctx, req.NamespacedName, profile); err != nil {
+		log.WithError(err).Errorf("Failed to get HardwareProfile %s", req.NamespacedName)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	hosts, err := r.hosts(ctx, log, profile)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	profile.Status.Hosts = hosts
+	setHardwareProfileConditions(profile)
+
+	if err := r.Status().Update(ctx, profile); err != nil {
+		log.WithError(err).Error("failed to update hardware profile status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// hosts returns the state of the profile on the BareMetalHosts of the InfraEnvs that reference it,
+// sorted by name
+func (r *HardwareProfileReconciler) hosts(ctx context.Context, log logrus.FieldLogger, profile *aiv1beta1.HardwareProfile) ([]aiv1beta1.HardwareProfileHost, error) {
+	infraEnvs := &aiv1beta1.InfraEnvList{}
+	if err := r.List(ctx, infraEnvs, client.InNamespace(profile.Namespace)); err != nil {
+		return nil, err
+	}
+	hosts := []aiv1beta1.HardwareProfileHost{}
+	for _, infraEnv := range infraEnvs.Items {
+		if infraEnv.Spec.HardwareProfileRef == nil || infraEnv.Spec.HardwareProfileRef.Name != profile.Name {
+			continue
+		}
+		bmhs := &bmh_v1alpha1.BareMetalHostList{}
+		if err := r.List(ctx, bmhs, client.InNamespace(infraEnv.Namespace), client.MatchingLabels{BMH_INFRA_ENV_LABEL: infraEnv.Name}); err != nil {
+			return nil, err
+		}
+		for i := range bmhs.Items {
+			host := hardwareProfileHost(log, &bmhs.Items[i], profile.Name)
+			host.InfraEnv = infraEnv.Name
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Name < hosts[j].Name })
+	return hosts, nil
+}
+
+// hardwareProfileHost returns the state of the profile recorded by the BMAC controller in the BMH
+func hardwareProfileHost(log logrus.FieldLogger, bmh *bmh_v1alpha1.BareMetalHost, profileName string) aiv1beta1.HardwareProfileHost {
+	host := aiv1beta1.HardwareProfileHost{Name: bmh.Name, State: aiv1beta1.HardwareProfileHostPending}
+	if value, ok := bmh.GetAnnotations()[BMH_HARDWARE_PROFILE_ANNOTATION]; ok {
+		var state hardwareProfileState
+		if err := json.Unmarshal([]byte(value), &state); err != nil {
+			log.WithError(err).Warnf("failed to parse the hardware profile state of BMH %s", bmh.Name)
+		} else if state.Profile == profileName {
+			host.State = state.State
+			host.Message = state.Message
+			return host
+		}
+	}
+	// The profile isn't applied to the hosts that were booted with the discovery image before
+	// the InfraEnv referenced it
+	if bmh.Spec.Image != nil || (bmh.Spec.CustomDeploy != nil && bmh.Spec.CustomDeploy.Method == ASSISTED_DEPLOY_METHOD) {
+		host.State = aiv1beta1.HardwareProfileHostSkipped
+		host.Message = "The BareMetalHost was booted with the discovery image before the profile was referenced"
+	}
+	return host
+}
+
+func setHardwareProfileConditions(profile *aiv1beta1.HardwareProfile) {
+	var failed, applying []string
+	for _, host := range profile.Status.Hosts {
+		switch host.State {
+		case aiv1beta1.HardwareProfileHostFailed:
+			failed = append(failed, host.Name)
+		case aiv1beta1.HardwareProfileHostPending, aiv1beta1.HardwareProfileHostApplying:
+			applying = append(applying, host.Name)
+		}
+	}
+
+	switch {
+	case len(profile.Status.Hosts) == 0:
+		setHardwareProfileCondition(profile, aiv1beta1.HardwareProfileAppliedCondition, corev1.ConditionFalse,
+			aiv1beta1.HardwareProfileNoHostsReason, "No BareMetalHost of an InfraEnv references the profile")
+	case len(applying) > 0 || len(failed) > 0:
+		setHardwareProfileCondition(profile, aiv1beta1.HardwareProfileAppliedCondition, corev1.ConditionFalse,
+			aiv1beta1.HardwareProfileApplyingReason, fmt.Sprintf("The profile is being applied to %d of %d BareMetalHosts",
+				len(applying), len(profile.Status.Hosts)))
+	default:
+		setHardwareProfileCondition(profile, aiv1beta1.HardwareProfileAppliedCondition, corev1.ConditionTrue,
+			aiv1beta1.HardwareProfileAppliedReason, "The profile was applied to the BareMetalHosts")
+	}
+
+	if len(failed) > 0 {
+		setHardwareProfileCondition(profile, aiv1beta1.HardwareProfileFailedCondition, corev1.ConditionTrue,
+			aiv1beta1.HardwareProfileHostsFailedReason, fmt.Sprintf("The profile couldn't be applied to BareMetalHosts %s",
+				strings.Join(failed, ", ")))
+	} else {
+		setHardwareProfileCondition(profile, aiv1beta1.HardwareProfileFailedCondition, corev1.ConditionFalse,
+			aiv1beta1.HardwareProfileNoFailureReason, "The profile didn't fail on any BareMetalHost")
+	}
+}
+
+func setHardwareProfileCondition(profile *aiv1beta1.HardwareProfile, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusConditionNoHeartbeat(&profile.Status.Conditions, conditionsv1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func (r *HardwareProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// The InfraEnvs and the BMHs of a namespace are mapped to all its profiles, a change of the
+	// reference of an InfraEnv or of the label of a BMH affects the profile it referenced before
+	mapToHardwareProfiles := func(ctx context.Context, a client.Object) []reconcile.Request {
+		log := logutil.FromContext(ctx, r.Log).WithFields(
+			logrus.Fields{
+				"name":      a.GetName(),
+				"namespace": a.GetNamespace(),
+			})
+		profiles := &aiv1beta1.HardwareProfileList{}
+		if err := r.List(ctx, profiles, client.InNamespace(a.GetNamespace())); err != nil {
+			log.Debugf("failed to list hardware profiles")
+			return []reconcile.Request{}
+		}
+		reply := []reconcile.Request{}
+		for _, profile := range profiles.Items {
+			reply = append(reply, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: profile.Namespace,
+				Name:      profile.Name,
+			}})
+		}
+		return reply
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&aiv1beta1.HardwareProfile{}).
+		Watches(&aiv1beta1.InfraEnv{}, handler.EnqueueRequestsFromMapFunc(mapToHardwareProfiles)).
+		Watches(&bmh_v1alpha1.BareMetalHost{}, handler.EnqueueRequestsFromMapFunc(mapToHardwareProfiles)).
+		Complete(r)
+}
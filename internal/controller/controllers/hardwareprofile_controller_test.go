@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+
+	bmh_v1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/common"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("HardwareProfile reconcile", func() {
+	var (
+		c       client.Client
+		r       *HardwareProfileReconciler
+		ctx     = context.Background()
+		profile *v1beta1.HardwareProfile
+	)
+
+	createBMH := func(name, infraEnv, annotation string) *bmh_v1alpha1.BareMetalHost {
+		bmh := newBMH(name, &bmh_v1alpha1.BareMetalHostSpec{})
+		bmh.Labels = map[string]string{BMH_INFRA_ENV_LABEL: infraEnv}
+		if annotation != "" {
+			bmh.Annotations = map[string]string{BMH_HARDWARE_PROFILE_ANNOTATION: annotation}
+		}
+		Expect(c.Create(ctx, bmh)).To(Succeed())
+		return bmh
+	}
+
+	reconcileProfile := func() *v1beta1.HardwareProfile {
+		key := types.NamespacedName{Name: profile.Name, Namespace: profile.Namespace}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		updated := &v1beta1.HardwareProfile{}
+		Expect(c.Get(ctx, key, updated)).To(Succeed())
+		return updated
+	}
+
+	expectCondition := func(p *v1beta1.HardwareProfile, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason string) {
+		condition := conditionsv1.FindStatusCondition(p.Status.Conditions, conditionType)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(status))
+		Expect(condition.Reason).To(Equal(reason))
+	}
+
+	BeforeEach(func() {
+		c = fakeclient.NewClientBuilder().WithScheme(GetKubeClientSchemes()).WithStatusSubresource(&v1beta1.HardwareProfile{}).Build()
+		r = &HardwareProfileReconciler{
+			Client: c,
+			Log:    common.GetTestLog(),
+			Scheme: scheme.Scheme,
+		}
+		profile = &v1beta1.HardwareProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Namespace: testNamespace},
+			Spec:       v1beta1.HardwareProfileSpec{BootMode: "UEFI"},
+		}
+		Expect(c.Create(ctx, profile)).To(Succeed())
+		Expect(c.Create(ctx, newInfraEnvImage("with-profile", testNamespace, v1beta1.InfraEnvSpec{
+			HardwareProfileRef: &corev1.LocalObjectReference{Name: profile.Name},
+		}))).To(Succeed())
+		Expect(c.Create(ctx, newInfraEnvImage("without-profile", testNamespace, v1beta1.InfraEnvSpec{}))).To(Succeed())
+	})
+
+	It("reports that no host references the profile", func() {
+		createBMH("other", "without-profile", "")
+
+		updated := reconcileProfile()
+		Expect(updated.Status.Hosts).To(BeEmpty())
+		expectCondition(updated, v1beta1.HardwareProfileAppliedCondition, corev1.ConditionFalse, v1beta1.HardwareProfileNoHostsReason)
+		expectCondition(updated, v1beta1.HardwareProfileFailedCondition, corev1.ConditionFalse, v1beta1.HardwareProfileNoFailureReason)
+	})
+
+	It("reports the state of the profile on the hosts of the infraEnvs referencing it", func() {
+		createBMH("host-b", "with-profile", `{"profile":"test-profile","state":"Applying","message":"Waiting"}`)
+		createBMH("host-a", "with-profile", "")
+		booted := newBMH("host-c", &bmh_v1alpha1.BareMetalHostSpec{CustomDeploy: &bmh_v1alpha1.CustomDeploy{Method: ASSISTED_DEPLOY_METHOD}})
+		booted.Labels = map[string]string{BMH_INFRA_ENV_LABEL: "with-profile"}
+		Expect(c.Create(ctx, booted)).To(Succeed())
+		createBMH("other", "without-profile", `{"profile":"test-profile","state":"Applied"}`)
+
+		updated := reconcileProfile()
+		Expect(updated.Status.Hosts).To(Equal([]v1beta1.HardwareProfileHost{
+			{Name: "host-a", InfraEnv: "with-profile", State: v1beta1.HardwareProfileHostPending},
+			{Name: "host-b", InfraEnv: "with-profile", State: v1beta1.HardwareProfileHostApplying, Message: "Waiting"},
+			{Name: "host-c", InfraEnv: "with-profile", State: v1beta1.HardwareProfileHostSkipped,
+				Message: "The BareMetalHost was booted with the discovery image before the profile was referenced"},
+		}))
+		expectCondition(updated, v1beta1.HardwareProfileAppliedCondition, corev1.ConditionFalse, v1beta1.HardwareProfileApplyingReason)
+		expectCondition(updated, v1beta1.HardwareProfileFailedCondition, corev1.ConditionFalse, v1beta1.HardwareProfileNoFailureReason)
+	})
+
+	It("ignores the state of another profile", func() {
+		createBMH("host", "with-profile", `{"profile":"other-profile","state":"Applied"}`)
+
+		updated := reconcileProfile()
+		Expect(updated.Status.Hosts).To(HaveLen(1))
+		Expect(updated.Status.Hosts[0].State).To(Equal(v1beta1.HardwareProfileHostPending))
+	})
+
+	It("reports that the profile was applied", func() {
+		createBMH("host-a", "with-profile", `{"profile":"test-profile","state":"Applied"}`)
+		createBMH("host-b", "with-profile", `{"profile":"test-profile","state":"Applied"}`)
+
+		updated := reconcileProfile()
+		expectCondition(updated, v1beta1.HardwareProfileAppliedCondition, corev1.ConditionTrue, v1beta1.HardwareProfileAppliedReason)
+		expectCondition(updated, v1beta1.HardwareProfileFailedCondition, corev1.ConditionFalse, v1beta1.HardwareProfileNoFailureReason)
+	})
+
+	It("reports the hosts where the profile failed", func() {
+		createBMH("host-a", "with-profile", `{"profile":"test-profile","state":"Applied"}`)
+		createBMH("host-b", "with-profile", `{"profile":"test-profile","state":"Failed","message":"HostFirmwareSettings invalid"}`)
+
+		updated := reconcileProfile()
+		expectCondition(updated, v1beta1.HardwareProfileAppliedCondition, corev1.ConditionFalse, v1beta1.HardwareProfileApplyingReason)
+		expectCondition(updated, v1beta1.HardwareProfileFailedCondition, corev1.ConditionTrue, v1beta1.HardwareProfileHostsFailedReason)
+		Expect(conditionsv1.FindStatusCondition(updated.Status.Conditions, v1beta1.HardwareProfileFailedCondition).Message).To(ContainSubstring("host-b"))
+		Expect(updated.Status.Hosts[1].Message).To(Equal("HostFirmwareSettings invalid"))
+	})
+})
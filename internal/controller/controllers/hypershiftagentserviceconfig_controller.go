@@ -246,7 +246,17 @@ func (hr *HypershiftAgentServiceConfigReconciler) Reconcile(origCtx context.Cont
 		return result, err
 	}
 
-	return updateConditions(ctx, log, asc)
+	// Report the health of the deployed components and drive their in-place upgrades
+	hr.reportSpokeCRDsVersionSkew(ctx, log, spokeClient, asc)
+	if result, err = reportHypershiftStatus(ctx, log, asc); err != nil {
+		return result, err
+	}
+
+	conditionsResult, err := updateConditions(ctx, log, asc)
+	if err != nil || conditionsResult.Requeue {
+		return conditionsResult, err
+	}
+	return result, nil
 }
 
 func (hr *HypershiftAgentServiceConfigReconciler) reconcileHubComponents(ctx context.Context, log *logrus.Entry, asc ASC) (ctrl.Result, error) {
@@ -385,6 +395,7 @@ func (hr *HypershiftAgentServiceConfigReconciler) getKubeconfigSecret(ctx contex
 
 func (hr *HypershiftAgentServiceConfigReconciler) ensureSyncSpokeAgentInstallCRDs(ctx context.Context, log *logrus.Entry, spokeClient client.Client, asc ASC) error {
 	if err := hr.syncSpokeAgentInstallCRDs(ctx, log, spokeClient); err != nil {
+		hr.reportSpokeCRDsVersionSkew(ctx, log, spokeClient, asc)
 		reason := aiv1beta1.ReasonSpokeClusterCRDsSyncFailure
 		msg := fmt.Sprintf("Failed to sync agent-install CRDs on spoke cluster: %s", err.Error())
 		log.WithError(err).Error(msg)
@@ -791,7 +802,7 @@ func newKonnectivityAgentDeployment(ctx context.Context, log logrus.FieldLogger,
 		return nil, nil, err
 	}
 	ka.ObjectMeta = metav1.ObjectMeta{
-		Name:      "konnectivity-agent-assisted-service",
+		Name:      konnectivityAgentAssistedServiceName,
 		Namespace: asc.namespace,
 	}
 	ka.OwnerReferences = nil
@@ -857,6 +868,7 @@ func newHypershiftAssistedServiceDeployment(ctx context.Context, log logrus.Fiel
 		serviceContainer.Env = append(serviceContainer.Env, corev1.EnvVar{Name: "KUBECONFIG", Value: kubeconfigPath})
 		deployment.Spec.Template.Spec.Containers[0] = serviceContainer
 
+		applyHypershiftUpgrade(asc, deployment)
 		return nil
 	}
 
@@ -910,6 +922,8 @@ func newHypershiftWebHookDeployment(ctx context.Context, log logrus.FieldLogger,
 			"--kubeconfig=/etc/kube/kubeconfig")
 		deployment.Spec.Template.Spec.Containers[0].Command = commands
 
+		setHypershiftRolloutStrategy(asc, deployment)
+		applyHypershiftUpgrade(asc, deployment)
 		return nil
 	}
 	return deployment, newMutateFn, nil
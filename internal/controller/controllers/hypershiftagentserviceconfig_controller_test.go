@@ -3,6 +3,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,6 +23,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -563,6 +566,298 @@ var _ = Describe("HypershiftAgentServiceConfig reconcile", func() {
 		})
 	})
 
+	Context("status", func() {
+		var (
+			statusSpokeClient client.WithWatch
+			serviceImageKey   = serviceName + "/" + serviceName
+			webhookImageKey   = webhookServiceName + "/" + webhookServiceName
+		)
+
+		reconcileHASC := func() ctrl.Result {
+			res, err := hr.Reconcile(ctx, newHypershiftAgentServiceConfigRequest(hsc))
+			Expect(err).To(BeNil())
+			return res
+		}
+
+		setDeploymentsReady := func(ready bool) {
+			for _, name := range []string{serviceName, webhookServiceName, konnectivityAgentAssistedServiceName} {
+				deployment := &appsv1.Deployment{}
+				Expect(hr.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, deployment)).To(Succeed())
+				var replicas int32 = 1
+				if deployment.Spec.Replicas != nil {
+					replicas = *deployment.Spec.Replicas
+				}
+				deployment.Status = appsv1.DeploymentStatus{Replicas: replicas, UpdatedReplicas: replicas}
+				if ready {
+					deployment.Status.ReadyReplicas = replicas
+					deployment.Status.AvailableReplicas = replicas
+				}
+				Expect(hr.Client.Status().Update(ctx, deployment)).To(Succeed())
+			}
+		}
+
+		setPVCStatus := func(name string, phase corev1.PersistentVolumeClaimPhase, capacity string) {
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(hr.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, pvc)).To(Succeed())
+			pvc.Status = corev1.PersistentVolumeClaimStatus{
+				Phase:    phase,
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+			}
+			Expect(hr.Client.Status().Update(ctx, pvc)).To(Succeed())
+		}
+
+		getDeploymentImage := func(name string) string {
+			deployment := &appsv1.Deployment{}
+			Expect(hr.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, deployment)).To(Succeed())
+			return deployment.Spec.Template.Spec.Containers[0].Image
+		}
+
+		expectUpgradeCondition := func(status corev1.ConditionStatus, reason string) {
+			condition := conditionsv1.FindStatusCondition(getHASCInstance().Status.Conditions, aiv1beta1.ConditionUpgradeCompleted)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(status))
+			Expect(condition.Reason).To(Equal(reason))
+		}
+
+		// startUpgrade deploys the service with the current images, and starts an upgrade of
+		// the deployments that don't get ready
+		startUpgrade := func(strategy *aiv1beta1.HypershiftUpgradeStrategy) {
+			hsc.Spec.UpgradeStrategy = strategy
+			Expect(hr.Client.Update(ctx, hsc)).To(Succeed())
+			reconcileHASC()
+			setDeploymentsReady(true)
+			reconcileHASC()
+			Expect(getHASCInstance().Status.DeployedImages).To(HaveKeyWithValue(serviceImageKey, "quay.io/test/service:1"))
+
+			setDeploymentsReady(false)
+			os.Setenv("SERVICE_IMAGE", "quay.io/test/service:2")
+			res := reconcileHASC()
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			upgrade := getHASCInstance().Status.Upgrade
+			Expect(upgrade).ToNot(BeNil())
+			Expect(upgrade.State).To(Equal(aiv1beta1.HypershiftUpgradeProgressing))
+			Expect(upgrade.FromImages).To(HaveKeyWithValue(webhookImageKey, "quay.io/test/service:1"))
+			Expect(upgrade.ToImages).To(HaveKeyWithValue(webhookImageKey, "quay.io/test/service:2"))
+			expectUpgradeCondition(corev1.ConditionFalse, aiv1beta1.ReasonUpgradeProgressing)
+		}
+
+		expireUpgrade := func() {
+			instance := getHASCInstance()
+			startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+			instance.Status.Upgrade.StartTime = &startTime
+			Expect(hr.Client.Status().Update(ctx, instance)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			os.Setenv("SERVICE_IMAGE", "quay.io/test/service:1")
+			statusSpokeClient = fakeclient.NewClientBuilder().WithScheme(GetKubeClientSchemes()).Build()
+			mockSpokeClientCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(fakeSpokeK8sClient{Client: statusSpokeClient}, nil).AnyTimes()
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("SERVICE_IMAGE")
+		})
+
+		It("reports the readiness of the components", func() {
+			reconcileHASC()
+			instance := getHASCInstance()
+			Expect(instance.Status.Components).To(HaveLen(4))
+			Expect(instance.Status.Components[0].Name).To(Equal(serviceName))
+			Expect(instance.Status.Components[0].Ready).To(BeFalse())
+			Expect(instance.Status.Components[0].Message).To(Equal("0 of 1 replicas are updated"))
+			Expect(instance.Status.DeployedImages).To(BeNil())
+
+			setDeploymentsReady(true)
+			reconcileHASC()
+			instance = getHASCInstance()
+			for _, component := range instance.Status.Components {
+				if component.Kind == "Deployment" {
+					Expect(component.Ready).To(BeTrue(), component.Name)
+				}
+			}
+			Expect(instance.Status.Components[3].Name).To(Equal(imageServiceName))
+			Expect(instance.Status.Components[3].Kind).To(Equal("StatefulSet"))
+			Expect(instance.Status.Components[3].Ready).To(BeTrue())
+			Expect(instance.Status.DeployedImages).To(Equal(map[string]string{
+				serviceImageKey: "quay.io/test/service:1",
+				webhookImageKey: "quay.io/test/service:1",
+			}))
+			Expect(instance.Status.Upgrade).To(BeNil())
+		})
+
+		It("reports the usage of the storage", func() {
+			reconcileHASC()
+			setPVCStatus(serviceName, corev1.ClaimBound, "20Gi")
+			setPVCStatus(databaseName, corev1.ClaimBound, "1Gi")
+			Expect(hr.Client.Create(ctx, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "image-service-data-assisted-image-service-0", Namespace: testNamespace},
+				Spec:       *hsc.Spec.ImageStorage,
+			})).To(Succeed())
+			setPVCStatus("image-service-data-assisted-image-service-0", corev1.ClaimPending, "0")
+
+			reconcileHASC()
+			instance := getHASCInstance()
+			Expect(instance.Status.Storage).To(HaveLen(3))
+			Expect(instance.Status.Storage[0].Name).To(Equal(serviceName))
+			Expect(instance.Status.Storage[0].Phase).To(Equal(corev1.ClaimBound))
+			Expect(instance.Status.Storage[0].Capacity.String()).To(Equal("20Gi"))
+			condition := conditionsv1.FindStatusCondition(instance.Status.Conditions, aiv1beta1.ConditionStorageReady)
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(aiv1beta1.ReasonStorageNotReady))
+			Expect(condition.Message).To(ContainSubstring("persistent volume claim postgres has a capacity of 1Gi, lower than the requested"))
+			Expect(condition.Message).To(ContainSubstring("persistent volume claim image-service-data-assisted-image-service-0 isn't bound"))
+		})
+
+		It("reports the agent-install CRDs in sync with the spoke cluster", func() {
+			reconcileHASC()
+			instance := getHASCInstance()
+			Expect(instance.Status.CRDVersionSkew).To(BeEmpty())
+			condition := conditionsv1.FindStatusCondition(instance.Status.Conditions, aiv1beta1.ConditionSpokeCRDsInSync)
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		})
+
+		It("finds the CRD version skew between the management and the spoke cluster", func() {
+			newCRD := func(name string, versions ...apiextensionsv1.CustomResourceDefinitionVersion) apiextensionsv1.CustomResourceDefinition {
+				return apiextensionsv1.CustomResourceDefinition{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Versions: versions},
+				}
+			}
+			v1alpha1 := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Served: true}
+			v1beta1 := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true, Storage: true}
+			v1beta1NotStored := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true}
+			v1alpha1Stored := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Served: true, Storage: true}
+
+			skew := crdVersionSkew(
+				[]apiextensionsv1.CustomResourceDefinition{
+					newCRD("agents", v1beta1),
+					newCRD("infraenvs", v1alpha1, v1beta1),
+					newCRD("nmstateconfigs", v1beta1),
+					newCRD("hypershiftagentserviceconfigs", v1alpha1, v1beta1),
+				},
+				[]apiextensionsv1.CustomResourceDefinition{
+					newCRD("agents", v1beta1),
+					newCRD("infraenvs", v1beta1),
+					newCRD("hypershiftagentserviceconfigs", v1alpha1Stored, v1beta1NotStored),
+					newCRD("agentclassifications", v1beta1),
+				})
+			Expect(skew).To(Equal([]aiv1beta1.HypershiftCRDVersionSkew{
+				{
+					Name:          "agentclassifications",
+					SpokeVersions: []string{"v1beta1"},
+					Message:       "The CRD doesn't exist on the management cluster",
+				},
+				{
+					Name:          "hypershiftagentserviceconfigs",
+					HubVersions:   []string{"v1alpha1", "v1beta1"},
+					SpokeVersions: []string{"v1alpha1", "v1beta1"},
+					Message:       "The CRD stores version v1beta1 on the management cluster and v1alpha1 on the spoke cluster",
+				},
+				{
+					Name:          "infraenvs",
+					HubVersions:   []string{"v1alpha1", "v1beta1"},
+					SpokeVersions: []string{"v1beta1"},
+					Message:       "The CRD serves different versions",
+				},
+				{
+					Name:        "nmstateconfigs",
+					HubVersions: []string{"v1beta1"},
+					Message:     "The CRD doesn't exist on the spoke cluster",
+				},
+			}))
+		})
+
+		It("completes an upgrade once the deployments are ready", func() {
+			startUpgrade(nil)
+
+			setDeploymentsReady(true)
+			Expect(reconcileHASC()).To(Equal(ctrl.Result{}))
+			instance := getHASCInstance()
+			Expect(instance.Status.Upgrade.State).To(Equal(aiv1beta1.HypershiftUpgradeCompleted))
+			Expect(instance.Status.Upgrade.CompletionTime).ToNot(BeNil())
+			Expect(instance.Status.DeployedImages).To(HaveKeyWithValue(serviceImageKey, "quay.io/test/service:2"))
+			expectUpgradeCondition(corev1.ConditionTrue, aiv1beta1.ReasonUpgradeSucceeded)
+		})
+
+		It("fails an upgrade that isn't ready in time", func() {
+			startUpgrade(nil)
+
+			expireUpgrade()
+			Expect(reconcileHASC()).To(Equal(ctrl.Result{}))
+			Expect(getHASCInstance().Status.Upgrade.State).To(Equal(aiv1beta1.HypershiftUpgradeFailed))
+			expectUpgradeCondition(corev1.ConditionFalse, aiv1beta1.ReasonUpgradeFailed)
+			Expect(getDeploymentImage(serviceName)).To(Equal("quay.io/test/service:2"))
+
+			// The upgrade still completes if the deployments get ready later
+			setDeploymentsReady(true)
+			reconcileHASC()
+			Expect(getHASCInstance().Status.Upgrade.State).To(Equal(aiv1beta1.HypershiftUpgradeCompleted))
+		})
+
+		It("rolls back an upgrade that isn't ready in time", func() {
+			startUpgrade(&aiv1beta1.HypershiftUpgradeStrategy{
+				ReadinessTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+				AutoRollback:     true,
+			})
+
+			expireUpgrade()
+			Expect(reconcileHASC().Requeue).To(BeTrue())
+			Expect(getHASCInstance().Status.Upgrade.State).To(Equal(aiv1beta1.HypershiftUpgradeRolledBack))
+			expectUpgradeCondition(corev1.ConditionFalse, aiv1beta1.ReasonUpgradeRolledBack)
+
+			reconcileHASC()
+			Expect(getDeploymentImage(serviceName)).To(Equal("quay.io/test/service:1"))
+			Expect(getDeploymentImage(webhookServiceName)).To(Equal("quay.io/test/service:1"))
+			Expect(getHASCInstance().Status.DeployedImages).To(HaveKeyWithValue(serviceImageKey, "quay.io/test/service:1"))
+
+			// A new upgrade starts when the images change again
+			os.Setenv("SERVICE_IMAGE", "quay.io/test/service:3")
+			Expect(reconcileHASC().Requeue).To(BeTrue())
+			upgrade := getHASCInstance().Status.Upgrade
+			Expect(upgrade.State).To(Equal(aiv1beta1.HypershiftUpgradeProgressing))
+			Expect(upgrade.FromImages).To(HaveKeyWithValue(serviceImageKey, "quay.io/test/service:1"))
+			reconcileHASC()
+			Expect(getDeploymentImage(serviceName)).To(Equal("quay.io/test/service:3"))
+		})
+
+		It("keeps the current env of the deployments when rolling them back", func() {
+			startUpgrade(&aiv1beta1.HypershiftUpgradeStrategy{AutoRollback: true})
+			os.Setenv("AGENT_IMAGE", "quay.io/test/agent:2")
+			defer os.Unsetenv("AGENT_IMAGE")
+			reconcileHASC()
+
+			expireUpgrade()
+			Expect(reconcileHASC().Requeue).To(BeTrue())
+			reconcileHASC()
+			Expect(getDeploymentImage(serviceName)).To(Equal("quay.io/test/service:1"))
+
+			// Only the images are rolled back, the previous images start with the env of the
+			// running version
+			cm := &corev1.ConfigMap{}
+			Expect(hr.Client.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("AGENT_DOCKER_IMAGE", "quay.io/test/agent:2"))
+			configHash, err := checksumMap(cm.Data)
+			Expect(err).ToNot(HaveOccurred())
+			deployment := &appsv1.Deployment{}
+			Expect(hr.Client.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, deployment)).To(Succeed())
+			Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(assistedConfigHashAnnotation, configHash))
+			container := deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.EnvFrom[0].ConfigMapRef.Name).To(Equal(serviceName))
+		})
+
+		It("rolls out the webhook deployment with the strategy of the upgrade", func() {
+			hsc.Spec.UpgradeStrategy = &aiv1beta1.HypershiftUpgradeStrategy{RolloutType: aiv1beta1.HypershiftRolloutRollingUpdate}
+			Expect(hr.Client.Update(ctx, hsc)).To(Succeed())
+			reconcileHASC()
+
+			deployment := &appsv1.Deployment{}
+			Expect(hr.Client.Get(ctx, types.NamespacedName{Name: webhookServiceName, Namespace: testNamespace}, deployment)).To(Succeed())
+			Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType))
+			Expect(hr.Client.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, deployment)).To(Succeed())
+			Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
+		})
+	})
+
 	Context("parsing rbac", func() {
 		validateObjectMeta := func(obj client.Object, name, namespace string) {
 			Expect(obj.GetName()).To(Equal(name))
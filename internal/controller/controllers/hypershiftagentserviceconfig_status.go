@@ -0,0 +1,509 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// upgradeImagesParam is the ASC property holding the images that the mutate functions of the
+	// hub deployments set, by deployment and container name
+	upgradeImagesParam string = "UpgradeImages"
+
+	konnectivityAgentAssistedServiceName string = "konnectivity-agent-assisted-service"
+
+	defaultHypershiftUpgradeReadinessTimeout = 10 * time.Minute
+)
+
+// hypershiftWorkloads is what is observed of the workloads of the service on the management cluster
+type hypershiftWorkloads struct {
+	components []aiv1beta1.HypershiftComponentStatus
+	// images of the containers of the deployments, by deployment and container name
+	images   map[string]string
+	pvcNames []string
+}
+
+func upgradeImageKey(deploymentName, containerName string) string {
+	return deploymentName + "/" + containerName
+}
+
+// applyHypershiftUpgrade records the images that the mutate function of the deployment set, and
+// replaces them with the images of before the upgrade when the last upgrade was rolled back.
+// The database is never rolled back, since it may have already upgraded its data.
+// Only the images are rolled back: the rest of the deployment, like its env and args, is still
+// the one of the running version, so the previous images have to accept it.
+func applyHypershiftUpgrade(asc ASC, deployment *appsv1.Deployment) {
+	images, ok := asc.properties[upgradeImagesParam].(map[string]string)
+	if !ok {
+		images = map[string]string{}
+		asc.properties[upgradeImagesParam] = images
+	}
+	upgrade := asc.Object.(*aiv1beta1.HypershiftAgentServiceConfig).Status.Upgrade
+	for i := range deployment.Spec.Template.Spec.Containers {
+		container := &deployment.Spec.Template.Spec.Containers[i]
+		if container.Name == databaseName {
+			continue
+		}
+		key := upgradeImageKey(deployment.Name, container.Name)
+		images[key] = container.Image
+		if upgrade != nil && upgrade.State == aiv1beta1.HypershiftUpgradeRolledBack {
+			if image, ok := upgrade.FromImages[key]; ok {
+				container.Image = image
+			}
+		}
+	}
+}
+
+// setHypershiftRolloutStrategy sets the rollout strategy of the upgrade strategy on the deployment
+func setHypershiftRolloutStrategy(asc ASC, deployment *appsv1.Deployment) {
+	strategy := asc.Object.(*aiv1beta1.HypershiftAgentServiceConfig).Spec.UpgradeStrategy
+	if strategy != nil && strategy.RolloutType == aiv1beta1.HypershiftRolloutRollingUpdate {
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	}
+}
+
+// reportHypershiftStatus reports the readiness of the components and the usage of the storage of
+// the service in the status, and drives the in-place upgrade of its deployments. The result
+// requeues the request when the upgrade has to be checked again.
+func reportHypershiftStatus(ctx context.Context, log *logrus.Entry, asc ASC) (ctrl.Result, error) {
+	instance := asc.Object.(*aiv1beta1.HypershiftAgentServiceConfig)
+
+	workloads, err := getHypershiftWorkloads(ctx, asc)
+	if err != nil {
+		log.WithError(err).Error("Failed to get the workloads of the service")
+		return ctrl.Result{}, err
+	}
+	instance.Status.Components = workloads.components
+
+	storage, problems, err := getHypershiftStorage(ctx, asc, workloads.pvcNames)
+	if err != nil {
+		log.WithError(err).Error("Failed to get the persistent volume claims of the service")
+		return ctrl.Result{}, err
+	}
+	instance.Status.Storage = storage
+	condition := conditionsv1.Condition{
+		Type:    aiv1beta1.ConditionStorageReady,
+		Status:  corev1.ConditionTrue,
+		Reason:  aiv1beta1.ReasonStorageReady,
+		Message: "All the persistent volume claims are bound with the requested capacity",
+	}
+	if len(problems) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = aiv1beta1.ReasonStorageNotReady
+		condition.Message = strings.Join(problems, "; ")
+	}
+	conditionsv1.SetStatusConditionNoHeartbeat(asc.conditions, condition)
+
+	return reconcileHypershiftUpgrade(log, asc, workloads), nil
+}
+
+func getHypershiftWorkloads(ctx context.Context, asc ASC) (*hypershiftWorkloads, error) {
+	workloads := &hypershiftWorkloads{
+		images: map[string]string{},
+		pvcNames: []string{
+			getPVCName(asc.Object.GetAnnotations(), serviceName),
+			getPVCName(asc.Object.GetAnnotations(), databaseName),
+		},
+	}
+
+	for _, name := range []string{serviceName, webhookServiceName, konnectivityAgentAssistedServiceName} {
+		deployment := &appsv1.Deployment{}
+		if err := asc.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: asc.namespace}, deployment); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, err
+			}
+			workloads.components = append(workloads.components, missingComponent(name, "Deployment"))
+			continue
+		}
+		workloads.components = append(workloads.components, deploymentComponentStatus(deployment))
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			workloads.images[upgradeImageKey(deployment.Name, container.Name)] = container.Image
+		}
+	}
+
+	if !isImageServiceEnabled(asc.Object.GetAnnotations()) {
+		return workloads, nil
+	}
+	statefulSet := &appsv1.StatefulSet{}
+	if err := asc.Client.Get(ctx, types.NamespacedName{Name: imageServiceName, Namespace: asc.namespace}, statefulSet); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		workloads.components = append(workloads.components, missingComponent(imageServiceName, "StatefulSet"))
+		return workloads, nil
+	}
+	component := statefulSetComponentStatus(statefulSet)
+	workloads.components = append(workloads.components, component)
+	// The claims of a StatefulSet are named after the claim template, the StatefulSet and the ordinal of the pod
+	for _, claim := range statefulSet.Spec.VolumeClaimTemplates {
+		for i := int32(0); i < component.Replicas; i++ {
+			workloads.pvcNames = append(workloads.pvcNames, fmt.Sprintf("%s-%s-%d", claim.Name, statefulSet.Name, i))
+		}
+	}
+	return workloads, nil
+}
+
+func missingComponent(name, kind string) aiv1beta1.HypershiftComponentStatus {
+	return aiv1beta1.HypershiftComponentStatus{
+		Name:    name,
+		Kind:    kind,
+		Message: fmt.Sprintf("%s %s doesn't exist", kind, name),
+	}
+}
+
+func deploymentComponentStatus(deployment *appsv1.Deployment) aiv1beta1.HypershiftComponentStatus {
+	var replicas int32 = 1
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	component := aiv1beta1.HypershiftComponentStatus{
+		Name:            deployment.Name,
+		Kind:            "Deployment",
+		Replicas:        replicas,
+		ReadyReplicas:   deployment.Status.ReadyReplicas,
+		UpdatedReplicas: deployment.Status.UpdatedReplicas,
+	}
+	switch {
+	case deployment.Status.ObservedGeneration < deployment.Generation:
+		component.Message = "The latest definition of the deployment wasn't observed yet"
+	case deployment.Status.UpdatedReplicas < replicas:
+		component.Message = fmt.Sprintf("%d of %d replicas are updated", deployment.Status.UpdatedReplicas, replicas)
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		component.Message = fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	case deployment.Status.AvailableReplicas < replicas:
+		component.Message = fmt.Sprintf("%d of %d replicas are available", deployment.Status.AvailableReplicas, replicas)
+	default:
+		component.Ready = true
+	}
+	return component
+}
+
+func statefulSetComponentStatus(statefulSet *appsv1.StatefulSet) aiv1beta1.HypershiftComponentStatus {
+	var replicas int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	component := aiv1beta1.HypershiftComponentStatus{
+		Name:            statefulSet.Name,
+		Kind:            "StatefulSet",
+		Replicas:        replicas,
+		ReadyReplicas:   statefulSet.Status.ReadyReplicas,
+		UpdatedReplicas: statefulSet.Status.UpdatedReplicas,
+	}
+	switch {
+	case statefulSet.Status.ObservedGeneration < statefulSet.Generation:
+		component.Message = "The latest definition of the stateful set wasn't observed yet"
+	case statefulSet.Status.UpdatedReplicas < replicas:
+		component.Message = fmt.Sprintf("%d of %d replicas are updated", statefulSet.Status.UpdatedReplicas, replicas)
+	case statefulSet.Status.ReadyReplicas < replicas:
+		component.Message = fmt.Sprintf("%d of %d replicas are ready", statefulSet.Status.ReadyReplicas, replicas)
+	default:
+		component.Ready = true
+	}
+	return component
+}
+
+// getHypershiftStorage returns the usage of the persistent volume claims, and the ones that are
+// missing, not bound or smaller than requested
+func getHypershiftStorage(ctx context.Context, asc ASC, pvcNames []string) ([]aiv1beta1.HypershiftStorageStatus, []string, error) {
+	var storage []aiv1beta1.HypershiftStorageStatus
+	var problems []string
+	for _, name := range pvcNames {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := asc.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: asc.namespace}, pvc); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, nil, err
+			}
+			problems = append(problems, fmt.Sprintf("persistent volume claim %s doesn't exist", name))
+			continue
+		}
+
+		status := aiv1beta1.HypershiftStorageStatus{Name: name, Phase: pvc.Status.Phase}
+		if requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			status.Requested = &requested
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			status.Capacity = &capacity
+		}
+		storage = append(storage, status)
+
+		switch {
+		case pvc.Status.Phase != corev1.ClaimBound:
+			problems = append(problems, fmt.Sprintf("persistent volume claim %s isn't bound", name))
+		case status.Requested != nil && status.Capacity != nil && status.Capacity.Cmp(*status.Requested) < 0:
+			problems = append(problems, fmt.Sprintf("persistent volume claim %s has a capacity of %s, lower than the requested %s",
+				name, status.Capacity.String(), status.Requested.String()))
+		}
+	}
+	return storage, problems, nil
+}
+
+// reconcileHypershiftUpgrade starts an upgrade when the images of the deployments change, and
+// completes it once the deployments are ready with the new images. When they aren't ready within
+// the readiness timeout, the upgrade fails and, if enabled, the deployments are rolled back.
+func reconcileHypershiftUpgrade(log *logrus.Entry, asc ASC, workloads *hypershiftWorkloads) ctrl.Result {
+	instance := asc.Object.(*aiv1beta1.HypershiftAgentServiceConfig)
+	images, ok := asc.properties[upgradeImagesParam].(map[string]string)
+	if !ok || len(images) == 0 {
+		return ctrl.Result{}
+	}
+	ready := deploymentsReady(workloads, images)
+	status := &instance.Status
+	upgrade := status.Upgrade
+
+	switch {
+	case status.DeployedImages == nil:
+		// The first deployment of the service isn't an upgrade
+		if ready {
+			status.DeployedImages = images
+		}
+		return ctrl.Result{}
+	case upgrade != nil && upgrade.State == aiv1beta1.HypershiftUpgradeRolledBack && reflect.DeepEqual(upgrade.ToImages, images):
+		// The deployments keep running the images of before the upgrade until the images change again
+		return ctrl.Result{}
+	case upgrade != nil && (upgrade.State == aiv1beta1.HypershiftUpgradeProgressing || upgrade.State == aiv1beta1.HypershiftUpgradeFailed):
+		if !reflect.DeepEqual(upgrade.ToImages, images) {
+			log.Infof("Images of the deployments changed during their upgrade, restarting the upgrade")
+			now := metav1.Now()
+			upgrade.State = aiv1beta1.HypershiftUpgradeProgressing
+			upgrade.ToImages = images
+			upgrade.StartTime = &now
+			upgrade.CompletionTime = nil
+		}
+		return progressHypershiftUpgrade(log, asc, ready)
+	case reflect.DeepEqual(status.DeployedImages, images):
+		return ctrl.Result{}
+	}
+
+	log.Infof("Upgrading the deployments from %v to %v", status.DeployedImages, images)
+	now := metav1.Now()
+	status.Upgrade = &aiv1beta1.HypershiftUpgradeStatus{
+		State:      aiv1beta1.HypershiftUpgradeProgressing,
+		FromImages: status.DeployedImages,
+		ToImages:   images,
+		StartTime:  &now,
+	}
+	if upgrade != nil && upgrade.State == aiv1beta1.HypershiftUpgradeRolledBack {
+		// The deployments were just reconciled with the images they were rolled back to
+		setUpgradeCondition(asc, status.Upgrade)
+		return ctrl.Result{Requeue: true}
+	}
+	return progressHypershiftUpgrade(log, asc, ready)
+}
+
+func progressHypershiftUpgrade(log *logrus.Entry, asc ASC, ready bool) ctrl.Result {
+	instance := asc.Object.(*aiv1beta1.HypershiftAgentServiceConfig)
+	upgrade := instance.Status.Upgrade
+	defer setUpgradeCondition(asc, upgrade)
+
+	now := metav1.Now()
+	if ready {
+		log.Infof("Upgrade of the deployments to %v completed", upgrade.ToImages)
+		upgrade.State = aiv1beta1.HypershiftUpgradeCompleted
+		upgrade.CompletionTime = &now
+		upgrade.Message = "The deployments are ready with the upgraded images"
+		instance.Status.DeployedImages = upgrade.ToImages
+		return ctrl.Result{}
+	}
+	if upgrade.State == aiv1beta1.HypershiftUpgradeFailed {
+		return ctrl.Result{}
+	}
+
+	timeout := defaultHypershiftUpgradeReadinessTimeout
+	strategy := instance.Spec.UpgradeStrategy
+	if strategy != nil && strategy.ReadinessTimeout != nil {
+		timeout = strategy.ReadinessTimeout.Duration
+	}
+	deadline := upgrade.StartTime.Add(timeout)
+	if now.Time.Before(deadline) {
+		upgrade.Message = "Waiting for the deployments to be ready with the upgraded images"
+		return ctrl.Result{RequeueAfter: deadline.Sub(now.Time)}
+	}
+
+	upgrade.CompletionTime = &now
+	if strategy != nil && strategy.AutoRollback {
+		log.Warnf("Deployments weren't ready within %s, rolling them back to %v", timeout, upgrade.FromImages)
+		upgrade.State = aiv1beta1.HypershiftUpgradeRolledBack
+		upgrade.Message = fmt.Sprintf("The deployments weren't ready within %s, they were rolled back to the images of before the upgrade", timeout)
+		return ctrl.Result{Requeue: true}
+	}
+	log.Warnf("Deployments weren't ready within %s", timeout)
+	upgrade.State = aiv1beta1.HypershiftUpgradeFailed
+	upgrade.Message = fmt.Sprintf("The deployments weren't ready within %s", timeout)
+	return ctrl.Result{}
+}
+
+// deploymentsReady returns true when the deployments run the images and all their replicas are ready
+func deploymentsReady(workloads *hypershiftWorkloads, images map[string]string) bool {
+	for key, image := range images {
+		if workloads.images[key] != image {
+			return false
+		}
+		deploymentName := strings.SplitN(key, "/", 2)[0]
+		for _, component := range workloads.components {
+			if component.Kind == "Deployment" && component.Name == deploymentName && !component.Ready {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func setUpgradeCondition(asc ASC, upgrade *aiv1beta1.HypershiftUpgradeStatus) {
+	condition := conditionsv1.Condition{
+		Type:    aiv1beta1.ConditionUpgradeCompleted,
+		Status:  corev1.ConditionFalse,
+		Message: upgrade.Message,
+	}
+	switch upgrade.State {
+	case aiv1beta1.HypershiftUpgradeCompleted:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = aiv1beta1.ReasonUpgradeSucceeded
+	case aiv1beta1.HypershiftUpgradeFailed:
+		condition.Reason = aiv1beta1.ReasonUpgradeFailed
+	case aiv1beta1.HypershiftUpgradeRolledBack:
+		condition.Reason = aiv1beta1.ReasonUpgradeRolledBack
+	default:
+		condition.Reason = aiv1beta1.ReasonUpgradeProgressing
+	}
+	conditionsv1.SetStatusConditionNoHeartbeat(asc.conditions, condition)
+}
+
+// reportSpokeCRDsVersionSkew reports the agent-install CRDs that differ between the management
+// cluster and the hosted cluster in the status
+func (hr *HypershiftAgentServiceConfigReconciler) reportSpokeCRDsVersionSkew(ctx context.Context, log *logrus.Entry, spokeClient client.Client, asc ASC) {
+	hubCRDs, err := hr.getAgentInstallCRDs(ctx, hr.Client)
+	if err != nil || len(hubCRDs.Items) == 0 {
+		// Nothing to compare the hosted cluster with
+		return
+	}
+	spokeCRDs, err := hr.getAgentInstallCRDs(ctx, spokeClient)
+	if err != nil {
+		log.WithError(err).Warn("Failed to list the agent-install CRDs of the spoke cluster")
+		return
+	}
+
+	skew := crdVersionSkew(hubCRDs.Items, spokeCRDs.Items)
+	asc.Object.(*aiv1beta1.HypershiftAgentServiceConfig).Status.CRDVersionSkew = skew
+	condition := conditionsv1.Condition{
+		Type:    aiv1beta1.ConditionSpokeCRDsInSync,
+		Status:  corev1.ConditionTrue,
+		Reason:  aiv1beta1.ReasonSpokeCRDsInSync,
+		Message: "The agent-install CRDs of the spoke cluster match the management cluster",
+	}
+	if len(skew) > 0 {
+		names := make([]string, 0, len(skew))
+		for _, crd := range skew {
+			names = append(names, crd.Name)
+		}
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = aiv1beta1.ReasonSpokeCRDsVersionSkew
+		condition.Message = fmt.Sprintf("The agent-install CRDs differ between the management and the spoke cluster: %s", strings.Join(names, ", "))
+	}
+	conditionsv1.SetStatusConditionNoHeartbeat(asc.conditions, condition)
+}
+
+func crdVersionSkew(hubCRDs, spokeCRDs []apiextensionsv1.CustomResourceDefinition) []aiv1beta1.HypershiftCRDVersionSkew {
+	spokeByName := make(map[string]*apiextensionsv1.CustomResourceDefinition, len(spokeCRDs))
+	for i := range spokeCRDs {
+		spokeByName[spokeCRDs[i].Name] = &spokeCRDs[i]
+	}
+
+	var skew []aiv1beta1.HypershiftCRDVersionSkew
+	for i := range hubCRDs {
+		hub := &hubCRDs[i]
+		spoke, ok := spokeByName[hub.Name]
+		delete(spokeByName, hub.Name)
+		if !ok {
+			skew = append(skew, aiv1beta1.HypershiftCRDVersionSkew{
+				Name:        hub.Name,
+				HubVersions: servedVersions(hub),
+				Message:     "The CRD doesn't exist on the spoke cluster",
+			})
+			continue
+		}
+		if message := crdDifference(hub, spoke); message != "" {
+			skew = append(skew, aiv1beta1.HypershiftCRDVersionSkew{
+				Name:          hub.Name,
+				HubVersions:   servedVersions(hub),
+				SpokeVersions: servedVersions(spoke),
+				Message:       message,
+			})
+		}
+	}
+	for _, spoke := range spokeByName {
+		skew = append(skew, aiv1beta1.HypershiftCRDVersionSkew{
+			Name:          spoke.Name,
+			SpokeVersions: servedVersions(spoke),
+			Message:       "The CRD doesn't exist on the management cluster",
+		})
+	}
+	sort.Slice(skew, func(i, j int) bool { return skew[i].Name < skew[j].Name })
+	return skew
+}
+
+func crdDifference(hub, spoke *apiextensionsv1.CustomResourceDefinition) string {
+	if !reflect.DeepEqual(servedVersions(hub), servedVersions(spoke)) {
+		return "The CRD serves different versions"
+	}
+	if hubStorage, spokeStorage := storageVersion(hub), storageVersion(spoke); hubStorage != spokeStorage {
+		return fmt.Sprintf("The CRD stores version %s on the management cluster and %s on the spoke cluster", hubStorage, spokeStorage)
+	}
+	if !equality.Semantic.DeepEqual(hub.Spec.Versions, spoke.Spec.Versions) {
+		return "The schema of the CRD differs"
+	}
+	return ""
+}
+
+func servedVersions(crd *apiextensionsv1.CustomResourceDefinition) []string {
+	versions := []string{}
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			versions = append(versions, version.Name)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
@@ -30,10 +30,12 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/bmcinventory"
 	"github.com/openshift/assisted-service/internal/bminventory"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/controller/controllers/mirrorregistry"
 	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/openshift/assisted-service/internal/hostapproval"
 	"github.com/openshift/assisted-service/internal/imageservice"
 	"github.com/openshift/assisted-service/internal/infraenv"
 	"github.com/openshift/assisted-service/internal/versions"
@@ -246,6 +248,14 @@ func (r *InfraEnvReconciler) updateInfraEnv(ctx context.Context, log logrus.Fiel
 		}
 	}
 
+	if policy, changed := hostApprovalPolicyChanged(infraEnv, internalInfraEnv); changed {
+		updateParams.InfraEnvUpdateParams.HostApprovalPolicy = policy
+	}
+
+	if requirements, changed := firmwareRequirementsChanged(infraEnv, internalInfraEnv); changed {
+		updateParams.InfraEnvUpdateParams.FirmwareRequirements = requirements
+	}
+
 	mirrorRegistryConfiguration, err := r.processMirrorRegistryConfig(ctx, log, infraEnv)
 	if err != nil {
 		return nil, err
@@ -295,6 +305,85 @@ func internalKernelArgs(kargs []aiv1beta1.KernelArgument) models.KernelArguments
 	return ret
 }
 
+// internalHostApprovalPolicy returns the host approval policy of the AgentApproval of the InfraEnv,
+// or nil when it only has AutoApprove
+func internalHostApprovalPolicy(approval *aiv1beta1.AgentApproval) *models.HostApprovalPolicy {
+	if approval == nil || (len(approval.Rules) == 0 && approval.DefaultAction == "") {
+		return nil
+	}
+	policy := &models.HostApprovalPolicy{DefaultAction: approval.DefaultAction}
+	for _, rule := range approval.Rules {
+		policy.Rules = append(policy.Rules, &models.HostApprovalRule{
+			Name:          rule.Name,
+			Action:        swag.String(rule.Action),
+			MACRanges:     rule.MACRanges,
+			SerialNumbers: rule.SerialNumbers,
+			Manufacturers: rule.Manufacturers,
+			ProductNames:  rule.ProductNames,
+			MinCPUCores:   rule.MinCPUCores,
+			MinMemoryMib:  rule.MinMemoryMiB,
+			Virtual:       rule.Virtual,
+		})
+	}
+	return policy
+}
+
+// hostApprovalPolicyChanged returns the host approval policy of the InfraEnv when it differs from
+// the one of the internal infra-env, an empty policy to remove it
+func hostApprovalPolicyChanged(infraEnv *aiv1beta1.InfraEnv, internalInfraEnv *common.InfraEnv) (*models.HostApprovalPolicy, bool) {
+	policy := internalHostApprovalPolicy(infraEnv.Spec.AgentApproval)
+	formatted, err := hostapproval.Format(policy)
+	if err != nil {
+		// Let the update fail with the validation error
+		return policy, true
+	}
+	if formatted == swag.StringValue(internalInfraEnv.HostApprovalPolicy) {
+		return nil, false
+	}
+	if policy == nil {
+		policy = &models.HostApprovalPolicy{}
+	}
+	return policy, true
+}
+
+// internalFirmwareRequirements returns the firmware requirements of the InfraEnv, or nil when it
+// doesn't have any
+func internalFirmwareRequirements(requirements *aiv1beta1.FirmwareRequirements) *models.FirmwareRequirements {
+	if requirements == nil {
+		return nil
+	}
+	ret := &models.FirmwareRequirements{
+		SecureBoot:   requirements.SecureBoot,
+		BiosVersions: requirements.BIOSVersions,
+		BiosSettings: requirements.BIOSSettings,
+	}
+	for _, level := range requirements.RAIDLevels {
+		ret.RaidLevels = append(ret.RaidLevels, string(level))
+	}
+	if bmcinventory.IsEmpty(ret) {
+		return nil
+	}
+	return ret
+}
+
+// firmwareRequirementsChanged returns the firmware requirements of the InfraEnv when they differ
+// from the ones of the internal infra-env, empty requirements to remove them
+func firmwareRequirementsChanged(infraEnv *aiv1beta1.InfraEnv, internalInfraEnv *common.InfraEnv) (*models.FirmwareRequirements, bool) {
+	requirements := internalFirmwareRequirements(infraEnv.Spec.FirmwareRequirements)
+	formatted, err := bmcinventory.Format(requirements)
+	if err != nil {
+		// Let the update fail with the validation error
+		return requirements, true
+	}
+	if formatted == swag.StringValue(internalInfraEnv.FirmwareRequirements) {
+		return nil, false
+	}
+	if requirements == nil {
+		requirements = &models.FirmwareRequirements{}
+	}
+	return requirements, true
+}
+
 func BuildMacInterfaceMap(log logrus.FieldLogger, nmStateConfig aiv1beta1.NMStateConfig) models.MacInterfaceMap {
 	macInterfaceMap := make(models.MacInterfaceMap, 0, len(nmStateConfig.Spec.Interfaces))
 	for _, cfg := range nmStateConfig.Spec.Interfaces {
@@ -480,6 +569,9 @@ func CreateInfraEnvParams(infraEnv *aiv1beta1.InfraEnv, imageType models.ImageTy
 		createParams.InfraenvCreateParams.KernelArguments = internalKernelArgs(infraEnv.Spec.KernelArguments)
 	}
 
+	createParams.InfraenvCreateParams.HostApprovalPolicy = internalHostApprovalPolicy(infraEnv.Spec.AgentApproval)
+	createParams.InfraenvCreateParams.FirmwareRequirements = internalFirmwareRequirements(infraEnv.Spec.FirmwareRequirements)
+
 	return createParams
 }
 
@@ -17,9 +17,11 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/bmcinventory"
 	"github.com/openshift/assisted-service/internal/bminventory"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/openshift/assisted-service/internal/hostapproval"
 	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/auth"
@@ -1655,3 +1657,83 @@ var _ = Describe("infraEnv reconcile with image service disabled", func() {
 		Expect(infraEnvImage.Status.InfraEnvDebugInfo.EventsURL).NotTo(BeEmpty())
 	})
 })
+
+var _ = Describe("hostApprovalPolicyChanged", func() {
+	var infraEnv *aiv1beta1.InfraEnv
+
+	BeforeEach(func() {
+		infraEnv = &aiv1beta1.InfraEnv{}
+	})
+
+	It("doesn't change the infra-env without approval rules", func() {
+		infraEnv.Spec.AgentApproval = &aiv1beta1.AgentApproval{AutoApprove: true}
+		_, changed := hostApprovalPolicyChanged(infraEnv, &common.InfraEnv{})
+		Expect(changed).To(BeFalse())
+	})
+
+	It("sets the approval rules", func() {
+		infraEnv.Spec.AgentApproval = &aiv1beta1.AgentApproval{
+			Rules:         []aiv1beta1.AgentApprovalRule{{Name: "lab", Action: "approve", MACRanges: []string{"52:54:00"}, MinMemoryMiB: 16384}},
+			DefaultAction: "quarantine",
+		}
+		policy, changed := hostApprovalPolicyChanged(infraEnv, &common.InfraEnv{})
+		Expect(changed).To(BeTrue())
+		Expect(policy.DefaultAction).To(Equal("quarantine"))
+		Expect(policy.Rules).To(HaveLen(1))
+		Expect(swag.StringValue(policy.Rules[0].Action)).To(Equal("approve"))
+		Expect(policy.Rules[0].MinMemoryMib).To(Equal(int64(16384)))
+
+		formatted, err := hostapproval.Format(policy)
+		Expect(err).ToNot(HaveOccurred())
+		internalInfraEnv := &common.InfraEnv{InfraEnv: models.InfraEnv{HostApprovalPolicy: swag.String(formatted)}}
+		_, changed = hostApprovalPolicyChanged(infraEnv, internalInfraEnv)
+		Expect(changed).To(BeFalse())
+	})
+
+	It("removes the approval rules", func() {
+		internalInfraEnv := &common.InfraEnv{InfraEnv: models.InfraEnv{HostApprovalPolicy: swag.String(`{"default_action":"approve"}`)}}
+		policy, changed := hostApprovalPolicyChanged(infraEnv, internalInfraEnv)
+		Expect(changed).To(BeTrue())
+		Expect(hostapproval.IsEmpty(policy)).To(BeTrue())
+	})
+})
+
+var _ = Describe("firmwareRequirementsChanged", func() {
+	var infraEnv *aiv1beta1.InfraEnv
+
+	BeforeEach(func() {
+		infraEnv = &aiv1beta1.InfraEnv{}
+	})
+
+	It("doesn't change the infra-env without firmware requirements", func() {
+		infraEnv.Spec.FirmwareRequirements = &aiv1beta1.FirmwareRequirements{}
+		_, changed := firmwareRequirementsChanged(infraEnv, &common.InfraEnv{})
+		Expect(changed).To(BeFalse())
+	})
+
+	It("sets the firmware requirements", func() {
+		infraEnv.Spec.FirmwareRequirements = &aiv1beta1.FirmwareRequirements{
+			SecureBoot:   swag.Bool(true),
+			BIOSVersions: []string{"2.19.*"},
+			RAIDLevels:   []aiv1beta1.RAIDLevel{"1"},
+		}
+		requirements, changed := firmwareRequirementsChanged(infraEnv, &common.InfraEnv{})
+		Expect(changed).To(BeTrue())
+		Expect(swag.BoolValue(requirements.SecureBoot)).To(BeTrue())
+		Expect(requirements.BiosVersions).To(Equal([]string{"2.19.*"}))
+		Expect(requirements.RaidLevels).To(Equal([]string{"1"}))
+
+		formatted, err := bmcinventory.Format(requirements)
+		Expect(err).ToNot(HaveOccurred())
+		internalInfraEnv := &common.InfraEnv{InfraEnv: models.InfraEnv{FirmwareRequirements: swag.String(formatted)}}
+		_, changed = firmwareRequirementsChanged(infraEnv, internalInfraEnv)
+		Expect(changed).To(BeFalse())
+	})
+
+	It("removes the firmware requirements", func() {
+		internalInfraEnv := &common.InfraEnv{InfraEnv: models.InfraEnv{FirmwareRequirements: swag.String(`{"secure_boot":true}`)}}
+		requirements, changed := firmwareRequirementsChanged(infraEnv, internalInfraEnv)
+		Expect(changed).To(BeTrue())
+		Expect(bmcinventory.IsEmpty(requirements)).To(BeTrue())
+	})
+})
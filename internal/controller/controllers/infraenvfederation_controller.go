@@ -0,0 +1,502 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/bminventory"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	infraEnvFederationRequeue = 20 * time.Second
+	// infraEnvFederationMigrationTimeout is how long the agent has to reach the peer hub once its
+	// migration started
+	infraEnvFederationMigrationTimeout = 30 * time.Minute
+)
+
+// infraEnvFederationEligibleStates are the states of the unbound Agents that can be migrated, their
+// agent is running the discovery image and checks in for steps
+var infraEnvFederationEligibleStates = []string{
+	models.HostStatusKnownUnbound,
+	models.HostStatusInsufficientUnbound,
+}
+
+// InfraEnvFederationReconciler reconciles a InfraEnvFederation object
+type InfraEnvFederationReconciler struct {
+	client.Client
+	APIReader             client.Reader
+	Log                   logrus.FieldLogger
+	Installer             bminventory.InstallerInternals
+	SpokeK8sClientFactory spoke_k8s_client.SpokeK8sClientFactory
+	// AuthType is the authentication of the agents, the peer hub only issues agent tokens with the
+	// local authentication
+	AuthType auth.AuthType
+}
+
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=infraenvfederations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=infraenvfederations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=infraenvs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=nmstateconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=agent-install.openshift.io,resources=agents,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *InfraEnvFederationReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx := addRequestIdIfNeeded(origCtx)
+	log := logutil.FromContext(ctx, r.Log).WithFields(
+		logrus.Fields{
+			"infra_env_federation":           req.Name,
+			"infra_env_federation_namespace": req.Namespace,
+		})
+
+	defer func() {
+		log.Debug("InfraEnvFederation Reconcile ended")
+	}()
+
+	log.Debug("InfraEnvFederation Reconcile started")
+
+	federation := &aiv1beta1.InfraEnvFederation{}
+	if err := r.Get(ctx, req.NamespacedName, federation); err != nil {
+		log.WithError(err).Errorf("Failed to get InfraEnvFederation %s", req.NamespacedName)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log = log.WithField("infra_env", federation.Spec.InfraEnvName)
+
+	infraEnv := &aiv1beta1.InfraEnv{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: federation.Namespace, Name: federation.Spec.InfraEnvName}, infraEnv); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationExportedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationInfraEnvNotFoundReason, fmt.Sprintf("InfraEnv %s not found", federation.Spec.InfraEnvName))
+		return ctrl.Result{}, r.updateInfraEnvFederationStatus(ctx, log, federation)
+	}
+
+	result, err := r.federate(ctx, log, federation, infraEnv)
+	if err != nil {
+		log.WithError(err).Error("failed to federate infra env")
+	}
+	if updateErr := r.updateInfraEnvFederationStatus(ctx, log, federation); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	return result, err
+}
+
+// federate exports the InfraEnv to the peer hub, then migrates the selected Agents once the peer
+// hub has the InfraEnv
+func (r *InfraEnvFederationReconciler) federate(ctx context.Context, log logrus.FieldLogger, federation *aiv1beta1.InfraEnvFederation, infraEnv *aiv1beta1.InfraEnv) (ctrl.Result, error) {
+	peerClient, err := r.peerClient(ctx, federation)
+	if err != nil {
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationExportedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationPeerUnreachableReason, fmt.Sprintf("Failed to connect to the peer hub: %s", err.Error()))
+		return ctrl.Result{RequeueAfter: defaultRequeueAfterOnError}, nil
+	}
+
+	peerInfraEnv, err := r.exportInfraEnv(ctx, log, peerClient, federation, infraEnv)
+	if err != nil {
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationExportedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationExportFailedReason, fmt.Sprintf("Failed to export InfraEnv %s: %s", infraEnv.Name, err.Error()))
+		return ctrl.Result{}, err
+	}
+	federation.Status.PeerISODownloadURL = peerInfraEnv.Status.ISODownloadURL
+	setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationExportedCondition, corev1.ConditionTrue,
+		aiv1beta1.InfraEnvFederationExportedReason, fmt.Sprintf("InfraEnv %s was exported to namespace %s of the peer hub", infraEnv.Name, peerInfraEnv.Namespace))
+
+	if federation.Spec.AgentSelector == nil {
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionTrue,
+			aiv1beta1.InfraEnvFederationNoAgentsSelectedReason, "No agent selector is set, the InfraEnv is exported without its Agents")
+		return ctrl.Result{}, nil
+	}
+	if peerInfraEnv.Status.ISODownloadURL == "" {
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationMigrationInProgressReason, fmt.Sprintf("Waiting for the peer hub to create InfraEnv %s", infraEnv.Name))
+		return ctrl.Result{RequeueAfter: infraEnvFederationRequeue}, nil
+	}
+	return r.migrateAgents(ctx, log, peerClient, federation, infraEnv)
+}
+
+func infraEnvFederationPeerNamespace(federation *aiv1beta1.InfraEnvFederation) string {
+	if federation.Spec.PeerHub.Namespace != "" {
+		return federation.Spec.PeerHub.Namespace
+	}
+	return federation.Namespace
+}
+
+func (r *InfraEnvFederationReconciler) peerClient(ctx context.Context, federation *aiv1beta1.InfraEnvFederation) (spoke_k8s_client.SpokeK8sClient, error) {
+	secretRef := types.NamespacedName{Namespace: federation.Namespace, Name: federation.Spec.PeerHub.KubeconfigSecretRef.Name}
+	secret, err := getSecret(ctx, r.Client, r.APIReader, secretRef)
+	if err != nil {
+		return nil, err
+	}
+	return r.SpokeK8sClientFactory.CreateFromSecret(nil, secret)
+}
+
+// exportInfraEnv copies the InfraEnv to the peer hub along with its pull secret and its
+// NMStateConfigs, so the peer hub serves an equivalent discovery ISO
+func (r *InfraEnvFederationReconciler) exportInfraEnv(ctx context.Context, log logrus.FieldLogger, peerClient client.Client, federation *aiv1beta1.InfraEnvFederation, infraEnv *aiv1beta1.InfraEnv) (*aiv1beta1.InfraEnv, error) {
+	namespace := infraEnvFederationPeerNamespace(federation)
+
+	if infraEnv.Spec.PullSecretRef != nil {
+		pullSecret, err := getSecret(ctx, r.Client, r.APIReader, types.NamespacedName{Namespace: infraEnv.Namespace, Name: infraEnv.Spec.PullSecretRef.Name})
+		if err != nil {
+			return nil, err
+		}
+		peerSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: pullSecret.Name, Namespace: namespace}}
+		if err = infraEnvFederationCopy(ctx, log, peerClient, peerSecret, func() error {
+			peerSecret.Type = pullSecret.Type
+			peerSecret.Data = pullSecret.Data
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&infraEnv.Spec.NMStateConfigLabelSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid label selector for InfraEnv %s", infraEnv.Name)
+	}
+	if !selector.Empty() {
+		nmStateConfigs := &aiv1beta1.NMStateConfigList{}
+		if err = r.List(ctx, nmStateConfigs, &client.ListOptions{LabelSelector: selector}); err != nil {
+			return nil, err
+		}
+		for i := range nmStateConfigs.Items {
+			nmStateConfig := &nmStateConfigs.Items[i]
+			peerNMStateConfig := &aiv1beta1.NMStateConfig{ObjectMeta: metav1.ObjectMeta{Name: nmStateConfig.Name, Namespace: namespace}}
+			if err = infraEnvFederationCopy(ctx, log, peerClient, peerNMStateConfig, func() error {
+				peerNMStateConfig.Labels = nmStateConfig.Labels
+				peerNMStateConfig.Spec = *nmStateConfig.Spec.DeepCopy()
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The InfraEnv is exported on its own, the peer hub doesn't have the cluster it's bound to
+	peerInfraEnv := &aiv1beta1.InfraEnv{ObjectMeta: metav1.ObjectMeta{Name: infraEnv.Name, Namespace: namespace}}
+	if err = infraEnvFederationCopy(ctx, log, peerClient, peerInfraEnv, func() error {
+		peerInfraEnv.Labels = infraEnv.Labels
+		peerInfraEnv.Spec = *infraEnv.Spec.DeepCopy()
+		peerInfraEnv.Spec.ClusterRef = nil
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return peerInfraEnv, nil
+}
+
+func infraEnvFederationCopy(ctx context.Context, log logrus.FieldLogger, peerClient client.Client, obj client.Object, mutate controllerutil.MutateFn) error {
+	result, err := controllerutil.CreateOrUpdate(ctx, peerClient, obj, mutate)
+	if err != nil {
+		return errors.Wrapf(err, "failed to copy %T %s/%s to the peer hub", obj, obj.GetNamespace(), obj.GetName())
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Infof("%T %s/%s %s on the peer hub", obj, obj.GetNamespace(), obj.GetName(), result)
+	}
+	return nil
+}
+
+// migrateAgents moves the selected Agents forward in their migration, each Agent records its
+// progress in the status of the federation
+func (r *InfraEnvFederationReconciler) migrateAgents(ctx context.Context, log logrus.FieldLogger, peerClient client.Client, federation *aiv1beta1.InfraEnvFederation, infraEnv *aiv1beta1.InfraEnv) (ctrl.Result, error) {
+	if r.AuthType != auth.TypeLocal {
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationMigrationFailedReason,
+			fmt.Sprintf("Agents can only be migrated with the %s authentication, the service uses the %s authentication", auth.TypeLocal, r.AuthType))
+		return ctrl.Result{}, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(federation.Spec.AgentSelector)
+	if err != nil {
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationMigrationFailedReason, fmt.Sprintf("Invalid agent selector: %s", err.Error()))
+		return ctrl.Result{}, nil
+	}
+	agentList := &aiv1beta1.AgentList{}
+	if err = r.List(ctx, agentList, client.InNamespace(federation.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return ctrl.Result{}, err
+	}
+	agents := make(map[string]*aiv1beta1.Agent)
+	for i := range agentList.Items {
+		agent := &agentList.Items[i]
+		if agent.Labels[aiv1beta1.InfraEnvNameLabel] == infraEnv.Name {
+			agents[agent.Name] = agent
+		}
+	}
+
+	for name, agent := range agents {
+		if infraEnvFederationAgentIndex(federation, name) >= 0 || !infraEnvFederationAgentIsEligible(agent) {
+			continue
+		}
+		federation.Status.Agents = append(federation.Status.Agents, aiv1beta1.FederatedAgent{Name: name})
+		index := len(federation.Status.Agents) - 1
+		if err = r.importAgent(ctx, log, peerClient, federation, agent); err != nil {
+			federation.Status.Agents = federation.Status.Agents[:index]
+			return ctrl.Result{}, err
+		}
+		federation.Status.Agents[index].State = aiv1beta1.FederatedAgentImporting
+		federation.Status.Agents[index].Message = "Waiting for the peer hub to issue the agent token"
+	}
+	sort.Slice(federation.Status.Agents, func(i, j int) bool {
+		return federation.Status.Agents[i].Name < federation.Status.Agents[j].Name
+	})
+
+	var infraEnvID strfmt.UUID
+	for i := range federation.Status.Agents {
+		federatedAgent := &federation.Status.Agents[i]
+		if federatedAgent.State != aiv1beta1.FederatedAgentImporting && federatedAgent.State != aiv1beta1.FederatedAgentMigrating {
+			continue
+		}
+		agent, ok := agents[federatedAgent.Name]
+		if !ok {
+			federatedAgent.State = aiv1beta1.FederatedAgentFailed
+			federatedAgent.Message = fmt.Sprintf("Agent %s no longer matches the agent selector", federatedAgent.Name)
+			continue
+		}
+		if infraEnvID == "" {
+			backendInfraEnv, err := r.Installer.GetInfraEnvByKubeKey(types.NamespacedName{Namespace: infraEnv.Namespace, Name: infraEnv.Name})
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			infraEnvID = *backendInfraEnv.ID
+		}
+		if err = r.migrateAgent(ctx, log.WithField("agent", agent.Name), peerClient, federation, federatedAgent, agent, infraEnvID); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	var pending, failed []string
+	for _, federatedAgent := range federation.Status.Agents {
+		switch federatedAgent.State {
+		case aiv1beta1.FederatedAgentImporting, aiv1beta1.FederatedAgentMigrating:
+			pending = append(pending, federatedAgent.Name)
+		case aiv1beta1.FederatedAgentFailed:
+			failed = append(failed, federatedAgent.Name)
+		}
+	}
+	switch {
+	case len(pending) > 0:
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationMigrationInProgressReason, fmt.Sprintf("Migrating Agents %s to the peer hub", strings.Join(pending, ", ")))
+		return ctrl.Result{RequeueAfter: infraEnvFederationRequeue}, nil
+	case len(failed) > 0:
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse,
+			aiv1beta1.InfraEnvFederationMigrationFailedReason, fmt.Sprintf("Failed to migrate Agents %s to the peer hub", strings.Join(failed, ", ")))
+	case len(federation.Status.Agents) == 0:
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionTrue,
+			aiv1beta1.InfraEnvFederationNoAgentsSelectedReason, fmt.Sprintf("No unbound Agent of InfraEnv %s matches the agent selector", infraEnv.Name))
+	default:
+		setInfraEnvFederationCondition(federation, aiv1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionTrue,
+			aiv1beta1.InfraEnvFederationAgentsMigratedReason, fmt.Sprintf("The Agents of InfraEnv %s were migrated to the peer hub", infraEnv.Name))
+	}
+	return ctrl.Result{}, nil
+}
+
+// infraEnvFederationAgentIsEligible returns true for the Agents that can move to the peer hub: the
+// Agents bound to a cluster and the Agents managed by a BMH stay on this hub
+func infraEnvFederationAgentIsEligible(agent *aiv1beta1.Agent) bool {
+	if agent.Spec.ClusterDeploymentName != nil {
+		return false
+	}
+	if _, ok := agent.Labels[AGENT_BMH_LABEL]; ok {
+		return false
+	}
+	return funk.ContainsString(infraEnvFederationEligibleStates, agent.Status.DebugInfo.State)
+}
+
+func infraEnvFederationAgentIndex(federation *aiv1beta1.InfraEnvFederation, name string) int {
+	for i := range federation.Status.Agents {
+		if federation.Status.Agents[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// importAgent copies the Agent to the peer hub. The Agent controller of the peer hub restores the
+// host from the annotations of the Agent and issues its agent token.
+func (r *InfraEnvFederationReconciler) importAgent(ctx context.Context, log logrus.FieldLogger, peerClient client.Client, federation *aiv1beta1.InfraEnvFederation, agent *aiv1beta1.Agent) error {
+	peerAgent := &aiv1beta1.Agent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        agent.Name,
+			Namespace:   infraEnvFederationPeerNamespace(federation),
+			Labels:      agent.Labels,
+			Annotations: map[string]string{},
+		},
+		Spec: *agent.Spec.DeepCopy(),
+	}
+	for key, value := range agent.Annotations {
+		if key != AgentMigrationTokenIssuedAnnotation {
+			peerAgent.Annotations[key] = value
+		}
+	}
+	peerAgent.Annotations[AgentImportedFromAnnotation] = fmt.Sprintf("%s/%s", federation.Namespace, federation.Name)
+
+	if err := peerClient.Create(ctx, peerAgent); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "failed to copy agent %s to the peer hub", agent.Name)
+	}
+	log.Infof("Imported agent %s to namespace %s of the peer hub", agent.Name, peerAgent.Namespace)
+	return nil
+}
+
+// migrateAgent records the migration of the host once the peer hub issued its agent token, then
+// deletes the Agent once the agent reaches the peer hub. The migration fails when the agent doesn't
+// reach the peer hub in time.
+func (r *InfraEnvFederationReconciler) migrateAgent(ctx context.Context, log logrus.FieldLogger, peerClient client.Client, federation *aiv1beta1.InfraEnvFederation,
+	federatedAgent *aiv1beta1.FederatedAgent, agent *aiv1beta1.Agent, infraEnvID strfmt.UUID) error {
+	hostID := strfmt.UUID(agent.Name)
+	tokenSecret := &corev1.Secret{}
+	tokenSecretKey := types.NamespacedName{Namespace: infraEnvFederationPeerNamespace(federation), Name: agentMigrationTokenSecretName(agent.Name)}
+
+	if federatedAgent.State == aiv1beta1.FederatedAgentImporting {
+		if err := peerClient.Get(ctx, tokenSecretKey, tokenSecret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		migration := &common.HostMigration{
+			HostID:         hostID,
+			InfraEnvID:     infraEnvID,
+			ServiceBaseURL: federation.Spec.PeerHub.ServiceURL,
+			PeerInfraEnvID: strfmt.UUID(tokenSecret.Data["infraEnvID"]),
+			Token:          string(tokenSecret.Data["token"]),
+		}
+		if err := r.Installer.StartHostMigrationInternal(ctx, migration); err != nil {
+			if !IsHTTPError(err, http.StatusConflict) {
+				return err
+			}
+			federatedAgent.State = aiv1beta1.FederatedAgentFailed
+			federatedAgent.Message = fmt.Sprintf("Failed to start the migration: %s", err.Error())
+			return nil
+		}
+		log.Infof("Migrating agent to %s", federation.Spec.PeerHub.ServiceURL)
+		federatedAgent.State = aiv1beta1.FederatedAgentMigrating
+		federatedAgent.Message = "Waiting for the agent to reach the peer hub"
+		return nil
+	}
+
+	migration, err := r.Installer.GetHostMigrationInternal(ctx, infraEnvID, hostID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case migration == nil:
+		federatedAgent.State = aiv1beta1.FederatedAgentFailed
+		federatedAgent.Message = "The migration of the host was not found"
+	case migration.Error != "":
+		federatedAgent.State = aiv1beta1.FederatedAgentFailed
+		federatedAgent.Message = migration.Error
+	case migration.MigratedAt != nil:
+		tokenSecret.Name = tokenSecretKey.Name
+		tokenSecret.Namespace = tokenSecretKey.Namespace
+		if err = peerClient.Delete(ctx, tokenSecret); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		log.Info("Agent reaches the peer hub, deleting it")
+		if err = r.Delete(ctx, agent); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		federatedAgent.State = aiv1beta1.FederatedAgentMigrated
+		federatedAgent.Message = "The agent reaches the peer hub"
+	case time.Since(migration.CreatedAt) > infraEnvFederationMigrationTimeout:
+		reason := fmt.Sprintf("the agent didn't reach the peer hub within %s", infraEnvFederationMigrationTimeout)
+		if err = r.Installer.FailHostMigrationInternal(ctx, infraEnvID, hostID, reason); err != nil {
+			return err
+		}
+		log.Warnf("Migration of the agent timed out after %s", infraEnvFederationMigrationTimeout)
+		federatedAgent.State = aiv1beta1.FederatedAgentFailed
+		federatedAgent.Message = reason
+	}
+	return nil
+}
+
+func setInfraEnvFederationCondition(federation *aiv1beta1.InfraEnvFederation, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusConditionNoHeartbeat(&federation.Status.Conditions, conditionsv1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func (r *InfraEnvFederationReconciler) updateInfraEnvFederationStatus(ctx context.Context, log logrus.FieldLogger, federation *aiv1beta1.InfraEnvFederation) error {
+	if err := r.Status().Update(ctx, federation); err != nil {
+		log.WithError(err).Error("failed to update infra env federation status")
+		return err
+	}
+	return nil
+}
+
+func (r *InfraEnvFederationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	mapAgentToInfraEnvFederations := func(ctx context.Context, agent client.Object) []reconcile.Request {
+		log := logutil.FromContext(ctx, r.Log).WithFields(
+			logrus.Fields{
+				"agent":           agent.GetName(),
+				"agent_namespace": agent.GetNamespace(),
+			})
+		infraEnvName, ok := agent.GetLabels()[aiv1beta1.InfraEnvNameLabel]
+		if !ok {
+			return []reconcile.Request{}
+		}
+		federationList := &aiv1beta1.InfraEnvFederationList{}
+		if err := r.List(ctx, federationList, client.InNamespace(agent.GetNamespace())); err != nil {
+			log.Debugf("failed to list infra env federations")
+			return []reconcile.Request{}
+		}
+		reply := []reconcile.Request{}
+		for _, federation := range federationList.Items {
+			if federation.Spec.InfraEnvName == infraEnvName && federation.Spec.AgentSelector != nil {
+				reply = append(reply, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: federation.Namespace,
+					Name:      federation.Name,
+				}})
+			}
+		}
+		return reply
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&aiv1beta1.InfraEnvFederation{}).
+		Watches(&aiv1beta1.Agent{}, handler.EnqueueRequestsFromMapFunc(mapAgentToInfraEnvFederations)).
+		Complete(r)
+}
@@ -0,0 +1,302 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/bminventory"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("InfraEnvFederation reconcile", func() {
+	var (
+		c                     client.Client
+		peer                  client.Client
+		r                     *InfraEnvFederationReconciler
+		ctx                   = context.Background()
+		mockCtrl              *gomock.Controller
+		mockInstallerInternal *bminventory.MockInstallerInternals
+		mockClientFactory     *spoke_k8s_client.MockSpokeK8sClientFactory
+		infraEnv              *v1beta1.InfraEnv
+		federation            *v1beta1.InfraEnvFederation
+		infraEnvID            strfmt.UUID
+		peerNamespace         = "peer-namespace"
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockInstallerInternal = bminventory.NewMockInstallerInternals(mockCtrl)
+		mockClientFactory = spoke_k8s_client.NewMockSpokeK8sClientFactory(mockCtrl)
+		c = fakeclient.NewClientBuilder().WithStatusSubresource(&v1beta1.InfraEnvFederation{}).Build()
+		peer = fakeclient.NewClientBuilder().Build()
+		r = &InfraEnvFederationReconciler{
+			Client:                c,
+			APIReader:             c,
+			Log:                   common.GetTestLog(),
+			Installer:             mockInstallerInternal,
+			SpokeK8sClientFactory: mockClientFactory,
+			AuthType:              auth.TypeLocal,
+		}
+
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "peer-kubeconfig", Namespace: testNamespace},
+			Data:       map[string][]byte{"kubeconfig": []byte("definitely_a_kubeconfig")},
+		})).To(Succeed())
+		mockClientFactory.EXPECT().CreateFromSecret(gomock.Nil(), gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(
+			func(_ *hivev1.ClusterDeployment, secret *corev1.Secret) (spoke_k8s_client.SpokeK8sClient, error) {
+				Expect(secret.Data["kubeconfig"]).To(Equal([]byte("definitely_a_kubeconfig")))
+				return fakeSpokeK8sClient{Client: peer}, nil
+			},
+		).AnyTimes()
+
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: testNamespace},
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+		})).To(Succeed())
+		Expect(c.Create(ctx, &v1beta1.NMStateConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nmstate", Namespace: testNamespace, Labels: map[string]string{"infraenv": "test"}},
+			Spec:       v1beta1.NMStateConfigSpec{Interfaces: []*v1beta1.Interface{{Name: "eth0", MacAddress: "52:54:00:00:00:01"}}},
+		})).To(Succeed())
+		infraEnv = &v1beta1.InfraEnv{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-infraenv", Namespace: testNamespace},
+			Spec: v1beta1.InfraEnvSpec{
+				PullSecretRef:              &corev1.LocalObjectReference{Name: "pull-secret"},
+				NMStateConfigLabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"infraenv": "test"}},
+				ClusterRef:                 &v1beta1.ClusterReference{Name: "test-cluster", Namespace: testNamespace},
+			},
+		}
+		Expect(c.Create(ctx, infraEnv)).To(Succeed())
+		infraEnvID = strfmt.UUID(uuid.New().String())
+		mockInstallerInternal.EXPECT().GetInfraEnvByKubeKey(types.NamespacedName{Namespace: testNamespace, Name: infraEnv.Name}).
+			Return(&common.InfraEnv{InfraEnv: models.InfraEnv{ID: &infraEnvID}}, nil).AnyTimes()
+
+		federation = &v1beta1.InfraEnvFederation{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-federation", Namespace: testNamespace},
+			Spec: v1beta1.InfraEnvFederationSpec{
+				InfraEnvName: infraEnv.Name,
+				PeerHub: v1beta1.PeerHub{
+					KubeconfigSecretRef: corev1.LocalObjectReference{Name: "peer-kubeconfig"},
+					Namespace:           peerNamespace,
+					ServiceURL:          "https://peer.example.com",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	reconcileFederation := func() (ctrl.Result, *v1beta1.InfraEnvFederation) {
+		key := types.NamespacedName{Namespace: federation.Namespace, Name: federation.Name}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		updated := &v1beta1.InfraEnvFederation{}
+		Expect(c.Get(ctx, key, updated)).To(Succeed())
+		return result, updated
+	}
+
+	expectCondition := func(federation *v1beta1.InfraEnvFederation, conditionType conditionsv1.ConditionType, status corev1.ConditionStatus, reason string) {
+		condition := conditionsv1.FindStatusCondition(federation.Status.Conditions, conditionType)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(status))
+		Expect(condition.Reason).To(Equal(reason))
+	}
+
+	// setPeerISODownloadURL stands for the InfraEnv controller of the peer hub
+	setPeerISODownloadURL := func() {
+		peerInfraEnv := &v1beta1.InfraEnv{}
+		Expect(peer.Get(ctx, types.NamespacedName{Namespace: peerNamespace, Name: infraEnv.Name}, peerInfraEnv)).To(Succeed())
+		peerInfraEnv.Status.ISODownloadURL = "https://peer.example.com/iso"
+		Expect(peer.Update(ctx, peerInfraEnv)).To(Succeed())
+	}
+
+	newUnboundAgent := func(labels map[string]string) *v1beta1.Agent {
+		agent := newAgent(uuid.New().String(), testNamespace, v1beta1.AgentSpec{Approved: true})
+		agent.Labels = map[string]string{v1beta1.InfraEnvNameLabel: infraEnv.Name}
+		for key, value := range labels {
+			agent.Labels[key] = value
+		}
+		agent.Annotations = map[string]string{AgentStateAnnotation: models.HostStatusKnownUnbound}
+		agent.Status.DebugInfo.State = models.HostStatusKnownUnbound
+		Expect(c.Create(ctx, agent)).To(Succeed())
+		return agent
+	}
+
+	It("reports a missing InfraEnv", func() {
+		federation.Spec.InfraEnvName = "missing"
+		Expect(c.Create(ctx, federation)).To(Succeed())
+
+		_, updated := reconcileFederation()
+		expectCondition(updated, v1beta1.InfraEnvFederationExportedCondition, corev1.ConditionFalse, v1beta1.InfraEnvFederationInfraEnvNotFoundReason)
+	})
+
+	It("exports the InfraEnv without its Agents", func() {
+		Expect(c.Create(ctx, federation)).To(Succeed())
+
+		result, updated := reconcileFederation()
+		Expect(result).To(Equal(ctrl.Result{}))
+		expectCondition(updated, v1beta1.InfraEnvFederationExportedCondition, corev1.ConditionTrue, v1beta1.InfraEnvFederationExportedReason)
+		expectCondition(updated, v1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionTrue, v1beta1.InfraEnvFederationNoAgentsSelectedReason)
+
+		peerInfraEnv := &v1beta1.InfraEnv{}
+		Expect(peer.Get(ctx, types.NamespacedName{Namespace: peerNamespace, Name: infraEnv.Name}, peerInfraEnv)).To(Succeed())
+		Expect(peerInfraEnv.Spec.ClusterRef).To(BeNil())
+		Expect(peerInfraEnv.Spec.PullSecretRef.Name).To(Equal("pull-secret"))
+
+		pullSecret := &corev1.Secret{}
+		Expect(peer.Get(ctx, types.NamespacedName{Namespace: peerNamespace, Name: "pull-secret"}, pullSecret)).To(Succeed())
+		Expect(pullSecret.Data).To(HaveKey(corev1.DockerConfigJsonKey))
+
+		nmStateConfig := &v1beta1.NMStateConfig{}
+		Expect(peer.Get(ctx, types.NamespacedName{Namespace: peerNamespace, Name: "nmstate"}, nmStateConfig)).To(Succeed())
+		Expect(nmStateConfig.Labels).To(HaveKeyWithValue("infraenv", "test"))
+	})
+
+	It("reports an unreachable peer hub", func() {
+		federation.Spec.PeerHub.KubeconfigSecretRef.Name = "missing"
+		Expect(c.Create(ctx, federation)).To(Succeed())
+
+		result, updated := reconcileFederation()
+		Expect(result).To(Equal(ctrl.Result{RequeueAfter: defaultRequeueAfterOnError}))
+		expectCondition(updated, v1beta1.InfraEnvFederationExportedCondition, corev1.ConditionFalse, v1beta1.InfraEnvFederationPeerUnreachableReason)
+	})
+
+	Context("with an agent selector", func() {
+		var agent *v1beta1.Agent
+
+		BeforeEach(func() {
+			federation.Spec.AgentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"site": "east"}}
+			Expect(c.Create(ctx, federation)).To(Succeed())
+			agent = newUnboundAgent(map[string]string{"site": "east"})
+		})
+
+		importAndIssueToken := func() strfmt.UUID {
+			result, updated := reconcileFederation()
+			Expect(result).To(Equal(ctrl.Result{RequeueAfter: infraEnvFederationRequeue}))
+			expectCondition(updated, v1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse, v1beta1.InfraEnvFederationMigrationInProgressReason)
+			Expect(updated.Status.Agents).To(BeEmpty())
+			setPeerISODownloadURL()
+
+			_, updated = reconcileFederation()
+			Expect(updated.Status.PeerISODownloadURL).To(Equal("https://peer.example.com/iso"))
+			Expect(updated.Status.Agents).To(HaveLen(1))
+			Expect(updated.Status.Agents[0].State).To(Equal(v1beta1.FederatedAgentImporting))
+
+			peerAgent := &v1beta1.Agent{}
+			Expect(peer.Get(ctx, types.NamespacedName{Namespace: peerNamespace, Name: agent.Name}, peerAgent)).To(Succeed())
+			Expect(peerAgent.Annotations).To(HaveKeyWithValue(AgentImportedFromAnnotation, testNamespace+"/"+federation.Name))
+			Expect(peerAgent.Annotations).To(HaveKeyWithValue(AgentStateAnnotation, models.HostStatusKnownUnbound))
+
+			// The Agent controller of the peer hub issues the agent token
+			peerInfraEnvID := strfmt.UUID(uuid.New().String())
+			Expect(peer.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: agentMigrationTokenSecretName(agent.Name), Namespace: peerNamespace},
+				Data:       map[string][]byte{"token": []byte("peer-token"), "infraEnvID": []byte(peerInfraEnvID.String())},
+			})).To(Succeed())
+			mockInstallerInternal.EXPECT().StartHostMigrationInternal(gomock.Any(), &common.HostMigration{
+				HostID:         strfmt.UUID(agent.Name),
+				InfraEnvID:     infraEnvID,
+				ServiceBaseURL: "https://peer.example.com",
+				PeerInfraEnvID: peerInfraEnvID,
+				Token:          "peer-token",
+			}).Return(nil).Times(1)
+
+			_, updated = reconcileFederation()
+			Expect(updated.Status.Agents[0].State).To(Equal(v1beta1.FederatedAgentMigrating))
+			return peerInfraEnvID
+		}
+
+		It("migrates the selected agents", func() {
+			newUnboundAgent(map[string]string{"site": "west"})
+			boundAgent := newUnboundAgent(map[string]string{"site": "east"})
+			boundAgent.Spec.ClusterDeploymentName = &v1beta1.ClusterReference{Name: "test-cluster", Namespace: testNamespace}
+			Expect(c.Update(ctx, boundAgent)).To(Succeed())
+			newUnboundAgent(map[string]string{"site": "east", AGENT_BMH_LABEL: "bmh"})
+
+			importAndIssueToken()
+
+			By("waiting for the agent to reply")
+			migration := &common.HostMigration{HostID: strfmt.UUID(agent.Name), InfraEnvID: infraEnvID, CreatedAt: time.Now()}
+			mockInstallerInternal.EXPECT().GetHostMigrationInternal(gomock.Any(), infraEnvID, strfmt.UUID(agent.Name)).Return(migration, nil).Times(1)
+			result, updated := reconcileFederation()
+			Expect(result).To(Equal(ctrl.Result{RequeueAfter: infraEnvFederationRequeue}))
+			Expect(updated.Status.Agents[0].State).To(Equal(v1beta1.FederatedAgentMigrating))
+
+			By("deleting the agent once it reaches the peer hub")
+			now := time.Now()
+			migration.MigratedAt = &now
+			mockInstallerInternal.EXPECT().GetHostMigrationInternal(gomock.Any(), infraEnvID, strfmt.UUID(agent.Name)).Return(migration, nil).Times(1)
+			result, updated = reconcileFederation()
+			Expect(result).To(Equal(ctrl.Result{}))
+			Expect(updated.Status.Agents).To(HaveLen(1))
+			Expect(updated.Status.Agents[0].State).To(Equal(v1beta1.FederatedAgentMigrated))
+			expectCondition(updated, v1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionTrue, v1beta1.InfraEnvFederationAgentsMigratedReason)
+
+			// The Agent controller deregisters the host of the deleted Agent
+			deleted := &v1beta1.Agent{}
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: agent.Name}, deleted)).To(Succeed())
+			Expect(deleted.DeletionTimestamp.IsZero()).To(BeFalse())
+			err := peer.Get(ctx, types.NamespacedName{Namespace: peerNamespace, Name: agentMigrationTokenSecretName(agent.Name)}, &corev1.Secret{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: boundAgent.Name}, &v1beta1.Agent{})).To(Succeed())
+		})
+
+		It("keeps the agent when the agent fails to migrate", func() {
+			importAndIssueToken()
+
+			mockInstallerInternal.EXPECT().GetHostMigrationInternal(gomock.Any(), infraEnvID, strfmt.UUID(agent.Name)).
+				Return(&common.HostMigration{Error: "the agent failed to migrate: unreachable"}, nil).Times(1)
+			result, updated := reconcileFederation()
+			Expect(result).To(Equal(ctrl.Result{}))
+			Expect(updated.Status.Agents[0].State).To(Equal(v1beta1.FederatedAgentFailed))
+			Expect(updated.Status.Agents[0].Message).To(Equal("the agent failed to migrate: unreachable"))
+			expectCondition(updated, v1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse, v1beta1.InfraEnvFederationMigrationFailedReason)
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: agent.Name}, &v1beta1.Agent{})).To(Succeed())
+		})
+
+		It("fails the migration when the agent doesn't reach the peer hub in time", func() {
+			importAndIssueToken()
+
+			mockInstallerInternal.EXPECT().GetHostMigrationInternal(gomock.Any(), infraEnvID, strfmt.UUID(agent.Name)).
+				Return(&common.HostMigration{CreatedAt: time.Now().Add(-infraEnvFederationMigrationTimeout - time.Minute)}, nil).Times(1)
+			mockInstallerInternal.EXPECT().FailHostMigrationInternal(gomock.Any(), infraEnvID, strfmt.UUID(agent.Name), gomock.Any()).Return(nil).Times(1)
+			result, updated := reconcileFederation()
+			Expect(result).To(Equal(ctrl.Result{}))
+			Expect(updated.Status.Agents[0].State).To(Equal(v1beta1.FederatedAgentFailed))
+			Expect(updated.Status.Agents[0].Message).To(ContainSubstring("didn't reach the peer hub"))
+			expectCondition(updated, v1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse, v1beta1.InfraEnvFederationMigrationFailedReason)
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: agent.Name}, &v1beta1.Agent{})).To(Succeed())
+		})
+
+		It("doesn't migrate the agents without the local authentication", func() {
+			r.AuthType = auth.TypeRHSSO
+			reconcileFederation()
+			setPeerISODownloadURL()
+
+			_, updated := reconcileFederation()
+			Expect(updated.Status.Agents).To(BeEmpty())
+			expectCondition(updated, v1beta1.InfraEnvFederationAgentsMigratedCondition, corev1.ConditionFalse, v1beta1.InfraEnvFederationMigrationFailedReason)
+			err := peer.Get(ctx, types.NamespacedName{Namespace: peerNamespace, Name: agent.Name}, &v1beta1.Agent{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
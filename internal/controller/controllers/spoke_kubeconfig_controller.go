@@ -0,0 +1,605 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/golang-jwt/jwt/v4"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	spokeHubAccessName = "assisted-service-hub-access"
+
+	// spokeKubeconfigTokenLabel marks the service account token secrets minted on the spoke cluster
+	// by the previous versions
+	spokeKubeconfigTokenLabel = "agent-install.openshift.io/hub-access-token"
+	// spokeKubeconfigGenerationAnnotation counts the rotations of the service account kubeconfig
+	spokeKubeconfigGenerationAnnotation = "agent-install.openshift.io/kubeconfig-generation"
+	// spokeKubeconfigRotatedAtAnnotation is the time of the last rotation of the service account kubeconfig
+	spokeKubeconfigRotatedAtAnnotation = "agent-install.openshift.io/kubeconfig-rotated-at"
+
+	// spokeKubeconfigCheckInterval is how often the service account kubeconfig is checked between
+	// rotations, to recover it when its token was revoked
+	spokeKubeconfigCheckInterval = time.Hour
+)
+
+// spokeHubAccessRules are the cluster-wide access of the hub to the installed clusters: the nodes and
+// their CSRs, the drain of the removed nodes, the Machines of the control plane, the health of the
+// cluster, the namespaces that it uses and the check of its own access
+var spokeHubAccessRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get", "list", "watch", "update", "patch", "delete"}},
+	{APIGroups: []string{""}, Resources: []string{"namespaces"}, ResourceNames: append([]string{"default"}, spokeHubNamespaces()...), Verbs: []string{"get"}},
+	{APIGroups: []string{""}, Resources: []string{"namespaces"}, ResourceNames: []string{spokeReclaimNamespaceName}, Verbs: []string{"update", "patch"}},
+	{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "delete"}},
+	{APIGroups: []string{""}, Resources: []string{"pods/eviction"}, Verbs: []string{"create"}},
+	{APIGroups: []string{"apps"}, Resources: []string{"daemonsets"}, Verbs: []string{"get", "list"}},
+	{APIGroups: []string{rbacv1.GroupName}, Resources: []string{"clusterroles", "clusterrolebindings"}, ResourceNames: []string{spokeHubAccessName}, Verbs: []string{"get"}},
+	{APIGroups: []string{"certificates.k8s.io"}, Resources: []string{"certificatesigningrequests"}, Verbs: []string{"get", "list", "watch"}},
+	{APIGroups: []string{"certificates.k8s.io"}, Resources: []string{"certificatesigningrequests/approval"}, Verbs: []string{"update"}},
+	{APIGroups: []string{"certificates.k8s.io"}, Resources: []string{"signers"}, ResourceNames: []string{"kubernetes.io/kube-apiserver-client-kubelet", "kubernetes.io/kubelet-serving"}, Verbs: []string{"approve"}},
+	{APIGroups: []string{"config.openshift.io"}, Resources: []string{"clusterversions", "clusteroperators"}, Verbs: []string{"get", "list", "watch"}},
+	{APIGroups: []string{"machineconfiguration.openshift.io"}, Resources: []string{"machineconfigpools"}, Verbs: []string{"get", "list", "update", "patch"}},
+	{APIGroups: []string{"machine.openshift.io"}, Resources: []string{"machines"}, Verbs: []string{"get", "list"}},
+}
+
+// spokeHubNamespaceAccessRules are the access of the hub to the namespaces that it uses on the
+// installed clusters, granted by a role in each of them. Each role also lets the hub check it.
+var spokeHubNamespaceAccessRules = map[string][]rbacv1.PolicyRule{
+	// The reclaim of the hosts and the service account itself
+	spokeReclaimNamespaceName: {
+		{APIGroups: []string{""}, Resources: []string{"secrets", "configmaps", "serviceaccounts"}, Verbs: []string{"get", "list", "create", "update", "patch", "delete"}},
+		{APIGroups: []string{""}, Resources: []string{"serviceaccounts/token"}, ResourceNames: []string{spokeHubAccessName}, Verbs: []string{"create"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"daemonsets"}, Verbs: []string{"get", "list", "create", "update", "patch", "delete"}},
+		{APIGroups: []string{rbacv1.GroupName}, Resources: []string{"roles", "rolebindings"}, Verbs: []string{"get", "create", "update", "patch", "delete"}},
+		{APIGroups: []string{"security.openshift.io"}, Resources: []string{"securitycontextconstraints"}, ResourceNames: []string{"privileged"}, Verbs: []string{"use"}},
+	},
+	// The BareMetalHosts and Machines of the day-2 nodes
+	OPENSHIFT_MACHINE_API_NAMESPACE: {
+		{APIGroups: []string{"metal3.io"}, Resources: []string{"baremetalhosts"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+		{APIGroups: []string{"machine.openshift.io"}, Resources: []string{"machines", "machinesets"}, Verbs: []string{"get", "list", "update", "patch", "delete"}},
+		spokeHubAccessCheckRule,
+	},
+	// The CA of the machine config server, injected in the ignition of the day-2 nodes
+	"openshift-machine-config-operator": {
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"machine-config-server-ca"}, Verbs: []string{"get"}},
+		spokeHubAccessCheckRule,
+	},
+	"kube-system": {
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"root-ca"}, Verbs: []string{"get"}},
+		spokeHubAccessCheckRule,
+	},
+	"openshift-config": {
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: kubeRootCAHypershiftNames, Verbs: []string{"get"}},
+		spokeHubAccessCheckRule,
+	},
+}
+
+// spokeHubAccessCheckRule lets the hub check its role in the namespaces where it can't manage the roles
+var spokeHubAccessCheckRule = rbacv1.PolicyRule{
+	APIGroups: []string{rbacv1.GroupName}, Resources: []string{"roles", "rolebindings"}, ResourceNames: []string{spokeHubAccessName}, Verbs: []string{"get"},
+}
+
+// spokeHubNamespaces returns the namespaces where the hub has a role, sorted
+func spokeHubNamespaces() []string {
+	namespaces := make([]string, 0, len(spokeHubNamespaceAccessRules))
+	for namespace := range spokeHubNamespaceAccessRules {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+type SpokeKubeconfigConfig struct {
+	// RotationInterval is how often the service account kubeconfig of the installed clusters is
+	// rotated, the rotation is disabled when it's 0
+	RotationInterval time.Duration `envconfig:"SPOKE_KUBECONFIG_ROTATION_INTERVAL" default:"0s"`
+}
+
+// SpokeKubeconfigReconciler mints a service account kubeconfig on the installed clusters and rotates
+// it periodically. The hub accesses the installed clusters with it instead of the admin kubeconfig,
+// whose client certificate can expire or be revoked.
+type SpokeKubeconfigReconciler struct {
+	client.Client
+	APIReader        client.Reader
+	Log              logrus.FieldLogger
+	Scheme           *runtime.Scheme
+	SpokeClientCache SpokeClientCache
+	Config           SpokeKubeconfigConfig
+}
+
+//+kubebuilder:rbac:groups=hive.openshift.io,resources=clusterdeployments,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
+
+func (r *SpokeKubeconfigReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx := addRequestIdIfNeeded(origCtx)
+	log := logutil.FromContext(ctx, r.Log).WithFields(
+		logrus.Fields{
+			"cluster_deployment":           req.Name,
+			"cluster_deployment_namespace": req.Namespace,
+		})
+
+	defer func() {
+		log.Debug("SpokeKubeconfig Reconcile ended")
+	}()
+
+	log.Debug("SpokeKubeconfig Reconcile started")
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(ctx, req.NamespacedName, cd); err != nil {
+		log.WithError(err).Errorf("Failed to get ClusterDeployment %s", req.NamespacedName)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !cd.Spec.Installed || cd.Spec.ClusterInstallRef == nil || cd.Spec.ClusterInstallRef.Kind != "AgentClusterInstall" {
+		return ctrl.Result{}, nil
+	}
+
+	current, err := r.serviceAccountKubeconfig(ctx, cd)
+	if err != nil {
+		log.WithError(err).Error("failed to get the service account kubeconfig secret")
+		return ctrl.Result{}, err
+	}
+
+	// The service account kubeconfig is used to mint the next one, unless it doesn't work anymore
+	// or the access of the service account changed. It's then rotated with the admin kubeconfig.
+	var (
+		spokeClient client.Client
+		base        *corev1.Secret
+	)
+	if current != nil {
+		spokeClient, err = r.checkedSpokeClient(ctx, cd, current)
+		if err != nil {
+			log.WithError(err).Warn("The service account kubeconfig doesn't work, recovering it with the admin kubeconfig")
+			// The controllers access the cluster with the admin kubeconfig until it's recovered
+			if k8serrors.IsUnauthorized(errors.Cause(err)) {
+				if err = r.Delete(ctx, current); client.IgnoreNotFound(err) != nil {
+					log.WithError(err).Error("failed to delete the service account kubeconfig secret")
+					return ctrl.Result{}, err
+				}
+			}
+		} else {
+			if next := rotatedAt(current).Add(r.Config.RotationInterval); time.Now().Before(next) {
+				return ctrl.Result{RequeueAfter: min(time.Until(next), spokeKubeconfigCheckInterval)}, nil
+			}
+			upToDate, checkErr := r.spokeHubAccessUpToDate(ctx, spokeClient)
+			if checkErr != nil {
+				log.WithError(checkErr).Warn("Failed to check the access of the service account, rotating the kubeconfig with the admin kubeconfig")
+			} else if !upToDate {
+				log.Info("The access of the service account changed, rotating the kubeconfig with the admin kubeconfig")
+			} else {
+				base = current
+			}
+		}
+	}
+	if base == nil {
+		if base, spokeClient, err = r.adminSpokeClient(ctx, log, cd); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	result, err := r.rotate(ctx, log, cd, current, base, spokeClient)
+	if err != nil && base == current && k8serrors.IsForbidden(errors.Cause(err)) {
+		// The service account can't grant itself the access that it lacks
+		log.WithError(err).Warn("The service account kubeconfig lacks access, rotating it with the admin kubeconfig")
+		if base, spokeClient, err = r.adminSpokeClient(ctx, log, cd); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.rotate(ctx, log, cd, current, base, spokeClient)
+	}
+	return result, err
+}
+
+// adminSpokeClient returns the admin kubeconfig secret and a client for the spoke cluster that was
+// checked to have access to it with the admin kubeconfig
+func (r *SpokeKubeconfigReconciler) adminSpokeClient(ctx context.Context, log logrus.FieldLogger, cd *hivev1.ClusterDeployment) (*corev1.Secret, client.Client, error) {
+	secret, err := getSecret(ctx, r.Client, r.APIReader, types.NamespacedName{
+		Namespace: cd.Namespace,
+		Name:      getClusterDeploymentAdminKubeConfigSecretName(cd),
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to get the admin kubeconfig secret")
+		return nil, nil, err
+	}
+	spokeClient, err := r.checkedSpokeClient(ctx, cd, secret)
+	if err != nil {
+		log.WithError(err).Error("The cluster can't be reached with the admin kubeconfig")
+		return nil, nil, err
+	}
+	return secret, spokeClient, nil
+}
+
+// rotate requests a new service account token on the spoke cluster, stores the kubeconfig with it in
+// the hub and deletes the token secrets of the previous versions. The access of the service account
+// is updated when rotating with the admin kubeconfig.
+func (r *SpokeKubeconfigReconciler) rotate(ctx context.Context, log logrus.FieldLogger, cd *hivev1.ClusterDeployment,
+	current, base *corev1.Secret, spokeClient client.Client) (ctrl.Result, error) {
+	if base != current {
+		if err := r.ensureSpokeHubAccess(ctx, log, spokeClient); err != nil {
+			log.WithError(err).Error("failed to create the service account on the spoke cluster")
+			return ctrl.Result{}, err
+		}
+	}
+
+	generation := kubeconfigGeneration(current) + 1
+	token, err := r.requestSpokeToken(ctx, spokeClient)
+	if err != nil {
+		log.WithError(err).Error("failed to request the service account token on the spoke cluster")
+		return ctrl.Result{}, err
+	}
+
+	kubeconfig, err := buildServiceAccountKubeconfig(base.Data["kubeconfig"], token)
+	if err != nil {
+		log.WithError(err).Error("failed to create the service account kubeconfig")
+		return ctrl.Result{}, err
+	}
+	if err = r.storeServiceAccountKubeconfig(ctx, cd, kubeconfig, generation); err != nil {
+		log.WithError(err).Error("failed to store the service account kubeconfig")
+		return ctrl.Result{}, err
+	}
+	log.Infof("Rotated the service account kubeconfig of the cluster to generation %d", generation)
+
+	// The previous tokens expire on their own. The never-expiring token secrets of the previous
+	// versions are deleted once the hub uses the new token, the token of the client in use last.
+	if err = r.deleteSpokeTokenSecrets(ctx, spokeClient, spokeTokenSecretName(generation-1)); err != nil {
+		log.WithError(err).Error("failed to delete the previous service account token secrets on the spoke cluster")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: min(r.Config.RotationInterval, spokeKubeconfigCheckInterval)}, nil
+}
+
+func (r *SpokeKubeconfigReconciler) serviceAccountKubeconfig(ctx context.Context, cd *hivev1.ClusterDeployment) (*corev1.Secret, error) {
+	secret, err := getSecret(ctx, r.Client, r.APIReader, types.NamespacedName{
+		Namespace: cd.Namespace,
+		Name:      getClusterDeploymentServiceAccountKubeConfigSecretName(cd),
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(errors.Cause(err)) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// checkedSpokeClient returns a client for the spoke cluster that was checked to have access to it
+func (r *SpokeKubeconfigReconciler) checkedSpokeClient(ctx context.Context, cd *hivev1.ClusterDeployment, secret *corev1.Secret) (client.Client, error) {
+	spokeClient, err := r.SpokeClientCache.Get(cd, secret)
+	if err != nil {
+		return nil, err
+	}
+	namespace := &corev1.Namespace{}
+	if err = spokeClient.Get(ctx, types.NamespacedName{Name: "default"}, namespace); err != nil {
+		return nil, errors.Wrapf(err, "failed to access the spoke cluster with secret %s/%s", secret.Namespace, secret.Name)
+	}
+	return spokeClient, nil
+}
+
+// ensureSpokeHubAccess creates the service account, binds it to the cluster role with the
+// cluster-wide access that the hub needs and to a role in each namespace that the hub uses. It's
+// run with the admin kubeconfig, the service account can't change its own access.
+func (r *SpokeKubeconfigReconciler) ensureSpokeHubAccess(ctx context.Context, log logrus.FieldLogger, c client.Client) error {
+	if err := ensureSpokeNamespace(ctx, c, log); err != nil {
+		return err
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spokeHubAccessName,
+			Namespace: spokeReclaimNamespaceName,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, c, sa, func() error { return nil })
+	if err != nil {
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Infof("ServiceAccount %s/%s %s for hub access", sa.Namespace, sa.Name, result)
+	}
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      spokeHubAccessName,
+		Namespace: spokeReclaimNamespaceName,
+	}}
+
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: spokeHubAccessName},
+	}
+	result, err = controllerutil.CreateOrUpdate(ctx, c, role, func() error {
+		role.Rules = spokeHubAccessRules
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Infof("ClusterRole %s %s for hub access", role.Name, result)
+	}
+
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     spokeHubAccessName,
+	}
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: spokeHubAccessName},
+	}
+	// The role of a binding can't be changed, the binding to cluster-admin of the previous versions
+	// is replaced
+	if err = c.Get(ctx, client.ObjectKeyFromObject(crb), crb); err == nil && crb.RoleRef != roleRef {
+		log.Infof("Replacing ClusterRoleBinding %s to ClusterRole %s for hub access", crb.Name, crb.RoleRef.Name)
+		if err = c.Delete(ctx, crb); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		crb = &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: spokeHubAccessName},
+		}
+	} else if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	result, err = controllerutil.CreateOrUpdate(ctx, c, crb, func() error {
+		crb.RoleRef = roleRef
+		crb.Subjects = subjects
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Infof("ClusterRoleBinding %s %s for hub access", crb.Name, result)
+	}
+
+	for _, namespace := range spokeHubNamespaces() {
+		// Some namespaces only exist on some versions, the hub doesn't use them on the others
+		if err = c.Get(ctx, types.NamespacedName{Name: namespace}, &corev1.Namespace{}); k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		nsRole := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: spokeHubAccessName, Namespace: namespace},
+		}
+		result, err = controllerutil.CreateOrUpdate(ctx, c, nsRole, func() error {
+			nsRole.Rules = spokeHubNamespaceAccessRules[namespace]
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if result != controllerutil.OperationResultNone {
+			log.Infof("Role %s/%s %s for hub access", nsRole.Namespace, nsRole.Name, result)
+		}
+		rb := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: spokeHubAccessName, Namespace: namespace},
+		}
+		result, err = controllerutil.CreateOrUpdate(ctx, c, rb, func() error {
+			rb.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: spokeHubAccessName}
+			rb.Subjects = subjects
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if result != controllerutil.OperationResultNone {
+			log.Infof("RoleBinding %s/%s %s for hub access", rb.Namespace, rb.Name, result)
+		}
+	}
+	return nil
+}
+
+// spokeHubAccessUpToDate checks with the service account kubeconfig that the roles of the service
+// account grant the access of this version, they are updated with the admin kubeconfig otherwise
+func (r *SpokeKubeconfigReconciler) spokeHubAccessUpToDate(ctx context.Context, c client.Client) (bool, error) {
+	role := &rbacv1.ClusterRole{}
+	if err := c.Get(ctx, types.NamespacedName{Name: spokeHubAccessName}, role); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if !equality.Semantic.DeepEqual(role.Rules, spokeHubAccessRules) {
+		return false, nil
+	}
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := c.Get(ctx, types.NamespacedName{Name: spokeHubAccessName}, crb); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if crb.RoleRef.Kind != "ClusterRole" || crb.RoleRef.Name != spokeHubAccessName {
+		return false, nil
+	}
+	for _, namespace := range spokeHubNamespaces() {
+		nsRole := &rbacv1.Role{}
+		err := c.Get(ctx, types.NamespacedName{Name: spokeHubAccessName, Namespace: namespace}, nsRole)
+		if k8serrors.IsNotFound(err) {
+			// Missing when the namespace doesn't exist
+			if err = c.Get(ctx, types.NamespacedName{Name: namespace}, &corev1.Namespace{}); k8serrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if err != nil {
+			return false, err
+		}
+		if !equality.Semantic.DeepEqual(nsRole.Rules, spokeHubNamespaceAccessRules[namespace]) {
+			return false, nil
+		}
+		if err = c.Get(ctx, types.NamespacedName{Name: spokeHubAccessName, Namespace: namespace}, &rbacv1.RoleBinding{}); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+	}
+	return true, nil
+}
+
+// spokeTokenSecretName is the name of the never-expiring token secrets minted by the previous
+// versions, they are deleted by the next rotation
+func spokeTokenSecretName(generation int) string {
+	return fmt.Sprintf("%s-token-%d", spokeHubAccessName, generation)
+}
+
+// requestSpokeToken requests a token of the service account that expires after the rotation
+// interval. It's valid for spokeKubeconfigCheckInterval past the interval, so the next rotation
+// requests its successor with it.
+func (r *SpokeKubeconfigReconciler) requestSpokeToken(ctx context.Context, c client.Client) ([]byte, error) {
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spokeHubAccessName,
+			Namespace: spokeReclaimNamespaceName,
+		},
+	}
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: swag.Int64(int64((r.Config.RotationInterval + spokeKubeconfigCheckInterval).Seconds())),
+		},
+	}
+	if err := c.SubResource("token").Create(ctx, sa, tokenRequest); err != nil {
+		return nil, err
+	}
+	if tokenRequest.Status.Token == "" {
+		return nil, errors.Errorf("the spoke cluster didn't issue a token for service account %s/%s", sa.Namespace, sa.Name)
+	}
+	return []byte(tokenRequest.Status.Token), nil
+}
+
+// deleteSpokeTokenSecrets deletes the token secrets minted by the previous versions, the last one
+// is deleted last
+func (r *SpokeKubeconfigReconciler) deleteSpokeTokenSecrets(ctx context.Context, c client.Client, last string) error {
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(spokeReclaimNamespaceName), client.MatchingLabels{spokeKubeconfigTokenLabel: "true"}); err != nil {
+		return err
+	}
+	sort.SliceStable(secrets.Items, func(i, j int) bool {
+		return secrets.Items[i].Name != last && secrets.Items[j].Name == last
+	})
+	for i := range secrets.Items {
+		if err := c.Delete(ctx, &secrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SpokeKubeconfigReconciler) storeServiceAccountKubeconfig(ctx context.Context, cd *hivev1.ClusterDeployment, kubeconfig []byte, generation int) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getClusterDeploymentServiceAccountKubeConfigSecretName(cd),
+			Namespace: cd.Namespace,
+		},
+	}
+	mutate := func() error {
+		metav1.SetMetaDataLabel(&secret.ObjectMeta, WatchResourceLabel, WatchResourceValue)
+		metav1.SetMetaDataLabel(&secret.ObjectMeta, BackupLabel, BackupLabelValue)
+		metav1.SetMetaDataAnnotation(&secret.ObjectMeta, spokeKubeconfigGenerationAnnotation, strconv.Itoa(generation))
+		metav1.SetMetaDataAnnotation(&secret.ObjectMeta, spokeKubeconfigRotatedAtAnnotation, time.Now().UTC().Format(time.RFC3339))
+		secret.Data = map[string][]byte{"kubeconfig": kubeconfig}
+		return controllerutil.SetOwnerReference(cd, secret, r.Scheme)
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, mutate)
+	return err
+}
+
+// buildServiceAccountKubeconfig returns a kubeconfig with the cluster of the base kubeconfig and the token
+func buildServiceAccountKubeconfig(base, token []byte) ([]byte, error) {
+	config, err := clientcmd.Load(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the kubeconfig")
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, errors.Errorf("the kubeconfig doesn't have the current context %s", config.CurrentContext)
+	}
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return nil, errors.Errorf("the kubeconfig doesn't have the cluster %s", kubeContext.Cluster)
+	}
+
+	result := clientcmdapi.NewConfig()
+	result.Clusters[kubeContext.Cluster] = cluster
+	result.AuthInfos[spokeHubAccessName] = &clientcmdapi.AuthInfo{Token: string(token)}
+	result.Contexts[spokeHubAccessName] = &clientcmdapi.Context{
+		Cluster:  kubeContext.Cluster,
+		AuthInfo: spokeHubAccessName,
+	}
+	result.CurrentContext = spokeHubAccessName
+	return clientcmd.Write(*result)
+}
+
+func kubeconfigGeneration(secret *corev1.Secret) int {
+	if secret == nil {
+		return 0
+	}
+	generation, err := strconv.Atoi(secret.Annotations[spokeKubeconfigGenerationAnnotation])
+	if err != nil {
+		return 0
+	}
+	return generation
+}
+
+// serviceAccountKubeconfigExpired checks if the token of the service account kubeconfig expired, it
+// isn't rotated anymore once the rotation is disabled. The tokens of the previous versions don't
+// expire.
+func serviceAccountKubeconfigExpired(secret *corev1.Secret, now time.Time) bool {
+	config, err := clientcmd.Load(secret.Data["kubeconfig"])
+	if err != nil {
+		return false
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return false
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok || authInfo.Token == "" {
+		return false
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err = jwt.NewParser().ParseUnverified(authInfo.Token, claims); err != nil {
+		return false
+	}
+	return !claims.VerifyExpiresAt(now.Unix(), false)
+}
+
+func rotatedAt(secret *corev1.Secret) time.Time {
+	t, err := time.Parse(time.RFC3339, secret.Annotations[spokeKubeconfigRotatedAtAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (r *SpokeKubeconfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("spoke-kubeconfig-controller").
+		For(&hivev1.ClusterDeployment{}).
+		Complete(r)
+}
@@ -0,0 +1,379 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const testAdminKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    certificate-authority-data: Y2EtZGF0YQ==
+    server: https://api.test-cluster.example.com:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: admin
+  name: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+`
+
+var _ = Describe("SpokeKubeconfig reconcile", func() {
+	var (
+		c                    client.Client
+		spokeClient          client.Client
+		r                    *SpokeKubeconfigReconciler
+		mockCtrl             *gomock.Controller
+		mockSpokeClientCache *MockSpokeClientCache
+		ctx                  = context.Background()
+		cd                   *hivev1.ClusterDeployment
+		unreachable          map[string]bool
+		hubKey               types.NamespacedName
+		tokenRequests        []*authenticationv1.TokenRequest
+		// serviceAccountFuncs intercept the calls of the client of the service account kubeconfig
+		serviceAccountFuncs *interceptor.Funcs
+	)
+
+	reconcile := func() ctrl.Result {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}})
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	createSpokeToken := func(generation int, token string) {
+		Expect(spokeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      spokeTokenSecretName(generation),
+				Namespace: spokeReclaimNamespaceName,
+				Labels:    map[string]string{spokeKubeconfigTokenLabel: "true"},
+			},
+			Data: map[string][]byte{corev1.ServiceAccountTokenKey: []byte(token)},
+		})).To(Succeed())
+	}
+
+	createServiceAccountKubeconfig := func(generation int, rotatedAt time.Time) {
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      hubKey.Name,
+				Namespace: hubKey.Namespace,
+				Annotations: map[string]string{
+					spokeKubeconfigGenerationAnnotation: strconv.Itoa(generation),
+					spokeKubeconfigRotatedAtAnnotation:  rotatedAt.UTC().Format(time.RFC3339),
+				},
+			},
+			Data: map[string][]byte{"kubeconfig": []byte(testAdminKubeconfig)},
+		})).To(Succeed())
+	}
+
+	expectServiceAccountKubeconfig := func(generation int, token string) {
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, hubKey, secret)).To(Succeed())
+		Expect(secret.Annotations[spokeKubeconfigGenerationAnnotation]).To(Equal(strconv.Itoa(generation)))
+		Expect(secret.Labels[WatchResourceLabel]).To(Equal(WatchResourceValue))
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+		Expect(secret.OwnerReferences[0].Name).To(Equal(cd.Name))
+
+		config, err := clientcmd.Load(secret.Data["kubeconfig"])
+		Expect(err).ToNot(HaveOccurred())
+		kubeContext := config.Contexts[config.CurrentContext]
+		Expect(config.Clusters[kubeContext.Cluster].Server).To(Equal("https://api.test-cluster.example.com:6443"))
+		Expect(config.AuthInfos[kubeContext.AuthInfo].Token).To(Equal(token))
+		Expect(config.AuthInfos[kubeContext.AuthInfo].ClientCertificateData).To(BeEmpty())
+	}
+
+	spokeTokenNames := func() []string {
+		secrets := &corev1.SecretList{}
+		Expect(spokeClient.List(ctx, secrets, client.InNamespace(spokeReclaimNamespaceName))).To(Succeed())
+		names := []string{}
+		for _, secret := range secrets.Items {
+			names = append(names, secret.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		c = fakeclient.NewClientBuilder().WithScheme(GetKubeClientSchemes()).Build()
+		tokenRequests = nil
+		spokeClient = fakeclient.NewClientBuilder().WithScheme(spoke_k8s_client.GetKubeClientSchemes()).
+			WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: OPENSHIFT_MACHINE_API_NAMESPACE}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// The fake client doesn't implement the token requests
+				SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+					tokenRequest, ok := subResource.(*authenticationv1.TokenRequest)
+					if subResourceName != "token" || !ok {
+						return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+					}
+					if err := c.Get(ctx, client.ObjectKeyFromObject(obj), &corev1.ServiceAccount{}); err != nil {
+						return err
+					}
+					tokenRequests = append(tokenRequests, tokenRequest)
+					tokenRequest.Status.Token = fmt.Sprintf("token-%d", len(tokenRequests))
+					return nil
+				},
+			}).Build()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockSpokeClientCache = NewMockSpokeClientCache(mockCtrl)
+		unreachable = map[string]bool{}
+		serviceAccountFuncs = nil
+		mockSpokeClientCache.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ *hivev1.ClusterDeployment, secret *corev1.Secret) (spoke_k8s_client.SpokeK8sClient, error) {
+				if unreachable[secret.Name] {
+					return nil, errors.New("Unauthorized")
+				}
+				if secret.Name == hubKey.Name && serviceAccountFuncs != nil {
+					return fakeSpokeK8sClient{Client: interceptor.NewClient(spokeClient.(client.WithWatch), *serviceAccountFuncs)}, nil
+				}
+				return fakeSpokeK8sClient{Client: spokeClient}, nil
+			}).AnyTimes()
+		r = &SpokeKubeconfigReconciler{
+			Client:           c,
+			APIReader:        c,
+			Log:              common.GetTestLog(),
+			Scheme:           GetKubeClientSchemes(),
+			SpokeClientCache: mockSpokeClientCache,
+			Config:           SpokeKubeconfigConfig{RotationInterval: 24 * time.Hour},
+		}
+
+		cd = newClusterDeployment("test-cluster", testNamespace, hivev1.ClusterDeploymentSpec{
+			Installed:         true,
+			ClusterInstallRef: &hivev1.ClusterInstallLocalReference{Kind: "AgentClusterInstall", Name: "test-cluster-aci"},
+		})
+		Expect(c.Create(ctx, cd)).To(Succeed())
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: getClusterDeploymentAdminKubeConfigSecretName(cd), Namespace: testNamespace},
+			Data:       map[string][]byte{"kubeconfig": []byte(testAdminKubeconfig)},
+		})).To(Succeed())
+		hubKey = types.NamespacedName{Name: getClusterDeploymentServiceAccountKubeConfigSecretName(cd), Namespace: testNamespace}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("ignores a cluster that isn't installed", func() {
+		cd.Spec.Installed = false
+		Expect(c.Update(ctx, cd)).To(Succeed())
+
+		Expect(reconcile()).To(Equal(ctrl.Result{}))
+		Expect(c.Get(ctx, hubKey, &corev1.Secret{})).ToNot(Succeed())
+	})
+
+	It("mints the first service account kubeconfig with the admin kubeconfig", func() {
+		result := reconcile()
+		Expect(result.RequeueAfter).To(Equal(spokeKubeconfigCheckInterval))
+		Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName, Namespace: spokeReclaimNamespaceName}, &corev1.ServiceAccount{})).To(Succeed())
+		role := &rbacv1.ClusterRole{}
+		Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName}, role)).To(Succeed())
+		Expect(role.Rules).To(Equal(spokeHubAccessRules))
+		crb := &rbacv1.ClusterRoleBinding{}
+		Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName}, crb)).To(Succeed())
+		Expect(crb.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: spokeHubAccessName}))
+		Expect(crb.Subjects).To(ConsistOf(rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: spokeHubAccessName, Namespace: spokeReclaimNamespaceName}))
+
+		By("granting the access to the namespaces with roles in the namespaces that exist")
+		for _, namespace := range []string{spokeReclaimNamespaceName, OPENSHIFT_MACHINE_API_NAMESPACE, "kube-system"} {
+			nsRole := &rbacv1.Role{}
+			Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName, Namespace: namespace}, nsRole)).To(Succeed())
+			Expect(nsRole.Rules).To(Equal(spokeHubNamespaceAccessRules[namespace]))
+			rb := &rbacv1.RoleBinding{}
+			Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName, Namespace: namespace}, rb)).To(Succeed())
+			Expect(rb.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: spokeHubAccessName}))
+			Expect(rb.Subjects).To(Equal(crb.Subjects))
+		}
+		Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName, Namespace: "openshift-config"}, &rbacv1.Role{})).ToNot(Succeed())
+		for _, rule := range spokeHubAccessRules {
+			Expect(rule.Resources).ToNot(ContainElement("secrets"))
+			if rule.APIGroups[0] == rbacv1.GroupName {
+				Expect(rule.Verbs).To(Equal([]string{"get"}))
+			}
+		}
+
+		By("requesting a token that outlives the rotation interval by the check interval")
+		Expect(tokenRequests).To(HaveLen(1))
+		Expect(*tokenRequests[0].Spec.ExpirationSeconds).To(Equal(int64((25 * time.Hour).Seconds())))
+		expectServiceAccountKubeconfig(1, "token-1")
+	})
+
+	It("replaces the binding to cluster-admin of the previous versions", func() {
+		Expect(spokeClient.Create(ctx, &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: spokeHubAccessName},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+		})).To(Succeed())
+
+		reconcile()
+		crb := &rbacv1.ClusterRoleBinding{}
+		Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName}, crb)).To(Succeed())
+		Expect(crb.RoleRef.Name).To(Equal(spokeHubAccessName))
+	})
+
+	It("doesn't rotate the kubeconfig before the interval passed", func() {
+		createServiceAccountKubeconfig(1, time.Now().Add(-23*time.Hour))
+		createSpokeToken(1, "token-1")
+
+		result := reconcile()
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(tokenRequests).To(BeEmpty())
+	})
+
+	It("rotates the kubeconfig and deletes the token secrets of the previous versions", func() {
+		createServiceAccountKubeconfig(1, time.Now().Add(-25*time.Hour))
+		createSpokeToken(1, "legacy-token-1")
+		createSpokeToken(0, "legacy-token-0")
+
+		reconcile()
+		expectServiceAccountKubeconfig(2, "token-1")
+		Expect(spokeTokenNames()).To(BeEmpty())
+	})
+
+	It("rotates the kubeconfig with the service account kubeconfig without changing its access", func() {
+		reconcile()
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, hubKey, secret)).To(Succeed())
+		secret.Annotations[spokeKubeconfigRotatedAtAnnotation] = time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339)
+		Expect(c.Update(ctx, secret)).To(Succeed())
+		unreachable[getClusterDeploymentAdminKubeConfigSecretName(cd)] = true
+		// The service account can't write the RBAC resources
+		rbacWrite := func(obj client.Object) error {
+			switch obj.(type) {
+			case *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding, *rbacv1.Role, *rbacv1.RoleBinding:
+				return k8serrors.NewForbidden(schema.GroupResource{Group: rbacv1.GroupName}, obj.GetName(), errors.New("no write access"))
+			}
+			return nil
+		}
+		serviceAccountFuncs = &interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if err := rbacWrite(obj); err != nil {
+					return err
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if err := rbacWrite(obj); err != nil {
+					return err
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}
+
+		reconcile()
+		expectServiceAccountKubeconfig(2, "token-2")
+	})
+
+	It("rotates the kubeconfig with the admin kubeconfig when the access of the service account changed", func() {
+		createServiceAccountKubeconfig(1, time.Now().Add(-25*time.Hour))
+		Expect(spokeClient.Create(ctx, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: spokeHubAccessName}})).To(Succeed())
+		// The service account can't update its own cluster role
+		serviceAccountFuncs = &interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*rbacv1.ClusterRole); ok {
+					return k8serrors.NewForbidden(schema.GroupResource{Group: rbacv1.GroupName, Resource: "clusterroles"}, obj.GetName(), errors.New("escalation"))
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}
+
+		reconcile()
+		expectServiceAccountKubeconfig(2, "token-1")
+		role := &rbacv1.ClusterRole{}
+		Expect(spokeClient.Get(ctx, types.NamespacedName{Name: spokeHubAccessName}, role)).To(Succeed())
+		Expect(role.Rules).To(Equal(spokeHubAccessRules))
+	})
+
+	It("recovers a kubeconfig that doesn't work with the admin kubeconfig", func() {
+		createServiceAccountKubeconfig(1, time.Now().Add(-time.Hour))
+		unreachable[hubKey.Name] = true
+
+		reconcile()
+		expectServiceAccountKubeconfig(2, "token-1")
+	})
+
+	It("accesses the cluster with the service account kubeconfig once it exists", func() {
+		key := spokeKubeconfigSecretKey(ctx, c, c, testNamespace, cd)
+		Expect(key.Name).To(Equal(getClusterDeploymentAdminKubeConfigSecretName(cd)))
+
+		createServiceAccountKubeconfig(1, time.Now())
+		key = spokeKubeconfigSecretKey(ctx, c, c, testNamespace, cd)
+		Expect(key).To(Equal(hubKey))
+	})
+
+	It("accesses the cluster with the admin kubeconfig once the service account token expired", func() {
+		serviceAccountKubeconfig := func(expiresAt time.Time) []byte {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": expiresAt.Unix()}).SignedString([]byte("key"))
+			Expect(err).ToNot(HaveOccurred())
+			kubeconfig, err := buildServiceAccountKubeconfig([]byte(testAdminKubeconfig), []byte(token))
+			Expect(err).ToNot(HaveOccurred())
+			return kubeconfig
+		}
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: hubKey.Name, Namespace: hubKey.Namespace},
+			Data:       map[string][]byte{"kubeconfig": serviceAccountKubeconfig(time.Now().Add(time.Hour))},
+		})).To(Succeed())
+		Expect(spokeKubeconfigSecretKey(ctx, c, c, testNamespace, cd)).To(Equal(hubKey))
+
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, hubKey, secret)).To(Succeed())
+		secret.Data["kubeconfig"] = serviceAccountKubeconfig(time.Now().Add(-time.Minute))
+		Expect(c.Update(ctx, secret)).To(Succeed())
+		key := spokeKubeconfigSecretKey(ctx, c, c, testNamespace, cd)
+		Expect(key.Name).To(Equal(getClusterDeploymentAdminKubeConfigSecretName(cd)))
+	})
+
+	It("deletes the kubeconfig rejected by the cluster", func() {
+		createServiceAccountKubeconfig(1, time.Now().Add(-time.Hour))
+		serviceAccountFuncs = &interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return k8serrors.NewUnauthorized("token expired")
+			},
+		}
+		unreachable[getClusterDeploymentAdminKubeConfigSecretName(cd)] = true
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}})
+		Expect(err).To(HaveOccurred())
+		Expect(k8serrors.IsNotFound(c.Get(ctx, hubKey, &corev1.Secret{}))).To(BeTrue())
+		key := spokeKubeconfigSecretKey(ctx, c, c, testNamespace, cd)
+		Expect(key.Name).To(Equal(getClusterDeploymentAdminKubeConfigSecretName(cd)))
+	})
+
+	It("fails when neither kubeconfig works", func() {
+		createServiceAccountKubeconfig(1, time.Now().Add(-time.Hour))
+		unreachable[hubKey.Name] = true
+		unreachable[getClusterDeploymentAdminKubeConfigSecretName(cd)] = true
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}})
+		Expect(err).To(HaveOccurred())
+	})
+})
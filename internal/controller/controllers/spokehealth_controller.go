@@ -0,0 +1,210 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/internal/bminventory"
+	"github.com/openshift/assisted-service/internal/spokehealth"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SpokeHealthReconciler collects periodically the health of the installed clusters, for the
+// configured duration after their installation, and reports it in the status of their
+// AgentClusterInstall and in the cluster of the REST API.
+type SpokeHealthReconciler struct {
+	client.Client
+	APIReader        client.Reader
+	Log              logrus.FieldLogger
+	Installer        bminventory.InstallerInternals
+	SpokeClientCache SpokeClientCache
+	Config           spokehealth.Config
+}
+
+//+kubebuilder:rbac:groups=extensions.hive.openshift.io,resources=agentclusterinstalls,verbs=get;list;watch
+//+kubebuilder:rbac:groups=extensions.hive.openshift.io,resources=agentclusterinstalls/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=hive.openshift.io,resources=clusterdeployments,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *SpokeHealthReconciler) Reconcile(origCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx := addRequestIdIfNeeded(origCtx)
+	log := logutil.FromContext(ctx, r.Log).WithFields(
+		logrus.Fields{
+			"agent_cluster_install":           req.Name,
+			"agent_cluster_install_namespace": req.Namespace,
+		})
+
+	defer func() {
+		log.Debug("SpokeHealth Reconcile ended")
+	}()
+
+	log.Debug("SpokeHealth Reconcile started")
+
+	clusterInstall := &hiveext.AgentClusterInstall{}
+	if err := r.Get(ctx, req.NamespacedName, clusterInstall); err != nil {
+		log.WithError(err).Errorf("Failed to get AgentClusterInstall %s", req.NamespacedName)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	completed := FindStatusCondition(clusterInstall.Status.Conditions, hiveext.ClusterCompletedCondition)
+	if completed == nil || completed.Status != corev1.ConditionTrue || completed.Reason != hiveext.ClusterInstalledReason {
+		return ctrl.Result{}, nil
+	}
+	if clusterInstall.Status.SpokeHealth != nil && clusterInstall.Status.SpokeHealth.MonitoringEndTime != nil {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := r.Installer.GetClusterByKubeKey(types.NamespacedName{
+		Namespace: clusterInstall.Namespace,
+		Name:      clusterInstall.Spec.ClusterDeploymentRef.Name,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to get the cluster of the AgentClusterInstall")
+		return ctrl.Result{}, err
+	}
+
+	// The health is monitored for the configured duration after the installation completed
+	installedAt := time.Time(cluster.InstallCompletedAt)
+	if installedAt.IsZero() {
+		installedAt = completed.LastTransitionTime.Time
+	}
+	endTime := installedAt.Add(r.Config.Duration)
+	now := time.Now()
+	if !now.Before(endTime) {
+		log.Infof("Monitoring of the health of cluster %s ended", cluster.ID.String())
+		if clusterInstall.Status.SpokeHealth == nil {
+			clusterInstall.Status.SpokeHealth = &hiveext.SpokeHealth{Status: models.SpokeHealthStatusUnavailable}
+		}
+		end := metav1.NewTime(endTime)
+		clusterInstall.Status.SpokeHealth.MonitoringEndTime = &end
+		return ctrl.Result{}, r.updateStatus(ctx, log, clusterInstall)
+	}
+
+	// The status updates of this controller trigger reconciles of the AgentClusterInstall, the
+	// health isn't collected again before the interval passed
+	if lastChecked := clusterInstall.Status.SpokeHealth; lastChecked != nil && lastChecked.LastCheckedTime != nil {
+		if next := lastChecked.LastCheckedTime.Add(r.Config.Interval); now.Before(next) {
+			return ctrl.Result{RequeueAfter: min(next.Sub(now), endTime.Sub(now))}, nil
+		}
+	}
+
+	health := r.collect(ctx, log, clusterInstall)
+	if err = r.Installer.UpdateClusterSpokeHealthInternal(ctx, *cluster.ID, health); err != nil {
+		log.WithError(err).Error("failed to store the health of the cluster")
+		return ctrl.Result{}, err
+	}
+
+	setSpokeHealth(clusterInstall, health)
+	if err = r.updateStatus(ctx, log, clusterInstall); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: min(r.Config.Interval, endTime.Sub(now))}, nil
+}
+
+// collect returns the health of the cluster of the AgentClusterInstall, the cluster is unavailable
+// when a client for it can't be created from its admin kubeconfig
+func (r *SpokeHealthReconciler) collect(ctx context.Context, log logrus.FieldLogger, clusterInstall *hiveext.AgentClusterInstall) *models.SpokeHealth {
+	spokeClient, err := r.spokeClient(ctx, log, clusterInstall)
+	if err != nil {
+		return &models.SpokeHealth{
+			CheckedAt: strfmt.DateTime(time.Now()),
+			Status:    models.SpokeHealthStatusUnavailable,
+			Message:   "The cluster can't be reached: " + err.Error(),
+		}
+	}
+	return spokehealth.Collect(ctx, spokeClient)
+}
+
+func (r *SpokeHealthReconciler) spokeClient(ctx context.Context, log logrus.FieldLogger, clusterInstall *hiveext.AgentClusterInstall) (client.Client, error) {
+	clusterDeployment := &hivev1.ClusterDeployment{}
+	cdKey := types.NamespacedName{
+		Namespace: clusterInstall.Namespace,
+		Name:      clusterInstall.Spec.ClusterDeploymentRef.Name,
+	}
+	if err := r.Get(ctx, cdKey, clusterDeployment); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ClusterDeployment %s", cdKey)
+	}
+	secret, err := spokeKubeconfigSecret(ctx, log, r.Client, r.APIReader, &aiv1beta1.ClusterReference{
+		Namespace: cdKey.Namespace,
+		Name:      cdKey.Name,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the admin kubeconfig secret")
+	}
+	return r.SpokeClientCache.Get(clusterDeployment, secret)
+}
+
+func (r *SpokeHealthReconciler) updateStatus(ctx context.Context, log logrus.FieldLogger, clusterInstall *hiveext.AgentClusterInstall) error {
+	if err := r.Status().Update(ctx, clusterInstall); err != nil {
+		log.WithError(err).Error("failed to update the spoke health of the AgentClusterInstall")
+		return err
+	}
+	return nil
+}
+
+// setSpokeHealth reports the collected health in the status and the conditions of the AgentClusterInstall
+func setSpokeHealth(clusterInstall *hiveext.AgentClusterInstall, health *models.SpokeHealth) {
+	checkedAt := metav1.NewTime(time.Time(health.CheckedAt))
+	clusterInstall.Status.SpokeHealth = &hiveext.SpokeHealth{
+		Status:               health.Status,
+		ClusterVersion:       health.ClusterVersion,
+		DegradedOperators:    health.DegradedOperators,
+		UnavailableOperators: health.UnavailableOperators,
+		ReadyNodes:           int(health.ReadyNodes),
+		TotalNodes:           int(health.TotalNodes),
+		NotReadyNodes:        health.NotReadyNodes,
+		LastCheckedTime:      &checkedAt,
+	}
+
+	condition := hivev1.ClusterInstallCondition{
+		Type:    hiveext.ClusterSpokeHealthyCondition,
+		Message: health.Message,
+	}
+	switch health.Status {
+	case models.SpokeHealthStatusHealthy:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = hiveext.ClusterSpokeHealthyReason
+	case models.SpokeHealthStatusDegraded:
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = hiveext.ClusterSpokeDegradedReason
+	default:
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = hiveext.ClusterSpokeUnavailableReason
+	}
+	setClusterCondition(&clusterInstall.Status.Conditions, condition)
+}
+
+func (r *SpokeHealthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("spoke-health-controller").
+		For(&hiveext.AgentClusterInstall{}).
+		Complete(r)
+}
@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	"github.com/openshift/assisted-service/internal/bminventory"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/internal/spokehealth"
+	"github.com/openshift/assisted-service/models"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/pkg/errors"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("SpokeHealth reconcile", func() {
+	var (
+		c                     client.Client
+		r                     *SpokeHealthReconciler
+		mockCtrl              *gomock.Controller
+		mockInstallerInternal *bminventory.MockInstallerInternals
+		mockSpokeClientCache  *MockSpokeClientCache
+		ctx                   = context.Background()
+		clusterID             = strfmt.UUID("2a6a4a54-5f0a-4c1b-a1b3-6b6f0a2c1e3d")
+		aci                   *hiveext.AgentClusterInstall
+		cluster               *common.Cluster
+		key                   types.NamespacedName
+	)
+
+	completedCondition := func(status corev1.ConditionStatus, reason string) hivev1.ClusterInstallCondition {
+		return hivev1.ClusterInstallCondition{
+			Type:               hiveext.ClusterCompletedCondition,
+			Status:             status,
+			Reason:             reason,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}
+	}
+
+	createACI := func(conditions ...hivev1.ClusterInstallCondition) {
+		cd := newClusterDeployment("test-cluster", testNamespace, hivev1.ClusterDeploymentSpec{})
+		Expect(c.Create(ctx, cd)).To(Succeed())
+		Expect(c.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: getClusterDeploymentAdminKubeConfigSecretName(cd), Namespace: testNamespace},
+			Data:       map[string][]byte{"kubeconfig": []byte("kubeconfig")},
+		})).To(Succeed())
+		aci = &hiveext.AgentClusterInstall{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-aci", Namespace: testNamespace},
+			Spec: hiveext.AgentClusterInstallSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: cd.Name},
+			},
+		}
+		Expect(c.Create(ctx, aci)).To(Succeed())
+		aci.Status.Conditions = conditions
+		Expect(c.Status().Update(ctx, aci)).To(Succeed())
+		key = types.NamespacedName{Name: aci.Name, Namespace: aci.Namespace}
+	}
+
+	mockSpokeCluster := func(objects ...client.Object) {
+		spokeClient := fakeclient.NewClientBuilder().WithScheme(spoke_k8s_client.GetKubeClientSchemes()).WithObjects(objects...).Build()
+		mockSpokeClientCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(fakeSpokeK8sClient{Client: spokeClient}, nil)
+	}
+
+	healthyCluster := func() []client.Object {
+		return []client.Object{
+			&configv1.ClusterVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "version"},
+				Status: configv1.ClusterVersionStatus{
+					Desired: configv1.Release{Version: "4.17.0"},
+					Conditions: []configv1.ClusterOperatorStatusCondition{
+						{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+					},
+				},
+			},
+			&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "master-0"},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			},
+		}
+	}
+
+	reconcile := func() (ctrl.Result, *hiveext.AgentClusterInstall) {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		updated := &hiveext.AgentClusterInstall{}
+		Expect(c.Get(ctx, key, updated)).To(Succeed())
+		return result, updated
+	}
+
+	BeforeEach(func() {
+		c = fakeclient.NewClientBuilder().WithScheme(GetKubeClientSchemes()).WithStatusSubresource(&hiveext.AgentClusterInstall{}).Build()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockInstallerInternal = bminventory.NewMockInstallerInternals(mockCtrl)
+		mockSpokeClientCache = NewMockSpokeClientCache(mockCtrl)
+		r = &SpokeHealthReconciler{
+			Client:           c,
+			APIReader:        c,
+			Log:              common.GetTestLog(),
+			Installer:        mockInstallerInternal,
+			SpokeClientCache: mockSpokeClientCache,
+			Config:           spokehealth.Config{Duration: 24 * time.Hour, Interval: 10 * time.Minute},
+		}
+		cluster = &common.Cluster{Cluster: models.Cluster{
+			ID:                 &clusterID,
+			InstallCompletedAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+		}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("ignores a cluster that isn't installed", func() {
+		createACI(completedCondition(corev1.ConditionFalse, hiveext.ClusterInstallationNotStartedReason))
+
+		result, updated := reconcile()
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(updated.Status.SpokeHealth).To(BeNil())
+	})
+
+	It("reports the health of an installed cluster", func() {
+		createACI(completedCondition(corev1.ConditionTrue, hiveext.ClusterInstalledReason))
+		mockInstallerInternal.EXPECT().GetClusterByKubeKey(gomock.Any()).Return(cluster, nil)
+		mockSpokeCluster(healthyCluster()...)
+		mockInstallerInternal.EXPECT().UpdateClusterSpokeHealthInternal(gomock.Any(), clusterID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ strfmt.UUID, health *models.SpokeHealth) error {
+				Expect(health.Status).To(Equal(models.SpokeHealthStatusHealthy))
+				return nil
+			})
+
+		result, updated := reconcile()
+		Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+		Expect(updated.Status.SpokeHealth).ToNot(BeNil())
+		Expect(updated.Status.SpokeHealth.Status).To(Equal(models.SpokeHealthStatusHealthy))
+		Expect(updated.Status.SpokeHealth.ClusterVersion).To(Equal("4.17.0"))
+		Expect(updated.Status.SpokeHealth.TotalNodes).To(Equal(1))
+		Expect(updated.Status.SpokeHealth.LastCheckedTime).ToNot(BeNil())
+		condition := FindStatusCondition(updated.Status.Conditions, hiveext.ClusterSpokeHealthyCondition)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(hiveext.ClusterSpokeHealthyReason))
+	})
+
+	It("reports a degraded cluster", func() {
+		createACI(completedCondition(corev1.ConditionTrue, hiveext.ClusterInstalledReason))
+		mockInstallerInternal.EXPECT().GetClusterByKubeKey(gomock.Any()).Return(cluster, nil)
+		objects := healthyCluster()
+		objects = append(objects, &configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+					{Type: configv1.OperatorDegraded, Status: configv1.ConditionTrue},
+				},
+			},
+		})
+		mockSpokeCluster(objects...)
+		mockInstallerInternal.EXPECT().UpdateClusterSpokeHealthInternal(gomock.Any(), clusterID, gomock.Any()).Return(nil)
+
+		_, updated := reconcile()
+		Expect(updated.Status.SpokeHealth.Status).To(Equal(models.SpokeHealthStatusDegraded))
+		Expect(updated.Status.SpokeHealth.DegradedOperators).To(Equal([]string{"ingress"}))
+		condition := FindStatusCondition(updated.Status.Conditions, hiveext.ClusterSpokeHealthyCondition)
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(hiveext.ClusterSpokeDegradedReason))
+		Expect(condition.Message).To(Equal("degraded cluster operators: ingress"))
+	})
+
+	It("reports a cluster that can't be reached", func() {
+		createACI(completedCondition(corev1.ConditionTrue, hiveext.ClusterInstalledReason))
+		mockInstallerInternal.EXPECT().GetClusterByKubeKey(gomock.Any()).Return(cluster, nil)
+		mockSpokeClientCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("certificate has expired"))
+		mockInstallerInternal.EXPECT().UpdateClusterSpokeHealthInternal(gomock.Any(), clusterID, gomock.Any()).Return(nil)
+
+		_, updated := reconcile()
+		Expect(updated.Status.SpokeHealth.Status).To(Equal(models.SpokeHealthStatusUnavailable))
+		condition := FindStatusCondition(updated.Status.Conditions, hiveext.ClusterSpokeHealthyCondition)
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(hiveext.ClusterSpokeUnavailableReason))
+		Expect(condition.Message).To(ContainSubstring("certificate has expired"))
+	})
+
+	It("doesn't collect the health again before the interval passed", func() {
+		createACI(completedCondition(corev1.ConditionTrue, hiveext.ClusterInstalledReason))
+		checkedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+		aci.Status.SpokeHealth = &hiveext.SpokeHealth{Status: models.SpokeHealthStatusHealthy, LastCheckedTime: &checkedAt}
+		Expect(c.Status().Update(ctx, aci)).To(Succeed())
+		mockInstallerInternal.EXPECT().GetClusterByKubeKey(gomock.Any()).Return(cluster, nil)
+
+		result, _ := reconcile()
+		Expect(result.RequeueAfter).To(BeNumerically("~", 9*time.Minute, time.Second))
+	})
+
+	It("stops the monitoring after the configured duration", func() {
+		createACI(completedCondition(corev1.ConditionTrue, hiveext.ClusterInstalledReason))
+		cluster.InstallCompletedAt = strfmt.DateTime(time.Now().Add(-25 * time.Hour))
+		mockInstallerInternal.EXPECT().GetClusterByKubeKey(gomock.Any()).Return(cluster, nil)
+
+		result, updated := reconcile()
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(updated.Status.SpokeHealth.MonitoringEndTime).ToNot(BeNil())
+
+		result, _ = reconcile()
+		Expect(result).To(Equal(ctrl.Result{}))
+	})
+})
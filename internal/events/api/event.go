@@ -38,6 +38,7 @@ type Sender interface {
 type Handler interface {
 	Sender
 	V2GetEvents(ctx context.Context, params *common.V2GetEventsParams) (*common.V2GetEventsResponse, error)
+	V2AggregateEvents(ctx context.Context, params *common.V2AggregateEventsParams) ([]*models.EventAggregation, error)
 }
 
 var DefaultEventCategories = []string{
@@ -16,6 +16,7 @@ import (
 
 	strfmt "github.com/go-openapi/strfmt"
 	common "github.com/openshift/assisted-service/internal/common"
+	models "github.com/openshift/assisted-service/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -303,6 +304,21 @@ func (mr *MockHandlerMockRecorder) V2AddMetricsEvent(ctx, clusterID, hostID, inf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2AddMetricsEvent", reflect.TypeOf((*MockHandler)(nil).V2AddMetricsEvent), varargs...)
 }
 
+// V2AggregateEvents mocks base method.
+func (m *MockHandler) V2AggregateEvents(ctx context.Context, params *common.V2AggregateEventsParams) ([]*models.EventAggregation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2AggregateEvents", ctx, params)
+	ret0, _ := ret[0].([]*models.EventAggregation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2AggregateEvents indicates an expected call of V2AggregateEvents.
+func (mr *MockHandlerMockRecorder) V2AggregateEvents(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2AggregateEvents", reflect.TypeOf((*MockHandler)(nil).V2AggregateEvents), ctx, params)
+}
+
 // V2GetEvents mocks base method.
 func (m *MockHandler) V2GetEvents(ctx context.Context, params *common.V2GetEventsParams) (*common.V2GetEventsResponse, error) {
 	m.ctrl.T.Helper()
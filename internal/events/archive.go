@@ -0,0 +1,200 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/pkg/s3wrapper"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	archivePrefix          = "events-archive"
+	archiveClustersDir     = "clusters"
+	archiveInfraEnvsDir    = "infra-envs"
+	archiveUnboundDir      = "unbound"
+	archiveObjectExtension = ".jsonl.gz"
+)
+
+// Archive stores events that are removed from the database by the retention policy in the
+// object storage, as gzip compressed JSON lines. The objects are grouped by the resource
+// that owns the events, so that the events of a given cluster or infra-env can be read back
+// without scanning the whole archive:
+//
+//	events-archive/clusters/<cluster-id>/<day>-<first-id>-<source>.jsonl.gz
+//	events-archive/infra-envs/<infra-env-id>/<day>-<first-id>-<source>.jsonl.gz
+//	events-archive/unbound/<day>-<first-id>-<source>.jsonl.gz
+//
+// The names only depend on the archived events, so the objects sort in chronological order and
+// archiving the same events again replaces the objects instead of duplicating the events.
+type Archive struct {
+	objectHandler s3wrapper.API
+	log           logrus.FieldLogger
+}
+
+func NewArchive(objectHandler s3wrapper.API, log logrus.FieldLogger) *Archive {
+	return &Archive{
+		objectHandler: objectHandler,
+		log:           log,
+	}
+}
+
+// SetObjectHandler sets the object storage used by the archive. The main program can't
+// create the storage client before the events handler, so it is injected after the fact.
+func (a *Archive) SetObjectHandler(objectHandler s3wrapper.API) {
+	a.objectHandler = objectHandler
+}
+
+func (a *Archive) enabled() bool {
+	return a != nil && a.objectHandler != nil
+}
+
+func archiveScope(event *common.Event) string {
+	switch {
+	case event.ClusterID != nil:
+		return path.Join(archivePrefix, archiveClustersDir, event.ClusterID.String())
+	case event.InfraEnvID != nil:
+		return path.Join(archivePrefix, archiveInfraEnvsDir, event.InfraEnvID.String())
+	default:
+		return path.Join(archivePrefix, archiveUnboundDir)
+	}
+}
+
+// archiveBatchName names the objects of a batch of events after the day of its oldest event, its
+// first event and the table or partition that the events are read from
+func archiveBatchName(source string, events []*common.Event) string {
+	oldest := time.Now()
+	for _, event := range events {
+		if event.EventTime != nil && time.Time(*event.EventTime).Before(oldest) {
+			oldest = time.Time(*event.EventTime)
+		}
+	}
+	return fmt.Sprintf("%s-%020d-%s", oldest.UTC().Format(time.DateOnly), events[0].ID, source)
+}
+
+// Write uploads the given events, read from the given table or partition, to the archive
+func (a *Archive) Write(ctx context.Context, source string, events []*common.Event) error {
+	if !a.enabled() {
+		return fmt.Errorf("events archive object storage is not configured")
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	byScope := map[string][]*common.Event{}
+	for _, event := range events {
+		scope := archiveScope(event)
+		byScope[scope] = append(byScope[scope], event)
+	}
+	batch := archiveBatchName(source, events)
+	for scope, scoped := range byScope {
+		data, err := encodeArchivedEvents(scoped)
+		if err != nil {
+			return err
+		}
+		objectName := path.Join(scope, batch+archiveObjectExtension)
+		if err = a.objectHandler.Upload(ctx, data, objectName); err != nil {
+			return fmt.Errorf("failed to upload archived events to %s: %w", objectName, err)
+		}
+		a.log.Debugf("Archived %d events to %s", len(scoped), objectName)
+	}
+	return nil
+}
+
+// ScanCluster calls visit with the archived events of the given cluster, see scan
+func (a *Archive) ScanCluster(ctx context.Context, clusterID strfmt.UUID, descending bool, visit func(*common.Event)) error {
+	return a.scan(ctx, path.Join(archivePrefix, archiveClustersDir, clusterID.String())+"/", descending, visit)
+}
+
+// ScanInfraEnv calls visit with the archived events of the given infra-env that are not bound to a cluster, see scan
+func (a *Archive) ScanInfraEnv(ctx context.Context, infraEnvID strfmt.UUID, descending bool, visit func(*common.Event)) error {
+	return a.scan(ctx, path.Join(archivePrefix, archiveInfraEnvsDir, infraEnvID.String())+"/", descending, visit)
+}
+
+// scan calls visit with the archived events under the given prefix, in chronological order or in
+// reverse. The objects are downloaded one at a time, so only one of them is held in memory.
+func (a *Archive) scan(ctx context.Context, prefix string, descending bool, visit func(*common.Event)) error {
+	if !a.enabled() {
+		return nil
+	}
+	objectNames, err := a.objectHandler.ListObjectsByPrefix(ctx, prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list archived events with prefix %s: %w", prefix, err)
+	}
+	sort.Strings(objectNames)
+	if descending {
+		slices.Reverse(objectNames)
+	}
+	for _, objectName := range objectNames {
+		if !strings.HasSuffix(objectName, archiveObjectExtension) {
+			continue
+		}
+		objectEvents, err := a.readObject(ctx, objectName)
+		if err != nil {
+			return err
+		}
+		if descending {
+			slices.Reverse(objectEvents)
+		}
+		for _, event := range objectEvents {
+			visit(event)
+		}
+	}
+	return nil
+}
+
+func (a *Archive) readObject(ctx context.Context, objectName string) ([]*common.Event, error) {
+	reader, _, err := a.objectHandler.Download(ctx, objectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archived events %s: %w", objectName, err)
+	}
+	defer reader.Close()
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archived events %s: %w", objectName, err)
+	}
+	defer gz.Close()
+	events := make([]*common.Event, 0)
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		event := &common.Event{}
+		if err = json.Unmarshal(line, &event.Event); err != nil {
+			return nil, fmt.Errorf("failed to parse archived event in %s: %w", objectName, err)
+		}
+		events = append(events, event)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read archived events %s: %w", objectName, err)
+	}
+	return events, nil
+}
+
+func encodeArchivedEvents(events []*common.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, event := range events {
+		if err := encoder.Encode(&event.Event); err != nil {
+			return nil, fmt.Errorf("failed to encode archived event: %w", err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archived events: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,161 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/s3wrapper"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Events archive", func() {
+	var (
+		ctrl          *gomock.Controller
+		mockS3Client  *s3wrapper.MockAPI
+		archive       *Archive
+		objects       map[string][]byte
+		clusterID     strfmt.UUID
+		infraEnvID    strfmt.UUID
+		archivedEvent = func(message string, clusterID, infraEnvID *strfmt.UUID) *common.Event {
+			eventTime := strfmt.DateTime(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC))
+			return &common.Event{Model: gorm.Model{ID: 42}, Event: models.Event{
+				Message:    swag.String(message),
+				Severity:   swag.String(models.EventSeverityInfo),
+				Category:   models.EventCategoryUser,
+				EventTime:  &eventTime,
+				ClusterID:  clusterID,
+				InfraEnvID: infraEnvID,
+			}}
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockS3Client = s3wrapper.NewMockAPI(ctrl)
+		archive = NewArchive(mockS3Client, logrus.New())
+		objects = map[string][]byte{}
+		clusterID = strfmt.UUID("2f2e3e8a-3f8c-4e54-9b0e-1f0d4a3c5e61")
+		infraEnvID = strfmt.UUID("8b7e1c0a-5d34-4c0b-a4c6-56a0b1f2e3d4")
+
+		mockS3Client.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, data []byte, objectName string) error {
+				objects[objectName] = data
+				return nil
+			}).AnyTimes()
+		mockS3Client.EXPECT().ListObjectsByPrefix(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, prefix string) ([]string, error) {
+				var names []string
+				for name := range objects {
+					if strings.HasPrefix(name, prefix) {
+						names = append(names, name)
+					}
+				}
+				return names, nil
+			}).AnyTimes()
+		mockS3Client.EXPECT().Download(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, objectName string) (io.ReadCloser, int64, error) {
+				data := objects[objectName]
+				return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+			}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	scanMessages := func(scan func(visit func(*common.Event)) error) []string {
+		messages := []string{}
+		Expect(scan(func(event *common.Event) {
+			messages = append(messages, *event.Message)
+		})).To(Succeed())
+		return messages
+	}
+
+	It("groups the archived events by cluster and infra-env", func() {
+		Expect(archive.Write(context.Background(), "events_p20240102", []*common.Event{
+			archivedEvent("cluster event", &clusterID, nil),
+			archivedEvent("bound host event", &clusterID, &infraEnvID),
+			archivedEvent("infra-env event", nil, &infraEnvID),
+			archivedEvent("unbound event", nil, nil),
+		})).To(Succeed())
+		Expect(objects).To(HaveLen(3))
+		for name := range objects {
+			Expect(name).To(HavePrefix(archivePrefix + "/"))
+			Expect(name).To(HaveSuffix("/2024-01-02-00000000000000000042-events_p20240102" + archiveObjectExtension))
+		}
+
+		Expect(scanMessages(func(visit func(*common.Event)) error {
+			return archive.ScanCluster(context.Background(), clusterID, false, visit)
+		})).To(Equal([]string{"cluster event", "bound host event"}))
+		Expect(scanMessages(func(visit func(*common.Event)) error {
+			return archive.ScanInfraEnv(context.Background(), infraEnvID, false, visit)
+		})).To(Equal([]string{"infra-env event"}))
+	})
+
+	It("replaces the objects when the same events are archived again", func() {
+		batch := []*common.Event{archivedEvent("cluster event", &clusterID, nil)}
+		Expect(archive.Write(context.Background(), "events_p20240102", batch)).To(Succeed())
+		Expect(archive.Write(context.Background(), "events_p20240102", batch)).To(Succeed())
+		Expect(objects).To(HaveLen(1))
+		Expect(scanMessages(func(visit func(*common.Event)) error {
+			return archive.ScanCluster(context.Background(), clusterID, false, visit)
+		})).To(Equal([]string{"cluster event"}))
+	})
+
+	It("scans the archived events in chronological order or in reverse", func() {
+		first := archivedEvent("first", &clusterID, nil)
+		second := archivedEvent("second", &clusterID, nil)
+		second.ID = 43
+		third := archivedEvent("third", &clusterID, nil)
+		third.ID = 44
+		Expect(archive.Write(context.Background(), "events", []*common.Event{third})).To(Succeed())
+		Expect(archive.Write(context.Background(), "events", []*common.Event{first, second})).To(Succeed())
+
+		Expect(scanMessages(func(visit func(*common.Event)) error {
+			return archive.ScanCluster(context.Background(), clusterID, false, visit)
+		})).To(Equal([]string{"first", "second", "third"}))
+		Expect(scanMessages(func(visit func(*common.Event)) error {
+			return archive.ScanCluster(context.Background(), clusterID, true, visit)
+		})).To(Equal([]string{"third", "second", "first"}))
+	})
+
+	It("returns no events when nothing was archived", func() {
+		Expect(scanMessages(func(visit func(*common.Event)) error {
+			return archive.ScanCluster(context.Background(), clusterID, false, visit)
+		})).To(BeEmpty())
+	})
+
+	It("fails to write when the object storage is not configured", func() {
+		Expect(NewArchive(nil, logrus.New()).Write(context.Background(), "events",
+			[]*common.Event{archivedEvent("cluster event", &clusterID, nil)})).ToNot(Succeed())
+	})
+})
+
+var _ = DescribeTable("Parse events partition upper bound",
+	func(bound string, expected time.Time, valid bool) {
+		upperBound, err := parsePartitionUpperBound(bound)
+		if !valid {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upperBound.Equal(expected)).To(BeTrue())
+	},
+	Entry("daily partition", "FOR VALUES FROM ('2024-01-02 00:00:00+00') TO ('2024-01-03 00:00:00+00')",
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), true),
+	Entry("legacy partition", "FOR VALUES FROM (MINVALUE) TO ('2024-01-03 00:00:00+00')",
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), true),
+	Entry("invalid bound", "FOR VALUES IN (1)", time.Time{}, false),
+)
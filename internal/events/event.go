@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -19,7 +20,9 @@ import (
 	"github.com/openshift/assisted-service/pkg/auth"
 	logutil "github.com/openshift/assisted-service/pkg/log"
 	"github.com/openshift/assisted-service/pkg/requestid"
+	"github.com/openshift/assisted-service/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -31,18 +34,39 @@ var DefaultEventCategories = []string{
 var defaultEventLimit int64 = 5000
 
 type Events struct {
-	db     *gorm.DB
-	log    logrus.FieldLogger
-	authz  auth.Authorizer
-	stream stream.Notifier
+	db          *gorm.DB
+	log         logrus.FieldLogger
+	authz       auth.Authorizer
+	stream      stream.Notifier
+	archive     *Archive
+	rateLimiter *RateLimiter
+}
+
+// Options contains the optional components of the events handler
+type Options struct {
+	// Archive is used to return the events that were moved to the archive by the retention policy
+	Archive *Archive
+	// RateLimiter decides which events are discarded, when nil only the static limits are applied
+	RateLimiter *RateLimiter
 }
 
 func New(db *gorm.DB, authz auth.Authorizer, stream stream.Notifier, log logrus.FieldLogger) eventsapi.Handler {
+	return NewWithOptions(db, authz, stream, Options{}, log)
+}
+
+// NewWithOptions creates an events handler with the given optional components
+func NewWithOptions(db *gorm.DB, authz auth.Authorizer, stream stream.Notifier, options Options, log logrus.FieldLogger) eventsapi.Handler {
+	rateLimiter := options.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = NewRateLimiter(RateLimitsConfig{}, db, log)
+	}
 	return &Events{
-		db:     db,
-		log:    log,
-		authz:  authz,
-		stream: stream,
+		db:          db,
+		log:         log,
+		authz:       authz,
+		stream:      stream,
+		archive:     options.Archive,
+		rateLimiter: rateLimiter,
 	}
 }
 
@@ -64,6 +88,7 @@ func (e *Events) v2SaveEvent(ctx context.Context, clusterID *strfmt.UUID, hostID
 			Message:   &message,
 			RequestID: rid,
 			Props:     additionalProps,
+			TraceID:   tracing.TraceIDFromContext(ctx),
 		},
 	}
 	if clusterID != nil {
@@ -108,42 +133,58 @@ func (e *Events) v2SaveEvent(ctx context.Context, clusterID *strfmt.UUID, hostID
 	}
 }
 
-// exceedsLimit checks if there are already events that are too close to the given one. It returns
-// a boolean flag with the result and a set of log fields that explain why the limit was exceeded.
+// exceedsLimit checks if the given event has to be discarded according to the rate limit policy of its
+// name: if there are already events that are too close to it, if it isn't part of the sample or if the
+// resource that sends it exceeded its rate. It returns a boolean flag with the result and a set of log
+// fields that explain why the limit was exceeded.
 func (e *Events) exceedsLimits(ctx context.Context, tx *gorm.DB, event *common.Event) (result bool,
 	reason logrus.Fields, err error) {
 	// Do nothing if there is no configured limit:
-	limit, ok := eventLimits[event.Name]
+	policy, ok := e.rateLimiter.policy(event.Name)
 	if !ok {
 		return
 	}
 
-	// Prepare the query to find the events whose distance to this one is less than the limit:
-	query := tx.Table("events").
-		Select("count(*)").
-		Where("name = ?", event.Name).
-		Where("event_time > ?", time.Now().Add(-limit))
-	if event.ClusterID != nil {
-		query = query.Where("cluster_id = ?", event.ClusterID.String())
-	}
-	if event.HostID != nil {
-		query = query.Where("host_id = ?", event.HostID.String())
-	}
-	if event.InfraEnvID != nil {
-		query = query.Where("infra_env_id = ?", event.InfraEnvID.String())
-	}
+	if policy.interval > 0 {
+		// Prepare the query to find the events whose distance to this one is less than the limit:
+		query := tx.Table("events").
+			Select("count(*)").
+			Where("name = ?", event.Name).
+			Where("event_time > ?", time.Now().Add(-policy.interval))
+		if event.ClusterID != nil {
+			query = query.Where("cluster_id = ?", event.ClusterID.String())
+		}
+		if event.HostID != nil {
+			query = query.Where("host_id = ?", event.HostID.String())
+		}
+		if event.InfraEnvID != nil {
+			query = query.Where("infra_env_id = ?", event.InfraEnvID.String())
+		}
 
-	// Run the query:
-	var count int
-	err = query.Scan(&count).Error
-	if err != nil {
-		return
+		// Run the query:
+		var count int
+		err = query.Scan(&count).Error
+		if err != nil {
+			return
+		}
+		if count > 0 {
+			result = true
+			reason = logrus.Fields{
+				"reason": discardReasonInterval,
+				"limit":  policy.interval,
+				"count":  count,
+			}
+			return
+		}
 	}
-	if count > 0 {
+
+	if discardReason := e.rateLimiter.allow(event, policy); discardReason != "" {
 		result = true
 		reason = logrus.Fields{
-			"limit": limit,
-			"count": count,
+			"reason":      discardReason,
+			"per_minute":  policy.perMinute,
+			"burst":       policy.burst,
+			"sample_rate": policy.sampleRate,
 		}
 	}
 	return
@@ -182,37 +223,46 @@ func (e *Events) reportDiscarded(ctx context.Context, event *common.Event,
 		fields[name] = value
 	}
 	log.WithFields(fields).Warn("Event will be discarded")
+	if discardReason, ok := reason["reason"].(string); ok {
+		e.rateLimiter.reportDiscarded(event.Name, discardReason)
+	}
 }
 
 // eventLimits contains the minimum distance in time between events. The key of the map is the
 // event name and the value is the distance.
 var eventLimits = map[string]time.Duration{
-	commonevents.UpgradeAgentFailedEventName:   time.Hour,
-	commonevents.UpgradeAgentFinishedEventName: time.Hour,
-	commonevents.UpgradeAgentStartedEventName:  time.Hour,
+	commonevents.UpgradeAgentFailedEventName:       time.Hour,
+	commonevents.UpgradeAgentFinishedEventName:     time.Hour,
+	commonevents.UpgradeAgentStartedEventName:      time.Hour,
+	commonevents.AgentTokenRotationFailedEventName: time.Hour,
 }
 
+// eventRatePolicies contains the limits configured in EVENT_RATE_LIMITS. The minimum distance in time
+// between events is kept in eventLimits, so that limits without an interval keep the default one.
+var eventRatePolicies = map[string]ratePolicy{}
+
 // InitializeEventLimits parses the EVENT_RATE_LIMITS JSON and merges custom limits with hardcoded defaults.
 // Custom limits override defaults. Returns an error if JSON is invalid or duration format is incorrect.
-// Format: {"event_name": "duration"}, e.g., {"upgrade_agent_failed": "2h", "infra_env_deregister_failed": "30m"}
+// Format: {"event_name": "duration"}, e.g., {"upgrade_agent_failed": "2h", "infra_env_deregister_failed": "30m"},
+// or {"event_name": {"interval": "duration", "per_minute": rate, "burst": burst, "sample_rate": fraction}}.
 func InitializeEventLimits(eventRateLimitsJSON string, log logrus.FieldLogger) error {
 	if eventRateLimitsJSON == "" {
 		logCurrentLimits(log)
 		return nil
 	}
 
-	var customLimits map[string]string
-	if err := json.Unmarshal([]byte(eventRateLimitsJSON), &customLimits); err != nil {
-		return fmt.Errorf("failed to parse EVENT_RATE_LIMITS json %s: %w", eventRateLimitsJSON, err)
+	customLimits, withInterval, err := parseEventRateLimits([]byte(eventRateLimitsJSON), "EVENT_RATE_LIMITS",
+		models.EventRateLimitSourceConfig)
+	if err != nil {
+		return err
 	}
 
 	// Merge custom limits into existing eventLimits (overriding defaults)
-	for eventName, durationStr := range customLimits {
-		duration, err := time.ParseDuration(durationStr)
-		if err != nil {
-			return fmt.Errorf("invalid duration for event '%s' in EVENT_RATE_LIMITS: %s: %w", eventName, durationStr, err)
+	for eventName, policy := range customLimits {
+		if withInterval[eventName] {
+			eventLimits[eventName] = policy.interval
 		}
-		eventLimits[eventName] = duration
+		eventRatePolicies[eventName] = policy
 	}
 
 	logCurrentLimits(log)
@@ -474,6 +524,10 @@ func (e Events) queryEvents(ctx context.Context, params *common.V2GetEventsParam
 	}
 
 	tx = filterEvents(tx, params.ClusterID, params.HostIds, params.InfraEnvID, params.Severities, params.Message, params.DeletedHosts, params.ClusterLevel, cleanQuery)
+	tx, err := filterEventsBySearch(tx, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	eventSeverityCount, err := countEventsBySeverity(tx.Session(&gorm.Session{}), params.ClusterID)
 	if err != nil {
@@ -496,16 +550,25 @@ func (e Events) queryEvents(ctx context.Context, params *common.V2GetEventsParam
 		return nil, nil, nil, err
 	}
 
-	tx.Order(clause.OrderByColumn{
-		Column: clause.Column{Name: "event_time"},
-		Desc:   *isDescending,
-	})
+	// The ID breaks the ties between events with the same time, so that cursors are stable
+	tx.Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: "event_time"}, Desc: *isDescending},
+		{Column: clause.Column{Table: "events", Name: "id"}, Desc: *isDescending},
+	}})
 
 	params.Limit, params.Offset = preparePaginationParams(params.Limit, params.Offset)
 	if *params.Limit == 0 {
 		return make([]*common.Event, 0), eventSeverityCount, &eventCount, nil
 	}
 
+	// The cursor replaces the offset, the count of events still covers all the pages
+	if params.Cursor != nil {
+		if tx, err = filterEventsByCursor(tx, params.Cursor, *isDescending); err != nil {
+			return nil, nil, nil, err
+		}
+		params.Offset = common.NoOffsetEvents
+	}
+
 	if e.authz != nil && !e.authz.IsAdmin(ctx) {
 		tx = e.authz.OwnedBy(ctx, cleanQuery.Table("(?) as s", tx))
 	}
@@ -518,11 +581,26 @@ func (e Events) queryEvents(ctx context.Context, params *common.V2GetEventsParam
 	return events, eventSeverityCount, &eventCount, nil
 }
 
+// nextEventsCursor returns the cursor of the page that follows the given one, or an empty string if
+// the page isn't full, so there are no more events
+func nextEventsCursor(events []*common.Event, limit *int64) string {
+	if limit == nil || *limit <= 0 || int64(len(events)) < *limit {
+		return ""
+	}
+	return encodeEventsCursor(events[len(events)-1])
+}
+
 func (e Events) V2GetEvents(ctx context.Context, params *common.V2GetEventsParams) (*common.V2GetEventsResponse, error) {
 	//initialize the selectedCategories either from the filter, if exists, or from the default values
 	if len(params.Categories) == 0 {
 		params.Categories = append(params.Categories, DefaultEventCategories...)
 	}
+	if swag.BoolValue(params.IncludeArchived) && e.archive.enabled() {
+		if params.Cursor != nil {
+			return nil, common.NewApiError(http.StatusBadRequest, errors.New("cursor can't be used together with include_archived"))
+		}
+		return e.getEventsWithArchive(ctx, params)
+	}
 	events, eventSeverityCount, eventCount, err := e.queryEvents(ctx, params)
 	if err != nil {
 		return nil, err
@@ -531,9 +609,187 @@ func (e Events) V2GetEvents(ctx context.Context, params *common.V2GetEventsParam
 		Events:             events,
 		EventSeverityCount: eventSeverityCount,
 		EventCount:         eventCount,
+		NextCursor:         nextEventsCursor(events, params.Limit),
 	}, nil
 }
 
+// getEventsWithArchive returns the events stored in the database together with the events that were
+// moved to the archive by the retention policy. The archived events are older than the events in the
+// database, so they come first in ascending order and last in descending order. The archive is
+// streamed and only the archived events of the requested page are kept in memory.
+func (e Events) getEventsWithArchive(ctx context.Context, params *common.V2GetEventsParams) (*common.V2GetEventsResponse, error) {
+	limit, offset := preparePaginationParams(params.Limit, params.Offset)
+	descending, err := isDescending(params.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events             []*common.Event
+		archived           []*common.Event
+		eventSeverityCount *common.EventSeverityCount
+		eventCount         *int64
+		archivedCount      int64
+		archivedSeverity   common.EventSeverityCount
+	)
+	// remaining returns the limit of the second source, once the first one filled part of the page
+	remaining := func(taken int) int64 {
+		if *limit < 0 {
+			return *limit
+		}
+		return *limit - int64(taken)
+	}
+	if *descending {
+		if events, eventSeverityCount, eventCount, err = e.queryEvents(ctx, e.pageParams(params, *offset, *limit)); err != nil {
+			return nil, err
+		}
+		archived, archivedCount, archivedSeverity, err = e.queryArchivedEvents(ctx, params, max(*offset-*eventCount, 0), remaining(len(events)), true)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, archived...)
+	} else {
+		if archived, archivedCount, archivedSeverity, err = e.queryArchivedEvents(ctx, params, *offset, *limit, false); err != nil {
+			return nil, err
+		}
+		events, eventSeverityCount, eventCount, err = e.queryEvents(ctx, e.pageParams(params, max(*offset-archivedCount, 0), remaining(len(archived))))
+		if err != nil {
+			return nil, err
+		}
+		events = append(archived, events...)
+	}
+
+	for severity, count := range archivedSeverity {
+		(*eventSeverityCount)[severity] += count
+	}
+	total := *eventCount + archivedCount
+	return &common.V2GetEventsResponse{
+		Events:             events,
+		EventSeverityCount: eventSeverityCount,
+		EventCount:         &total,
+	}, nil
+}
+
+func eventTime(event *common.Event) time.Time {
+	if event.EventTime == nil {
+		return time.Time{}
+	}
+	return time.Time(*event.EventTime)
+}
+
+// pageParams returns a copy of the given parameters for the given page
+func (e Events) pageParams(params *common.V2GetEventsParams, offset, limit int64) *common.V2GetEventsParams {
+	pageParams := *params
+	pageParams.Offset = swag.Int64(offset)
+	pageParams.Limit = swag.Int64(limit)
+	return &pageParams
+}
+
+// queryArchivedEvents scans the archived events of the requested cluster or infra-env, applies the same
+// filters as queryEvents and keeps the events of the given page. It also returns the number of archived
+// events that match, and their count by severity, computed before filtering by severity, the same way as
+// for the events stored in the database.
+func (e Events) queryArchivedEvents(ctx context.Context, params *common.V2GetEventsParams, offset, limit int64,
+	descending bool) ([]*common.Event, int64, common.EventSeverityCount, error) {
+	severityCount := common.EventSeverityCount{}
+	var (
+		scan func(visit func(*common.Event)) error
+		page = make([]*common.Event, 0)
+	)
+	switch {
+	case params.ClusterID != nil:
+		if allowed, accessErr := e.canReadArchive(ctx, &common.Cluster{}, params.ClusterID.String()); accessErr != nil || !allowed {
+			return page, 0, severityCount, accessErr
+		}
+		scan = func(visit func(*common.Event)) error {
+			return e.archive.ScanCluster(ctx, *params.ClusterID, descending, visit)
+		}
+	case params.InfraEnvID != nil:
+		if allowed, accessErr := e.canReadArchive(ctx, &common.InfraEnv{}, params.InfraEnvID.String()); accessErr != nil || !allowed {
+			return page, 0, severityCount, accessErr
+		}
+		scan = func(visit func(*common.Event)) error {
+			return e.archive.ScanInfraEnv(ctx, *params.InfraEnvID, descending, visit)
+		}
+	default:
+		// The archive is organized by cluster and infra-env, other queries are served from the database only
+		return page, 0, severityCount, nil
+	}
+
+	activeHosts := map[strfmt.UUID]bool{}
+	if params.ClusterID != nil && swag.BoolValue(params.DeletedHosts) {
+		var hostIDs []strfmt.UUID
+		if err := e.db.Model(&common.Host{}).Where("cluster_id = ?", params.ClusterID.String()).Pluck("id", &hostIDs).Error; err != nil {
+			return nil, 0, severityCount, err
+		}
+		for _, hostID := range hostIDs {
+			activeHosts[hostID] = true
+		}
+	}
+
+	var count int64
+	err := scan(func(event *common.Event) {
+		if !matchesArchivedEvent(event, params, activeHosts) {
+			return
+		}
+		if params.ClusterID != nil && event.Severity != nil {
+			severityCount[*event.Severity]++
+		}
+		if params.Severities != nil && (event.Severity == nil || !funk.ContainsString(params.Severities, *event.Severity)) {
+			return
+		}
+		if count >= offset && (limit < 0 || count < offset+limit) {
+			page = append(page, event)
+		}
+		count++
+	})
+	if err != nil {
+		return nil, 0, severityCount, err
+	}
+	return page, count, severityCount, nil
+}
+
+// canReadArchive checks that the current user has access to the resource that owns the archived events.
+// Resources that were already deleted are also considered, as their events may still be archived.
+func (e Events) canReadArchive(ctx context.Context, resource interface{}, id string) (bool, error) {
+	if e.authz == nil || e.authz.IsAdmin(ctx) {
+		return true, nil
+	}
+	var count int64
+	err := e.authz.OwnedBy(ctx, e.db.Unscoped().Model(resource).Where("id = ?", id)).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// matchesArchivedEvent applies to an archived event the filters that filterEvents applies to the database query
+func matchesArchivedEvent(event *common.Event, params *common.V2GetEventsParams, activeHosts map[strfmt.UUID]bool) bool {
+	if !funk.ContainsString(params.Categories, event.Category) {
+		return false
+	}
+	if !matchesArchivedEventSearch(event, params) {
+		return false
+	}
+	if params.ClusterID == nil {
+		return true
+	}
+	if params.Message != nil && (event.Message == nil ||
+		!strings.Contains(strings.ToLower(*event.Message), strings.ToLower(*params.Message))) {
+		return false
+	}
+	if !swag.BoolValue(params.DeletedHosts) && !swag.BoolValue(params.ClusterLevel) && params.HostIds == nil {
+		return true
+	}
+	if swag.BoolValue(params.DeletedHosts) && event.HostID != nil && !activeHosts[*event.HostID] {
+		return true
+	}
+	if swag.BoolValue(params.ClusterLevel) && event.HostID == nil {
+		return true
+	}
+	return event.HostID != nil && funk.Contains(params.HostIds, *event.HostID)
+}
+
 func toProps(attrs ...interface{}) (result string, err error) {
 	props := make(map[string]interface{})
 	length := len(attrs)
@@ -16,20 +16,21 @@ import (
 	"github.com/openshift/assisted-service/restapi/operations/events"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
 )
 
 var _ restapi.EventsAPI = &Api{}
 
 type Api struct {
-	handler eventsapi.Handler
-	log     logrus.FieldLogger
+	handler     eventsapi.Handler
+	rateLimiter *RateLimiter
+	log         logrus.FieldLogger
 }
 
-func NewApi(handler eventsapi.Handler, log logrus.FieldLogger) *Api {
+func NewApi(handler eventsapi.Handler, rateLimiter *RateLimiter, log logrus.FieldLogger) *Api {
 	return &Api{
-		handler: handler,
-		log:     log,
+		handler:     handler,
+		rateLimiter: rateLimiter,
+		log:         log,
 	}
 }
 
@@ -88,17 +89,25 @@ func (a *Api) V2TriggerEvent(ctx context.Context, params events.V2TriggerEventPa
 func (a *Api) V2ListEvents(ctx context.Context, params events.V2ListEventsParams) middleware.Responder {
 	log := logutil.FromContext(ctx, a.log)
 	V2getEventsParams := common.V2GetEventsParams{
-		ClusterID:    params.ClusterID,
-		HostIds:      params.HostIds,
-		InfraEnvID:   params.InfraEnvID,
-		Limit:        params.Limit,
-		Offset:       params.Offset,
-		Order:        params.Order,
-		Severities:   params.Severities,
-		Message:      params.Message,
-		DeletedHosts: params.DeletedHosts,
-		ClusterLevel: params.ClusterLevel,
-		Categories:   params.Categories,
+		ClusterID:       params.ClusterID,
+		HostIds:         params.HostIds,
+		InfraEnvID:      params.InfraEnvID,
+		Limit:           params.Limit,
+		Offset:          params.Offset,
+		Order:           params.Order,
+		Severities:      params.Severities,
+		Message:         params.Message,
+		DeletedHosts:    params.DeletedHosts,
+		ClusterLevel:    params.ClusterLevel,
+		Categories:      params.Categories,
+		IncludeArchived: params.IncludeArchived,
+		Names:           params.Names,
+		StartTime:       params.StartTime,
+		EndTime:         params.EndTime,
+		RequestID:       params.RequestID,
+		Props:           params.Props,
+		Search:          params.Search,
+		Cursor:          params.Cursor,
 	}
 
 	// DEPRECATED
@@ -108,11 +117,8 @@ func (a *Api) V2ListEvents(ctx context.Context, params events.V2ListEventsParams
 
 	response, err := a.handler.V2GetEvents(ctx, &V2getEventsParams)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return common.NewApiError(http.StatusNotFound, err)
-		}
 		log.WithError(err).Errorf("failed to get events")
-		return common.NewApiError(http.StatusInternalServerError, err)
+		return common.GenerateErrorResponder(err)
 	}
 
 	evs := response.GetEvents()
@@ -130,6 +136,7 @@ func (a *Api) V2ListEvents(ctx context.Context, params events.V2ListEventsParams
 			EventTime:  ev.EventTime,
 			Message:    ev.Message,
 			Props:      ev.Props,
+			TraceID:    ev.TraceID,
 		}
 	}
 
@@ -139,5 +146,40 @@ func (a *Api) V2ListEvents(ctx context.Context, params events.V2ListEventsParams
 		WithSeverityCountError((*eventSeverityCount)[models.EventSeverityError]).
 		WithSeverityCountCritical((*eventSeverityCount)[models.EventSeverityCritical]).
 		WithEventCount(*eventCount).
+		WithNextCursor(response.GetNextCursor()).
 		WithPayload(ret)
 }
+
+func (a *Api) V2AggregateEvents(ctx context.Context, params events.V2AggregateEventsParams) middleware.Responder {
+	log := logutil.FromContext(ctx, a.log)
+	aggregations, err := a.handler.V2AggregateEvents(ctx, &common.V2AggregateEventsParams{
+		ClusterID:  params.ClusterID,
+		InfraEnvID: params.InfraEnvID,
+		Names:      params.Names,
+		Severities: params.Severities,
+		Categories: params.Categories,
+		StartTime:  params.StartTime,
+		EndTime:    params.EndTime,
+		Interval:   params.Interval,
+		GroupBy:    params.GroupBy,
+	})
+	if err != nil {
+		log.WithError(err).Errorf("failed to aggregate events")
+		return common.GenerateErrorResponder(err)
+	}
+	return events.NewV2AggregateEventsOK().WithPayload(aggregations)
+}
+
+func (a *Api) V2GetEventRateLimits(ctx context.Context, params events.V2GetEventRateLimitsParams) middleware.Responder {
+	return events.NewV2GetEventRateLimitsOK().WithPayload(a.rateLimiter.Policies())
+}
+
+func (a *Api) V2UpdateEventRateLimits(ctx context.Context, params events.V2UpdateEventRateLimitsParams) middleware.Responder {
+	log := logutil.FromContext(ctx, a.log)
+	if err := a.rateLimiter.UpdateAPIPolicies(ctx, params.RateLimits); err != nil {
+		log.WithError(err).Error("failed to update event rate limits")
+		return common.GenerateErrorResponder(err)
+	}
+	log.Infof("Event rate limits updated, %d limits set through the API", len(params.RateLimits))
+	return events.NewV2UpdateEventRateLimitsOK().WithPayload(a.rateLimiter.Policies())
+}
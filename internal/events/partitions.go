@@ -0,0 +1,115 @@
+package events
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	eventsTableName         = "events"
+	eventsPartitionPrefix   = "events_p"
+	eventsPartitionDayFmt   = "20060102"
+	eventsPartitionBoundFmt = "2006-01-02 00:00:00+00"
+	// eventsPartitionStorage keeps the autovacuum settings of the events table on its partitions, the
+	// partitioned table itself can't hold storage parameters
+	eventsPartitionStorage = "WITH (autovacuum_vacuum_scale_factor = 0.05, autovacuum_analyze_scale_factor = 0.025)"
+)
+
+var partitionUpperBoundRegexp = regexp.MustCompile(`TO \('([^']+)'\)`)
+
+// eventsPartition describes a partition of the events table
+type eventsPartition struct {
+	name string
+	// upperBound is the exclusive upper bound of the event_time values stored in the partition,
+	// it is zero for the default partition
+	upperBound time.Time
+}
+
+func (p *eventsPartition) isDefault() bool {
+	return p.upperBound.IsZero()
+}
+
+func isEventsTablePartitioned(db *gorm.DB) (bool, error) {
+	var count int64
+	err := db.Raw(`SELECT count(*) FROM pg_partitioned_table pt
+		JOIN pg_class c ON c.oid = pt.partrelid
+		WHERE c.relname = ? AND pg_table_is_visible(c.oid)`, eventsTableName).Scan(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check if the events table is partitioned: %w", err)
+	}
+	return count > 0, nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func listEventsPartitions(db *gorm.DB) ([]*eventsPartition, error) {
+	type partitionRow struct {
+		Name  string
+		Bound string
+	}
+	var rows []partitionRow
+	err := db.Raw(`SELECT c.relname AS name, pg_get_expr(c.relpartbound, c.oid) AS bound
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = ? AND pg_table_is_visible(p.oid)`, eventsTableName).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the partitions of the events table: %w", err)
+	}
+	partitions := make([]*eventsPartition, 0, len(rows))
+	for _, row := range rows {
+		partition := &eventsPartition{name: row.Name}
+		if row.Bound != "DEFAULT" {
+			partition.upperBound, err = parsePartitionUpperBound(row.Bound)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the bounds of partition %s: %w", row.Name, err)
+			}
+		}
+		partitions = append(partitions, partition)
+	}
+	return partitions, nil
+}
+
+func parsePartitionUpperBound(bound string) (time.Time, error) {
+	match := partitionUpperBoundRegexp.FindStringSubmatch(bound)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("unexpected partition bound %q", bound)
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05-07", "2006-01-02 15:04:05-07:00", "2006-01-02 15:04:05.999999-07"} {
+		if t, err := time.Parse(layout, match[1]); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unexpected partition bound time %q", match[1])
+}
+
+// createEventsPartitions makes sure that there are daily partitions for the given number of days
+// starting at the given time. Days already covered by an existing partition are skipped.
+func createEventsPartitions(db *gorm.DB, partitions []*eventsPartition, from time.Time, days int) error {
+	day := startOfDay(from)
+	for _, partition := range partitions {
+		if !partition.isDefault() && partition.upperBound.After(day) {
+			day = partition.upperBound.UTC()
+		}
+	}
+	end := startOfDay(from).AddDate(0, 0, days)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		statement := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s%s PARTITION OF events FOR VALUES FROM ('%s') TO ('%s') %s",
+			eventsPartitionPrefix, day.Format(eventsPartitionDayFmt),
+			day.Format(eventsPartitionBoundFmt), day.AddDate(0, 0, 1).Format(eventsPartitionBoundFmt), eventsPartitionStorage)
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to create events partition for %s: %w", day.Format(time.DateOnly), err)
+		}
+	}
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,418 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+)
+
+const (
+	discardReasonInterval = "interval"
+	discardReasonRate     = "rate"
+	discardReasonSampled  = "sampled"
+
+	// bucketIdleTimeout is the time after which the token bucket of a resource that stopped sending
+	// events is removed
+	bucketIdleTimeout = 10 * time.Minute
+)
+
+type RateLimitsConfig struct {
+	// RateLimitsFile is a JSON file, in the EVENT_RATE_LIMITS format, that is reloaded when it changes.
+	// The operator mounts the rate limits of the AgentServiceConfig in this file.
+	RateLimitsFile string `envconfig:"EVENT_RATE_LIMITS_FILE" default:""`
+	// RefreshInterval is how often the rate limits file and the rate limits set through the API are reloaded
+	RefreshInterval time.Duration `envconfig:"EVENT_RATE_LIMITS_REFRESH_INTERVAL" default:"1m"`
+}
+
+// DiscardedEventsReporter is notified about every event discarded by the rate limits
+type DiscardedEventsReporter interface {
+	EventDiscarded(name, reason string)
+}
+
+// ratePolicy is the rate limit applied to the events with a given name
+type ratePolicy struct {
+	// interval is the minimum distance in time between two events of the same resource
+	interval time.Duration
+	// perMinute and burst configure a token bucket per resource, a zero perMinute disables it
+	perMinute float64
+	burst     int
+	// sampleRate is the fraction of the info events that are stored
+	sampleRate float64
+	source     string
+}
+
+func (p ratePolicy) toModel(name string) *models.EventRateLimit {
+	ret := &models.EventRateLimit{
+		EventName:     swag.String(name),
+		RatePerMinute: p.perMinute,
+		Burst:         int64(p.burst),
+		SampleRate:    swag.Float64(p.sampleRate),
+		Source:        p.source,
+	}
+	if p.interval > 0 {
+		ret.Interval = p.interval.String()
+	}
+	return ret
+}
+
+// eventRateLimitConfig is the format of a rate limit, in EVENT_RATE_LIMITS and in the rate limits file,
+// that is not just a minimum interval
+type eventRateLimitConfig struct {
+	Interval   string   `json:"interval,omitempty"`
+	PerMinute  float64  `json:"per_minute,omitempty"`
+	Burst      int      `json:"burst,omitempty"`
+	SampleRate *float64 `json:"sample_rate,omitempty"`
+}
+
+// parseEventRateLimits parses rate limits in the EVENT_RATE_LIMITS format, where the value for each event
+// name is either a duration or an object, e.g. {"upgrade_agent_failed":"2h","host_x":{"per_minute":10,"burst":5}}.
+// The second value returned is the set of names that explicitly configure an interval.
+func parseEventRateLimits(data []byte, origin string, source string) (map[string]ratePolicy, map[string]bool, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s json %s: %w", origin, string(data), err)
+	}
+	policies := make(map[string]ratePolicy, len(raw))
+	withInterval := make(map[string]bool)
+	for eventName, value := range raw {
+		policy := ratePolicy{sampleRate: 1, source: source}
+		config := eventRateLimitConfig{}
+		var durationStr string
+		if err := json.Unmarshal(value, &durationStr); err == nil {
+			config.Interval = durationStr
+		} else if err = json.Unmarshal(value, &config); err != nil {
+			return nil, nil, fmt.Errorf("invalid rate limit for event '%s' in %s: %s: %w", eventName, origin, string(value), err)
+		}
+		if config.Interval != "" {
+			duration, err := time.ParseDuration(config.Interval)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid duration for event '%s' in %s: %s: %w", eventName, origin, config.Interval, err)
+			}
+			policy.interval = duration
+			withInterval[eventName] = true
+		}
+		if config.PerMinute < 0 || config.Burst < 0 {
+			return nil, nil, fmt.Errorf("invalid rate for event '%s' in %s: per_minute and burst must not be negative", eventName, origin)
+		}
+		policy.perMinute = config.PerMinute
+		policy.burst = config.Burst
+		if config.SampleRate != nil {
+			if *config.SampleRate < 0 || *config.SampleRate > 1 {
+				return nil, nil, fmt.Errorf("invalid sample rate for event '%s' in %s: %v is not between 0 and 1", eventName, origin, *config.SampleRate)
+			}
+			policy.sampleRate = *config.SampleRate
+		}
+		policies[eventName] = policy
+	}
+	return policies, withInterval, nil
+}
+
+// policyFromModel converts a rate limit received through the API
+func policyFromModel(limit *models.EventRateLimit) (ratePolicy, error) {
+	policy := ratePolicy{
+		perMinute:  limit.RatePerMinute,
+		burst:      int(limit.Burst),
+		sampleRate: 1,
+		source:     models.EventRateLimitSourceAPI,
+	}
+	if limit.Interval != "" {
+		duration, err := time.ParseDuration(limit.Interval)
+		if err != nil {
+			return policy, errors.Wrapf(err, "invalid interval for event %s", swag.StringValue(limit.EventName))
+		}
+		policy.interval = duration
+	}
+	if policy.perMinute < 0 || policy.burst < 0 {
+		return policy, errors.Errorf("invalid rate for event %s: rate_per_minute and burst must not be negative", swag.StringValue(limit.EventName))
+	}
+	if limit.SampleRate != nil {
+		if *limit.SampleRate < 0 || *limit.SampleRate > 1 {
+			return policy, errors.Errorf("invalid sample rate for event %s: %v is not between 0 and 1", swag.StringValue(limit.EventName), *limit.SampleRate)
+		}
+		policy.sampleRate = *limit.SampleRate
+	}
+	return policy, nil
+}
+
+type bucketKey struct {
+	name       string
+	clusterID  string
+	hostID     string
+	infraEnvID string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter decides which events are stored. The policy of an event name is taken from, in increasing
+// order of precedence, the hardcoded defaults and EVENT_RATE_LIMITS, the rate limits file and the rate
+// limits set through the API.
+type RateLimiter struct {
+	config  RateLimitsConfig
+	db      *gorm.DB
+	log     logrus.FieldLogger
+	metrics DiscardedEventsReporter
+	sample  func() float64
+
+	mu           sync.RWMutex
+	fileContent  []byte
+	filePolicies map[string]ratePolicy
+	apiPolicies  map[string]ratePolicy
+	buckets      map[bucketKey]*bucket
+}
+
+func NewRateLimiter(config RateLimitsConfig, db *gorm.DB, log logrus.FieldLogger) *RateLimiter {
+	l := &RateLimiter{
+		config:  config,
+		db:      db,
+		log:     log,
+		sample:  rand.Float64, // #nosec
+		buckets: make(map[bucketKey]*bucket),
+	}
+	l.reloadFile()
+	return l
+}
+
+// SetMetrics sets the reporter of the discarded events. The metrics manager is created after the
+// events handler, so it can't be passed to the constructor.
+func (l *RateLimiter) SetMetrics(metrics DiscardedEventsReporter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.metrics = metrics
+}
+
+func (l *RateLimiter) policy(name string) (ratePolicy, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if policy, ok := l.apiPolicies[name]; ok {
+		return policy, true
+	}
+	if policy, ok := l.filePolicies[name]; ok {
+		return policy, true
+	}
+	return staticPolicy(name)
+}
+
+// staticPolicy returns the policy configured by the hardcoded defaults and EVENT_RATE_LIMITS
+func staticPolicy(name string) (ratePolicy, bool) {
+	policy, hasPolicy := eventRatePolicies[name]
+	interval, hasInterval := eventLimits[name]
+	if !hasPolicy && !hasInterval {
+		return ratePolicy{}, false
+	}
+	if !hasPolicy {
+		policy = ratePolicy{sampleRate: 1, source: models.EventRateLimitSourceDefault}
+	}
+	policy.interval = interval
+	return policy, true
+}
+
+// allow applies the sampling and the token bucket of the policy to the given event. It returns the
+// reason why the event has to be discarded, or an empty string if it can be stored.
+func (l *RateLimiter) allow(event *common.Event, policy ratePolicy) string {
+	if policy.sampleRate < 1 && swag.StringValue(event.Severity) == models.EventSeverityInfo &&
+		l.sample() >= policy.sampleRate {
+		return discardReasonSampled
+	}
+	if policy.perMinute <= 0 {
+		return ""
+	}
+	key := bucketKey{name: event.Name}
+	if event.ClusterID != nil {
+		key.clusterID = event.ClusterID.String()
+	}
+	if event.HostID != nil {
+		key.hostID = event.HostID.String()
+	}
+	if event.InfraEnvID != nil {
+		key.infraEnvID = event.InfraEnvID.String()
+	}
+	limit := rate.Limit(policy.perMinute / 60)
+	burst := policy.burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(limit, burst)}
+		l.buckets[key] = b
+	} else if b.limiter.Limit() != limit || b.limiter.Burst() != burst {
+		b.limiter.SetLimit(limit)
+		b.limiter.SetBurst(burst)
+	}
+	b.lastSeen = time.Now()
+	if !b.limiter.Allow() {
+		return discardReasonRate
+	}
+	return ""
+}
+
+func (l *RateLimiter) reportDiscarded(name, reason string) {
+	l.mu.RLock()
+	metrics := l.metrics
+	l.mu.RUnlock()
+	if metrics != nil {
+		metrics.EventDiscarded(name, reason)
+	}
+}
+
+// Policies returns the effective rate limits, sorted by event name
+func (l *RateLimiter) Policies() models.EventRateLimits {
+	names := map[string]bool{}
+	for name := range eventLimits {
+		names[name] = true
+	}
+	for name := range eventRatePolicies {
+		names[name] = true
+	}
+	l.mu.RLock()
+	for name := range l.filePolicies {
+		names[name] = true
+	}
+	for name := range l.apiPolicies {
+		names[name] = true
+	}
+	l.mu.RUnlock()
+
+	ret := make(models.EventRateLimits, 0, len(names))
+	for name := range names {
+		if policy, ok := l.policy(name); ok {
+			ret = append(ret, policy.toModel(name))
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return *ret[i].EventName < *ret[j].EventName
+	})
+	return ret
+}
+
+// UpdateAPIPolicies replaces the rate limits set through the API. They are stored in the database so
+// that all the replicas apply them after their next refresh.
+func (l *RateLimiter) UpdateAPIPolicies(ctx context.Context, limits models.EventRateLimits) error {
+	policies := make(map[string]ratePolicy, len(limits))
+	for _, limit := range limits {
+		name := swag.StringValue(limit.EventName)
+		if name == "" {
+			return common.NewApiError(400, errors.New("event name must not be empty"))
+		}
+		if _, ok := policies[name]; ok {
+			return common.NewApiError(400, errors.Errorf("event %s has more than one rate limit", name))
+		}
+		policy, err := policyFromModel(limit)
+		if err != nil {
+			return common.NewApiError(400, err)
+		}
+		policies[name] = policy
+	}
+
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.EventRateLimit{}).Error; err != nil {
+			return err
+		}
+		for name, policy := range policies {
+			if err := tx.Create(policy.toModel(name)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return common.NewApiError(500, errors.Wrap(err, "failed to store the event rate limits"))
+	}
+
+	l.mu.Lock()
+	l.apiPolicies = policies
+	l.mu.Unlock()
+	return nil
+}
+
+// Refresh reloads the rate limits file, when it changed, and the rate limits set through the API, and
+// removes the token buckets of the resources that stopped sending events
+func (l *RateLimiter) Refresh() {
+	l.reloadFile()
+	if err := l.reloadAPIPolicies(); err != nil {
+		l.log.WithError(err).Error("Failed to load the event rate limits set through the API")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, b := range l.buckets {
+		if time.Since(b.lastSeen) > bucketIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *RateLimiter) reloadFile() {
+	if l.config.RateLimitsFile == "" {
+		return
+	}
+	content, err := os.ReadFile(l.config.RateLimitsFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			l.log.WithError(err).Errorf("Failed to read the event rate limits file %s", l.config.RateLimitsFile)
+		}
+		return
+	}
+	l.mu.RLock()
+	unchanged := bytes.Equal(content, l.fileContent)
+	l.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	var policies map[string]ratePolicy
+	if len(bytes.TrimSpace(content)) > 0 {
+		// Keep the previous rate limits if the file is invalid
+		if policies, _, err = parseEventRateLimits(content, l.config.RateLimitsFile, models.EventRateLimitSourceConfig); err != nil {
+			l.log.WithError(err).Error("Failed to parse the event rate limits file")
+			return
+		}
+	}
+	l.mu.Lock()
+	l.fileContent = content
+	l.filePolicies = policies
+	l.mu.Unlock()
+	l.log.Infof("Loaded %d event rate limits from %s", len(policies), l.config.RateLimitsFile)
+}
+
+func (l *RateLimiter) reloadAPIPolicies() error {
+	if l.db == nil {
+		return nil
+	}
+	var limits []*models.EventRateLimit
+	if err := l.db.Find(&limits).Error; err != nil {
+		return err
+	}
+	policies := make(map[string]ratePolicy, len(limits))
+	for _, limit := range limits {
+		policy, err := policyFromModel(limit)
+		if err != nil {
+			return err
+		}
+		policies[swag.StringValue(limit.EventName)] = policy
+	}
+	l.mu.Lock()
+	l.apiPolicies = policies
+	l.mu.Unlock()
+	return nil
+}
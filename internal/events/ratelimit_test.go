@@ -0,0 +1,291 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	commontesting "github.com/openshift/assisted-service/internal/common/testing"
+	"github.com/openshift/assisted-service/models"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type discardedEvent struct {
+	name   string
+	reason string
+}
+
+type fakeDiscardedEventsReporter struct {
+	discarded []discardedEvent
+}
+
+func (f *fakeDiscardedEventsReporter) EventDiscarded(name, reason string) {
+	f.discarded = append(f.discarded, discardedEvent{name: name, reason: reason})
+}
+
+var _ = Describe("parseEventRateLimits", func() {
+	It("accepts durations and objects", func() {
+		policies, withInterval, err := parseEventRateLimits(
+			[]byte(`{"e1":"5m","e2":{"per_minute":10,"burst":5},"e3":{"interval":"1h","sample_rate":0.25}}`),
+			"EVENT_RATE_LIMITS", models.EventRateLimitSourceConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policies).To(HaveLen(3))
+		Expect(policies["e1"].interval).To(Equal(5 * time.Minute))
+		Expect(policies["e1"].sampleRate).To(Equal(1.0))
+		Expect(policies["e2"].interval).To(BeZero())
+		Expect(policies["e2"].perMinute).To(Equal(10.0))
+		Expect(policies["e2"].burst).To(Equal(5))
+		Expect(policies["e3"].interval).To(Equal(time.Hour))
+		Expect(policies["e3"].sampleRate).To(Equal(0.25))
+		Expect(withInterval).To(Equal(map[string]bool{"e1": true, "e3": true}))
+	})
+
+	It("rejects an invalid sample rate", func() {
+		_, _, err := parseEventRateLimits([]byte(`{"e1":{"sample_rate":2}}`), "EVENT_RATE_LIMITS", models.EventRateLimitSourceConfig)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid sample rate for event 'e1'"))
+	})
+
+	It("rejects a negative rate", func() {
+		_, _, err := parseEventRateLimits([]byte(`{"e1":{"per_minute":-1}}`), "EVENT_RATE_LIMITS", models.EventRateLimitSourceConfig)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid rate for event 'e1'"))
+	})
+
+	It("rejects a value that is neither a duration nor an object", func() {
+		_, _, err := parseEventRateLimits([]byte(`{"e1":5}`), "EVENT_RATE_LIMITS", models.EventRateLimitSourceConfig)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid rate limit for event 'e1'"))
+	})
+})
+
+var _ = Describe("policyFromModel", func() {
+	It("keeps a sample rate of 0", func() {
+		policy, err := policyFromModel(&models.EventRateLimit{EventName: swag.String("e1"), SampleRate: swag.Float64(0)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy.sampleRate).To(BeZero())
+	})
+
+	It("defaults to keeping all the events", func() {
+		policy, err := policyFromModel(&models.EventRateLimit{EventName: swag.String("e1")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy.sampleRate).To(Equal(1.0))
+	})
+
+	It("rejects a sample rate out of range", func() {
+		_, err := policyFromModel(&models.EventRateLimit{EventName: swag.String("e1"), SampleRate: swag.Float64(1.5)})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		limiter    *RateLimiter
+		reporter   *fakeDiscardedEventsReporter
+		clusterID  = strfmt.UUID(uuid.New().String())
+		clusterID2 = strfmt.UUID(uuid.New().String())
+	)
+
+	newEvent := func(name, severity string, cluster *strfmt.UUID) *common.Event {
+		return &common.Event{Event: models.Event{Name: name, Severity: swag.String(severity), ClusterID: cluster}}
+	}
+
+	BeforeEach(func() {
+		limiter = NewRateLimiter(RateLimitsConfig{}, nil, logrus.New())
+		reporter = &fakeDiscardedEventsReporter{}
+		limiter.SetMetrics(reporter)
+	})
+
+	It("discards the events that exceed the rate of each resource", func() {
+		policy := ratePolicy{perMinute: 1, burst: 2, sampleRate: 1}
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityInfo, &clusterID), policy)).To(BeEmpty())
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityInfo, &clusterID), policy)).To(BeEmpty())
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityInfo, &clusterID), policy)).To(Equal(discardReasonRate))
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityInfo, &clusterID2), policy)).To(BeEmpty())
+	})
+
+	It("samples only info events", func() {
+		limiter.sample = func() float64 { return 0.5 }
+		policy := ratePolicy{sampleRate: 0.1}
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityInfo, &clusterID), policy)).To(Equal(discardReasonSampled))
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityWarning, &clusterID), policy)).To(BeEmpty())
+		limiter.sample = func() float64 { return 0.05 }
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityInfo, &clusterID), policy)).To(BeEmpty())
+	})
+
+	It("removes idle buckets on refresh", func() {
+		policy := ratePolicy{perMinute: 1, burst: 1, sampleRate: 1}
+		Expect(limiter.allow(newEvent("e1", models.EventSeverityInfo, &clusterID), policy)).To(BeEmpty())
+		Expect(limiter.buckets).To(HaveLen(1))
+		for _, b := range limiter.buckets {
+			b.lastSeen = time.Now().Add(-2 * bucketIdleTimeout)
+		}
+		limiter.Refresh()
+		Expect(limiter.buckets).To(BeEmpty())
+	})
+
+	It("reports the discarded events", func() {
+		limiter.reportDiscarded("e1", discardReasonRate)
+		Expect(reporter.discarded).To(Equal([]discardedEvent{{name: "e1", reason: discardReasonRate}}))
+	})
+
+	Context("with a rate limits file", func() {
+		var fileName string
+
+		BeforeEach(func() {
+			fileName = filepath.Join(GinkgoT().TempDir(), "event-rate-limits.json")
+			Expect(os.WriteFile(fileName, []byte(`{"upgrade_agent_failed":{"interval":"5m","per_minute":2}}`), 0600)).To(Succeed())
+			limiter = NewRateLimiter(RateLimitsConfig{RateLimitsFile: fileName}, nil, logrus.New())
+		})
+
+		It("overrides the default limits", func() {
+			policy, ok := limiter.policy("upgrade_agent_failed")
+			Expect(ok).To(BeTrue())
+			Expect(policy.interval).To(Equal(5 * time.Minute))
+			Expect(policy.perMinute).To(Equal(2.0))
+			Expect(policy.source).To(Equal(models.EventRateLimitSourceConfig))
+		})
+
+		It("reloads the file when it changes", func() {
+			Expect(os.WriteFile(fileName, []byte(`{"new_event":"1m"}`), 0600)).To(Succeed())
+			limiter.Refresh()
+			policy, ok := limiter.policy("upgrade_agent_failed")
+			Expect(ok).To(BeTrue())
+			Expect(policy.interval).To(Equal(time.Hour))
+			Expect(policy.source).To(Equal(models.EventRateLimitSourceDefault))
+			policy, ok = limiter.policy("new_event")
+			Expect(ok).To(BeTrue())
+			Expect(policy.interval).To(Equal(time.Minute))
+		})
+
+		It("keeps the previous limits when the file is invalid", func() {
+			Expect(os.WriteFile(fileName, []byte(`{bad json}`), 0600)).To(Succeed())
+			limiter.Refresh()
+			policy, ok := limiter.policy("upgrade_agent_failed")
+			Expect(ok).To(BeTrue())
+			Expect(policy.interval).To(Equal(5 * time.Minute))
+		})
+
+		It("returns the effective limits", func() {
+			limits := limiter.Policies()
+			Expect(limits).ToNot(BeEmpty())
+			for _, limit := range limits {
+				if *limit.EventName == "upgrade_agent_failed" {
+					Expect(limit.Interval).To(Equal("5m0s"))
+					Expect(limit.RatePerMinute).To(Equal(2.0))
+					Expect(limit.Source).To(Equal(models.EventRateLimitSourceConfig))
+				} else {
+					Expect(limit.Source).To(Equal(models.EventRateLimitSourceDefault))
+				}
+			}
+		})
+	})
+})
+
+var _ = Describe("Event rate limits set through the API", func() {
+	var (
+		ctrl      *gomock.Controller
+		db        *gorm.DB
+		dbName    string
+		limiter   *RateLimiter
+		theEvents *Events
+		clusterID = strfmt.UUID(uuid.New().String())
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		ctrl = gomock.NewController(GinkgoT())
+		limiter = NewRateLimiter(RateLimitsConfig{}, db, logrus.New())
+		theEvents = NewWithOptions(db, nil, commontesting.GetDummyNotificationStream(ctrl),
+			Options{RateLimiter: limiter}, logrus.New()).(*Events)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	countEvents := func(name string) int64 {
+		var count int64
+		Expect(db.Model(&common.Event{}).Where("name = ?", name).Count(&count).Error).ToNot(HaveOccurred())
+		return count
+	}
+
+	It("applies the limits to the new events", func() {
+		Expect(limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+			{EventName: swag.String("e1"), RatePerMinute: 1, Burst: 1},
+		})).To(Succeed())
+		for i := 0; i < 3; i++ {
+			theEvents.V2AddEvent(context.TODO(), &clusterID, nil, nil, "e1", models.EventSeverityInfo, "message", time.Now())
+		}
+		Expect(countEvents("e1")).To(Equal(int64(1)))
+	})
+
+	It("overrides the interval of the default limits", func() {
+		Expect(limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+			{EventName: swag.String("upgrade_agent_failed")},
+		})).To(Succeed())
+		for i := 0; i < 2; i++ {
+			theEvents.V2AddEvent(context.TODO(), &clusterID, nil, nil, "upgrade_agent_failed", models.EventSeverityError, "message", time.Now())
+		}
+		Expect(countEvents("upgrade_agent_failed")).To(Equal(int64(2)))
+	})
+
+	It("loads the limits stored by other replicas", func() {
+		Expect(limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+			{EventName: swag.String("e1"), Interval: "10m", SampleRate: swag.Float64(0.5)},
+		})).To(Succeed())
+		other := NewRateLimiter(RateLimitsConfig{}, db, logrus.New())
+		other.Refresh()
+		policy, ok := other.policy("e1")
+		Expect(ok).To(BeTrue())
+		Expect(policy.interval).To(Equal(10 * time.Minute))
+		Expect(policy.sampleRate).To(Equal(0.5))
+		Expect(policy.source).To(Equal(models.EventRateLimitSourceAPI))
+	})
+
+	It("replaces the previous limits", func() {
+		Expect(limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+			{EventName: swag.String("e1"), Interval: "10m"},
+		})).To(Succeed())
+		Expect(limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+			{EventName: swag.String("e2"), Interval: "10m"},
+		})).To(Succeed())
+		limiter.Refresh()
+		_, ok := limiter.policy("e1")
+		Expect(ok).To(BeFalse())
+		_, ok = limiter.policy("e2")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("rejects invalid limits", func() {
+		err := limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+			{EventName: swag.String("e1"), Interval: "10minutes"},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(400)))
+
+		err = limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+			{EventName: swag.String("e1")}, {EventName: swag.String("e1")},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(400)))
+
+		for _, sampleRate := range []float64{-0.5, 1.5} {
+			err = limiter.UpdateAPIPolicies(context.TODO(), models.EventRateLimits{
+				{EventName: swag.String("e1"), SampleRate: swag.Float64(sampleRate)},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(400)))
+		}
+	})
+})
@@ -0,0 +1,170 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/pkg/leader"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RetentionConfig struct {
+	// RetentionPeriod is the age after which events are removed from the database, zero keeps the events forever
+	RetentionPeriod time.Duration `envconfig:"EVENTS_RETENTION_PERIOD" default:"0"`
+	// ArchiveEnabled exports the expired events to the object storage before they are removed from the database
+	ArchiveEnabled bool `envconfig:"EVENTS_ARCHIVE_ENABLED" default:"false"`
+	// PartitioningEnabled creates daily partitions of the events table, expired events are then removed
+	// by dropping whole partitions instead of deleting rows
+	PartitioningEnabled bool `envconfig:"EVENTS_PARTITIONING_ENABLED" default:"false"`
+	// PartitionsAhead is the number of daily partitions that are created in advance
+	PartitionsAhead int `envconfig:"EVENTS_PARTITIONS_AHEAD" default:"7"`
+	// BatchSize is the number of events that are archived and deleted together
+	BatchSize int `envconfig:"EVENTS_RETENTION_BATCH_SIZE" default:"5000"`
+	// MaxDeletedPerInterval limits the number of rows deleted one by one in a single run of the retention worker
+	MaxDeletedPerInterval int `envconfig:"EVENTS_RETENTION_MAX_DELETED_PER_INTERVAL" default:"100000"`
+}
+
+// Retention enforces the retention policy of the events table
+type Retention struct {
+	RetentionConfig
+	db            *gorm.DB
+	archive       *Archive
+	leaderElector leader.Leader
+	log           logrus.FieldLogger
+}
+
+func NewRetention(config RetentionConfig, db *gorm.DB, archive *Archive, leaderElector leader.Leader, log logrus.FieldLogger) *Retention {
+	return &Retention{
+		RetentionConfig: config,
+		db:              db,
+		archive:         archive,
+		leaderElector:   leaderElector,
+		log:             log,
+	}
+}
+
+// EnforceRetention maintains the partitions of the events table, when partitioning is enabled, and removes
+// the events that are older than the retention period, archiving them first when archiving is enabled.
+func (r *Retention) EnforceRetention() {
+	if !r.leaderElector.IsLeader() {
+		return
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	partitioned := false
+	if r.PartitioningEnabled {
+		var err error
+		if partitioned, err = r.ensurePartitions(now); err != nil {
+			r.log.WithError(err).Error("Failed to maintain the partitions of the events table")
+		}
+	}
+
+	if r.RetentionPeriod <= 0 {
+		return
+	}
+	olderThan := now.Add(-r.RetentionPeriod)
+	if partitioned {
+		if err := r.dropExpiredPartitions(ctx, olderThan); err != nil {
+			r.log.WithError(err).Error("Failed to drop expired partitions of the events table")
+			return
+		}
+	}
+	// Events that are not in a daily partition (for example in the default partition) are deleted row by row
+	if err := r.deleteExpiredEvents(ctx, olderThan); err != nil {
+		r.log.WithError(err).Error("Failed to delete expired events")
+	}
+}
+
+// ensurePartitions creates the daily partitions ahead of time. The events table is converted to a
+// partitioned table by a migration, it is left alone if that migration hasn't run.
+func (r *Retention) ensurePartitions(now time.Time) (bool, error) {
+	partitioned, err := isEventsTablePartitioned(r.db)
+	if err != nil || !partitioned {
+		return false, err
+	}
+	partitions, err := listEventsPartitions(r.db)
+	if err != nil {
+		return true, err
+	}
+	return true, createEventsPartitions(r.db, partitions, now, r.PartitionsAhead)
+}
+
+func (r *Retention) dropExpiredPartitions(ctx context.Context, olderThan time.Time) error {
+	partitions, err := listEventsPartitions(r.db)
+	if err != nil {
+		return err
+	}
+	for _, partition := range partitions {
+		if partition.isDefault() || partition.upperBound.After(olderThan) {
+			continue
+		}
+		if r.ArchiveEnabled {
+			if err = r.archivePartition(ctx, partition); err != nil {
+				return err
+			}
+		}
+		r.log.Infof("Dropping expired events partition %s", partition.name)
+		if err = r.db.Exec(fmt.Sprintf("DROP TABLE %s", quoteIdentifier(partition.name))).Error; err != nil {
+			return fmt.Errorf("failed to drop events partition %s: %w", partition.name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Retention) archivePartition(ctx context.Context, partition *eventsPartition) error {
+	var lastID uint
+	for {
+		var batch []*common.Event
+		err := r.db.Unscoped().Table(partition.name).Where("id > ?", lastID).
+			Order("id").Limit(r.BatchSize).Find(&batch).Error
+		if err != nil {
+			return fmt.Errorf("failed to read events from partition %s: %w", partition.name, err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		// The objects are named after the partition, so archiving it again after a failure to drop it
+		// replaces them
+		if err = r.archive.Write(ctx, partition.name, batch); err != nil {
+			return err
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+func (r *Retention) deleteExpiredEvents(ctx context.Context, olderThan time.Time) error {
+	deleted := 0
+	for deleted < r.MaxDeletedPerInterval {
+		var batch []*common.Event
+		err := r.db.Unscoped().Where("event_time < ?", olderThan).
+			Order("event_time, id").Limit(r.BatchSize).Find(&batch).Error
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		if r.ArchiveEnabled {
+			if err = r.archive.Write(ctx, eventsTableName, batch); err != nil {
+				return err
+			}
+		}
+		ids := make([]uint, len(batch))
+		for i, event := range batch {
+			ids[i] = event.ID
+		}
+		err = r.db.Unscoped().Where("event_time < ?", olderThan).Where("id IN (?)", ids).Delete(&common.Event{}).Error
+		if err != nil {
+			return err
+		}
+		deleted += len(batch)
+	}
+	if deleted > 0 {
+		r.log.Infof("Deleted %d events older than %s", deleted, olderThan.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	eventgen "github.com/openshift/assisted-service/internal/common/events"
+	commontesting "github.com/openshift/assisted-service/internal/common/testing"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/leader"
+	"github.com/openshift/assisted-service/pkg/s3wrapper"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Events retention", func() {
+	var (
+		ctrl         *gomock.Controller
+		db           *gorm.DB
+		dbName       string
+		mockS3Client *s3wrapper.MockAPI
+		archive      *Archive
+		theEvents    *Events
+		objects      map[string][]byte
+		clusterID    = strfmt.UUID("0c1d7e4c-6f0b-4a55-8a8e-5f3e2c1b9d70")
+		config       RetentionConfig
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		ctrl = gomock.NewController(GinkgoT())
+		mockS3Client = s3wrapper.NewMockAPI(ctrl)
+		objects = map[string][]byte{}
+		mockS3Client.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, data []byte, objectName string) error {
+				objects[objectName] = data
+				return nil
+			}).AnyTimes()
+		mockS3Client.EXPECT().ListObjectsByPrefix(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, prefix string) ([]string, error) {
+				var names []string
+				for name := range objects {
+					if strings.HasPrefix(name, prefix) {
+						names = append(names, name)
+					}
+				}
+				return names, nil
+			}).AnyTimes()
+		mockS3Client.EXPECT().Download(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, objectName string) (io.ReadCloser, int64, error) {
+				data := objects[objectName]
+				return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+			}).AnyTimes()
+		archive = NewArchive(mockS3Client, logrus.New())
+		theEvents = NewWithOptions(db, nil, commontesting.GetDummyNotificationStream(ctrl), Options{Archive: archive}, logrus.New()).(*Events)
+
+		cluster := common.Cluster{Cluster: models.Cluster{ID: &clusterID, OpenshiftClusterID: strfmt.UUID(uuid.New().String())}}
+		Expect(db.Create(&cluster).Error).ShouldNot(HaveOccurred())
+
+		config = RetentionConfig{
+			RetentionPeriod:       24 * time.Hour,
+			ArchiveEnabled:        true,
+			BatchSize:             1,
+			MaxDeletedPerInterval: 100,
+		}
+		theEvents.V2AddEvent(context.TODO(), &clusterID, nil, nil,
+			eventgen.ClusterRegistrationSucceededEventName, models.EventSeverityInfo, "old event", time.Now().Add(-48*time.Hour))
+		theEvents.V2AddEvent(context.TODO(), &clusterID, nil, nil,
+			eventgen.ClusterRegistrationSucceededEventName, models.EventSeverityWarning, "older event", time.Now().Add(-72*time.Hour))
+		theEvents.V2AddEvent(context.TODO(), &clusterID, nil, nil,
+			eventgen.ClusterRegistrationSucceededEventName, models.EventSeverityInfo, "new event", time.Now())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	getEvents := func(includeArchived bool) *common.V2GetEventsResponse {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.IncludeArchived = swag.Bool(includeArchived)
+		response, err := theEvents.V2GetEvents(context.TODO(), params)
+		Expect(err).ToNot(HaveOccurred())
+		return response
+	}
+
+	It("archives and deletes the expired events", func() {
+		NewRetention(config, db, archive, &leader.DummyElector{}, logrus.New()).EnforceRetention()
+		Expect(objects).To(HaveLen(2))
+
+		response := getEvents(false)
+		Expect(response.GetEvents()).To(HaveLen(1))
+		Expect(*response.GetEvents()[0].Message).To(Equal("new event"))
+
+		response = getEvents(true)
+		Expect(response.GetEvents()).To(HaveLen(3))
+		Expect(*response.EventCount).To(BeEquivalentTo(3))
+		Expect(response.EventSeverityCount).To(Equal(&common.EventSeverityCount{
+			models.EventSeverityInfo:    2,
+			models.EventSeverityWarning: 1,
+		}))
+		Expect(*response.GetEvents()[0].Message).To(Equal("older event"))
+		Expect(*response.GetEvents()[2].Message).To(Equal("new event"))
+	})
+
+	It("paginates the merged events", func() {
+		NewRetention(config, db, archive, &leader.DummyElector{}, logrus.New()).EnforceRetention()
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.IncludeArchived = swag.Bool(true)
+		params.Limit = swag.Int64(1)
+		params.Offset = swag.Int64(1)
+		response, err := theEvents.V2GetEvents(context.TODO(), params)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetEvents()).To(HaveLen(1))
+		Expect(*response.GetEvents()[0].Message).To(Equal("old event"))
+		Expect(*response.EventCount).To(BeEquivalentTo(3))
+	})
+
+	It("paginates the merged events in descending order", func() {
+		NewRetention(config, db, archive, &leader.DummyElector{}, logrus.New()).EnforceRetention()
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.IncludeArchived = swag.Bool(true)
+		params.Order = swag.String("descending")
+		params.Limit = swag.Int64(2)
+		response, err := theEvents.V2GetEvents(context.TODO(), params)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetEvents()).To(HaveLen(2))
+		Expect(*response.GetEvents()[0].Message).To(Equal("new event"))
+		Expect(*response.GetEvents()[1].Message).To(Equal("old event"))
+		Expect(*response.EventCount).To(BeEquivalentTo(3))
+	})
+
+	It("doesn't duplicate the archived events when they are archived again", func() {
+		retention := NewRetention(config, db, archive, &leader.DummyElector{}, logrus.New())
+		var expired []*common.Event
+		Expect(db.Where("event_time < ?", time.Now().Add(-config.RetentionPeriod)).Order("event_time, id").Find(&expired).Error).To(Succeed())
+		// An earlier run archived the first batch but failed before deleting it
+		Expect(archive.Write(context.TODO(), eventsTableName, expired[:1])).To(Succeed())
+		retention.EnforceRetention()
+		Expect(objects).To(HaveLen(2))
+		Expect(*getEvents(true).EventCount).To(BeEquivalentTo(3))
+	})
+
+	It("deletes the expired events without archiving them", func() {
+		config.ArchiveEnabled = false
+		NewRetention(config, db, archive, &leader.DummyElector{}, logrus.New()).EnforceRetention()
+		Expect(objects).To(BeEmpty())
+		Expect(getEvents(true).GetEvents()).To(HaveLen(1))
+	})
+
+	It("keeps the events when the retention period is not set", func() {
+		config.RetentionPeriod = 0
+		NewRetention(config, db, archive, &leader.DummyElector{}, logrus.New()).EnforceRetention()
+		Expect(getEvents(false).GetEvents()).To(HaveLen(3))
+	})
+})
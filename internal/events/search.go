@@ -0,0 +1,209 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
+)
+
+const (
+	// fullTextSearchConfig is the text search configuration used to index the events messages, queries
+	// must use the same one for the index to be used
+	fullTextSearchConfig = "english"
+
+	defaultAggregationInterval = "hour"
+)
+
+// eventsCursor identifies the last event of a page. Events are sorted by time and then by ID, so the
+// next page starts right after this event even when several events have the same time.
+type eventsCursor struct {
+	EventTime time.Time `json:"t"`
+	ID        uint      `json:"id"`
+}
+
+func encodeEventsCursor(event *common.Event) string {
+	data, _ := json.Marshal(eventsCursor{EventTime: eventTime(event), ID: event.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeEventsCursor(cursor string) (*eventsCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, common.NewApiError(http.StatusBadRequest, errors.Errorf("invalid events cursor %s", cursor))
+	}
+	ret := &eventsCursor{}
+	if err = json.Unmarshal(data, ret); err != nil {
+		return nil, common.NewApiError(http.StatusBadRequest, errors.Errorf("invalid events cursor %s", cursor))
+	}
+	return ret, nil
+}
+
+// parsePropsFilter checks that the props filter is a JSON object
+func parsePropsFilter(props string) (map[string]interface{}, error) {
+	var ret map[string]interface{}
+	if err := json.Unmarshal([]byte(props), &ret); err != nil || ret == nil {
+		return nil, common.NewApiError(http.StatusBadRequest, errors.Errorf("props filter must be a JSON object: %s", props))
+	}
+	return ret, nil
+}
+
+// filterEventsByTime applies the time range filter, the start time is inclusive and the end time exclusive
+func filterEventsByTime(tx *gorm.DB, startTime, endTime *strfmt.DateTime) *gorm.DB {
+	if startTime != nil {
+		tx = tx.Where("events.event_time >= ?", time.Time(*startTime))
+	}
+	if endTime != nil {
+		tx = tx.Where("events.event_time < ?", time.Time(*endTime))
+	}
+	return tx
+}
+
+// filterEventsBySearch applies the filters that aren't related to the resource the events belong to:
+// names, time range, request, properties and full-text search over the message
+func filterEventsBySearch(tx *gorm.DB, params *common.V2GetEventsParams) (*gorm.DB, error) {
+	if len(params.Names) > 0 {
+		tx = tx.Where("events.name IN (?)", params.Names)
+	}
+	tx = filterEventsByTime(tx, params.StartTime, params.EndTime)
+	if params.RequestID != nil {
+		tx = tx.Where("events.request_id = ?", params.RequestID.String())
+	}
+	if params.Props != nil {
+		if _, err := parsePropsFilter(*params.Props); err != nil {
+			return nil, err
+		}
+		tx = tx.Where(fmt.Sprintf("%s(events.props) @> ?::jsonb", common.EventsPropsJSONBFunction), *params.Props)
+	}
+	if swag.StringValue(params.Search) != "" {
+		// The expression must match the one of the events_message_fts index
+		tx = tx.Where(fmt.Sprintf("to_tsvector('%s', COALESCE(events.message, '')) @@ websearch_to_tsquery('%s', ?)",
+			fullTextSearchConfig, fullTextSearchConfig), *params.Search)
+	}
+	return tx, nil
+}
+
+// filterEventsByCursor only keeps the events that come after the cursor in the requested order
+func filterEventsByCursor(tx *gorm.DB, cursor *string, descending bool) (*gorm.DB, error) {
+	if cursor == nil {
+		return tx, nil
+	}
+	c, err := decodeEventsCursor(*cursor)
+	if err != nil {
+		return nil, err
+	}
+	if descending {
+		return tx.Where("(events.event_time, events.id) < (?, ?)", c.EventTime, c.ID), nil
+	}
+	return tx.Where("(events.event_time, events.id) > (?, ?)", c.EventTime, c.ID), nil
+}
+
+// matchesArchivedEventSearch applies to an archived event the filters that filterEventsBySearch applies
+// to the database query. The full-text search is approximated by requiring all the words of the search
+// in the message.
+func matchesArchivedEventSearch(event *common.Event, params *common.V2GetEventsParams) bool {
+	if len(params.Names) > 0 && !funk.ContainsString(params.Names, event.Name) {
+		return false
+	}
+	t := eventTime(event)
+	if params.StartTime != nil && t.Before(time.Time(*params.StartTime)) {
+		return false
+	}
+	if params.EndTime != nil && !t.Before(time.Time(*params.EndTime)) {
+		return false
+	}
+	if params.RequestID != nil && event.RequestID != *params.RequestID {
+		return false
+	}
+	if params.Props != nil {
+		filter, err := parsePropsFilter(*params.Props)
+		if err != nil {
+			return false
+		}
+		var props map[string]interface{}
+		if event.Props == "" || json.Unmarshal([]byte(event.Props), &props) != nil {
+			return false
+		}
+		for key, value := range filter {
+			if !reflect.DeepEqual(props[key], value) {
+				return false
+			}
+		}
+	}
+	if search := swag.StringValue(params.Search); search != "" {
+		message := strings.ToLower(swag.StringValue(event.Message))
+		for _, word := range strings.Fields(strings.ToLower(search)) {
+			if !strings.Contains(message, word) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// V2AggregateEvents counts the events of a cluster or an infra-env per time bucket, optionally grouped
+// by name and severity. Only admins can aggregate the events of all the resources.
+func (e Events) V2AggregateEvents(ctx context.Context, params *common.V2AggregateEventsParams) ([]*models.EventAggregation, error) {
+	categories := params.Categories
+	if len(categories) == 0 {
+		categories = DefaultEventCategories
+	}
+	interval := swag.StringValue(params.Interval)
+	if interval == "" {
+		interval = defaultAggregationInterval
+	}
+	if !funk.ContainsString([]string{"minute", "hour", "day"}, interval) {
+		return nil, common.NewApiError(http.StatusBadRequest, errors.Errorf("unsupported aggregation interval %s", interval))
+	}
+
+	cleanQuery := e.db.Session(&gorm.Session{})
+	tx := e.db.Model(&common.Event{}).Where("category IN (?)", categories)
+	tx = e.prepareEventsTable(ctx, tx, params.ClusterID, nil, params.InfraEnvID, params.Severities, nil, nil)
+	if tx == nil {
+		return make([]*models.EventAggregation, 0), nil
+	}
+	tx = filterEvents(tx, params.ClusterID, nil, params.InfraEnvID, params.Severities, nil, nil, nil, cleanQuery)
+	if len(params.Severities) > 0 {
+		tx = tx.Where("events.severity IN (?)", params.Severities)
+	}
+	if len(params.Names) > 0 {
+		tx = tx.Where("events.name IN (?)", params.Names)
+	}
+	tx = filterEventsByTime(tx, params.StartTime, params.EndTime)
+
+	query := cleanQuery.Table("(?) as s", tx)
+	if e.authz != nil && !e.authz.IsAdmin(ctx) {
+		query = e.authz.OwnedBy(ctx, query)
+	}
+	columns := []string{"date_trunc(?, s.event_time) AS bucket_start"}
+	groups := []string{"bucket_start"}
+	for _, field := range []string{"name", "severity"} {
+		if funk.ContainsString(params.GroupBy, field) {
+			columns = append(columns, "s."+field)
+			groups = append(groups, "s."+field)
+		}
+	}
+	columns = append(columns, "COUNT(*) AS count")
+
+	ret := make([]*models.EventAggregation, 0)
+	err := query.Select(strings.Join(columns, ", "), interval).
+		Group(strings.Join(groups, ", ")).
+		Order(strings.Join(groups, ", ")).
+		Scan(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,238 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	commontesting "github.com/openshift/assisted-service/internal/common/testing"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/requestid"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Events cursor", func() {
+	It("encodes and decodes the position of an event", func() {
+		eventTime := strfmt.DateTime(time.Date(2023, 2, 21, 20, 0, 0, 0, time.UTC))
+		cursor := encodeEventsCursor(&common.Event{Model: gorm.Model{ID: 7}, Event: models.Event{EventTime: &eventTime}})
+		decoded, err := decodeEventsCursor(cursor)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decoded.ID).To(Equal(uint(7)))
+		Expect(decoded.EventTime.Equal(time.Time(eventTime))).To(BeTrue())
+	})
+
+	It("rejects an invalid cursor", func() {
+		_, err := decodeEventsCursor("not a cursor")
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(400)))
+	})
+})
+
+var _ = Describe("matchesArchivedEventSearch", func() {
+	var event *common.Event
+
+	BeforeEach(func() {
+		eventTime := strfmt.DateTime(time.Date(2023, 2, 21, 20, 0, 0, 0, time.UTC))
+		event = &common.Event{Event: models.Event{
+			Name:      "cluster_installation_failed",
+			EventTime: &eventTime,
+			Message:   swag.String("Failed installing cluster: disk too small"),
+			Props:     `{"reason":"disk","retries":2}`,
+		}}
+	})
+
+	It("matches the event when there are no filters", func() {
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{})).To(BeTrue())
+	})
+
+	It("filters by name", func() {
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{Names: []string{"cluster_installation_failed"}})).To(BeTrue())
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{Names: []string{"other"}})).To(BeFalse())
+	})
+
+	It("filters by time range", func() {
+		start := strfmt.DateTime(time.Date(2023, 2, 21, 20, 0, 0, 0, time.UTC))
+		end := strfmt.DateTime(time.Date(2023, 2, 21, 21, 0, 0, 0, time.UTC))
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{StartTime: &start, EndTime: &end})).To(BeTrue())
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{EndTime: &start})).To(BeFalse())
+	})
+
+	It("filters by properties", func() {
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{Props: swag.String(`{"reason":"disk"}`)})).To(BeTrue())
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{Props: swag.String(`{"reason":"network"}`)})).To(BeFalse())
+	})
+
+	It("searches the words of the message", func() {
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{Search: swag.String("DISK small")})).To(BeTrue())
+		Expect(matchesArchivedEventSearch(event, &common.V2GetEventsParams{Search: swag.String("disk network")})).To(BeFalse())
+	})
+})
+
+var _ = Describe("Events search", func() {
+	var (
+		ctrl      *gomock.Controller
+		db        *gorm.DB
+		dbName    string
+		theEvents *Events
+		ctx       = context.Background()
+		clusterID = strfmt.UUID(uuid.New().String())
+		requestID = uuid.New().String()
+		baseTime  = time.Date(2023, 2, 21, 20, 0, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		ctrl = gomock.NewController(GinkgoT())
+		theEvents = New(db, nil, commontesting.GetDummyNotificationStream(ctrl), logrus.New()).(*Events)
+		c := common.Cluster{Cluster: models.Cluster{ID: &clusterID, OpenshiftClusterID: strfmt.UUID(uuid.New().String())}}
+		Expect(db.Create(&c).Error).ShouldNot(HaveOccurred())
+
+		theEvents.V2AddEvent(ctx, &clusterID, nil, nil, "host_disk_too_small", models.EventSeverityWarning,
+			"Host disk is too small for installation", baseTime, map[string]interface{}{"host": "h1"})
+		theEvents.V2AddEvent(requestid.ToContext(ctx, requestID), &clusterID, nil, nil, "cluster_installation_started", models.EventSeverityInfo,
+			"Installation started", baseTime.Add(30*time.Minute))
+		theEvents.V2AddEvent(ctx, &clusterID, nil, nil, "cluster_installation_failed", models.EventSeverityError,
+			"Installation failed because the disks were too small", baseTime.Add(90*time.Minute), map[string]interface{}{"host": "h2"})
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	messages := func(params *common.V2GetEventsParams) []string {
+		response, err := theEvents.V2GetEvents(ctx, params)
+		Expect(err).ToNot(HaveOccurred())
+		ret := make([]string, 0)
+		for _, ev := range response.GetEvents() {
+			ret = append(ret, swag.StringValue(ev.Message))
+		}
+		return ret
+	}
+
+	It("filters by name", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.Names = []string{"cluster_installation_started", "cluster_installation_failed"}
+		Expect(messages(params)).To(Equal([]string{"Installation started", "Installation failed because the disks were too small"}))
+	})
+
+	It("filters by time range", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		start := strfmt.DateTime(baseTime.Add(30 * time.Minute))
+		end := strfmt.DateTime(baseTime.Add(90 * time.Minute))
+		params.StartTime = &start
+		params.EndTime = &end
+		Expect(messages(params)).To(Equal([]string{"Installation started"}))
+	})
+
+	It("filters by request ID", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		rid := strfmt.UUID(requestID)
+		params.RequestID = &rid
+		Expect(messages(params)).To(Equal([]string{"Installation started"}))
+	})
+
+	It("filters by properties", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.Props = swag.String(`{"host":"h2"}`)
+		Expect(messages(params)).To(Equal([]string{"Installation failed because the disks were too small"}))
+	})
+
+	It("ignores the events with properties that aren't valid JSON", func() {
+		Expect(db.Model(&common.Event{}).Where("message = ?", "Installation started").Update("props", "not json").Error).To(Succeed())
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.Props = swag.String(`{"host":"h2"}`)
+		Expect(messages(params)).To(Equal([]string{"Installation failed because the disks were too small"}))
+	})
+
+	It("rejects properties that aren't an object", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.Props = swag.String(`["host"]`)
+		_, err := theEvents.V2GetEvents(ctx, params)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(400)))
+	})
+
+	It("searches the message", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.Search = swag.String("small disk")
+		Expect(messages(params)).To(Equal([]string{
+			"Host disk is too small for installation",
+			"Installation failed because the disks were too small",
+		}))
+		params.Search = swag.String("small -installation")
+		Expect(messages(params)).To(BeEmpty())
+	})
+
+	It("paginates with a cursor", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.Limit = swag.Int64(2)
+		response, err := theEvents.V2GetEvents(ctx, params)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetEvents()).To(HaveLen(2))
+		Expect(*response.GetEventCount()).To(Equal(int64(3)))
+		Expect(response.GetNextCursor()).ToNot(BeEmpty())
+
+		params.Cursor = swag.String(response.GetNextCursor())
+		response, err = theEvents.V2GetEvents(ctx, params)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.GetEvents()).To(HaveLen(1))
+		Expect(*response.GetEvents()[0].Message).To(Equal("Installation failed because the disks were too small"))
+		Expect(*response.GetEventCount()).To(Equal(int64(3)))
+		Expect(response.GetNextCursor()).To(BeEmpty())
+	})
+
+	It("paginates with a cursor in descending order", func() {
+		params := common.GetDefaultV2GetEventsParams(&clusterID, nil, nil)
+		params.Limit = swag.Int64(1)
+		params.Order = swag.String("descending")
+		response, err := theEvents.V2GetEvents(ctx, params)
+		Expect(err).ToNot(HaveOccurred())
+		params.Cursor = swag.String(response.GetNextCursor())
+		Expect(messages(params)).To(Equal([]string{"Installation started"}))
+	})
+
+	It("aggregates the events per time bucket", func() {
+		aggregations, err := theEvents.V2AggregateEvents(ctx, &common.V2AggregateEventsParams{
+			ClusterID: &clusterID,
+			Interval:  swag.String("hour"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(aggregations).To(HaveLen(2))
+		Expect(time.Time(*aggregations[0].BucketStart).Equal(baseTime)).To(BeTrue())
+		Expect(*aggregations[0].Count).To(Equal(int64(2)))
+		Expect(time.Time(*aggregations[1].BucketStart).Equal(baseTime.Add(time.Hour))).To(BeTrue())
+		Expect(*aggregations[1].Count).To(Equal(int64(1)))
+	})
+
+	It("aggregates the events by severity", func() {
+		aggregations, err := theEvents.V2AggregateEvents(ctx, &common.V2AggregateEventsParams{
+			ClusterID: &clusterID,
+			Interval:  swag.String("day"),
+			GroupBy:   []string{"severity"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(aggregations).To(HaveLen(3))
+		for _, aggregation := range aggregations {
+			Expect(aggregation.Name).To(BeEmpty())
+			Expect(aggregation.Severity).ToNot(BeEmpty())
+			Expect(*aggregation.Count).To(Equal(int64(1)))
+		}
+	})
+
+	It("rejects an unsupported interval", func() {
+		_, err := theEvents.V2AggregateEvents(ctx, &common.V2AggregateEventsParams{
+			ClusterID: &clusterID,
+			Interval:  swag.String("week"),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(400)))
+	})
+})
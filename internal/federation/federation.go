@@ -0,0 +1,82 @@
+package federation
+
+import (
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IssueAgentToken issues the agent token of a host imported from a peer hub. The peer hub delivers
+// the token to the agent, so it's recorded as delivered right away.
+func IssueAgentToken(db *gorm.DB, infraEnvID, hostID strfmt.UUID) (string, error) {
+	now := time.Now()
+	token := common.AgentToken{
+		ID:          uuid.New().String(),
+		InfraEnvID:  infraEnvID,
+		HostID:      hostID,
+		CreatedAt:   now,
+		DeliveredAt: &now,
+	}
+	if err := db.Create(&token).Error; err != nil {
+		return "", err
+	}
+	return gencrypto.LocalHostJWT(infraEnvID.String(), hostID.String(), token.ID)
+}
+
+// Start records the migration of a host to a peer hub, the outcome of a previous migration of the
+// host is discarded
+func Start(db *gorm.DB, migration *common.HostMigration) error {
+	migration.CreatedAt = time.Now()
+	migration.MigratedAt = nil
+	migration.Error = ""
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(migration).Error
+}
+
+// Get returns the last migration of the host, nil when the host was never migrated
+func Get(db *gorm.DB, infraEnvID, hostID strfmt.UUID) (*common.HostMigration, error) {
+	var migration common.HostMigration
+	err := db.Where("host_id = ? AND infra_env_id = ?", hostID.String(), infraEnvID.String()).Take(&migration).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &migration, nil
+}
+
+// Pending returns the migration of the host that the agent didn't reply to yet, nil when there is
+// none
+func Pending(db *gorm.DB, infraEnvID, hostID strfmt.UUID) (*common.HostMigration, error) {
+	migration, err := Get(db, infraEnvID, hostID)
+	if err != nil || migration == nil {
+		return nil, err
+	}
+	if migration.MigratedAt != nil || migration.Error != "" {
+		return nil, nil
+	}
+	return migration, nil
+}
+
+// RecordResult records the reply of the agent to its pending migration, the host was migrated when
+// the reason is empty. It returns false when the host has no pending migration, the reply was
+// already recorded.
+func RecordResult(db *gorm.DB, infraEnvID, hostID strfmt.UUID, reason string) (bool, error) {
+	updates := map[string]interface{}{"token": ""}
+	if reason == "" {
+		updates["migrated_at"] = time.Now()
+	} else {
+		updates["error"] = reason
+	}
+	// The model identifies the record, so that the token is deleted from the secrets backend
+	result := db.Model(&common.HostMigration{HostID: hostID, InfraEnvID: infraEnvID}).
+		Where("migrated_at IS NULL AND error = ''").
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,118 @@
+package federation
+
+import (
+	"os"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/gencrypto"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("IssueAgentToken", func() {
+	var (
+		db                 *gorm.DB
+		dbName             string
+		infraEnvID, hostID strfmt.UUID
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		infraEnvID = strfmt.UUID(uuid.New().String())
+		hostID = strfmt.UUID(uuid.New().String())
+		_, priv, err := gencrypto.ECDSAKeyPairPEM()
+		Expect(err).NotTo(HaveOccurred())
+		os.Setenv("EC_PRIVATE_KEY_PEM", priv)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_PRIVATE_KEY_PEM")
+		common.DeleteTestDB(db, dbName)
+	})
+
+	It("issues a delivered token limited to the host", func() {
+		signed, err := IssueAgentToken(db, infraEnvID, hostID)
+		Expect(err).ToNot(HaveOccurred())
+
+		claims := jwt.MapClaims{}
+		_, _, err = new(jwt.Parser).ParseUnverified(signed, claims)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claims[string(gencrypto.InfraEnvKey)]).To(Equal(infraEnvID.String()))
+		Expect(claims[string(gencrypto.HostKey)]).To(Equal(hostID.String()))
+
+		var token common.AgentToken
+		Expect(db.Where("id = ?", claims[gencrypto.TokenIDClaim]).Take(&token).Error).ToNot(HaveOccurred())
+		Expect(token.HostID).To(Equal(hostID))
+		Expect(token.DeliveredAt).ToNot(BeNil())
+	})
+})
+
+var _ = Describe("Host migration", func() {
+	var (
+		db                 *gorm.DB
+		dbName             string
+		infraEnvID, hostID strfmt.UUID
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		infraEnvID = strfmt.UUID(uuid.New().String())
+		hostID = strfmt.UUID(uuid.New().String())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	start := func() {
+		Expect(Start(db, &common.HostMigration{
+			HostID:         hostID,
+			InfraEnvID:     infraEnvID,
+			ServiceBaseURL: "https://peer.example.com",
+			PeerInfraEnvID: strfmt.UUID(uuid.New().String()),
+			Token:          "peer-token",
+		})).To(Succeed())
+	}
+
+	It("has no pending migration when the host isn't migrated", func() {
+		Expect(Pending(db, infraEnvID, hostID)).To(BeNil())
+		Expect(Get(db, infraEnvID, hostID)).To(BeNil())
+	})
+
+	It("records the failure of the agent", func() {
+		start()
+		Expect(Pending(db, infraEnvID, hostID)).ToNot(BeNil())
+
+		recorded, err := RecordResult(db, infraEnvID, hostID, "failed to restart the agent")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorded).To(BeTrue())
+		Expect(Pending(db, infraEnvID, hostID)).To(BeNil())
+		migration, err := Get(db, infraEnvID, hostID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migration.Error).To(Equal("failed to restart the agent"))
+		Expect(migration.MigratedAt).To(BeNil())
+		Expect(migration.Token).To(BeEmpty())
+
+		By("ignoring the repeated replies")
+		recorded, err = RecordResult(db, infraEnvID, hostID, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorded).To(BeFalse())
+	})
+
+	It("discards the outcome of the previous migration when the host is migrated again", func() {
+		start()
+		_, err := RecordResult(db, infraEnvID, hostID, "failed to restart the agent")
+		Expect(err).ToNot(HaveOccurred())
+
+		start()
+		migration, err := Pending(db, infraEnvID, hostID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migration).ToNot(BeNil())
+		Expect(migration.Error).To(BeEmpty())
+		Expect(migration.Token).To(Equal("peer-token"))
+	})
+})
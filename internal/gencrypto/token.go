@@ -15,6 +15,16 @@ type LocalJWTKeyType string
 const (
 	InfraEnvKey LocalJWTKeyType = "infra_env_id"
 	ClusterKey  LocalJWTKeyType = "cluster_id"
+	HostKey     LocalJWTKeyType = "host_id"
+)
+
+const (
+	// IssuedAtClaim is the time the local token was issued, in seconds since the epoch. It isn't the
+	// registered iat claim, the parser rejects the tokens whose iat is in the future when the clocks
+	// of the replicas drift.
+	IssuedAtClaim = "issued_at"
+	// TokenIDClaim identifies the tokens issued to the hosts, so they can be revoked
+	TokenIDClaim = "jti"
 )
 
 type CryptoPair struct {
@@ -31,14 +41,38 @@ func LocalJWT(id string, keyType LocalJWTKeyType) (string, error) {
 }
 
 func LocalJWTForKey(id string, private_key_pem string, keyType LocalJWTKeyType) (string, error) {
+	return signLocalJWT(private_key_pem, jwt.MapClaims{
+		string(keyType): id,
+		IssuedAtClaim:   time.Now().Unix(),
+	})
+}
+
+// LocalHostJWT returns an agent token limited to a host of an infra-env, tokenID is the jti claim
+// used to revoke the token
+func LocalHostJWT(infraEnvID, hostID, tokenID string) (string, error) {
+	key, ok := os.LookupEnv("EC_PRIVATE_KEY_PEM")
+	if !ok || key == "" {
+		return "", errors.Errorf("EC_PRIVATE_KEY_PEM not found")
+	}
+	return LocalHostJWTForKey(infraEnvID, hostID, tokenID, key)
+}
+
+func LocalHostJWTForKey(infraEnvID, hostID, tokenID string, private_key_pem string) (string, error) {
+	return signLocalJWT(private_key_pem, jwt.MapClaims{
+		string(InfraEnvKey): infraEnvID,
+		string(HostKey):     hostID,
+		TokenIDClaim:        tokenID,
+		IssuedAtClaim:       time.Now().Unix(),
+	})
+}
+
+func signLocalJWT(private_key_pem string, claims jwt.MapClaims) (string, error) {
 	priv, err := jwt.ParseECPrivateKeyFromPEM([]byte(private_key_pem))
 	if err != nil {
 		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
-		string(keyType): id,
-	})
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
 
 	tokenString, err := token.SignedString(priv)
 	if err != nil {
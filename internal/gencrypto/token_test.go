@@ -96,6 +96,23 @@ var _ = Context("with an ECDSA key pair", func() {
 
 		validateToken(tokenString, publicKey, id)
 	})
+
+	It("LocalHostJWTForKey creates a valid token limited to a host", func() {
+		infraEnvID := uuid.New().String()
+		hostID := uuid.New().String()
+		tokenString, err := LocalHostJWTForKey(infraEnvID, hostID, "token-id", privateKeyPEM)
+		Expect(err).ToNot(HaveOccurred())
+
+		parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodES256.Alg()}}
+		parsed, err := parser.Parse(tokenString, func(t *jwt.Token) (interface{}, error) { return publicKey, nil })
+		Expect(err).ToNot(HaveOccurred())
+		claims, ok := parsed.Claims.(jwt.MapClaims)
+		Expect(ok).To(BeTrue())
+		Expect(claims[string(InfraEnvKey)]).To(Equal(infraEnvID))
+		Expect(claims[string(HostKey)]).To(Equal(hostID))
+		Expect(claims[TokenIDClaim]).To(Equal("token-id"))
+		Expect(claims[IssuedAtClaim]).To(BeNumerically("~", time.Now().Unix(), 5))
+	})
 })
 
 var _ = Describe("JWTForSymmetricKey", func() {
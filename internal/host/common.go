@@ -52,6 +52,8 @@ const (
 	statusInfoUnbinding                                                   = "Host is waiting to be unbound from the cluster"
 	statusInfoRebootingDay2                                               = "Host has rebooted and no further updates will be posted. Please check console for progress and to possibly approve pending CSRs"
 	statusInfoRebootingForReclaim                                         = "Host is rebooting into the discovery image"
+	statusInfoPendingAttestation                                          = "Waiting for the host to attest its TPM"
+	statusInfoAttestationFailed                                           = "The host failed the TPM attestation: %s"
 )
 
 var BootstrapStages = [...]models.HostStage{
@@ -483,6 +483,7 @@ func (m *Manager) refreshStatusInternal(ctx context.Context, h *models.Host, c *
 		// For changes to be detected and reported correctly, the comparison needs to be
 		// performed before the new validations are updated to the DB.
 		m.reportValidationStatusChanged(ctx, vc, h, newValidationRes, currentValidationRes)
+		m.reportApprovalDecision(ctx, h, newValidationRes, currentValidationRes)
 		_, err = m.updateValidationsInDB(ctx, db, h, newValidationRes)
 		if err != nil {
 			return err
@@ -1195,6 +1196,39 @@ func (m *Manager) reportValidationStatusChanged(ctx context.Context, vc *validat
 	}
 }
 
+// reportApprovalDecision explains the decision of the approval policy of the infra-env when it
+// changes, the message of the validation is the explanation
+func (m *Manager) reportApprovalDecision(ctx context.Context, h *models.Host, newValidationRes, currentValidationRes ValidationsStatus) {
+	category, err := AreApprovalRulesSatisfied.category()
+	if err != nil {
+		return
+	}
+	decision, ok := m.getValidationResult(newValidationRes, category, AreApprovalRulesSatisfied)
+	if !ok || (decision.Status != ValidationSuccess && decision.Status != ValidationFailure) {
+		return
+	}
+	if previous, ok := m.getValidationResult(currentValidationRes, category, AreApprovalRulesSatisfied); ok &&
+		previous.Status == decision.Status && previous.Message == decision.Message {
+		return
+	}
+	if decision.Status == ValidationFailure {
+		eventgen.SendHostQuarantinedEvent(ctx, m.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID,
+			decision.Message)
+		return
+	}
+	eventgen.SendHostApprovalDecidedEvent(ctx, m.eventsHandler, *h.ID, hostutil.GetHostnameForMsg(h), h.InfraEnvID, h.ClusterID,
+		decision.Message)
+}
+
+func (m *Manager) getValidationResult(vs ValidationsStatus, category string, vID validationID) (*ValidationResult, bool) {
+	for i := range vs[category] {
+		if vs[category][i].ID == vID {
+			return &vs[category][i], true
+		}
+	}
+	return nil, false
+}
+
 func (m *Manager) getValidationStatus(vs ValidationsStatus, category string, vID validationID) (ValidationStatus, bool) {
 	for _, v := range vs[category] {
 		if v.ID == vID {
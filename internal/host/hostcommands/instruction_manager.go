@@ -20,7 +20,9 @@ import (
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/auth"
 	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
@@ -51,6 +53,8 @@ type InstructionManager struct {
 	poolHostToSteps               stateToStepsMap
 	disabledStepsMap              map[models.StepType]bool
 	upgradeAgentCmd               CommandGetter
+	rotateAgentTokenCmd           CommandGetter
+	migrateAgentCmd               CommandGetter
 	eventsHandler                 eventsapi.Sender
 }
 
@@ -97,6 +101,7 @@ func NewInstructionManager(log logrus.FieldLogger, db *gorm.DB, hwValidator hard
 	downloadBootArtifactsCmd := NewDownloadBootArtifactsCmd(log, instructionConfig.ImageServiceBaseURL, instructionConfig.AuthType, osImages, db, instructionConfig.ImageExpirationTime, instructionConfig.HostFSMountDir)
 	rebootForReclaimCmd := NewRebootForReclaimCmd(log, instructionConfig.HostFSMountDir)
 	verifyVipsCmd := newVerifyVipsCmd(log, db)
+	tpmAttestationCmd := NewTpmAttestationCmd(log, db)
 
 	return &InstructionManager{
 		log:              log,
@@ -117,6 +122,7 @@ func NewInstructionManager(log logrus.FieldLogger, db *gorm.DB, hwValidator hard
 			models.HostStatusError:                    {[]CommandGetter{logsCmd, stopCmd}, defaultBackedOffInstructionInSec, models.StepsPostStepActionContinue},
 			models.HostStatusCancelled:                {[]CommandGetter{logsCmd, stopCmd}, defaultBackedOffInstructionInSec, models.StepsPostStepActionContinue},
 			models.HostStatusBinding:                  {[]CommandGetter{noopCmd}, 0, models.StepsPostStepActionExit},
+			models.HostStatusPendingAttestation:       {[]CommandGetter{tpmAttestationCmd}, defaultNextInstructionInSec, models.StepsPostStepActionContinue},
 		},
 		addHostsClusterToSteps: stateToStepsMap{
 			models.HostStatusKnown:                {[]CommandGetter{connectivityCmd, apivipConnectivityCmd, tangConnectivityCmd, inventoryCmd, ntpSynchronizerCmd, domainNameResolutionCmd}, defaultNextInstructionInSec, models.StepsPostStepActionContinue},
@@ -130,6 +136,7 @@ func NewInstructionManager(log logrus.FieldLogger, db *gorm.DB, hwValidator hard
 			models.HostStatusResetting:            {[]CommandGetter{}, defaultBackedOffInstructionInSec, models.StepsPostStepActionContinue},
 			models.HostStatusError:                {[]CommandGetter{logsCmd, stopCmd}, defaultBackedOffInstructionInSec, models.StepsPostStepActionContinue},
 			models.HostStatusCancelled:            {[]CommandGetter{logsCmd, stopCmd}, defaultBackedOffInstructionInSec, models.StepsPostStepActionContinue},
+			models.HostStatusPendingAttestation:   {[]CommandGetter{tpmAttestationCmd}, defaultNextInstructionInSec, models.StepsPostStepActionContinue},
 		},
 		poolHostToSteps: stateToStepsMap{
 			models.HostStatusDiscoveringUnbound:         {[]CommandGetter{inventoryCmd, ntpSynchronizerCmd}, defaultNextInstructionInSec, models.StepsPostStepActionContinue},
@@ -141,9 +148,12 @@ func NewInstructionManager(log logrus.FieldLogger, db *gorm.DB, hwValidator hard
 			models.HostStatusUnbindingPendingUserAction: {[]CommandGetter{noopCmd}, 0, models.StepsPostStepActionExit},
 			models.HostStatusReclaiming:                 {[]CommandGetter{downloadBootArtifactsCmd}, defaultNextInstructionInSec, models.StepsPostStepActionContinue},
 			models.HostStatusReclaimingRebooting:        {[]CommandGetter{rebootForReclaimCmd}, defaultBackedOffInstructionInSec, models.StepsPostStepActionExit},
+			models.HostStatusPendingAttestation:         {[]CommandGetter{tpmAttestationCmd}, defaultNextInstructionInSec, models.StepsPostStepActionContinue},
 		},
-		upgradeAgentCmd: upgradeAgentCmd,
-		eventsHandler:   eventsHandler,
+		upgradeAgentCmd:     upgradeAgentCmd,
+		rotateAgentTokenCmd: NewRotateAgentTokenCmd(log, db),
+		migrateAgentCmd:     NewMigrateAgentCmd(log, db, instructionConfig.HostFSMountDir),
+		eventsHandler:       eventsHandler,
 	}
 }
 
@@ -153,13 +163,39 @@ func (i *InstructionManager) isStepDisabled(stepType models.StepType) bool {
 }
 
 func (i *InstructionManager) GetNextSteps(ctx context.Context, host *models.Host) (models.Steps, error) {
+	hostStatus := swag.StringValue(host.Status)
+	ctx, span := tracing.StartSpan(ctx, "InstructionManager.GetNextSteps",
+		attribute.String("infra_env_id", host.InfraEnvID.String()),
+		attribute.String("host_status", hostStatus))
+	defer span.End()
+	if host.ID != nil {
+		span.SetAttributes(attribute.String("host_id", host.ID.String()))
+	}
 
 	log := logutil.FromContext(ctx, i.log)
 	InfraEnvID := host.InfraEnvID
 	hostID := host.ID
-	hostStatus := swag.StringValue(host.Status)
 	log.Debugf("GetNextSteps infra_env: <%s>, host: <%s>, host status: <%s>", InfraEnvID, hostID, hostStatus)
 
+	// The migration of an unbound host to a peer hub replaces all the other steps, the agent exits
+	// once it's configured to reach the peer service and it's restarted:
+	if hostutil.IsUnboundHost(host) && !i.isStepDisabled(models.StepTypeMigrateAgent) {
+		steps, err := i.migrateAgentCmd.GetSteps(ctx, host)
+		if err != nil {
+			log.WithError(err).Warn("Failed to generate the step to migrate the agent")
+		} else if len(steps) > 0 {
+			steps[0].StepID = createStepID(steps[0].StepType)
+			returnSteps := models.Steps{
+				Instructions:           steps,
+				NextInstructionSeconds: defaultNextInstructionInSec,
+				PostStepAction:         swag.String(models.StepsPostStepActionExit),
+			}
+			span.SetAttributes(attribute.Int("steps", len(returnSteps.Instructions)))
+			logSteps(returnSteps, InfraEnvID, hostID, log)
+			return returnSteps, nil
+		}
+	}
+
 	returnSteps := models.Steps{}
 	stateToSteps := i.installingClusterStateToSteps
 	if hostutil.IsDay2Host(host) {
@@ -203,6 +239,17 @@ func (i *InstructionManager) GetNextSteps(ctx context.Context, host *models.Host
 		returnSteps.NextInstructionSeconds = defaultNextInstructionInSec
 	}
 
+	// The new agent token of the host is delivered before the other steps, whatever the state of
+	// the host, so the agent can still reach the service when its current token expires:
+	if i.isAgentTokenRotationAllowed(host) {
+		steps, err := i.rotateAgentTokenCmd.GetSteps(ctx, host)
+		if err != nil {
+			log.WithError(err).Warn("Failed to generate the step to rotate the agent token")
+		} else {
+			returnSteps.Instructions = append(steps, returnSteps.Instructions...)
+		}
+	}
+
 	// If the agent isn't compatible with the service and the host is in a state that allows to
 	// upgrade the agent, then replace all the calculated steps with a single step to upgrade
 	// the agent:
@@ -234,6 +281,7 @@ func (i *InstructionManager) GetNextSteps(ctx context.Context, host *models.Host
 		)
 	}
 
+	span.SetAttributes(attribute.Int("steps", len(returnSteps.Instructions)))
 	logSteps(returnSteps, InfraEnvID, hostID, log)
 	return returnSteps, nil
 }
@@ -263,6 +311,19 @@ func (i *InstructionManager) isAgentUpgradeAllowed(host *models.Host) bool {
 	}
 }
 
+// isAgentTokenRotationAllowed checks that the agents are authenticated with the local agent tokens,
+// the only ones the service issues to the hosts, and that the agent of the host runs the image of the
+// service, the older agents don't know the step. They get their token once upgraded.
+func (i *InstructionManager) isAgentTokenRotationAllowed(host *models.Host) bool {
+	if i.config.AuthType != auth.TypeLocal && i.config.AuthType != auth.TypeOIDC {
+		return false
+	}
+	if !common.IsAgentCompatible(i.config.AgentImage, host.DiscoveryAgentVersion) {
+		return false
+	}
+	return !i.isStepDisabled(models.StepTypeRotateAgentToken)
+}
+
 func generateDisabledStepsMap(log logrus.FieldLogger, disabledSteps []models.StepType) map[models.StepType]bool {
 	result := make(map[models.StepType]bool)
 	for _, step := range disabledSteps {
@@ -285,6 +346,11 @@ func logSteps(steps models.Steps, infraEnvId strfmt.UUID, hostId *strfmt.UUID, l
 		log.Debugf("No steps required for infraEnv <%s> host <%s>", infraEnvId, hostId)
 	}
 	for _, step := range steps.Instructions {
-		log.Infof("Submitting step <%s> id <%s> to infra_env <%s> host <%s>  Arguments: <%+v>", step.StepType, step.StepID, infraEnvId, hostId, step.Args)
+		args := step.Args
+		if step.StepType == models.StepTypeRotateAgentToken || step.StepType == models.StepTypeMigrateAgent {
+			// The arguments hold the new agent token
+			args = []string{"<redacted>"}
+		}
+		log.Infof("Submitting step <%s> id <%s> to infra_env <%s> host <%s>  Arguments: <%+v>", step.StepType, step.StepID, infraEnvId, hostId, args)
 	}
 }
@@ -3,6 +3,7 @@ package hostcommands
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -18,12 +19,15 @@ import (
 	"github.com/openshift/assisted-service/internal/connectivity"
 	eventsapi "github.com/openshift/assisted-service/internal/events/api"
 	"github.com/openshift/assisted-service/internal/events/eventstest"
+	"github.com/openshift/assisted-service/internal/federation"
+	"github.com/openshift/assisted-service/internal/gencrypto"
 	"github.com/openshift/assisted-service/internal/hardware"
 	"github.com/openshift/assisted-service/internal/host/hostutil"
 	"github.com/openshift/assisted-service/internal/oc"
 	"github.com/openshift/assisted-service/internal/stream"
 	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
 	"github.com/thoas/go-funk"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
@@ -521,6 +525,130 @@ var _ = Describe("agent_upgrade", func() {
 
 })
 
+var _ = Describe("agent_token_rotation", func() {
+	var (
+		ctx               = context.Background()
+		host              models.Host
+		db                *gorm.DB
+		ctrl              *gomock.Controller
+		instructionConfig InstructionConfig
+		dbName            string
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		ctrl = gomock.NewController(GinkgoT())
+		instructionConfig = InstructionConfig{AgentImage: "quay.io/my/image:v1.2.3", AuthType: auth.TypeLocal}
+		hostId := strfmt.UUID(uuid.New().String())
+		infraEnvId := strfmt.UUID(uuid.New().String())
+		host = hostutil.GenerateTestHost(hostId, infraEnvId, strfmt.UUID(uuid.New().String()), models.HostStatusDisabled)
+		host.DiscoveryAgentVersion = instructionConfig.AgentImage
+		Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+		token := common.AgentToken{ID: uuid.New().String(), InfraEnvID: infraEnvId, HostID: hostId, CreatedAt: time.Now()}
+		Expect(db.Create(&token).Error).ShouldNot(HaveOccurred())
+		_, priv, err := gencrypto.ECDSAKeyPairPEM()
+		Expect(err).NotTo(HaveOccurred())
+		os.Setenv("EC_PRIVATE_KEY_PEM", priv)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_PRIVATE_KEY_PEM")
+		common.DeleteTestDB(db, dbName)
+		ctrl.Finish()
+	})
+
+	getNextSteps := func() models.Steps {
+		instMng := NewInstructionManager(common.GetTestLog(), db, hardware.NewMockValidator(ctrl), oc.NewMockRelease(ctrl),
+			instructionConfig, connectivity.NewMockValidator(ctrl), eventsapi.NewMockHandler(ctrl), versions.NewMockHandler(ctrl),
+			versions.NewMockOSImages(ctrl), false)
+		stepsReply, err := instMng.GetNextSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		return stepsReply
+	}
+
+	It("Delivers the pending agent token of the host", func() {
+		stepsReply := getNextSteps()
+		Expect(stepsReply.Instructions).To(HaveLen(1))
+		Expect(stepsReply.Instructions[0].StepType).To(Equal(models.StepTypeRotateAgentToken))
+	})
+
+	It("Doesn't deliver agent tokens with the other authentication types", func() {
+		instructionConfig.AuthType = auth.TypeRHSSO
+		Expect(getNextSteps().Instructions).To(BeEmpty())
+	})
+
+	It("Doesn't deliver agent tokens when the step is disabled", func() {
+		instructionConfig.DisabledSteps = []models.StepType{models.StepTypeRotateAgentToken}
+		Expect(getNextSteps().Instructions).To(BeEmpty())
+	})
+
+	It("Doesn't deliver agent tokens to the agents that don't run the image of the service", func() {
+		host.DiscoveryAgentVersion = "quay.io/my/image:v1.2.2"
+		Expect(getNextSteps().Instructions).To(BeEmpty())
+	})
+})
+
+var _ = Describe("agent_migration", func() {
+	var (
+		ctx               = context.Background()
+		host              models.Host
+		db                *gorm.DB
+		ctrl              *gomock.Controller
+		instructionConfig InstructionConfig
+		dbName            string
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		ctrl = gomock.NewController(GinkgoT())
+		instructionConfig = InstructionConfig{AgentImage: "quay.io/my/image:v1.2.3", HostFSMountDir: "/host"}
+		hostId := strfmt.UUID(uuid.New().String())
+		infraEnvId := strfmt.UUID(uuid.New().String())
+		host = hostutil.GenerateUnassignedTestHost(hostId, infraEnvId, models.HostStatusDisabledUnbound)
+		Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+		Expect(federation.Start(db, &common.HostMigration{
+			HostID:         hostId,
+			InfraEnvID:     infraEnvId,
+			ServiceBaseURL: "https://peer.example.com",
+			PeerInfraEnvID: strfmt.UUID(uuid.New().String()),
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+		ctrl.Finish()
+	})
+
+	getNextSteps := func() models.Steps {
+		instMng := NewInstructionManager(common.GetTestLog(), db, hardware.NewMockValidator(ctrl), oc.NewMockRelease(ctrl),
+			instructionConfig, connectivity.NewMockValidator(ctrl), eventsapi.NewMockHandler(ctrl), versions.NewMockHandler(ctrl),
+			versions.NewMockOSImages(ctrl), false)
+		stepsReply, err := instMng.GetNextSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		return stepsReply
+	}
+
+	It("Replaces the steps of the migrated host", func() {
+		stepsReply := getNextSteps()
+		Expect(stepsReply.Instructions).To(HaveLen(1))
+		Expect(stepsReply.Instructions[0].StepType).To(Equal(models.StepTypeMigrateAgent))
+		Expect(stepsReply.Instructions[0].StepID).ToNot(BeEmpty())
+		Expect(swag.StringValue(stepsReply.PostStepAction)).To(Equal(models.StepsPostStepActionExit))
+	})
+
+	It("Doesn't migrate the hosts bound to a cluster", func() {
+		clusterId := strfmt.UUID(uuid.New().String())
+		host.ClusterID = &clusterId
+		host.Status = swag.String(models.HostStatusDisabled)
+		Expect(getNextSteps().Instructions).To(BeEmpty())
+	})
+
+	It("Doesn't migrate the hosts when the step is disabled", func() {
+		instructionConfig.DisabledSteps = []models.StepType{models.StepTypeMigrateAgent}
+		Expect(getNextSteps().Instructions).To(BeEmpty())
+	})
+})
+
 func checkStepsByState(state string, host *models.Host, db *gorm.DB, mockEvents *eventsapi.MockHandler,
 	dummyNotificationStream stream.Notifier, instMng *InstructionManager, mockValidator *hardware.MockValidator, mockRelease *oc.MockRelease, mockVersions *versions.MockHandler,
 	mockConnectivity *connectivity.MockValidator, ctx context.Context, expectedStepTypes []models.StepType) {
@@ -0,0 +1,54 @@
+package hostcommands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/federation"
+	"github.com/openshift/assisted-service/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type migrateAgentCmd struct {
+	baseCmd
+	db             *gorm.DB
+	hostFSMountDir string
+}
+
+func NewMigrateAgentCmd(log logrus.FieldLogger, db *gorm.DB, hostFSMountDir string) *migrateAgentCmd {
+	return &migrateAgentCmd{
+		baseCmd:        baseCmd{log: log},
+		db:             db,
+		hostFSMountDir: hostFSMountDir,
+	}
+}
+
+// GetSteps returns the step configuring the agent to reach the peer hub that the host is migrated
+// to, until the agent replies. No step is returned when the host isn't migrated.
+func (c *migrateAgentCmd) GetSteps(ctx context.Context, host *models.Host) ([]*models.Step, error) {
+	migration, err := federation.Pending(c.db, host.InfraEnvID, *host.ID)
+	if err != nil || migration == nil {
+		return nil, err
+	}
+
+	request := models.MigrateAgentRequest{
+		ServiceBaseURL: swag.String(migration.ServiceBaseURL),
+		InfraEnvID:     &migration.PeerInfraEnvID,
+		Token:          migration.Token,
+		HostFsMountDir: swag.String(c.hostFSMountDir),
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal MigrateAgentRequest: %w", err)
+	}
+
+	return []*models.Step{{
+		StepType: models.StepTypeMigrateAgent,
+		Args: []string{
+			string(data),
+		},
+	}}, nil
+}
@@ -0,0 +1,88 @@
+package hostcommands
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/federation"
+	"github.com/openshift/assisted-service/internal/host/hostutil"
+	"github.com/openshift/assisted-service/models"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Migrate agent command", func() {
+	var ctx context.Context
+	var host models.Host
+	var db *gorm.DB
+	var cmd CommandGetter
+	var id, infraEnvId, peerInfraEnvId strfmt.UUID
+	var dbName string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		db, dbName = common.PrepareTestDB()
+		id = strfmt.UUID(uuid.New().String())
+		infraEnvId = strfmt.UUID(uuid.New().String())
+		peerInfraEnvId = strfmt.UUID(uuid.New().String())
+		host = hostutil.GenerateUnassignedTestHost(id, infraEnvId, models.HostStatusKnownUnbound)
+		Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+		cmd = NewMigrateAgentCmd(common.GetTestLog(), db, "/host")
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	startMigration := func() {
+		Expect(federation.Start(db, &common.HostMigration{
+			HostID:         id,
+			InfraEnvID:     infraEnvId,
+			ServiceBaseURL: "https://peer.example.com",
+			PeerInfraEnvID: peerInfraEnvId,
+			Token:          "peer-token",
+		})).To(Succeed())
+	}
+
+	It("Doesn't create a step when the host isn't migrated", func() {
+		reply, err := cmd.GetSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reply).To(BeEmpty())
+	})
+
+	It("Configures the agent to reach the peer hub", func() {
+		startMigration()
+		reply, err := cmd.GetSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reply).To(HaveLen(1))
+		Expect(reply[0].StepType).To(Equal(models.StepTypeMigrateAgent))
+
+		var request models.MigrateAgentRequest
+		Expect(json.Unmarshal([]byte(reply[0].Args[0]), &request)).To(Succeed())
+		Expect(request.Validate(strfmt.Default)).To(Succeed())
+		Expect(swag.StringValue(request.ServiceBaseURL)).To(Equal("https://peer.example.com"))
+		Expect(*request.InfraEnvID).To(Equal(peerInfraEnvId))
+		Expect(request.Token).To(Equal("peer-token"))
+		Expect(swag.StringValue(request.HostFsMountDir)).To(Equal("/host"))
+	})
+
+	It("Doesn't create a step once the agent replied", func() {
+		startMigration()
+		recorded, err := federation.RecordResult(db, infraEnvId, id, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorded).To(BeTrue())
+		reply, err := cmd.GetSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reply).To(BeEmpty())
+
+		migration, err := federation.Get(db, infraEnvId, id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migration.MigratedAt).ToNot(BeNil())
+		Expect(migration.Token).To(BeEmpty())
+	})
+})
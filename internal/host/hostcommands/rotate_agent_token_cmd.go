@@ -0,0 +1,73 @@
+package hostcommands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/openshift/assisted-service/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type rotateAgentTokenCmd struct {
+	baseCmd
+	db *gorm.DB
+}
+
+func NewRotateAgentTokenCmd(log logrus.FieldLogger, db *gorm.DB) *rotateAgentTokenCmd {
+	return &rotateAgentTokenCmd{
+		baseCmd: baseCmd{log: log},
+		db:      db,
+	}
+}
+
+// GetSteps returns the step delivering the newest agent token of the host, until the agent confirms
+// that it uses it. No step is returned when the host has no pending token.
+func (c *rotateAgentTokenCmd) GetSteps(ctx context.Context, host *models.Host) ([]*models.Step, error) {
+	var token common.AgentToken
+	err := c.db.Where("host_id = ? AND infra_env_id = ? AND delivered_at IS NULL AND revoked_at IS NULL AND expires_at IS NULL",
+		host.ID.String(), host.InfraEnvID.String()).Order("created_at DESC").Take(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := gencrypto.LocalHostJWT(token.InfraEnvID.String(), token.HostID.String(), token.ID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(models.RotateAgentTokenRequest{Token: signed})
+	if err != nil {
+		return nil, err
+	}
+
+	return []*models.Step{{
+		StepType: models.StepTypeRotateAgentToken,
+		StepID:   RotateAgentTokenStepID(token.ID),
+		Args: []string{
+			string(data),
+		},
+	}}, nil
+}
+
+// RotateAgentTokenStepID returns the ID of the step delivering an agent token, the reply of the
+// agent identifies the token with it
+func RotateAgentTokenStepID(tokenID string) string {
+	return fmt.Sprintf("%s-%s", models.StepTypeRotateAgentToken, tokenID)
+}
+
+// AgentTokenIDFromStepID returns the ID of the agent token delivered by the step
+func AgentTokenIDFromStepID(stepID string) (string, bool) {
+	prefix := string(models.StepTypeRotateAgentToken) + "-"
+	if !strings.HasPrefix(stepID, prefix) || len(stepID) == len(prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(stepID, prefix), true
+}
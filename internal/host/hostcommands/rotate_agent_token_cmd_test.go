@@ -0,0 +1,88 @@
+package hostcommands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/openshift/assisted-service/internal/host/hostutil"
+	"github.com/openshift/assisted-service/models"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Rotate agent token command", func() {
+	var ctx context.Context
+	var host models.Host
+	var db *gorm.DB
+	var cmd CommandGetter
+	var id, clusterId, infraEnvId strfmt.UUID
+	var dbName string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		db, dbName = common.PrepareTestDB()
+		id = strfmt.UUID(uuid.New().String())
+		clusterId = strfmt.UUID(uuid.New().String())
+		infraEnvId = strfmt.UUID(uuid.New().String())
+		host = hostutil.GenerateTestHost(id, infraEnvId, clusterId, models.HostStatusInsufficient)
+		Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+		_, priv, err := gencrypto.ECDSAKeyPairPEM()
+		Expect(err).NotTo(HaveOccurred())
+		os.Setenv("EC_PRIVATE_KEY_PEM", priv)
+		cmd = NewRotateAgentTokenCmd(common.GetTestLog(), db)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("EC_PRIVATE_KEY_PEM")
+		common.DeleteTestDB(db, dbName)
+	})
+
+	createToken := func(createdAt time.Time) *common.AgentToken {
+		token := &common.AgentToken{ID: uuid.New().String(), InfraEnvID: infraEnvId, HostID: id, CreatedAt: createdAt}
+		Expect(db.Create(token).Error).ShouldNot(HaveOccurred())
+		return token
+	}
+
+	It("Doesn't create a step when the host has no pending token", func() {
+		reply, err := cmd.GetSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reply).To(BeEmpty())
+
+		now := time.Now()
+		delivered := createToken(now)
+		Expect(db.Model(delivered).Update("delivered_at", now).Error).ShouldNot(HaveOccurred())
+		reply, err = cmd.GetSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reply).To(BeEmpty())
+	})
+
+	It("Delivers the newest token of the host", func() {
+		createToken(time.Now().Add(-time.Minute))
+		newest := createToken(time.Now())
+
+		reply, err := cmd.GetSteps(ctx, &host)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reply).To(HaveLen(1))
+		Expect(reply[0].StepType).To(Equal(models.StepTypeRotateAgentToken))
+		tokenID, ok := AgentTokenIDFromStepID(reply[0].StepID)
+		Expect(ok).To(BeTrue())
+		Expect(tokenID).To(Equal(newest.ID))
+
+		var request models.RotateAgentTokenRequest
+		Expect(json.Unmarshal([]byte(reply[0].Args[0]), &request)).To(Succeed())
+		claims := jwt.MapClaims{}
+		_, _, err = jwt.NewParser().ParseUnverified(request.Token, claims)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claims[gencrypto.TokenIDClaim]).To(Equal(newest.ID))
+		Expect(claims[string(gencrypto.HostKey)]).To(Equal(id.String()))
+		Expect(claims[string(gencrypto.InfraEnvKey)]).To(Equal(infraEnvId.String()))
+	})
+})
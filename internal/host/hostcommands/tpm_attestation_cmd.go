@@ -0,0 +1,49 @@
+package hostcommands
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/openshift/assisted-service/internal/attestation"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type tpmAttestationCmd struct {
+	baseCmd
+	db *gorm.DB
+}
+
+func NewTpmAttestationCmd(log logrus.FieldLogger, db *gorm.DB) *tpmAttestationCmd {
+	return &tpmAttestationCmd{
+		baseCmd: baseCmd{log: log},
+		db:      db,
+	}
+}
+
+// GetSteps returns the attestation challenge of the host pending attestation. No step is returned
+// when the endorsement of the host is rejected by the policy of the infra-env, the host has to
+// register again.
+func (c *tpmAttestationCmd) GetSteps(ctx context.Context, host *models.Host) ([]*models.Step, error) {
+	infraEnv, err := common.GetInfraEnvFromDB(c.db, host.InfraEnvID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := attestation.NewChallenge(c.db, infraEnv, host)
+	if err != nil || request == nil {
+		return nil, err
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*models.Step{{
+		StepType: models.StepTypeTpmAttestation,
+		Args: []string{
+			string(data),
+		},
+	}}, nil
+}
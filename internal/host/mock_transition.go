@@ -130,6 +130,21 @@ func (mr *MockTransitionHandlerMockRecorder) HostNotResponsiveWhilePreparingInst
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostNotResponsiveWhilePreparingInstallation", reflect.TypeOf((*MockTransitionHandler)(nil).HostNotResponsiveWhilePreparingInstallation), sw, args)
 }
 
+// IsAttestationPending mocks base method.
+func (m *MockTransitionHandler) IsAttestationPending(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAttestationPending", sw, args)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAttestationPending indicates an expected call of IsAttestationPending.
+func (mr *MockTransitionHandlerMockRecorder) IsAttestationPending(sw, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAttestationPending", reflect.TypeOf((*MockTransitionHandler)(nil).IsAttestationPending), sw, args)
+}
+
 // IsClusterPostInstallation mocks base method.
 func (m *MockTransitionHandler) IsClusterPostInstallation(sw stateswitch.StateSwitch, arg1 stateswitch.TransitionArgs) (bool, error) {
 	m.ctrl.T.Helper()
@@ -390,6 +405,20 @@ func (mr *MockTransitionHandlerMockRecorder) PostReclaim(sw, args any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostReclaim", reflect.TypeOf((*MockTransitionHandler)(nil).PostReclaim), sw, args)
 }
 
+// PostRefreshAttestation mocks base method.
+func (m *MockTransitionHandler) PostRefreshAttestation(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostRefreshAttestation", sw, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostRefreshAttestation indicates an expected call of PostRefreshAttestation.
+func (mr *MockTransitionHandlerMockRecorder) PostRefreshAttestation(sw, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostRefreshAttestation", reflect.TypeOf((*MockTransitionHandler)(nil).PostRefreshAttestation), sw, args)
+}
+
 // PostRefreshHost mocks base method.
 func (m *MockTransitionHandler) PostRefreshHost(reason string) stateswitch.PostTransition {
 	m.ctrl.T.Helper()
@@ -34,6 +34,7 @@ func (m *Manager) initMonitoringQueryGenerator() {
 				models.HostStatusInstallingInProgress,
 				models.HostStatusInstallingPendingUserAction,
 				models.HostStatusResettingPendingUserAction,
+				models.HostStatusPendingAttestation,
 			}
 
 			// monitor following states for limited time, until log collection finished or timed-out
@@ -269,6 +270,7 @@ func (m *Manager) infraEnvHostMonitoring() {
 		models.HostStatusKnownUnbound,
 		models.HostStatusReclaiming,
 		models.HostStatusReclaimingRebooting,
+		models.HostStatusPendingAttestation,
 	}
 
 	query := m.monitorInfraEnvQueryGenerator.NewInfraEnvQuery()
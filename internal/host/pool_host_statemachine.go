@@ -44,6 +44,34 @@ func NewPoolHostStateMachine(sm stateswitch.StateMachine, th TransitionHandler)
 		},
 	})
 
+	sm.AddTransitionRule(stateswitch.TransitionRule{
+		TransitionType: TransitionTypeRegisterHost,
+		SourceStates: []stateswitch.State{
+			stateswitch.State(models.HostStatusPendingAttestation),
+		},
+		Condition:        stateswitch.And(stateswitch.Not(th.IsAttestationPending), th.IsUnboundHost),
+		DestinationState: stateswitch.State(models.HostStatusDiscoveringUnbound),
+		PostTransition:   th.PostRegisterHost,
+		Documentation: stateswitch.TransitionRuleDoc{
+			Name:        "Registration of an unbound host no longer pending attestation",
+			Description: "When the infra-env no longer requires the attestation, the unbound host pending attestation registers like a new host",
+		},
+	})
+
+	sm.AddTransitionRule(stateswitch.TransitionRule{
+		TransitionType: TransitionTypeRefresh,
+		SourceStates: []stateswitch.State{
+			stateswitch.State(models.HostStatusPendingAttestation),
+		},
+		Condition:        stateswitch.And(stateswitch.Not(th.IsAttestationPending), th.IsUnboundHost),
+		DestinationState: stateswitch.State(models.HostStatusDiscoveringUnbound),
+		PostTransition:   th.PostRefreshHost(statusInfoDiscovering),
+		Documentation: stateswitch.TransitionRuleDoc{
+			Name:        "Unbound host attested",
+			Description: "Once the unbound host attested, or the infra-env no longer requires the attestation, the discovery of the host starts",
+		},
+	})
+
 	sm.AddTransitionRule(stateswitch.TransitionRule{
 		TransitionType: TransitionTypeBindHost,
 		SourceStates: []stateswitch.State{
@@ -110,7 +138,8 @@ func NewPoolHostStateMachine(sm stateswitch.StateMachine, th TransitionHandler)
 	})
 
 	var hasMinRequiredHardware = stateswitch.And(If(HasMinValidDisks), If(HasMinCPUCores), If(HasMinMemory))
-	sufficientToBeBound := stateswitch.And(hasMinRequiredHardware, If(IsHostnameValid))
+	var hasRequiredFirmware = stateswitch.And(If(AreSecureBootRequirementsSatisfied), If(AreFirmwareRequirementsSatisfied), If(AreRAIDRequirementsSatisfied))
+	sufficientToBeBound := stateswitch.And(hasMinRequiredHardware, If(IsHostnameValid), If(AreApprovalRulesSatisfied), hasRequiredFirmware)
 
 	sm.AddTransitionRule(stateswitch.TransitionRule{
 		TransitionType: TransitionTypeRefresh,
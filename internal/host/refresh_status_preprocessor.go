@@ -328,6 +328,22 @@ func newValidations(v *validator) []validation {
 			id:        NoIscsiNicBelongsToMachineCidr,
 			condition: v.noIscsiNicBelongsToMachineCidr,
 		},
+		{
+			id:        AreApprovalRulesSatisfied,
+			condition: v.areApprovalRulesSatisfied,
+		},
+		{
+			id:        AreSecureBootRequirementsSatisfied,
+			condition: v.areSecureBootRequirementsSatisfied,
+		},
+		{
+			id:        AreFirmwareRequirementsSatisfied,
+			condition: v.areFirmwareRequirementsSatisfied,
+		},
+		{
+			id:        AreRAIDRequirementsSatisfied,
+			condition: v.areRAIDRequirementsSatisfied,
+		},
 	}
 }
 
@@ -29,6 +29,92 @@ func NewHostStateMachine(sm stateswitch.StateMachine, th TransitionHandler) stat
 	documentStates(sm)
 	documentTransitionTypes(sm)
 
+	// The attestation rules come first, the hosts pending attestation must not reach the other states
+	sm.AddTransitionRule(stateswitch.TransitionRule{
+		TransitionType: TransitionTypeRegisterHost,
+		SourceStates: []stateswitch.State{
+			"",
+			stateswitch.State(models.HostStatusDiscovering),
+			stateswitch.State(models.HostStatusKnown),
+			stateswitch.State(models.HostStatusDisconnected),
+			stateswitch.State(models.HostStatusInsufficient),
+			stateswitch.State(models.HostStatusResettingPendingUserAction),
+			stateswitch.State(models.HostStatusBinding),
+			stateswitch.State(models.HostStatusPendingForInput),
+			stateswitch.State(models.HostStatusDiscoveringUnbound),
+			stateswitch.State(models.HostStatusDisconnectedUnbound),
+			stateswitch.State(models.HostStatusInsufficientUnbound),
+			stateswitch.State(models.HostStatusKnownUnbound),
+			stateswitch.State(models.HostStatusUnbinding),
+			stateswitch.State(models.HostStatusUnbindingPendingUserAction),
+			stateswitch.State(models.HostStatusReclaimingRebooting),
+			stateswitch.State(models.HostStatusPendingAttestation),
+		},
+		Condition:        th.IsAttestationPending,
+		DestinationState: stateswitch.State(models.HostStatusPendingAttestation),
+		PostTransition:   th.PostRegisterHost,
+		Documentation: stateswitch.TransitionRuleDoc{
+			Name:        "Registration pending attestation",
+			Description: "When the host registers to an infra-env that requires the hosts to attest their TPM, it's held until it attests, whether it's bound or not. The host attests again every time it registers",
+		},
+	})
+
+	sm.AddTransitionRule(stateswitch.TransitionRule{
+		TransitionType: TransitionTypeRegisterHost,
+		SourceStates: []stateswitch.State{
+			stateswitch.State(models.HostStatusResetting),
+		},
+		Condition:        stateswitch.And(stateswitch.Not(th.IsHostInReboot), th.IsAttestationPending),
+		DestinationState: stateswitch.State(models.HostStatusPendingAttestation),
+		PostTransition:   th.PostRegisterHost,
+		Documentation: stateswitch.TransitionRuleDoc{
+			Name:        "Register non-rebooting host in resetting pending attestation",
+			Description: "The same as 'Register non-rebooting host in resetting', when the host must attest its TPM",
+		},
+	})
+
+	sm.AddTransitionRule(stateswitch.TransitionRule{
+		TransitionType: TransitionTypeRegisterHost,
+		SourceStates: []stateswitch.State{
+			stateswitch.State(models.HostStatusPendingAttestation),
+		},
+		Condition:        stateswitch.And(stateswitch.Not(th.IsAttestationPending), stateswitch.Not(th.IsUnboundHost)),
+		DestinationState: stateswitch.State(models.HostStatusDiscovering),
+		PostTransition:   th.PostRegisterHost,
+		Documentation: stateswitch.TransitionRuleDoc{
+			Name:        "Registration of a host no longer pending attestation",
+			Description: "When the infra-env no longer requires the attestation, the host pending attestation registers like a new host",
+		},
+	})
+
+	sm.AddTransitionRule(stateswitch.TransitionRule{
+		TransitionType: TransitionTypeRefresh,
+		SourceStates: []stateswitch.State{
+			stateswitch.State(models.HostStatusPendingAttestation),
+		},
+		Condition:        th.IsAttestationPending,
+		DestinationState: stateswitch.State(models.HostStatusPendingAttestation),
+		PostTransition:   th.PostRefreshAttestation,
+		Documentation: stateswitch.TransitionRuleDoc{
+			Name:        "Host still pending attestation",
+			Description: "The host stays pending until it answers the attestation challenge with a quote that matches the policy of the infra-env, the status info tells why it failed the attestation",
+		},
+	})
+
+	sm.AddTransitionRule(stateswitch.TransitionRule{
+		TransitionType: TransitionTypeRefresh,
+		SourceStates: []stateswitch.State{
+			stateswitch.State(models.HostStatusPendingAttestation),
+		},
+		Condition:        stateswitch.And(stateswitch.Not(th.IsAttestationPending), stateswitch.Not(th.IsUnboundHost)),
+		DestinationState: stateswitch.State(models.HostStatusDiscovering),
+		PostTransition:   th.PostRefreshHost(statusInfoDiscovering),
+		Documentation: stateswitch.TransitionRuleDoc{
+			Name:        "Host attested",
+			Description: "Once the host attested, or the infra-env no longer requires the attestation, the discovery of the host starts",
+		},
+	})
+
 	sm.AddTransitionRule(stateswitch.TransitionRule{
 		TransitionType: TransitionTypeRegisterHost,
 		SourceStates: []stateswitch.State{
@@ -1030,6 +1116,10 @@ func NewHostStateMachine(sm stateswitch.StateMachine, th TransitionHandler) stat
 		If(NoSkipMissingDisk),
 		If(NoIPCollisionsInNetwork),
 		If(NoIscsiNicBelongsToMachineCidr),
+		If(AreApprovalRulesSatisfied),
+		If(AreSecureBootRequirementsSatisfied),
+		If(AreFirmwareRequirementsSatisfied),
+		If(AreRAIDRequirementsSatisfied),
 		If(AreNodeFeatureDiscoveryRequirementsSatisfied),
 		If(AreNvidiaGPURequirementsSatisfied),
 		If(ArePipelinesRequirementsSatisfied),
@@ -1231,6 +1321,10 @@ func NewHostStateMachine(sm stateswitch.StateMachine, th TransitionHandler) stat
 }
 
 func documentStates(sm stateswitch.StateMachine) {
+	sm.DescribeState(stateswitch.State(models.HostStatusPendingAttestation), stateswitch.StateDoc{
+		Name:        "Pending Attestation",
+		Description: "Hosts that registered to an infra-env that requires the hosts to attest their TPM are held in this state until they answer the attestation challenge with a quote that matches the policy of the infra-env. They can't be discovered or bound before that",
+	})
 	sm.DescribeState(stateswitch.State(models.HostStatusDiscovering), stateswitch.StateDoc{
 		Name:        "Discovering",
 		Description: "This is the first state that the host is in after it has been registered. We usually don't know much about the host at this point, unless it reached this state through other circumstances",
@@ -11,6 +11,7 @@ import (
 	"github.com/filanov/stateswitch"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/attestation"
 	"github.com/openshift/assisted-service/internal/common"
 	eventgen "github.com/openshift/assisted-service/internal/common/events"
 	"github.com/openshift/assisted-service/internal/constants"
@@ -45,6 +46,7 @@ type TransitionHandler interface {
 	IsDay2Host(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) (bool, error)
 	IsHostInDone(sw stateswitch.StateSwitch, _ stateswitch.TransitionArgs) (bool, error)
 	IsHostInReboot(sw stateswitch.StateSwitch, _ stateswitch.TransitionArgs) (bool, error)
+	IsAttestationPending(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) (bool, error)
 	IsLogCollectionTimedOut(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) (bool, error)
 	IsUnboundHost(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) (bool, error)
 	IsValidRoleForInstallation(sw stateswitch.StateSwitch, _ stateswitch.TransitionArgs) (bool, error)
@@ -56,6 +58,7 @@ type TransitionHandler interface {
 	PostInstallHost(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error
 	PostPreparingForInstallationHost(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error
 	PostReclaim(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error
+	PostRefreshAttestation(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error
 	PostRefreshHost(reason string) stateswitch.PostTransition
 	PostHostStageTimeout(reason string) stateswitch.PostTransition
 	PostRefreshHostDisconnection(statusInfo string, connectionTimedOut bool) stateswitch.PostTransition
@@ -117,27 +120,36 @@ func (th *transitionHandler) PostRegisterHost(sw stateswitch.StateSwitch, args s
 		extra := append(resetFields[:], "discovery_agent_version", params.discoveryAgentVersion, "ntp_sources", "", "kind", hostParam.Kind)
 		extra = append(extra, resetLogsField...)
 
-		// Use the destination state determined by the state machine (could be discovering or discovering-unbound)
+		// Use the destination state determined by the state machine (could be discovering, discovering-unbound
+		// or pending-attestation)
 		// The state machine calls SetState before PostTransition, so hostParam.Status is already set
 		dstStatus := swag.StringValue(hostParam.Status)
-		// Use statusInfoDiscovering for both bound and unbound hosts (the state machine has already set the correct status)
+		statusInfo, err := th.registrationStatusInfo(params.db, hostParam)
+		if err != nil {
+			return err
+		}
 		var dbHost *common.Host
 		if dbHost, err = hostutil.UpdateHostStatus(params.ctx, log, params.db, th.eventsHandler, th.stream, hostParam.InfraEnvID, *hostParam.ID, sHost.srcState,
-			dstStatus, statusInfoDiscovering, extra...); err != nil {
+			dstStatus, statusInfo, extra...); err != nil {
 			return err
 		}
 		sHost.host = &dbHost.Host
 		return nil
 	}
-	// For new hosts, the state machine has already set the correct status (discovering or discovering-unbound)
+	// For new hosts, the state machine has already set the correct status (discovering, discovering-unbound
+	// or pending-attestation)
+	statusInfo, err := th.registrationStatusInfo(params.db, hostParam)
+	if err != nil {
+		return err
+	}
 	hostParam.StatusUpdatedAt = strfmt.DateTime(time.Now())
-	hostParam.StatusInfo = swag.String(statusInfoDiscovering)
+	hostParam.StatusInfo = swag.String(statusInfo)
 	hostToCreate := &common.Host{
 		Host:                    *hostParam,
 		TriggerMonitorTimestamp: time.Now(),
 	}
 	log.Infof("Register new host %s infra env %s", hostToCreate.ID.String(), hostToCreate.InfraEnvID)
-	err := params.db.Create(hostToCreate).Error
+	err = params.db.Create(hostToCreate).Error
 	if err != nil {
 		return err
 	}
@@ -151,6 +163,26 @@ func (th *transitionHandler) PostRegisterHost(sw stateswitch.StateSwitch, args s
 	return nil
 }
 
+func (th *transitionHandler) registrationStatusInfo(db *gorm.DB, host *models.Host) (string, error) {
+	if swag.StringValue(host.Status) != models.HostStatusPendingAttestation {
+		return statusInfoDiscovering, nil
+	}
+	return attestationStatusInfo(db, host)
+}
+
+// attestationStatusInfo returns the status info of a host pending attestation, with the reason why
+// it failed the attestation if it did
+func attestationStatusInfo(db *gorm.DB, host *models.Host) (string, error) {
+	reason, err := attestation.FailureReason(db, host.InfraEnvID, *host.ID)
+	if err != nil {
+		return "", err
+	}
+	if reason != "" {
+		return fmt.Sprintf(statusInfoAttestationFailed, reason), nil
+	}
+	return statusInfoPendingAttestation, nil
+}
+
 func (th *transitionHandler) PostRegisterDuringInstallation(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error {
 	sHost, ok := sw.(*stateHost)
 	if !ok {
@@ -908,6 +940,28 @@ func (th *transitionHandler) PostRefreshHost(reason string) stateswitch.PostTran
 	return ret
 }
 
+func (th *transitionHandler) PostRefreshAttestation(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error {
+	sHost, ok := sw.(*stateHost)
+	if !ok {
+		return errors.New("PostRefreshAttestation incompatible type of StateSwitch")
+	}
+	params, ok := args.(*TransitionArgsRefreshHost)
+	if !ok {
+		return errors.New("PostRefreshAttestation invalid argument")
+	}
+	statusInfo, err := attestationStatusInfo(params.db, sHost.host)
+	if err != nil {
+		return err
+	}
+	statusInfo = hostutil.TruncateStatusInfo(statusInfo, th.log)
+	if sHost.srcState != swag.StringValue(sHost.host.Status) || swag.StringValue(sHost.host.StatusInfo) != statusInfo {
+		_, err = hostutil.UpdateHostStatus(params.ctx, logutil.FromContext(params.ctx, th.log), params.db,
+			th.eventsHandler, th.stream, sHost.host.InfraEnvID, *sHost.host.ID,
+			sHost.srcState, swag.StringValue(sHost.host.Status), statusInfo)
+	}
+	return err
+}
+
 func (th *transitionHandler) PostHostStageTimeout(reason string) stateswitch.PostTransition {
 	ret := func(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) error {
 		// Not using reason directly to avoid closures issue.
@@ -972,6 +1026,25 @@ func (th *transitionHandler) IsUnboundHost(sw stateswitch.StateSwitch, args stat
 	return hostutil.IsUnboundHost(sHost.host), nil
 }
 
+// IsAttestationPending checks that the host registered to an infra-env that requires the hosts to
+// attest their TPM, and that it didn't attest since it registered
+func (th *transitionHandler) IsAttestationPending(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) (bool, error) {
+	sHost, ok := sw.(*stateHost)
+	if !ok {
+		return false, errors.New("IsAttestationPending incompatible type of StateSwitch")
+	}
+	var db *gorm.DB
+	switch params := args.(type) {
+	case *TransitionArgsRegisterHost:
+		db = params.db
+	case *TransitionArgsRefreshHost:
+		db = params.db
+	default:
+		return false, errors.New("IsAttestationPending invalid argument")
+	}
+	return attestation.IsPending(db, sHost.host.InfraEnvID, *sHost.host.ID)
+}
+
 func (th *transitionHandler) HostNotResponsiveWhilePreparingInstallation(sw stateswitch.StateSwitch, args stateswitch.TransitionArgs) (bool, error) {
 	sHost, ok := sw.(*stateHost)
 	if !ok {
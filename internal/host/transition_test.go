@@ -839,6 +839,54 @@ var _ = Describe("RegisterHost", func() {
 		}
 
 	})
+
+	Context("register pending attestation", func() {
+		pend := func(attested bool, reason string) {
+			record := common.HostAttestation{HostID: hostId, InfraEnvID: infraEnvId, Error: reason}
+			if attested {
+				record.AttestedAt = swag.Time(time.Now())
+			}
+			Expect(db.Create(&record).Error).ShouldNot(HaveOccurred())
+		}
+
+		It("holds the new host pending attestation", func() {
+			pend(false, "")
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(eventgen.HostRegistrationSucceededEventName),
+				eventstest.WithInfraEnvIdMatcher(infraEnvId.String())))
+			Expect(hapi.RegisterHost(ctx, &models.Host{ID: &hostId, InfraEnvID: infraEnvId}, db)).ShouldNot(HaveOccurred())
+			h := hostutil.GetHostFromDB(hostId, infraEnvId, db)
+			Expect(swag.StringValue(h.Status)).Should(Equal(models.HostStatusPendingAttestation))
+			Expect(swag.StringValue(h.StatusInfo)).Should(Equal(statusInfoPendingAttestation))
+		})
+
+		It("holds the known host pending attestation with the reason of the failure", func() {
+			pend(false, "the agent didn't send the TPM endorsement of the host")
+			host := hostutil.GenerateTestHost(hostId, infraEnvId, clusterId, models.HostStatusKnown)
+			Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(eventgen.HostStatusUpdatedEventName),
+				eventstest.WithHostIdMatcher(hostId.String())))
+			Expect(hapi.RegisterHost(ctx, &models.Host{ID: &hostId, InfraEnvID: infraEnvId, ClusterID: &clusterId}, db)).ShouldNot(HaveOccurred())
+			h := hostutil.GetHostFromDB(hostId, infraEnvId, db)
+			Expect(swag.StringValue(h.Status)).Should(Equal(models.HostStatusPendingAttestation))
+			Expect(swag.StringValue(h.StatusInfo)).Should(Equal(
+				"The host failed the TPM attestation: the agent didn't send the TPM endorsement of the host"))
+		})
+
+		It("discovers the attested host", func() {
+			pend(true, "")
+			host := hostutil.GenerateTestHost(hostId, infraEnvId, clusterId, models.HostStatusPendingAttestation)
+			host.ClusterID = nil
+			Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(eventgen.HostStatusUpdatedEventName),
+				eventstest.WithHostIdMatcher(hostId.String())))
+			Expect(hapi.RegisterHost(ctx, &models.Host{ID: &hostId, InfraEnvID: infraEnvId}, db)).ShouldNot(HaveOccurred())
+			h := hostutil.GetHostFromDB(hostId, infraEnvId, db)
+			Expect(swag.StringValue(h.Status)).Should(Equal(models.HostStatusDiscoveringUnbound))
+		})
+	})
 })
 
 var _ = Describe("HostInstallationFailed", func() {
@@ -7015,6 +7063,10 @@ var allValidationIDs = []validationID{
 	AreMetalLBRequirementsSatisfied,
 	AreLokiRequirementsSatisfied,
 	AreOpenShiftLoggingRequirementsSatisfied,
+	AreApprovalRulesSatisfied,
+	AreSecureBootRequirementsSatisfied,
+	AreFirmwareRequirementsSatisfied,
+	AreRAIDRequirementsSatisfied,
 }
 
 var allConditions = []conditionId{
@@ -84,6 +84,10 @@ const (
 	AreMetalLBRequirementsSatisfied                = validationID(models.HostValidationIDMetallbRequirementsSatisfied)
 	AreLokiRequirementsSatisfied                   = validationID(models.HostValidationIDLokiRequirementsSatisfied)
 	AreOpenShiftLoggingRequirementsSatisfied       = validationID(models.HostValidationIDOpenshiftLoggingRequirementsSatisfied)
+	AreApprovalRulesSatisfied                      = validationID(models.HostValidationIDApprovalRulesSatisfied)
+	AreSecureBootRequirementsSatisfied             = validationID(models.HostValidationIDSecureBootRequirementsSatisfied)
+	AreFirmwareRequirementsSatisfied               = validationID(models.HostValidationIDFirmwareRequirementsSatisfied)
+	AreRAIDRequirementsSatisfied                   = validationID(models.HostValidationIDRaidRequirementsSatisfied)
 )
 
 func (v validationID) category() (string, error) {
@@ -127,7 +131,11 @@ func (v validationID) category() (string, error) {
 		DiskEncryptionRequirementsSatisfied,
 		CompatibleAgent,
 		NoSkipInstallationDisk,
-		NoSkipMissingDisk:
+		NoSkipMissingDisk,
+		AreApprovalRulesSatisfied,
+		AreSecureBootRequirementsSatisfied,
+		AreFirmwareRequirementsSatisfied,
+		AreRAIDRequirementsSatisfied:
 		return "hardware", nil
 	case AreLsoRequirementsSatisfied,
 		AreOdfRequirementsSatisfied,
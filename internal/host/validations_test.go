@@ -2035,6 +2035,138 @@ var _ = Describe("Validations test", func() {
 		}
 	})
 
+	Context("Approval rules satisfied", func() {
+		var host models.Host
+
+		BeforeEach(func() {
+			cluster := hostutil.GenerateTestCluster(clusterID)
+			Expect(db.Create(&cluster).Error).ToNot(HaveOccurred())
+			mockProviderRegistry.EXPECT().IsHostSupported(commontesting.EqPlatformType(models.PlatformTypeVsphere), gomock.Any()).Return(false, nil).AnyTimes()
+			mockVersions.EXPECT().GetReleaseImage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&models.ReleaseImage{URL: swag.String("quay.io/openshift/some-image::latest")}, nil).AnyTimes()
+			host = hostutil.GenerateTestHostByKind(hostID, infraEnvID, &clusterID, models.HostStatusDiscovering, models.HostKindHost, models.HostRoleMaster)
+			host.Inventory = hostutil.GenerateMasterInventory()
+			Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+		})
+
+		createInfraEnv := func(policy string) {
+			infraEnv := hostutil.GenerateTestInfraEnv(infraEnvID)
+			if policy != "" {
+				infraEnv.HostApprovalPolicy = swag.String(policy)
+			}
+			Expect(db.Create(infraEnv).Error).ToNot(HaveOccurred())
+		}
+
+		expectDecisionEvent := func(name string) {
+			mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+				eventstest.WithNameMatcher(name),
+				eventstest.WithHostIdMatcher(hostID.String()),
+				eventstest.WithInfraEnvIdMatcher(infraEnvID.String()),
+			))
+		}
+
+		It("is suppressed without approval policy", func() {
+			createInfraEnv("")
+			mockAndRefreshStatus(&host)
+			host = hostutil.GetHostFromDB(hostID, infraEnvID, db).Host
+			_, _, ok := getValidationResult(host.ValidationsInfo, AreApprovalRulesSatisfied)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("succeeds when a rule approves the host", func() {
+			createInfraEnv(`{"rules":[{"name":"masters","action":"approve","min_cpu_cores":1}],"default_action":"quarantine"}`)
+			expectDecisionEvent(eventgen.HostApprovalDecidedEventName)
+			mockAndRefreshStatus(&host)
+			host = hostutil.GetHostFromDB(hostID, infraEnvID, db).Host
+			status, message, ok := getValidationResult(host.ValidationsInfo, AreApprovalRulesSatisfied)
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(ValidationSuccess))
+			Expect(message).To(Equal("Host approved by the approval rule masters"))
+		})
+
+		It("fails when the host is quarantined", func() {
+			createInfraEnv(`{"rules":[{"name":"lab","action":"approve","serial_numbers":["LAB-*"]}],"default_action":"quarantine"}`)
+			expectDecisionEvent(eventgen.HostQuarantinedEventName)
+			mockAndRefreshStatus(&host)
+			host = hostutil.GetHostFromDB(hostID, infraEnvID, db).Host
+			status, message, ok := getValidationResult(host.ValidationsInfo, AreApprovalRulesSatisfied)
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(ValidationFailure))
+			Expect(message).To(Equal("Host quarantined by the default action of the approval policy, no approval rule matches it"))
+		})
+	})
+
+	Context("Firmware requirements satisfied", func() {
+		var host models.Host
+
+		BeforeEach(func() {
+			cluster := hostutil.GenerateTestCluster(clusterID)
+			Expect(db.Create(&cluster).Error).ToNot(HaveOccurred())
+			mockProviderRegistry.EXPECT().IsHostSupported(commontesting.EqPlatformType(models.PlatformTypeVsphere), gomock.Any()).Return(false, nil).AnyTimes()
+			mockVersions.EXPECT().GetReleaseImage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&models.ReleaseImage{URL: swag.String("quay.io/openshift/some-image::latest")}, nil).AnyTimes()
+			host = hostutil.GenerateTestHostByKind(hostID, infraEnvID, &clusterID, models.HostStatusDiscovering, models.HostKindHost, models.HostRoleMaster)
+			host.Inventory = hostutil.GenerateMasterInventory()
+			host.BmcInventory = `{"bios_version":"2.19.1","boot_mode":"UEFI","firmware_settings":{"ProcVirtualization":"Enabled"},"raid_volumes":[{"level":"1","physical_disks":2}]}`
+		})
+
+		createInfraEnv := func(requirements string) {
+			infraEnv := hostutil.GenerateTestInfraEnv(infraEnvID)
+			if requirements != "" {
+				infraEnv.FirmwareRequirements = swag.String(requirements)
+			}
+			Expect(db.Create(infraEnv).Error).ToNot(HaveOccurred())
+		}
+
+		refreshAndGetResult := func(id validationID) (ValidationStatus, string, bool) {
+			Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+			mockAndRefreshStatus(&host)
+			host = hostutil.GetHostFromDB(hostID, infraEnvID, db).Host
+			return getValidationResult(host.ValidationsInfo, id)
+		}
+
+		It("is suppressed without firmware requirements", func() {
+			createInfraEnv("")
+			_, _, ok := refreshAndGetResult(AreSecureBootRequirementsSatisfied)
+			Expect(ok).To(BeFalse())
+			for _, id := range []validationID{AreFirmwareRequirementsSatisfied, AreRAIDRequirementsSatisfied} {
+				_, _, ok = getValidationResult(host.ValidationsInfo, id)
+				Expect(ok).To(BeFalse())
+			}
+		})
+
+		It("fails when secure boot is required and disabled", func() {
+			createInfraEnv(`{"secure_boot":true}`)
+			status, message, ok := refreshAndGetResult(AreSecureBootRequirementsSatisfied)
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(ValidationFailure))
+			Expect(message).To(Equal("Secure boot is disabled on the host, the infra-env requires it to be enabled"))
+		})
+
+		It("succeeds when the firmware matches the requirements", func() {
+			createInfraEnv(`{"bios_versions":["2.19.*"],"bios_settings":{"ProcVirtualization":"Enabled"}}`)
+			status, _, ok := refreshAndGetResult(AreFirmwareRequirementsSatisfied)
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(ValidationSuccess))
+		})
+
+		It("fails when a RAID volume is missing", func() {
+			createInfraEnv(`{"raid_levels":["1","5"]}`)
+			status, message, ok := refreshAndGetResult(AreRAIDRequirementsSatisfied)
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(ValidationFailure))
+			Expect(message).To(Equal("The host is missing RAID volumes of levels 5 required by the infra-env, it has RAID volumes of levels 1"))
+		})
+
+		It("is pending without the BMC inventory", func() {
+			createInfraEnv(`{"raid_levels":["1"]}`)
+			host.BmcInventory = ""
+			status, _, ok := refreshAndGetResult(AreRAIDRequirementsSatisfied)
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(ValidationPending))
+		})
+	})
+
 	Context("Has sufficient packet loss requirements for role", func() {
 		var (
 			host    models.Host
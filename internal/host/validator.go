@@ -15,10 +15,12 @@ import (
 	ignition_types "github.com/coreos/ignition/v2/config/v3_2/types"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/bmcinventory"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/constants"
 	"github.com/openshift/assisted-service/internal/hardware"
 	"github.com/openshift/assisted-service/internal/host/hostutil"
+	"github.com/openshift/assisted-service/internal/hostapproval"
 	"github.com/openshift/assisted-service/internal/network"
 	"github.com/openshift/assisted-service/internal/operators"
 	"github.com/openshift/assisted-service/internal/provider/registry"
@@ -102,6 +104,11 @@ type validationContext struct {
 	softTimeoutsEnabled     bool
 	objectHandler           s3wrapper.API
 	ctx                     context.Context
+	// hostApprovalPolicy and firmwareRequirements are loaded together on the first use,
+	// infraEnvPoliciesLoaded tells missing policies apart from ones that aren't loaded yet
+	hostApprovalPolicy     *models.HostApprovalPolicy
+	firmwareRequirements   *models.FirmwareRequirements
+	infraEnvPoliciesLoaded bool
 }
 
 type validationCondition func(context *validationContext) (ValidationStatus, string)
@@ -128,6 +135,55 @@ func (c *validationContext) loadInfraEnv() error {
 	return err
 }
 
+// loadInfraEnvPolicies loads the approval policy and the firmware requirements of the infra-env of
+// the host, the infra-env isn't loaded for the hosts bound to a cluster, only its policies are read
+func (c *validationContext) loadInfraEnvPolicies() error {
+	if c.infraEnvPoliciesLoaded {
+		return nil
+	}
+	infraEnv := c.infraEnv
+	if infraEnv == nil {
+		var infraEnvs []*common.InfraEnv
+		if err := c.db.Select("id", "host_approval_policy", "firmware_requirements").Where("id = ?", c.host.InfraEnvID.String()).
+			Limit(1).Find(&infraEnvs).Error; err != nil {
+			return err
+		}
+		if len(infraEnvs) > 0 {
+			infraEnv = infraEnvs[0]
+		}
+	}
+	if infraEnv != nil {
+		policy, err := hostapproval.PolicyFromInfraEnv(infraEnv)
+		if err != nil {
+			return err
+		}
+		requirements, err := bmcinventory.RequirementsFromInfraEnv(infraEnv)
+		if err != nil {
+			return err
+		}
+		c.hostApprovalPolicy = policy
+		c.firmwareRequirements = requirements
+	}
+	c.infraEnvPoliciesLoaded = true
+	return nil
+}
+
+// loadHostApprovalPolicy returns the approval policy of the infra-env of the host
+func (c *validationContext) loadHostApprovalPolicy() (*models.HostApprovalPolicy, error) {
+	if err := c.loadInfraEnvPolicies(); err != nil {
+		return nil, err
+	}
+	return c.hostApprovalPolicy, nil
+}
+
+// loadFirmwareRequirements returns the firmware requirements of the infra-env of the host
+func (c *validationContext) loadFirmwareRequirements() (*models.FirmwareRequirements, error) {
+	if err := c.loadInfraEnvPolicies(); err != nil {
+		return nil, err
+	}
+	return c.firmwareRequirements, nil
+}
+
 func (c *validationContext) loadInventory() error {
 	inventory, err := c.inventoryCache.GetOrUnmarshal(c.host)
 	if inventory == nil || err != nil {
@@ -2062,3 +2118,75 @@ func (v *validator) multipathiSCSI(c *validationContext, installationDisk *model
 	}
 	return ValidationSuccess, msg
 }
+
+func (v *validator) areApprovalRulesSatisfied(c *validationContext) (ValidationStatus, string) {
+	policy, err := c.loadHostApprovalPolicy()
+	if err != nil {
+		return ValidationError, "Failed to load the host approval policy of the infra-env"
+	}
+	if policy == nil {
+		return ValidationSuccessSuppressOutput, ""
+	}
+	inventory, err := c.inventoryCache.GetOrUnmarshal(c.host)
+	if err != nil {
+		return ValidationError, "Failed to unmarshal this host's inventory"
+	}
+	if inventory == nil {
+		return ValidationPending, "Host inventory not available yet"
+	}
+	decision := hostapproval.Evaluate(policy, inventory)
+	if decision.Quarantined() {
+		return ValidationFailure, decision.Reason
+	}
+	return ValidationSuccess, decision.Reason
+}
+
+func (v *validator) areSecureBootRequirementsSatisfied(c *validationContext) (ValidationStatus, string) {
+	return v.checkFirmwareRequirement(c, func(requirements *models.FirmwareRequirements, bmcInventory *models.BmcInventory, inventory *models.Inventory) bmcinventory.Result {
+		return bmcinventory.CheckSecureBoot(requirements, bmcInventory, inventory)
+	})
+}
+
+func (v *validator) areFirmwareRequirementsSatisfied(c *validationContext) (ValidationStatus, string) {
+	return v.checkFirmwareRequirement(c, func(requirements *models.FirmwareRequirements, bmcInventory *models.BmcInventory, _ *models.Inventory) bmcinventory.Result {
+		return bmcinventory.CheckFirmware(requirements, bmcInventory)
+	})
+}
+
+func (v *validator) areRAIDRequirementsSatisfied(c *validationContext) (ValidationStatus, string) {
+	return v.checkFirmwareRequirement(c, func(requirements *models.FirmwareRequirements, bmcInventory *models.BmcInventory, _ *models.Inventory) bmcinventory.Result {
+		return bmcinventory.CheckRAID(requirements, bmcInventory)
+	})
+}
+
+// checkFirmwareRequirement checks a firmware requirement of the infra-env against the BMC inventory
+// reported by the BareMetalHost of the host
+func (v *validator) checkFirmwareRequirement(c *validationContext,
+	check func(*models.FirmwareRequirements, *models.BmcInventory, *models.Inventory) bmcinventory.Result) (ValidationStatus, string) {
+	requirements, err := c.loadFirmwareRequirements()
+	if err != nil {
+		return ValidationError, "Failed to load the firmware requirements of the infra-env"
+	}
+	if bmcinventory.IsEmpty(requirements) {
+		return ValidationSuccessSuppressOutput, ""
+	}
+	bmcInventory, err := bmcinventory.FromHost(c.host)
+	if err != nil {
+		return ValidationError, "Failed to parse the BMC inventory of the host"
+	}
+	inventory, err := c.inventoryCache.GetOrUnmarshal(c.host)
+	if err != nil {
+		return ValidationError, "Failed to unmarshal this host's inventory"
+	}
+	result := check(requirements, bmcInventory, inventory)
+	switch result.Status {
+	case bmcinventory.Pending:
+		return ValidationPending, result.Message
+	case bmcinventory.Satisfied:
+		return ValidationSuccess, result.Message
+	case bmcinventory.NotSatisfied:
+		return ValidationFailure, result.Message
+	default:
+		return ValidationSuccessSuppressOutput, ""
+	}
+}
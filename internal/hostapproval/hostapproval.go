@@ -0,0 +1,237 @@
+package hostapproval
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/conversions"
+	"github.com/pkg/errors"
+)
+
+const (
+	ActionApprove    = "approve"
+	ActionQuarantine = "quarantine"
+	ActionManual     = "manual"
+)
+
+// Decision is the outcome of the approval policy of an infra-env for a host
+type Decision struct {
+	// Action is approve, quarantine or manual
+	Action string
+	// Rule is the name of the rule that matched the host, empty when none did
+	Rule string
+	// Reason explains the decision to the user
+	Reason string
+}
+
+func (d *Decision) Approved() bool {
+	return d.Action == ActionApprove
+}
+
+func (d *Decision) Quarantined() bool {
+	return d.Action == ActionQuarantine
+}
+
+// PolicyFromInfraEnv returns the approval policy of the infra-env, or nil when it doesn't have any
+func PolicyFromInfraEnv(infraEnv *common.InfraEnv) (*models.HostApprovalPolicy, error) {
+	if infraEnv == nil || infraEnv.HostApprovalPolicy == nil || *infraEnv.HostApprovalPolicy == "" {
+		return nil, nil
+	}
+	var policy models.HostApprovalPolicy
+	if err := json.Unmarshal([]byte(*infraEnv.HostApprovalPolicy), &policy); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the host approval policy of infra-env %s", infraEnv.ID)
+	}
+	return &policy, nil
+}
+
+// IsEmpty returns true when the policy leaves all the hosts to the user, like no policy at all
+func IsEmpty(policy *models.HostApprovalPolicy) bool {
+	return policy == nil || (len(policy.Rules) == 0 && defaultAction(policy) == ActionManual)
+}
+
+// Format validates the policy set by the user and returns it in the format stored in the
+// infra-env, an empty string for an empty policy
+func Format(policy *models.HostApprovalPolicy) (string, error) {
+	if IsEmpty(policy) {
+		return "", nil
+	}
+	if err := ValidatePolicy(policy); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ValidatePolicy checks the approval policy set by the user
+func ValidatePolicy(policy *models.HostApprovalPolicy) error {
+	switch policy.DefaultAction {
+	case "", ActionApprove, ActionQuarantine, ActionManual:
+	default:
+		return errors.Errorf("invalid default action %q of the host approval policy", policy.DefaultAction)
+	}
+	for i, rule := range policy.Rules {
+		if rule == nil || rule.Action == nil {
+			return errors.Errorf("the host approval rule %d doesn't have an action", i)
+		}
+		if *rule.Action != ActionApprove && *rule.Action != ActionQuarantine {
+			return errors.Errorf("invalid action %q of the host approval rule %s", *rule.Action, ruleName(rule, i))
+		}
+		for _, macRange := range rule.MACRanges {
+			if _, _, err := parseMACRange(macRange); err != nil {
+				return errors.Wrapf(err, "invalid MAC range of the host approval rule %s", ruleName(rule, i))
+			}
+		}
+		for _, patterns := range [][]string{rule.SerialNumbers, rule.Manufacturers, rule.ProductNames} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return errors.Errorf("invalid pattern %q of the host approval rule %s", pattern, ruleName(rule, i))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func ruleName(rule *models.HostApprovalRule, index int) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return fmt.Sprintf("%d", index)
+}
+
+func defaultAction(policy *models.HostApprovalPolicy) string {
+	if policy.DefaultAction == "" {
+		return ActionManual
+	}
+	return policy.DefaultAction
+}
+
+// Evaluate decides what happens to the host, the first rule that matches the host decides, the
+// default action of the policy when none does
+func Evaluate(policy *models.HostApprovalPolicy, inventory *models.Inventory) *Decision {
+	for i, rule := range policy.Rules {
+		if rule == nil || rule.Action == nil || !matches(rule, inventory) {
+			continue
+		}
+		name := ruleName(rule, i)
+		if *rule.Action == ActionApprove {
+			return &Decision{Action: ActionApprove, Rule: name, Reason: fmt.Sprintf("Host approved by the approval rule %s", name)}
+		}
+		return &Decision{Action: ActionQuarantine, Rule: name, Reason: fmt.Sprintf("Host quarantined by the approval rule %s", name)}
+	}
+	switch defaultAction(policy) {
+	case ActionApprove:
+		return &Decision{Action: ActionApprove, Reason: "Host approved by the default action of the approval policy, no approval rule matches it"}
+	case ActionQuarantine:
+		return &Decision{Action: ActionQuarantine, Reason: "Host quarantined by the default action of the approval policy, no approval rule matches it"}
+	default:
+		return &Decision{Action: ActionManual, Reason: "No approval rule matches the host, it must be approved manually"}
+	}
+}
+
+// matches returns true when the host matches all the criteria of the rule
+func matches(rule *models.HostApprovalRule, inventory *models.Inventory) bool {
+	vendor := inventory.SystemVendor
+	if vendor == nil {
+		vendor = &models.SystemVendor{}
+	}
+	if len(rule.MACRanges) > 0 && !matchesMACRanges(rule.MACRanges, inventory.Interfaces) {
+		return false
+	}
+	if len(rule.SerialNumbers) > 0 && !matchesPatterns(rule.SerialNumbers, vendor.SerialNumber) {
+		return false
+	}
+	if len(rule.Manufacturers) > 0 && !matchesPatterns(rule.Manufacturers, vendor.Manufacturer) {
+		return false
+	}
+	if len(rule.ProductNames) > 0 && !matchesPatterns(rule.ProductNames, vendor.ProductName) {
+		return false
+	}
+	if rule.MinCPUCores > 0 && (inventory.CPU == nil || inventory.CPU.Count < rule.MinCPUCores) {
+		return false
+	}
+	if rule.MinMemoryMib > 0 && (inventory.Memory == nil || inventory.Memory.PhysicalBytes < conversions.MibToBytes(rule.MinMemoryMib)) {
+		return false
+	}
+	if rule.Virtual != nil && *rule.Virtual != vendor.Virtual {
+		return false
+	}
+	return true
+}
+
+func matchesPatterns(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesMACRanges(macRanges []string, interfaces []*models.Interface) bool {
+	for _, iface := range interfaces {
+		if iface == nil {
+			continue
+		}
+		mac, err := net.ParseMAC(iface.MacAddress)
+		if err != nil {
+			continue
+		}
+		for _, macRange := range macRanges {
+			start, end, err := parseMACRange(macRange)
+			if err == nil && bytes.Compare(mac, start) >= 0 && bytes.Compare(mac, end) <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseMACRange returns the first and last MAC addresses of the range: a MAC address, two MAC
+// addresses separated by a dash, or a prefix of one to five octets
+func parseMACRange(macRange string) (net.HardwareAddr, net.HardwareAddr, error) {
+	macRange = strings.TrimSpace(macRange)
+	if first, last, found := strings.Cut(macRange, "-"); found {
+		start, err := net.ParseMAC(strings.TrimSpace(first))
+		if err != nil {
+			return nil, nil, err
+		}
+		end, err := net.ParseMAC(strings.TrimSpace(last))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(start) != 6 || len(end) != 6 || bytes.Compare(start, end) > 0 {
+			return nil, nil, errors.Errorf("invalid MAC range %q", macRange)
+		}
+		return start, end, nil
+	}
+	octets := strings.Split(macRange, ":")
+	if len(octets) > 6 {
+		return nil, nil, errors.Errorf("invalid MAC range %q", macRange)
+	}
+	start := make(net.HardwareAddr, 6)
+	end := make(net.HardwareAddr, 6)
+	for i := range start {
+		if i >= len(octets) {
+			end[i] = 0xff
+			continue
+		}
+		octet, err := strconv.ParseUint(octets[i], 16, 8)
+		if err != nil || len(octets[i]) != 2 {
+			return nil, nil, errors.Errorf("invalid MAC range %q", macRange)
+		}
+		start[i] = byte(octet)
+		end[i] = byte(octet)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,13 @@
+package hostapproval
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHostApproval(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Host Approval Suite")
+}
@@ -0,0 +1,134 @@
+package hostapproval
+
+import (
+	"github.com/go-openapi/swag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/conversions"
+)
+
+func newInventory() *models.Inventory {
+	return &models.Inventory{
+		CPU:    &models.CPU{Count: 8},
+		Memory: &models.Memory{PhysicalBytes: conversions.GibToBytes(32)},
+		Interfaces: []*models.Interface{
+			{Name: "eth0", MacAddress: "52:54:00:12:34:56"},
+			{Name: "eth1", MacAddress: "3c:fd:fe:aa:bb:cc"},
+		},
+		SystemVendor: &models.SystemVendor{
+			Manufacturer: "Dell Inc.",
+			ProductName:  "PowerEdge R640",
+			SerialNumber: "CN7792194L0123",
+		},
+	}
+}
+
+func rule(action string) *models.HostApprovalRule {
+	return &models.HostApprovalRule{Action: &action}
+}
+
+var _ = Describe("Evaluate", func() {
+	It("approves with the first matching rule", func() {
+		quarantine := rule(ActionQuarantine)
+		quarantine.Name = "lab"
+		quarantine.SerialNumbers = []string{"LAB*"}
+		approve := rule(ActionApprove)
+		approve.Name = "dell"
+		approve.Manufacturers = []string{"Dell*"}
+		decision := Evaluate(&models.HostApprovalPolicy{Rules: []*models.HostApprovalRule{quarantine, approve}}, newInventory())
+		Expect(decision.Approved()).To(BeTrue())
+		Expect(decision.Rule).To(Equal("dell"))
+		Expect(decision.Reason).To(Equal("Host approved by the approval rule dell"))
+	})
+
+	It("quarantines with the first matching rule", func() {
+		quarantine := rule(ActionQuarantine)
+		quarantine.Virtual = swag.Bool(false)
+		approve := rule(ActionApprove)
+		decision := Evaluate(&models.HostApprovalPolicy{Rules: []*models.HostApprovalRule{quarantine, approve}}, newInventory())
+		Expect(decision.Quarantined()).To(BeTrue())
+		Expect(decision.Reason).To(Equal("Host quarantined by the approval rule 0"))
+	})
+
+	It("requires all the criteria of a rule", func() {
+		approve := rule(ActionApprove)
+		approve.Manufacturers = []string{"Dell*"}
+		approve.MinCPUCores = 16
+		decision := Evaluate(&models.HostApprovalPolicy{Rules: []*models.HostApprovalRule{approve}}, newInventory())
+		Expect(decision.Action).To(Equal(ActionManual))
+		Expect(decision.Rule).To(BeEmpty())
+	})
+
+	DescribeTable("default action",
+		func(defaultAction, expected string) {
+			approve := rule(ActionApprove)
+			approve.SerialNumbers = []string{"OTHER"}
+			policy := &models.HostApprovalPolicy{Rules: []*models.HostApprovalRule{approve}, DefaultAction: defaultAction}
+			Expect(Evaluate(policy, newInventory()).Action).To(Equal(expected))
+		},
+		Entry("not set", "", ActionManual),
+		Entry("manual", ActionManual, ActionManual),
+		Entry("approve", ActionApprove, ActionApprove),
+		Entry("quarantine", ActionQuarantine, ActionQuarantine),
+	)
+
+	DescribeTable("criteria",
+		func(update func(r *models.HostApprovalRule), expected bool) {
+			approve := rule(ActionApprove)
+			update(approve)
+			Expect(Evaluate(&models.HostApprovalPolicy{Rules: []*models.HostApprovalRule{approve}}, newInventory()).Approved()).To(Equal(expected))
+		},
+		Entry("no criteria", func(r *models.HostApprovalRule) {}, true),
+		Entry("MAC address", func(r *models.HostApprovalRule) { r.MACRanges = []string{"3C:FD:FE:AA:BB:CC"} }, true),
+		Entry("other MAC address", func(r *models.HostApprovalRule) { r.MACRanges = []string{"3c:fd:fe:aa:bb:cd"} }, false),
+		Entry("MAC range", func(r *models.HostApprovalRule) { r.MACRanges = []string{"52:54:00:12:00:00-52:54:00:12:ff:ff"} }, true),
+		Entry("other MAC range", func(r *models.HostApprovalRule) { r.MACRanges = []string{"52:54:00:13:00:00-52:54:00:13:ff:ff"} }, false),
+		Entry("OUI", func(r *models.HostApprovalRule) { r.MACRanges = []string{"00:1a:2b", "3c:fd:fe"} }, true),
+		Entry("other OUI", func(r *models.HostApprovalRule) { r.MACRanges = []string{"00:1a:2b"} }, false),
+		Entry("serial number", func(r *models.HostApprovalRule) { r.SerialNumbers = []string{"CN77*"} }, true),
+		Entry("other serial number", func(r *models.HostApprovalRule) { r.SerialNumbers = []string{"CN78*"} }, false),
+		Entry("product name", func(r *models.HostApprovalRule) { r.ProductNames = []string{"PowerEdge R6?0"} }, true),
+		Entry("other product name", func(r *models.HostApprovalRule) { r.ProductNames = []string{"ProLiant*"} }, false),
+		Entry("enough CPU cores", func(r *models.HostApprovalRule) { r.MinCPUCores = 8 }, true),
+		Entry("not enough CPU cores", func(r *models.HostApprovalRule) { r.MinCPUCores = 9 }, false),
+		Entry("enough memory", func(r *models.HostApprovalRule) { r.MinMemoryMib = 32 * 1024 }, true),
+		Entry("not enough memory", func(r *models.HostApprovalRule) { r.MinMemoryMib = 64 * 1024 }, false),
+		Entry("physical", func(r *models.HostApprovalRule) { r.Virtual = swag.Bool(false) }, true),
+		Entry("virtual", func(r *models.HostApprovalRule) { r.Virtual = swag.Bool(true) }, false),
+	)
+})
+
+var _ = Describe("Format", func() {
+	It("clears an empty policy", func() {
+		for _, policy := range []*models.HostApprovalPolicy{nil, {}, {DefaultAction: ActionManual}} {
+			formatted, err := Format(policy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(formatted).To(BeEmpty())
+		}
+	})
+
+	It("formats a valid policy", func() {
+		approve := rule(ActionApprove)
+		approve.MACRanges = []string{"3c:fd:fe"}
+		formatted, err := Format(&models.HostApprovalPolicy{Rules: []*models.HostApprovalRule{approve}, DefaultAction: ActionQuarantine})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(formatted).To(ContainSubstring(`"default_action":"quarantine"`))
+	})
+
+	DescribeTable("rejects an invalid policy",
+		func(update func(r *models.HostApprovalRule), message string) {
+			approve := rule(ActionApprove)
+			approve.Name = "r"
+			update(approve)
+			_, err := Format(&models.HostApprovalPolicy{Rules: []*models.HostApprovalRule{approve}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("action", func(r *models.HostApprovalRule) { r.Action = swag.String("manual") }, `invalid action "manual"`),
+		Entry("MAC address", func(r *models.HostApprovalRule) { r.MACRanges = []string{"3c:fd:fg"} }, "invalid MAC range"),
+		Entry("reversed MAC range", func(r *models.HostApprovalRule) { r.MACRanges = []string{"3c:fd:fe:00:00:02-3c:fd:fe:00:00:01"} }, "invalid MAC range"),
+		Entry("pattern", func(r *models.HostApprovalRule) { r.SerialNumbers = []string{"["} }, `invalid pattern "["`),
+	)
+})
@@ -37,11 +37,13 @@ import (
 	logutil "github.com/openshift/assisted-service/pkg/log"
 	"github.com/openshift/assisted-service/pkg/mirrorregistries"
 	"github.com/openshift/assisted-service/pkg/s3wrapper"
+	"github.com/openshift/assisted-service/pkg/tracing"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"github.com/thoas/go-funk"
 	"github.com/vincent-petithory/dataurl"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -1254,14 +1256,19 @@ func (g *installerGenerator) createHostIgnitions() error {
 	return nil
 }
 
-func (g *installerGenerator) runCreateCommand(ctx context.Context, installerPath, command string, envVars []string) error {
+func (g *installerGenerator) runCreateCommand(ctx context.Context, installerPath, command string, envVars []string) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "openshift-install create "+command,
+		attribute.String("cluster_id", g.cluster.ID.String()))
+	defer tracing.EndSpan(span, &err)
+
 	log := logutil.FromContext(ctx, g.log)
 	cmd := exec.Command(installerPath, "create", command, "--dir", g.workDir) //nolint:gosec
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &out
-	cmd.Env = envVars
-	err := cmd.Run()
+	// The installer continues the trace if it supports it
+	cmd.Env = append(envVars, tracing.EnvFromContext(ctx)...)
+	err = cmd.Run()
 	if err != nil {
 		log.WithError(err).
 			Errorf("error running openshift-install create %s, stdout: %s", command, out.String())
@@ -19,16 +19,30 @@ import (
 	"github.com/openshift/assisted-service/internal/metrics"
 	"github.com/openshift/assisted-service/internal/oc"
 	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sys/unix"
 )
 
 // Installers implements a thread safe LRU cache for ocp install binaries
 // on the pod's ephermal file system. The number of binaries stored is
 // limited by the storageCapacity parameter.
+//
+// When a shared directory is configured, the cache is placed on a volume
+// that is mounted by all replicas and access to it is additionally
+// coordinated across replicas with file locks, so that a given release
+// is only extracted once and then reused by every replica.
 type Installers struct {
 	sync.Mutex
-	log logrus.FieldLogger
+	// sharedLock coordinates access to the cache directory across replicas, nil when the cache is not shared
+	sharedLock *sharedLock
+	// lease is held for as long as the process runs, so that the other replicas can tell whether the
+	// hard links of this replica are still in use
+	lease *sharedLock
+	// owner identifies the hard links created by this instance
+	owner string
+	log   logrus.FieldLogger
 	// parent directory of the binary cache
 	eventsHandler   eventsapi.Handler
 	diskStatsHelper metrics.DiskStatsHelper
@@ -46,6 +60,9 @@ type Config struct {
 	MaxReleaseSize Size `envconfig:"INSTALLER_CACHE_MAX_RELEASE_SIZE" default:"2GiB"`
 	// ReleaseFetchRetryIntervalMicroseconds is the number of microseconds that the cache should wait before retrying the fetch of a release if unable to do so for capacity reasons.
 	ReleaseFetchRetryInterval time.Duration `envconfig:"INSTALLER_CACHE_RELEASE_FETCH_RETRY_INTERVAL" default:"30s"`
+	// SharedDir is a directory on a volume shared by all replicas. When set, it is used instead of CacheDir,
+	// its content is preserved on construction and extraction is coordinated across replicas.
+	SharedDir string `envconfig:"INSTALLER_CACHE_SHARED_DIR" default:""`
 }
 
 func (s *Size) Decode(value string) error {
@@ -129,29 +146,166 @@ func New(config Config, eventsHandler eventsapi.Handler, metricsAPI metrics.API,
 		return nil, fmt.Errorf("config.MaxReleaseSize (%d bytes) must not be greater than config.MaxCapacity (%d bytes)", config.MaxReleaseSize, config.MaxCapacity)
 	}
 
-	err := os.RemoveAll(config.CacheDir)
-	if err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to remove cache directory %s: %w", config.CacheDir, err)
+	shared := config.SharedDir != ""
+	if shared {
+		// Other replicas may be using the content of the shared directory, so it must not be reset
+		config.CacheDir = config.SharedDir
+	} else {
+		err := os.RemoveAll(config.CacheDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove cache directory %s: %w", config.CacheDir, err)
+		}
 	}
 
-	err = os.MkdirAll(config.CacheDir, 0755)
+	err := os.MkdirAll(config.CacheDir, 0755)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cache directory %s: %w", config.CacheDir, err)
 	}
 
-	return &Installers{
+	installers := &Installers{
 		log:             log,
 		eventsHandler:   eventsHandler,
 		diskStatsHelper: diskStatsHelper,
 		config:          config,
 		metricsAPI:      metricsAPI,
+		owner:           uuid.NewString(),
+	}
+	if shared {
+		installers.sharedLock = newSharedLock(filepath.Join(config.CacheDir, sharedLockFileName))
+		installers.lease = newSharedLock(filepath.Join(config.CacheDir, leasesDirName, installers.owner))
+		if err = installers.lease.lock(); err != nil {
+			return nil, fmt.Errorf("failed to acquire the lease of the shared cache directory %s: %w", config.CacheDir, err)
+		}
+		if err = installers.sweepStaleLinks(); err != nil {
+			return nil, err
+		}
+	}
+	return installers, nil
+}
+
+// lockRelease acquires the lock of the given release across all the replicas using the same
+// directory, together with the shared lock of the whole cache. It returns the function that
+// releases both.
+func (i *Installers) lockRelease(path string) (func(), error) {
+	if i.sharedLock == nil {
+		return func() {}, nil
+	}
+	releaseLock := newReleaseLock(i.config.CacheDir, path)
+	if err := releaseLock.lock(); err != nil {
+		return nil, fmt.Errorf("failed to lock release %s in shared cache directory %s: %w", path, i.config.CacheDir, err)
+	}
+	if err := i.sharedLock.rLock(); err != nil {
+		i.unlockShared(releaseLock)
+		return nil, fmt.Errorf("failed to lock shared cache directory %s: %w", i.config.CacheDir, err)
+	}
+	return func() {
+		i.unlockShared(i.sharedLock)
+		i.unlockShared(releaseLock)
 	}, nil
 }
 
+func (i *Installers) unlockShared(l *sharedLock) {
+	if err := l.unlock(); err != nil {
+		i.log.WithError(err).Errorf("failed to unlock shared cache directory %s", i.config.CacheDir)
+	}
+}
+
+// sweepStaleLinks removes the hard links left behind by the replicas that are gone, which would
+// otherwise keep their releases from ever being evicted. A replica holds the lock of its lease for
+// as long as it runs, so the lease of a replica that is gone can be locked.
+func (i *Installers) sweepStaleLinks() error {
+	if err := i.sharedLock.lock(); err != nil {
+		return fmt.Errorf("failed to lock shared cache directory %s: %w", i.config.CacheDir, err)
+	}
+	defer i.unlockShared(i.sharedLock)
+
+	alive := map[string]bool{i.owner: true}
+	isAlive := func(owner string) bool {
+		if _, ok := alive[owner]; !ok {
+			alive[owner] = i.isLeaseHeld(owner)
+		}
+		return alive[owner]
+	}
+
+	err := filepath.Walk(i.config.CacheDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && isLockDir(info.Name()) {
+			return filepath.SkipDir
+		}
+		owner, ok := linkOwner(info.Name())
+		if !info.Mode().IsRegular() || !ok || isAlive(owner) {
+			return nil
+		}
+		i.log.Infof("removing hard link %s left behind by replica %s", path, owner)
+		if err := cleanHardLink(path); err != nil {
+			i.log.WithError(err).Warnf("failed to remove stale hard link %s", path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sweep stale hard links in %s: %w", i.config.CacheDir, err)
+	}
+
+	leases, err := os.ReadDir(filepath.Join(i.config.CacheDir, leasesDirName))
+	if err != nil {
+		return fmt.Errorf("failed to list the leases of shared cache directory %s: %w", i.config.CacheDir, err)
+	}
+	for _, lease := range leases {
+		if !isAlive(lease.Name()) {
+			if err := os.Remove(filepath.Join(i.config.CacheDir, leasesDirName, lease.Name())); err != nil && !os.IsNotExist(err) {
+				i.log.WithError(err).Warnf("failed to remove the lease of replica %s", lease.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// isLeaseHeld returns true when the replica that owns the lease is still running. Errors are
+// treated as a held lease, so that links that may be in use are never removed.
+func (i *Installers) isLeaseHeld(owner string) bool {
+	path := filepath.Join(i.config.CacheDir, leasesDirName, owner)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return false
+	}
+	lease := newSharedLock(path)
+	locked, err := lease.tryLock()
+	if err != nil {
+		i.log.WithError(err).Warnf("failed to check the lease of replica %s", owner)
+		return true
+	}
+	if locked {
+		i.unlockShared(lease)
+	}
+	return !locked
+}
+
+func isLockDir(name string) bool {
+	return name == releaseLocksDirName || name == leasesDirName
+}
+
+// linkOwner returns the owner of a hard link named by getLinkForReleasePath
+func linkOwner(name string) (string, bool) {
+	if !strings.HasPrefix(name, "ln_") {
+		return "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(name, "ln_"), "_", 3)
+	if len(parts) < 3 {
+		return "", false
+	}
+	return parts[0], true
+}
+
 // Get returns the path to an openshift-baremetal-install binary extracted from
 // the referenced release image. Tries the mirror release image first if it's set. It is safe for concurrent use. A cache of
 // binaries is maintained to reduce re-downloading of the same release.
-func (i *Installers) Get(ctx context.Context, releaseID, releaseIDMirror, pullSecret string, ocRelease oc.Release, ocpVersion string, clusterID strfmt.UUID) (*Release, error) {
+func (i *Installers) Get(ctx context.Context, releaseID, releaseIDMirror, pullSecret string, ocRelease oc.Release, ocpVersion string, clusterID strfmt.UUID) (_ *Release, err error) {
+	ctx, span := tracing.StartSpan(ctx, "installercache.Get",
+		attribute.String("release_image", releaseID),
+		attribute.String("cluster_id", clusterID.String()))
+	defer tracing.EndSpan(span, &err)
+
 	majorMinorVersion, err := ocRelease.GetMajorMinorVersion(i.log, releaseID, releaseIDMirror, pullSecret)
 	if err != nil {
 		i.log.Warnf("unable to get majorMinorVersion to record metric for %s falling back to full URI", releaseID)
@@ -165,6 +319,7 @@ func (i *Installers) Get(ctx context.Context, releaseID, releaseIDMirror, pullSe
 			release, err := i.get(releaseID, releaseIDMirror, pullSecret, ocRelease, ocpVersion, clusterID)
 			if err == nil {
 				i.metricsAPI.InstallerCacheGetReleaseCached(majorMinorVersion, release.cached)
+				span.SetAttributes(attribute.Bool("cached", release.cached))
 				return release, nil
 			}
 			_, isCapacityError := err.(*errorInsufficientCacheCapacity)
@@ -196,8 +351,14 @@ func (i *Installers) extractReleaseIfNeeded(path, releaseID, releaseIDMirror, pu
 	if err != nil && !os.IsNotExist(err) {
 		return 0, false, fmt.Errorf("could not determine disk usage information for cache dir %s: %w", i.config.CacheDir, err)
 	}
-	if i.shouldEvict(int64(usedBytes)) && !i.evict() { // nolint: gosec
-		return 0, false, &errorInsufficientCacheCapacity{Message: fmt.Sprintf("insufficient capacity in %s to store release", i.config.CacheDir)}
+	if i.shouldEvict(int64(usedBytes)) { // nolint: gosec
+		evicted, err := i.evictExclusively()
+		if err != nil {
+			return 0, false, err
+		}
+		if !evicted {
+			return 0, false, &errorInsufficientCacheCapacity{Message: fmt.Sprintf("insufficient capacity in %s to store release", i.config.CacheDir)}
+		}
 	}
 	extractStartTime := time.Now()
 	_, err = ocRelease.Extract(i.log, releaseID, releaseIDMirror, i.config.CacheDir, pullSecret, ocpVersion)
@@ -207,6 +368,23 @@ func (i *Installers) extractReleaseIfNeeded(path, releaseID, releaseIDMirror, pu
 	return time.Since(extractStartTime).Seconds(), false, nil
 }
 
+// evictExclusively evicts releases while holding the exclusive lock of a shared cache, so that no
+// replica is extracting a release, which isn't linked yet and would be evicted otherwise. The
+// shared lock that the caller holds is restored afterwards.
+func (i *Installers) evictExclusively() (bool, error) {
+	if i.sharedLock == nil {
+		return i.evict(), nil
+	}
+	if err := i.sharedLock.lock(); err != nil {
+		return false, fmt.Errorf("failed to lock shared cache directory %s: %w", i.config.CacheDir, err)
+	}
+	evicted := i.evict()
+	if err := i.sharedLock.rLock(); err != nil {
+		return false, fmt.Errorf("failed to lock shared cache directory %s: %w", i.config.CacheDir, err)
+	}
+	return evicted, nil
+}
+
 func (i *Installers) get(releaseID, releaseIDMirror, pullSecret string, ocRelease oc.Release, ocpVersion string, clusterID strfmt.UUID) (*Release, error) {
 	i.Lock()
 	defer i.Unlock()
@@ -222,6 +400,11 @@ func (i *Installers) get(releaseID, releaseIDMirror, pullSecret string, ocReleas
 	if err != nil {
 		return nil, err
 	}
+	unlockRelease, err := i.lockRelease(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockRelease()
 	release.extractDuration, release.cached, err = i.extractReleaseIfNeeded(path, releaseID, releaseIDMirror, pullSecret, ocpVersion, ocRelease)
 	if err != nil {
 		return nil, err
@@ -253,7 +436,7 @@ func (i *Installers) get(releaseID, releaseIDMirror, pullSecret string, ocReleas
 
 func (i *Installers) getLinkForReleasePath(workdir string, path string, binary string) (string, error) {
 	for {
-		link := filepath.Join(workdir, fmt.Sprintf("ln_%s_%s", uuid.NewString(), binary))
+		link := filepath.Join(workdir, fmt.Sprintf("ln_%s_%s_%s", i.owner, uuid.NewString(), binary))
 		err := os.Link(path, link)
 		if err == nil {
 			return link, nil
@@ -289,6 +472,10 @@ func (i *Installers) evict() bool {
 			return err
 		}
 
+		//skip the locks of a shared cache
+		if info.IsDir() && isLockDir(info.Name()) {
+			return filepath.SkipDir
+		}
 		if !info.Mode().IsRegular() {
 			return nil
 		}
@@ -296,6 +483,10 @@ func (i *Installers) evict() bool {
 		if strings.HasPrefix(info.Name(), "ln_") {
 			return nil
 		}
+		//skip the lock file of a shared cache
+		if info.Name() == sharedLockFileName {
+			return nil
+		}
 
 		stat, ok := info.Sys().(*syscall.Stat_t)
 		if !ok || stat.Nlink == 1 {
@@ -2,6 +2,8 @@ package installercache
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -415,6 +417,169 @@ var _ = Describe("installer cache", func() {
 		_, err = os.Stat(filepath.Join(cacheConfig.CacheDir, "test.txt"))
 		Expect(os.IsNotExist(err)).To(BeTrue())
 	})
+
+	Context("shared cache directory", func() {
+		var sharedDir string
+
+		getSharedConfig := func() Config {
+			cacheConfig := getInstallerCacheConfig(10, 5)
+			cacheConfig.CacheDir = filepath.Join(cacheDir, "local")
+			cacheConfig.SharedDir = sharedDir
+			return cacheConfig
+		}
+
+		BeforeEach(func() {
+			sharedDir = filepath.Join(cacheDir, "shared")
+		})
+
+		It("should preserve the shared directory contents on construction", func() {
+			Expect(os.MkdirAll(sharedDir, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(sharedDir, "test.txt"), []byte("test"), 0600)).To(Succeed())
+
+			shared, err := New(getSharedConfig(), eventsHandler, metricsAPI, diskStatsHelper, logrus.New())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(shared.config.CacheDir).To(Equal(sharedDir))
+
+			_, err = os.Stat(filepath.Join(sharedDir, "test.txt"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should extract a release only once for all the replicas sharing the directory", func() {
+			releaseID := "4.17.11-x86_64"
+			version := "4.17.11"
+			replicas := make([]*Installers, 3)
+			for idx := range replicas {
+				var err error
+				replicas[idx], err = New(getSharedConfig(), eventsHandler, metricsAPI, diskStatsHelper, getLogger())
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			workdir := filepath.Join(sharedDir, "quay.io", "release-dev")
+			fname := filepath.Join(workdir, releaseID)
+			var extractions int
+			var extractionsMutex sync.Mutex
+			mockRelease.EXPECT().GetReleaseBinaryPath(gomock.Any(), sharedDir, version).
+				Return(workdir, releaseID, fname, nil).AnyTimes()
+			mockRelease.EXPECT().Extract(gomock.Any(), releaseID, gomock.Any(), sharedDir, gomock.Any(), version).
+				DoAndReturn(func(log logrus.FieldLogger, releaseImage string, releaseImageMirror string, cacheDir string, pullSecret string, version string) (string, error) {
+					extractionsMutex.Lock()
+					extractions++
+					extractionsMutex.Unlock()
+					Expect(os.MkdirAll(workdir, 0700)).To(Succeed())
+					time.Sleep(10 * time.Millisecond)
+					return "", os.WriteFile(fname, []byte("abcde"), 0600)
+				}).AnyTimes()
+			mockRelease.EXPECT().GetMajorMinorVersion(gomock.Any(), releaseID, gomock.Any(), gomock.Any()).Return("4.17", nil).AnyTimes()
+			metricsAPI.EXPECT().InstallerCacheReleaseEvicted(gomock.Any()).AnyTimes()
+			metricsAPI.EXPECT().InstallerCacheGetReleaseCached("4.17", gomock.Any()).AnyTimes()
+			expectEventsSent()
+
+			var wg sync.WaitGroup
+			var reportedError error
+			var errMutex sync.Mutex
+			for _, replica := range replicas {
+				wg.Add(1)
+				go func(replica *Installers) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					release, err := replica.Get(ctx, releaseID, "mirror", "pull-secret", mockRelease, version, strfmt.UUID(uuid.NewString()))
+					handleError(&errMutex, err, &reportedError)
+					cleanup(release)
+				}(replica)
+			}
+			wg.Wait()
+			Expect(reportedError).ToNot(HaveOccurred())
+			Expect(extractions).To(Equal(1))
+			Expect(getUsedBytesForDirectory(sharedDir)).To(BeNumerically("<=", 10))
+		})
+
+		It("should extract different releases concurrently", func() {
+			replicas := make([]*Installers, 2)
+			for idx := range replicas {
+				var err error
+				replicas[idx], err = New(getSharedConfig(), eventsHandler, metricsAPI, diskStatsHelper, getLogger())
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			workdir := filepath.Join(sharedDir, "quay.io", "release-dev")
+			var extracting sync.WaitGroup
+			extracting.Add(len(replicas))
+			bothExtracting := make(chan struct{})
+			go func() {
+				extracting.Wait()
+				close(bothExtracting)
+			}()
+			mockRelease.EXPECT().GetReleaseBinaryPath(gomock.Any(), sharedDir, gomock.Any()).
+				DoAndReturn(func(releaseImage string, cacheDir string, version string) (string, string, string, error) {
+					return workdir, releaseImage, filepath.Join(workdir, releaseImage), nil
+				}).AnyTimes()
+			mockRelease.EXPECT().Extract(gomock.Any(), gomock.Any(), gomock.Any(), sharedDir, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(log logrus.FieldLogger, releaseImage string, releaseImageMirror string, cacheDir string, pullSecret string, version string) (string, error) {
+					extracting.Done()
+					// Each extraction only completes once the other one has started
+					select {
+					case <-bothExtracting:
+					case <-time.After(5 * time.Second):
+						return "", errors.New("releases were not extracted concurrently")
+					}
+					Expect(os.MkdirAll(workdir, 0700)).To(Succeed())
+					return "", os.WriteFile(filepath.Join(workdir, releaseImage), []byte("abcde"), 0600)
+				}).Times(2)
+			mockRelease.EXPECT().GetMajorMinorVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("4.17", nil).AnyTimes()
+			metricsAPI.EXPECT().InstallerCacheReleaseEvicted(gomock.Any()).AnyTimes()
+			metricsAPI.EXPECT().InstallerCacheGetReleaseCached("4.17", gomock.Any()).AnyTimes()
+			expectEventsSent()
+
+			var wg sync.WaitGroup
+			var reportedError error
+			var errMutex sync.Mutex
+			for idx, replica := range replicas {
+				wg.Add(1)
+				go func(replica *Installers, releaseID string) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					release, err := replica.Get(ctx, releaseID, "mirror", "pull-secret", mockRelease, "4.17.11", strfmt.UUID(uuid.NewString()))
+					handleError(&errMutex, err, &reportedError)
+					cleanup(release)
+				}(replica, fmt.Sprintf("4.17.1%d-x86_64", idx))
+			}
+			wg.Wait()
+			Expect(reportedError).ToNot(HaveOccurred())
+		})
+
+		It("should remove the hard links left behind by replicas that are gone", func() {
+			running, err := New(getSharedConfig(), eventsHandler, metricsAPI, diskStatsHelper, getLogger())
+			Expect(err).ToNot(HaveOccurred())
+
+			workdir := filepath.Join(sharedDir, "quay.io", "release-dev")
+			Expect(os.MkdirAll(workdir, 0700)).To(Succeed())
+			binary := filepath.Join(workdir, "4.17.11-x86_64")
+			Expect(os.WriteFile(binary, []byte("abcde"), 0600)).To(Succeed())
+			runningLink, err := running.getLinkForReleasePath(workdir, binary, "4.17.11-x86_64")
+			Expect(err).ToNot(HaveOccurred())
+
+			goneOwner := uuid.NewString()
+			Expect(os.WriteFile(filepath.Join(sharedDir, leasesDirName, goneOwner), nil, 0600)).To(Succeed())
+			goneLink := filepath.Join(workdir, fmt.Sprintf("ln_%s_%s_4.17.11-x86_64", goneOwner, uuid.NewString()))
+			Expect(os.Link(binary, goneLink)).To(Succeed())
+			unknownLink := filepath.Join(workdir, fmt.Sprintf("ln_%s_%s_4.17.11-x86_64", uuid.NewString(), uuid.NewString()))
+			Expect(os.Link(binary, unknownLink)).To(Succeed())
+
+			_, err = New(getSharedConfig(), eventsHandler, metricsAPI, diskStatsHelper, getLogger())
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = os.Stat(runningLink)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = os.Stat(goneLink)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			_, err = os.Stat(unknownLink)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			_, err = os.Stat(filepath.Join(sharedDir, leasesDirName, goneOwner))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			_, err = os.Stat(filepath.Join(sharedDir, leasesDirName, running.owner))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 })
 
 var _ = Describe("Size.Decode", func() {
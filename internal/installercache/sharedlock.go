@@ -0,0 +1,102 @@
+package installercache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// sharedLockFileName is the lock of the whole cache, held shared while a release is extracted
+	// or linked and exclusively while releases are evicted
+	sharedLockFileName = ".installercache.lock"
+	// releaseLocksDirName holds one lock file per release, so that a given release is extracted by
+	// only one replica while other releases are extracted concurrently
+	releaseLocksDirName = ".installercache.releases"
+	// leasesDirName holds one lease file per running replica, locked for as long as it runs
+	leasesDirName = ".installercache.leases"
+)
+
+// sharedLock is an advisory lock on a file in the shared cache directory, used to coordinate
+// the replicas using the directory. Waiting replicas block until the holder is done.
+type sharedLock struct {
+	path string
+	file *os.File
+}
+
+func newSharedLock(path string) *sharedLock {
+	return &sharedLock{path: path}
+}
+
+func newReleaseLock(cacheDir, releasePath string) *sharedLock {
+	sum := sha256.Sum256([]byte(releasePath))
+	return newSharedLock(filepath.Join(cacheDir, releaseLocksDirName, hex.EncodeToString(sum[:16])))
+}
+
+// lock blocks until the exclusive lock is acquired. Callers must serialize calls
+// within the process, the lock only coordinates between processes.
+func (l *sharedLock) lock() error {
+	return l.flock(unix.LOCK_EX)
+}
+
+// rLock blocks until the shared lock is acquired. When the exclusive lock is held, it is
+// converted to the shared lock.
+func (l *sharedLock) rLock() error {
+	return l.flock(unix.LOCK_SH)
+}
+
+// tryLock acquires the exclusive lock without blocking, it returns false when another process
+// holds the lock.
+func (l *sharedLock) tryLock() (bool, error) {
+	err := l.flock(unix.LOCK_EX | unix.LOCK_NB)
+	if err == unix.EWOULDBLOCK {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (l *sharedLock) flock(how int) error {
+	if l.file == nil {
+		if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+			return fmt.Errorf("failed to create lock directory for %s: %w", l.path, err)
+		}
+		f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open lock file %s: %w", l.path, err)
+		}
+		l.file = f
+	}
+	var err error
+	for {
+		err = unix.Flock(int(l.file.Fd()), how)
+		if err != unix.EINTR {
+			break
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	l.file.Close()
+	l.file = nil
+	if err == unix.EWOULDBLOCK {
+		return err
+	}
+	return fmt.Errorf("failed to lock %s: %w", l.path, err)
+}
+
+func (l *sharedLock) unlock() error {
+	if l.file == nil {
+		return nil
+	}
+	f := l.file
+	l.file = nil
+	defer f.Close()
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_UN); err != nil {
+		return fmt.Errorf("failed to unlock %s: %w", l.path, err)
+	}
+	return nil
+}
@@ -47,6 +47,10 @@ const (
 	// blacklist metrics
 	counterClusterBlacklistedEvents = "assisted_installer_cluster_blacklisted_events_total"
 	gaugeBlacklistedClustersCurrent = "assisted_installer_blacklisted_clusters_current"
+	counterEventsDiscarded          = "assisted_installer_events_discarded_total"
+	// API rate limits metrics
+	counterAPIRequestsRateLimited       = "assisted_installer_api_requests_rate_limited_total"
+	counterAPIRateLimitsBackendFailures = "assisted_installer_api_rate_limits_backend_failures_total"
 )
 
 const (
@@ -76,6 +80,10 @@ const (
 	// blacklist metric descriptions
 	counterDescriptionClusterBlacklistedEvents = "Counts cluster blacklisting events (no cluster labels to avoid high cardinality)"
 	gaugeDescriptionBlacklistedClustersCurrent = "Current number of clusters that are blacklisted"
+	counterDescriptionEventsDiscarded          = "Number of events discarded by the event rate limits, by event name, reason"
+	// API rate limits metric descriptions
+	counterDescriptionAPIRequestsRateLimited       = "Number of API requests rejected by the API rate limits, by route class, key type"
+	counterDescriptionAPIRateLimitsBackendFailures = "Number of API rate limits checks or syncs of the buckets that failed in the database"
 )
 
 const (
@@ -96,6 +104,10 @@ const (
 	labelReleaseID             = "releaseId"
 	labelSuccess               = "success"
 	labelFullScan              = "fullscan"
+	labelEventName             = "name"
+	labelReason                = "reason"
+	labelRouteClass            = "class"
+	labelKeyType               = "key_type"
 )
 
 type API interface {
@@ -120,6 +132,9 @@ type API interface {
 	// blacklist metrics
 	BlacklistedClusterInc()
 	BlacklistedClustersCurrent(count int)
+	EventDiscarded(name, reason string)
+	APIRequestRateLimited(class, keyType string)
+	APIRateLimitsBackendFailed()
 }
 
 type MetricsManager struct {
@@ -153,6 +168,10 @@ type MetricsManager struct {
 	// blacklist metrics
 	serviceLogicClusterBlacklistedEvents   *prometheus.CounterVec
 	serviceLogicBlacklistedClustersCurrent *prometheus.GaugeVec
+	serviceLogicEventsDiscarded            *prometheus.CounterVec
+	// API rate limits metrics
+	serviceLogicAPIRequestsRateLimited       *prometheus.CounterVec
+	serviceLogicAPIRateLimitsBackendFailures *prometheus.CounterVec
 
 	collectors []prometheus.Collector
 }
@@ -378,6 +397,30 @@ func NewMetricsManager(registry prometheus.Registerer, eventsHandler eventsapi.H
 				Name:      gaugeBlacklistedClustersCurrent,
 				Help:      gaugeDescriptionBlacklistedClustersCurrent,
 			}, []string{}),
+
+		serviceLogicEventsDiscarded: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      counterEventsDiscarded,
+				Help:      counterDescriptionEventsDiscarded,
+			}, []string{labelEventName, labelReason}),
+
+		serviceLogicAPIRequestsRateLimited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      counterAPIRequestsRateLimited,
+				Help:      counterDescriptionAPIRequestsRateLimited,
+			}, []string{labelRouteClass, labelKeyType}),
+
+		serviceLogicAPIRateLimitsBackendFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      counterAPIRateLimitsBackendFailures,
+				Help:      counterDescriptionAPIRateLimitsBackendFailures,
+			}, []string{}),
 	}
 
 	m.collectors = append(m.collectors, newDirectoryUsageCollector(metricsManagerConfig.DirectoryUsageMonitorConfig.Directories, diskStatsHelper, log))
@@ -409,6 +452,9 @@ func NewMetricsManager(registry prometheus.Registerer, eventsHandler eventsapi.H
 		// blacklist metrics
 		m.serviceLogicClusterBlacklistedEvents,
 		m.serviceLogicBlacklistedClustersCurrent,
+		m.serviceLogicEventsDiscarded,
+		m.serviceLogicAPIRequestsRateLimited,
+		m.serviceLogicAPIRateLimitsBackendFailures,
 	)
 
 	for _, collector := range m.collectors {
@@ -606,6 +652,24 @@ func (m *MetricsManager) BlacklistedClustersCurrent(count int) {
 	m.serviceLogicBlacklistedClustersCurrent.WithLabelValues().Set(float64(count))
 }
 
+// EventDiscarded increments the number of events with the given name that were discarded by the
+// event rate limits for the given reason.
+func (m *MetricsManager) EventDiscarded(name, reason string) {
+	m.serviceLogicEventsDiscarded.WithLabelValues(name, reason).Inc()
+}
+
+// APIRequestRateLimited increments the number of API requests of the given route class rejected by
+// the API rate limits of the given key type, e.g. user or host.
+func (m *MetricsManager) APIRequestRateLimited(class, keyType string) {
+	m.serviceLogicAPIRequestsRateLimited.WithLabelValues(class, keyType).Inc()
+}
+
+// APIRateLimitsBackendFailed increments the number of API rate limits checks that fell back to the
+// in-memory buckets, or syncs of the buckets that failed, because the database failed.
+func (m *MetricsManager) APIRateLimitsBackendFailed() {
+	m.serviceLogicAPIRateLimitsBackendFailures.WithLabelValues().Inc()
+}
+
 func bytesToGib(bytes int64) int64 {
 	return bytes / int64(units.GiB)
 }
@@ -43,6 +43,30 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// APIRateLimitsBackendFailed mocks base method.
+func (m *MockAPI) APIRateLimitsBackendFailed() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "APIRateLimitsBackendFailed")
+}
+
+// APIRateLimitsBackendFailed indicates an expected call of APIRateLimitsBackendFailed.
+func (mr *MockAPIMockRecorder) APIRateLimitsBackendFailed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIRateLimitsBackendFailed", reflect.TypeOf((*MockAPI)(nil).APIRateLimitsBackendFailed))
+}
+
+// APIRequestRateLimited mocks base method.
+func (m *MockAPI) APIRequestRateLimited(class, keyType string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "APIRequestRateLimited", class, keyType)
+}
+
+// APIRequestRateLimited indicates an expected call of APIRequestRateLimited.
+func (mr *MockAPIMockRecorder) APIRequestRateLimited(class, keyType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIRequestRateLimited", reflect.TypeOf((*MockAPI)(nil).APIRequestRateLimited), class, keyType)
+}
+
 // BlacklistedClusterInc mocks base method.
 func (m *MockAPI) BlacklistedClusterInc() {
 	m.ctrl.T.Helper()
@@ -139,6 +163,18 @@ func (mr *MockAPIMockRecorder) Duration(operation, duration any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duration", reflect.TypeOf((*MockAPI)(nil).Duration), operation, duration)
 }
 
+// EventDiscarded mocks base method.
+func (m *MockAPI) EventDiscarded(name, reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EventDiscarded", name, reason)
+}
+
+// EventDiscarded indicates an expected call of EventDiscarded.
+func (mr *MockAPIMockRecorder) EventDiscarded(name, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventDiscarded", reflect.TypeOf((*MockAPI)(nil).EventDiscarded), name, reason)
+}
+
 // FileSystemUsage mocks base method.
 func (m *MockAPI) FileSystemUsage(usageInPercentage float64) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	gormigrate "github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+var addEventsSearchIndexesID = "20261018120000"
+
+func addEventsSearchIndexes() *gormigrate.Migration {
+	// The events table is the largest one, so the indexes are built without blocking the writes to it
+	migrate := func(db *gorm.DB) error {
+		// The expression must match the one used by the full-text search of the events
+		if err := createIndexConcurrently(db, "events_message_fts", `events USING GIN (to_tsvector('english', COALESCE(message, '')))`); err != nil {
+			return err
+		}
+		return createIndexConcurrently(db, "events_by_name_and_time", "events (name, event_time)")
+	}
+
+	rollback := func(tx *gorm.DB) error {
+		if err := tx.Exec(`DROP INDEX IF EXISTS events_message_fts`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DROP INDEX IF EXISTS events_by_name_and_time`).Error; err != nil {
+			return err
+		}
+		return nil
+	}
+
+	return &gormigrate.Migration{
+		ID:       addEventsSearchIndexesID,
+		Migrate:  migrate,
+		Rollback: rollback,
+	}
+}
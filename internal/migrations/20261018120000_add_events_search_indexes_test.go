@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("add events search indexes", func() {
+	var (
+		db     *gorm.DB
+		dbName string
+		gm     *gormigrate.Gormigrate
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		gm = gormigrate.New(db, gormigrate.DefaultOptions, post())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	indexExists := func(name string) bool {
+		var count int64
+		Expect(db.Raw(`SELECT COUNT(*) FROM pg_indexes WHERE tablename = 'events' AND indexname = ?`, name).Row().Scan(&count)).To(Succeed())
+		return count > 0
+	}
+
+	It("Migrates down and up", func() {
+		Expect(gm.MigrateTo(addEventsSearchIndexesID)).ToNot(HaveOccurred())
+		Expect(indexExists("events_message_fts")).To(BeTrue())
+		Expect(indexExists("events_by_name_and_time")).To(BeTrue())
+
+		Expect(gm.RollbackMigration(addEventsSearchIndexes())).ToNot(HaveOccurred())
+		Expect(indexExists("events_message_fts")).To(BeFalse())
+		Expect(indexExists("events_by_name_and_time")).To(BeFalse())
+
+		Expect(gm.MigrateTo(addEventsSearchIndexesID)).ToNot(HaveOccurred())
+		Expect(indexExists("events_message_fts")).To(BeTrue())
+		Expect(indexExists("events_by_name_and_time")).To(BeTrue())
+	})
+})
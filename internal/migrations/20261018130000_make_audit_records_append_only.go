@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	gormigrate "github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+var makeAuditRecordsAppendOnlyID = "20261018130000"
+
+func makeAuditRecordsAppendOnly() *gormigrate.Migration {
+	migrate := func(tx *gorm.DB) error {
+		// The audit records are kept for compliance, the database rejects any change to them
+		if err := tx.Exec(`CREATE OR REPLACE FUNCTION audit_records_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit records are append-only, % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DROP TRIGGER IF EXISTS audit_records_append_only ON audit_records`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`CREATE TRIGGER audit_records_append_only BEFORE UPDATE OR DELETE ON audit_records
+	FOR EACH ROW EXECUTE FUNCTION audit_records_append_only()`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DROP TRIGGER IF EXISTS audit_records_no_truncate ON audit_records`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`CREATE TRIGGER audit_records_no_truncate BEFORE TRUNCATE ON audit_records
+	FOR EACH STATEMENT EXECUTE FUNCTION audit_records_append_only()`).Error
+	}
+
+	rollback := func(tx *gorm.DB) error {
+		if err := tx.Exec(`DROP TRIGGER IF EXISTS audit_records_append_only ON audit_records`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DROP TRIGGER IF EXISTS audit_records_no_truncate ON audit_records`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DROP FUNCTION IF EXISTS audit_records_append_only()`).Error
+	}
+
+	return &gormigrate.Migration{
+		ID:       makeAuditRecordsAppendOnlyID,
+		Migrate:  migrate,
+		Rollback: rollback,
+	}
+}
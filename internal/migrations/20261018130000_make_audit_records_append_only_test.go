@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("make audit records append-only", func() {
+	var (
+		db     *gorm.DB
+		dbName string
+		gm     *gormigrate.Gormigrate
+		record *models.AuditRecord
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		gm = gormigrate.New(db, gormigrate.DefaultOptions, post())
+		record = &models.AuditRecord{
+			ID:     strfmt.UUID(uuid.New().String()),
+			Time:   strfmt.DateTime(time.Now()),
+			Actor:  "user",
+			Method: "DELETE",
+		}
+		Expect(db.Create(record).Error).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	It("Migrates down and up", func() {
+		Expect(gm.MigrateTo(makeAuditRecordsAppendOnlyID)).ToNot(HaveOccurred())
+		Expect(db.Model(record).Update("actor", "other").Error).To(HaveOccurred())
+		Expect(db.Delete(record).Error).To(HaveOccurred())
+		Expect(db.Exec("TRUNCATE audit_records").Error).To(HaveOccurred())
+		Expect(db.Create(&models.AuditRecord{ID: strfmt.UUID(uuid.New().String())}).Error).ToNot(HaveOccurred())
+
+		Expect(gm.RollbackMigration(makeAuditRecordsAppendOnly())).ToNot(HaveOccurred())
+		Expect(db.Model(record).Update("actor", "other").Error).ToNot(HaveOccurred())
+		Expect(db.Delete(record).Error).ToNot(HaveOccurred())
+
+		Expect(gm.MigrateTo(makeAuditRecordsAppendOnlyID)).ToNot(HaveOccurred())
+		Expect(db.Exec("DELETE FROM audit_records").Error).To(HaveOccurred())
+	})
+})
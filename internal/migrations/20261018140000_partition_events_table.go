@@ -0,0 +1,198 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gormigrate "github.com/go-gormigrate/gormigrate/v2"
+	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
+)
+
+var partitionEventsTableID = "20261018140000"
+
+const (
+	eventsLegacyPartition  = "events_legacy"
+	eventsDefaultPartition = "events_default"
+	// eventsLegacyBound is the check constraint that proves the bounds of the legacy partition, so
+	// that neither setting event_time not null nor attaching the partition scans the table
+	eventsLegacyBound = "events_legacy_bound"
+	// eventsLegacyKey is the unique constraint on the primary key of the partitioned table, built
+	// beforehand so that attaching the legacy partition doesn't build it
+	eventsLegacyKey = "events_id_event_time_key"
+	// eventsBackfillBatchSize is the number of events whose event_time is set by each statement of the
+	// backfill, so that no statement holds the locks of the whole table
+	eventsBackfillBatchSize = 10000
+	// eventsPartitionStorage keeps the autovacuum settings of the events table on its partitions, the
+	// partitioned table itself can't hold storage parameters
+	eventsPartitionStorage = "WITH (autovacuum_vacuum_scale_factor = 0.05, autovacuum_analyze_scale_factor = 0.025)"
+)
+
+// partitionEventsTable converts the events table into a table partitioned by range of event_time.
+// The existing table becomes the first partition, holding all the events up to the end of the next
+// day, so no rows are copied. The daily partitions that follow are created by the retention worker
+// and a default partition catches the events out of their range.
+//
+// The constraints that the conversion needs are built while events are still written, the events
+// table is only locked for the renames and the attachment of the partition.
+func partitionEventsTable() *gormigrate.Migration {
+	migrate := func(db *gorm.DB) error {
+		var partitioned int64
+		err := db.Raw(`SELECT count(*) FROM pg_partitioned_table pt
+			JOIN pg_class c ON c.oid = pt.partrelid
+			WHERE c.relname = 'events' AND pg_table_is_visible(c.oid)`).Scan(&partitioned).Error
+		if err != nil || partitioned > 0 {
+			return err
+		}
+
+		legacyUpperBound := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 2).Format("2006-01-02 00:00:00+00")
+		statements := []string{
+			fmt.Sprintf("ALTER TABLE events DROP CONSTRAINT IF EXISTS %s", eventsLegacyBound),
+			fmt.Sprintf("ALTER TABLE events ADD CONSTRAINT %s CHECK (event_time IS NOT NULL AND event_time < '%s') NOT VALID",
+				eventsLegacyBound, legacyUpperBound),
+		}
+		for _, statement := range statements {
+			if err = db.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to prepare the partitioning of the events table, statement %q: %w", statement, err)
+			}
+		}
+		// The partition key must be part of the primary key, so it can't be null
+		if err = backfillEventTimes(db); err != nil {
+			return err
+		}
+		statement := fmt.Sprintf("ALTER TABLE events VALIDATE CONSTRAINT %s", eventsLegacyBound)
+		if err = db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to prepare the partitioning of the events table, statement %q: %w", statement, err)
+		}
+		if err = buildEventsLegacyKey(db); err != nil {
+			return err
+		}
+
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err = tx.Exec("LOCK TABLE events IN ACCESS EXCLUSIVE MODE").Error; err != nil {
+				return err
+			}
+
+			var sequence string
+			if err = tx.Raw("SELECT pg_get_serial_sequence('events', 'id')").Scan(&sequence).Error; err != nil {
+				return err
+			}
+
+			type indexDef struct {
+				Indexname    string
+				Indexdef     string
+				IsConstraint bool
+			}
+			var indexes []indexDef
+			err = tx.Raw(`SELECT i.indexname, i.indexdef, EXISTS (
+					SELECT 1 FROM pg_constraint c WHERE c.conname = i.indexname AND c.conrelid = 'events'::regclass) AS is_constraint
+				FROM pg_indexes i WHERE i.schemaname = current_schema() AND i.tablename = 'events'`).
+				Scan(&indexes).Error
+			if err != nil {
+				return err
+			}
+
+			statements := []string{
+				"ALTER TABLE events ALTER COLUMN event_time SET NOT NULL",
+				fmt.Sprintf("ALTER TABLE events RENAME TO %s", eventsLegacyPartition),
+			}
+			for _, index := range indexes {
+				statements = append(statements, fmt.Sprintf("ALTER INDEX %s RENAME TO %s",
+					quoteIdentifier(index.Indexname), quoteIdentifier(legacyIndexName(index.Indexname))))
+			}
+			statements = append(statements,
+				fmt.Sprintf("CREATE TABLE events (LIKE %s INCLUDING DEFAULTS) PARTITION BY RANGE (event_time)", eventsLegacyPartition),
+				"ALTER TABLE events ADD PRIMARY KEY (id, event_time)",
+				fmt.Sprintf("CREATE TABLE %s PARTITION OF events DEFAULT %s", eventsDefaultPartition, eventsPartitionStorage),
+				fmt.Sprintf("ALTER TABLE events ATTACH PARTITION %s FOR VALUES FROM (MINVALUE) TO ('%s')",
+					eventsLegacyPartition, legacyUpperBound),
+				fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", eventsLegacyPartition, eventsLegacyBound),
+			)
+			// Recreating the indexes on the partitioned table reuses the equivalent indexes of the legacy partition,
+			// the indexes of the constraints are replaced by the primary key
+			for _, index := range indexes {
+				if !index.IsConstraint {
+					statements = append(statements, index.Indexdef)
+				}
+			}
+			if sequence != "" {
+				// Otherwise the sequence would be dropped together with the legacy partition
+				statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY events.id", sequence))
+			}
+			for _, statement := range statements {
+				if err = tx.Exec(statement).Error; err != nil {
+					return fmt.Errorf("failed to partition the events table, statement %q: %w", statement, err)
+				}
+			}
+			return nil
+		})
+	}
+
+	return &gormigrate.Migration{
+		ID:      partitionEventsTableID,
+		Migrate: migrate,
+	}
+}
+
+// backfillEventTimes sets the event_time of the events that don't have one, in batches so that the
+// writers are never blocked for long
+func backfillEventTimes(db *gorm.DB) error {
+	for {
+		result := db.Exec(`UPDATE events SET event_time = created_at
+			WHERE id IN (SELECT id FROM events WHERE event_time IS NULL LIMIT ?)`, eventsBackfillBatchSize)
+		if result.Error != nil {
+			return fmt.Errorf("failed to backfill the event times of the events table: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+	}
+}
+
+// buildEventsLegacyKey builds, without blocking the writers, the unique constraint on the columns of
+// the primary key of the partitioned table, unless the primary key already covers them
+func buildEventsLegacyKey(db *gorm.DB) error {
+	var primaryKey []string
+	err := db.Raw(`SELECT a.attname FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = 'events'::regclass AND i.indisprimary`).Scan(&primaryKey).Error
+	if err != nil {
+		return err
+	}
+	if len(primaryKey) == 2 && funk.ContainsString(primaryKey, "id") && funk.ContainsString(primaryKey, "event_time") {
+		return nil
+	}
+
+	var constraints int64
+	err = db.Raw(`SELECT count(*) FROM pg_constraint WHERE conname = ? AND conrelid = 'events'::regclass`, eventsLegacyKey).
+		Scan(&constraints).Error
+	if err != nil || constraints > 0 {
+		return err
+	}
+	statements := []string{
+		// A build that failed before leaves an invalid index behind
+		fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s", eventsLegacyKey),
+		fmt.Sprintf("CREATE UNIQUE INDEX CONCURRENTLY %s ON events (id, event_time)", eventsLegacyKey),
+		fmt.Sprintf("ALTER TABLE events ADD CONSTRAINT %s UNIQUE USING INDEX %s", eventsLegacyKey, eventsLegacyKey),
+	}
+	for _, statement := range statements {
+		if err = db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to prepare the partitioning of the events table, statement %q: %w", statement, err)
+		}
+	}
+	return nil
+}
+
+func legacyIndexName(name string) string {
+	const maxIdentifierLength = 63
+	suffix := "_legacy"
+	if len(name)+len(suffix) > maxIdentifierLength {
+		name = name[:maxIdentifierLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
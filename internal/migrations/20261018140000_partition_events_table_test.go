@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("partition events table", func() {
+	var (
+		db     *gorm.DB
+		dbName string
+		gm     *gormigrate.Gormigrate
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		gm = gormigrate.New(db, gormigrate.DefaultOptions, post())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	isPartitioned := func() bool {
+		var count int64
+		Expect(db.Raw(`SELECT count(*) FROM pg_partitioned_table pt JOIN pg_class c ON c.oid = pt.partrelid
+			WHERE c.relname = 'events'`).Row().Scan(&count)).To(Succeed())
+		return count > 0
+	}
+
+	It("keeps the existing events in the legacy partition", func() {
+		eventTime := strfmt.DateTime(time.Now())
+		Expect(db.Create(&common.Event{Event: models.Event{Name: "before", Message: swag.String("before"), EventTime: &eventTime}}).Error).To(Succeed())
+
+		Expect(gm.MigrateTo(partitionEventsTableID)).To(Succeed())
+		Expect(isPartitioned()).To(BeTrue())
+
+		var count int64
+		Expect(db.Table(eventsLegacyPartition).Count(&count).Error).To(Succeed())
+		Expect(count).To(BeEquivalentTo(1))
+
+		eventTime = strfmt.DateTime(time.Now().AddDate(0, 0, 3))
+		Expect(db.Create(&common.Event{Event: models.Event{Name: "after", Message: swag.String("after"), EventTime: &eventTime}}).Error).To(Succeed())
+		Expect(db.Table(eventsDefaultPartition).Count(&count).Error).To(Succeed())
+		Expect(count).To(BeEquivalentTo(1))
+		Expect(db.Model(&common.Event{}).Count(&count).Error).To(Succeed())
+		Expect(count).To(BeEquivalentTo(2))
+	})
+
+	It("sets the event time of the events that don't have one", func() {
+		Expect(db.Create(&common.Event{Event: models.Event{Name: "before", Message: swag.String("before")}}).Error).To(Succeed())
+		Expect(db.Exec("UPDATE events SET event_time = NULL").Error).To(Succeed())
+
+		Expect(gm.MigrateTo(partitionEventsTableID)).To(Succeed())
+
+		var count int64
+		Expect(db.Table(eventsLegacyPartition).Where("event_time = created_at").Count(&count).Error).To(Succeed())
+		Expect(count).To(BeEquivalentTo(1))
+	})
+
+	It("keeps the autovacuum settings on the default partition", func() {
+		Expect(gm.MigrateTo(partitionEventsTableID)).To(Succeed())
+
+		var vacuumScaleFactor, analyzeScaleFactor string
+		Expect(db.Raw(`SELECT
+				COALESCE((SELECT option_value FROM pg_options_to_table(reloptions) WHERE option_name = 'autovacuum_vacuum_scale_factor'), ''),
+				COALESCE((SELECT option_value FROM pg_options_to_table(reloptions) WHERE option_name = 'autovacuum_analyze_scale_factor'), '')
+			FROM pg_class WHERE relname = ?`, eventsDefaultPartition).Row().Scan(&vacuumScaleFactor, &analyzeScaleFactor)).To(Succeed())
+		Expect(vacuumScaleFactor).To(Equal("0.05"))
+		Expect(analyzeScaleFactor).To(Equal("0.025"))
+	})
+
+	It("does nothing when the events table is already partitioned", func() {
+		Expect(gm.MigrateTo(partitionEventsTableID)).To(Succeed())
+		Expect(partitionEventsTable().Migrate(db)).To(Succeed())
+		Expect(isPartitioned()).To(BeTrue())
+	})
+})
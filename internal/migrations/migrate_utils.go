@@ -34,3 +34,21 @@ func migrateColumn(id, table, columnName, srcType, dstType string) *gormigrate.M
 func migrateToText(id, table, columnName string) *gormigrate.Migration {
 	return migrateColumn(id, table, columnName, "varchar(2048)", "text")
 }
+
+// createIndexConcurrently builds an index without blocking the writes to the table, so it must not run
+// in a transaction, which is the default of the migrations. An index left invalid by a build that
+// failed is dropped and built again.
+func createIndexConcurrently(db *gorm.DB, name, definition string) error {
+	var invalid int64
+	err := db.Raw(`SELECT count(*) FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE c.relname = ? AND pg_table_is_visible(c.oid) AND NOT i.indisvalid`, name).Scan(&invalid).Error
+	if err != nil {
+		return err
+	}
+	if invalid > 0 {
+		if err = db.Exec(fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s", name)).Error; err != nil {
+			return err
+		}
+	}
+	return db.Exec(fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s", name, definition)).Error
+}
@@ -52,6 +52,9 @@ func post() []*gormigrate.Migration {
 		addHostsByInfraEnvIdIndex(),
 		populatePrimaryIPStackForExistingClusters(),
 		setEventsAutovacuumSettings(),
+		addEventsSearchIndexes(),
+		makeAuditRecordsAppendOnly(),
+		partitionEventsTable(),
 	}
 
 	sort.SliceStable(postMigrations, func(i, j int) bool { return postMigrations[i].ID < postMigrations[j].ID })
@@ -0,0 +1,59 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/pkg/auth"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/quotas"
+	"github.com/sirupsen/logrus"
+)
+
+var _ restapi.QuotasAPI = &QuotasAPI{}
+
+// QuotasAPI reports the usage of the resources by the tenants and their quotas
+type QuotasAPI struct {
+	quotaApi     API
+	authzHandler auth.Authorizer
+	log          logrus.FieldLogger
+}
+
+func NewQuotasAPI(quotaApi API, authzHandler auth.Authorizer, log logrus.FieldLogger) *QuotasAPI {
+	return &QuotasAPI{
+		quotaApi:     quotaApi,
+		authzHandler: authzHandler,
+		log:          log,
+	}
+}
+
+func (q *QuotasAPI) V2ListQuotas(ctx context.Context, params operations.V2ListQuotasParams) middleware.Responder {
+	log := logutil.FromContext(ctx, q.log)
+
+	orgID := ocm.OrgIDFromContext(ctx)
+	userName := ocm.UserNameFromContext(ctx)
+	if params.OrgID != nil || params.UserName != nil {
+		otherOrg := params.OrgID != nil && *params.OrgID != orgID
+		otherUser := params.UserName != nil && *params.UserName != userName
+		if (otherOrg || otherUser) && !q.authzHandler.IsAdmin(ctx) {
+			return common.GenerateErrorResponder(common.NewInfraError(http.StatusForbidden,
+				fmt.Errorf("only the admins can report the quotas of other organizations and users")))
+		}
+		// Only the requested tenants are reported
+		orgID = swag.StringValue(params.OrgID)
+		userName = swag.StringValue(params.UserName)
+	}
+
+	usages, err := q.quotaApi.Usage(ctx, orgID, userName)
+	if err != nil {
+		log.WithError(err).Error("failed to get the usage of the quotas")
+		return common.GenerateErrorResponder(err)
+	}
+	return operations.NewV2ListQuotasOK().WithPayload(usages)
+}
@@ -0,0 +1,70 @@
+package quota
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-openapi/swag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/quotas"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("V2ListQuotas", func() {
+	var (
+		ctrl      *gomock.Controller
+		mockQuota *MockAPI
+		api       *QuotasAPI
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockQuota = NewMockAPI(ctrl)
+		authzHandler := auth.NewAuthzHandler(&auth.Config{AuthType: auth.TypeOIDC}, nil, common.GetTestLog(), nil)
+		api = NewQuotasAPI(mockQuota, authzHandler, common.GetTestLog())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	contextOf := func(role ocm.RoleType) context.Context {
+		return context.WithValue(context.Background(), restapi.AuthKey,
+			&ocm.AuthPayload{Username: "jdoe", Organization: "12345", Role: role})
+	}
+
+	It("reports the quotas of the organization and the user of the request", func() {
+		ctx := contextOf(ocm.UserRole)
+		usages := models.QuotaUsages{{Scope: swag.String(models.QuotaUsageScopeOrg), Tenant: swag.String("12345")}}
+		mockQuota.EXPECT().Usage(ctx, "12345", "jdoe").Return(usages, nil).Times(1)
+		reply := api.V2ListQuotas(ctx, operations.V2ListQuotasParams{})
+		Expect(reply).To(BeAssignableToTypeOf(operations.NewV2ListQuotasOK()))
+		Expect(reply.(*operations.V2ListQuotasOK).Payload).To(Equal(usages))
+	})
+
+	It("reports only the requested tenant", func() {
+		ctx := contextOf(ocm.UserRole)
+		mockQuota.EXPECT().Usage(ctx, "", "jdoe").Return(models.QuotaUsages{}, nil).Times(1)
+		reply := api.V2ListQuotas(ctx, operations.V2ListQuotasParams{UserName: swag.String("jdoe")})
+		Expect(reply).To(BeAssignableToTypeOf(operations.NewV2ListQuotasOK()))
+	})
+
+	It("doesn't report the quotas of the other tenants to the users", func() {
+		reply := api.V2ListQuotas(contextOf(ocm.UserRole), operations.V2ListQuotasParams{OrgID: swag.String("67890")})
+		Expect(reply).To(BeAssignableToTypeOf(&common.InfraErrorResponse{}))
+		Expect(reply.(*common.InfraErrorResponse).StatusCode()).To(Equal(int32(http.StatusForbidden)))
+	})
+
+	It("reports the quotas of the other tenants to the admins", func() {
+		ctx := contextOf(ocm.ReadOnlyAdminRole)
+		mockQuota.EXPECT().Usage(ctx, "67890", "").Return(models.QuotaUsages{}, nil).Times(1)
+		reply := api.V2ListQuotas(ctx, operations.V2ListQuotasParams{OrgID: swag.String("67890")})
+		Expect(reply).To(BeAssignableToTypeOf(operations.NewV2ListQuotasOK()))
+	})
+})
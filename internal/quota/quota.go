@@ -0,0 +1,342 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	errorutil "github.com/openshift/assisted-service/pkg/error"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=quota.go -package=quota -destination=mock_quota.go
+
+type Config struct {
+	// Limits is a JSON document with the default limits of the organizations and the users, and
+	// the limits of specific organizations and users, e.g.
+	// {"org":{"clusters":100,"hosts":1000},"user":{"clusters":10},"orgs":{"12345":{"clusters":500}},"users":{"jdoe":{"iso_downloads":5}}}
+	Limits string `envconfig:"QUOTA_LIMITS" default:""`
+	// ISODownloadsPeriod is the period over which the ISO download URLs are counted
+	ISODownloadsPeriod time.Duration `envconfig:"QUOTA_ISO_DOWNLOADS_PERIOD" default:"24h"`
+}
+
+// Limits are the quotas of a tenant, the resources without a limit are unlimited
+type Limits struct {
+	Clusters     *int64 `json:"clusters,omitempty"`
+	InfraEnvs    *int64 `json:"infra_envs,omitempty"`
+	Hosts        *int64 `json:"hosts,omitempty"`
+	ISODownloads *int64 `json:"iso_downloads,omitempty"`
+}
+
+// merge returns the limits, with the limits of the overrides taking precedence
+func (l Limits) merge(overrides Limits) Limits {
+	if overrides.Clusters != nil {
+		l.Clusters = overrides.Clusters
+	}
+	if overrides.InfraEnvs != nil {
+		l.InfraEnvs = overrides.InfraEnvs
+	}
+	if overrides.Hosts != nil {
+		l.Hosts = overrides.Hosts
+	}
+	if overrides.ISODownloads != nil {
+		l.ISODownloads = overrides.ISODownloads
+	}
+	return l
+}
+
+func (l Limits) limit(resource string) *int64 {
+	switch resource {
+	case models.QuotaUsageResourceClusters:
+		return l.Clusters
+	case models.QuotaUsageResourceInfraEnvs:
+		return l.InfraEnvs
+	case models.QuotaUsageResourceHosts:
+		return l.Hosts
+	case models.QuotaUsageResourceIsoDownloads:
+		return l.ISODownloads
+	}
+	return nil
+}
+
+// limitsConfig is the format of QUOTA_LIMITS
+type limitsConfig struct {
+	Org   Limits            `json:"org"`
+	User  Limits            `json:"user"`
+	Orgs  map[string]Limits `json:"orgs"`
+	Users map[string]Limits `json:"users"`
+}
+
+func parseLimits(data string) (*limitsConfig, error) {
+	config := &limitsConfig{}
+	if data == "" {
+		return config, nil
+	}
+	if err := json.Unmarshal([]byte(data), config); err != nil {
+		return nil, fmt.Errorf("failed to parse QUOTA_LIMITS json %s: %w", data, err)
+	}
+	all := []Limits{config.Org, config.User}
+	for _, limits := range config.Orgs {
+		all = append(all, limits)
+	}
+	for _, limits := range config.Users {
+		all = append(all, limits)
+	}
+	for _, limits := range all {
+		for _, limit := range []*int64{limits.Clusters, limits.InfraEnvs, limits.Hosts, limits.ISODownloads} {
+			if limit != nil && *limit < 0 {
+				return nil, fmt.Errorf("invalid QUOTA_LIMITS json %s: the limits must not be negative", data)
+			}
+		}
+	}
+	return config, nil
+}
+
+// tenant is an organization or a user, the quotas of both apply to the resources of a user
+type tenant struct {
+	scope string
+	name  string
+}
+
+// The checks lock the usage of the tenants with a limit until the end of the transaction tx, which
+// must only end once the new resource is created, so that concurrent requests can't exceed a limit.
+type API interface {
+	// CheckClusterQuota fails if the organization or the user of the context can't create another cluster
+	CheckClusterQuota(ctx context.Context, tx *gorm.DB) error
+	// CheckInfraEnvQuota fails if the organization or the user of the context can't create another infra-env
+	CheckInfraEnvQuota(ctx context.Context, tx *gorm.DB) error
+	// CheckHostQuota fails if the owner of the infra-env can't register another host
+	CheckHostQuota(ctx context.Context, tx *gorm.DB, infraEnv *common.InfraEnv) error
+	// RecordISODownload counts a new download URL of the ISO of the infra-env, or fails if its owner
+	// requested too many ISO download URLs during the period. The service only issues the URLs, the
+	// downloads themselves are served by the image service and are not counted.
+	RecordISODownload(ctx context.Context, infraEnv *common.InfraEnv) error
+	// Usage returns the usage of the resources by the organization and the user, and their limits
+	Usage(ctx context.Context, orgID, userName string) (models.QuotaUsages, error)
+}
+
+type Manager struct {
+	cfg    Config
+	limits *limitsConfig
+	db     *gorm.DB
+	log    logrus.FieldLogger
+}
+
+var _ API = &Manager{}
+
+func NewManager(cfg Config, db *gorm.DB, log logrus.FieldLogger) (*Manager, error) {
+	limits, err := parseLimits(cfg.Limits)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{
+		cfg:    cfg,
+		limits: limits,
+		db:     db,
+		log:    log,
+	}, nil
+}
+
+func (m *Manager) limitsOf(t tenant) Limits {
+	if t.scope == models.QuotaUsageScopeOrg {
+		return m.limits.Org.merge(m.limits.Orgs[t.name])
+	}
+	return m.limits.User.merge(m.limits.Users[t.name])
+}
+
+// tenants returns the tenants charged for the resources created by the user of the context. The
+// admins, which include the internal callers like the controllers, have no quotas.
+func tenants(ctx context.Context, orgID, userName string) []tenant {
+	if ocm.PayloadFromContext(ctx).Role == ocm.AdminRole {
+		return nil
+	}
+	return owners(orgID, userName)
+}
+
+// owners returns the tenants that own a resource
+func owners(orgID, userName string) []tenant {
+	ret := []tenant{}
+	if orgID != "" {
+		ret = append(ret, tenant{scope: models.QuotaUsageScopeOrg, name: orgID})
+	}
+	if userName != "" {
+		ret = append(ret, tenant{scope: models.QuotaUsageScopeUser, name: userName})
+	}
+	return ret
+}
+
+func tenantColumn(scope string) string {
+	if scope == models.QuotaUsageScopeOrg {
+		return "org_id"
+	}
+	return "user_name"
+}
+
+// count returns the current usage of the resource by the tenant
+func (m *Manager) count(db *gorm.DB, t tenant, resource string) (int64, error) {
+	var count int64
+	var query *gorm.DB
+	column := tenantColumn(t.scope)
+	switch resource {
+	case models.QuotaUsageResourceClusters:
+		query = db.Model(&common.Cluster{}).Where(column+" = ?", t.name)
+	case models.QuotaUsageResourceInfraEnvs:
+		query = db.Model(&common.InfraEnv{}).Where(column+" = ?", t.name)
+	case models.QuotaUsageResourceHosts:
+		// The hosts belong to the owner of their infra-env
+		query = db.Model(&common.Host{}).
+			Joins("JOIN infra_envs ON infra_envs.id = hosts.infra_env_id").
+			Where("infra_envs."+column+" = ?", t.name)
+	case models.QuotaUsageResourceIsoDownloads:
+		query = db.Model(&common.ISODownload{}).
+			Where(column+" = ? AND created_at > ?", t.name, time.Now().Add(-m.cfg.ISODownloadsPeriod))
+	default:
+		return 0, fmt.Errorf("unknown quota resource %s", resource)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// lock locks the usage of the resource by the tenant until the end of the transaction, so that
+// the concurrent checks of the same tenant wait for the resources created after the previous ones
+func lock(tx *gorm.DB, t tenant, resource string) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtext('quota'), hashtext(?))",
+		fmt.Sprintf("%s/%s/%s", t.scope, t.name, resource)).Error
+}
+
+// check fails if one of the tenants already reached its limit of the resource. The tenants with a
+// limit stay locked until the end of the transaction, it must create the resource before it ends.
+func (m *Manager) check(tx *gorm.DB, tenants []tenant, resource string) error {
+	for _, t := range tenants {
+		limit := m.limitsOf(t).limit(resource)
+		if limit == nil {
+			continue
+		}
+		if err := lock(tx, t, resource); err != nil {
+			return common.NewApiError(500, fmt.Errorf("failed to lock the %s of %s %s: %w", resource, t.scope, t.name, err))
+		}
+		usage, err := m.count(tx, t, resource)
+		if err != nil {
+			return common.NewApiError(500, fmt.Errorf("failed to count the %s of %s %s: %w", resource, t.scope, t.name, err))
+		}
+		if usage >= *limit {
+			quotaErr := &errorutil.QuotaExceededError{
+				Scope:    t.scope,
+				Tenant:   t.name,
+				Resource: resource,
+				Limit:    *limit,
+				Usage:    usage,
+			}
+			if resource == models.QuotaUsageResourceIsoDownloads {
+				quotaErr.Period = m.cfg.ISODownloadsPeriod
+				quotaErr.RetryAfter = m.isoDownloadsRetryAfter(tx, t, usage-*limit)
+			}
+			m.log.Info(quotaErr.Error())
+			return quotaErr
+		}
+	}
+	return nil
+}
+
+// isoDownloadsRetryAfter returns the time after which enough downloads of the tenant will be older
+// than the period for its usage to be below its limit
+func (m *Manager) isoDownloadsRetryAfter(db *gorm.DB, t tenant, excess int64) time.Duration {
+	var download common.ISODownload
+	err := db.Where(tenantColumn(t.scope)+" = ? AND created_at > ?", t.name, time.Now().Add(-m.cfg.ISODownloadsPeriod)).
+		Order("created_at").Offset(int(excess)).Take(&download).Error
+	if err != nil {
+		return 0
+	}
+	return time.Until(download.CreatedAt.Add(m.cfg.ISODownloadsPeriod))
+}
+
+func (m *Manager) CheckClusterQuota(ctx context.Context, tx *gorm.DB) error {
+	return m.check(tx, tenants(ctx, ocm.OrgIDFromContext(ctx), ocm.UserNameFromContext(ctx)), models.QuotaUsageResourceClusters)
+}
+
+func (m *Manager) CheckInfraEnvQuota(ctx context.Context, tx *gorm.DB) error {
+	return m.check(tx, tenants(ctx, ocm.OrgIDFromContext(ctx), ocm.UserNameFromContext(ctx)), models.QuotaUsageResourceInfraEnvs)
+}
+
+// CheckHostQuota doesn't exempt the admins: the agents register the hosts, with the admin payload
+// under the local authentication
+func (m *Manager) CheckHostQuota(ctx context.Context, tx *gorm.DB, infraEnv *common.InfraEnv) error {
+	return m.check(tx, owners(infraEnv.OrgID, infraEnv.UserName), models.QuotaUsageResourceHosts)
+}
+
+func (m *Manager) RecordISODownload(ctx context.Context, infraEnv *common.InfraEnv) error {
+	downloadTenants := tenants(ctx, infraEnv.OrgID, infraEnv.UserName)
+	limited := false
+	for _, t := range downloadTenants {
+		limited = limited || m.limitsOf(t).ISODownloads != nil
+	}
+	// The downloads are only counted when they are limited
+	if !limited {
+		return nil
+	}
+	now := time.Now()
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := m.check(tx, downloadTenants, models.QuotaUsageResourceIsoDownloads); err != nil {
+			return err
+		}
+		download := &common.ISODownload{
+			InfraEnvID: *infraEnv.ID,
+			OrgID:      infraEnv.OrgID,
+			UserName:   infraEnv.UserName,
+			CreatedAt:  now,
+		}
+		if err := tx.Create(download).Error; err != nil {
+			return common.NewApiError(500, fmt.Errorf("failed to record the ISO download of infra-env %s: %w", infraEnv.ID, err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// The downloads older than the period are not needed anymore
+	if err := m.db.Where("created_at < ?", now.Add(-m.cfg.ISODownloadsPeriod)).Delete(&common.ISODownload{}).Error; err != nil {
+		m.log.WithError(err).Warn("failed to delete the old ISO downloads")
+	}
+	return nil
+}
+
+func (m *Manager) Usage(ctx context.Context, orgID, userName string) (models.QuotaUsages, error) {
+	ret := models.QuotaUsages{}
+	usageTenants := []tenant{}
+	if orgID != "" {
+		usageTenants = append(usageTenants, tenant{scope: models.QuotaUsageScopeOrg, name: orgID})
+	}
+	if userName != "" {
+		usageTenants = append(usageTenants, tenant{scope: models.QuotaUsageScopeUser, name: userName})
+	}
+	for _, t := range usageTenants {
+		limits := m.limitsOf(t)
+		for _, resource := range []string{models.QuotaUsageResourceClusters, models.QuotaUsageResourceInfraEnvs,
+			models.QuotaUsageResourceHosts, models.QuotaUsageResourceIsoDownloads} {
+			usage, err := m.count(m.db.WithContext(ctx), t, resource)
+			if err != nil {
+				return nil, err
+			}
+			quotaUsage := &models.QuotaUsage{
+				Scope:    swag.String(t.scope),
+				Tenant:   swag.String(t.name),
+				Resource: swag.String(resource),
+				Usage:    swag.Int64(usage),
+				Limit:    limits.limit(resource),
+			}
+			if resource == models.QuotaUsageResourceIsoDownloads {
+				quotaUsage.Period = m.cfg.ISODownloadsPeriod.String()
+			}
+			ret = append(ret, quotaUsage)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,210 @@
+package quota
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	errorutil "github.com/openshift/assisted-service/pkg/error"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("parseLimits", func() {
+	It("has no limits by default", func() {
+		limits, err := parseLimits("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(limits.Org.limit(models.QuotaUsageResourceClusters)).To(BeNil())
+		Expect(limits.User.limit(models.QuotaUsageResourceHosts)).To(BeNil())
+	})
+
+	It("overrides the default limits with the limits of the tenants", func() {
+		limits, err := parseLimits(`{"org":{"clusters":100,"hosts":1000},"orgs":{"12345":{"clusters":500}}}`)
+		Expect(err).ToNot(HaveOccurred())
+		manager := &Manager{limits: limits}
+		orgLimits := manager.limitsOf(tenant{scope: models.QuotaUsageScopeOrg, name: "12345"})
+		Expect(swag.Int64Value(orgLimits.Clusters)).To(Equal(int64(500)))
+		Expect(swag.Int64Value(orgLimits.Hosts)).To(Equal(int64(1000)))
+		otherLimits := manager.limitsOf(tenant{scope: models.QuotaUsageScopeOrg, name: "67890"})
+		Expect(swag.Int64Value(otherLimits.Clusters)).To(Equal(int64(100)))
+		Expect(manager.limitsOf(tenant{scope: models.QuotaUsageScopeUser, name: "jdoe"}).Clusters).To(BeNil())
+	})
+
+	It("rejects invalid limits", func() {
+		_, err := parseLimits(`{"org":`)
+		Expect(err).To(HaveOccurred())
+		_, err = parseLimits(`{"users":{"jdoe":{"clusters":-1}}}`)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Manager", func() {
+	var (
+		db      *gorm.DB
+		dbName  string
+		manager *Manager
+		userCtx context.Context
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		var err error
+		manager, err = NewManager(Config{
+			Limits:             `{"org":{"clusters":2},"user":{"infra_envs":1,"hosts":1,"iso_downloads":2}}`,
+			ISODownloadsPeriod: time.Hour,
+		}, db, common.GetTestLog())
+		Expect(err).ToNot(HaveOccurred())
+		userCtx = context.WithValue(context.Background(), restapi.AuthKey,
+			&ocm.AuthPayload{Username: "jdoe", Organization: "12345", Role: ocm.UserRole})
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	createCluster := func(orgID, userName string) {
+		id := strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &id, OrgID: orgID, UserName: userName}}).Error).ToNot(HaveOccurred())
+	}
+
+	createInfraEnv := func(orgID, userName string) *common.InfraEnv {
+		id := strfmt.UUID(uuid.New().String())
+		infraEnv := &common.InfraEnv{InfraEnv: models.InfraEnv{ID: &id, OrgID: orgID, UserName: userName}}
+		Expect(db.Create(infraEnv).Error).ToNot(HaveOccurred())
+		return infraEnv
+	}
+
+	expectQuotaExceeded := func(err error, scope, resource string, statusCode int32) {
+		Expect(err).To(HaveOccurred())
+		Expect(errorutil.IsQuotaExceeded(err)).To(BeTrue())
+		quotaErr := err.(*errorutil.QuotaExceededError)
+		Expect(quotaErr.Scope).To(Equal(scope))
+		Expect(quotaErr.Resource).To(Equal(resource))
+		Expect(quotaErr.StatusCode()).To(Equal(statusCode))
+	}
+
+	It("limits the clusters of the organization", func() {
+		createCluster("12345", "jdoe")
+		createCluster("12345", "other")
+		createCluster("67890", "jdoe")
+		expectQuotaExceeded(manager.CheckClusterQuota(userCtx, db),
+			models.QuotaUsageScopeOrg, models.QuotaUsageResourceClusters, http.StatusForbidden)
+
+		Expect(db.Where("user_name = ?", "other").Delete(&common.Cluster{}).Error).ToNot(HaveOccurred())
+		Expect(manager.CheckClusterQuota(userCtx, db)).To(Succeed())
+	})
+
+	It("serializes the checks of the same tenant until the resource is created", func() {
+		createCluster("12345", "other")
+		tx := db.Begin()
+		Expect(manager.CheckClusterQuota(userCtx, tx)).To(Succeed())
+
+		checked := make(chan error, 1)
+		go func() {
+			defer GinkgoRecover()
+			concurrentTx := db.Begin()
+			defer concurrentTx.Rollback()
+			checked <- manager.CheckClusterQuota(userCtx, concurrentTx)
+		}()
+		Consistently(checked, "500ms").ShouldNot(Receive())
+
+		createCluster("12345", "jdoe")
+		tx.Rollback()
+		var err error
+		Eventually(checked).Should(Receive(&err))
+		expectQuotaExceeded(err, models.QuotaUsageScopeOrg, models.QuotaUsageResourceClusters, http.StatusForbidden)
+	})
+
+	It("limits the infra-envs of the user", func() {
+		Expect(manager.CheckInfraEnvQuota(userCtx, db)).To(Succeed())
+		createInfraEnv("12345", "jdoe")
+		expectQuotaExceeded(manager.CheckInfraEnvQuota(userCtx, db),
+			models.QuotaUsageScopeUser, models.QuotaUsageResourceInfraEnvs, http.StatusForbidden)
+	})
+
+	It("charges the hosts to the owner of their infra-env", func() {
+		infraEnv := createInfraEnv("12345", "jdoe")
+		Expect(manager.CheckHostQuota(context.Background(), db, infraEnv)).To(Succeed())
+		id := strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.Host{Host: models.Host{ID: &id, InfraEnvID: *infraEnv.ID}}).Error).ToNot(HaveOccurred())
+
+		// The agents register the hosts, the quota applies to the owner of the infra-env regardless
+		agentCtx := context.WithValue(context.Background(), restapi.AuthKey, &ocm.AuthPayload{Role: ocm.UserRole})
+		expectQuotaExceeded(manager.CheckHostQuota(agentCtx, db, infraEnv),
+			models.QuotaUsageScopeUser, models.QuotaUsageResourceHosts, http.StatusForbidden)
+
+		// The local authentication authenticates the agents with the admin payload
+		expectQuotaExceeded(manager.CheckHostQuota(context.Background(), db, infraEnv),
+			models.QuotaUsageScopeUser, models.QuotaUsageResourceHosts, http.StatusForbidden)
+	})
+
+	It("limits the rate of the ISO downloads", func() {
+		infraEnv := createInfraEnv("12345", "jdoe")
+		Expect(manager.RecordISODownload(userCtx, infraEnv)).To(Succeed())
+		Expect(manager.RecordISODownload(userCtx, infraEnv)).To(Succeed())
+		err := manager.RecordISODownload(userCtx, infraEnv)
+		expectQuotaExceeded(err, models.QuotaUsageScopeUser, models.QuotaUsageResourceIsoDownloads, http.StatusTooManyRequests)
+		retryAfter := err.(*errorutil.QuotaExceededError).RetryAfter
+		Expect(retryAfter).To(BeNumerically(">", 59*time.Minute))
+		Expect(retryAfter).To(BeNumerically("<=", time.Hour))
+
+		// The downloads older than the period don't count
+		Expect(db.Model(&common.ISODownload{}).Where("1 = 1").
+			Update("created_at", time.Now().Add(-2*time.Hour)).Error).ToNot(HaveOccurred())
+		Expect(manager.RecordISODownload(userCtx, infraEnv)).To(Succeed())
+		var count int64
+		Expect(db.Model(&common.ISODownload{}).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(Equal(int64(1)))
+	})
+
+	It("doesn't count the unlimited ISO downloads", func() {
+		unlimited, err := NewManager(Config{ISODownloadsPeriod: time.Hour}, db, common.GetTestLog())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unlimited.RecordISODownload(userCtx, createInfraEnv("12345", "jdoe"))).To(Succeed())
+		var count int64
+		Expect(db.Model(&common.ISODownload{}).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(BeZero())
+	})
+
+	It("doesn't limit the admins", func() {
+		createCluster("12345", "jdoe")
+		createCluster("12345", "jdoe")
+		adminCtx := context.WithValue(context.Background(), restapi.AuthKey,
+			&ocm.AuthPayload{Username: "jdoe", Organization: "12345", Role: ocm.AdminRole})
+		Expect(manager.CheckClusterQuota(adminCtx, db)).To(Succeed())
+	})
+
+	It("reports the usage and the limits", func() {
+		createCluster("12345", "jdoe")
+		createInfraEnv("12345", "jdoe")
+		usages, err := manager.Usage(userCtx, "12345", "jdoe")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(HaveLen(8))
+
+		find := func(scope, resource string) *models.QuotaUsage {
+			for _, usage := range usages {
+				if *usage.Scope == scope && *usage.Resource == resource {
+					return usage
+				}
+			}
+			return nil
+		}
+		orgClusters := find(models.QuotaUsageScopeOrg, models.QuotaUsageResourceClusters)
+		Expect(*orgClusters.Tenant).To(Equal("12345"))
+		Expect(*orgClusters.Usage).To(Equal(int64(1)))
+		Expect(*orgClusters.Limit).To(Equal(int64(2)))
+		Expect(find(models.QuotaUsageScopeOrg, models.QuotaUsageResourceHosts).Limit).To(BeNil())
+		userInfraEnvs := find(models.QuotaUsageScopeUser, models.QuotaUsageResourceInfraEnvs)
+		Expect(*userInfraEnvs.Usage).To(Equal(int64(1)))
+		Expect(*userInfraEnvs.Limit).To(Equal(int64(1)))
+		Expect(find(models.QuotaUsageScopeUser, models.QuotaUsageResourceIsoDownloads).Period).To(Equal("1h0m0s"))
+	})
+})
@@ -0,0 +1,191 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/openshift/assisted-service/internal/common"
+	"gorm.io/gorm"
+)
+
+// rate is a generic cell rate: the requests are allowed one per interval, with bursts of up to
+// tolerance / interval requests
+type rate struct {
+	interval  time.Duration
+	tolerance time.Duration
+}
+
+func rateOf(perMinute float64, burst int) rate {
+	interval := time.Duration(float64(time.Minute) / perMinute)
+	return rate{interval: interval, tolerance: interval * time.Duration(burst)}
+}
+
+// next returns the theoretical arrival time of the next request after a request at now allowed
+// with the given theoretical arrival time, and the time at which that request can be allowed
+func (r rate) next(tat, now time.Time) (time.Time, time.Time) {
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(r.interval)
+	return newTat, newTat.Add(-r.tolerance)
+}
+
+func (r rate) remaining(newTat, now time.Time) int {
+	return int((r.tolerance - newTat.Sub(now)) / r.interval)
+}
+
+type backend interface {
+	// allow charges a request at now to the bucket, and returns whether it's allowed, the number of
+	// requests that can still be made right away or the time after which it can be retried
+	allow(ctx context.Context, key string, r rate, now time.Time) (bool, int, time.Duration, error)
+	// sync shares the buckets with the other replicas
+	sync(ctx context.Context, now time.Time) error
+	cleanup(now time.Time) error
+}
+
+// memoryBackend keeps the buckets of the replica
+type memoryBackend struct {
+	mu   sync.Mutex
+	tats map[string]time.Time
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{tats: make(map[string]time.Time)}
+}
+
+func (m *memoryBackend) allow(_ context.Context, key string, r rate, now time.Time) (bool, int, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	newTat, allowAt := r.next(m.tats[key], now)
+	if allowAt.After(now) {
+		return false, 0, allowAt.Sub(now), nil
+	}
+	m.tats[key] = newTat
+	return true, r.remaining(newTat, now), 0, nil
+}
+
+func (m *memoryBackend) sync(context.Context, time.Time) error {
+	return nil
+}
+
+func (m *memoryBackend) cleanup(now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, tat := range m.tats {
+		if tat.Before(now) {
+			delete(m.tats, key)
+		}
+	}
+	return nil
+}
+
+// postgresBackend shares the buckets of the replicas through the database without a write per
+// request: the requests are charged to the in-memory buckets of the replica, and the requests allowed
+// since the last sync are added to the database buckets periodically, in a single statement. The
+// buckets of the replica then take the state of the database buckets, which include the requests of
+// the other replicas. The replicas can go over a budget by the requests they allow between two syncs.
+type postgresBackend struct {
+	db      *gorm.DB
+	mu      sync.Mutex
+	buckets map[string]*syncedBucket
+}
+
+type syncedBucket struct {
+	tat  time.Time
+	rate rate
+	// pending is the number of requests allowed since the last sync
+	pending int64
+}
+
+func newPostgresBackend(db *gorm.DB) *postgresBackend {
+	return &postgresBackend{db: db, buckets: make(map[string]*syncedBucket)}
+}
+
+const syncQuery = `
+INSERT INTO api_rate_limit_buckets AS b (key, tat)
+SELECT u.key, @now + u.charge FROM unnest(CAST(@keys AS text[]), CAST(@charges AS bigint[])) AS u(key, charge)
+ON CONFLICT (key) DO UPDATE SET tat = GREATEST(b.tat, @now) + EXCLUDED.tat - @now
+RETURNING key, tat`
+
+func (p *postgresBackend) allow(_ context.Context, key string, r rate, now time.Time) (bool, int, time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	bucket, ok := p.buckets[key]
+	if !ok {
+		bucket = &syncedBucket{}
+		p.buckets[key] = bucket
+	}
+	bucket.rate = r
+	newTat, allowAt := r.next(bucket.tat, now)
+	if allowAt.After(now) {
+		return false, 0, allowAt.Sub(now), nil
+	}
+	bucket.tat = newTat
+	bucket.pending++
+	return true, r.remaining(newTat, now), 0, nil
+}
+
+// sync adds the requests allowed by the replica to the database buckets, and updates the buckets of
+// the replica that aren't full with the requests of the other replicas
+func (p *postgresBackend) sync(ctx context.Context, now time.Time) error {
+	p.mu.Lock()
+	var keys []string
+	var pending, charges []int64
+	for key, bucket := range p.buckets {
+		if bucket.pending == 0 && !bucket.tat.After(now) {
+			continue
+		}
+		keys = append(keys, key)
+		pending = append(pending, bucket.pending)
+		charges = append(charges, bucket.pending*bucket.rate.interval.Microseconds())
+		bucket.pending = 0
+	}
+	p.mu.Unlock()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var rows []common.APIRateLimitBucket
+	err := p.db.WithContext(ctx).Raw(syncQuery, map[string]interface{}{
+		"keys":    pq.StringArray(keys),
+		"charges": pq.Int64Array(charges),
+		"now":     now.UnixMicro(),
+	}).Scan(&rows).Error
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		// The requests are added by the next sync
+		for i, key := range keys {
+			if bucket, ok := p.buckets[key]; ok {
+				bucket.pending += pending[i]
+			}
+		}
+		return err
+	}
+	for _, row := range rows {
+		bucket, ok := p.buckets[row.Key]
+		if !ok {
+			continue
+		}
+		// The requests allowed during the sync aren't in the database yet
+		tat := time.UnixMicro(row.Tat).Add(time.Duration(bucket.pending) * bucket.rate.interval)
+		if tat.After(bucket.tat) {
+			bucket.tat = tat
+		}
+	}
+	return nil
+}
+
+func (p *postgresBackend) cleanup(now time.Time) error {
+	p.mu.Lock()
+	for key, bucket := range p.buckets {
+		if bucket.pending == 0 && bucket.tat.Before(now) {
+			delete(p.buckets, key)
+		}
+	}
+	p.mu.Unlock()
+	return p.db.Where("tat < ?", now.UnixMicro()).Delete(&common.APIRateLimitBucket{}).Error
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/go-openapi/errors"
+)
+
+type resultKey struct{}
+
+// WithResult adds the holder of the result of the rate limits check of the request to the context,
+// the check is made by the authorizer and the result is reported by the outer middleware
+func WithResult(ctx context.Context, result *Result) context.Context {
+	return context.WithValue(ctx, resultKey{}, result)
+}
+
+// ResultFromContext returns the result of the rate limits check of the request, or nil if the
+// request isn't rate limited
+func ResultFromContext(ctx context.Context) *Result {
+	result, _ := ctx.Value(resultKey{}).(*Result)
+	return result
+}
+
+// RetryAfterSeconds returns the value of the Retry-After header of a rate limited request
+func (r *Result) RetryAfterSeconds() int {
+	return int(math.Max(1, math.Ceil(r.RetryAfter.Seconds())))
+}
+
+// Authorizer checks the rate limits of the request before authorizing it. The rate limits are
+// checked by the authorizer because the authenticated user is unknown before, and the authorizer
+// is only called for the authenticated requests.
+func Authorizer(limiter *Limiter, authorize func(*http.Request) error) func(*http.Request) error {
+	if !limiter.Enabled() {
+		return authorize
+	}
+	return func(request *http.Request) error {
+		if result := limiter.Check(request); result != nil {
+			if holder := ResultFromContext(request.Context()); holder != nil {
+				*holder = *result
+			}
+			if !result.Allowed {
+				return errors.New(http.StatusTooManyRequests,
+					"too many %s requests, retry in %d seconds", result.Class, result.RetryAfterSeconds())
+			}
+		}
+		return authorize(request)
+	}
+}
@@ -0,0 +1,295 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift/assisted-service/client"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// The route classes have separate budgets
+const (
+	ClassRead   = "read"
+	ClassWrite  = "write"
+	ClassEvents = "events"
+	ClassAgent  = "agent"
+)
+
+// The types of the keys of the buckets, a request is charged to the bucket of its principal and to
+// the bucket of its organization
+const (
+	KeyTypeHost   = "host"
+	KeyTypeToken  = "token"
+	KeyTypeUser   = "user"
+	KeyTypeOrg    = "org"
+	KeyTypeSource = "source"
+)
+
+const (
+	BackendPostgres = "postgres"
+	BackendMemory   = "memory"
+)
+
+type Config struct {
+	Enabled bool `envconfig:"API_RATE_LIMIT_ENABLED" default:"false"`
+	// Limits is a JSON document with the budgets of the route classes that override the default
+	// budgets, e.g. {"write":{"per_minute":60,"burst":20,"org_per_minute":600,"org_burst":100}}
+	Limits string `envconfig:"API_RATE_LIMITS" default:""`
+	// Backend is where the buckets are kept, postgres to share them between the replicas or memory
+	Backend         string        `envconfig:"API_RATE_LIMIT_BACKEND" default:"postgres"`
+	ExemptAdmins    bool          `envconfig:"API_RATE_LIMIT_EXEMPT_ADMINS" default:"true"`
+	CleanupInterval time.Duration `envconfig:"API_RATE_LIMIT_CLEANUP_INTERVAL" default:"10m"`
+	// SyncInterval is how often the postgres backend shares the buckets of the replica with the
+	// other replicas
+	SyncInterval time.Duration `envconfig:"API_RATE_LIMIT_SYNC_INTERVAL" default:"1s"`
+}
+
+// Budget is the rate of the requests of a route class allowed to a principal and to an
+// organization, a zero rate is unlimited
+type Budget struct {
+	PerMinute    float64 `json:"per_minute"`
+	Burst        int     `json:"burst"`
+	OrgPerMinute float64 `json:"org_per_minute"`
+	OrgBurst     int     `json:"org_burst"`
+}
+
+var defaultBudgets = map[string]Budget{
+	ClassRead:   {PerMinute: 600, Burst: 100},
+	ClassWrite:  {PerMinute: 120, Burst: 30},
+	ClassEvents: {PerMinute: 120, Burst: 30},
+	// The agents poll their next steps and post their replies every few seconds
+	ClassAgent: {PerMinute: 600, Burst: 100},
+}
+
+func parseBudgets(data string) (map[string]Budget, error) {
+	budgets := make(map[string]Budget, len(defaultBudgets))
+	for class, budget := range defaultBudgets {
+		budgets[class] = budget
+	}
+	if data == "" {
+		return budgets, nil
+	}
+	var overrides map[string]Budget
+	if err := json.Unmarshal([]byte(data), &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse API_RATE_LIMITS json %s: %w", data, err)
+	}
+	for class, budget := range overrides {
+		if _, ok := defaultBudgets[class]; !ok {
+			return nil, fmt.Errorf("invalid API_RATE_LIMITS json %s: unknown route class %s", data, class)
+		}
+		if budget.PerMinute < 0 || budget.Burst < 0 || budget.OrgPerMinute < 0 || budget.OrgBurst < 0 {
+			return nil, fmt.Errorf("invalid API_RATE_LIMITS json %s: the rates and the bursts must not be negative", data)
+		}
+		budgets[class] = budget
+	}
+	return budgets, nil
+}
+
+// MetricsReporter is notified about the rate limited requests
+type MetricsReporter interface {
+	APIRequestRateLimited(class, keyType string)
+	APIRateLimitsBackendFailed()
+}
+
+// Result is the state of the bucket that limited a request, or of the bucket of its principal when
+// it was allowed
+type Result struct {
+	Class      string
+	KeyType    string
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Allowed    bool
+}
+
+type Limiter struct {
+	cfg      Config
+	budgets  map[string]Budget
+	backend  backend
+	fallback *memoryBackend
+	metrics  MetricsReporter
+	log      logrus.FieldLogger
+	now      func() time.Time
+}
+
+func NewLimiter(cfg Config, db *gorm.DB, metrics MetricsReporter, log logrus.FieldLogger) (*Limiter, error) {
+	budgets, err := parseBudgets(cfg.Limits)
+	if err != nil {
+		return nil, err
+	}
+	l := &Limiter{
+		cfg:      cfg,
+		budgets:  budgets,
+		fallback: newMemoryBackend(),
+		metrics:  metrics,
+		log:      log,
+		now:      time.Now,
+	}
+	switch cfg.Backend {
+	case BackendPostgres:
+		l.backend = newPostgresBackend(db)
+	case BackendMemory:
+		l.backend = l.fallback
+	default:
+		return nil, fmt.Errorf("invalid API_RATE_LIMIT_BACKEND %s, must be %s or %s", cfg.Backend, BackendPostgres, BackendMemory)
+	}
+	return l, nil
+}
+
+func (l *Limiter) Enabled() bool {
+	return l != nil && l.cfg.Enabled
+}
+
+// routeClass returns the class of the budget charged for the request. The agents are told from the
+// users by the authentication that succeeded, the headers of the request may be forged.
+func routeClass(r *http.Request, payload *ocm.AuthPayload) string {
+	if payload.AgentAuth || payload.AgentToken != nil {
+		return ClassAgent
+	}
+	if strings.HasPrefix(r.URL.Path, client.DefaultBasePath+"/v2/events") {
+		return ClassEvents
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ClassRead
+	}
+	return ClassWrite
+}
+
+type bucketKey struct {
+	keyType string
+	value   string
+}
+
+// principalKey returns the key of the bucket of the caller: the host of the agents, the API token,
+// the user or, when the request has no user, its source. The parameters of the route are set on the
+// context by the inner middleware.
+func principalKey(r *http.Request, class string, payload *ocm.AuthPayload) bucketKey {
+	if class == ClassAgent {
+		if hostID := params.GetParam(r.Context(), params.HostId); hostID != "" {
+			return bucketKey{keyType: KeyTypeHost, value: hostID}
+		}
+		if payload.AgentToken != nil && payload.AgentToken.HostID != "" {
+			return bucketKey{keyType: KeyTypeHost, value: payload.AgentToken.HostID}
+		}
+		// The agents of all the hosts of an infra-env share its token and its routes, e.g. the
+		// registration of the hosts, so the requests without a host are charged to their source
+		return bucketKey{keyType: KeyTypeSource, value: source(r)}
+	}
+	if payload.APIToken != nil {
+		return bucketKey{keyType: KeyTypeToken, value: payload.APIToken.ID}
+	}
+	if payload.Username != "" {
+		return bucketKey{keyType: KeyTypeUser, value: payload.Username}
+	}
+	return bucketKey{keyType: KeyTypeSource, value: source(r)}
+}
+
+func source(r *http.Request) string {
+	source, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return source
+}
+
+// allow charges the request to the bucket, the database buckets fall back to the in-memory buckets
+// of the replica when the database fails
+func (l *Limiter) allow(ctx context.Context, class string, key bucketKey, perMinute float64, burst int) Result {
+	if burst < 1 {
+		burst = 1
+	}
+	rate := rateOf(perMinute, burst)
+	name := strings.Join([]string{class, key.keyType, key.value}, ":")
+	now := l.now()
+	allowed, remaining, retryAfter, err := l.backend.allow(ctx, name, rate, now)
+	if err != nil {
+		l.log.WithError(err).Warnf("Failed to check the API rate limit of %s, using the in-memory buckets", name)
+		if l.metrics != nil {
+			l.metrics.APIRateLimitsBackendFailed()
+		}
+		allowed, remaining, retryAfter, _ = l.fallback.allow(ctx, name, rate, now)
+	}
+	return Result{
+		Class:      class,
+		KeyType:    key.keyType,
+		Limit:      burst,
+		Remaining:  remaining,
+		RetryAfter: retryAfter,
+		Allowed:    allowed,
+	}
+}
+
+// Check charges the request to the buckets of its principal and of its organization. It returns nil
+// when no budget applies to the request.
+func (l *Limiter) Check(r *http.Request) *Result {
+	payload := ocm.PayloadFromContext(r.Context())
+	if l.cfg.ExemptAdmins && payload.Role == ocm.AdminRole {
+		return nil
+	}
+	class := routeClass(r, payload)
+	budget := l.budgets[class]
+	if budget.PerMinute <= 0 && budget.OrgPerMinute <= 0 {
+		return nil
+	}
+
+	var result *Result
+	if budget.PerMinute > 0 {
+		principalResult := l.allow(r.Context(), class, principalKey(r, class, payload), budget.PerMinute, budget.Burst)
+		result = &principalResult
+	}
+	if budget.OrgPerMinute > 0 && class != ClassAgent && payload.Organization != "" && (result == nil || result.Allowed) {
+		orgResult := l.allow(r.Context(), class, bucketKey{keyType: KeyTypeOrg, value: payload.Organization}, budget.OrgPerMinute, budget.OrgBurst)
+		if result == nil || !orgResult.Allowed {
+			result = &orgResult
+		}
+	}
+	if result != nil && !result.Allowed && l.metrics != nil {
+		l.metrics.APIRequestRateLimited(result.Class, result.KeyType)
+	}
+	return result
+}
+
+// Cleanup removes the buckets that are full again, they are equivalent to missing buckets
+func (l *Limiter) Cleanup() {
+	if !l.Enabled() {
+		return
+	}
+	now := l.now()
+	if err := l.backend.cleanup(now); err != nil {
+		l.log.WithError(err).Error("Failed to remove the full API rate limits buckets")
+	}
+	if l.backend != l.fallback {
+		_ = l.fallback.cleanup(now)
+	}
+}
+
+func (l *Limiter) CleanupInterval() time.Duration {
+	return l.cfg.CleanupInterval
+}
+
+// Sync shares the buckets of the replica with the other replicas
+func (l *Limiter) Sync() {
+	if !l.Enabled() {
+		return
+	}
+	if err := l.backend.sync(context.Background(), l.now()); err != nil {
+		l.log.WithError(err).Warn("Failed to sync the API rate limits buckets with the database")
+		if l.metrics != nil {
+			l.metrics.APIRateLimitsBackendFailed()
+		}
+	}
+}
+
+func (l *Limiter) SyncInterval() time.Duration {
+	return l.cfg.SyncInterval
+}
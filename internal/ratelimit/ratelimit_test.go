@@ -0,0 +1,324 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/metrics"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("parseBudgets", func() {
+	It("uses the default budgets", func() {
+		budgets, err := parseBudgets("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(budgets).To(Equal(defaultBudgets))
+	})
+
+	It("overrides the budgets of the route classes", func() {
+		budgets, err := parseBudgets(`{"write":{"per_minute":60,"burst":20,"org_per_minute":600,"org_burst":100}}`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(budgets[ClassWrite]).To(Equal(Budget{PerMinute: 60, Burst: 20, OrgPerMinute: 600, OrgBurst: 100}))
+		Expect(budgets[ClassRead]).To(Equal(defaultBudgets[ClassRead]))
+	})
+
+	It("rejects invalid budgets", func() {
+		_, err := parseBudgets(`{"write":`)
+		Expect(err).To(HaveOccurred())
+		_, err = parseBudgets(`{"other":{"per_minute":1}}`)
+		Expect(err).To(HaveOccurred())
+		_, err = parseBudgets(`{"read":{"per_minute":-1}}`)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("routeClass", func() {
+	It("classifies the requests", func() {
+		newRequest := func(method, path string) *http.Request {
+			return httptest.NewRequest(method, "/api/assisted-install/v2"+path, nil)
+		}
+		user := &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole}
+		Expect(routeClass(newRequest(http.MethodGet, "/clusters"), user)).To(Equal(ClassRead))
+		Expect(routeClass(newRequest(http.MethodPost, "/clusters"), user)).To(Equal(ClassWrite))
+		Expect(routeClass(newRequest(http.MethodDelete, "/clusters/id"), user)).To(Equal(ClassWrite))
+		Expect(routeClass(newRequest(http.MethodGet, "/events"), user)).To(Equal(ClassEvents))
+		Expect(routeClass(newRequest(http.MethodGet, "/events/search"), user)).To(Equal(ClassEvents))
+		agentRequest := newRequest(http.MethodGet, "/infra-envs/id/hosts/id/instructions")
+		Expect(routeClass(agentRequest, &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole, AgentAuth: true})).To(Equal(ClassAgent))
+		Expect(routeClass(agentRequest, &ocm.AuthPayload{Role: ocm.AdminRole,
+			AgentToken: &ocm.AgentTokenScope{InfraEnvID: "infra-env"}})).To(Equal(ClassAgent))
+	})
+
+	It("doesn't trust the agents authentication header of the users", func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/assisted-install/v2/clusters", nil)
+		req.Header.Set("X-Secret-Key", "forged")
+		Expect(routeClass(req, &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole})).To(Equal(ClassWrite))
+	})
+})
+
+var _ = Describe("memoryBackend", func() {
+	It("allows bursts and refills the bucket at the rate", func() {
+		backend := newMemoryBackend()
+		r := rateOf(60, 2)
+		now := time.Now()
+
+		allowed, remaining, _, _ := backend.allow(context.Background(), "key", r, now)
+		Expect(allowed).To(BeTrue())
+		Expect(remaining).To(Equal(1))
+		allowed, remaining, _, _ = backend.allow(context.Background(), "key", r, now)
+		Expect(allowed).To(BeTrue())
+		Expect(remaining).To(Equal(0))
+		allowed, _, retryAfter, _ := backend.allow(context.Background(), "key", r, now)
+		Expect(allowed).To(BeFalse())
+		Expect(retryAfter).To(Equal(time.Second))
+
+		allowed, _, _, _ = backend.allow(context.Background(), "other", r, now)
+		Expect(allowed).To(BeTrue())
+		allowed, _, _, _ = backend.allow(context.Background(), "key", r, now.Add(time.Second))
+		Expect(allowed).To(BeTrue())
+
+		Expect(backend.sync(context.Background(), now.Add(2*time.Second))).To(Succeed())
+		Expect(backend.cleanup(now.Add(time.Minute))).To(Succeed())
+		Expect(backend.tats).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Limiter", func() {
+	var (
+		ctrl        *gomock.Controller
+		mockMetrics *metrics.MockAPI
+		limiter     *Limiter
+		now         time.Time
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockMetrics = metrics.NewMockAPI(ctrl)
+		var err error
+		limiter, err = NewLimiter(Config{
+			Enabled:      true,
+			Limits:       `{"write":{"per_minute":60,"burst":1,"org_per_minute":60,"org_burst":2}}`,
+			Backend:      BackendMemory,
+			ExemptAdmins: true,
+		}, nil, mockMetrics, common.GetTestLog())
+		Expect(err).ToNot(HaveOccurred())
+		now = time.Now()
+		limiter.now = func() time.Time { return now }
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	newRequest := func(method string, payload *ocm.AuthPayload) *http.Request {
+		req := httptest.NewRequest(method, "/api/assisted-install/v2/clusters", nil)
+		return req.WithContext(context.WithValue(req.Context(), restapi.AuthKey, payload))
+	}
+
+	It("charges the requests to their user", func() {
+		jdoe := &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole}
+		Expect(limiter.Check(newRequest(http.MethodPost, jdoe)).Allowed).To(BeTrue())
+
+		mockMetrics.EXPECT().APIRequestRateLimited(ClassWrite, KeyTypeUser).Times(1)
+		result := limiter.Check(newRequest(http.MethodPost, jdoe))
+		Expect(result.Allowed).To(BeFalse())
+		Expect(result.RetryAfter).To(Equal(time.Second))
+		Expect(result.RetryAfterSeconds()).To(Equal(1))
+
+		// The route classes have separate budgets
+		Expect(limiter.Check(newRequest(http.MethodGet, jdoe)).Allowed).To(BeTrue())
+		Expect(limiter.Check(newRequest(http.MethodPost, &ocm.AuthPayload{Username: "other", Role: ocm.UserRole})).Allowed).To(BeTrue())
+	})
+
+	It("charges the requests with an API token to the token", func() {
+		token := &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole, APIToken: &ocm.APITokenScope{ID: "token-id"}}
+		Expect(limiter.Check(newRequest(http.MethodPost, token)).KeyType).To(Equal(KeyTypeToken))
+		Expect(limiter.Check(newRequest(http.MethodPost, &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole})).Allowed).To(BeTrue())
+	})
+
+	It("charges the requests to their organization", func() {
+		Expect(limiter.Check(newRequest(http.MethodPost, &ocm.AuthPayload{Username: "a", Organization: "org", Role: ocm.UserRole})).Allowed).To(BeTrue())
+		Expect(limiter.Check(newRequest(http.MethodPost, &ocm.AuthPayload{Username: "b", Organization: "org", Role: ocm.UserRole})).Allowed).To(BeTrue())
+		mockMetrics.EXPECT().APIRequestRateLimited(ClassWrite, KeyTypeOrg).Times(1)
+		result := limiter.Check(newRequest(http.MethodPost, &ocm.AuthPayload{Username: "c", Organization: "org", Role: ocm.UserRole}))
+		Expect(result.Allowed).To(BeFalse())
+		Expect(result.KeyType).To(Equal(KeyTypeOrg))
+	})
+
+	It("charges the agents requests to their host", func() {
+		agentRequest := func(hostID string) *http.Request {
+			req := newRequest(http.MethodPost, &ocm.AuthPayload{Username: "jdoe", Organization: "org", Role: ocm.UserRole, AgentAuth: true})
+			return req.WithContext(params.SetParam(req.Context(), params.HostId, hostID))
+		}
+		limiter.budgets[ClassAgent] = Budget{PerMinute: 60, Burst: 1}
+		result := limiter.Check(agentRequest("host-1"))
+		Expect(result.Allowed).To(BeTrue())
+		Expect(result.KeyType).To(Equal(KeyTypeHost))
+		Expect(limiter.Check(agentRequest("host-2")).Allowed).To(BeTrue())
+		mockMetrics.EXPECT().APIRequestRateLimited(ClassAgent, KeyTypeHost).Times(1)
+		Expect(limiter.Check(agentRequest("host-1")).Allowed).To(BeFalse())
+	})
+
+	It("charges the agents requests without a host to their source", func() {
+		agentRequest := func(remoteAddr string) *http.Request {
+			req := newRequest(http.MethodPost, &ocm.AuthPayload{Role: ocm.UserRole, AgentAuth: true})
+			req.RemoteAddr = remoteAddr
+			return req.WithContext(params.SetParam(req.Context(), params.InfraEnvId, "infra-env"))
+		}
+		limiter.budgets[ClassAgent] = Budget{PerMinute: 60, Burst: 1}
+		result := limiter.Check(agentRequest("192.0.2.1:1234"))
+		Expect(result.Allowed).To(BeTrue())
+		Expect(result.KeyType).To(Equal(KeyTypeSource))
+		// The other hosts of the infra-env aren't limited
+		Expect(limiter.Check(agentRequest("192.0.2.2:1234")).Allowed).To(BeTrue())
+		mockMetrics.EXPECT().APIRequestRateLimited(ClassAgent, KeyTypeSource).Times(1)
+		Expect(limiter.Check(agentRequest("192.0.2.1:4321")).Allowed).To(BeFalse())
+	})
+
+	It("charges the agents requests with the token of a host to the host", func() {
+		req := newRequest(http.MethodPost, &ocm.AuthPayload{Role: ocm.UserRole,
+			AgentToken: &ocm.AgentTokenScope{ID: "token-id", InfraEnvID: "infra-env", HostID: "host-1"}})
+		limiter.budgets[ClassAgent] = Budget{PerMinute: 60, Burst: 1}
+		Expect(limiter.Check(req).KeyType).To(Equal(KeyTypeHost))
+	})
+
+	It("doesn't limit the admins", func() {
+		admin := &ocm.AuthPayload{Username: "admin", Role: ocm.AdminRole}
+		for i := 0; i < 3; i++ {
+			Expect(limiter.Check(newRequest(http.MethodPost, admin))).To(BeNil())
+		}
+	})
+
+	It("rejects the requests above the budget in the authorizer", func() {
+		authorize := Authorizer(limiter, func(*http.Request) error { return nil })
+		jdoe := &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole}
+		Expect(authorize(newRequest(http.MethodPost, jdoe))).To(Succeed())
+		mockMetrics.EXPECT().APIRequestRateLimited(ClassWrite, KeyTypeUser).Times(1)
+		result := &Result{}
+		req := newRequest(http.MethodPost, jdoe)
+		err := authorize(req.WithContext(WithResult(req.Context(), result)))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("too many write requests"))
+		Expect(result.Allowed).To(BeFalse())
+		Expect(result.Class).To(Equal(ClassWrite))
+	})
+
+	It("falls back to the in-memory buckets when the database fails", func() {
+		limiter.backend = failingBackend{}
+		mockMetrics.EXPECT().APIRateLimitsBackendFailed().Times(1)
+		Expect(limiter.Check(newRequest(http.MethodPost, &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole})).Allowed).To(BeTrue())
+	})
+
+	It("reports the failed syncs", func() {
+		limiter.backend = failingBackend{}
+		mockMetrics.EXPECT().APIRateLimitsBackendFailed().Times(1)
+		limiter.Sync()
+	})
+})
+
+type failingBackend struct{}
+
+func (failingBackend) allow(context.Context, string, rate, time.Time) (bool, int, time.Duration, error) {
+	return false, 0, 0, errors.New("connection refused")
+}
+
+func (failingBackend) sync(context.Context, time.Time) error {
+	return errors.New("connection refused")
+}
+
+func (failingBackend) cleanup(time.Time) error {
+	return errors.New("connection refused")
+}
+
+var _ = Describe("postgresBackend", func() {
+	var (
+		db      *gorm.DB
+		dbName  string
+		backend *postgresBackend
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		backend = newPostgresBackend(db)
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	It("shares the buckets through the database", func() {
+		r := rateOf(60, 2)
+		now := time.Now()
+		other := newPostgresBackend(db)
+
+		allowed, remaining, _, err := backend.allow(context.Background(), "key", r, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(remaining).To(Equal(1))
+		allowed, remaining, _, err = backend.allow(context.Background(), "key", r, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(remaining).To(Equal(0))
+		allowed, _, retryAfter, err := backend.allow(context.Background(), "key", r, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+		Expect(retryAfter).To(Equal(time.Second))
+
+		// The checks don't write the database
+		var count int64
+		Expect(db.Model(&common.APIRateLimitBucket{}).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(BeZero())
+
+		// The other replica only knows about the requests once they are synced
+		allowed, _, _, err = other.allow(context.Background(), "key", r, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(backend.sync(context.Background(), now)).To(Succeed())
+		Expect(other.sync(context.Background(), now)).To(Succeed())
+		allowed, _, retryAfter, err = other.allow(context.Background(), "key", r, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+		Expect(retryAfter).To(Equal(2 * time.Second))
+
+		Expect(backend.sync(context.Background(), now)).To(Succeed())
+		allowed, _, retryAfter, err = backend.allow(context.Background(), "key", r, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+		Expect(retryAfter).To(Equal(2 * time.Second))
+		allowed, _, _, err = backend.allow(context.Background(), "key", r, now.Add(2*time.Second))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+
+		Expect(backend.sync(context.Background(), now.Add(2*time.Second))).To(Succeed())
+		Expect(backend.cleanup(now.Add(time.Minute))).To(Succeed())
+		Expect(db.Model(&common.APIRateLimitBucket{}).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(BeZero())
+		Expect(backend.buckets).To(BeEmpty())
+	})
+
+	It("reports the errors of the database and syncs the requests later", func() {
+		now := time.Now()
+		allowed, _, _, err := backend.allow(context.Background(), "key", rateOf(60, 2), now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(db.Migrator().DropTable(&common.APIRateLimitBucket{})).To(Succeed())
+		Expect(backend.sync(context.Background(), now)).ToNot(Succeed())
+		Expect(backend.buckets["key"].pending).To(Equal(int64(1)))
+
+		Expect(db.Migrator().CreateTable(&common.APIRateLimitBucket{})).To(Succeed())
+		Expect(backend.sync(context.Background(), now)).To(Succeed())
+		var bucket common.APIRateLimitBucket
+		Expect(db.Take(&bucket, "key = ?", "key").Error).ToNot(HaveOccurred())
+		Expect(bucket.Tat).To(Equal(now.Add(time.Second).UnixMicro()))
+	})
+})
@@ -0,0 +1,109 @@
+package secrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kubernetesSecretKey        = "value"
+	kubernetesPathAnnotation   = "agent-install.openshift.io/secret-path"
+	kubernetesManagedByLabel   = "app.kubernetes.io/managed-by"
+	kubernetesManagedByValue   = "assisted-service"
+	kubernetesMaxNameLength    = 253
+	kubernetesSecretNamePrefix = "assisted-"
+)
+
+// KubernetesBackend keeps the secrets in Kubernetes Secrets of a namespace, the version of a secret
+// is the resource version of its Secret
+type KubernetesBackend struct {
+	client    client.Client
+	namespace string
+}
+
+func NewKubernetesBackend(kubeClient client.Client, namespace string) *KubernetesBackend {
+	return &KubernetesBackend{client: kubeClient, namespace: namespace}
+}
+
+// secretName returns the name of the Secret of the path, a hash replaces the end of the paths
+// that are too long for a name
+func secretName(path string) string {
+	name := kubernetesSecretNamePrefix + strings.NewReplacer("/", "-", "_", "-").Replace(strings.ToLower(path))
+	if len(name) <= kubernetesMaxNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(path))
+	suffix := hex.EncodeToString(sum[:8])
+	return name[:kubernetesMaxNameLength-len(suffix)-1] + "-" + suffix
+}
+
+func (b *KubernetesBackend) key(path string) types.NamespacedName {
+	return types.NamespacedName{Namespace: b.namespace, Name: secretName(path)}
+}
+
+func (b *KubernetesBackend) Get(ctx context.Context, path string) (*Secret, error) {
+	secret := &corev1.Secret{}
+	if err := b.client.Get(ctx, b.key(path), secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	value, ok := secret.Data[kubernetesSecretKey]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &Secret{Value: string(value), Version: secret.ResourceVersion}, nil
+}
+
+func (b *KubernetesBackend) Put(ctx context.Context, path, value string) (*Secret, error) {
+	key := b.key(path)
+	secret := &corev1.Secret{}
+	err := b.client.Get(ctx, key, secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+	if k8serrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Labels:      map[string]string{kubernetesManagedByLabel: kubernetesManagedByValue},
+				Annotations: map[string]string{kubernetesPathAnnotation: path},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{kubernetesSecretKey: []byte(value)},
+		}
+		if err = b.client.Create(ctx, secret); err != nil {
+			return nil, err
+		}
+	} else {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[kubernetesSecretKey] = []byte(value)
+		if err = b.client.Update(ctx, secret); err != nil {
+			return nil, err
+		}
+	}
+	return &Secret{Value: value, Version: secret.ResourceVersion}, nil
+}
+
+func (b *KubernetesBackend) Delete(ctx context.Context, path string) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName(path), Namespace: b.namespace}}
+	if err := b.client.Delete(ctx, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
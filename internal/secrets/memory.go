@@ -0,0 +1,51 @@
+package secrets
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// MemoryBackend keeps the secrets in the memory of the replica, it's meant for tests and
+// development
+type MemoryBackend struct {
+	mu       sync.Mutex
+	secrets  map[string]*Secret
+	versions map[string]int
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		secrets:  make(map[string]*Secret),
+		versions: make(map[string]int),
+	}
+}
+
+func (b *MemoryBackend) Get(_ context.Context, path string) (*Secret, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	secret, ok := b.secrets[path]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &Secret{Value: secret.Value, Version: secret.Version}, nil
+}
+
+func (b *MemoryBackend) Put(_ context.Context, path, value string) (*Secret, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.versions[path]++
+	secret := &Secret{Value: value, Version: strconv.Itoa(b.versions[path])}
+	b.secrets[path] = secret
+	return &Secret{Value: secret.Value, Version: secret.Version}, nil
+}
+
+func (b *MemoryBackend) Delete(_ context.Context, path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.secrets[path]; !ok {
+		return ErrNotFound
+	}
+	delete(b.secrets, path)
+	return nil
+}
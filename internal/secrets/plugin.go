@@ -0,0 +1,451 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	gormPluginName = "secrets"
+	gormRestoreKey = "secrets:restore"
+	gormClearKey   = "secrets:clear"
+)
+
+// secretColumns are the columns of each table that hold secrets
+var secretColumns = map[string][]string{
+	"clusters":        {"pull_secret", "http_proxy", "https_proxy"},
+	"infra_envs":      {"pull_secret", "proxy_http_proxy", "proxy_https_proxy"},
+	"hosts":           {"fencing_credentials"},
+	"host_migrations": {"token"},
+}
+
+// proxyColumns only hold a secret when the proxy URL has a password
+var proxyColumns = map[string]bool{
+	"http_proxy":        true,
+	"https_proxy":       true,
+	"proxy_http_proxy":  true,
+	"proxy_https_proxy": true,
+}
+
+// conditionPattern matches the conditions on a single column, like "id = ?" or "hosts.id = ?"
+var conditionPattern = regexp.MustCompile(`^(?:"?\w+"?\.)?"?(\w+)"?\s*=\s*\?$`)
+
+var andPattern = regexp.MustCompile(`(?i)\s+and\s+`)
+
+// GormPlugin keeps the secret columns of the records in the secrets backend. The records are
+// written with references to their secrets instead of the secrets, and read with the secrets in
+// place of the references, so the rest of the service doesn't know where the secrets are kept.
+type GormPlugin struct {
+	manager *Manager
+	schemas sync.Map
+}
+
+var _ gorm.Plugin = &GormPlugin{}
+
+func NewGormPlugin(manager *Manager) *GormPlugin {
+	return &GormPlugin{manager: manager}
+}
+
+func (p *GormPlugin) Name() string {
+	return gormPluginName
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registrations := []error{
+		cb.Create().Before("gorm:create").Register("secrets:before_create", p.store),
+		cb.Create().After("gorm:create").Register("secrets:after_create", restore),
+		cb.Update().Before("gorm:update").Register("secrets:before_update", p.store),
+		cb.Update().After("gorm:update").Register("secrets:after_update", p.afterUpdate),
+		cb.Query().After("gorm:query").Register("secrets:after_query", p.resolve),
+		cb.Delete().After("gorm:delete").Register("secrets:after_delete", p.delete),
+	}
+	return errors.Join(registrations...)
+}
+
+// fieldRestore is the value of a field of a record before its secret was replaced by a reference
+type fieldRestore struct {
+	field  *schema.Field
+	record reflect.Value
+	value  interface{}
+}
+
+func managedColumns(stmt *gorm.Statement) []string {
+	if stmt.Schema == nil {
+		return nil
+	}
+	return secretColumns[stmt.Schema.Table]
+}
+
+// isSecret returns true when the value of the column must be kept in the backend
+func isSecret(column, value string) bool {
+	if value == "" || IsReference(value) {
+		return false
+	}
+	if !proxyColumns[column] {
+		return true
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return false
+	}
+	_, hasPassword := u.User.Password()
+	return hasPassword
+}
+
+func stringOf(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case *string:
+		if v == nil {
+			return "", false
+		}
+		return *v, true
+	}
+	return "", false
+}
+
+func keyString(value interface{}) string {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// conditionValues returns the values of the columns that the WHERE clause of the statement
+// compares for equality, it's how the record of an update without model is identified
+func conditionValues(stmt *gorm.Statement) map[string]string {
+	values := map[string]string{}
+	c, ok := stmt.Clauses["WHERE"]
+	if !ok {
+		return values
+	}
+	where, ok := c.Expression.(clause.Where)
+	if !ok {
+		return values
+	}
+	for _, expr := range where.Exprs {
+		switch e := expr.(type) {
+		case clause.Eq:
+			switch column := e.Column.(type) {
+			case clause.Column:
+				values[column.Name] = keyString(e.Value)
+			case string:
+				values[column] = keyString(e.Value)
+			}
+		case clause.Expr:
+			parts := andPattern.Split(strings.TrimSpace(e.SQL), -1)
+			if len(parts) != len(e.Vars) {
+				continue
+			}
+			for i, part := range parts {
+				if match := conditionPattern.FindStringSubmatch(strings.TrimSpace(part)); match != nil {
+					values[match[1]] = keyString(e.Vars[i])
+				}
+			}
+		}
+	}
+	return values
+}
+
+// recordKey returns the values of the primary key of the record, from the record or from the
+// conditions of the statement
+func recordKey(stmt *gorm.Statement, record reflect.Value) ([]string, bool) {
+	if len(stmt.Schema.PrimaryFields) == 0 {
+		return nil, false
+	}
+	var conditions map[string]string
+	keys := make([]string, 0, len(stmt.Schema.PrimaryFields))
+	for _, field := range stmt.Schema.PrimaryFields {
+		if record.IsValid() && record.Kind() == reflect.Struct {
+			if value, zero := field.ValueOf(stmt.Context, record); !zero {
+				if key := keyString(value); key != "" {
+					keys = append(keys, key)
+					continue
+				}
+			}
+		}
+		if conditions == nil {
+			conditions = conditionValues(stmt)
+		}
+		key := conditions[field.DBName]
+		if key == "" {
+			return nil, false
+		}
+		keys = append(keys, key)
+	}
+	return keys, true
+}
+
+// secretPath returns the path of the secret of the column of the record. The secrets of the
+// records that can't be identified are refused, their paths couldn't be found to delete them.
+func secretPath(stmt *gorm.Statement, record reflect.Value, column string) (string, error) {
+	keys, ok := recordKey(stmt, record)
+	if !ok {
+		return "", fmt.Errorf("failed to store the %s of a %s in the secrets backend, the record can't be identified by its primary key", column, stmt.Schema.Table)
+	}
+	return strings.Join(append(append([]string{stmt.Schema.Table}, keys...), column), "/"), nil
+}
+
+func forEachRecord(value reflect.Value, f func(record reflect.Value) error) error {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Struct:
+		return f(value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			record := reflect.Indirect(value.Index(i))
+			if record.Kind() != reflect.Struct {
+				continue
+			}
+			if err := f(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) schemaOf(db *gorm.DB, dest interface{}) (*schema.Schema, error) {
+	destType := reflect.Indirect(reflect.ValueOf(dest)).Type()
+	for destType.Kind() == reflect.Slice || destType.Kind() == reflect.Array || destType.Kind() == reflect.Ptr {
+		destType = destType.Elem()
+	}
+	if destType == db.Statement.Schema.ModelType {
+		return db.Statement.Schema, nil
+	}
+	return schema.Parse(dest, &p.schemas, db.NamingStrategy)
+}
+
+// store replaces the secrets that the statement writes with references to them, after writing
+// them to the backend
+func (p *GormPlugin) store(db *gorm.DB) {
+	stmt := db.Statement
+	columns := managedColumns(stmt)
+	if db.Error != nil || len(columns) == 0 || stmt.Dest == nil {
+		return
+	}
+	ctx := stmt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if updates, ok := stmt.Dest.(map[string]interface{}); ok {
+		var stored map[string]interface{}
+		var cleared []string
+		for key, value := range updates {
+			field := stmt.Schema.LookUpField(key)
+			if field == nil || !slices.Contains(columns, field.DBName) {
+				continue
+			}
+			secret, ok := stringOf(value)
+			if ok && secret == "" {
+				// The secret of the cleared column is deleted once the update succeeded
+				if path, err := secretPath(stmt, stmt.ReflectValue, field.DBName); err == nil {
+					cleared = append(cleared, path)
+				}
+				continue
+			}
+			if !ok || !isSecret(field.DBName, secret) {
+				continue
+			}
+			path, err := secretPath(stmt, stmt.ReflectValue, field.DBName)
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			reference, err := p.manager.Store(ctx, path, secret)
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			if stored == nil {
+				stored = make(map[string]interface{}, len(updates))
+				for k, v := range updates {
+					stored[k] = v
+				}
+			}
+			stored[key] = reference
+		}
+		if stored != nil {
+			stmt.Dest = stored
+		}
+		if len(cleared) > 0 {
+			db.InstanceSet(gormClearKey, cleared)
+		}
+		return
+	}
+
+	destSchema, err := p.schemaOf(db, stmt.Dest)
+	if err != nil {
+		return
+	}
+	var restores []fieldRestore
+	err = forEachRecord(reflect.ValueOf(stmt.Dest), func(record reflect.Value) error {
+		for _, column := range columns {
+			field := destSchema.LookUpField(column)
+			if field == nil {
+				continue
+			}
+			value, _ := field.ValueOf(ctx, record)
+			secret, ok := stringOf(value)
+			if !ok || !isSecret(column, secret) {
+				continue
+			}
+			if !record.CanAddr() {
+				return fmt.Errorf("failed to store the %s of a %s in the secrets backend, the record isn't addressable", column, stmt.Schema.Table)
+			}
+			// The model identifies the record of an update when the value written isn't the record
+			keyRecord := record
+			if destSchema != stmt.Schema {
+				keyRecord = stmt.ReflectValue
+			}
+			path, err := secretPath(stmt, keyRecord, column)
+			if err != nil {
+				return err
+			}
+			reference, err := p.manager.Store(ctx, path, secret)
+			if err != nil {
+				return err
+			}
+			if err = field.Set(ctx, record, reference); err != nil {
+				return err
+			}
+			restores = append(restores, fieldRestore{field: field, record: record, value: value})
+		}
+		return nil
+	})
+	if len(restores) > 0 {
+		db.InstanceSet(gormRestoreKey, restores)
+	}
+	if err != nil {
+		restore(db)
+		_ = db.AddError(err)
+	}
+}
+
+// restore puts back the secrets of the records in place of the references that were written
+func restore(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormRestoreKey)
+	if !ok {
+		return
+	}
+	restores, ok := value.([]fieldRestore)
+	if !ok {
+		return
+	}
+	for _, r := range restores {
+		_ = r.field.Set(db.Statement.Context, r.record, r.value)
+	}
+	db.InstanceSet(gormRestoreKey, []fieldRestore(nil))
+}
+
+// afterUpdate puts back the secrets of the updated records and deletes the secrets of the columns
+// that the update cleared
+func (p *GormPlugin) afterUpdate(db *gorm.DB) {
+	restore(db)
+	value, ok := db.InstanceGet(gormClearKey)
+	if !ok {
+		return
+	}
+	db.InstanceSet(gormClearKey, []string(nil))
+	cleared, ok := value.([]string)
+	if !ok || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, path := range cleared {
+		if err := p.manager.Delete(ctx, path); err != nil {
+			p.manager.log.WithError(err).Warnf("Failed to delete secret %s of a cleared column", path)
+		}
+	}
+}
+
+// resolve replaces the references of the records that were read with their secrets. A secret that
+// can't be resolved doesn't fail the query, the record keeps its reference, which isn't written
+// again as a secret when the record is saved.
+func (p *GormPlugin) resolve(db *gorm.DB) {
+	stmt := db.Statement
+	columns := managedColumns(stmt)
+	if db.Error != nil || len(columns) == 0 || !stmt.ReflectValue.IsValid() {
+		return
+	}
+	ctx := stmt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_ = forEachRecord(stmt.ReflectValue, func(record reflect.Value) error {
+		if record.Type() != stmt.Schema.ModelType {
+			return nil
+		}
+		for _, column := range columns {
+			field := stmt.Schema.LookUpField(column)
+			if field == nil {
+				continue
+			}
+			value, zero := field.ValueOf(ctx, record)
+			if zero {
+				continue
+			}
+			reference, ok := stringOf(value)
+			if !ok || !IsReference(reference) {
+				continue
+			}
+			secret, err := p.manager.Resolve(ctx, reference)
+			if err != nil {
+				p.manager.log.WithError(err).Warnf("Failed to resolve the %s of a %s", column, stmt.Schema.Table)
+				continue
+			}
+			if err = field.Set(ctx, record, secret); err != nil {
+				p.manager.log.WithError(err).Warnf("Failed to set the %s of a %s", column, stmt.Schema.Table)
+			}
+		}
+		return nil
+	})
+}
+
+// delete removes the secrets of the records that were hard deleted, the soft deleted records keep
+// their secrets until they are purged
+func (p *GormPlugin) delete(db *gorm.DB) {
+	stmt := db.Statement
+	columns := managedColumns(stmt)
+	if db.Error != nil || len(columns) == 0 || db.RowsAffected == 0 {
+		return
+	}
+	if !stmt.Unscoped && len(stmt.Schema.QueryClauses) > 0 {
+		return
+	}
+	ctx := stmt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	keys, ok := recordKey(stmt, stmt.ReflectValue)
+	if !ok {
+		return
+	}
+	for _, column := range columns {
+		path := strings.Join(append(append([]string{stmt.Schema.Table}, keys...), column), "/")
+		if err := p.manager.Delete(ctx, path); err != nil {
+			p.manager.log.WithError(err).Warnf("Failed to delete secret %s of a deleted record", path)
+		}
+	}
+}
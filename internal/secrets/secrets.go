@@ -0,0 +1,204 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	BackendVault      = "vault"
+	BackendKubernetes = "kubernetes"
+	BackendMemory     = "memory"
+)
+
+// referencePrefix marks the values of the DB columns that are references to a secret of the
+// backend, the other values are the secrets themselves
+const referencePrefix = "secretref:"
+
+type Config struct {
+	// Backend is where the secrets are kept, they are kept in the DB when not set
+	Backend string `envconfig:"SECRETS_BACKEND" default:""`
+	// CacheTTL is how long a secret is used before it's read again from the backend, it bounds the
+	// time it takes for the service to use a secret rotated in the backend
+	CacheTTL            time.Duration `envconfig:"SECRETS_CACHE_TTL" default:"5m"`
+	VaultAddress        string        `envconfig:"VAULT_ADDR" default:""`
+	VaultToken          string        `envconfig:"VAULT_TOKEN" default:""`
+	VaultTokenFile      string        `envconfig:"VAULT_TOKEN_FILE" default:""`
+	VaultNamespace      string        `envconfig:"VAULT_NAMESPACE" default:""`
+	VaultMount          string        `envconfig:"VAULT_KV_MOUNT" default:"secret"`
+	VaultPathPrefix     string        `envconfig:"VAULT_PATH_PREFIX" default:"assisted-service"`
+	KubernetesNamespace string        `envconfig:"SECRETS_NAMESPACE" default:""`
+}
+
+var ErrNotFound = errors.New("secret not found")
+
+// Secret is a version of a secret of the backend
+type Secret struct {
+	Value string
+	// Version changes every time the secret is written, in the backend or by the service
+	Version string
+}
+
+//go:generate mockgen -source=secrets.go -package=secrets -destination=mock_backend.go
+type Backend interface {
+	// Get returns the current version of the secret, ErrNotFound when it doesn't exist
+	Get(ctx context.Context, path string) (*Secret, error)
+	// Put writes a new version of the secret
+	Put(ctx context.Context, path, value string) (*Secret, error)
+	Delete(ctx context.Context, path string) error
+}
+
+type cacheEntry struct {
+	secret    *Secret
+	fetchedAt time.Time
+}
+
+// Manager keeps the secrets in the backend and resolves the references to them, the secrets are
+// cached for the TTL of the configuration
+type Manager struct {
+	backend Backend
+	ttl     time.Duration
+	log     logrus.FieldLogger
+	now     func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewManager returns the manager of the configured backend, nil when the secrets are kept in the
+// DB. The Kubernetes client is only used by the Kubernetes backend.
+func NewManager(cfg Config, kubeClient client.Client, log logrus.FieldLogger) (*Manager, error) {
+	var backend Backend
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case BackendVault:
+		vault, err := newVaultBackend(cfg)
+		if err != nil {
+			return nil, err
+		}
+		backend = vault
+	case BackendKubernetes:
+		if kubeClient == nil {
+			return nil, errors.New("the kubernetes secrets backend requires a kubernetes client")
+		}
+		if cfg.KubernetesNamespace == "" {
+			return nil, errors.New("SECRETS_NAMESPACE is required by the kubernetes secrets backend")
+		}
+		backend = NewKubernetesBackend(kubeClient, cfg.KubernetesNamespace)
+	case BackendMemory:
+		backend = NewMemoryBackend()
+	default:
+		return nil, fmt.Errorf("invalid SECRETS_BACKEND %s, must be %s, %s or %s", cfg.Backend, BackendVault, BackendKubernetes, BackendMemory)
+	}
+	return NewManagerWithBackend(backend, cfg.CacheTTL, log), nil
+}
+
+func NewManagerWithBackend(backend Backend, ttl time.Duration, log logrus.FieldLogger) *Manager {
+	return &Manager{
+		backend: backend,
+		ttl:     ttl,
+		log:     log,
+		now:     time.Now,
+		cache:   make(map[string]cacheEntry),
+	}
+}
+
+func (m *Manager) Enabled() bool {
+	return m != nil
+}
+
+// IsReference returns true when the value is a reference to a secret of the backend
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, referencePrefix)
+}
+
+func Reference(path string) string {
+	return referencePrefix + path
+}
+
+func pathOf(reference string) string {
+	return strings.TrimPrefix(reference, referencePrefix)
+}
+
+// Store writes the secret to the backend and returns the reference to keep in its place. The
+// secret isn't written again when it didn't change, so saving a record that was read with its
+// secrets doesn't create a new version of each of them. Nor is it written when it's the cached
+// version of a secret that was rotated in the backend since, saving a record that was read before
+// the rotation keeps the rotated secret.
+func (m *Manager) Store(ctx context.Context, path, value string) (string, error) {
+	m.mu.Lock()
+	entry, cached := m.cache[path]
+	m.mu.Unlock()
+	if cached && entry.secret.Value == value && m.now().Sub(entry.fetchedAt) < m.ttl {
+		return Reference(path), nil
+	}
+	secret, err := m.backend.Get(ctx, path)
+	if err == nil && secret.Value != value && cached && entry.secret.Value == value && entry.secret.Version != secret.Version {
+		m.log.Infof("Secret %s was rotated from version %s to version %s, keeping the rotated version", path, entry.secret.Version, secret.Version)
+	} else if err != nil || secret.Value != value {
+		secret, err = m.backend.Put(ctx, path, value)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to store secret %s", path)
+		}
+	}
+	m.mu.Lock()
+	m.cache[path] = cacheEntry{secret: secret, fetchedAt: m.now()}
+	m.mu.Unlock()
+	return Reference(path), nil
+}
+
+// Resolve returns the secret that the value references, or the value when it isn't a reference.
+// When the backend can't be reached the secret of the cache is used, even after its TTL.
+func (m *Manager) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	path := pathOf(value)
+	now := m.now()
+	m.mu.Lock()
+	entry, cached := m.cache[path]
+	m.mu.Unlock()
+	if cached && now.Sub(entry.fetchedAt) < m.ttl {
+		return entry.secret.Value, nil
+	}
+
+	secret, err := m.backend.Get(ctx, path)
+	if err != nil {
+		if cached && !errors.Is(err, ErrNotFound) {
+			m.log.WithError(err).Warnf("Failed to read secret %s, using the cached version %s", path, entry.secret.Version)
+			return entry.secret.Value, nil
+		}
+		return "", errors.Wrapf(err, "failed to resolve secret %s", path)
+	}
+	if cached && entry.secret.Version != secret.Version {
+		m.log.Infof("Secret %s was rotated from version %s to version %s", path, entry.secret.Version, secret.Version)
+	}
+	m.mu.Lock()
+	m.cache[path] = cacheEntry{secret: secret, fetchedAt: now}
+	m.mu.Unlock()
+	return secret.Value, nil
+}
+
+// Delete removes the secret from the backend, it doesn't fail when the secret doesn't exist
+func (m *Manager) Delete(ctx context.Context, path string) error {
+	m.Invalidate(path)
+	if err := m.backend.Delete(ctx, path); err != nil && !errors.Is(err, ErrNotFound) {
+		return errors.Wrapf(err, "failed to delete secret %s", path)
+	}
+	return nil
+}
+
+// Invalidate drops the secret from the cache, it's read again from the backend on its next use
+func (m *Manager) Invalidate(path string) {
+	m.mu.Lock()
+	delete(m.cache, path)
+	m.mu.Unlock()
+}
@@ -0,0 +1,253 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("NewManager", func() {
+	It("keeps the secrets in the DB without backend", func() {
+		manager, err := NewManager(Config{}, nil, logrus.New())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manager.Enabled()).To(BeFalse())
+	})
+
+	It("validates the configuration of the backend", func() {
+		_, err := NewManager(Config{Backend: "other"}, nil, logrus.New())
+		Expect(err).To(HaveOccurred())
+		_, err = NewManager(Config{Backend: BackendVault, VaultToken: "token"}, nil, logrus.New())
+		Expect(err).To(HaveOccurred())
+		_, err = NewManager(Config{Backend: BackendVault, VaultAddress: "http://vault:8200"}, nil, logrus.New())
+		Expect(err).To(HaveOccurred())
+		_, err = NewManager(Config{Backend: BackendKubernetes, KubernetesNamespace: "assisted-installer"}, nil, logrus.New())
+		Expect(err).To(HaveOccurred())
+		manager, err := NewManager(Config{Backend: BackendMemory}, nil, logrus.New())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manager.Enabled()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Manager", func() {
+	var (
+		ctx     = context.Background()
+		backend *MemoryBackend
+		manager *Manager
+		now     time.Time
+	)
+
+	BeforeEach(func() {
+		backend = NewMemoryBackend()
+		manager = NewManagerWithBackend(backend, time.Minute, logrus.New())
+		now = time.Now()
+		manager.now = func() time.Time { return now }
+	})
+
+	It("resolves the references to the stored secrets", func() {
+		reference, err := manager.Store(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsReference(reference)).To(BeTrue())
+		Expect(manager.Resolve(ctx, reference)).To(Equal("secret"))
+		Expect(manager.Resolve(ctx, "plaintext")).To(Equal("plaintext"))
+	})
+
+	It("doesn't write a secret that didn't change", func() {
+		_, err := manager.Store(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		now = now.Add(2 * time.Minute)
+		_, err = manager.Store(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backend.Get(ctx, "clusters/id/pull_secret")).To(Equal(&Secret{Value: "secret", Version: "1"}))
+		_, err = manager.Store(ctx, "clusters/id/pull_secret", "other")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backend.Get(ctx, "clusters/id/pull_secret")).To(Equal(&Secret{Value: "other", Version: "2"}))
+	})
+
+	It("uses the rotated secret after the TTL", func() {
+		reference, err := manager.Store(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = backend.Put(ctx, "clusters/id/pull_secret", "rotated")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manager.Resolve(ctx, reference)).To(Equal("secret"))
+		now = now.Add(2 * time.Minute)
+		Expect(manager.Resolve(ctx, reference)).To(Equal("rotated"))
+	})
+
+	It("doesn't write back the cached version of a rotated secret", func() {
+		reference, err := manager.Store(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manager.Resolve(ctx, reference)).To(Equal("secret"))
+		_, err = backend.Put(ctx, "clusters/id/pull_secret", "rotated")
+		Expect(err).ToNot(HaveOccurred())
+		now = now.Add(2 * time.Minute)
+		_, err = manager.Store(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backend.Get(ctx, "clusters/id/pull_secret")).To(Equal(&Secret{Value: "rotated", Version: "2"}))
+		Expect(manager.Resolve(ctx, reference)).To(Equal("rotated"))
+	})
+
+	It("fails to resolve a deleted secret", func() {
+		reference, err := manager.Store(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manager.Delete(ctx, "clusters/id/pull_secret")).To(Succeed())
+		Expect(manager.Delete(ctx, "clusters/id/pull_secret")).To(Succeed())
+		_, err = manager.Resolve(ctx, reference)
+		Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+	})
+
+	It("uses the cached secret when the backend is unreachable", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		mockBackend := NewMockBackend(ctrl)
+		manager = NewManagerWithBackend(mockBackend, time.Minute, logrus.New())
+		manager.now = func() time.Time { return now }
+
+		mockBackend.EXPECT().Get(gomock.Any(), "hosts/id/fencing_credentials").Return(&Secret{Value: "secret", Version: "1"}, nil)
+		reference := Reference("hosts/id/fencing_credentials")
+		Expect(manager.Resolve(ctx, reference)).To(Equal("secret"))
+		now = now.Add(2 * time.Minute)
+		mockBackend.EXPECT().Get(gomock.Any(), "hosts/id/fencing_credentials").Return(nil, errors.New("connection refused"))
+		Expect(manager.Resolve(ctx, reference)).To(Equal("secret"))
+		mockBackend.EXPECT().Get(gomock.Any(), "hosts/id/fencing_credentials").Return(nil, ErrNotFound)
+		_, err := manager.Resolve(ctx, reference)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("vaultBackend", func() {
+	var (
+		ctx      = context.Background()
+		server   *httptest.Server
+		secrets  map[string]map[string]string
+		versions map[string]int
+		tokens   []string
+	)
+
+	BeforeEach(func() {
+		secrets = map[string]map[string]string{}
+		versions = map[string]int{}
+		tokens = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokens = append(tokens, r.Header.Get("X-Vault-Token"))
+			path := r.URL.Path
+			switch {
+			case r.Method == http.MethodPost && strings.HasPrefix(path, "/v1/secret/data/"):
+				key := strings.TrimPrefix(path, "/v1/secret/data/")
+				var body struct {
+					Data map[string]string `json:"data"`
+				}
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				secrets[key] = body.Data
+				versions[key]++
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": versions[key]}})
+			case r.Method == http.MethodGet && strings.HasPrefix(path, "/v1/secret/data/"):
+				key := strings.TrimPrefix(path, "/v1/secret/data/")
+				data, ok := secrets[key]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+					"data":     data,
+					"metadata": map[string]interface{}{"version": versions[key]},
+				}})
+			case r.Method == http.MethodDelete && strings.HasPrefix(path, "/v1/secret/metadata/"):
+				delete(secrets, strings.TrimPrefix(path, "/v1/secret/metadata/"))
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusForbidden)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("keeps the secrets in the KV secrets engine", func() {
+		vault, err := newVaultBackend(Config{VaultAddress: server.URL, VaultToken: "token", VaultMount: "secret", VaultPathPrefix: "assisted-service"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vault.Put(ctx, "clusters/id/pull_secret", "secret")).To(Equal(&Secret{Value: "secret", Version: "1"}))
+		Expect(secrets).To(HaveKeyWithValue("assisted-service/clusters/id/pull_secret", map[string]string{"value": "secret"}))
+		Expect(vault.Put(ctx, "clusters/id/pull_secret", "rotated")).To(Equal(&Secret{Value: "rotated", Version: "2"}))
+		Expect(vault.Get(ctx, "clusters/id/pull_secret")).To(Equal(&Secret{Value: "rotated", Version: "2"}))
+		Expect(vault.Delete(ctx, "clusters/id/pull_secret")).To(Succeed())
+		_, err = vault.Get(ctx, "clusters/id/pull_secret")
+		Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		Expect(tokens).To(HaveEach("token"))
+	})
+
+	It("reads the token file on each request", func() {
+		dir, err := os.MkdirTemp("", "vault")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		tokenFile := filepath.Join(dir, "token")
+		Expect(os.WriteFile(tokenFile, []byte("first\n"), 0600)).To(Succeed())
+		vault, err := newVaultBackend(Config{VaultAddress: server.URL, VaultTokenFile: tokenFile, VaultMount: "secret"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = vault.Put(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(tokenFile, []byte("second\n"), 0600)).To(Succeed())
+		_, err = vault.Get(ctx, "clusters/id/pull_secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokens).To(Equal([]string{"first", "second"}))
+	})
+
+	It("fails on the errors of vault", func() {
+		vault, err := newVaultBackend(Config{VaultAddress: server.URL, VaultToken: "token", VaultMount: "other"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = vault.Put(ctx, "clusters/id/pull_secret", "secret")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("403"))
+	})
+})
+
+var _ = Describe("KubernetesBackend", func() {
+	var (
+		ctx     = context.Background()
+		backend *KubernetesBackend
+	)
+
+	BeforeEach(func() {
+		backend = NewKubernetesBackend(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), "assisted-installer")
+	})
+
+	It("keeps the secrets in Secrets of the namespace", func() {
+		created, err := backend.Put(ctx, "hosts/id/infra_env_id/fencing_credentials", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(backend.client.Get(ctx, types.NamespacedName{Namespace: "assisted-installer", Name: "assisted-hosts-id-infra-env-id-fencing-credentials"}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("value", []byte("secret")))
+		Expect(secret.Annotations).To(HaveKeyWithValue(kubernetesPathAnnotation, "hosts/id/infra_env_id/fencing_credentials"))
+
+		updated, err := backend.Put(ctx, "hosts/id/infra_env_id/fencing_credentials", "rotated")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Version).ToNot(Equal(created.Version))
+		Expect(backend.Get(ctx, "hosts/id/infra_env_id/fencing_credentials")).To(Equal(updated))
+
+		Expect(backend.Delete(ctx, "hosts/id/infra_env_id/fencing_credentials")).To(Succeed())
+		_, err = backend.Get(ctx, "hosts/id/infra_env_id/fencing_credentials")
+		Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+	})
+
+	It("shortens the names of the long paths", func() {
+		name := secretName(strings.Repeat("a", 300))
+		Expect(len(name)).To(Equal(kubernetesMaxNameLength))
+		Expect(name).ToNot(Equal(secretName(strings.Repeat("a", 301))))
+	})
+})
@@ -0,0 +1,151 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// vaultBackend keeps the secrets in a Vault KV version 2 secrets engine, the secret of a path is
+// the value key of the KV secret
+type vaultBackend struct {
+	address    string
+	token      string
+	tokenFile  string
+	namespace  string
+	mount      string
+	pathPrefix string
+	client     *http.Client
+}
+
+func newVaultBackend(cfg Config) (*vaultBackend, error) {
+	if cfg.VaultAddress == "" {
+		return nil, errors.New("VAULT_ADDR is required by the vault secrets backend")
+	}
+	if cfg.VaultToken == "" && cfg.VaultTokenFile == "" {
+		return nil, errors.New("VAULT_TOKEN or VAULT_TOKEN_FILE is required by the vault secrets backend")
+	}
+	if _, err := url.Parse(cfg.VaultAddress); err != nil {
+		return nil, errors.Wrap(err, "invalid VAULT_ADDR")
+	}
+	return &vaultBackend{
+		address:    strings.TrimSuffix(cfg.VaultAddress, "/"),
+		token:      cfg.VaultToken,
+		tokenFile:  cfg.VaultTokenFile,
+		namespace:  cfg.VaultNamespace,
+		mount:      strings.Trim(cfg.VaultMount, "/"),
+		pathPrefix: strings.Trim(cfg.VaultPathPrefix, "/"),
+		client:     &http.Client{Timeout: vaultRequestTimeout},
+	}, nil
+}
+
+type vaultMetadata struct {
+	Version int `json:"version"`
+}
+
+type vaultReadResponse struct {
+	Data struct {
+		Data     map[string]string `json:"data"`
+		Metadata vaultMetadata     `json:"metadata"`
+	} `json:"data"`
+}
+
+type vaultWriteResponse struct {
+	Data vaultMetadata `json:"data"`
+}
+
+// getToken returns the token of the configuration, the token file is read on each request so the
+// token can be renewed by an agent
+func (v *vaultBackend) getToken() (string, error) {
+	if v.tokenFile == "" {
+		return v.token, nil
+	}
+	token, err := os.ReadFile(v.tokenFile)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read the vault token file")
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+func (v *vaultBackend) request(ctx context.Context, method, api, secretPath string, body interface{}, reply interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+	requestURL := fmt.Sprintf("%s/v1/%s", v.address, path.Join(v.mount, api, v.pathPrefix, secretPath))
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+	if err != nil {
+		return err
+	}
+	token, err := v.getToken()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "vault request %s %s failed", method, secretPath)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("vault request %s %s failed with status %d: %s", method, secretPath, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if reply == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return errors.Wrapf(json.NewDecoder(resp.Body).Decode(reply), "failed to decode the vault reply to %s %s", method, secretPath)
+}
+
+func (v *vaultBackend) Get(ctx context.Context, secretPath string) (*Secret, error) {
+	var reply vaultReadResponse
+	if err := v.request(ctx, http.MethodGet, "data", secretPath, nil, &reply); err != nil {
+		return nil, err
+	}
+	value, ok := reply.Data.Data["value"]
+	if !ok {
+		// The latest version is deleted
+		return nil, ErrNotFound
+	}
+	return &Secret{Value: value, Version: strconv.Itoa(reply.Data.Metadata.Version)}, nil
+}
+
+func (v *vaultBackend) Put(ctx context.Context, secretPath, value string) (*Secret, error) {
+	var reply vaultWriteResponse
+	body := map[string]interface{}{"data": map[string]string{"value": value}}
+	if err := v.request(ctx, http.MethodPost, "data", secretPath, body, &reply); err != nil {
+		return nil, err
+	}
+	return &Secret{Value: value, Version: strconv.Itoa(reply.Data.Version)}, nil
+}
+
+// Delete removes all the versions of the secret
+func (v *vaultBackend) Delete(ctx context.Context, secretPath string) error {
+	return v.request(ctx, http.MethodDelete, "metadata", secretPath, nil, nil)
+}
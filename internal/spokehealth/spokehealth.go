@@ -0,0 +1,155 @@
+package spokehealth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Config is the configuration of the monitoring of the health of the clusters after their installation
+type Config struct {
+	// Duration is how long the health of a cluster is monitored after its installation, the
+	// monitoring is disabled when it's 0
+	Duration time.Duration `envconfig:"SPOKE_HEALTH_MONITOR_DURATION" default:"0s"`
+	// Interval is how often the health of a cluster is collected
+	Interval time.Duration `envconfig:"SPOKE_HEALTH_MONITOR_INTERVAL" default:"10m"`
+}
+
+// clusterVersionFailing is the condition of the ClusterVersion reporting that the cluster version
+// operator can't reconcile the cluster
+const clusterVersionFailing configv1.ClusterStatusConditionType = "Failing"
+
+// Collect returns the health of the cluster of the client: the conditions of its ClusterVersion,
+// the cluster operators that are degraded or unavailable, and the nodes that aren't ready. A cluster
+// that can't be reached is unavailable.
+func Collect(ctx context.Context, c client.Client) *models.SpokeHealth {
+	health := &models.SpokeHealth{CheckedAt: strfmt.DateTime(time.Now())}
+
+	clusterVersion := &configv1.ClusterVersion{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "version"}, clusterVersion); err != nil {
+		return unavailable(health, errors.Wrap(err, "failed to get the cluster version"))
+	}
+	health.ClusterVersion = currentVersion(clusterVersion)
+	health.ClusterVersionAvailable = conditionIsTrue(clusterVersion.Status.Conditions, configv1.OperatorAvailable)
+	health.ClusterVersionProgressing = conditionIsTrue(clusterVersion.Status.Conditions, configv1.OperatorProgressing)
+	health.ClusterVersionFailing = conditionIsTrue(clusterVersion.Status.Conditions, clusterVersionFailing)
+
+	operators := &configv1.ClusterOperatorList{}
+	if err := c.List(ctx, operators); err != nil {
+		return unavailable(health, errors.Wrap(err, "failed to list the cluster operators"))
+	}
+	health.DegradedOperators = []string{}
+	health.UnavailableOperators = []string{}
+	for _, operator := range operators.Items {
+		if conditionIsTrue(operator.Status.Conditions, configv1.OperatorDegraded) {
+			health.DegradedOperators = append(health.DegradedOperators, operator.Name)
+		}
+		if !conditionIsTrue(operator.Status.Conditions, configv1.OperatorAvailable) {
+			health.UnavailableOperators = append(health.UnavailableOperators, operator.Name)
+		}
+	}
+	sort.Strings(health.DegradedOperators)
+	sort.Strings(health.UnavailableOperators)
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return unavailable(health, errors.Wrap(err, "failed to list the nodes"))
+	}
+	health.NotReadyNodes = []string{}
+	for _, node := range nodes.Items {
+		if nodeIsReady(&node) {
+			health.ReadyNodes++
+		} else {
+			health.NotReadyNodes = append(health.NotReadyNodes, node.Name)
+		}
+	}
+	health.TotalNodes = int64(len(nodes.Items))
+	sort.Strings(health.NotReadyNodes)
+
+	health.Status, health.Message = summarize(health)
+	return health
+}
+
+func unavailable(health *models.SpokeHealth, err error) *models.SpokeHealth {
+	health.Status = models.SpokeHealthStatusUnavailable
+	health.Message = fmt.Sprintf("The cluster can't be reached: %s", err.Error())
+	return health
+}
+
+// summarize returns the status of the collected health, and what isn't healthy
+func summarize(health *models.SpokeHealth) (string, string) {
+	var problems []string
+	if !health.ClusterVersionAvailable {
+		problems = append(problems, "the cluster version isn't available")
+	}
+	if health.ClusterVersionFailing {
+		problems = append(problems, "the cluster version operator is failing")
+	}
+	if len(health.DegradedOperators) > 0 {
+		problems = append(problems, fmt.Sprintf("degraded cluster operators: %s", strings.Join(health.DegradedOperators, ", ")))
+	}
+	if len(health.UnavailableOperators) > 0 {
+		problems = append(problems, fmt.Sprintf("unavailable cluster operators: %s", strings.Join(health.UnavailableOperators, ", ")))
+	}
+	if len(health.NotReadyNodes) > 0 {
+		problems = append(problems, fmt.Sprintf("nodes not ready: %s", strings.Join(health.NotReadyNodes, ", ")))
+	}
+	if len(problems) > 0 {
+		return models.SpokeHealthStatusDegraded, strings.Join(problems, "; ")
+	}
+	return models.SpokeHealthStatusHealthy, fmt.Sprintf("The cluster version %s, the cluster operators and the %d nodes are healthy",
+		health.ClusterVersion, health.TotalNodes)
+}
+
+// currentVersion returns the last version that the cluster completed, or the version it's
+// installing or upgrading to
+func currentVersion(clusterVersion *configv1.ClusterVersion) string {
+	for _, update := range clusterVersion.Status.History {
+		if update.State == configv1.CompletedUpdate {
+			return update.Version
+		}
+	}
+	return clusterVersion.Status.Desired.Version
+}
+
+func conditionIsTrue(conditions []configv1.ClusterOperatorStatusCondition, conditionType configv1.ClusterStatusConditionType) bool {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Status == configv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func nodeIsReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// FromCluster returns the last health collected for the cluster, or nil when it wasn't monitored
+func FromCluster(cluster *common.Cluster) (*models.SpokeHealth, error) {
+	if cluster.SpokeHealth == "" {
+		return nil, nil
+	}
+	var health models.SpokeHealth
+	if err := json.Unmarshal([]byte(cluster.SpokeHealth), &health); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the spoke health of cluster %s", cluster.ID)
+	}
+	return &health, nil
+}
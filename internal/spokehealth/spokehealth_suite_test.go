@@ -0,0 +1,13 @@
+package spokehealth
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSpokeHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Spoke Health Suite")
+}
@@ -0,0 +1,135 @@
+package spokehealth
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/spoke_k8s_client"
+	"github.com/openshift/assisted-service/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Collect", func() {
+	ctx := context.Background()
+
+	clusterVersion := func(conditions ...configv1.ClusterOperatorStatusCondition) *configv1.ClusterVersion {
+		return &configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "version"},
+			Status: configv1.ClusterVersionStatus{
+				Desired: configv1.Release{Version: "4.17.1"},
+				History: []configv1.UpdateHistory{
+					{State: configv1.PartialUpdate, Version: "4.17.1"},
+					{State: configv1.CompletedUpdate, Version: "4.17.0"},
+				},
+				Conditions: conditions,
+			},
+		}
+	}
+
+	operator := func(name string, available, degraded configv1.ConditionStatus) *configv1.ClusterOperator {
+		return &configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{Type: configv1.OperatorAvailable, Status: available},
+					{Type: configv1.OperatorDegraded, Status: degraded},
+				},
+			},
+		}
+	}
+
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+			},
+		}
+	}
+
+	collect := func(objects ...runtime.Object) *models.SpokeHealth {
+		c := fake.NewClientBuilder().WithScheme(spoke_k8s_client.GetKubeClientSchemes()).WithRuntimeObjects(objects...).Build()
+		health := Collect(ctx, c)
+		Expect(health.CheckedAt).ToNot(Equal(strfmt.DateTime{}))
+		return health
+	}
+
+	available := configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue}
+
+	It("reports a healthy cluster", func() {
+		health := collect(
+			clusterVersion(available),
+			operator("console", configv1.ConditionTrue, configv1.ConditionFalse),
+			node("master-0", corev1.ConditionTrue),
+			node("master-1", corev1.ConditionTrue),
+		)
+		Expect(health.Status).To(Equal(models.SpokeHealthStatusHealthy))
+		Expect(health.ClusterVersion).To(Equal("4.17.0"))
+		Expect(health.ClusterVersionAvailable).To(BeTrue())
+		Expect(health.DegradedOperators).To(BeEmpty())
+		Expect(health.UnavailableOperators).To(BeEmpty())
+		Expect(health.TotalNodes).To(BeEquivalentTo(2))
+		Expect(health.ReadyNodes).To(BeEquivalentTo(2))
+		Expect(health.Message).To(Equal("The cluster version 4.17.0, the cluster operators and the 2 nodes are healthy"))
+	})
+
+	It("reports the degraded and unavailable operators and the nodes that aren't ready", func() {
+		health := collect(
+			clusterVersion(available, configv1.ClusterOperatorStatusCondition{Type: "Failing", Status: configv1.ConditionTrue}),
+			operator("ingress", configv1.ConditionTrue, configv1.ConditionTrue),
+			operator("dns", configv1.ConditionFalse, configv1.ConditionTrue),
+			operator("console", configv1.ConditionTrue, configv1.ConditionFalse),
+			node("master-0", corev1.ConditionTrue),
+			node("worker-0", corev1.ConditionFalse),
+			node("worker-1", corev1.ConditionUnknown),
+		)
+		Expect(health.Status).To(Equal(models.SpokeHealthStatusDegraded))
+		Expect(health.ClusterVersionFailing).To(BeTrue())
+		Expect(health.DegradedOperators).To(Equal([]string{"dns", "ingress"}))
+		Expect(health.UnavailableOperators).To(Equal([]string{"dns"}))
+		Expect(health.NotReadyNodes).To(Equal([]string{"worker-0", "worker-1"}))
+		Expect(health.TotalNodes).To(BeEquivalentTo(3))
+		Expect(health.ReadyNodes).To(BeEquivalentTo(1))
+		Expect(health.Message).To(Equal("the cluster version operator is failing; degraded cluster operators: dns, ingress; " +
+			"unavailable cluster operators: dns; nodes not ready: worker-0, worker-1"))
+	})
+
+	It("reports a cluster version that isn't available", func() {
+		health := collect(clusterVersion())
+		Expect(health.Status).To(Equal(models.SpokeHealthStatusDegraded))
+		Expect(health.Message).To(Equal("the cluster version isn't available"))
+	})
+
+	It("reports a cluster that can't be reached", func() {
+		health := collect()
+		Expect(health.Status).To(Equal(models.SpokeHealthStatusUnavailable))
+		Expect(health.Message).To(HavePrefix("The cluster can't be reached: failed to get the cluster version"))
+	})
+})
+
+var _ = Describe("FromCluster", func() {
+	It("returns nil when the cluster wasn't monitored", func() {
+		health, err := FromCluster(&common.Cluster{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(health).To(BeNil())
+	})
+
+	It("returns the stored health", func() {
+		health, err := FromCluster(&common.Cluster{Cluster: models.Cluster{SpokeHealth: `{"status":"degraded","degraded_operators":["dns"]}`}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(health.Status).To(Equal(models.SpokeHealthStatusDegraded))
+		Expect(health.DegradedOperators).To(Equal([]string{"dns"}))
+	})
+
+	It("fails on an invalid health", func() {
+		_, err := FromCluster(&common.Cluster{Cluster: models.Cluster{SpokeHealth: "{"}})
+		Expect(err).To(HaveOccurred())
+	})
+})
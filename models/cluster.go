@@ -257,6 +257,9 @@ type Cluster struct {
 	// Service networks that are associated with this cluster.
 	ServiceNetworks []*ServiceNetwork `json:"service_networks" gorm:"foreignkey:ClusterID;references:ID"`
 
+	// JSON formatted health of the installed cluster, collected periodically after the installation when the spoke health monitoring is enabled.
+	SpokeHealth string `json:"spoke_health,omitempty" gorm:"type:text"`
+
 	// SSH public key for debugging OpenShift nodes.
 	SSHPublicKey string `json:"ssh_public_key,omitempty"`
 
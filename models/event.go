@@ -31,7 +31,7 @@ type Event struct {
 	// event time
 	// Required: true
 	// Format: date-time
-	EventTime *strfmt.DateTime `json:"event_time" gorm:"type:timestamp with time zone"`
+	EventTime *strfmt.DateTime `json:"event_time" gorm:"type:timestamp with time zone;primaryKey"`
 
 	// Unique identifier of the host this event relates to.
 	// Format: uuid
@@ -59,6 +59,9 @@ type Event struct {
 	// Required: true
 	// Enum: [info warning error critical]
 	Severity *string `json:"severity"`
+
+	// Identifier of the OpenTelemetry trace of the request that caused this event to occur.
+	TraceID string `json:"trace_id,omitempty"`
 }
 
 // Validate validates this event
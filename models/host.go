@@ -26,6 +26,9 @@ type Host struct {
 	// Contains a serialized api_vip_connectivity_response
 	APIVipConnectivity string `json:"api_vip_connectivity,omitempty" gorm:"type:text"`
 
+	// JSON formatted string of the firmware, boot and RAID details of the host reported by its BareMetalHost.
+	BmcInventory string `json:"bmc_inventory,omitempty" gorm:"type:text"`
+
 	// bootstrap
 	Bootstrap bool `json:"bootstrap,omitempty"`
 
@@ -172,7 +175,7 @@ type Host struct {
 
 	// status
 	// Required: true
-	// Enum: [discovering known disconnected insufficient disabled preparing-for-installation preparing-failed preparing-successful pending-for-input installing installing-in-progress installing-pending-user-action resetting-pending-user-action installed error resetting added-to-existing-cluster cancelled binding unbinding unbinding-pending-user-action known-unbound disconnected-unbound insufficient-unbound disabled-unbound discovering-unbound reclaiming reclaiming-rebooting]
+	// Enum: [discovering known disconnected insufficient disabled preparing-for-installation preparing-failed preparing-successful pending-for-input installing installing-in-progress installing-pending-user-action resetting-pending-user-action installed error resetting added-to-existing-cluster cancelled binding unbinding unbinding-pending-user-action known-unbound disconnected-unbound insufficient-unbound disabled-unbound discovering-unbound reclaiming reclaiming-rebooting pending-attestation]
 	Status *string `json:"status"`
 
 	// status info
@@ -594,7 +597,7 @@ var hostTypeStatusPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["discovering","known","disconnected","insufficient","disabled","preparing-for-installation","preparing-failed","preparing-successful","pending-for-input","installing","installing-in-progress","installing-pending-user-action","resetting-pending-user-action","installed","error","resetting","added-to-existing-cluster","cancelled","binding","unbinding","unbinding-pending-user-action","known-unbound","disconnected-unbound","insufficient-unbound","disabled-unbound","discovering-unbound","reclaiming","reclaiming-rebooting"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["discovering","known","disconnected","insufficient","disabled","preparing-for-installation","preparing-failed","preparing-successful","pending-for-input","installing","installing-in-progress","installing-pending-user-action","resetting-pending-user-action","installed","error","resetting","added-to-existing-cluster","cancelled","binding","unbinding","unbinding-pending-user-action","known-unbound","disconnected-unbound","insufficient-unbound","disabled-unbound","discovering-unbound","reclaiming","reclaiming-rebooting","pending-attestation"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -687,6 +690,9 @@ const (
 
 	// HostStatusReclaimingRebooting captures enum value "reclaiming-rebooting"
 	HostStatusReclaimingRebooting string = "reclaiming-rebooting"
+
+	// HostStatusPendingAttestation captures enum value "pending-attestation"
+	HostStatusPendingAttestation string = "pending-attestation"
 )
 
 // prop value enum
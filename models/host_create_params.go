@@ -26,6 +26,9 @@ type HostCreateParams struct {
 	// Required: true
 	// Format: uuid
 	HostID *strfmt.UUID `json:"host_id"`
+
+	// tpm endorsement
+	TpmEndorsement *TpmEndorsement `json:"tpm_endorsement,omitempty"`
 }
 
 // Validate validates this host create params
@@ -36,6 +39,10 @@ func (m *HostCreateParams) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTpmEndorsement(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -55,8 +62,52 @@ func (m *HostCreateParams) validateHostID(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this host create params based on context it is used
+func (m *HostCreateParams) validateTpmEndorsement(formats strfmt.Registry) error {
+	if swag.IsZero(m.TpmEndorsement) { // not required
+		return nil
+	}
+
+	if m.TpmEndorsement != nil {
+		if err := m.TpmEndorsement.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("tpm_endorsement")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("tpm_endorsement")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this host create params based on the context it is used
 func (m *HostCreateParams) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateTpmEndorsement(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *HostCreateParams) contextValidateTpmEndorsement(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.TpmEndorsement != nil {
+		if err := m.TpmEndorsement.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("tpm_endorsement")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("tpm_endorsement")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
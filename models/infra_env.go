@@ -53,9 +53,15 @@ type InfraEnv struct {
 	// Format: date-time
 	ExpiresAt strfmt.DateTime `json:"expires_at,omitempty" gorm:"type:timestamp with time zone"`
 
+	// JSON formatted firmware requirements of the hosts with a BareMetalHost that register to the infra-env.
+	FirmwareRequirements *string `json:"firmware_requirements,omitempty" gorm:"type:text"`
+
 	// Image generator version.
 	GeneratorVersion string `json:"generator_version,omitempty"`
 
+	// JSON formatted host approval policy of the hosts that register to the infra-env.
+	HostApprovalPolicy *string `json:"host_approval_policy,omitempty" gorm:"type:text"`
+
 	// Self link.
 	// Required: true
 	Href *string `json:"href"`
@@ -116,6 +122,9 @@ type InfraEnv struct {
 	// static network configuration string in the format expected by discovery ignition generation.
 	StaticNetworkConfig string `json:"static_network_config,omitempty"`
 
+	// JSON formatted TPM attestation policy required from the hosts that register to the infra-env.
+	TpmAttestationPolicy *string `json:"tpm_attestation_policy,omitempty" gorm:"type:text"`
+
 	// type
 	// Required: true
 	Type *ImageType `json:"type"`
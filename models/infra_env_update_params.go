@@ -27,6 +27,12 @@ type InfraEnvUpdateParams struct {
 	// Max Length: 65535
 	AdditionalTrustBundle *string `json:"additional_trust_bundle,omitempty"`
 
+	// firmware requirements
+	FirmwareRequirements *FirmwareRequirements `json:"firmware_requirements,omitempty"`
+
+	// host approval policy
+	HostApprovalPolicy *HostApprovalPolicy `json:"host_approval_policy,omitempty"`
+
 	// JSON formatted string containing the user overrides for the initial ignition config.
 	IgnitionConfigOverride string `json:"ignition_config_override,omitempty"`
 
@@ -64,6 +70,9 @@ type InfraEnvUpdateParams struct {
 
 	// static network config
 	StaticNetworkConfig []*HostStaticNetworkConfig `json:"static_network_config"`
+
+	// tpm attestation policy
+	TpmAttestationPolicy *TpmAttestationPolicy `json:"tpm_attestation_policy,omitempty"`
 }
 
 // Validate validates this infra env update params
@@ -98,6 +107,18 @@ func (m *InfraEnvUpdateParams) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTpmAttestationPolicy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateHostApprovalPolicy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateFirmwareRequirements(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -219,6 +240,63 @@ func (m *InfraEnvUpdateParams) validateStaticNetworkConfig(formats strfmt.Regist
 	return nil
 }
 
+func (m *InfraEnvUpdateParams) validateTpmAttestationPolicy(formats strfmt.Registry) error {
+	if swag.IsZero(m.TpmAttestationPolicy) { // not required
+		return nil
+	}
+
+	if m.TpmAttestationPolicy != nil {
+		if err := m.TpmAttestationPolicy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("tpm_attestation_policy")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("tpm_attestation_policy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *InfraEnvUpdateParams) validateHostApprovalPolicy(formats strfmt.Registry) error {
+	if swag.IsZero(m.HostApprovalPolicy) { // not required
+		return nil
+	}
+
+	if m.HostApprovalPolicy != nil {
+		if err := m.HostApprovalPolicy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("host_approval_policy")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("host_approval_policy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *InfraEnvUpdateParams) validateFirmwareRequirements(formats strfmt.Registry) error {
+	if swag.IsZero(m.FirmwareRequirements) { // not required
+		return nil
+	}
+
+	if m.FirmwareRequirements != nil {
+		if err := m.FirmwareRequirements.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("firmware_requirements")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("firmware_requirements")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ContextValidate validate this infra env update params based on the context it is used
 func (m *InfraEnvUpdateParams) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
@@ -239,6 +317,18 @@ func (m *InfraEnvUpdateParams) ContextValidate(ctx context.Context, formats strf
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateTpmAttestationPolicy(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateHostApprovalPolicy(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateFirmwareRequirements(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -309,6 +399,54 @@ func (m *InfraEnvUpdateParams) contextValidateStaticNetworkConfig(ctx context.Co
 	return nil
 }
 
+func (m *InfraEnvUpdateParams) contextValidateTpmAttestationPolicy(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.TpmAttestationPolicy != nil {
+		if err := m.TpmAttestationPolicy.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("tpm_attestation_policy")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("tpm_attestation_policy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *InfraEnvUpdateParams) contextValidateHostApprovalPolicy(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.HostApprovalPolicy != nil {
+		if err := m.HostApprovalPolicy.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("host_approval_policy")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("host_approval_policy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *InfraEnvUpdateParams) contextValidateFirmwareRequirements(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.FirmwareRequirements != nil {
+		if err := m.FirmwareRequirements.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("firmware_requirements")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("firmware_requirements")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *InfraEnvUpdateParams) MarshalBinary() ([]byte, error) {
 	if m == nil {
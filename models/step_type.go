@@ -86,6 +86,15 @@ const (
 
 	// StepTypeVerifyVips captures enum value "verify-vips"
 	StepTypeVerifyVips StepType = "verify-vips"
+
+	// StepTypeRotateAgentToken captures enum value "rotate-agent-token"
+	StepTypeRotateAgentToken StepType = "rotate-agent-token"
+
+	// StepTypeTpmAttestation captures enum value "tpm-attestation"
+	StepTypeTpmAttestation StepType = "tpm-attestation"
+
+	// StepTypeMigrateAgent captures enum value "migrate-agent"
+	StepTypeMigrateAgent StepType = "migrate-agent"
 )
 
 // for schema
@@ -93,7 +102,7 @@ var stepTypeEnum []interface{}
 
 func init() {
 	var res []StepType
-	if err := json.Unmarshal([]byte(`["connectivity-check","execute","inventory","install","free-network-addresses","dhcp-lease-allocate","api-vip-connectivity-check","tang-connectivity-check","ntp-synchronizer","installation-disk-speed-check","container-image-availability","domain-resolution","stop-installation","logs-gather","next-step-runner","upgrade-agent","download-boot-artifacts","reboot-for-reclaim","verify-vips"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["connectivity-check","execute","inventory","install","free-network-addresses","dhcp-lease-allocate","api-vip-connectivity-check","tang-connectivity-check","ntp-synchronizer","installation-disk-speed-check","container-image-availability","domain-resolution","stop-installation","logs-gather","next-step-runner","upgrade-agent","download-boot-artifacts","reboot-for-reclaim","verify-vips","rotate-agent-token","tpm-attestation","migrate-agent"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -1,15 +1,23 @@
 package app
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	errormiddleware "github.com/go-openapi/errors"
 	"github.com/openshift/assisted-service/client"
+	"github.com/openshift/assisted-service/internal/audit"
+	"github.com/openshift/assisted-service/internal/ratelimit"
+	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/thread"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
@@ -79,6 +87,8 @@ func SetupCORSMiddleware(handler http.Handler, domains []string) http.Handler {
 			"Severity-Count-Error",
 			"Severity-Count-Critical",
 			"Event-Count",
+			"Traceparent",
+			"Tracestate",
 		},
 		ExposedHeaders: []string{
 			"Severity-Count-Info",
@@ -86,6 +96,11 @@ func SetupCORSMiddleware(handler http.Handler, domains []string) http.Handler {
 			"Severity-Count-Error",
 			"Severity-Count-Critical",
 			"Event-Count",
+			"Next-Cursor",
+			"Traceparent",
+			"Retry-After",
+			"X-RateLimit-Limit",
+			"X-RateLimit-Remaining",
 		},
 		MaxAge: int((10 * time.Minute).Seconds()),
 	})
@@ -121,6 +136,169 @@ func WithIPXEScriptMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	maxBodySize int64
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write keeps the bodies of the responses of the creations, they identify the created resources
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if w.status == http.StatusCreated && int64(w.body.Len()+len(b)) <= w.maxBodySize {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush is needed by the handlers that stream their responses
+func (w *auditResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type auditBodyReader struct {
+	io.Reader
+	io.Closer
+}
+
+// auditRequestBody returns the JSON body of the request, and restores the body for the handlers.
+// The other bodies, e.g. uploaded files, are only described.
+func auditRequestBody(r *http.Request, maxBodySize int64) interface{} {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+		return fmt.Sprintf("<%s body>", contentType)
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	r.Body = &auditBodyReader{Reader: io.MultiReader(bytes.NewReader(data), r.Body), Closer: r.Body}
+	if err != nil {
+		return "<unreadable body>"
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if int64(len(data)) > maxBodySize {
+		return fmt.Sprintf("<body larger than %d bytes>", maxBodySize)
+	}
+	var body interface{}
+	if err = json.Unmarshal(data, &body); err != nil {
+		return "<invalid JSON body>"
+	}
+	return body
+}
+
+// auditCreatedResource adds the resource created by the call to its audit record
+func auditCreatedResource(record *models.AuditRecord, body []byte) {
+	var resource struct {
+		ID         string `json:"id"`
+		Kind       string `json:"kind"`
+		ClusterID  string `json:"cluster_id"`
+		InfraEnvID string `json:"infra_env_id"`
+	}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return
+	}
+	switch resource.Kind {
+	case models.ClusterKindCluster, models.ClusterKindAddHostsCluster:
+		record.ClusterID = resource.ID
+	case models.InfraEnvKindInfraEnv:
+		record.InfraEnvID = resource.ID
+	case models.HostKindHost, models.HostKindAddToExistingClusterHost:
+		record.HostID = resource.ID
+	}
+	if record.ClusterID == "" {
+		record.ClusterID = resource.ClusterID
+	}
+	if record.InfraEnvID == "" {
+		record.InfraEnvID = resource.InfraEnvID
+	}
+}
+
+// WithAuditMiddleware returns middleware which writes an audit record of each call to the API that
+// may modify resources, with the user that made it, its parameters without their secrets and its
+// result. The user and the operation of the call are added to the record by the audit authorizer
+// and the audit inner middleware.
+func WithAuditMiddleware(next http.Handler, auditor *audit.Auditor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !auditor.Enabled() || !audit.IsAudited(r.Method) || !strings.HasPrefix(r.URL.Path, client.DefaultBasePath) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := time.Now()
+		record := auditor.NewRecord(r)
+		body := auditRequestBody(r, auditor.MaxBodySize())
+		recorder := &auditResponseWriter{ResponseWriter: w, status: http.StatusOK, maxBodySize: auditor.MaxBodySize()}
+		next.ServeHTTP(recorder, r.WithContext(audit.WithRecord(r.Context(), record)))
+
+		record.StatusCode = int64(recorder.status)
+		record.DurationMs = time.Since(start).Milliseconds()
+		record.Params = audit.RedactParams(r.URL.Query(), body)
+		if recorder.status == http.StatusCreated {
+			auditCreatedResource(record, recorder.body.Bytes())
+		}
+		auditor.Write(r.Context(), record)
+	})
+}
+
+type rateLimitResponseWriter struct {
+	http.ResponseWriter
+	result      *ratelimit.Result
+	wroteHeader bool
+}
+
+// WriteHeader adds the state of the rate limits of the request to the headers of the response
+func (w *rateLimitResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.result.Class != "" {
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(w.result.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(w.result.Remaining))
+			if status == http.StatusTooManyRequests && !w.result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(w.result.RetryAfterSeconds()))
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *rateLimitResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush is needed by the handlers that stream their responses
+func (w *rateLimitResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// WithRateLimitMiddleware returns middleware which reports the API rate limits of the requests in the
+// X-RateLimit-Limit, X-RateLimit-Remaining and Retry-After headers of the responses. The requests are
+// charged to their budgets by the rate limits authorizer, once their user is authenticated.
+func WithRateLimitMiddleware(next http.Handler, limiter *ratelimit.Limiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.Enabled() || !strings.HasPrefix(r.URL.Path, client.DefaultBasePath) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		result := &ratelimit.Result{}
+		writer := &rateLimitResponseWriter{ResponseWriter: w, result: result}
+		next.ServeHTTP(writer, r.WithContext(ratelimit.WithResult(r.Context(), result)))
+	})
+}
+
 func WrapServeError() func(http.ResponseWriter, *http.Request, error) {
 	unsupportedHTTPCodes := map[int32]struct{}{
 		http.StatusUnprocessableEntity: {},
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/openshift/assisted-service/internal/common"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+/* AgentTokenAuthzHandler rejects the requests made with the local agent
+ * tokens on the routes of other infra-envs and hosts than the ones of the
+ * token, and on the routes of the hosts whose agent tokens were revoked. The
+ * revoked host tokens are already rejected by the authenticator, but the
+ * host may still hold an infra-env token, which is accepted for the other
+ * hosts of the infra-env.
+ */
+type AgentTokenAuthzHandler struct {
+	Authorizer
+	log   logrus.FieldLogger
+	db    *gorm.DB
+	cache *cache.Cache
+}
+
+var _ Authorizer = &AgentTokenAuthzHandler{}
+
+func NewAgentTokenAuthzHandler(authorizer Authorizer, log logrus.FieldLogger, db *gorm.DB) *AgentTokenAuthzHandler {
+	return &AgentTokenAuthzHandler{
+		Authorizer: authorizer,
+		log:        log,
+		db:         db,
+		cache:      cache.New(agentTokensCacheTTL, 10*time.Minute),
+	}
+}
+
+func (a *AgentTokenAuthzHandler) CreateAuthorizer() func(*http.Request) error {
+	authorize := a.Authorizer.CreateAuthorizer()
+	return func(request *http.Request) error {
+		if err := a.authorizeAgentToken(request); err != nil {
+			return err
+		}
+		return authorize(request)
+	}
+}
+
+func (a *AgentTokenAuthzHandler) authorizeAgentToken(request *http.Request) error {
+	agentToken := ocm.AgentTokenFromContext(request.Context())
+	if agentToken == nil {
+		return nil
+	}
+	infraEnvID := params.GetParam(request.Context(), params.InfraEnvId)
+	if infraEnvID != "" && infraEnvID != agentToken.InfraEnvID {
+		return common.NewInfraError(http.StatusForbidden,
+			fmt.Errorf("the agent token of infra-env %s can't access infra-env %s", agentToken.InfraEnvID, infraEnvID))
+	}
+	hostID := params.GetParam(request.Context(), params.HostId)
+	if hostID == "" {
+		return nil
+	}
+	if agentToken.HostID != "" && hostID != agentToken.HostID {
+		return common.NewInfraError(http.StatusForbidden,
+			fmt.Errorf("the agent token of host %s can't access host %s", agentToken.HostID, hostID))
+	}
+	revoked, err := a.isRevoked(hostID)
+	if err != nil {
+		a.log.WithError(err).Errorf("failed to check the agent tokens of host %s", hostID)
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+	if revoked {
+		return common.NewInfraError(http.StatusUnauthorized, fmt.Errorf("the agent tokens of host %s were revoked", hostID))
+	}
+	return nil
+}
+
+func (a *AgentTokenAuthzHandler) isRevoked(hostID string) (bool, error) {
+	if revoked, exists := a.cache.Get(hostID); exists {
+		return revoked.(bool), nil
+	}
+	var count int64
+	err := a.db.Model(&common.AgentToken{}).Where("host_id = ? AND revoked_at IS NOT NULL", hostID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	a.cache.SetDefault(hostID, count > 0)
+	return count > 0, nil
+}
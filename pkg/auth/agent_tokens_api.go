@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/openshift/assisted-service/internal/common"
+	eventgen "github.com/openshift/assisted-service/internal/common/events"
+	eventsapi "github.com/openshift/assisted-service/internal/events/api"
+	"github.com/openshift/assisted-service/internal/host/hostutil"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/agent_tokens"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var _ restapi.AgentTokensAPI = &AgentTokensAPI{}
+
+// AgentTokensAPI rotates the local agent tokens of the infra-envs and revokes the ones of the
+// compromised hosts
+type AgentTokensAPI struct {
+	cfg           *Config
+	authzHandler  Authorizer
+	eventsHandler eventsapi.Sender
+	log           logrus.FieldLogger
+	db            *gorm.DB
+}
+
+func NewAgentTokensAPI(cfg *Config, authzHandler Authorizer, eventsHandler eventsapi.Sender, log logrus.FieldLogger, db *gorm.DB) *AgentTokensAPI {
+	return &AgentTokensAPI{
+		cfg:           cfg,
+		authzHandler:  authzHandler,
+		eventsHandler: eventsHandler,
+		log:           log,
+		db:            db,
+	}
+}
+
+func (t *AgentTokensAPI) checkAuthType() error {
+	if t.cfg.AuthType != TypeLocal && t.cfg.AuthType != TypeOIDC {
+		return common.NewApiError(http.StatusBadRequest,
+			fmt.Errorf("the agent tokens can't be rotated with the %s authentication", t.cfg.AuthType))
+	}
+	return nil
+}
+
+// checkAccess checks that the user can update the resource, the resources the user can't read
+// aren't found
+func (t *AgentTokensAPI) checkAccess(ctx context.Context, kind string, obj interface{}, id strfmt.UUID) error {
+	notFound := common.NewApiError(http.StatusNotFound, fmt.Errorf("%s %s not found", kind, id))
+	if canRead, err := t.authzHandler.HasAccessTo(ctx, obj, ReadAction); !canRead || err != nil {
+		if err != nil {
+			return err
+		}
+		return notFound
+	}
+	canUpdate, err := t.authzHandler.HasAccessTo(ctx, obj, UpdateAction)
+	if err != nil {
+		return err
+	}
+	if !canUpdate {
+		return common.NewInfraError(http.StatusForbidden, fmt.Errorf("the user isn't allowed to update %s %s", kind, id))
+	}
+	return nil
+}
+
+func (t *AgentTokensAPI) V2RotateAgentTokens(ctx context.Context, params operations.V2RotateAgentTokensParams) middleware.Responder {
+	log := logutil.FromContext(ctx, t.log)
+	if err := t.checkAuthType(); err != nil {
+		return common.GenerateErrorResponder(err)
+	}
+
+	infraEnv, err := common.GetInfraEnvFromDB(t.db, params.InfraEnvID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.GenerateErrorResponder(common.NewApiError(http.StatusNotFound,
+				fmt.Errorf("infra-env %s not found", params.InfraEnvID)))
+		}
+		log.WithError(err).Errorf("failed to get infra-env %s", params.InfraEnvID)
+		return common.GenerateErrorResponder(err)
+	}
+	if err = t.checkAccess(ctx, "infra-env", infraEnv, params.InfraEnvID); err != nil {
+		return common.GenerateErrorResponder(err)
+	}
+
+	overlap := t.cfg.AgentTokenRotationOverlap
+	if params.RotateParams != nil && params.RotateParams.OverlapSeconds != nil {
+		overlap = time.Duration(*params.RotateParams.OverlapSeconds) * time.Second
+	}
+	now := time.Now()
+	overlapEndsAt := now.Add(overlap)
+
+	var hosts int
+	err = t.db.Transaction(func(tx *gorm.DB) error {
+		hosts, err = rotateAgentTokens(tx, infraEnv, now, overlapEndsAt, overlapEndsAt)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Errorf("failed to rotate the agent tokens of infra-env %s", params.InfraEnvID)
+		return common.GenerateErrorResponder(err)
+	}
+
+	log.Infof("Rotated the agent tokens of infra-env %s and of its %d hosts, the previous tokens expire at %s",
+		params.InfraEnvID, hosts, overlapEndsAt.Format(time.RFC3339))
+	eventgen.SendAgentTokensRotatedEvent(ctx, t.eventsHandler, params.InfraEnvID, overlapEndsAt.Format(time.RFC3339))
+	return operations.NewV2RotateAgentTokensOK().WithPayload(&models.AgentTokensRotation{
+		InfraEnvID:             params.InfraEnvID,
+		RotatedAt:              strfmt.DateTime(now),
+		PreviousTokensExpireAt: strfmt.DateTime(overlapEndsAt),
+		Hosts:                  int64(hosts),
+	})
+}
+
+// rotateAgentTokens rotates the agent tokens of the infra-env, the previous tokens of the infra-env
+// are accepted until infraEnvOverlapEndsAt and the previous tokens of its hosts until
+// hostsOverlapEndsAt, and issues a token to each of its hosts that weren't revoked. It returns the
+// number of hosts that got a token.
+func rotateAgentTokens(tx *gorm.DB, infraEnv *common.InfraEnv, now, infraEnvOverlapEndsAt, hostsOverlapEndsAt time.Time) (int, error) {
+	err := tx.Model(&common.InfraEnv{}).Where("id = ?", infraEnv.ID.String()).Updates(map[string]interface{}{
+		"agent_tokens_previous_rotated_at": infraEnv.AgentTokensRotatedAt,
+		"agent_tokens_rotated_at":          now,
+		"agent_tokens_overlap_ends_at":     infraEnvOverlapEndsAt,
+	}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Model(&common.AgentToken{}).
+		Where("infra_env_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", infraEnv.ID.String(), hostsOverlapEndsAt).
+		Update("expires_at", hostsOverlapEndsAt).Error
+	if err != nil {
+		return 0, err
+	}
+
+	// The revoked hosts don't get a new token
+	var hostIDs []strfmt.UUID
+	err = tx.Model(&common.Host{}).Where("infra_env_id = ?", infraEnv.ID.String()).
+		Where("id NOT IN (?)", tx.Model(&common.AgentToken{}).Select("host_id").Where("revoked_at IS NOT NULL")).
+		Pluck("id", &hostIDs).Error
+	if err != nil {
+		return 0, err
+	}
+	for _, hostID := range hostIDs {
+		token := common.AgentToken{
+			ID:         uuid.New().String(),
+			InfraEnvID: *infraEnv.ID,
+			HostID:     hostID,
+			CreatedAt:  now,
+		}
+		if err = tx.Create(&token).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(hostIDs), nil
+}
+
+func (t *AgentTokensAPI) V2RevokeHostAgentToken(ctx context.Context, params operations.V2RevokeHostAgentTokenParams) middleware.Responder {
+	log := logutil.FromContext(ctx, t.log)
+	if err := t.checkAuthType(); err != nil {
+		return common.GenerateErrorResponder(err)
+	}
+
+	host, err := common.GetHostFromDB(t.db, params.InfraEnvID.String(), params.HostID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.GenerateErrorResponder(common.NewApiError(http.StatusNotFound,
+				fmt.Errorf("host %s of infra-env %s not found", params.HostID, params.InfraEnvID)))
+		}
+		log.WithError(err).Errorf("failed to get host %s", params.HostID)
+		return common.GenerateErrorResponder(err)
+	}
+	if err = t.checkAccess(ctx, "host", host, params.HostID); err != nil {
+		return common.GenerateErrorResponder(err)
+	}
+
+	infraEnv, err := common.GetInfraEnvFromDB(t.db, params.InfraEnvID)
+	if err != nil {
+		log.WithError(err).Errorf("failed to get infra-env %s", params.InfraEnvID)
+		return common.GenerateErrorResponder(err)
+	}
+
+	now := time.Now()
+	overlapEndsAt := now.Add(t.cfg.AgentTokenRotationOverlap)
+	revoked := false
+	err = t.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&common.AgentToken{}).
+			Where("host_id = ? AND infra_env_id = ? AND revoked_at IS NULL", params.HostID.String(), params.InfraEnvID.String()).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			err = tx.Model(&common.AgentToken{}).Where("host_id = ? AND revoked_at IS NOT NULL", params.HostID.String()).Count(&count).Error
+			if err != nil || count > 0 {
+				return err
+			}
+			// The host was never issued a token of its own, the revoked token only marks it so the
+			// infra-env tokens are rejected for it
+			err = tx.Create(&common.AgentToken{
+				ID:         uuid.New().String(),
+				InfraEnvID: params.InfraEnvID,
+				HostID:     params.HostID,
+				CreatedAt:  now,
+				RevokedAt:  &now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		revoked = true
+		// The host holds the infra-env token of the discovery image, which would let it register
+		// again under another host ID, so it's rotated without overlap. The other hosts get a token
+		// of their own, their previous tokens are accepted until the end of the overlap.
+		_, err = rotateAgentTokens(tx, infraEnv, now, now, overlapEndsAt)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Errorf("failed to revoke the agent tokens of host %s", params.HostID)
+		return common.GenerateErrorResponder(err)
+	}
+	if !revoked {
+		return operations.NewV2RevokeHostAgentTokenNoContent()
+	}
+
+	log.Infof("Revoked the agent tokens of host %s of infra-env %s and rotated the agent tokens of the infra-env, the previous tokens of the infra-env expired and the ones of the hosts expire at %s",
+		params.HostID, params.InfraEnvID, overlapEndsAt.Format(time.RFC3339))
+	eventgen.SendAgentTokenRevokedEvent(ctx, t.eventsHandler, params.HostID, hostutil.GetHostnameForMsg(&host.Host),
+		params.InfraEnvID, host.ClusterID)
+	eventgen.SendAgentTokensRotatedEvent(ctx, t.eventsHandler, params.InfraEnvID, now.Format(time.RFC3339))
+	return operations.NewV2RevokeHostAgentTokenNoContent()
+}
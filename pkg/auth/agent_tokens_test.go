@@ -0,0 +1,296 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	eventgen "github.com/openshift/assisted-service/internal/common/events"
+	eventsapi "github.com/openshift/assisted-service/internal/events/api"
+	"github.com/openshift/assisted-service/internal/events/eventstest"
+	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/openshift/assisted-service/models"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/agent_tokens"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("agentTokenAccepted", func() {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d).Truncate(time.Second)
+		return &t
+	}
+
+	It("accepts all the tokens until the first rotation", func() {
+		Expect(agentTokenAccepted(&common.InfraEnv{}, nil, now)).To(BeTrue())
+		Expect(agentTokenAccepted(&common.InfraEnv{}, at(-time.Hour), now)).To(BeTrue())
+	})
+
+	It("accepts the tokens issued before the last rotation during its overlap", func() {
+		infraEnv := &common.InfraEnv{AgentTokensRotatedAt: at(-time.Minute), AgentTokensOverlapEndsAt: at(time.Minute)}
+		Expect(agentTokenAccepted(infraEnv, nil, now)).To(BeTrue())
+		Expect(agentTokenAccepted(infraEnv, at(-time.Hour), now)).To(BeTrue())
+		Expect(agentTokenAccepted(infraEnv, at(-time.Hour), now.Add(2*time.Minute))).To(BeFalse())
+		Expect(agentTokenAccepted(infraEnv, nil, now.Add(2*time.Minute))).To(BeFalse())
+		Expect(agentTokenAccepted(infraEnv, at(0), now.Add(2*time.Minute))).To(BeTrue())
+	})
+
+	It("rejects the tokens issued before the previous rotation", func() {
+		infraEnv := &common.InfraEnv{
+			AgentTokensPreviousRotatedAt: at(-time.Hour),
+			AgentTokensRotatedAt:         at(-time.Minute),
+			AgentTokensOverlapEndsAt:     at(time.Minute),
+		}
+		Expect(agentTokenAccepted(infraEnv, nil, now)).To(BeFalse())
+		Expect(agentTokenAccepted(infraEnv, at(-2*time.Hour), now)).To(BeFalse())
+		Expect(agentTokenAccepted(infraEnv, at(-30*time.Minute), now)).To(BeTrue())
+		Expect(agentTokenAccepted(infraEnv, at(-time.Minute), now)).To(BeTrue())
+	})
+
+	It("considers the tokens issued during the second of the rotation issued after it", func() {
+		rotatedAt := now.Truncate(time.Second).Add(500 * time.Millisecond)
+		infraEnv := &common.InfraEnv{AgentTokensRotatedAt: &rotatedAt, AgentTokensOverlapEndsAt: &rotatedAt}
+		Expect(agentTokenAccepted(infraEnv, at(0), now.Add(time.Minute))).To(BeTrue())
+		Expect(agentTokenAccepted(infraEnv, at(-time.Second), now.Add(time.Minute))).To(BeFalse())
+	})
+})
+
+var _ = Describe("Agent tokens", func() {
+	var (
+		db           *gorm.DB
+		dbName       string
+		ctrl         *gomock.Controller
+		mockEvents   *eventsapi.MockSender
+		cfg          *Config
+		privKey      string
+		authzHandler *AgentTokenAuthzHandler
+		tokensAPI    *AgentTokensAPI
+		infraEnvID   strfmt.UUID
+		hostA        strfmt.UUID
+		hostB        strfmt.UUID
+	)
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		ctrl = gomock.NewController(GinkgoT())
+		mockEvents = eventsapi.NewMockSender(ctrl)
+		var pubKey string
+		var err error
+		pubKey, privKey, err = gencrypto.ECDSAKeyPairPEM()
+		Expect(err).ToNot(HaveOccurred())
+		cfg = &Config{AuthType: TypeLocal, ECPublicKeyPEM: pubKey, AgentTokenRotationOverlap: time.Hour}
+		log := logrus.New()
+		authzHandler = NewAgentTokenAuthzHandler(NewAuthzHandler(cfg, nil, log, db), log, db)
+		tokensAPI = NewAgentTokensAPI(cfg, authzHandler, mockEvents, log, db)
+
+		infraEnvID = strfmt.UUID(uuid.New().String())
+		hostA = strfmt.UUID(uuid.New().String())
+		hostB = strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.InfraEnv{InfraEnv: models.InfraEnv{ID: &infraEnvID}}).Error).ToNot(HaveOccurred())
+		for _, id := range []strfmt.UUID{hostA, hostB} {
+			hostID := id
+			host := common.Host{Host: models.Host{ID: &hostID, InfraEnvID: infraEnvID, Status: swag.String(models.HostStatusKnownUnbound)}}
+			Expect(db.Create(&host).Error).ToNot(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	// Each check uses a new authenticator, so nothing is cached
+	authenticate := func(token string) (*ocm.AuthPayload, error) {
+		a, err := NewLocalAuthenticator(cfg, logrus.New(), db)
+		Expect(err).ToNot(HaveOccurred())
+		payload, err := a.AuthAgentAuth(token)
+		if err != nil {
+			return nil, err
+		}
+		return payload.(*ocm.AuthPayload), nil
+	}
+
+	legacyToken := func() string {
+		priv, err := jwt.ParseECPrivateKeyFromPEM([]byte(privKey))
+		Expect(err).ToNot(HaveOccurred())
+		token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{"infra_env_id": infraEnvID.String()}).SignedString(priv)
+		Expect(err).ToNot(HaveOccurred())
+		return token
+	}
+
+	hostToken := func(hostID strfmt.UUID) string {
+		var token common.AgentToken
+		Expect(db.Order("created_at DESC").Take(&token, "host_id = ?", hostID.String()).Error).ToNot(HaveOccurred())
+		signed, err := gencrypto.LocalHostJWTForKey(infraEnvID.String(), hostID.String(), token.ID, privKey)
+		Expect(err).ToNot(HaveOccurred())
+		return signed
+	}
+
+	rotate := func(overlapSeconds *int64) *models.AgentTokensRotation {
+		mockEvents.EXPECT().SendInfraEnvEvent(gomock.Any(), eventstest.NewEventMatcher(
+			eventstest.WithNameMatcher(eventgen.AgentTokensRotatedEventName),
+			eventstest.WithInfraEnvIdMatcher(infraEnvID.String()))).Times(1)
+		response := tokensAPI.V2RotateAgentTokens(context.Background(), operations.V2RotateAgentTokensParams{
+			InfraEnvID:   infraEnvID,
+			RotateParams: &models.AgentTokensRotateParams{OverlapSeconds: overlapSeconds},
+		})
+		Expect(response).To(BeAssignableToTypeOf(operations.NewV2RotateAgentTokensOK()))
+		return response.(*operations.V2RotateAgentTokensOK).Payload
+	}
+
+	revokeAgain := func(hostID strfmt.UUID) {
+		response := tokensAPI.V2RevokeHostAgentToken(context.Background(), operations.V2RevokeHostAgentTokenParams{
+			InfraEnvID: infraEnvID,
+			HostID:     hostID,
+		})
+		Expect(response).To(BeAssignableToTypeOf(operations.NewV2RevokeHostAgentTokenNoContent()))
+	}
+
+	revoke := func(hostID strfmt.UUID) {
+		mockEvents.EXPECT().SendHostEvent(gomock.Any(), eventstest.NewEventMatcher(
+			eventstest.WithNameMatcher(eventgen.AgentTokenRevokedEventName),
+			eventstest.WithHostIdMatcher(hostID.String()))).Times(1)
+		mockEvents.EXPECT().SendInfraEnvEvent(gomock.Any(), eventstest.NewEventMatcher(
+			eventstest.WithNameMatcher(eventgen.AgentTokensRotatedEventName),
+			eventstest.WithInfraEnvIdMatcher(infraEnvID.String()))).Times(1)
+		revokeAgain(hostID)
+	}
+
+	It("keeps accepting the previous tokens during the overlap of the rotation", func() {
+		token := legacyToken()
+		rotation := rotate(nil)
+		Expect(rotation.Hosts).To(BeEquivalentTo(2))
+		Expect(time.Time(rotation.PreviousTokensExpireAt)).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+
+		payload, err := authenticate(token)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.AgentToken).To(Equal(&ocm.AgentTokenScope{InfraEnvID: infraEnvID.String()}))
+	})
+
+	It("rejects the previous tokens after the overlap of the rotation", func() {
+		token := legacyToken()
+		rotate(swag.Int64(0))
+		_, err := authenticate(token)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.InfraErrorResponse).StatusCode()).To(Equal(int32(http.StatusUnauthorized)))
+	})
+
+	It("issues a token to each host and expires its previous one with the overlap", func() {
+		rotate(nil)
+		first := hostToken(hostA)
+		payload, err := authenticate(first)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.AgentToken.HostID).To(Equal(hostA.String()))
+		Expect(payload.AgentToken.ID).ToNot(BeEmpty())
+
+		rotate(swag.Int64(0))
+		_, err = authenticate(first)
+		Expect(err).To(HaveOccurred())
+		_, err = authenticate(hostToken(hostA))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("revokes the tokens of a single host", func() {
+		rotate(nil)
+		revoke(hostA)
+
+		_, err := authenticate(hostToken(hostA))
+		Expect(err).To(HaveOccurred())
+		_, err = authenticate(hostToken(hostB))
+		Expect(err).ToNot(HaveOccurred())
+
+		// The revoked host doesn't get a new token
+		Expect(rotate(nil).Hosts).To(BeEquivalentTo(1))
+	})
+
+	It("rejects the infra-env tokens on the routes of the revoked hosts", func() {
+		revoke(hostA)
+		var count int64
+		Expect(db.Model(&common.AgentToken{}).Where("host_id = ?", hostA.String()).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(BeEquivalentTo(1))
+		revokeAgain(hostA)
+		Expect(db.Model(&common.AgentToken{}).Where("host_id = ?", hostA.String()).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(BeEquivalentTo(1))
+
+		payload, err := authenticate(legacyToken())
+		Expect(err).ToNot(HaveOccurred())
+		request := func(hostID strfmt.UUID) *http.Request {
+			ctx := context.WithValue(context.Background(), restapi.AuthKey, payload)
+			ctx = params.SetParam(ctx, params.InfraEnvId, infraEnvID.String())
+			ctx = params.SetParam(ctx, params.HostId, hostID.String())
+			return httptest.NewRequest(http.MethodGet, "/v2/infra-envs/"+infraEnvID.String()+"/hosts/"+hostID.String()+"/instructions", nil).
+				WithContext(ctx)
+		}
+		err = authzHandler.authorizeAgentToken(request(hostA))
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.InfraErrorResponse).StatusCode()).To(Equal(int32(http.StatusUnauthorized)))
+		Expect(authzHandler.authorizeAgentToken(request(hostB))).To(Succeed())
+	})
+
+	It("rotates the infra-env tokens held by the revoked host without overlap", func() {
+		token := legacyToken()
+		rotate(nil)
+		previous := hostToken(hostB)
+		revoke(hostA)
+		_, err := authenticate(token)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*common.InfraErrorResponse).StatusCode()).To(Equal(int32(http.StatusUnauthorized)))
+
+		// The other hosts got a new token, their previous one is accepted during the overlap
+		_, err = authenticate(hostToken(hostB))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = authenticate(previous)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("rejects the routes of the other infra-envs and hosts than the ones of the token", func() {
+		rotate(nil)
+		payload, err := authenticate(hostToken(hostA))
+		Expect(err).ToNot(HaveOccurred())
+		request := func(infraEnvID, hostID strfmt.UUID) *http.Request {
+			ctx := context.WithValue(context.Background(), restapi.AuthKey, payload)
+			ctx = params.SetParam(ctx, params.InfraEnvId, infraEnvID.String())
+			ctx = params.SetParam(ctx, params.HostId, hostID.String())
+			return httptest.NewRequest(http.MethodGet, "/v2/infra-envs/"+infraEnvID.String()+"/hosts/"+hostID.String()+"/instructions", nil).
+				WithContext(ctx)
+		}
+		expectForbidden := func(err error) {
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*common.InfraErrorResponse).StatusCode()).To(Equal(int32(http.StatusForbidden)))
+		}
+		Expect(authzHandler.authorizeAgentToken(request(infraEnvID, hostA))).To(Succeed())
+		expectForbidden(authzHandler.authorizeAgentToken(request(infraEnvID, hostB)))
+		expectForbidden(authzHandler.authorizeAgentToken(request(strfmt.UUID(uuid.New().String()), hostA)))
+
+		payload, err = authenticate(legacyToken())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authzHandler.authorizeAgentToken(request(infraEnvID, hostB))).To(Succeed())
+		expectForbidden(authzHandler.authorizeAgentToken(request(strfmt.UUID(uuid.New().String()), hostB)))
+	})
+
+	It("can't rotate the tokens of the other authentication types", func() {
+		cfg.AuthType = TypeRHSSO
+		response := tokensAPI.V2RotateAgentTokens(context.Background(), operations.V2RotateAgentTokensParams{InfraEnvID: infraEnvID})
+		Expect(response.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusBadRequest)))
+	})
+
+	It("doesn't find the unknown infra-envs", func() {
+		response := tokensAPI.V2RotateAgentTokens(context.Background(), operations.V2RotateAgentTokensParams{
+			InfraEnvID: strfmt.UUID(uuid.New().String()),
+		})
+		Expect(response.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusNotFound)))
+	})
+})
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/internal/common"
+	eventsapi "github.com/openshift/assisted-service/internal/events/api"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// APITokenPrefix distinguishes the API tokens from the tokens of the identity provider
+	APITokenPrefix = "ast_"
+	apiTokenBytes  = 32
+	// The last use of a token is only stored once per interval, to avoid a database write per request
+	apiTokenLastUsedInterval = time.Minute
+)
+
+// Names of the audit events of the API tokens
+const (
+	APITokenCreatedEventName = "api_token_created"
+	APITokenRevokedEventName = "api_token_revoked"
+	APITokenUsedEventName    = "api_token_used"
+)
+
+/* APITokenAuthenticator authenticates the users with the API tokens they
+ * created for their automation, and delegates the authentication of the
+ * other tokens to the authenticator of the configured authentication type.
+ * The requests made with an API token are made on behalf of the owner of
+ * the token, limited to the resources and the actions of the token.
+ */
+type APITokenAuthenticator struct {
+	Authenticator
+	eventsHandler eventsapi.Sender
+	log           logrus.FieldLogger
+	db            *gorm.DB
+}
+
+var _ Authenticator = &APITokenAuthenticator{}
+
+func NewAPITokenAuthenticator(authenticator Authenticator, eventsHandler eventsapi.Sender, log logrus.FieldLogger, db *gorm.DB) *APITokenAuthenticator {
+	return &APITokenAuthenticator{
+		Authenticator: authenticator,
+		eventsHandler: eventsHandler,
+		log:           log,
+		db:            db,
+	}
+}
+
+// GenerateAPIToken returns a new random API token and the hash stored in the database to authenticate it
+func GenerateAPIToken() (string, string, error) {
+	secret := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	token := APITokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return token, HashAPIToken(token), nil
+}
+
+// APITokensSupported returns whether the API tokens can be used with the authentication type. The
+// tokens act on behalf of the user that created them, so they require an authentication type that
+// authenticates users: local authentication rejects all the users and none authenticates nobody.
+func APITokensSupported(authType AuthType) bool {
+	return authType == TypeRHSSO || authType == TypeOIDC
+}
+
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiTokenFromHeader returns the API token of a Bearer authorization header
+func apiTokenFromHeader(header string) (string, bool) {
+	authHeaderParts := strings.Fields(header)
+	if len(authHeaderParts) != 2 || strings.ToLower(authHeaderParts[0]) != "bearer" {
+		return "", false
+	}
+	if !strings.HasPrefix(authHeaderParts[1], APITokenPrefix) {
+		return "", false
+	}
+	return authHeaderParts[1], true
+}
+
+func (a *APITokenAuthenticator) AuthUserAuth(token string) (interface{}, error) {
+	secret, ok := apiTokenFromHeader(token)
+	if !ok || !APITokensSupported(a.AuthType()) {
+		return a.Authenticator.AuthUserAuth(token)
+	}
+
+	var apiToken common.APIToken
+	if err := a.db.Take(&apiToken, "token_hash = ?", HashAPIToken(secret)).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			a.log.WithError(err).Error("failed to get API token")
+		}
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("Invalid API token"))
+	}
+
+	now := time.Now()
+	if apiToken.RevokedAt != nil {
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("API token %s was revoked", apiToken.ID))
+	}
+	if !time.Time(apiToken.ExpiresAt).After(now) {
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("API token %s expired", apiToken.ID))
+	}
+	a.recordUse(&apiToken.APIToken, now)
+
+	// The tokens never have the admin roles of their owners
+	return &ocm.AuthPayload{
+		Username:     apiToken.UserName,
+		Organization: apiToken.OrgID,
+		Role:         ocm.UserRole,
+		APIToken: &ocm.APITokenScope{
+			ID:          apiToken.ID.String(),
+			Name:        swag.StringValue(apiToken.Name),
+			ClusterIDs:  apiToken.ClusterIds,
+			InfraEnvIDs: apiToken.InfraEnvIds,
+			Actions:     apiToken.Actions,
+		},
+	}, nil
+}
+
+// recordUse updates the last use of the token, and raises an audit event whenever it is updated
+func (a *APITokenAuthenticator) recordUse(apiToken *models.APIToken, now time.Time) {
+	if apiToken.LastUsedAt != nil && now.Sub(time.Time(*apiToken.LastUsedAt)) < apiTokenLastUsedInterval {
+		return
+	}
+	// The condition makes sure that only one of the replicas raises the event
+	reply := a.db.Model(&common.APIToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", apiToken.ID, now.Add(-apiTokenLastUsedInterval)).
+		Update("last_used_at", now)
+	if reply.Error != nil {
+		a.log.WithError(reply.Error).Warnf("failed to update the last use of API token %s", apiToken.ID)
+		return
+	}
+	if reply.RowsAffected > 0 {
+		sendAPITokenEvent(context.Background(), a.eventsHandler, apiToken, APITokenUsedEventName,
+			fmt.Sprintf("API token %s of user %s was used", swag.StringValue(apiToken.Name), apiToken.UserName))
+	}
+}
+
+// sendAPITokenEvent raises the event on the clusters and infra-envs of the token, so their owners can
+// audit the token, or as a service event when the token isn't limited to specific resources
+func sendAPITokenEvent(ctx context.Context, eventsHandler eventsapi.Sender, apiToken *models.APIToken, name, message string) {
+	if eventsHandler == nil {
+		return
+	}
+	now := time.Now()
+	props := []interface{}{"api_token_id", apiToken.ID.String(), "api_token_name", swag.StringValue(apiToken.Name), "user_name", apiToken.UserName}
+	if len(apiToken.ClusterIds) == 0 && len(apiToken.InfraEnvIds) == 0 {
+		eventsHandler.V2AddEvent(ctx, nil, nil, nil, name, models.EventSeverityInfo, message, now, props...)
+		return
+	}
+	for _, id := range apiToken.ClusterIds {
+		clusterID := strfmt.UUID(id)
+		eventsHandler.V2AddEvent(ctx, &clusterID, nil, nil, name, models.EventSeverityInfo, message, now, props...)
+	}
+	for _, id := range apiToken.InfraEnvIds {
+		infraEnvID := strfmt.UUID(id)
+		eventsHandler.V2AddEvent(ctx, nil, nil, &infraEnvID, name, models.EventSeverityInfo, message, now, props...)
+	}
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/openshift/assisted-service/internal/common"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
+)
+
+// The API tokens can't be used to grant access to other users or to create other tokens
+var apiTokenForbiddenPaths = []string{"/v2/api-tokens", "/v2/role-bindings"}
+
+/* APITokenAuthzHandler limits the requests made with API tokens to the
+ * resources and the actions of the token, on top of the access policy of
+ * the authorizer of the configured authentication type. The requests that
+ * weren't made with an API token are only checked by that authorizer.
+ */
+type APITokenAuthzHandler struct {
+	Authorizer
+	log logrus.FieldLogger
+	db  *gorm.DB
+}
+
+var _ Authorizer = &APITokenAuthzHandler{}
+
+func NewAPITokenAuthzHandler(authorizer Authorizer, log logrus.FieldLogger, db *gorm.DB) *APITokenAuthzHandler {
+	return &APITokenAuthzHandler{
+		Authorizer: authorizer,
+		log:        log,
+		db:         db,
+	}
+}
+
+func isScoped(token *ocm.APITokenScope) bool {
+	return len(token.ClusterIDs) > 0 || len(token.InfraEnvIDs) > 0
+}
+
+func (a *APITokenAuthzHandler) CreateAuthorizer() func(*http.Request) error {
+	authorize := a.Authorizer.CreateAuthorizer()
+	return func(request *http.Request) error {
+		if err := a.authorizeAPIToken(request); err != nil {
+			return err
+		}
+		return authorize(request)
+	}
+}
+
+func (a *APITokenAuthzHandler) authorizeAPIToken(request *http.Request) error {
+	token := ocm.APITokenFromContext(request.Context())
+	if token == nil {
+		return nil
+	}
+
+	for _, path := range apiTokenForbiddenPaths {
+		if strings.Contains(request.URL.Path, path) {
+			return common.NewInfraError(http.StatusForbidden,
+				fmt.Errorf("API token %s: Unauthorized to access route (not allowed with API tokens)", token.Name))
+		}
+	}
+
+	action := toAction(request)
+	if !funk.ContainsString(token.Actions, string(action)) {
+		return common.NewInfraError(http.StatusForbidden,
+			fmt.Errorf("API token %s: Unauthorized to %s resources", token.Name, action))
+	}
+
+	var obj interface{}
+	if clusterID := params.GetParam(request.Context(), params.ClusterId); clusterID != "" {
+		obj = clusterFromID(clusterID)
+	} else if infraEnvID := params.GetParam(request.Context(), params.InfraEnvId); infraEnvID != "" {
+		obj = infraEnvFromID(infraEnvID)
+	} else {
+		// The lists are limited to the resources of the token, but new resources can't be added to it
+		if isScoped(token) && action != ReadAction {
+			return common.NewInfraError(http.StatusForbidden,
+				fmt.Errorf("API token %s: Unauthorized to create resources outside of its clusters and infra-envs", token.Name))
+		}
+		return nil
+	}
+
+	inScope, err := a.inScope(token, obj)
+	if err != nil {
+		a.log.Errorf("Failed to verify the scope of API token %s. Error %v", token.ID, err)
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+	if !inScope {
+		return common.NewApiError(http.StatusNotFound, fmt.Errorf("Object Not Found"))
+	}
+	return nil
+}
+
+// inScope checks that the object is one of the resources of the token, the infra-envs and
+// the hosts bound to the clusters of the token are also part of its resources
+func (a *APITokenAuthzHandler) inScope(token *ocm.APITokenScope, obj interface{}) (bool, error) {
+	if !isScoped(token) {
+		return true, nil
+	}
+	if cluster, ok := obj.(*common.Cluster); ok && cluster != nil {
+		return funk.ContainsString(token.ClusterIDs, cluster.ID.String()), nil
+	}
+	if infraEnv, ok := obj.(*common.InfraEnv); ok && infraEnv != nil {
+		if funk.ContainsString(token.InfraEnvIDs, infraEnv.ID.String()) {
+			return true, nil
+		}
+		if len(token.ClusterIDs) == 0 {
+			return false, nil
+		}
+		var boundInfraEnv common.InfraEnv
+		err := a.db.Select("cluster_id").First(&boundInfraEnv, "id = ?", infraEnv.ID.String()).Error
+		if err != nil {
+			return handleOwnershipQueryError(err)
+		}
+		return funk.ContainsString(token.ClusterIDs, boundInfraEnv.ClusterID.String()), nil
+	}
+	if host, ok := obj.(*common.Host); ok && host != nil {
+		if host.ClusterID != nil && funk.ContainsString(token.ClusterIDs, host.ClusterID.String()) {
+			return true, nil
+		}
+		return a.inScope(token, infraEnvFromID(host.InfraEnvID.String()))
+	}
+	return false, errors.New("can not perform scope check on this object")
+}
+
+func (a *APITokenAuthzHandler) limitToScope(ctx context.Context, db *gorm.DB) *gorm.DB {
+	token := ocm.APITokenFromContext(ctx)
+	if token == nil || !isScoped(token) {
+		return db
+	}
+	return db.Scopes(func(tx *gorm.DB) *gorm.DB {
+		switch queriedType(tx) {
+		case reflect.TypeOf(common.Cluster{}):
+			return tx.Where("id IN (?)", token.ClusterIDs)
+		case reflect.TypeOf(common.InfraEnv{}):
+			return tx.Where("id IN (?) OR cluster_id IN (?)", token.InfraEnvIDs, token.ClusterIDs)
+		default:
+			return tx.Where("cluster_id IN (?) OR infra_env_id IN (?)", token.ClusterIDs, token.InfraEnvIDs)
+		}
+	})
+}
+
+func (a *APITokenAuthzHandler) OwnedBy(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return a.limitToScope(ctx, a.Authorizer.OwnedBy(ctx, db))
+}
+
+func (a *APITokenAuthzHandler) OwnedByUser(ctx context.Context, db *gorm.DB, username string) *gorm.DB {
+	return a.limitToScope(ctx, a.Authorizer.OwnedByUser(ctx, db, username))
+}
+
+func (a *APITokenAuthzHandler) HasAccessTo(ctx context.Context, obj interface{}, action Action) (bool, error) {
+	if token := ocm.APITokenFromContext(ctx); token != nil {
+		if !funk.ContainsString(token.Actions, string(action)) {
+			return false, nil
+		}
+		if inScope, err := a.inScope(token, obj); !inScope || err != nil {
+			return false, err
+		}
+	}
+	return a.Authorizer.HasAccessTo(ctx, obj, action)
+}
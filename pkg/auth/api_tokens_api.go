@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	"github.com/openshift/assisted-service/internal/common"
+	eventsapi "github.com/openshift/assisted-service/internal/events/api"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/tokens"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
+)
+
+var _ restapi.TokensAPI = &APITokensAPI{}
+
+// APITokensAPI manages the API tokens the users create for their automation
+type APITokensAPI struct {
+	cfg           *Config
+	authzHandler  Authorizer
+	eventsHandler eventsapi.Sender
+	log           logrus.FieldLogger
+	db            *gorm.DB
+}
+
+func NewAPITokensAPI(cfg *Config, authzHandler Authorizer, eventsHandler eventsapi.Sender, log logrus.FieldLogger, db *gorm.DB) *APITokensAPI {
+	return &APITokensAPI{
+		cfg:           cfg,
+		authzHandler:  authzHandler,
+		eventsHandler: eventsHandler,
+		log:           log,
+		db:            db,
+	}
+}
+
+func (t *APITokensAPI) V2ListAPITokens(ctx context.Context, params operations.V2ListAPITokensParams) middleware.Responder {
+	log := logutil.FromContext(ctx, t.log)
+
+	query := t.db.Order("created_at")
+	if !t.authzHandler.IsAdmin(ctx) {
+		query = query.Where("user_name = ?", ocm.UserNameFromContext(ctx))
+	}
+	var apiTokens []*common.APIToken
+	if err := query.Find(&apiTokens).Error; err != nil {
+		log.WithError(err).Error("failed to list API tokens")
+		return common.GenerateErrorResponder(err)
+	}
+	payload := make(models.APITokens, 0, len(apiTokens))
+	for _, apiToken := range apiTokens {
+		payload = append(payload, &apiToken.APIToken)
+	}
+	return operations.NewV2ListAPITokensOK().WithPayload(payload)
+}
+
+func (t *APITokensAPI) checkAuthType() error {
+	if !APITokensSupported(t.cfg.AuthType) {
+		return common.NewApiError(http.StatusBadRequest,
+			fmt.Errorf("API tokens can't be created with the %s authentication, they require an authentication of the users, %s or %s",
+				t.cfg.AuthType, TypeRHSSO, TypeOIDC))
+	}
+	return nil
+}
+
+// checkResourceAccess checks that the user can perform the actions of the token on the resource
+func (t *APITokensAPI) checkResourceAccess(ctx context.Context, kind string, model, obj interface{}, id strfmt.UUID, actions []string) error {
+	notFound := common.NewApiError(http.StatusNotFound, fmt.Errorf("%s %s not found", kind, id))
+	var count int64
+	if err := t.db.Model(model).Where("id = ?", id.String()).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return notFound
+	}
+	if canRead, err := t.authzHandler.HasAccessTo(ctx, obj, ReadAction); !canRead || err != nil {
+		if err != nil {
+			return err
+		}
+		return notFound
+	}
+	for _, action := range actions {
+		isAllowed, err := t.authzHandler.HasAccessTo(ctx, obj, Action(action))
+		if err != nil {
+			return err
+		}
+		if !isAllowed {
+			return common.NewInfraError(http.StatusForbidden,
+				fmt.Errorf("the API token can't be allowed to %s %s %s, the user isn't allowed to", action, kind, id))
+		}
+	}
+	return nil
+}
+
+func (t *APITokensAPI) V2CreateAPIToken(ctx context.Context, params operations.V2CreateAPITokenParams) middleware.Responder {
+	log := logutil.FromContext(ctx, t.log)
+	newParams := params.NewAPITokenParams
+
+	if err := t.checkAuthType(); err != nil {
+		return common.GenerateErrorResponder(err)
+	}
+	name := strings.TrimSpace(swag.StringValue(newParams.Name))
+	if name == "" {
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusBadRequest,
+			errors.New("the name of an API token must not be empty")))
+	}
+	actions := funk.UniqString(newParams.Actions)
+	if len(actions) == 0 {
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusBadRequest,
+			errors.New("an API token must be allowed to perform at least one action")))
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(t.cfg.APITokenDefaultLifetime)
+	if !time.Time(newParams.ExpiresAt).IsZero() {
+		expiresAt = time.Time(newParams.ExpiresAt)
+	}
+	if !expiresAt.After(now) {
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusBadRequest,
+			errors.New("the expiration time of an API token must be in the future")))
+	}
+	if t.cfg.APITokenMaxLifetime > 0 && expiresAt.After(now.Add(t.cfg.APITokenMaxLifetime)) {
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusBadRequest,
+			fmt.Errorf("API tokens can't be valid for more than %s", t.cfg.APITokenMaxLifetime)))
+	}
+
+	clusterIDs := make([]string, 0, len(newParams.ClusterIds))
+	for _, id := range newParams.ClusterIds {
+		if err := t.checkResourceAccess(ctx, "cluster", &common.Cluster{}, clusterFromID(id.String()), id, actions); err != nil {
+			log.WithError(err).Infof("API token %s can't access cluster %s", name, id)
+			return common.GenerateErrorResponder(err)
+		}
+		clusterIDs = append(clusterIDs, id.String())
+	}
+	infraEnvIDs := make([]string, 0, len(newParams.InfraEnvIds))
+	for _, id := range newParams.InfraEnvIds {
+		if err := t.checkResourceAccess(ctx, "infra-env", &common.InfraEnv{}, infraEnvFromID(id.String()), id, actions); err != nil {
+			log.WithError(err).Infof("API token %s can't access infra-env %s", name, id)
+			return common.GenerateErrorResponder(err)
+		}
+		infraEnvIDs = append(infraEnvIDs, id.String())
+	}
+
+	token, tokenHash, err := GenerateAPIToken()
+	if err != nil {
+		log.WithError(err).Error("failed to generate API token")
+		return common.GenerateErrorResponder(err)
+	}
+	apiToken := common.APIToken{
+		APIToken: models.APIToken{
+			ID:          strfmt.UUID(uuid.New().String()),
+			Name:        swag.String(name),
+			UserName:    ocm.UserNameFromContext(ctx),
+			OrgID:       ocm.OrgIDFromContext(ctx),
+			ClusterIds:  funk.UniqString(clusterIDs),
+			InfraEnvIds: funk.UniqString(infraEnvIDs),
+			Actions:     actions,
+			ExpiresAt:   strfmt.DateTime(expiresAt),
+			CreatedAt:   strfmt.DateTime(now),
+		},
+		TokenHash: tokenHash,
+	}
+	if err = t.db.Create(&apiToken).Error; err != nil {
+		log.WithError(err).Error("failed to create API token")
+		return common.GenerateErrorResponder(err)
+	}
+	log.Infof("User %s created API token %s %s", apiToken.UserName, apiToken.ID, name)
+	sendAPITokenEvent(ctx, t.eventsHandler, &apiToken.APIToken, APITokenCreatedEventName,
+		fmt.Sprintf("User %s created API token %s allowed to %s", apiToken.UserName, name, strings.Join(actions, ", ")))
+
+	// The secret of the token is only returned once, only its hash is stored
+	payload := apiToken.APIToken
+	payload.Token = token
+	return operations.NewV2CreateAPITokenCreated().WithPayload(&payload)
+}
+
+func (t *APITokensAPI) V2RevokeAPIToken(ctx context.Context, params operations.V2RevokeAPITokenParams) middleware.Responder {
+	log := logutil.FromContext(ctx, t.log)
+
+	var apiToken common.APIToken
+	if err := t.db.Take(&apiToken, "id = ?", params.APITokenID.String()).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.GenerateErrorResponder(common.NewApiError(http.StatusNotFound,
+				fmt.Errorf("API token %s not found", params.APITokenID)))
+		}
+		log.WithError(err).Errorf("failed to get API token %s", params.APITokenID)
+		return common.GenerateErrorResponder(err)
+	}
+
+	payload := ocm.PayloadFromContext(ctx)
+	if apiToken.UserName != payload.Username && payload.Role != ocm.AdminRole {
+		if t.authzHandler.IsAdmin(ctx) {
+			return common.GenerateErrorResponder(common.NewInfraError(http.StatusForbidden,
+				fmt.Errorf("only the owner of API token %s and the admins can revoke it", params.APITokenID)))
+		}
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusNotFound,
+			fmt.Errorf("API token %s not found", params.APITokenID)))
+	}
+	if apiToken.RevokedAt != nil {
+		return operations.NewV2RevokeAPITokenNoContent()
+	}
+
+	revokedAt := strfmt.DateTime(time.Now())
+	err := t.db.Model(&common.APIToken{}).Where("id = ?", params.APITokenID.String()).Update("revoked_at", revokedAt).Error
+	if err != nil {
+		log.WithError(err).Errorf("failed to revoke API token %s", params.APITokenID)
+		return common.GenerateErrorResponder(err)
+	}
+	log.Infof("User %s revoked API token %s of user %s", payload.Username, apiToken.ID, apiToken.UserName)
+	sendAPITokenEvent(ctx, t.eventsHandler, &apiToken.APIToken, APITokenRevokedEventName,
+		fmt.Sprintf("User %s revoked API token %s of user %s", payload.Username, swag.StringValue(apiToken.Name), apiToken.UserName))
+	return operations.NewV2RevokeAPITokenNoContent()
+}
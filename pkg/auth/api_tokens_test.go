@@ -0,0 +1,314 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	eventsapi "github.com/openshift/assisted-service/internal/events/api"
+	"github.com/openshift/assisted-service/models"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/tokens"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// oidcTestAuthenticator authenticates every request like the none authenticator, with the
+// authentication type of an authenticator of users
+type oidcTestAuthenticator struct {
+	*NoneAuthenticator
+}
+
+func (a *oidcTestAuthenticator) AuthType() AuthType {
+	return TypeOIDC
+}
+
+var _ = Describe("API tokens", func() {
+	var (
+		db            *gorm.DB
+		dbName        string
+		ctrl          *gomock.Controller
+		mockEvents    *eventsapi.MockSender
+		cfg           *Config
+		authzHandler  *APITokenAuthzHandler
+		authenticator *APITokenAuthenticator
+		tokensAPI     *APITokensAPI
+		clusterA      strfmt.UUID
+		clusterB      strfmt.UUID
+		clusterC      strfmt.UUID
+		infraEnvA     strfmt.UUID
+	)
+
+	userContext := func(username string) context.Context {
+		payload := &ocm.AuthPayload{Username: username, Organization: "acme", Role: ocm.UserRole}
+		return context.WithValue(context.Background(), restapi.AuthKey, payload)
+	}
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		ctrl = gomock.NewController(GinkgoT())
+		mockEvents = eventsapi.NewMockSender(ctrl)
+		cfg = &Config{
+			AuthType:                TypeOIDC,
+			APITokenDefaultLifetime: 24 * time.Hour,
+			APITokenMaxLifetime:     7 * 24 * time.Hour,
+		}
+		log := logrus.New()
+		authzHandler = NewAPITokenAuthzHandler(NewAuthzHandler(cfg, nil, log, db), log, db)
+		authenticator = NewAPITokenAuthenticator(&oidcTestAuthenticator{NewNoneAuthenticator(log)}, mockEvents, log, db)
+		tokensAPI = NewAPITokensAPI(cfg, authzHandler, mockEvents, log, db)
+
+		clusterA = strfmt.UUID(uuid.New().String())
+		clusterB = strfmt.UUID(uuid.New().String())
+		clusterC = strfmt.UUID(uuid.New().String())
+		infraEnvA = strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterA, UserName: "alice"}}).Error).ToNot(HaveOccurred())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterB, UserName: "bob"}}).Error).ToNot(HaveOccurred())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterC, UserName: "alice"}}).Error).ToNot(HaveOccurred())
+		Expect(db.Create(&common.InfraEnv{InfraEnv: models.InfraEnv{ID: &infraEnvA, UserName: "alice", ClusterID: clusterA}}).Error).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	expectEvent := func(name string, clusterID *strfmt.UUID) {
+		mockEvents.EXPECT().V2AddEvent(gomock.Any(), clusterID, nil, nil, name, models.EventSeverityInfo,
+			gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	}
+
+	createToken := func(ctx context.Context, newParams *models.APITokenCreateParams) *models.APIToken {
+		response := tokensAPI.V2CreateAPIToken(ctx, operations.V2CreateAPITokenParams{NewAPITokenParams: newParams})
+		Expect(response).To(BeAssignableToTypeOf(operations.NewV2CreateAPITokenCreated()))
+		return response.(*operations.V2CreateAPITokenCreated).Payload
+	}
+
+	readToken := func(clusterIDs ...strfmt.UUID) *models.APIToken {
+		for _, id := range clusterIDs {
+			expectEvent(APITokenCreatedEventName, &id)
+		}
+		return createToken(userContext("alice"), &models.APITokenCreateParams{
+			Name:       swag.String("ci"),
+			ClusterIds: clusterIDs,
+			Actions:    []string{string(ReadAction)},
+		})
+	}
+
+	authenticate := func(token string) (context.Context, error) {
+		payload, err := authenticator.AuthUserAuth("Bearer " + token)
+		if err != nil {
+			return nil, err
+		}
+		return context.WithValue(context.Background(), restapi.AuthKey, payload), nil
+	}
+
+	expectUnauthorized := func(err error) {
+		Expect(err).To(HaveOccurred())
+		infraError, ok := err.(*common.InfraErrorResponse)
+		Expect(ok).To(BeTrue())
+		Expect(infraError.StatusCode()).To(Equal(int32(http.StatusUnauthorized)))
+	}
+
+	Context("authentication", func() {
+		It("authenticates the owner of the token", func() {
+			apiToken := readToken(clusterA)
+			Expect(apiToken.Token).To(HavePrefix(APITokenPrefix))
+			Expect(time.Time(apiToken.ExpiresAt)).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+
+			expectEvent(APITokenUsedEventName, &clusterA)
+			ctx, err := authenticate(apiToken.Token)
+			Expect(err).ToNot(HaveOccurred())
+			payload := ocm.PayloadFromContext(ctx)
+			Expect(payload.Username).To(Equal("alice"))
+			Expect(payload.Organization).To(Equal("acme"))
+			Expect(payload.Role).To(Equal(ocm.UserRole))
+			Expect(payload.APIToken.ID).To(Equal(apiToken.ID.String()))
+			Expect(payload.APIToken.ClusterIDs).To(ConsistOf(clusterA.String()))
+		})
+
+		It("only stores the hash of the token", func() {
+			apiToken := readToken(clusterA)
+			var stored common.APIToken
+			Expect(db.Take(&stored, "id = ?", apiToken.ID.String()).Error).ToNot(HaveOccurred())
+			Expect(stored.TokenHash).To(Equal(HashAPIToken(apiToken.Token)))
+			Expect(stored.Token).To(BeEmpty())
+		})
+
+		It("records the last use of the token at most once a minute", func() {
+			apiToken := readToken(clusterA)
+			expectEvent(APITokenUsedEventName, &clusterA)
+			_, err := authenticate(apiToken.Token)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = authenticate(apiToken.Token)
+			Expect(err).ToNot(HaveOccurred())
+
+			var stored common.APIToken
+			Expect(db.Take(&stored, "id = ?", apiToken.ID.String()).Error).ToNot(HaveOccurred())
+			Expect(stored.LastUsedAt).ToNot(BeNil())
+			Expect(time.Time(*stored.LastUsedAt)).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("rejects unknown tokens", func() {
+			_, err := authenticate(APITokenPrefix + "unknown")
+			expectUnauthorized(err)
+		})
+
+		It("rejects expired tokens", func() {
+			apiToken := readToken(clusterA)
+			Expect(db.Model(&common.APIToken{}).Where("id = ?", apiToken.ID.String()).
+				Update("expires_at", time.Now().Add(-time.Minute)).Error).ToNot(HaveOccurred())
+			_, err := authenticate(apiToken.Token)
+			expectUnauthorized(err)
+		})
+
+		It("delegates the other tokens to the authenticator of the authentication type", func() {
+			payload, err := authenticator.AuthUserAuth("Bearer some.identity.token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload.(*ocm.AuthPayload).Role).To(Equal(ocm.AdminRole))
+		})
+
+		It("doesn't accept the tokens with an authentication type that doesn't authenticate users", func() {
+			apiToken := readToken(clusterA)
+			log := logrus.New()
+			authenticator = NewAPITokenAuthenticator(NewNoneAuthenticator(log), mockEvents, log, db)
+			payload, err := authenticator.AuthUserAuth("Bearer " + apiToken.Token)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload.(*ocm.AuthPayload).APIToken).To(BeNil())
+		})
+	})
+
+	Context("authorization", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			apiToken := readToken(clusterA)
+			expectEvent(APITokenUsedEventName, &clusterA)
+			var err error
+			ctx, err = authenticate(apiToken.Token)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		hasAccess := func(obj interface{}, action Action) bool {
+			allowed, err := authzHandler.HasAccessTo(ctx, obj, action)
+			Expect(err).ToNot(HaveOccurred())
+			return allowed
+		}
+
+		It("limits the access to the resources and the actions of the token", func() {
+			Expect(hasAccess(clusterFromID(clusterA.String()), ReadAction)).To(BeTrue())
+			Expect(hasAccess(clusterFromID(clusterA.String()), UpdateAction)).To(BeFalse())
+			Expect(hasAccess(infraEnvFromID(infraEnvA.String()), ReadAction)).To(BeTrue())
+			Expect(hasAccess(clusterFromID(clusterC.String()), ReadAction)).To(BeFalse())
+			Expect(hasAccess(clusterFromID(clusterB.String()), ReadAction)).To(BeFalse())
+		})
+
+		It("limits the queries to the resources of the token", func() {
+			var clusters []*common.Cluster
+			Expect(authzHandler.OwnedBy(ctx, db).Find(&clusters).Error).ToNot(HaveOccurred())
+			Expect(clusters).To(HaveLen(1))
+			Expect(*clusters[0].ID).To(Equal(clusterA))
+		})
+
+		request := func(method, path string, param string, id strfmt.UUID) *http.Request {
+			requestCtx := ctx
+			if param != "" {
+				requestCtx = params.SetParam(ctx, param, id.String())
+			}
+			return httptest.NewRequest(method, path, nil).WithContext(requestCtx)
+		}
+
+		It("rejects the requests outside of the scope of the token", func() {
+			Expect(authzHandler.authorizeAPIToken(request(http.MethodGet, "/v2/clusters/"+clusterA.String(), params.ClusterId, clusterA))).To(Succeed())
+			Expect(authzHandler.authorizeAPIToken(request(http.MethodGet, "/v2/clusters", "", ""))).To(Succeed())
+
+			err := authzHandler.authorizeAPIToken(request(http.MethodGet, "/v2/clusters/"+clusterC.String(), params.ClusterId, clusterC))
+			Expect(err.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusNotFound)))
+			err = authzHandler.authorizeAPIToken(request(http.MethodPatch, "/v2/clusters/"+clusterA.String(), params.ClusterId, clusterA))
+			Expect(err.(*common.InfraErrorResponse).StatusCode()).To(Equal(int32(http.StatusForbidden)))
+			err = authzHandler.authorizeAPIToken(request(http.MethodGet, "/api/assisted-install/v2/api-tokens", "", ""))
+			Expect(err.(*common.InfraErrorResponse).StatusCode()).To(Equal(int32(http.StatusForbidden)))
+		})
+	})
+
+	Context("API", func() {
+		It("only creates tokens on the resources the user can access", func() {
+			response := tokensAPI.V2CreateAPIToken(userContext("alice"), operations.V2CreateAPITokenParams{
+				NewAPITokenParams: &models.APITokenCreateParams{
+					Name:       swag.String("ci"),
+					ClusterIds: []strfmt.UUID{clusterB},
+					Actions:    []string{string(ReadAction)},
+				}})
+			verifyApiError(response, http.StatusNotFound)
+		})
+
+		It("limits the lifetime of the tokens", func() {
+			response := tokensAPI.V2CreateAPIToken(userContext("alice"), operations.V2CreateAPITokenParams{
+				NewAPITokenParams: &models.APITokenCreateParams{
+					Name:      swag.String("ci"),
+					Actions:   []string{string(ReadAction)},
+					ExpiresAt: strfmt.DateTime(time.Now().Add(30 * 24 * time.Hour)),
+				}})
+			verifyApiError(response, http.StatusBadRequest)
+		})
+
+		It("raises a service event for the tokens that aren't limited to resources", func() {
+			expectEvent(APITokenCreatedEventName, nil)
+			apiToken := readToken()
+			Expect(apiToken.ClusterIds).To(BeEmpty())
+		})
+
+		for _, authType := range []AuthType{TypeLocal, TypeNone} {
+			authType := authType
+			It(fmt.Sprintf("refuses to create tokens with the %s authentication", authType), func() {
+				cfg.AuthType = authType
+				response := tokensAPI.V2CreateAPIToken(userContext("alice"), operations.V2CreateAPITokenParams{
+					NewAPITokenParams: &models.APITokenCreateParams{
+						Name:    swag.String("ci"),
+						Actions: []string{string(ReadAction)},
+					},
+				})
+				verifyApiError(response, http.StatusBadRequest)
+				Expect(response.(*common.ApiErrorResponse).Error()).To(ContainSubstring(string(authType)))
+			})
+		}
+
+		It("lists the tokens of the user without their secrets", func() {
+			readToken(clusterA)
+			response := tokensAPI.V2ListAPITokens(userContext("alice"), operations.V2ListAPITokensParams{})
+			Expect(response).To(BeAssignableToTypeOf(operations.NewV2ListAPITokensOK()))
+			apiTokens := response.(*operations.V2ListAPITokensOK).Payload
+			Expect(apiTokens).To(HaveLen(1))
+			Expect(apiTokens[0].Token).To(BeEmpty())
+
+			response = tokensAPI.V2ListAPITokens(userContext("bob"), operations.V2ListAPITokensParams{})
+			Expect(response.(*operations.V2ListAPITokensOK).Payload).To(BeEmpty())
+		})
+
+		It("revokes the tokens", func() {
+			apiToken := readToken(clusterA)
+			revokeParams := operations.V2RevokeAPITokenParams{APITokenID: apiToken.ID}
+
+			verifyApiError(tokensAPI.V2RevokeAPIToken(userContext("bob"), revokeParams), http.StatusNotFound)
+
+			expectEvent(APITokenRevokedEventName, &clusterA)
+			response := tokensAPI.V2RevokeAPIToken(userContext("alice"), revokeParams)
+			Expect(response).To(BeAssignableToTypeOf(operations.NewV2RevokeAPITokenNoContent()))
+
+			_, err := authenticate(apiToken.Token)
+			expectUnauthorized(err)
+			Expect(err.Error()).To(ContainSubstring("revoked"))
+		})
+	})
+})
@@ -13,9 +13,13 @@ import (
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/filemiddleware"
 	"github.com/openshift/assisted-service/restapi"
+	auditapi "github.com/openshift/assisted-service/restapi/operations/audit"
+	authzapi "github.com/openshift/assisted-service/restapi/operations/authz"
 	eventsapi "github.com/openshift/assisted-service/restapi/operations/events"
 	"github.com/openshift/assisted-service/restapi/operations/installer"
 	managed_domains_api "github.com/openshift/assisted-service/restapi/operations/managed_domains"
+	quotasapi "github.com/openshift/assisted-service/restapi/operations/quotas"
+	tokensapi "github.com/openshift/assisted-service/restapi/operations/tokens"
 	versionsapi "github.com/openshift/assisted-service/restapi/operations/versions"
 )
 
@@ -288,16 +292,68 @@ func (f fakeInventory) ListClusterHosts(ctx context.Context, params installer.Li
 
 var _ restapi.InstallerAPI = fakeInventory{}
 
+type fakeAuthzAPI struct{}
+
+func (f fakeAuthzAPI) V2ListRoleBindings(ctx context.Context, params authzapi.V2ListRoleBindingsParams) middleware.Responder {
+	return authzapi.NewV2ListRoleBindingsOK()
+}
+
+func (f fakeAuthzAPI) V2CreateRoleBinding(ctx context.Context, params authzapi.V2CreateRoleBindingParams) middleware.Responder {
+	return authzapi.NewV2CreateRoleBindingCreated()
+}
+
+func (f fakeAuthzAPI) V2DeleteRoleBinding(ctx context.Context, params authzapi.V2DeleteRoleBindingParams) middleware.Responder {
+	return authzapi.NewV2DeleteRoleBindingNoContent()
+}
+
+type fakeAuditAPI struct{}
+
+func (f fakeAuditAPI) V2ListAuditRecords(ctx context.Context, params auditapi.V2ListAuditRecordsParams) middleware.Responder {
+	return auditapi.NewV2ListAuditRecordsOK()
+}
+
+type fakeQuotasAPI struct{}
+
+func (f fakeQuotasAPI) V2ListQuotas(ctx context.Context, params quotasapi.V2ListQuotasParams) middleware.Responder {
+	return quotasapi.NewV2ListQuotasOK()
+}
+
+type fakeTokensAPI struct{}
+
+func (f fakeTokensAPI) V2ListAPITokens(ctx context.Context, params tokensapi.V2ListAPITokensParams) middleware.Responder {
+	return tokensapi.NewV2ListAPITokensOK()
+}
+
+func (f fakeTokensAPI) V2CreateAPIToken(ctx context.Context, params tokensapi.V2CreateAPITokenParams) middleware.Responder {
+	return tokensapi.NewV2CreateAPITokenCreated()
+}
+
+func (f fakeTokensAPI) V2RevokeAPIToken(ctx context.Context, params tokensapi.V2RevokeAPITokenParams) middleware.Responder {
+	return tokensapi.NewV2RevokeAPITokenNoContent()
+}
+
 type fakeEventsAPI struct{}
 
 func (f fakeEventsAPI) V2ListEvents(ctx context.Context, params eventsapi.V2ListEventsParams) middleware.Responder {
 	return eventsapi.NewV2ListEventsOK()
 }
 
+func (f fakeEventsAPI) V2AggregateEvents(ctx context.Context, params eventsapi.V2AggregateEventsParams) middleware.Responder {
+	return eventsapi.NewV2AggregateEventsOK()
+}
+
 func (f fakeEventsAPI) V2TriggerEvent(ctx context.Context, params eventsapi.V2TriggerEventParams) middleware.Responder {
 	return eventsapi.NewV2TriggerEventCreated()
 }
 
+func (f fakeEventsAPI) V2GetEventRateLimits(ctx context.Context, params eventsapi.V2GetEventRateLimitsParams) middleware.Responder {
+	return eventsapi.NewV2GetEventRateLimitsOK()
+}
+
+func (f fakeEventsAPI) V2UpdateEventRateLimits(ctx context.Context, params eventsapi.V2UpdateEventRateLimitsParams) middleware.Responder {
+	return eventsapi.NewV2UpdateEventRateLimitsOK()
+}
+
 type fakeVersionsAPI struct{}
 
 func (f fakeVersionsAPI) V2ListComponentVersions(
@@ -6,6 +6,7 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
 	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/pkg/ocm"
 )
 
 const (
@@ -27,6 +28,21 @@ func UserAuthHeaderWriter(token string) runtime.ClientAuthInfoWriter {
 	return AuthHeaderWriter(token, userAuthHeader)
 }
 
+// AgentAuth marks the payloads returned by the agents authentication, so that the calls of the
+// agents can be told from the calls of the users once they are authenticated. The payloads are
+// copied, the authenticators may cache them.
+func AgentAuth(authenticate func(token string) (interface{}, error)) func(token string) (interface{}, error) {
+	return func(token string) (interface{}, error) {
+		principal, err := authenticate(token)
+		if payload, ok := principal.(*ocm.AuthPayload); ok && err == nil {
+			agentPayload := *payload
+			agentPayload.AgentAuth = true
+			return &agentPayload, nil
+		}
+		return principal, err
+	}
+}
+
 func shouldStorePayloadInCache(err error) bool {
 	if err == nil {
 		return true
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/security"
@@ -18,6 +19,7 @@ const (
 	TypeRHSSO      AuthType = "rhsso"
 	TypeLocal      AuthType = "local"
 	TypeAgentLocal AuthType = "agent-installer-local"
+	TypeOIDC       AuthType = "oidc"
 )
 
 type Authenticator interface {
@@ -42,6 +44,28 @@ type Config struct {
 	AdminUsers                 []string `envconfig:"ADMIN_USERS" default:""`
 	EnableOrgTenancy           bool     `envconfig:"ENABLE_ORG_TENANCY" default:"false"`
 	EnableOrgBasedFeatureGates bool     `envconfig:"ENABLE_ORG_BASED_FEATURE_GATES" default:"false"`
+
+	// Lifetime of the API tokens created without an expiration time
+	APITokenDefaultLifetime time.Duration `envconfig:"API_TOKEN_DEFAULT_LIFETIME" default:"2160h"`
+	// Longest lifetime of the API tokens, not limited when 0
+	APITokenMaxLifetime time.Duration `envconfig:"API_TOKEN_MAX_LIFETIME" default:"8760h"`
+
+	// How long the local agent tokens issued before a rotation remain valid, unless the rotation
+	// sets its own overlap
+	AgentTokenRotationOverlap time.Duration `envconfig:"AGENT_TOKEN_ROTATION_OVERLAP" default:"1h"`
+
+	// The OIDC settings only apply to the oidc authentication type
+	OIDCIssuerURL string `envconfig:"OIDC_ISSUER_URL" default:""`
+	// Expected audience of the tokens, not checked when empty
+	OIDCClientID string `envconfig:"OIDC_CLIENT_ID" default:""`
+	// Overrides the JWKS URL found in the discovery document of the issuer
+	OIDCJwksURL string `envconfig:"OIDC_JWKS_URL" default:""`
+	// The claims may be nested, for example realm_access.roles
+	OIDCUsernameClaim       string   `envconfig:"OIDC_USERNAME_CLAIM" default:"preferred_username"`
+	OIDCGroupsClaim         string   `envconfig:"OIDC_GROUPS_CLAIM" default:"groups"`
+	OIDCTenantClaim         string   `envconfig:"OIDC_TENANT_CLAIM" default:""`
+	OIDCAdminGroups         []string `envconfig:"OIDC_ADMIN_GROUPS" default:""`
+	OIDCReadOnlyAdminGroups []string `envconfig:"OIDC_READ_ONLY_ADMIN_GROUPS" default:""`
 }
 
 func NewAuthenticator(cfg *Config, ocmClient *ocm.Client, log logrus.FieldLogger, db *gorm.DB) (a Authenticator, err error) {
@@ -54,6 +78,8 @@ func NewAuthenticator(cfg *Config, ocmClient *ocm.Client, log logrus.FieldLogger
 		a, err = NewLocalAuthenticator(cfg, log, db)
 	case TypeAgentLocal:
 		a, err = NewAgentLocalAuthenticator(cfg, log)
+	case TypeOIDC:
+		a, err = NewOIDCAuthenticator(cfg, log, db)
 	default:
 		err = fmt.Errorf("invalid authenticator type %v", cfg.AuthType)
 	}
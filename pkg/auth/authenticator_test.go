@@ -2,10 +2,12 @@ package auth
 
 import (
 	"encoding/base64"
+	"errors"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/openshift/assisted-service/pkg/ocm"
 	"github.com/sirupsen/logrus"
 )
 
@@ -69,3 +71,20 @@ var _ = Describe("NewAuthenticator", func() {
 
 	})
 })
+
+var _ = Describe("AgentAuth", func() {
+	It("marks the payloads of the agents authentication", func() {
+		payload := &ocm.AuthPayload{Username: "jdoe", Role: ocm.UserRole}
+		principal, err := AgentAuth(func(string) (interface{}, error) { return payload, nil })("token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(principal.(*ocm.AuthPayload).AgentAuth).To(BeTrue())
+		Expect(principal.(*ocm.AuthPayload).Username).To(Equal("jdoe"))
+		// The payload may be cached by the authenticator
+		Expect(payload.AgentAuth).To(BeFalse())
+	})
+
+	It("returns the errors of the authentication", func() {
+		_, err := AgentAuth(func(string) (interface{}, error) { return nil, errors.New("unauthorized") })("token")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -57,6 +57,12 @@ func NewAuthzHandler(cfg *Config, ocmCLient *ocm.Client, log logrus.FieldLogger,
 
 	case TypeAgentLocal:
 		authzr = &AgentLocalAuthzHandler{}
+	case TypeOIDC:
+		authzr = &RBACAuthzHandler{
+			cfg: cfg,
+			log: log,
+			db:  db,
+		}
 	default:
 		authzr = &NoneHandler{}
 	}
@@ -95,6 +95,12 @@ func (au *aUtils) proccessPublicKeys(cas *x509.CertPool) (keyMap map[string]*rsa
 	for _, c := range certs.Keys {
 		var pubKey *rsa.PublicKey
 
+		// Identity providers may publish other kinds of keys next to the RSA signing keys
+		if c.Kty != "RSA" {
+			logrus.Debugf("Skipping JWK %s of type %s", c.KID, c.Kty)
+			continue
+		}
+
 		// Try to convert cert to string.
 		pemStr, err = au.certToPEM(c)
 		if err != nil {
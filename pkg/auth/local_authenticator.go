@@ -18,6 +18,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// agentTokensCacheTTL is how long the rotations and the revocations of the agent tokens can take to
+// be enforced by all the replicas
+const agentTokensCacheTTL = 30 * time.Second
+
 type LocalAuthenticator struct {
 	cache     *cache.Cache
 	db        *gorm.DB
@@ -80,16 +84,30 @@ func (a *LocalAuthenticator) AuthAgentAuth(token string) (interface{}, error) {
 	}
 
 	if infraEnvOk {
-		_, exists := a.cache.Get(infraEnvID)
-		if !exists {
-			if infraEnvExists(a.db, infraEnvID) {
-				a.cache.Set(infraEnvID, "", cache.DefaultExpiration)
-			} else {
-				err := errors.Errorf("infraEnv %s does not exist", infraEnvID)
+		infraEnv, err := a.infraEnvAgentTokens(infraEnvID)
+		if err != nil {
+			return nil, common.NewInfraError(http.StatusUnauthorized, err)
+		}
+		if hostID, hostOk := claims[string(gencrypto.HostKey)].(string); hostOk {
+			tokenID, _ := claims[gencrypto.TokenIDClaim].(string)
+			if err = a.validateHostToken(tokenID, infraEnvID, hostID); err != nil {
+				a.log.WithError(err).Error("failed to validate host agent token")
 				return nil, common.NewInfraError(http.StatusUnauthorized, err)
 			}
+			a.log.Debugf("Authenticating host %s of infraEnv %s JWT", hostID, infraEnvID)
+			payload := ocm.AdminPayload()
+			payload.AgentToken = &ocm.AgentTokenScope{ID: tokenID, InfraEnvID: infraEnvID, HostID: hostID}
+			return payload, nil
+		}
+		if !agentTokenAccepted(infraEnv, issuedAt(claims), time.Now()) {
+			err = errors.Errorf("the agent tokens of infraEnv %s were rotated", infraEnvID)
+			a.log.Error(err)
+			return nil, common.NewInfraError(http.StatusUnauthorized, err)
 		}
 		a.log.Debugf("Authenticating infraEnv %s JWT", infraEnvID)
+		payload := ocm.AdminPayload()
+		payload.AgentToken = &ocm.AgentTokenScope{InfraEnvID: infraEnvID}
+		return payload, nil
 	} else if clusterOk {
 		_, exists := a.cache.Get(clusterID)
 		if !exists {
@@ -145,7 +163,73 @@ func clusterExists(db *gorm.DB, clusterID string) bool {
 	return err == nil
 }
 
-func infraEnvExists(db *gorm.DB, infraEnvID string) bool {
-	_, err := common.GetInfraEnvFromDB(db, strfmt.UUID(infraEnvID))
-	return err == nil
+// infraEnvAgentTokens returns the rotations of the agent tokens of the infra-env, they are cached for
+// a short time only so the rotations made by the other replicas are enforced quickly
+func (a *LocalAuthenticator) infraEnvAgentTokens(infraEnvID string) (*common.InfraEnv, error) {
+	if cached, exists := a.cache.Get(infraEnvID); exists {
+		return cached.(*common.InfraEnv), nil
+	}
+	var infraEnv common.InfraEnv
+	err := a.db.Select("id", "agent_tokens_rotated_at", "agent_tokens_previous_rotated_at", "agent_tokens_overlap_ends_at").
+		Take(&infraEnv, "id = ?", infraEnvID).Error
+	if err != nil {
+		return nil, errors.Errorf("infraEnv %s does not exist", infraEnvID)
+	}
+	a.cache.Set(infraEnvID, &infraEnv, agentTokensCacheTTL)
+	return &infraEnv, nil
+}
+
+func (a *LocalAuthenticator) validateHostToken(tokenID, infraEnvID, hostID string) error {
+	if tokenID == "" {
+		return errors.Errorf("the agent token of host %s has no ID", hostID)
+	}
+	var token *common.AgentToken
+	if cached, exists := a.cache.Get(tokenID); exists {
+		token = cached.(*common.AgentToken)
+	} else {
+		token = &common.AgentToken{}
+		if err := a.db.Take(token, "id = ?", tokenID).Error; err != nil {
+			return errors.Errorf("agent token %s of host %s does not exist", tokenID, hostID)
+		}
+		a.cache.Set(tokenID, token, agentTokensCacheTTL)
+	}
+	if token.InfraEnvID.String() != infraEnvID || token.HostID.String() != hostID {
+		return errors.Errorf("agent token %s wasn't issued to host %s of infraEnv %s", tokenID, hostID, infraEnvID)
+	}
+	if token.RevokedAt != nil {
+		return errors.Errorf("agent token %s of host %s was revoked", tokenID, hostID)
+	}
+	if token.ExpiresAt != nil && !time.Now().Before(*token.ExpiresAt) {
+		return errors.Errorf("agent token %s of host %s expired", tokenID, hostID)
+	}
+	return nil
+}
+
+// issuedAt returns the time the token was issued, nil for the tokens issued before it was added to
+// the claims
+func issuedAt(claims jwt.MapClaims) *time.Time {
+	seconds, ok := claims[gencrypto.IssuedAtClaim].(float64)
+	if !ok {
+		return nil
+	}
+	t := time.Unix(int64(seconds), 0)
+	return &t
+}
+
+// agentTokenAccepted checks the issue time of an infra-env agent token against the rotations of the
+// agent tokens of the infra-env. The tokens issued since the last rotation are accepted, the ones
+// issued between the two last rotations only until the end of the overlap of the last one.
+func agentTokenAccepted(infraEnv *common.InfraEnv, issuedAt *time.Time, now time.Time) bool {
+	// The issue time is in seconds, the tokens issued during the second of a rotation are
+	// considered issued after it
+	issuedSince := func(t *time.Time) bool {
+		return issuedAt != nil && !issuedAt.Before(t.Truncate(time.Second))
+	}
+	if infraEnv.AgentTokensRotatedAt == nil || issuedSince(infraEnv.AgentTokensRotatedAt) {
+		return true
+	}
+	if infraEnv.AgentTokensOverlapEndsAt == nil || !now.Before(*infraEnv.AgentTokensOverlapEndsAt) {
+		return false
+	}
+	return infraEnv.AgentTokensPreviousRotatedAt == nil || issuedSince(infraEnv.AgentTokensPreviousRotatedAt)
 }
@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	// Tokens signed with an unknown key trigger a reload of the keys of the issuer, at most once per interval
+	oidcKeysRefreshInterval = time.Minute
+)
+
+/* OIDCAuthenticator authenticates the users with the JWTs issued by any
+ * OpenID Connect provider (Keycloak, Dex, Azure AD...). The agents and the
+ * image downloads are authenticated like in the local authentication, with
+ * the tokens signed by the service.
+ */
+type OIDCAuthenticator struct {
+	*LocalAuthenticator
+	issuer              string
+	clientID            string
+	usernameClaim       string
+	groupsClaim         string
+	tenantClaim         string
+	adminUsers          []string
+	adminGroups         []string
+	readOnlyAdminGroups []string
+	utils               AUtilsInteface
+	trustedCAs          *x509.CertPool
+	log                 logrus.FieldLogger
+
+	keysMutex       sync.RWMutex
+	keyMap          map[string]*rsa.PublicKey
+	keysRefreshedAt time.Time
+}
+
+func NewOIDCAuthenticator(cfg *Config, log logrus.FieldLogger, db *gorm.DB) (*OIDCAuthenticator, error) {
+	if cfg.OIDCIssuerURL == "" {
+		return nil, errors.Errorf("oidc authentication requires the URL of the issuer")
+	}
+
+	local, err := NewLocalAuthenticator(cfg, log, db)
+	if err != nil {
+		return nil, err
+	}
+
+	trustedCAs, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, errors.Errorf("can't load system trusted CAs: %v", err)
+	}
+
+	a := &OIDCAuthenticator{
+		LocalAuthenticator:  local,
+		issuer:              cfg.OIDCIssuerURL,
+		clientID:            cfg.OIDCClientID,
+		usernameClaim:       cfg.OIDCUsernameClaim,
+		groupsClaim:         cfg.OIDCGroupsClaim,
+		tenantClaim:         cfg.OIDCTenantClaim,
+		adminUsers:          cfg.AdminUsers,
+		adminGroups:         cfg.OIDCAdminGroups,
+		readOnlyAdminGroups: cfg.OIDCReadOnlyAdminGroups,
+		trustedCAs:          trustedCAs,
+		log:                 log,
+	}
+
+	jwksURL := cfg.OIDCJwksURL
+	if cfg.JwkCert == "" && jwksURL == "" {
+		if jwksURL, err = a.discoverJwksURL(); err != nil {
+			return nil, err
+		}
+	}
+	a.utils = NewAuthUtils(cfg.JwkCert, jwksURL)
+
+	if err = a.refreshKeys(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+var _ Authenticator = &OIDCAuthenticator{}
+
+func (a *OIDCAuthenticator) AuthType() AuthType {
+	return TypeOIDC
+}
+
+func (a *OIDCAuthenticator) httpClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: a.trustedCAs,
+			},
+		},
+	}
+}
+
+// discoverJwksURL reads the URL of the keys of the issuer from its OpenID Connect discovery document
+func (a *OIDCAuthenticator) discoverJwksURL() (string, error) {
+	discoveryURL := strings.TrimSuffix(a.issuer, "/") + oidcDiscoveryPath
+	a.log.Infof("Getting the OIDC configuration from %s", discoveryURL)
+	res, err := a.httpClient().Get(discoveryURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to get the OIDC configuration of issuer %s", a.issuer)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", errors.Errorf("unexpected status %d getting the OIDC configuration of issuer %s", res.StatusCode, a.issuer)
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JwksURI string `json:"jwks_uri"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&discovery); err != nil {
+		return "", errors.Wrapf(err, "unable to parse the OIDC configuration of issuer %s", a.issuer)
+	}
+	if discovery.Issuer != a.issuer {
+		return "", errors.Errorf("the OIDC configuration is for issuer %s, expected %s", discovery.Issuer, a.issuer)
+	}
+	if discovery.JwksURI == "" {
+		return "", errors.Errorf("the OIDC configuration of issuer %s has no jwks_uri", a.issuer)
+	}
+	return discovery.JwksURI, nil
+}
+
+func (a *OIDCAuthenticator) refreshKeys() error {
+	keyMap, err := a.utils.proccessPublicKeys(a.trustedCAs)
+	if err != nil {
+		return err
+	}
+
+	a.keysMutex.Lock()
+	defer a.keysMutex.Unlock()
+	a.keyMap = keyMap
+	a.keysRefreshedAt = time.Now()
+	return nil
+}
+
+func (a *OIDCAuthenticator) getKey(kid string) (*rsa.PublicKey, bool) {
+	a.keysMutex.RLock()
+	defer a.keysMutex.RUnlock()
+	key, ok := a.keyMap[kid]
+	return key, ok
+}
+
+func (a *OIDCAuthenticator) shouldRefreshKeys() bool {
+	a.keysMutex.RLock()
+	defer a.keysMutex.RUnlock()
+	return time.Since(a.keysRefreshedAt) > oidcKeysRefreshInterval
+}
+
+func (a *OIDCAuthenticator) getValidationToken(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, errors.Errorf("no kid found in jwt token")
+	}
+
+	if key, found := a.getKey(kid); found {
+		return key, nil
+	}
+
+	// The issuer may have rotated its keys since they were loaded
+	if a.shouldRefreshKeys() {
+		if err := a.refreshKeys(); err != nil {
+			a.log.WithError(err).Error("Failed to refresh the OIDC keys")
+		} else if key, found := a.getKey(kid); found {
+			return key, nil
+		}
+	}
+	return nil, errors.Errorf("No matching key in auth keymap for key id [%v]", kid)
+}
+
+func (a *OIDCAuthenticator) AuthUserAuth(token string) (interface{}, error) {
+	authHeaderParts := strings.Fields(token)
+	if len(authHeaderParts) != 2 || strings.ToLower(authHeaderParts[0]) != "bearer" {
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("Authorization header format must be Bearer {token}"))
+	}
+
+	parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodRS256.Alg()}}
+	parsedToken, err := parser.Parse(authHeaderParts[1], a.getValidationToken)
+	if err != nil && !isValidationErrorIssuedAt(err) {
+		a.log.WithError(err).Error("Failed to validate the OIDC token")
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("Error parsing token or token is invalid"))
+	}
+
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("Unable to parse JWT token claims"))
+	}
+	if !claims.VerifyIssuer(a.issuer, true) {
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("Token was not issued by %s", a.issuer))
+	}
+	if a.clientID != "" && !claims.VerifyAudience(a.clientID, true) {
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("Token audience does not include %s", a.clientID))
+	}
+
+	payload := a.parsePayload(claims)
+	if payload.Username == "" {
+		a.log.Errorf("Missing %s claim in token", a.usernameClaim)
+		return nil, common.NewInfraError(http.StatusUnauthorized, errors.Errorf("Missing username in token"))
+	}
+	payload.Role = a.getRole(payload)
+	payload.IsAuthorized = true
+	return payload, nil
+}
+
+func (a *OIDCAuthenticator) parsePayload(claims jwt.MapClaims) *ocm.AuthPayload {
+	payload := &ocm.AuthPayload{}
+	payload.Username, _ = claimValue(claims, a.usernameClaim).(string)
+	payload.Email, _ = claims["email"].(string)
+	payload.FirstName, _ = claims["given_name"].(string)
+	payload.LastName, _ = claims["family_name"].(string)
+	payload.Issuer, _ = claims["iss"].(string)
+	payload.ClientID, _ = claims["azp"].(string)
+	payload.Groups = claimStrings(claimValue(claims, a.groupsClaim))
+	if tenant := claimValue(claims, a.tenantClaim); tenant != nil {
+		payload.Organization = fmt.Sprint(tenant)
+	}
+	return payload
+}
+
+func (a *OIDCAuthenticator) getRole(payload *ocm.AuthPayload) ocm.RoleType {
+	if funk.ContainsString(a.adminUsers, payload.Username) {
+		return ocm.AdminRole
+	}
+	for _, group := range payload.Groups {
+		if funk.ContainsString(a.adminGroups, group) {
+			return ocm.AdminRole
+		}
+	}
+	for _, group := range payload.Groups {
+		if funk.ContainsString(a.readOnlyAdminGroups, group) {
+			return ocm.ReadOnlyAdminRole
+		}
+	}
+	return ocm.UserRole
+}
+
+// claimValue returns the value of a claim, the names of nested claims are separated by dots
+func claimValue(claims jwt.MapClaims, name string) interface{} {
+	if name == "" {
+		return nil
+	}
+	var value interface{} = map[string]interface{}(claims)
+	for _, part := range strings.Split(name, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+	return value
+}
+
+// claimStrings converts a claim that holds either a list of strings or a single string
+func claimStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/gencrypto"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("OIDC authenticator", func() {
+	var (
+		server     *httptest.Server
+		issuer     string
+		privateKey crypto.PrivateKey
+		kid        string
+		jwks       []byte
+		cfg        *Config
+	)
+
+	rotateKeys := func() {
+		var publicKey crypto.PublicKey
+		publicKey, privateKey, _ = GenKeys(2048)
+		jwks, _, kid, _ = GenJSJWKS(publicKey, publicKey)
+	}
+
+	BeforeEach(func() {
+		rotateKeys()
+		mux := http.NewServeMux()
+		server = httptest.NewServer(mux)
+		issuer = server.URL + "/realms/assisted"
+		mux.HandleFunc("/realms/assisted/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+			Expect(json.NewEncoder(w).Encode(map[string]string{
+				"issuer":   issuer,
+				"jwks_uri": server.URL + "/realms/assisted/certs",
+			})).To(Succeed())
+		})
+		mux.HandleFunc("/realms/assisted/certs", func(w http.ResponseWriter, _ *http.Request) {
+			_, err := w.Write(jwks)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		pubKey, _, err := gencrypto.ECDSAKeyPairPEM()
+		Expect(err).ToNot(HaveOccurred())
+		cfg = &Config{
+			AuthType:                TypeOIDC,
+			ECPublicKeyPEM:          pubKey,
+			OIDCIssuerURL:           issuer,
+			OIDCClientID:            "assisted-service",
+			OIDCUsernameClaim:       "preferred_username",
+			OIDCGroupsClaim:         "groups",
+			OIDCTenantClaim:         "tenant",
+			OIDCAdminGroups:         []string{"installer-admins"},
+			OIDCReadOnlyAdminGroups: []string{"installer-auditors"},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newAuthenticator := func() *OIDCAuthenticator {
+		a, err := NewOIDCAuthenticator(cfg, logrus.New(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		return a
+	}
+
+	signToken := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(privateKey)
+		Expect(err).ToNot(HaveOccurred())
+		return "Bearer " + signed
+	}
+
+	userClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":                issuer,
+			"aud":                []string{"assisted-service", "account"},
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"preferred_username": "jdoe",
+			"email":              "jdoe@example.com",
+			"groups":             []string{"team-a", "team-b"},
+			"tenant":             "acme",
+		}
+	}
+
+	expectUnauthorized := func(err error) {
+		Expect(err).To(HaveOccurred())
+		infraError, ok := err.(*common.InfraErrorResponse)
+		Expect(ok).To(BeTrue())
+		Expect(infraError.StatusCode()).To(Equal(int32(http.StatusUnauthorized)))
+	}
+
+	It("maps the claims of the token to the user", func() {
+		payload, err := newAuthenticator().AuthUserAuth(signToken(userClaims()))
+		Expect(err).ToNot(HaveOccurred())
+		user := payload.(*ocm.AuthPayload)
+		Expect(user.Username).To(Equal("jdoe"))
+		Expect(user.Email).To(Equal("jdoe@example.com"))
+		Expect(user.Groups).To(ConsistOf("team-a", "team-b"))
+		Expect(user.Organization).To(Equal("acme"))
+		Expect(user.Role).To(Equal(ocm.UserRole))
+	})
+
+	It("reads nested claims", func() {
+		cfg.OIDCGroupsClaim = "realm_access.roles"
+		claims := userClaims()
+		delete(claims, "groups")
+		claims["realm_access"] = map[string]interface{}{"roles": []string{"installer-admins"}}
+		payload, err := newAuthenticator().AuthUserAuth(signToken(claims))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.(*ocm.AuthPayload).Groups).To(ConsistOf("installer-admins"))
+		Expect(payload.(*ocm.AuthPayload).Role).To(Equal(ocm.AdminRole))
+	})
+
+	It("maps the groups to the roles", func() {
+		claims := userClaims()
+		claims["groups"] = []string{"team-a", "installer-auditors"}
+		payload, err := newAuthenticator().AuthUserAuth(signToken(claims))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.(*ocm.AuthPayload).Role).To(Equal(ocm.ReadOnlyAdminRole))
+
+		cfg.AdminUsers = []string{"jdoe"}
+		payload, err = newAuthenticator().AuthUserAuth(signToken(claims))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.(*ocm.AuthPayload).Role).To(Equal(ocm.AdminRole))
+	})
+
+	It("rejects the tokens of another issuer", func() {
+		claims := userClaims()
+		claims["iss"] = "https://sso.example.com/realms/other"
+		_, err := newAuthenticator().AuthUserAuth(signToken(claims))
+		expectUnauthorized(err)
+	})
+
+	It("rejects the tokens of another client", func() {
+		claims := userClaims()
+		claims["aud"] = "other-client"
+		_, err := newAuthenticator().AuthUserAuth(signToken(claims))
+		expectUnauthorized(err)
+	})
+
+	It("rejects expired tokens", func() {
+		claims := userClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+		_, err := newAuthenticator().AuthUserAuth(signToken(claims))
+		expectUnauthorized(err)
+	})
+
+	It("rejects tokens without a username", func() {
+		claims := userClaims()
+		delete(claims, "preferred_username")
+		_, err := newAuthenticator().AuthUserAuth(signToken(claims))
+		expectUnauthorized(err)
+	})
+
+	It("rejects a malformed authorization header", func() {
+		_, err := newAuthenticator().AuthUserAuth("jdoe")
+		expectUnauthorized(err)
+	})
+
+	It("reloads the keys after the issuer rotated them", func() {
+		a := newAuthenticator()
+		rotateKeys()
+		token := signToken(userClaims())
+
+		// The keys were just loaded, they are not reloaded right away
+		_, err := a.AuthUserAuth(token)
+		expectUnauthorized(err)
+
+		a.keysRefreshedAt = time.Now().Add(-2 * oidcKeysRefreshInterval)
+		_, err = a.AuthUserAuth(token)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("uses the configured JWKS URL instead of the discovery", func() {
+		cfg.OIDCIssuerURL = "https://sso.example.com/realms/assisted"
+		cfg.OIDCJwksURL = server.URL + "/realms/assisted/certs"
+		issuer = cfg.OIDCIssuerURL
+		_, err := newAuthenticator().AuthUserAuth(signToken(userClaims()))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails when the discovery is for another issuer", func() {
+		cfg.OIDCIssuerURL = issuer + "/"
+		_, err := NewOIDCAuthenticator(cfg, logrus.New(), nil)
+		Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("expected %s/", issuer))))
+	})
+
+	It("requires an issuer", func() {
+		cfg.OIDCIssuerURL = ""
+		_, err := NewOIDCAuthenticator(cfg, logrus.New(), nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,283 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	params "github.com/openshift/assisted-service/pkg/context"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
+)
+
+// The roles granted by the role bindings, each role includes the permissions of the previous ones
+var rbacRoles = []string{models.RoleBindingRoleViewer, models.RoleBindingRoleEditor, models.RoleBindingRoleAdmin}
+
+/* RBACAuthzHandler is the authorizer middleware that is being used for
+ * OIDC authentication cases. Users have full access to the resources they
+ * own, and the role bindings stored in the database grant access to the
+ * resources of other users, per cluster, infra-env or tenant, to users and
+ * to groups of the identity provider.
+ */
+type RBACAuthzHandler struct {
+	cfg *Config
+	log logrus.FieldLogger
+	db  *gorm.DB
+}
+
+var _ Authorizer = &RBACAuthzHandler{}
+
+func (a *RBACAuthzHandler) CreateAuthorizer() func(*http.Request) error {
+	return a.authorizerMiddleware
+}
+
+func (a *RBACAuthzHandler) IsAdmin(ctx context.Context) bool {
+	authPayload := ocm.PayloadFromContext(ctx)
+	allowedRoles := []ocm.RoleType{ocm.AdminRole, ocm.ReadOnlyAdminRole}
+	return funk.Contains(allowedRoles, authPayload.Role)
+}
+
+func (a *RBACAuthzHandler) isReadOnlyAdmin(ctx context.Context) bool {
+	return ocm.PayloadFromContext(ctx).Role == ocm.ReadOnlyAdminRole
+}
+
+func (a *RBACAuthzHandler) HasOrgBasedCapability(ctx context.Context, capability string) (bool, error) {
+	return true, nil
+}
+
+// rolesIncluding returns the roles that include the permissions of the input role
+func rolesIncluding(role string) []string {
+	return rbacRoles[funk.IndexOfString(rbacRoles, role):]
+}
+
+// roleForAction returns the minimal role needed to perform the action
+func roleForAction(action Action) string {
+	if action == ReadAction {
+		return models.RoleBindingRoleViewer
+	}
+	return models.RoleBindingRoleEditor
+}
+
+// boundTo limits the role bindings query to the bindings of the user and of its groups
+func boundTo(db *gorm.DB, payload *ocm.AuthPayload) *gorm.DB {
+	query := db.Where("subject_kind = ? AND subject = ?", models.RoleBindingSubjectKindUser, payload.Username)
+	if len(payload.Groups) > 0 {
+		query = query.Or("subject_kind = ? AND subject IN (?)", models.RoleBindingSubjectKindGroup, payload.Groups)
+	}
+	return query
+}
+
+// boundResources returns a sub query selecting the resources of the scope the user has a role binding on
+func (a *RBACAuthzHandler) boundResources(payload *ocm.AuthPayload, scope string) *gorm.DB {
+	return a.db.Model(&models.RoleBinding{}).Select("resource_id").
+		Where("scope = ?", scope).
+		Where(boundTo(a.db, payload))
+}
+
+func (a *RBACAuthzHandler) hasRoleBinding(payload *ocm.AuthPayload, scope, resourceID, role string) (bool, error) {
+	if resourceID == "" {
+		return false, nil
+	}
+	var count int64
+	err := a.db.Model(&models.RoleBinding{}).
+		Where("scope = ? AND resource_id = ? AND role IN (?)", scope, resourceID, rolesIncluding(role)).
+		Where(boundTo(a.db, payload)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (a *RBACAuthzHandler) OwnedBy(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if a.IsAdmin(ctx) {
+		return db
+	}
+	payload := ocm.PayloadFromContext(ctx)
+	// The resource ID columns depend on the queried table, which is only known once the query runs
+	return db.Scopes(func(tx *gorm.DB) *gorm.DB {
+		tenants := a.boundResources(payload, models.RoleBindingScopeTenant)
+		clusters := a.boundResources(payload, models.RoleBindingScopeCluster)
+		infraEnvs := a.boundResources(payload, models.RoleBindingScopeInfraEnv)
+		switch queriedType(tx) {
+		case reflect.TypeOf(common.Cluster{}):
+			return tx.Where("user_name = ? OR org_id IN (?) OR id IN (?)", payload.Username, tenants, clusters)
+		case reflect.TypeOf(common.InfraEnv{}):
+			return tx.Where("user_name = ? OR org_id IN (?) OR id IN (?) OR cluster_id IN (?)",
+				payload.Username, tenants, infraEnvs, clusters)
+		default:
+			// The events are queried with the owner of their cluster or infra-env
+			return tx.Where("user_name = ? OR org_id IN (?) OR cluster_id IN (?) OR infra_env_id IN (?)",
+				payload.Username, tenants, clusters, infraEnvs)
+		}
+	})
+}
+
+// queriedType returns the type of the records of the query, either from its model or from its destination
+func queriedType(db *gorm.DB) reflect.Type {
+	switch db.Statement.Table {
+	case "clusters":
+		return reflect.TypeOf(common.Cluster{})
+	case "infra_envs":
+		return reflect.TypeOf(common.InfraEnv{})
+	}
+	for _, value := range []interface{}{db.Statement.Model, db.Statement.Dest} {
+		if value == nil {
+			continue
+		}
+		t := reflect.TypeOf(value)
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		return t
+	}
+	return nil
+}
+
+func (a *RBACAuthzHandler) OwnedByUser(ctx context.Context, db *gorm.DB, username string) *gorm.DB {
+	if username == "" {
+		return a.OwnedBy(ctx, db)
+	}
+	return a.OwnedBy(ctx, db).Where("user_name = ?", username)
+}
+
+func (a *RBACAuthzHandler) HasAccessTo(ctx context.Context, obj interface{}, action Action) (bool, error) {
+	if a.isReadOnlyAdmin(ctx) {
+		if action == ReadAction {
+			return true, nil
+		}
+	} else if a.IsAdmin(ctx) {
+		return true, nil
+	}
+	payload := ocm.PayloadFromContext(ctx)
+	role := roleForAction(action)
+	if cluster, ok := obj.(*common.Cluster); ok && cluster != nil {
+		return a.hasClusterRole(cluster.ID.String(), role, payload)
+	}
+	if infraEnv, ok := obj.(*common.InfraEnv); ok && infraEnv != nil {
+		return a.hasInfraEnvRole(infraEnv.ID.String(), role, payload)
+	}
+	if host, ok := obj.(*common.Host); ok && host != nil {
+		if host.ClusterID != nil {
+			return a.hasClusterRole(host.ClusterID.String(), role, payload)
+		}
+		return a.hasInfraEnvRole(host.InfraEnvID.String(), role, payload)
+	}
+	return false, errors.New("can not perform access check on this object")
+}
+
+// HasRoleOn checks that the current user has at least the input role on the resource of the scope
+func (a *RBACAuthzHandler) HasRoleOn(ctx context.Context, scope, resourceID, role string) (bool, error) {
+	if a.isReadOnlyAdmin(ctx) {
+		if role == models.RoleBindingRoleViewer {
+			return true, nil
+		}
+	} else if a.IsAdmin(ctx) {
+		return true, nil
+	}
+	payload := ocm.PayloadFromContext(ctx)
+	switch scope {
+	case models.RoleBindingScopeCluster:
+		return a.hasClusterRole(resourceID, role, payload)
+	case models.RoleBindingScopeInfraEnv:
+		return a.hasInfraEnvRole(resourceID, role, payload)
+	case models.RoleBindingScopeTenant:
+		return a.hasRoleBinding(payload, models.RoleBindingScopeTenant, resourceID, role)
+	default:
+		return false, fmt.Errorf("unknown role binding scope %s", scope)
+	}
+}
+
+// hasOwnerOrTenantRole checks the roles that come from the ownership of the resource,
+// the owner of a resource is an admin of the resource
+func (a *RBACAuthzHandler) hasOwnerOrTenantRole(userName, orgID, role string, payload *ocm.AuthPayload) (bool, error) {
+	if userName == payload.Username {
+		return true, nil
+	}
+	return a.hasRoleBinding(payload, models.RoleBindingScopeTenant, orgID, role)
+}
+
+func (a *RBACAuthzHandler) hasClusterRole(id, role string, payload *ocm.AuthPayload) (bool, error) {
+	if a.db == nil {
+		return true, nil
+	}
+
+	var cluster common.Cluster
+	err := a.db.Select("user_name", "org_id").First(&cluster, "id = ?", id).Error
+	if err != nil {
+		return handleOwnershipQueryError(err)
+	}
+	if isAllowed, err := a.hasOwnerOrTenantRole(cluster.UserName, cluster.OrgID, role, payload); isAllowed || err != nil {
+		return isAllowed, err
+	}
+	return a.hasRoleBinding(payload, models.RoleBindingScopeCluster, id, role)
+}
+
+func (a *RBACAuthzHandler) hasInfraEnvRole(id, role string, payload *ocm.AuthPayload) (bool, error) {
+	if a.db == nil {
+		return true, nil
+	}
+
+	var infraEnv common.InfraEnv
+	err := a.db.Select("user_name", "org_id", "cluster_id").First(&infraEnv, "id = ?", id).Error
+	if err != nil {
+		return handleOwnershipQueryError(err)
+	}
+	if isAllowed, err := a.hasOwnerOrTenantRole(infraEnv.UserName, infraEnv.OrgID, role, payload); isAllowed || err != nil {
+		return isAllowed, err
+	}
+	if isAllowed, err := a.hasRoleBinding(payload, models.RoleBindingScopeInfraEnv, id, role); isAllowed || err != nil {
+		return isAllowed, err
+	}
+
+	//the roles on the cluster an infra-env is bound to also apply to the infra-env
+	if infraEnv.ClusterID != "" {
+		return a.hasClusterRole(infraEnv.ClusterID.String(), role, payload)
+	}
+	return false, nil
+}
+
+func (a *RBACAuthzHandler) authorizerMiddleware(request *http.Request) error {
+	payload := ocm.PayloadFromContext(request.Context())
+	if ok := hasSufficientRole(a.log, request, payload); !ok {
+		return common.NewInfraError(
+			http.StatusForbidden,
+			fmt.Errorf(
+				"%s: Unauthorized to access route (insufficient role %s)",
+				payload.Username, payload.Role))
+	}
+
+	if payload.Role != ocm.UserRole {
+		return nil
+	}
+
+	//List requests and resources outside the scope of clusters or infraEnvs
+	//handle their authorization at the application level
+	var obj interface{}
+	if clusterID := params.GetParam(request.Context(), params.ClusterId); clusterID != "" {
+		obj = clusterFromID(clusterID)
+	} else if infraEnvID := params.GetParam(request.Context(), params.InfraEnvId); infraEnvID != "" {
+		obj = infraEnvFromID(infraEnvID)
+	} else {
+		return nil
+	}
+
+	isAllowed, err := a.HasAccessTo(request.Context(), obj, toAction(request))
+	if err != nil {
+		a.log.Errorf("Failed to verify access to object. Error %v", err)
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+	if !isAllowed {
+		if toAction(request) != ReadAction {
+			// Returns status forbidden if only read is allowed on object
+			if canRead, _ := a.HasAccessTo(request.Context(), obj, ReadAction); canRead {
+				return common.NewInfraError(http.StatusForbidden, fmt.Errorf("Unauthorized to manipulate object"))
+			}
+		}
+		return common.NewApiError(http.StatusNotFound, fmt.Errorf("Object Not Found"))
+	}
+	return nil
+}
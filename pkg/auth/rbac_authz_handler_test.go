@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/authz"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("RBAC authorizer", func() {
+	var (
+		db        *gorm.DB
+		dbName    string
+		handler   *RBACAuthzHandler
+		clusterA  strfmt.UUID
+		clusterB  strfmt.UUID
+		infraEnvA strfmt.UUID
+		infraEnvB strfmt.UUID
+	)
+
+	userContext := func(username string, groups ...string) context.Context {
+		payload := &ocm.AuthPayload{Username: username, Groups: groups, Role: ocm.UserRole}
+		return context.WithValue(context.Background(), restapi.AuthKey, payload)
+	}
+
+	bind := func(kind, subject, role, scope, resourceID string) {
+		Expect(db.Create(&models.RoleBinding{
+			ID:          strfmt.UUID(uuid.New().String()),
+			SubjectKind: swag.String(kind),
+			Subject:     swag.String(subject),
+			Role:        swag.String(role),
+			Scope:       swag.String(scope),
+			ResourceID:  swag.String(resourceID),
+		}).Error).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		db, dbName = common.PrepareTestDB()
+		handler = NewAuthzHandler(&Config{AuthType: TypeOIDC}, nil, logrus.New(), db).(*RBACAuthzHandler)
+
+		clusterA = strfmt.UUID(uuid.New().String())
+		clusterB = strfmt.UUID(uuid.New().String())
+		infraEnvA = strfmt.UUID(uuid.New().String())
+		infraEnvB = strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterA, UserName: "alice", OrgID: "acme"}}).Error).ToNot(HaveOccurred())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterB, UserName: "bob", OrgID: "globex"}}).Error).ToNot(HaveOccurred())
+		Expect(db.Create(&common.InfraEnv{InfraEnv: models.InfraEnv{ID: &infraEnvA, UserName: "alice", OrgID: "acme", ClusterID: clusterA}}).Error).ToNot(HaveOccurred())
+		Expect(db.Create(&common.InfraEnv{InfraEnv: models.InfraEnv{ID: &infraEnvB, UserName: "bob", OrgID: "globex"}}).Error).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	Context("HasAccessTo", func() {
+		hasAccess := func(ctx context.Context, obj interface{}, action Action) bool {
+			allowed, err := handler.HasAccessTo(ctx, obj, action)
+			Expect(err).ToNot(HaveOccurred())
+			return allowed
+		}
+
+		It("gives full access to the owner", func() {
+			ctx := userContext("alice")
+			Expect(hasAccess(ctx, clusterFromID(clusterA.String()), DeleteAction)).To(BeTrue())
+			Expect(hasAccess(ctx, infraEnvFromID(infraEnvA.String()), UpdateAction)).To(BeTrue())
+			Expect(hasAccess(ctx, clusterFromID(clusterB.String()), ReadAction)).To(BeFalse())
+			Expect(hasAccess(ctx, infraEnvFromID(infraEnvB.String()), ReadAction)).To(BeFalse())
+		})
+
+		It("gives read access to the viewers", func() {
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleViewer, models.RoleBindingScopeCluster, clusterB.String())
+			ctx := userContext("carol")
+			Expect(hasAccess(ctx, clusterFromID(clusterB.String()), ReadAction)).To(BeTrue())
+			Expect(hasAccess(ctx, clusterFromID(clusterB.String()), UpdateAction)).To(BeFalse())
+			Expect(hasAccess(ctx, clusterFromID(clusterA.String()), ReadAction)).To(BeFalse())
+		})
+
+		It("gives write access to the editors of the groups of the user", func() {
+			bind(models.RoleBindingSubjectKindGroup, "team-a", models.RoleBindingRoleEditor, models.RoleBindingScopeInfraEnv, infraEnvB.String())
+			Expect(hasAccess(userContext("carol", "team-a"), infraEnvFromID(infraEnvB.String()), UpdateAction)).To(BeTrue())
+			Expect(hasAccess(userContext("carol", "team-b"), infraEnvFromID(infraEnvB.String()), ReadAction)).To(BeFalse())
+			Expect(hasAccess(userContext("team-a"), infraEnvFromID(infraEnvB.String()), ReadAction)).To(BeFalse())
+		})
+
+		It("gives access to the resources of a tenant", func() {
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleAdmin, models.RoleBindingScopeTenant, "acme")
+			ctx := userContext("carol")
+			Expect(hasAccess(ctx, clusterFromID(clusterA.String()), DeleteAction)).To(BeTrue())
+			Expect(hasAccess(ctx, infraEnvFromID(infraEnvA.String()), UpdateAction)).To(BeTrue())
+			Expect(hasAccess(ctx, clusterFromID(clusterB.String()), ReadAction)).To(BeFalse())
+		})
+
+		It("applies the roles on a cluster to its infra-envs and hosts", func() {
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleEditor, models.RoleBindingScopeCluster, clusterA.String())
+			ctx := userContext("carol")
+			Expect(hasAccess(ctx, infraEnvFromID(infraEnvA.String()), UpdateAction)).To(BeTrue())
+			host := &common.Host{Host: models.Host{InfraEnvID: infraEnvA, ClusterID: &clusterA}}
+			Expect(hasAccess(ctx, host, UpdateAction)).To(BeTrue())
+		})
+
+		It("denies the access to missing resources", func() {
+			Expect(hasAccess(userContext("alice"), clusterFromID(uuid.New().String()), ReadAction)).To(BeFalse())
+		})
+
+		It("gives read access to the read-only admins", func() {
+			ctx := context.WithValue(context.Background(), restapi.AuthKey, &ocm.AuthPayload{Username: "root", Role: ocm.ReadOnlyAdminRole})
+			Expect(hasAccess(ctx, clusterFromID(clusterB.String()), ReadAction)).To(BeTrue())
+			Expect(hasAccess(ctx, clusterFromID(clusterB.String()), UpdateAction)).To(BeFalse())
+		})
+	})
+
+	Context("OwnedBy", func() {
+		It("lists the owned and bound clusters", func() {
+			bind(models.RoleBindingSubjectKindGroup, "team-a", models.RoleBindingRoleViewer, models.RoleBindingScopeCluster, clusterB.String())
+			clusters, err := common.GetClustersFromDBWhere(handler.OwnedBy(userContext("alice", "team-a"), db),
+				common.SkipEagerLoading, common.SkipDeletedRecords)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clusters).To(HaveLen(2))
+
+			clusters, err = common.GetClustersFromDBWhere(handler.OwnedBy(userContext("alice"), db),
+				common.SkipEagerLoading, common.SkipDeletedRecords)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clusters).To(HaveLen(1))
+			Expect(*clusters[0].ID).To(Equal(clusterA))
+		})
+
+		It("lists the infra-envs of the bound clusters and tenants", func() {
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleViewer, models.RoleBindingScopeCluster, clusterA.String())
+			infraEnvs, err := common.GetInfraEnvsFromDBWhere(handler.OwnedBy(userContext("carol"), db))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(infraEnvs).To(HaveLen(1))
+			Expect(*infraEnvs[0].ID).To(Equal(infraEnvA))
+
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleViewer, models.RoleBindingScopeTenant, "globex")
+			infraEnvs, err = common.GetInfraEnvsFromDBWhere(handler.OwnedBy(userContext("carol"), db))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(infraEnvs).To(HaveLen(2))
+		})
+
+		It("counts the bound resources", func() {
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleViewer, models.RoleBindingScopeInfraEnv, infraEnvB.String())
+			var count int64
+			Expect(handler.OwnedBy(userContext("carol"), db.Model(&common.InfraEnv{})).Count(&count).Error).ToNot(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+	})
+
+	Context("role bindings API", func() {
+		var api *RoleBindingsAPI
+
+		BeforeEach(func() {
+			api = NewRoleBindingsAPI(handler, logrus.New(), db)
+		})
+
+		newBinding := func(subject, role, scope, resourceID string) *models.RoleBinding {
+			return &models.RoleBinding{
+				SubjectKind: swag.String(models.RoleBindingSubjectKindUser),
+				Subject:     swag.String(subject),
+				Role:        swag.String(role),
+				Scope:       swag.String(scope),
+				ResourceID:  swag.String(resourceID),
+			}
+		}
+
+		It("lets the owner grant and revoke roles on its cluster", func() {
+			ctx := userContext("alice")
+			reply := api.V2CreateRoleBinding(ctx, operations.V2CreateRoleBindingParams{
+				RoleBinding: newBinding("carol", models.RoleBindingRoleEditor, models.RoleBindingScopeCluster, clusterA.String()),
+			})
+			Expect(reply).To(BeAssignableToTypeOf(operations.NewV2CreateRoleBindingCreated()))
+			created := reply.(*operations.V2CreateRoleBindingCreated).Payload
+			Expect(created.CreatedBy).To(Equal("alice"))
+
+			reply = api.V2ListRoleBindings(userContext("carol"), operations.V2ListRoleBindingsParams{})
+			Expect(reply.(*operations.V2ListRoleBindingsOK).Payload).To(HaveLen(1))
+
+			reply = api.V2DeleteRoleBinding(ctx, operations.V2DeleteRoleBindingParams{RoleBindingID: created.ID})
+			Expect(reply).To(BeAssignableToTypeOf(operations.NewV2DeleteRoleBindingNoContent()))
+			allowed, err := handler.HasAccessTo(userContext("carol"), clusterFromID(clusterA.String()), ReadAction)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+		})
+
+		It("rejects duplicated role bindings", func() {
+			ctx := userContext("alice")
+			params := operations.V2CreateRoleBindingParams{
+				RoleBinding: newBinding("carol", models.RoleBindingRoleViewer, models.RoleBindingScopeCluster, clusterA.String()),
+			}
+			Expect(api.V2CreateRoleBinding(ctx, params)).To(BeAssignableToTypeOf(operations.NewV2CreateRoleBindingCreated()))
+			params.RoleBinding = newBinding("carol", models.RoleBindingRoleViewer, models.RoleBindingScopeCluster, clusterA.String())
+			verifyApiError(api.V2CreateRoleBinding(ctx, params), http.StatusConflict)
+		})
+
+		It("only lets the admins of a resource grant roles on it", func() {
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleEditor, models.RoleBindingScopeCluster, clusterB.String())
+			params := operations.V2CreateRoleBindingParams{
+				RoleBinding: newBinding("carol", models.RoleBindingRoleAdmin, models.RoleBindingScopeCluster, clusterB.String()),
+			}
+			verifyApiError(api.V2CreateRoleBinding(userContext("carol"), params), http.StatusForbidden)
+			verifyApiError(api.V2CreateRoleBinding(userContext("alice"), params), http.StatusNotFound)
+
+			params.RoleBinding = newBinding("alice", models.RoleBindingRoleAdmin, models.RoleBindingScopeTenant, "globex")
+			verifyApiError(api.V2CreateRoleBinding(userContext("bob"), params), http.StatusNotFound)
+		})
+
+		It("hides the role bindings of the resources the user does not administer", func() {
+			bind(models.RoleBindingSubjectKindUser, "carol", models.RoleBindingRoleViewer, models.RoleBindingScopeCluster, clusterB.String())
+			reply := api.V2ListRoleBindings(userContext("alice"), operations.V2ListRoleBindingsParams{})
+			Expect(reply.(*operations.V2ListRoleBindingsOK).Payload).To(BeEmpty())
+			reply = api.V2ListRoleBindings(userContext("bob"), operations.V2ListRoleBindingsParams{})
+			Expect(reply.(*operations.V2ListRoleBindingsOK).Payload).To(HaveLen(1))
+		})
+
+		It("is not supported by the other authorizers", func() {
+			api = NewRoleBindingsAPI(&NoneHandler{}, logrus.New(), db)
+			verifyApiError(api.V2ListRoleBindings(context.Background(), operations.V2ListRoleBindingsParams{}), http.StatusNotImplemented)
+		})
+	})
+})
+
+func verifyApiError(responder interface{}, expectedHttpStatus int32) {
+	var statusCode int32
+	switch err := responder.(type) {
+	case *common.ApiErrorResponse:
+		statusCode = err.StatusCode()
+	case *common.InfraErrorResponse:
+		statusCode = err.StatusCode()
+	default:
+		Fail("unexpected responder type")
+	}
+	ExpectWithOffset(1, statusCode).To(Equal(expectedHttpStatus))
+}
@@ -363,17 +363,23 @@ func (a *AuthzHandler) ocmAuthorizer(request *http.Request) error {
 
 func (a *AuthzHandler) getObjFromRequest(request *http.Request) interface{} {
 	if clusterID := params.GetParam(request.Context(), params.ClusterId); clusterID != "" {
-		id := strfmt.UUID(clusterID)
-		cluster := &common.Cluster{Cluster: models.Cluster{ID: &id}}
-		return cluster
+		return clusterFromID(clusterID)
 	} else if infraEnvID := params.GetParam(request.Context(), params.InfraEnvId); infraEnvID != "" {
-		id := strfmt.UUID(infraEnvID)
-		infraEnv := &common.InfraEnv{InfraEnv: models.InfraEnv{ID: &id}}
-		return infraEnv
+		return infraEnvFromID(infraEnvID)
 	}
 	return nil
 }
 
+func clusterFromID(clusterID string) *common.Cluster {
+	id := strfmt.UUID(clusterID)
+	return &common.Cluster{Cluster: models.Cluster{ID: &id}}
+}
+
+func infraEnvFromID(infraEnvID string) *common.InfraEnv {
+	id := strfmt.UUID(infraEnvID)
+	return &common.InfraEnv{InfraEnv: models.InfraEnv{ID: &id}}
+}
+
 func (a *AuthzHandler) allowedToUseAssistedInstaller(username string) (bool, error) {
 	return a.client.Authorization.AccessReview(
 		context.Background(), username, ocm.AMSActionCreate, "", ocm.BareMetalClusterResource)
@@ -387,11 +393,19 @@ func (a *AuthzHandler) hasClusterEditRole(payload *ocm.AuthPayload, action, subs
 func (a *AuthzHandler) hasSufficientRole(
 	request *http.Request,
 	payload *ocm.AuthPayload) bool {
+	return hasSufficientRole(a.log, request, payload)
+}
+
+// hasSufficientRole checks the role of the user against the scopes of the security policies of the route
+func hasSufficientRole(
+	log logrus.FieldLogger,
+	request *http.Request,
+	payload *ocm.AuthPayload) bool {
 
 	route := middleware.MatchedRouteFrom(request)
 
 	allScopesAreAllowedResponse := func() bool {
-		a.log.Debugf(
+		log.Debugf(
 			"%s: Authorized user: %s all roles are allowed",
 			route.PathPattern, payload.Username)
 		return true
@@ -411,13 +425,13 @@ func (a *AuthzHandler) hasSufficientRole(
 			return allScopesAreAllowedResponse()
 		}
 		if funk.Contains(policyScopes, string(payload.Role)) {
-			a.log.Debugf(
+			log.Debugf(
 				"%s: Authorized user: %s for role: %s",
 				route.PathPattern, payload.Username, payload.Role)
 			return true
 		}
 	}
-	a.log.Warnf(
+	log.Warnf(
 		"Unauthorized user %s: insufficient role: %s allowed roles: %q",
 		payload.Username,
 		payload.Role,
@@ -706,6 +706,10 @@ var _ = Describe("authz", func() {
 				},
 				log.WithField("pkg", "auth"), nil).CreateAuthorizer(),
 			InstallerAPI:      fakeInventory{},
+			AuditAPI:          fakeAuditAPI{},
+			AuthzAPI:          fakeAuthzAPI{},
+			QuotasAPI:         fakeQuotasAPI{},
+			TokensAPI:         fakeTokensAPI{},
 			EventsAPI:         &fakeEventsAPI{},
 			Logger:            logrus.Printf,
 			VersionsAPI:       fakeVersionsAPI{},
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/pkg/ocm"
+	"github.com/openshift/assisted-service/restapi"
+	operations "github.com/openshift/assisted-service/restapi/operations/authz"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var _ restapi.AuthzAPI = &RoleBindingsAPI{}
+
+// RoleBindingsAPI manages the role bindings used by the RBAC authorizer. The API is only
+// available with the oidc authentication, the other authorizers don't use role bindings.
+type RoleBindingsAPI struct {
+	authzHandler *RBACAuthzHandler
+	log          logrus.FieldLogger
+	db           *gorm.DB
+}
+
+func NewRoleBindingsAPI(authzHandler Authorizer, log logrus.FieldLogger, db *gorm.DB) *RoleBindingsAPI {
+	if apiTokenHandler, ok := authzHandler.(*APITokenAuthzHandler); ok {
+		authzHandler = apiTokenHandler.Authorizer
+	}
+	rbacHandler, _ := authzHandler.(*RBACAuthzHandler)
+	return &RoleBindingsAPI{
+		authzHandler: rbacHandler,
+		log:          log,
+		db:           db,
+	}
+}
+
+func (r *RoleBindingsAPI) notSupported() middleware.Responder {
+	return common.GenerateErrorResponder(common.NewApiError(http.StatusNotImplemented,
+		errors.New("role bindings are only supported with the oidc authentication")))
+}
+
+// canManage checks that the user is an admin of the resource of the role binding
+func (r *RoleBindingsAPI) canManage(ctx context.Context, binding *models.RoleBinding) (bool, error) {
+	return r.authzHandler.HasRoleOn(ctx, *binding.Scope, *binding.ResourceID, models.RoleBindingRoleAdmin)
+}
+
+func (r *RoleBindingsAPI) V2ListRoleBindings(ctx context.Context, params operations.V2ListRoleBindingsParams) middleware.Responder {
+	if r.authzHandler == nil {
+		return r.notSupported()
+	}
+	log := logutil.FromContext(ctx, r.log)
+
+	query := r.db.Order("created_at")
+	if params.Scope != nil {
+		query = query.Where("scope = ?", *params.Scope)
+	}
+	if params.ResourceID != nil {
+		query = query.Where("resource_id = ?", *params.ResourceID)
+	}
+	if params.Subject != nil {
+		query = query.Where("subject = ?", *params.Subject)
+	}
+	var bindings []*models.RoleBinding
+	if err := query.Find(&bindings).Error; err != nil {
+		log.WithError(err).Error("failed to list role bindings")
+		return common.GenerateErrorResponder(err)
+	}
+	if r.authzHandler.IsAdmin(ctx) {
+		return operations.NewV2ListRoleBindingsOK().WithPayload(bindings)
+	}
+
+	// Users see the role bindings granted to them and the role bindings of the resources they administer
+	payload := ocm.PayloadFromContext(ctx)
+	visible := make([]*models.RoleBinding, 0, len(bindings))
+	canManage := map[string]bool{}
+	for _, binding := range bindings {
+		if isBoundTo(binding, payload) {
+			visible = append(visible, binding)
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", *binding.Scope, *binding.ResourceID)
+		allowed, found := canManage[key]
+		if !found {
+			var err error
+			if allowed, err = r.canManage(ctx, binding); err != nil {
+				log.WithError(err).Errorf("failed to check the roles of user %s on %s", payload.Username, key)
+				return common.GenerateErrorResponder(err)
+			}
+			canManage[key] = allowed
+		}
+		if allowed {
+			visible = append(visible, binding)
+		}
+	}
+	return operations.NewV2ListRoleBindingsOK().WithPayload(visible)
+}
+
+func isBoundTo(binding *models.RoleBinding, payload *ocm.AuthPayload) bool {
+	switch *binding.SubjectKind {
+	case models.RoleBindingSubjectKindUser:
+		return *binding.Subject == payload.Username
+	case models.RoleBindingSubjectKindGroup:
+		for _, group := range payload.Groups {
+			if *binding.Subject == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *RoleBindingsAPI) V2CreateRoleBinding(ctx context.Context, params operations.V2CreateRoleBindingParams) middleware.Responder {
+	if r.authzHandler == nil {
+		return r.notSupported()
+	}
+	log := logutil.FromContext(ctx, r.log)
+	binding := params.RoleBinding
+
+	if *binding.Subject == "" || *binding.ResourceID == "" {
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusBadRequest,
+			errors.New("the subject and the resource of a role binding must not be empty")))
+	}
+
+	allowed, err := r.canManage(ctx, binding)
+	if err != nil {
+		log.WithError(err).Errorf("failed to check the roles on %s %s", *binding.Scope, *binding.ResourceID)
+		return common.GenerateErrorResponder(err)
+	}
+	if !allowed {
+		// Users that can't see the resource must not learn that it exists
+		if canRead, _ := r.authzHandler.HasRoleOn(ctx, *binding.Scope, *binding.ResourceID, models.RoleBindingRoleViewer); canRead {
+			return common.GenerateErrorResponder(common.NewInfraError(http.StatusForbidden,
+				fmt.Errorf("only the admins of %s %s can grant roles on it", *binding.Scope, *binding.ResourceID)))
+		}
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusNotFound,
+			fmt.Errorf("%s %s not found", *binding.Scope, *binding.ResourceID)))
+	}
+
+	var count int64
+	err = r.db.Model(&models.RoleBinding{}).
+		Where("subject_kind = ? AND subject = ? AND role = ? AND scope = ? AND resource_id = ?",
+			*binding.SubjectKind, *binding.Subject, *binding.Role, *binding.Scope, *binding.ResourceID).
+		Count(&count).Error
+	if err != nil {
+		log.WithError(err).Error("failed to look for an existing role binding")
+		return common.GenerateErrorResponder(err)
+	}
+	if count > 0 {
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusConflict,
+			fmt.Errorf("%s %s already has role %s on %s %s", *binding.SubjectKind, *binding.Subject, *binding.Role, *binding.Scope, *binding.ResourceID)))
+	}
+
+	binding.ID = strfmt.UUID(uuid.New().String())
+	binding.CreatedAt = strfmt.DateTime(time.Now())
+	binding.CreatedBy = ocm.UserNameFromContext(ctx)
+	if err = r.db.Create(binding).Error; err != nil {
+		log.WithError(err).Error("failed to create role binding")
+		return common.GenerateErrorResponder(err)
+	}
+	log.Infof("User %s granted role %s on %s %s to %s %s", binding.CreatedBy, *binding.Role,
+		*binding.Scope, *binding.ResourceID, *binding.SubjectKind, *binding.Subject)
+	return operations.NewV2CreateRoleBindingCreated().WithPayload(binding)
+}
+
+func (r *RoleBindingsAPI) V2DeleteRoleBinding(ctx context.Context, params operations.V2DeleteRoleBindingParams) middleware.Responder {
+	if r.authzHandler == nil {
+		return r.notSupported()
+	}
+	log := logutil.FromContext(ctx, r.log)
+
+	var binding models.RoleBinding
+	if err := r.db.First(&binding, "id = ?", params.RoleBindingID.String()).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.GenerateErrorResponder(common.NewApiError(http.StatusNotFound,
+				fmt.Errorf("role binding %s not found", params.RoleBindingID)))
+		}
+		log.WithError(err).Errorf("failed to get role binding %s", params.RoleBindingID)
+		return common.GenerateErrorResponder(err)
+	}
+
+	allowed, err := r.canManage(ctx, &binding)
+	if err != nil {
+		log.WithError(err).Errorf("failed to check the roles on %s %s", *binding.Scope, *binding.ResourceID)
+		return common.GenerateErrorResponder(err)
+	}
+	if !allowed {
+		if isBoundTo(&binding, ocm.PayloadFromContext(ctx)) {
+			return common.GenerateErrorResponder(common.NewInfraError(http.StatusForbidden,
+				fmt.Errorf("only the admins of %s %s can revoke roles on it", *binding.Scope, *binding.ResourceID)))
+		}
+		return common.GenerateErrorResponder(common.NewApiError(http.StatusNotFound,
+			fmt.Errorf("role binding %s not found", params.RoleBindingID)))
+	}
+
+	if err = r.db.Delete(&models.RoleBinding{}, "id = ?", params.RoleBindingID.String()).Error; err != nil {
+		log.WithError(err).Errorf("failed to delete role binding %s", params.RoleBindingID)
+		return common.GenerateErrorResponder(err)
+	}
+	log.Infof("User %s revoked role %s on %s %s from %s %s", ocm.UserNameFromContext(ctx), *binding.Role,
+		*binding.Scope, *binding.ResourceID, *binding.SubjectKind, *binding.Subject)
+	return operations.NewV2DeleteRoleBindingNoContent()
+}
@@ -2,7 +2,10 @@ package error
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/swag"
 	. "github.com/onsi/ginkgo"
@@ -71,4 +74,29 @@ var _ = Describe("Error Utils", func() {
 		Expect(ase).Should(HaveOccurred())
 		Expect(ase.Error()).Should(Equal("test error"))
 	})
+
+	Context("quota exceeded", func() {
+		It("is a forbidden error for the quotas of resources", func() {
+			err := &QuotaExceededError{Scope: "org", Tenant: "12345", Resource: "clusters", Limit: 10, Usage: 10}
+			Expect(err.StatusCode()).Should(Equal(int32(http.StatusForbidden)))
+			Expect(err.Error()).Should(ContainSubstring("org 12345 reached its limit of 10 clusters"))
+		})
+
+		It("is a too many requests error for the quotas of rates", func() {
+			err := &QuotaExceededError{Scope: "user", Tenant: "jdoe", Resource: "iso-downloads", Limit: 5, Usage: 5, Period: time.Hour}
+			Expect(err.StatusCode()).Should(Equal(int32(http.StatusTooManyRequests)))
+			Expect(err.Error()).Should(Equal("quota exceeded: user jdoe reached its limit of 5 iso-downloads per 1h0m0s"))
+		})
+
+		It("is detected in wrapped errors and in the responses of the service", func() {
+			Expect(IsQuotaExceeded(fmt.Errorf("failed: %w", &QuotaExceededError{}))).Should(BeTrue())
+			Expect(IsQuotaExceeded(&installer.GetInfraEnvDownloadURLTooManyRequests{
+				Payload: &models.Error{Kind: swag.String(QuotaExceededKind)},
+			})).Should(BeTrue())
+			Expect(IsQuotaExceeded(&installer.GetInfraEnvDownloadURLNotFound{
+				Payload: &models.Error{Kind: swag.String("Error")},
+			})).Should(BeFalse())
+			Expect(IsQuotaExceeded(errors.New("test error"))).Should(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,58 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/swag"
+)
+
+// QuotaExceededKind is the kind of the error payloads returned when a tenant exceeds one of its quotas
+const QuotaExceededKind = "QuotaExceeded"
+
+// QuotaExceededError is returned when an organization or a user can't create more of a resource
+type QuotaExceededError struct {
+	// Scope is either org or user
+	Scope    string
+	Tenant   string
+	Resource string
+	Limit    int64
+	Usage    int64
+	// Period is only set for the quotas that limit a rate, e.g. of the ISO downloads
+	Period time.Duration
+	// RetryAfter is the time after which the usage of a rate quota will be below its limit
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Period > 0 {
+		return fmt.Sprintf("quota exceeded: %s %s reached its limit of %d %s per %s",
+			e.Scope, e.Tenant, e.Limit, e.Resource, e.Period)
+	}
+	return fmt.Sprintf("quota exceeded: %s %s reached its limit of %d %s, delete some of them or ask for a higher limit",
+		e.Scope, e.Tenant, e.Limit, e.Resource)
+}
+
+// StatusCode is 429 for the quotas that limit a rate, the request can be retried after a while, and
+// 403 for the quotas that limit a number of resources
+func (e *QuotaExceededError) StatusCode() int32 {
+	if e.Period > 0 {
+		return http.StatusTooManyRequests
+	}
+	return http.StatusForbidden
+}
+
+// IsQuotaExceeded returns true if the error, or the error response of the service, reports an
+// exceeded quota
+func IsQuotaExceeded(err error) bool {
+	var quotaErr *QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return true
+	}
+	if apiErr, ok := err.(AssistedServiceErrorAPI); ok && apiErr.GetPayload() != nil {
+		return swag.StringValue(apiErr.GetPayload().Kind) == QuotaExceededKind
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 
 	params "github.com/openshift/assisted-service/pkg/context"
 	"github.com/openshift/assisted-service/pkg/requestid"
+	"github.com/openshift/assisted-service/pkg/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,5 +23,9 @@ type Config struct {
 // FromContext equip a given logger with values from the given context
 func FromContext(ctx context.Context, inner logrus.FieldLogger) logrus.FieldLogger {
 	requestID := requestid.FromContext(ctx)
-	return requestid.RequestIDLogger(inner, requestID).WithFields(params.GetContextParams(ctx))
+	log := requestid.RequestIDLogger(inner, requestID).WithFields(params.GetContextParams(ctx))
+	if traceID := tracing.TraceIDFromContext(ctx); traceID != "" {
+		log = log.WithField("trace_id", traceID)
+	}
+	return log
 }
@@ -174,6 +174,31 @@ type AuthPayload struct {
 	Email        string   `json:"email"`
 	Issuer       string   `json:"iss"`
 	ClientID     string   `json:"clientId"`
+	Groups       []string `json:"groups,omitempty"`
 	Role         RoleType `json:"scope"`
 	IsAuthorized bool     `json:"is_authorized"`
+	// Set when the user was authenticated with an API token
+	APIToken *APITokenScope `json:"-"`
+	// Set when the agent was authenticated with a local agent token
+	AgentToken *AgentTokenScope `json:"-"`
+	// Set when the caller was authenticated by the agents authentication, i.e. the X-Secret-Key header
+	AgentAuth bool `json:"-"`
+}
+
+// AgentTokenScope identifies the local agent token an agent was authenticated with. HostID and ID
+// are only set for the tokens issued to a host.
+type AgentTokenScope struct {
+	ID         string
+	InfraEnvID string
+	HostID     string
+}
+
+// APITokenScope defines the resources and the actions an API token is limited to
+type APITokenScope struct {
+	ID   string
+	Name string
+	// The token can access all the resources of its owner when both lists are empty
+	ClusterIDs  []string
+	InfraEnvIDs []string
+	Actions     []string
 }
@@ -79,6 +79,26 @@ func OrgIDFromContext(ctx context.Context) string {
 	return payload.Organization
 }
 
+// GroupsFromContext returns the groups of the user from the specified context
+func GroupsFromContext(ctx context.Context) []string {
+	payload := PayloadFromContext(ctx)
+	return payload.Groups
+}
+
+// APITokenFromContext returns the scope of the API token the user was authenticated with,
+// nil when the user wasn't authenticated with an API token
+func APITokenFromContext(ctx context.Context) *APITokenScope {
+	payload := PayloadFromContext(ctx)
+	return payload.APIToken
+}
+
+// AgentTokenFromContext returns the local agent token the agent was authenticated with, nil when
+// the request wasn't authenticated with one
+func AgentTokenFromContext(ctx context.Context) *AgentTokenScope {
+	payload := PayloadFromContext(ctx)
+	return payload.AgentToken
+}
+
 // EmailFromContext returns email from the specified context
 func EmailFromContext(ctx context.Context) string {
 	payload := PayloadFromContext(ctx)
@@ -0,0 +1,128 @@
+package s3wrapper
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/openshift/assisted-service/pkg/tracing"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ API = &tracingClient{}
+
+// tracingClient adds a span to the trace of the context for each operation of the wrapped client
+type tracingClient struct {
+	API
+}
+
+// NewTracingClient wraps the storage client so its operations show up in the traces of the requests
+func NewTracingClient(client API) API {
+	return &tracingClient{API: client}
+}
+
+func (c *tracingClient) startSpan(ctx context.Context, operation, objectName string) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, "s3."+operation,
+		attribute.String("s3.operation", operation),
+		attribute.String("s3.object", objectName),
+		attribute.Bool("s3.aws", c.API.IsAwsS3()))
+}
+
+func (c *tracingClient) Upload(ctx context.Context, data []byte, objectName string) (err error) {
+	ctx, span := c.startSpan(ctx, "Upload", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.Upload(ctx, data, objectName)
+}
+
+func (c *tracingClient) UploadStream(ctx context.Context, reader io.Reader, objectName string) (err error) {
+	ctx, span := c.startSpan(ctx, "UploadStream", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.UploadStream(ctx, reader, objectName)
+}
+
+func (c *tracingClient) UploadFile(ctx context.Context, filePath, objectName string) (err error) {
+	ctx, span := c.startSpan(ctx, "UploadFile", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.UploadFile(ctx, filePath, objectName)
+}
+
+func (c *tracingClient) UploadWithMetadata(ctx context.Context, data []byte, objectName string, metadata map[string]string) (err error) {
+	ctx, span := c.startSpan(ctx, "UploadWithMetadata", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.UploadWithMetadata(ctx, data, objectName, metadata)
+}
+
+func (c *tracingClient) UploadStreamWithMetadata(ctx context.Context, reader io.Reader, objectName string, metadata map[string]string) (err error) {
+	ctx, span := c.startSpan(ctx, "UploadStreamWithMetadata", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.UploadStreamWithMetadata(ctx, reader, objectName, metadata)
+}
+
+func (c *tracingClient) UploadFileWithMetadata(ctx context.Context, filePath, objectName string, metadata map[string]string) (err error) {
+	ctx, span := c.startSpan(ctx, "UploadFileWithMetadata", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.UploadFileWithMetadata(ctx, filePath, objectName, metadata)
+}
+
+// Download only traces the start of the download, the returned reader is consumed by the caller
+func (c *tracingClient) Download(ctx context.Context, objectName string) (_ io.ReadCloser, _ int64, err error) {
+	ctx, span := c.startSpan(ctx, "Download", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.Download(ctx, objectName)
+}
+
+func (c *tracingClient) DoesObjectExist(ctx context.Context, objectName string) (_ bool, err error) {
+	ctx, span := c.startSpan(ctx, "DoesObjectExist", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.DoesObjectExist(ctx, objectName)
+}
+
+func (c *tracingClient) WaitForObject(ctx context.Context, objectName string) (err error) {
+	ctx, span := c.startSpan(ctx, "WaitForObject", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.WaitForObject(ctx, objectName)
+}
+
+func (c *tracingClient) DeleteObject(ctx context.Context, objectName string) (_ bool, err error) {
+	ctx, span := c.startSpan(ctx, "DeleteObject", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.DeleteObject(ctx, objectName)
+}
+
+func (c *tracingClient) GetObjectSizeBytes(ctx context.Context, objectName string) (_ int64, err error) {
+	ctx, span := c.startSpan(ctx, "GetObjectSizeBytes", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.GetObjectSizeBytes(ctx, objectName)
+}
+
+func (c *tracingClient) GeneratePresignedDownloadURL(ctx context.Context, objectName string, downloadFilename string, duration time.Duration) (_ string, err error) {
+	ctx, span := c.startSpan(ctx, "GeneratePresignedDownloadURL", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.GeneratePresignedDownloadURL(ctx, objectName, downloadFilename, duration)
+}
+
+func (c *tracingClient) UpdateObjectTimestamp(ctx context.Context, objectName string) (_ bool, err error) {
+	ctx, span := c.startSpan(ctx, "UpdateObjectTimestamp", objectName)
+	defer tracing.EndSpan(span, &err)
+	return c.API.UpdateObjectTimestamp(ctx, objectName)
+}
+
+func (c *tracingClient) ExpireObjects(ctx context.Context, prefix string, deleteTime time.Duration, callback func(ctx context.Context, log logrus.FieldLogger, objectName string)) {
+	ctx, span := c.startSpan(ctx, "ExpireObjects", prefix)
+	defer span.End()
+	c.API.ExpireObjects(ctx, prefix, deleteTime, callback)
+}
+
+func (c *tracingClient) ListObjectsByPrefix(ctx context.Context, prefix string) (_ []string, err error) {
+	ctx, span := c.startSpan(ctx, "ListObjectsByPrefix", prefix)
+	defer tracing.EndSpan(span, &err)
+	return c.API.ListObjectsByPrefix(ctx, prefix)
+}
+
+func (c *tracingClient) ListObjectsByPrefixWithMetadata(ctx context.Context, prefix string) (_ []ObjectInfo, err error) {
+	ctx, span := c.startSpan(ctx, "ListObjectsByPrefixWithMetadata", prefix)
+	defer tracing.EndSpan(span, &err)
+	return c.API.ListObjectsByPrefixWithMetadata(ctx, prefix)
+}
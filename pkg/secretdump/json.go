@@ -0,0 +1,111 @@
+package secretdump
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const redacted = "<SECRET>"
+
+// secretKeys are the parts of the names of the JSON fields that hold secrets,
+// e.g. pull_secret, ignition_endpoint_token or bmc_password
+var secretKeys = []string{"secret", "password", "token", "private_key", "kubeconfig", "credential"}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secretKey := range secretKeys {
+		if strings.Contains(key, secretKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// DumpSecretJSON generates a JSON representation of a JSON document with the
+// values of the secret fields removed, at any depth. The credentials of the
+// URLs, e.g. of the proxies, are removed as well.
+func DumpSecretJSON(data []byte) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+	return MarshalRedacted(value)
+}
+
+// MarshalRedacted returns the JSON representation of a value decoded from JSON without its secrets,
+// the redaction placeholders are not escaped
+func MarshalRedacted(value interface{}) (string, error) {
+	var sb strings.Builder
+	encoder := json.NewEncoder(&sb)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(RedactSecretValues(value)); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// RedactSecretValues removes the values of the secret fields from a value
+// decoded from JSON, and returns it. The strings that hold JSON documents,
+// e.g. the install config overrides, are redacted as well.
+func RedactSecretValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range v {
+			if isSecretKey(key) {
+				v[key] = redacted
+			} else {
+				v[key] = RedactSecretValues(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = RedactSecretValues(v[i])
+		}
+	case string:
+		if redactedJSON, ok := redactJSONString(v); ok {
+			return redactedJSON
+		}
+		return redactURLCredentials(v)
+	}
+	return value
+}
+
+// redactJSONString returns the JSON document held by a string without its
+// secrets, and false if the string isn't a JSON object or array
+func redactJSONString(value string) (string, bool) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	var document interface{}
+	if err := json.Unmarshal([]byte(trimmed), &document); err != nil {
+		return "", false
+	}
+	redactedJSON, err := MarshalRedacted(document)
+	if err != nil {
+		return "", false
+	}
+	return redactedJSON, true
+}
+
+// redactURLCredentials removes the password from the URLs, e.g. http://user:<SECRET>@proxy:8080
+func redactURLCredentials(value string) string {
+	schemeEnd := strings.Index(value, "://")
+	if schemeEnd < 0 {
+		return value
+	}
+	rest := value[schemeEnd+len("://"):]
+	authority := rest
+	if end := strings.IndexAny(rest, "/?#"); end >= 0 {
+		authority = rest[:end]
+	}
+	at := strings.LastIndex(authority, "@")
+	if at < 0 {
+		return value
+	}
+	colon := strings.Index(authority[:at], ":")
+	if colon < 0 {
+		return value
+	}
+	return value[:schemeEnd+len("://")+colon+1] + redacted + rest[at:]
+}
@@ -82,4 +82,57 @@ struct Example {
 			Expect(actual).To(Equal(expected))
 		})
 	})
+
+	Context("Dump secret JSON", func() {
+		It("redacts the secret fields at any depth", func() {
+			actual, err := DumpSecretJSON([]byte(`{
+				"name": "test",
+				"pull_secret": "{\"auths\": {}}",
+				"platform": {"vsphere": {"password": "pass", "username": "user"}},
+				"hosts": [{"bmc_credentials": {"user": "admin"}, "hostname": "host-0"}],
+				"ssh_public_key": "ssh-rsa AAAA"
+			}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(MatchJSON(`{
+				"name": "test",
+				"pull_secret": "<SECRET>",
+				"platform": {"vsphere": {"password": "<SECRET>", "username": "user"}},
+				"hosts": [{"bmc_credentials": "<SECRET>", "hostname": "host-0"}],
+				"ssh_public_key": "ssh-rsa AAAA"
+			}`))
+		})
+
+		It("redacts the passwords of the URLs", func() {
+			actual, err := DumpSecretJSON([]byte(`{
+				"http_proxy": "http://user:p@ss@proxy.example.com:8080/path",
+				"https_proxy": "http://user@proxy.example.com:8080",
+				"no_proxy": "example.com"
+			}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(MatchJSON(`{
+				"http_proxy": "http://user:<SECRET>@proxy.example.com:8080/path",
+				"https_proxy": "http://user@proxy.example.com:8080",
+				"no_proxy": "example.com"
+			}`))
+		})
+
+		It("redacts the secret fields of the JSON documents held by strings", func() {
+			actual, err := DumpSecretJSON([]byte(`{
+				"install_config_params": "{\"pullSecret\": \"{\\\"auths\\\": {}}\", \"platform\": {\"vsphere\": {\"password\": \"pass\"}}}",
+				"ignition_config_override": "[{\"token\": \"abc\"}]",
+				"description": "{not json"
+			}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(MatchJSON(`{
+				"install_config_params": "{\"platform\":{\"vsphere\":{\"password\":\"<SECRET>\"}},\"pullSecret\":\"<SECRET>\"}",
+				"ignition_config_override": "[{\"token\":\"<SECRET>\"}]",
+				"description": "{not json"
+			}`))
+		})
+
+		It("fails on invalid JSON", func() {
+			_, err := DumpSecretJSON([]byte(`{"pull_secret":`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
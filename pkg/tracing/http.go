@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	rmiddleware "github.com/go-openapi/runtime/middleware"
+	"github.com/openshift/assisted-service/pkg/requestid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush is needed by the handlers that stream their responses
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Middleware starts a server span for each request. The span continues the trace of the client when
+// the request carries a trace context, and the trace context is returned in the response so that
+// clients can find the trace of their requests.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := Tracer().Start(ctx, fmt.Sprintf("%s %s", strings.ToUpper(r.Method), r.URL.Path),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("user_agent.original", r.UserAgent()),
+			))
+		defer span.End()
+
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(w.Header()))
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		span.SetAttributes(attribute.Int("http.response.status_code", recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+	})
+}
+
+// RouteMiddleware names the server span after the operation matched by the API router and adds the
+// request ID to it. It must be an inner middleware, the route is unknown before the router runs.
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := trace.SpanFromContext(r.Context())
+		if mr := rmiddleware.MatchedRouteFrom(r); mr != nil {
+			if mr.Operation != nil && mr.Operation.ID != "" {
+				span.SetName(mr.Operation.ID)
+			}
+			span.SetAttributes(attribute.String("http.route", mr.PathPattern))
+		}
+		if requestID := requestid.FromContext(r.Context()); requestID != "" {
+			span.SetAttributes(attribute.String("request_id", requestID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/openshift/assisted-service"
+
+	SamplerAlwaysOn                = "always_on"
+	SamplerAlwaysOff               = "always_off"
+	SamplerTraceIDRatio            = "traceidratio"
+	SamplerParentBasedAlwaysOn     = "parentbased_always_on"
+	SamplerParentBasedTraceIDRatio = "parentbased_traceidratio"
+)
+
+// Config controls the export of the OpenTelemetry spans of the service
+type Config struct {
+	Enabled bool `envconfig:"TRACING_ENABLED" default:"false"`
+	// Endpoint is the host:port of the OTLP gRPC collector
+	Endpoint    string `envconfig:"TRACING_OTLP_ENDPOINT" default:"localhost:4317"`
+	Insecure    bool   `envconfig:"TRACING_OTLP_INSECURE" default:"false"`
+	ServiceName string `envconfig:"TRACING_SERVICE_NAME" default:"assisted-service"`
+	// Sampler is one of always_on, always_off, traceidratio, parentbased_always_on or parentbased_traceidratio
+	Sampler string `envconfig:"TRACING_SAMPLER" default:"parentbased_traceidratio"`
+	// SamplerRatio is the fraction of the traces that are sampled by the traceidratio samplers
+	SamplerRatio float64 `envconfig:"TRACING_SAMPLER_RATIO" default:"0.1"`
+}
+
+func newSampler(cfg Config) (sdktrace.Sampler, error) {
+	if cfg.SamplerRatio < 0 || cfg.SamplerRatio > 1 {
+		return nil, fmt.Errorf("invalid tracing sampler ratio %f, it must be between 0 and 1", cfg.SamplerRatio)
+	}
+	switch strings.ToLower(cfg.Sampler) {
+	case SamplerAlwaysOn:
+		return sdktrace.AlwaysSample(), nil
+	case SamplerAlwaysOff:
+		return sdktrace.NeverSample(), nil
+	case SamplerTraceIDRatio:
+		return sdktrace.TraceIDRatioBased(cfg.SamplerRatio), nil
+	case SamplerParentBasedAlwaysOn:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case SamplerParentBasedTraceIDRatio:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplerRatio)), nil
+	default:
+		return nil, fmt.Errorf("unsupported tracing sampler %s", cfg.Sampler)
+	}
+}
+
+// Init sets the global tracer provider and propagator. When tracing is disabled the spans are not
+// recorded, but the trace context received from the clients is still propagated. The returned
+// function flushes the pending spans and must be called before the process exits.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	sampler, err := newSampler(cfg)
+	if err != nil {
+		return nil, err
+	}
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the tracing resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the service, it doesn't record anything until Init enables tracing
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartSpan starts an internal span that is a child of the span of the context, if any
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records the error, if any, and ends the span. It is meant to be deferred with a pointer to
+// the named error result of the traced function.
+func EndSpan(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// TraceIDFromContext returns the ID of the trace of the context, or an empty string if there is no
+// valid trace
+func TraceIDFromContext(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// EnvFromContext returns the environment variables that pass the trace context of ctx to a child
+// process, following the OpenTelemetry environment carrier convention
+func EnvFromContext(ctx context.Context) []string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	env := make([]string, 0, len(carrier))
+	for _, key := range carrier.Keys() {
+		env = append(env, fmt.Sprintf("%s=%s", strings.ToUpper(key), carrier.Get(key)))
+	}
+	return env
+}
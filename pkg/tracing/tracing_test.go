@@ -0,0 +1,149 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/pkg/requestid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tracing")
+}
+
+// spanRecorder keeps the ended spans in memory
+type spanRecorder struct {
+	mutex sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (r *spanRecorder) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+func (r *spanRecorder) Shutdown(context.Context) error                  { return nil }
+func (r *spanRecorder) ForceFlush(context.Context) error                { return nil }
+
+func (r *spanRecorder) OnEnd(span sdktrace.ReadOnlySpan) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+func (r *spanRecorder) ended() []sdktrace.ReadOnlySpan {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.spans
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, attr := range span.Attributes() {
+		if string(attr.Key) == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+var _ = Describe("newSampler", func() {
+	It("creates the configured sampler", func() {
+		sampler, err := newSampler(Config{Sampler: SamplerAlwaysOff})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sampler.Description()).To(Equal("AlwaysOffSampler"))
+
+		sampler, err = newSampler(Config{Sampler: SamplerParentBasedTraceIDRatio, SamplerRatio: 0.5})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sampler.Description()).To(ContainSubstring("TraceIDRatioBased{0.5}"))
+	})
+
+	It("rejects an unsupported sampler", func() {
+		_, err := newSampler(Config{Sampler: "sometimes"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects an invalid ratio", func() {
+		_, err := newSampler(Config{Sampler: SamplerTraceIDRatio, SamplerRatio: 2})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Tracing", func() {
+	var (
+		recorder *spanRecorder
+		previous trace.TracerProvider
+	)
+
+	BeforeEach(func() {
+		recorder = &spanRecorder{}
+		previous = otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		_, err := Init(context.Background(), Config{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	Context("Middleware", func() {
+		It("continues the trace of the client", func() {
+			var traceID string
+			handler := Middleware(requestid.Middleware(RouteMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				traceID = TraceIDFromContext(r.Context())
+				w.WriteHeader(http.StatusInternalServerError)
+			}))))
+			request := httptest.NewRequest(http.MethodGet, "/api/assisted-install/v2/clusters", nil)
+			request.Header.Set("traceparent", traceParent)
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+
+			Expect(traceID).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(response.Header().Get("traceparent")).To(ContainSubstring(traceID))
+			spans := recorder.ended()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name()).To(Equal("GET /api/assisted-install/v2/clusters"))
+			Expect(spans[0].SpanKind()).To(Equal(trace.SpanKindServer))
+			Expect(spans[0].Parent().SpanID().String()).To(Equal("00f067aa0ba902b7"))
+			Expect(attributeValue(spans[0], "http.response.status_code").AsInt64()).To(Equal(int64(500)))
+			Expect(attributeValue(spans[0], "request_id").AsString()).ToNot(BeEmpty())
+			Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		})
+	})
+
+	It("records the errors of the spans", func() {
+		traced := func(ctx context.Context) (err error) {
+			_, span := StartSpan(ctx, "operation", attribute.String("key", "value"))
+			defer EndSpan(span, &err)
+			return errors.New("failed")
+		}
+		Expect(traced(context.Background())).To(HaveOccurred())
+		spans := recorder.ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name()).To(Equal("operation"))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(attributeValue(spans[0], "key").AsString()).To(Equal("value"))
+	})
+
+	It("passes the trace context to child processes", func() {
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(),
+			propagation.MapCarrier{"traceparent": traceParent})
+		Expect(EnvFromContext(ctx)).To(ContainElement("TRACEPARENT=" + traceParent))
+		Expect(EnvFromContext(context.Background())).To(BeEmpty())
+	})
+
+	It("returns no trace ID without a trace", func() {
+		Expect(TraceIDFromContext(context.Background())).To(BeEmpty())
+	})
+})
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -155,18 +156,7 @@ func (a *AgentClassificationValidatingAdmissionHook) validateCreate(admissionSpe
 		}
 	}
 
-	// Validate the specified label key and value
-	f := field.NewPath("spec")
-	errs := validation.ValidateLabels(map[string]string{ClassificationLabelPrefix + newObject.Spec.LabelKey: newObject.Spec.LabelValue}, f)
-	if strings.HasPrefix(newObject.Spec.LabelValue, "QUERYERROR") {
-		errs = append(errs, field.Invalid(f, newObject.Spec.LabelValue, "label must not start with QUERYERROR as this is reserved"))
-	}
-
-	// Validate that we can parse the specified query
-	_, err := gojq.Parse(newObject.Spec.Query)
-	if err != nil {
-		errs = append(errs, field.Invalid(f, newObject.Spec.Query, err.Error()))
-	}
+	errs := validateClassificationSpec(&newObject.Spec, field.NewPath("spec"))
 
 	if len(errs) > 0 {
 		contextLogger.Infof("Validation failed: %s", errs.ToAggregate().Error())
@@ -233,9 +223,92 @@ func (a *AgentClassificationValidatingAdmissionHook) validateUpdate(admissionSpe
 		}
 	}
 
+	// Validate the query or the rules, they may be modified after creation
+	if errs := validateClassificationSpec(&newObject.Spec, field.NewPath("spec")); len(errs) > 0 {
+		contextLogger.Infof("Validation failed: %s", errs.ToAggregate().Error())
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: errs.ToAggregate().Error(),
+			},
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1.AdmissionResponse{
 		Allowed: true,
 	}
 }
+
+// validateClassificationSpec validates that the classification has exactly one of a query with a
+// label value, or rules, and that the label key and the label values are valid
+func validateClassificationSpec(spec *v1beta1.AgentClassificationSpec, f *field.Path) field.ErrorList {
+	if len(spec.Rules) > 0 {
+		var errs field.ErrorList
+		if spec.Query != "" || spec.LabelValue != "" {
+			errs = append(errs, field.Forbidden(f.Child("rules"), "rules may not be specified together with query and labelValue"))
+		}
+		errs = append(errs, validation.ValidateLabels(map[string]string{ClassificationLabelPrefix + spec.LabelKey: ""}, f)...)
+		return append(errs, validateClassificationRules(spec.Rules, f.Child("rules"))...)
+	}
+
+	// Validate the specified label key and value
+	errs := validateClassificationLabel(spec.LabelKey, spec.LabelValue, f)
+	if spec.Query == "" {
+		errs = append(errs, field.Required(f.Child("query"), "either query or rules must be specified"))
+	}
+
+	// Validate that we can parse the specified query
+	if _, err := gojq.Parse(spec.Query); err != nil {
+		errs = append(errs, field.Invalid(f, spec.Query, err.Error()))
+	}
+	return errs
+}
+
+func validateClassificationLabel(labelKey, labelValue string, f *field.Path) field.ErrorList {
+	errs := validation.ValidateLabels(map[string]string{ClassificationLabelPrefix + labelKey: labelValue}, f)
+	if strings.HasPrefix(labelValue, "QUERYERROR") {
+		errs = append(errs, field.Invalid(f, labelValue, "label must not start with QUERYERROR as this is reserved"))
+	}
+	return errs
+}
+
+// validateClassificationRules validates that the queries of the rules can be parsed and that the
+// label values they produce are valid
+func validateClassificationRules(rules []v1beta1.AgentClassificationRule, f *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for i, rule := range rules {
+		rulePath := f.Index(i)
+		if rule.Name != "" && names[rule.Name] {
+			errs = append(errs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		names[rule.Name] = true
+		if _, err := gojq.Parse(rule.Query); err != nil {
+			errs = append(errs, field.Invalid(rulePath.Child("query"), rule.Query, err.Error()))
+		}
+		if rule.LabelValue != "" {
+			errs = append(errs, validateClassificationLabelValue(rule.LabelValue, rulePath.Child("labelValue"))...)
+		}
+		for j, tier := range rule.Tiers {
+			errs = append(errs, validateClassificationLabelValue(tier.LabelValue, rulePath.Child("tiers").Index(j).Child("labelValue"))...)
+		}
+	}
+	return errs
+}
+
+func validateClassificationLabelValue(labelValue string, f *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if labelValue == "" {
+		errs = append(errs, field.Required(f, "label value must not be empty"))
+	}
+	for _, msg := range utilvalidation.IsValidLabelValue(labelValue) {
+		errs = append(errs, field.Invalid(f, labelValue, msg))
+	}
+	if strings.HasPrefix(labelValue, "QUERYERROR") {
+		errs = append(errs, field.Invalid(f, labelValue, "label must not start with QUERYERROR as this is reserved"))
+	}
+	return errs
+}
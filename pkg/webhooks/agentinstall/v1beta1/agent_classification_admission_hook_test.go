@@ -51,6 +51,23 @@ var _ = Describe("agent classification web validate", func() {
 		invalidValue  = "med!um"
 		validQuery    = ".cpu.count == 2 and .memory.physicalBytes >= 4294967296 and .memory.physicalBytes < 8589934592"
 		invalidQuery  = ".cpu.count == 2 and"
+		validRules    = []v1beta1.AgentClassificationRule{
+			{
+				Name:       "gpu",
+				Priority:   10,
+				Query:      "[.gpus[]?] | length > 0",
+				LabelValue: "gpu",
+			},
+			{
+				Name:  "size",
+				Query: ".cpu.count",
+				Tiers: []v1beta1.AgentClassificationTier{
+					{LabelValue: "small", MinScore: 0},
+					{LabelValue: "medium", MinScore: 8},
+					{LabelValue: "large", MinScore: 32},
+				},
+			},
+		}
 	)
 	cases := []struct {
 		name            string
@@ -211,6 +228,129 @@ var _ = Describe("agent classification web validate", func() {
 			operation:       admissionv1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test AgentClassification rules are valid on create",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    validRules,
+			},
+			oldSpec:         v1beta1.AgentClassificationSpec{},
+			operation:       admissionv1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test AgentClassification rules with query and label value on create",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey:   validKey,
+				LabelValue: validValue,
+				Query:      validQuery,
+				Rules:      validRules,
+			},
+			oldSpec:         v1beta1.AgentClassificationSpec{},
+			operation:       admissionv1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test AgentClassification rule query is invalid on create",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    []v1beta1.AgentClassificationRule{{Name: "size", Query: invalidQuery, LabelValue: validValue}},
+			},
+			oldSpec:         v1beta1.AgentClassificationSpec{},
+			operation:       admissionv1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test AgentClassification rule tier label value is invalid on create",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules: []v1beta1.AgentClassificationRule{{
+					Name:  "size",
+					Query: ".cpu.count",
+					Tiers: []v1beta1.AgentClassificationTier{{LabelValue: invalidValue, MinScore: 8}},
+				}},
+			},
+			oldSpec:         v1beta1.AgentClassificationSpec{},
+			operation:       admissionv1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test AgentClassification rule label value is query error on create",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    []v1beta1.AgentClassificationRule{{Name: "size", Query: validQuery, LabelValue: "QUERYERROR-foo"}},
+			},
+			oldSpec:         v1beta1.AgentClassificationSpec{},
+			operation:       admissionv1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test AgentClassification rule names are duplicated on create",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules: []v1beta1.AgentClassificationRule{
+					{Name: "size", Query: validQuery, LabelValue: validValue},
+					{Name: "size", Query: ".cpu.count > 32", LabelValue: "large"},
+				},
+			},
+			oldSpec:         v1beta1.AgentClassificationSpec{},
+			operation:       admissionv1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test AgentClassification rules are changed on update",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    validRules[1:],
+			},
+			oldSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    validRules,
+			},
+			operation:       admissionv1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test AgentClassification rule query is invalid on update",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    []v1beta1.AgentClassificationRule{{Name: "size", Query: invalidQuery, LabelValue: validValue}},
+			},
+			oldSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    validRules,
+			},
+			operation:       admissionv1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test AgentClassification rules are added to a query on update",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey:   validKey,
+				LabelValue: validValue,
+				Query:      validQuery,
+				Rules:      validRules,
+			},
+			oldSpec: v1beta1.AgentClassificationSpec{
+				LabelKey:   validKey,
+				LabelValue: validValue,
+				Query:      validQuery,
+			},
+			operation:       admissionv1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test AgentClassification rules are removed without query on update",
+			newSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+			},
+			oldSpec: v1beta1.AgentClassificationSpec{
+				LabelKey: validKey,
+				Rules:    validRules,
+			},
+			operation:       admissionv1.Update,
+			expectedAllowed: false,
+		},
 	}
 
 	for i := range cases {
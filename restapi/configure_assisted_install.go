@@ -15,11 +15,16 @@ import (
 	"github.com/go-openapi/runtime/security"
 
 	"github.com/openshift/assisted-service/restapi/operations"
+	"github.com/openshift/assisted-service/restapi/operations/agent_tokens"
+	"github.com/openshift/assisted-service/restapi/operations/audit"
+	"github.com/openshift/assisted-service/restapi/operations/authz"
 	"github.com/openshift/assisted-service/restapi/operations/events"
 	"github.com/openshift/assisted-service/restapi/operations/installer"
 	"github.com/openshift/assisted-service/restapi/operations/managed_domains"
 	"github.com/openshift/assisted-service/restapi/operations/manifests"
 	"github.com/openshift/assisted-service/restapi/operations/operators"
+	"github.com/openshift/assisted-service/restapi/operations/quotas"
+	"github.com/openshift/assisted-service/restapi/operations/tokens"
 	"github.com/openshift/assisted-service/restapi/operations/versions"
 )
 
@@ -27,15 +32,58 @@ type contextKey string
 
 const AuthKey contextKey = "Auth"
 
+//go:generate mockery -name AgentTokensAPI -inpkg
+
+/* AgentTokensAPI  */
+type AgentTokensAPI interface {
+
+	/* V2RevokeHostAgentToken Revokes the agent tokens of a host, the host can no longer authenticate, and rotates the agent tokens of its infra-env, the other hosts get tokens of their own. */
+	V2RevokeHostAgentToken(ctx context.Context, params agent_tokens.V2RevokeHostAgentTokenParams) middleware.Responder
+
+	/* V2RotateAgentTokens Rotates the agent tokens of the infra-env. The previous tokens remain valid during the overlap, and the registered hosts receive a new token with their next steps. */
+	V2RotateAgentTokens(ctx context.Context, params agent_tokens.V2RotateAgentTokensParams) middleware.Responder
+}
+
+//go:generate mockery -name AuditAPI -inpkg
+
+/* AuditAPI  */
+type AuditAPI interface {
+	/* V2ListAuditRecords Lists the audit records of the calls that modified the resources of the service, the most recent first. */
+	V2ListAuditRecords(ctx context.Context, params audit.V2ListAuditRecordsParams) middleware.Responder
+}
+
+//go:generate mockery -name AuthzAPI -inpkg
+
+/* AuthzAPI  */
+type AuthzAPI interface {
+	/* V2CreateRoleBinding Grants a role on a cluster, an infra-env or a tenant to a user or a group. */
+	V2CreateRoleBinding(ctx context.Context, params authz.V2CreateRoleBindingParams) middleware.Responder
+
+	/* V2DeleteRoleBinding Revokes a role binding. */
+	V2DeleteRoleBinding(ctx context.Context, params authz.V2DeleteRoleBindingParams) middleware.Responder
+
+	/* V2ListRoleBindings Lists the role bindings of the resources the user administers. */
+	V2ListRoleBindings(ctx context.Context, params authz.V2ListRoleBindingsParams) middleware.Responder
+}
+
 //go:generate mockery -name EventsAPI -inpkg
 
 /* EventsAPI  */
 type EventsAPI interface {
+	/* V2AggregateEvents Counts the events of a cluster or an infra-env per time bucket, optionally grouped by name and severity. */
+	V2AggregateEvents(ctx context.Context, params events.V2AggregateEventsParams) middleware.Responder
+
+	/* V2GetEventRateLimits Lists the effective event rate limits. */
+	V2GetEventRateLimits(ctx context.Context, params events.V2GetEventRateLimitsParams) middleware.Responder
+
 	/* V2ListEvents Lists events for a cluster. */
 	V2ListEvents(ctx context.Context, params events.V2ListEventsParams) middleware.Responder
 
 	/* V2TriggerEvent Add new assisted installer event. */
 	V2TriggerEvent(ctx context.Context, params events.V2TriggerEventParams) middleware.Responder
+
+	/* V2UpdateEventRateLimits Replaces the event rate limits overridden through the API. */
+	V2UpdateEventRateLimits(ctx context.Context, params events.V2UpdateEventRateLimitsParams) middleware.Responder
 }
 
 //go:generate mockery -name InstallerAPI -inpkg
@@ -290,6 +338,28 @@ type OperatorsAPI interface {
 	V2ReportMonitoredOperatorStatus(ctx context.Context, params operators.V2ReportMonitoredOperatorStatusParams) middleware.Responder
 }
 
+//go:generate mockery -name QuotasAPI -inpkg
+
+/* QuotasAPI  */
+type QuotasAPI interface {
+	/* V2ListQuotas Lists the usage of the resources by the organization and the user of the caller, and the limits of their quotas. */
+	V2ListQuotas(ctx context.Context, params quotas.V2ListQuotasParams) middleware.Responder
+}
+
+//go:generate mockery -name TokensAPI -inpkg
+
+/* TokensAPI  */
+type TokensAPI interface {
+	/* V2CreateAPIToken Creates an API token that makes requests on behalf of the user, limited to the given resources and actions. */
+	V2CreateAPIToken(ctx context.Context, params tokens.V2CreateAPITokenParams) middleware.Responder
+
+	/* V2ListAPITokens Lists the API tokens of the user, the secrets of the tokens are not returned. */
+	V2ListAPITokens(ctx context.Context, params tokens.V2ListAPITokensParams) middleware.Responder
+
+	/* V2RevokeAPIToken Revokes an API token, the requests made with the token are rejected from now on. */
+	V2RevokeAPIToken(ctx context.Context, params tokens.V2RevokeAPITokenParams) middleware.Responder
+}
+
 //go:generate mockery -name VersionsAPI -inpkg
 
 /* VersionsAPI  */
@@ -306,11 +376,16 @@ type VersionsAPI interface {
 
 // Config is configuration for Handler
 type Config struct {
+	AgentTokensAPI
+	AuditAPI
+	AuthzAPI
 	EventsAPI
 	InstallerAPI
 	ManagedDomainsAPI
 	ManifestsAPI
 	OperatorsAPI
+	QuotasAPI
+	TokensAPI
 	VersionsAPI
 	Logger func(string, ...interface{})
 	// InnerMiddleware is for the handler executors. These do not apply to the swagger.json document.
@@ -526,16 +601,31 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.UpdateInfraEnv(ctx, params)
 	})
+	api.EventsV2AggregateEventsHandler = events.V2AggregateEventsHandlerFunc(func(params events.V2AggregateEventsParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.EventsAPI.V2AggregateEvents(ctx, params)
+	})
 	api.InstallerV2CancelInstallationHandler = installer.V2CancelInstallationHandlerFunc(func(params installer.V2CancelInstallationParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.V2CancelInstallation(ctx, params)
 	})
+	api.TokensV2CreateAPITokenHandler = tokens.V2CreateAPITokenHandlerFunc(func(params tokens.V2CreateAPITokenParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.TokensAPI.V2CreateAPIToken(ctx, params)
+	})
 	api.ManifestsV2CreateClusterManifestHandler = manifests.V2CreateClusterManifestHandlerFunc(func(params manifests.V2CreateClusterManifestParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
 		return c.ManifestsAPI.V2CreateClusterManifest(ctx, params)
 	})
+	api.AuthzV2CreateRoleBindingHandler = authz.V2CreateRoleBindingHandlerFunc(func(params authz.V2CreateRoleBindingParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.AuthzAPI.V2CreateRoleBinding(ctx, params)
+	})
 	api.ManifestsV2DeleteClusterManifestHandler = manifests.V2DeleteClusterManifestHandlerFunc(func(params manifests.V2DeleteClusterManifestParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
@@ -586,6 +676,16 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.V2GetPresignedForClusterFiles(ctx, params)
 	})
+	api.TokensV2ListAPITokensHandler = tokens.V2ListAPITokensHandlerFunc(func(params tokens.V2ListAPITokensParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.TokensAPI.V2ListAPITokens(ctx, params)
+	})
+	api.AuditV2ListAuditRecordsHandler = audit.V2ListAuditRecordsHandlerFunc(func(params audit.V2ListAuditRecordsParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.AuditAPI.V2ListAuditRecords(ctx, params)
+	})
 	api.OperatorsV2ListBundlesHandler = operators.V2ListBundlesHandlerFunc(func(params operators.V2ListBundlesParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
@@ -641,6 +741,11 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.V2CompleteInstallation(ctx, params)
 	})
+	api.AuthzV2DeleteRoleBindingHandler = authz.V2DeleteRoleBindingHandlerFunc(func(params authz.V2DeleteRoleBindingParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.AuthzAPI.V2DeleteRoleBinding(ctx, params)
+	})
 	api.InstallerV2DeregisterClusterHandler = installer.V2DeregisterClusterHandlerFunc(func(params installer.V2DeregisterClusterParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
@@ -676,6 +781,11 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.V2GetClusterInstallConfig(ctx, params)
 	})
+	api.EventsV2GetEventRateLimitsHandler = events.V2GetEventRateLimitsHandlerFunc(func(params events.V2GetEventRateLimitsParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.EventsAPI.V2GetEventRateLimits(ctx, params)
+	})
 	api.InstallerV2GetHostHandler = installer.V2GetHostHandlerFunc(func(params installer.V2GetHostParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
@@ -736,11 +846,21 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.V2ListHosts(ctx, params)
 	})
+	api.QuotasV2ListQuotasHandler = quotas.V2ListQuotasHandlerFunc(func(params quotas.V2ListQuotasParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.QuotasAPI.V2ListQuotas(ctx, params)
+	})
 	api.VersionsV2ListReleaseSourcesHandler = versions.V2ListReleaseSourcesHandlerFunc(func(params versions.V2ListReleaseSourcesParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
 		return c.VersionsAPI.V2ListReleaseSources(ctx, params)
 	})
+	api.AuthzV2ListRoleBindingsHandler = authz.V2ListRoleBindingsHandlerFunc(func(params authz.V2ListRoleBindingsParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.AuthzAPI.V2ListRoleBindings(ctx, params)
+	})
 	api.VersionsV2ListSupportedOpenshiftVersionsHandler = versions.V2ListSupportedOpenshiftVersionsHandlerFunc(func(params versions.V2ListSupportedOpenshiftVersionsParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
@@ -786,6 +906,21 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.V2ResetHostValidation(ctx, params)
 	})
+	api.TokensV2RevokeAPITokenHandler = tokens.V2RevokeAPITokenHandlerFunc(func(params tokens.V2RevokeAPITokenParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.TokensAPI.V2RevokeAPIToken(ctx, params)
+	})
+	api.AgentTokensV2RevokeHostAgentTokenHandler = agent_tokens.V2RevokeHostAgentTokenHandlerFunc(func(params agent_tokens.V2RevokeHostAgentTokenParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.AgentTokensAPI.V2RevokeHostAgentToken(ctx, params)
+	})
+	api.AgentTokensV2RotateAgentTokensHandler = agent_tokens.V2RotateAgentTokensHandlerFunc(func(params agent_tokens.V2RotateAgentTokensParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.AgentTokensAPI.V2RotateAgentTokens(ctx, params)
+	})
 	api.InstallerV2SetIgnoredValidationsHandler = installer.V2SetIgnoredValidationsHandlerFunc(func(params installer.V2SetIgnoredValidationsParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
@@ -811,6 +946,11 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx = storeAuth(ctx, principal)
 		return c.InstallerAPI.V2UpdateClusterLogsProgress(ctx, params)
 	})
+	api.EventsV2UpdateEventRateLimitsHandler = events.V2UpdateEventRateLimitsHandlerFunc(func(params events.V2UpdateEventRateLimitsParams, principal interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		ctx = storeAuth(ctx, principal)
+		return c.EventsAPI.V2UpdateEventRateLimits(ctx, params)
+	})
 	api.InstallerV2UpdateHostHandler = installer.V2UpdateHostHandlerFunc(func(params installer.V2UpdateHostParams, principal interface{}) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		ctx = storeAuth(ctx, principal)
@@ -41,6 +41,257 @@ func init() {
   "host": "api.openshift.com",
   "basePath": "/api/assisted-install",
   "paths": {
+    "/v2/api-tokens": {
+      "get": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Lists the API tokens of the user, the secrets of the tokens are not returned.",
+        "tags": [
+          "tokens"
+        ],
+        "operationId": "v2ListAPITokens",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/api-tokens"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Creates an API token that makes requests on behalf of the user, limited to the given resources and actions.",
+        "tags": [
+          "tokens"
+        ],
+        "operationId": "v2CreateAPIToken",
+        "parameters": [
+          {
+            "description": "The parameters of the token to create.",
+            "name": "new-api-token-params",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/api-token-create-params"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/api-token"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/api-tokens/{api_token_id}": {
+      "delete": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Revokes an API token, the requests made with the token are rejected from now on.",
+        "tags": [
+          "tokens"
+        ],
+        "operationId": "v2RevokeAPIToken",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The API token to revoke.",
+            "name": "api_token_id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success."
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/audit-records": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin"
+            ]
+          }
+        ],
+        "description": "Lists the audit records of the calls that modified the resources of the service, the most recent first.",
+        "tags": [
+          "audit"
+        ],
+        "operationId": "v2ListAuditRecords",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only the records of the calls received at or after this time.",
+            "name": "start_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only the records of the calls received before this time.",
+            "name": "end_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only the records of the calls made by this user.",
+            "name": "actor",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "Only the records of the calls made on this cluster.",
+            "name": "cluster_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "Only the records of the calls made on this infra-env.",
+            "name": "infra_env_id",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "The maximum number of records to return, 1000 by default.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "The number of records to skip before returning the rest.",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/audit-records"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/v2/clusters": {
       "get": {
         "security": [
@@ -3224,6 +3475,60 @@ func init() {
             "description": "A comma-separated list of event categories.",
             "name": "categories",
             "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Also return events that were archived to object storage by the events retention policy.",
+            "name": "include_archived",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "Retrieved events names.",
+            "name": "names",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only retrieve the events that occurred at or after this time.",
+            "name": "start_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only retrieve the events that occurred before this time.",
+            "name": "end_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "Only retrieve the events caused by this request.",
+            "name": "request_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "A JSON object that the properties of the retrieved events must contain.",
+            "name": "props",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Full-text search over the messages of the retrieved events.",
+            "name": "search",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Cursor returned in the Next-Cursor header of the previous page. Used instead of offset, it can't be combined with include_archived.",
+            "name": "cursor",
+            "in": "query"
           }
         ],
         "responses": {
@@ -3237,6 +3542,10 @@ func init() {
                 "type": "integer",
                 "description": "Count of events retrieved."
               },
+              "Next-Cursor": {
+                "type": "string",
+                "description": "Cursor to pass to retrieve the next page of events, empty when there are no more events."
+              },
               "Severity-Count-Critical": {
                 "type": "integer",
                 "description": "Count of events with severity 'critical'."
@@ -3370,7 +3679,7 @@ func init() {
         }
       }
     },
-    "/v2/infra-env/{infra_env_id}/hosts/{host_id}/downloads/ignition": {
+    "/v2/events/aggregations": {
       "get": {
         "security": [
           {
@@ -3379,8 +3688,251 @@ func init() {
               "read-only-admin",
               "user"
             ]
-          },
-          {
+          }
+        ],
+        "description": "Counts the events of a cluster or an infra-env per time bucket, optionally grouped by name and severity.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "v2AggregateEvents",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The cluster to aggregate events for.",
+            "name": "cluster_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The infra-env to aggregate events for.",
+            "name": "infra_env_id",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "Only count the events with these names.",
+            "name": "names",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "info",
+                "warning",
+                "error",
+                "critical"
+              ],
+              "type": "string"
+            },
+            "description": "Only count the events with these severities.",
+            "name": "severities",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "A comma-separated list of event categories.",
+            "name": "categories",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only count the events that occurred at or after this time.",
+            "name": "start_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only count the events that occurred before this time.",
+            "name": "end_time",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "minute",
+              "hour",
+              "day"
+            ],
+            "type": "string",
+            "description": "Size of the time buckets, defaults to hour.",
+            "name": "interval",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "name",
+                "severity"
+              ],
+              "type": "string"
+            },
+            "description": "Fields to group the events by in each time bucket.",
+            "name": "group_by",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/event-aggregation-list"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/events/rate-limits": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin"
+            ]
+          }
+        ],
+        "description": "Lists the effective event rate limits.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "v2GetEventRateLimits",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/event-rate-limits"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "security": [
+          {
+            "userAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "description": "Replaces the event rate limits overridden through the API.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "v2UpdateEventRateLimits",
+        "parameters": [
+          {
+            "description": "The event rate limits to apply on top of the configured ones.",
+            "name": "rate-limits",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/event-rate-limits"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/event-rate-limits"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/infra-env/{infra_env_id}/hosts/{host_id}/downloads/ignition": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin",
+              "user"
+            ]
+          },
+          {
             "agentAuth": []
           }
         ],
@@ -3852,6 +4404,80 @@ func init() {
         }
       }
     },
+    "/v2/infra-envs/{infra_env_id}/agent-tokens/rotate": {
+      "post": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Rotates the agent tokens of the infra-env. The previous tokens remain valid during the overlap, and the registered hosts receive a new token with their next steps.",
+        "tags": [
+          "agent_tokens"
+        ],
+        "operationId": "v2RotateAgentTokens",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The infra-env whose agent tokens are rotated.",
+            "name": "infra_env_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "The parameters of the rotation.",
+            "name": "rotate-params",
+            "in": "body",
+            "required": false,
+            "schema": {
+              "$ref": "#/definitions/agent-tokens-rotate-params"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/agent-tokens-rotation"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/v2/infra-envs/{infra_env_id}/downloads/files": {
       "get": {
         "security": [
@@ -4141,6 +4767,12 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
+          "429": {
+            "description": "Too many requests.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -5017,23 +5649,93 @@ func init() {
           "409": {
             "description": "Conflict.",
             "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "501": {
+            "description": "Not implemented.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "503": {
+            "description": "Unavailable.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/infra-envs/{infra_env_id}/hosts/{host_id}/agent-token/revoke": {
+      "post": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Revokes the agent tokens of a host, the host can no longer authenticate, and rotates the agent tokens of its infra-env, the other hosts get tokens of their own.",
+        "tags": [
+          "agent_tokens"
+        ],
+        "operationId": "v2RevokeHostAgentToken",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The infra-env of the host.",
+            "name": "infra_env_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The host whose agent tokens are revoked.",
+            "name": "host_id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success."
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
             }
           },
-          "500": {
-            "description": "Error.",
+          "403": {
+            "description": "Forbidden.",
             "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/infra_error"
             }
           },
-          "501": {
-            "description": "Not implemented.",
+          "404": {
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
           },
-          "503": {
-            "description": "Unavailable.",
+          "500": {
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -5901,20 +6603,295 @@ func init() {
               "$ref": "#/definitions/bundle"
             }
           },
-          "400": {
-            "description": "Error.",
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "404": {
+            "description": "Bundle not found",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/quotas": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Lists the usage of the resources by the organization and the user of the caller, and the limits of their quotas.",
+        "tags": [
+          "quotas"
+        ],
+        "operationId": "v2ListQuotas",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The organization to report, only the admins can report another organization.",
+            "name": "org_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The user to report, only the admins can report another user.",
+            "name": "user_name",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/quota-usages"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/release-sources": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Retrieves openshift release sources configuration.",
+        "tags": [
+          "versions"
+        ],
+        "operationId": "v2ListReleaseSources",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/release-sources"
+            }
+          }
+        }
+      }
+    },
+    "/v2/role-bindings": {
+      "get": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Lists the role bindings of the resources the user administers.",
+        "tags": [
+          "authz"
+        ],
+        "operationId": "v2ListRoleBindings",
+        "parameters": [
+          {
+            "enum": [
+              "cluster",
+              "infra-env",
+              "tenant"
+            ],
+            "type": "string",
+            "description": "Only list the role bindings of this kind of resource.",
+            "name": "scope",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only list the role bindings of this resource.",
+            "name": "resource_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only list the role bindings granted to this user or group.",
+            "name": "subject",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/role-bindings"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Grants a role on a cluster, an infra-env or a tenant to a user or a group.",
+        "tags": [
+          "authz"
+        ],
+        "operationId": "v2CreateRoleBinding",
+        "parameters": [
+          {
+            "description": "The role binding to create.",
+            "name": "role-binding",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/role-binding"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/role-binding"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/role-bindings/{role_binding_id}": {
+      "delete": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Revokes a role binding.",
+        "tags": [
+          "authz"
+        ],
+        "operationId": "v2DeleteRoleBinding",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The role binding to be deleted.",
+            "name": "role_binding_id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success."
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
             "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/infra_error"
             }
           },
           "404": {
-            "description": "Bundle not found",
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
           },
           "500": {
-            "description": "Internal server error",
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -5922,32 +6899,6 @@ func init() {
         }
       }
     },
-    "/v2/release-sources": {
-      "get": {
-        "security": [
-          {
-            "userAuth": [
-              "admin",
-              "read-only-admin",
-              "user"
-            ]
-          }
-        ],
-        "description": "Retrieves openshift release sources configuration.",
-        "tags": [
-          "versions"
-        ],
-        "operationId": "v2ListReleaseSources",
-        "responses": {
-          "200": {
-            "description": "Success.",
-            "schema": {
-              "$ref": "#/definitions/release-sources"
-            }
-          }
-        }
-      }
-    },
     "/v2/support-levels/architectures": {
       "get": {
         "security": [
@@ -6359,6 +7310,204 @@ func init() {
     }
   },
   "definitions": {
+    "agent-tokens-rotate-params": {
+      "type": "object",
+      "properties": {
+        "overlap_seconds": {
+          "description": "How long the previous agent tokens remain valid after the rotation, in seconds. Defaults to the overlap configured in the service, 0 revokes them right away.",
+          "type": "integer",
+          "minimum": 0,
+          "x-nullable": true
+        }
+      }
+    },
+    "agent-tokens-rotation": {
+      "type": "object",
+      "properties": {
+        "hosts": {
+          "description": "The number of hosts that will receive a new agent token with their next steps.",
+          "type": "integer"
+        },
+        "infra_env_id": {
+          "description": "The infra-env whose agent tokens were rotated.",
+          "type": "string",
+          "format": "uuid"
+        },
+        "previous_tokens_expire_at": {
+          "description": "The time after which the previous agent tokens are no longer accepted.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "rotated_at": {
+          "description": "The time of the rotation, the agent tokens issued before it are the previous tokens.",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "api-token": {
+      "description": "A token used by automation to access the API on behalf of its owner.",
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "actions": {
+          "description": "Actions the token can perform on the resources, any of read, update and delete.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"type:text[]\"",
+          "x-go-type": {
+            "hints": {
+              "noValidation": true
+            },
+            "import": {
+              "package": "github.com/lib/pq"
+            },
+            "type": "StringArray"
+          }
+        },
+        "cluster_ids": {
+          "description": "Clusters the token can access, the token can access all the resources of its owner when both cluster_ids and infra_env_ids are empty.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"type:text[]\"",
+          "x-go-type": {
+            "hints": {
+              "noValidation": true
+            },
+            "import": {
+              "package": "github.com/lib/pq"
+            },
+            "type": "StringArray"
+          }
+        },
+        "created_at": {
+          "description": "Time the token was created.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+        },
+        "expires_at": {
+          "description": "Time after which the token is rejected.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+        },
+        "id": {
+          "description": "Unique identifier of the token.",
+          "type": "string",
+          "format": "uuid",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "infra_env_ids": {
+          "description": "Infra-envs the token can access, in addition to the infra-envs bound to its clusters.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"type:text[]\"",
+          "x-go-type": {
+            "hints": {
+              "noValidation": true
+            },
+            "import": {
+              "package": "github.com/lib/pq"
+            },
+            "type": "StringArray"
+          }
+        },
+        "last_used_at": {
+          "description": "Last time the token was used, updated at most once a minute.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\"",
+          "x-nullable": true
+        },
+        "name": {
+          "description": "Name of the token, e.g. the pipeline that uses it.",
+          "type": "string"
+        },
+        "org_id": {
+          "description": "Organization of the user that created the token.",
+          "type": "string"
+        },
+        "revoked_at": {
+          "description": "Time the token was revoked.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\"",
+          "x-nullable": true
+        },
+        "token": {
+          "description": "The secret of the token, only returned when the token is created.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "user_name": {
+          "description": "User that created the token, the requests made with the token are made on its behalf.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "api-token-create-params": {
+      "description": "The parameters of a new API token.",
+      "type": "object",
+      "required": [
+        "name",
+        "actions"
+      ],
+      "properties": {
+        "actions": {
+          "description": "Actions the token can perform on the resources.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": [
+              "read",
+              "update",
+              "delete"
+            ]
+          }
+        },
+        "cluster_ids": {
+          "description": "Clusters the token can access, the token can access all the resources of its owner when both cluster_ids and infra_env_ids are empty.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "uuid"
+          }
+        },
+        "expires_at": {
+          "description": "Time after which the token is rejected, defaults to the default lifetime of the tokens.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "infra_env_ids": {
+          "description": "Infra-envs the token can access, in addition to the infra-envs bound to its clusters.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "uuid"
+          }
+        },
+        "name": {
+          "description": "Name of the token, e.g. the pipeline that uses it.",
+          "type": "string"
+        }
+      }
+    },
+    "api-tokens": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/api-token"
+      }
+    },
     "api_vip": {
       "description": "The virtual IP used to reach the OpenShift cluster's API.",
       "type": "object",
@@ -6435,29 +7584,122 @@ func init() {
           "description": "The error that occurred while downloading the worker ignition file, ignored when is_success is true",
           "type": "string"
         },
-        "ignition": {
-          "description": "Ignition file fetched from the target cluster's API machine config server.\nThis ignition file may be incomplete as almost all files / systemd units are removed from it by the agent in order to save space.",
+        "ignition": {
+          "description": "Ignition file fetched from the target cluster's API machine config server.\nThis ignition file may be incomplete as almost all files / systemd units are removed from it by the agent in order to save space.",
+          "type": "string"
+        },
+        "is_success": {
+          "description": "Whether the agent was able to download the ignition or not",
+          "type": "boolean"
+        },
+        "url": {
+          "description": "This parameter mirrors the url parameter of the corresponding api_vip_connectivity_request",
+          "type": "string"
+        }
+      }
+    },
+    "architecture-support-level-id": {
+      "type": "string",
+      "enum": [
+        "X86_64_ARCHITECTURE",
+        "ARM64_ARCHITECTURE",
+        "PPC64LE_ARCHITECTURE",
+        "S390X_ARCHITECTURE",
+        "MULTIARCH_RELEASE_IMAGE"
+      ]
+    },
+    "audit-record": {
+      "description": "A call to the API that modified the resources of the service.",
+      "type": "object",
+      "properties": {
+        "actor": {
+          "description": "User that made the call, empty when the call wasn't authenticated.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "api_token_id": {
+          "description": "API token the call was made with, if any.",
+          "type": "string"
+        },
+        "cluster_id": {
+          "description": "Cluster the call was made on.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "duration_ms": {
+          "description": "Duration of the call in milliseconds.",
+          "type": "integer"
+        },
+        "host_id": {
+          "description": "Host the call was made on.",
+          "type": "string"
+        },
+        "id": {
+          "description": "Unique identifier of the record.",
+          "type": "string",
+          "format": "uuid",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "infra_env_id": {
+          "description": "Infra-env the call was made on.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "method": {
+          "description": "HTTP method of the call.",
+          "type": "string"
+        },
+        "operation": {
+          "description": "Operation of the API that was called, e.g. v2RegisterCluster.",
+          "type": "string"
+        },
+        "org_id": {
+          "description": "Organization of the user that made the call.",
+          "type": "string"
+        },
+        "params": {
+          "description": "Parameters of the call in JSON format, with the secrets redacted.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
+        "path": {
+          "description": "Path of the call.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
+        "request_id": {
+          "description": "Identifier of the request, also found in the logs and the events of the call.",
+          "type": "string"
+        },
+        "role": {
+          "description": "Role of the user that made the call.",
+          "type": "string"
+        },
+        "source_ip": {
+          "description": "Address the call came from, the first address of the X-Forwarded-For header when set.",
           "type": "string"
         },
-        "is_success": {
-          "description": "Whether the agent was able to download the ignition or not",
-          "type": "boolean"
+        "status_code": {
+          "description": "HTTP status code of the response.",
+          "type": "integer"
         },
-        "url": {
-          "description": "This parameter mirrors the url parameter of the corresponding api_vip_connectivity_request",
+        "time": {
+          "description": "Time the call was received.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone;index\""
+        },
+        "user_agent": {
+          "description": "User agent of the client that made the call.",
           "type": "string"
         }
       }
     },
-    "architecture-support-level-id": {
-      "type": "string",
-      "enum": [
-        "X86_64_ARCHITECTURE",
-        "ARM64_ARCHITECTURE",
-        "PPC64LE_ARCHITECTURE",
-        "S390X_ARCHITECTURE",
-        "MULTIARCH_RELEASE_IMAGE"
-      ]
+    "audit-records": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/audit-record"
+      }
     },
     "bind-host-params": {
       "required": [
@@ -6470,6 +7712,61 @@ func init() {
         }
       }
     },
+    "bmc-inventory": {
+      "description": "The firmware, boot and RAID details of a host reported by its BareMetalHost.",
+      "type": "object",
+      "properties": {
+        "bios_date": {
+          "description": "The release date of the BIOS.",
+          "type": "string"
+        },
+        "bios_vendor": {
+          "type": "string"
+        },
+        "bios_version": {
+          "type": "string"
+        },
+        "boot_mode": {
+          "description": "The boot mode of the host: UEFI, UEFISecureBoot or legacy.",
+          "type": "string"
+        },
+        "firmware_settings": {
+          "description": "The current firmware settings of the host, by name.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "raid_volumes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/bmc-raid-volume"
+          }
+        }
+      }
+    },
+    "bmc-raid-volume": {
+      "description": "A RAID volume of a host, as configured through its BMC.",
+      "type": "object",
+      "properties": {
+        "level": {
+          "description": "The RAID level of the volume, like 0, 1, 5, 6, 1+0, 5+0 or 6+0.",
+          "type": "string"
+        },
+        "physical_disks": {
+          "description": "The number of physical disks of the volume.",
+          "type": "integer"
+        },
+        "size_gibibytes": {
+          "description": "The size of the volume in GiB, 0 when it uses all the space of its disks.",
+          "type": "integer"
+        },
+        "software": {
+          "description": "Whether the volume is a software RAID volume.",
+          "type": "boolean"
+        }
+      }
+    },
     "boot": {
       "type": "object",
       "properties": {
@@ -6931,6 +8228,11 @@ func init() {
           "x-go-custom-tag": "gorm:\"foreignkey:ClusterID;references:ID\"",
           "x-nullable": true
         },
+        "spoke_health": {
+          "description": "JSON formatted health of the installed cluster, collected periodically after the installation when the spoke health monitoring is enabled.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
         "ssh_public_key": {
           "description": "SSH public key for debugging OpenShift nodes.",
           "type": "string"
@@ -7364,6 +8666,7 @@ func init() {
         "sufficient-masters-count",
         "dns-domain-defined",
         "pull-secret-set",
+        "pull-secret-valid",
         "ntp-server-configured",
         "lso-requirements-satisfied",
         "ocs-requirements-satisfied",
@@ -7397,7 +8700,8 @@ func init() {
         "oadp-requirements-satisfied",
         "metallb-requirements-satisfied",
         "loki-requirements-satisfied",
-        "openshift-logging-requirements-satisfied"
+        "openshift-logging-requirements-satisfied",
+        "approval-rules-satisfied"
       ]
     },
     "cluster_default_config": {
@@ -8185,7 +9489,7 @@ func init() {
         "event_time": {
           "type": "string",
           "format": "date-time",
-          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone;primaryKey\""
         },
         "host_id": {
           "description": "Unique identifier of the host this event relates to.",
@@ -8227,15 +9531,102 @@ func init() {
             "error",
             "critical"
           ]
+        },
+        "trace_id": {
+          "description": "Identifier of the OpenTelemetry trace of the request that caused this event to occur.",
+          "type": "string"
+        }
+      }
+    },
+    "event-aggregation": {
+      "description": "Number of events in a time bucket, grouped by the requested fields.",
+      "type": "object",
+      "required": [
+        "bucket_start",
+        "count"
+      ],
+      "properties": {
+        "bucket_start": {
+          "description": "Start of the time bucket.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "count": {
+          "description": "Number of events in the bucket.",
+          "type": "integer"
+        },
+        "name": {
+          "description": "Name of the events, when grouped by name.",
+          "type": "string"
+        },
+        "severity": {
+          "description": "Severity of the events, when grouped by severity.",
+          "type": "string"
         }
       }
     },
+    "event-aggregation-list": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/event-aggregation"
+      }
+    },
     "event-list": {
       "type": "array",
       "items": {
         "$ref": "#/definitions/event"
       }
     },
+    "event-rate-limit": {
+      "description": "Rate limiting policy applied to the events with a given name.",
+      "type": "object",
+      "required": [
+        "event_name"
+      ],
+      "properties": {
+        "burst": {
+          "description": "Number of events that can be emitted at once before the per minute rate applies.",
+          "type": "integer",
+          "minimum": 0
+        },
+        "event_name": {
+          "description": "Name of the event the policy applies to.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "interval": {
+          "description": "Minimum duration between two events with the same name for the same resource, for example 1h.",
+          "type": "string"
+        },
+        "rate_per_minute": {
+          "description": "Number of events per minute allowed for the same resource once the burst is consumed.",
+          "type": "number",
+          "minimum": 0
+        },
+        "sample_rate": {
+          "description": "Fraction of the info events that are kept, between 0 and 1. Defaults to 1, 0 discards all of them.",
+          "type": "number",
+          "maximum": 1,
+          "minimum": 0,
+          "x-nullable": true
+        },
+        "source": {
+          "description": "Where the policy comes from.",
+          "type": "string",
+          "enum": [
+            "default",
+            "config",
+            "api"
+          ]
+        }
+      }
+    },
+    "event-rate-limits": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/event-rate-limit"
+      }
+    },
     "feature": {
       "type": "object",
       "required": [
@@ -8367,6 +9758,38 @@ func init() {
         "Done"
       ]
     },
+    "firmware-requirements": {
+      "description": "The firmware configuration required from the hosts with a BareMetalHost that register to an infra-env.",
+      "type": "object",
+      "properties": {
+        "bios_settings": {
+          "description": "The firmware settings that the host must have, by name.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "bios_versions": {
+          "description": "The BIOS version of the host must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "raid_levels": {
+          "description": "The RAID levels of the volumes that the host must have, one volume for each level.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "secure_boot": {
+          "description": "Whether secure boot must be enabled or disabled, any state is accepted when not set.",
+          "type": "boolean",
+          "x-nullable": true
+        }
+      }
+    },
     "free-addresses-list": {
       "type": "array",
       "items": {
@@ -8443,6 +9866,11 @@ func init() {
           "type": "string",
           "x-go-custom-tag": "gorm:\"type:text\""
         },
+        "bmc_inventory": {
+          "description": "JSON formatted string of the firmware, boot and RAID details of the host reported by its BareMetalHost.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
         "bootstrap": {
           "type": "boolean"
         },
@@ -8687,7 +10115,8 @@ func init() {
             "disabled-unbound",
             "discovering-unbound",
             "reclaiming",
-            "reclaiming-rebooting"
+            "reclaiming-rebooting",
+            "pending-attestation"
           ]
         },
         "status_info": {
@@ -8735,6 +10164,92 @@ func init() {
         }
       }
     },
+    "host-approval-policy": {
+      "description": "The rules that decide whether the hosts that register to an infra-env are approved or quarantined.",
+      "type": "object",
+      "properties": {
+        "default_action": {
+          "description": "What happens to the hosts that don't match any rule, manual leaves them to the user like without rules.",
+          "type": "string",
+          "enum": [
+            "approve",
+            "quarantine",
+            "manual"
+          ]
+        },
+        "rules": {
+          "description": "The first rule that matches the host decides.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/host-approval-rule"
+          }
+        }
+      }
+    },
+    "host-approval-rule": {
+      "description": "A rule that approves or quarantines the hosts that match all its criteria, a rule without criteria matches all the hosts.",
+      "type": "object",
+      "required": [
+        "action"
+      ],
+      "properties": {
+        "action": {
+          "description": "What happens to the hosts that match the rule.",
+          "type": "string",
+          "enum": [
+            "approve",
+            "quarantine"
+          ]
+        },
+        "mac_ranges": {
+          "description": "The host must have a MAC address in one of the ranges: a single MAC address, an inclusive range of two MAC addresses separated by a dash, or a prefix of up to five octets like an OUI.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "manufacturers": {
+          "description": "The system manufacturer of the host reported by its SMBIOS, e.g. Dell Inc., must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "min_cpu_cores": {
+          "description": "The host must have at least this number of CPU cores.",
+          "type": "integer",
+          "minimum": 0
+        },
+        "min_memory_mib": {
+          "description": "The host must have at least this amount of physical memory, in MiB.",
+          "type": "integer",
+          "minimum": 0
+        },
+        "name": {
+          "description": "Name of the rule, reported with the decisions it makes.",
+          "type": "string"
+        },
+        "product_names": {
+          "description": "The product name of the host must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "serial_numbers": {
+          "description": "The serial number of the host must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "virtual": {
+          "description": "The host must be a virtual machine when true, a physical machine when false.",
+          "type": "boolean",
+          "x-nullable": true
+        }
+      }
+    },
     "host-create-params": {
       "type": "object",
       "required": [
@@ -8747,6 +10262,9 @@ func init() {
         "host_id": {
           "type": "string",
           "format": "uuid"
+        },
+        "tpm_endorsement": {
+          "$ref": "#/definitions/tpm-endorsement"
         }
       }
     },
@@ -9007,7 +10525,11 @@ func init() {
         "oadp-requirements-satisfied",
         "metallb-requirements-satisfied",
         "loki-requirements-satisfied",
-        "openshift-logging-requirements-satisfied"
+        "openshift-logging-requirements-satisfied",
+        "approval-rules-satisfied",
+        "secure-boot-requirements-satisfied",
+        "firmware-requirements-satisfied",
+        "raid-requirements-satisfied"
       ]
     },
     "host_network": {
@@ -9291,10 +10813,22 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
         },
+        "firmware_requirements": {
+          "description": "JSON formatted firmware requirements of the hosts with a BareMetalHost that register to the infra-env.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\"",
+          "x-nullable": true
+        },
         "generator_version": {
           "description": "Image generator version.",
           "type": "string"
         },
+        "host_approval_policy": {
+          "description": "JSON formatted host approval policy of the hosts that register to the infra-env.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\"",
+          "x-nullable": true
+        },
         "href": {
           "description": "Self link.",
           "type": "string"
@@ -9367,6 +10901,12 @@ func init() {
           "description": "static network configuration string in the format expected by discovery ignition generation.",
           "type": "string"
         },
+        "tpm_attestation_policy": {
+          "description": "JSON formatted TPM attestation policy required from the hosts that register to the infra-env.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\"",
+          "x-nullable": true
+        },
         "type": {
           "$ref": "#/definitions/image_type"
         },
@@ -9427,6 +10967,12 @@ func init() {
           ],
           "x-nullable": false
         },
+        "firmware_requirements": {
+          "$ref": "#/definitions/firmware-requirements"
+        },
+        "host_approval_policy": {
+          "$ref": "#/definitions/host-approval-policy"
+        },
         "ignition_config_override": {
           "description": "JSON formatted string containing the user overrides for the initial ignition config.",
           "type": "string"
@@ -9479,6 +11025,9 @@ func init() {
           "items": {
             "$ref": "#/definitions/host_static_network_config"
           }
+        },
+        "tpm_attestation_policy": {
+          "$ref": "#/definitions/tpm-attestation-policy"
         }
       }
     },
@@ -9502,6 +11051,12 @@ func init() {
           "maxLength": 65535,
           "x-nullable": true
         },
+        "firmware_requirements": {
+          "$ref": "#/definitions/firmware-requirements"
+        },
+        "host_approval_policy": {
+          "$ref": "#/definitions/host-approval-policy"
+        },
         "ignition_config_override": {
           "description": "JSON formatted string containing the user overrides for the initial ignition config.",
           "type": "string"
@@ -9551,6 +11106,9 @@ func init() {
           "items": {
             "$ref": "#/definitions/host_static_network_config"
           }
+        },
+        "tpm_attestation_policy": {
+          "$ref": "#/definitions/tpm-attestation-policy"
         }
       }
     },
@@ -10158,37 +11716,81 @@ func init() {
             "openshift"
           ]
         },
-        "manifest_source": {
-          "description": "Describes whether manifest is sourced from a user or created by the system.",
+        "manifest_source": {
+          "description": "Describes whether manifest is sourced from a user or created by the system.",
+          "type": "string",
+          "enum": [
+            "user",
+            "system"
+          ]
+        }
+      }
+    },
+    "memory": {
+      "type": "object",
+      "properties": {
+        "physical_bytes": {
+          "type": "integer"
+        },
+        "physical_bytes_method": {
+          "description": "The method by which the physical memory was set",
+          "$ref": "#/definitions/memory_method"
+        },
+        "usable_bytes": {
+          "type": "integer"
+        }
+      }
+    },
+    "memory_method": {
+      "type": "string",
+      "enum": [
+        "dmidecode",
+        "ghw",
+        "meminfo"
+      ]
+    },
+    "migrate_agent_request": {
+      "description": "Information sent to the agent for migrating a host to the infra-env of a peer service, without rebooting it.",
+      "type": "object",
+      "required": [
+        "service_base_url",
+        "infra_env_id",
+        "host_fs_mount_dir"
+      ],
+      "properties": {
+        "host_fs_mount_dir": {
+          "description": "The base directory on the host that contains the configuration of the agent, it is\nupdated so the agent reaches the peer service after it is restarted.",
+          "type": "string"
+        },
+        "infra_env_id": {
+          "description": "The infra-env of the peer service that the host belongs to from now on.",
           "type": "string",
-          "enum": [
-            "user",
-            "system"
-          ]
+          "format": "uuid"
+        },
+        "service_base_url": {
+          "description": "The URL of the peer service that the agent must reach from now on.",
+          "type": "string"
+        },
+        "token": {
+          "description": "The agent authentication token issued by the peer service, empty when the agent keeps its current credentials.",
+          "type": "string"
         }
       }
     },
-    "memory": {
+    "migrate_agent_response": {
       "type": "object",
       "properties": {
-        "physical_bytes": {
-          "type": "integer"
-        },
-        "physical_bytes_method": {
-          "description": "The method by which the physical memory was set",
-          "$ref": "#/definitions/memory_method"
-        },
-        "usable_bytes": {
-          "type": "integer"
+        "result": {
+          "$ref": "#/definitions/migrate_agent_result"
         }
       }
     },
-    "memory_method": {
+    "migrate_agent_result": {
+      "description": "Agent migration result.",
       "type": "string",
       "enum": [
-        "dmidecode",
-        "ghw",
-        "meminfo"
+        "success",
+        "failure"
       ]
     },
     "monitored-operator": {
@@ -10731,6 +12333,59 @@ func init() {
       },
       "x-go-custom-tag": "gorm:\"embedded;embeddedPrefix:proxy_\""
     },
+    "quota-usage": {
+      "description": "The usage of a resource by a tenant, and the limit of its quota.",
+      "type": "object",
+      "required": [
+        "scope",
+        "tenant",
+        "resource",
+        "usage"
+      ],
+      "properties": {
+        "limit": {
+          "description": "The maximum usage allowed, unlimited when not set.",
+          "type": "integer",
+          "x-nullable": true
+        },
+        "period": {
+          "description": "The period over which the ISO downloads are counted, e.g. 24h0m0s.",
+          "type": "string"
+        },
+        "resource": {
+          "description": "The resource the quota limits.",
+          "type": "string",
+          "enum": [
+            "clusters",
+            "infra-envs",
+            "hosts",
+            "iso-downloads"
+          ]
+        },
+        "scope": {
+          "description": "Whether the quota applies to an organization or to a user.",
+          "type": "string",
+          "enum": [
+            "org",
+            "user"
+          ]
+        },
+        "tenant": {
+          "description": "The ID of the organization or the name of the user.",
+          "type": "string"
+        },
+        "usage": {
+          "description": "The number of resources of the tenant, or of ISO downloads during the period.",
+          "type": "integer"
+        }
+      }
+    },
+    "quota-usages": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/quota-usage"
+      }
+    },
     "reboot_for_reclaim_request": {
       "description": "Information sent to the agent for rebooting a host into discovery.",
       "type": "object",
@@ -10870,6 +12525,102 @@ func init() {
         "$ref": "#/definitions/release-source"
       }
     },
+    "role-binding": {
+      "description": "Grants a role on a cluster, an infra-env or a whole tenant to a user or a group of the OIDC identity provider.",
+      "type": "object",
+      "required": [
+        "subject_kind",
+        "subject",
+        "role",
+        "scope",
+        "resource_id"
+      ],
+      "properties": {
+        "created_at": {
+          "description": "The time the role binding was created.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+        },
+        "created_by": {
+          "description": "Name of the user that created the role binding.",
+          "type": "string"
+        },
+        "id": {
+          "description": "Unique identifier of the role binding.",
+          "type": "string",
+          "format": "uuid",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "resource_id": {
+          "description": "ID of the cluster or of the infra-env, or name of the tenant, the role is granted on.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "role": {
+          "description": "Viewers can read the resources, editors can also modify them and admins can also manage the role bindings of the resources.",
+          "type": "string",
+          "enum": [
+            "viewer",
+            "editor",
+            "admin"
+          ]
+        },
+        "scope": {
+          "description": "Kind of the resource the role is granted on.",
+          "type": "string",
+          "enum": [
+            "cluster",
+            "infra-env",
+            "tenant"
+          ]
+        },
+        "subject": {
+          "description": "Name of the user or of the group the role is granted to.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "subject_kind": {
+          "description": "Whether the subject is a user name or a group name.",
+          "type": "string",
+          "enum": [
+            "user",
+            "group"
+          ]
+        }
+      }
+    },
+    "role-bindings": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/role-binding"
+      }
+    },
+    "rotate_agent_token_request": {
+      "type": "object",
+      "properties": {
+        "token": {
+          "description": "The new agent authentication token the agent must use from now on.",
+          "type": "string"
+        }
+      }
+    },
+    "rotate_agent_token_response": {
+      "type": "object",
+      "properties": {
+        "result": {
+          "$ref": "#/definitions/rotate_agent_token_result"
+        }
+      }
+    },
+    "rotate_agent_token_result": {
+      "description": "Agent token rotation result.",
+      "type": "string",
+      "enum": [
+        "success",
+        "failure"
+      ]
+    },
     "route": {
       "type": "object",
       "properties": {
@@ -10933,6 +12684,75 @@ func init() {
         "unreachable"
       ]
     },
+    "spoke-health": {
+      "description": "The health of an installed cluster, collected periodically by the service after the installation.",
+      "type": "object",
+      "properties": {
+        "checked_at": {
+          "description": "The time that the health was collected.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "cluster_version": {
+          "description": "The current version of the cluster.",
+          "type": "string"
+        },
+        "cluster_version_available": {
+          "description": "Whether the cluster version operator reports the cluster as available.",
+          "type": "boolean"
+        },
+        "cluster_version_failing": {
+          "description": "Whether the cluster version operator reports a failure.",
+          "type": "boolean"
+        },
+        "cluster_version_progressing": {
+          "description": "Whether the cluster is being upgraded.",
+          "type": "boolean"
+        },
+        "degraded_operators": {
+          "description": "The cluster operators that are degraded.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "message": {
+          "description": "What isn't healthy in the cluster.",
+          "type": "string"
+        },
+        "not_ready_nodes": {
+          "description": "The nodes of the cluster that aren't ready.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "ready_nodes": {
+          "description": "The number of nodes of the cluster that are ready.",
+          "type": "integer"
+        },
+        "status": {
+          "description": "healthy when the cluster version, the cluster operators and the nodes are healthy, degraded when one of them is not, and unavailable when the cluster can't be reached.",
+          "type": "string",
+          "enum": [
+            "healthy",
+            "degraded",
+            "unavailable"
+          ]
+        },
+        "total_nodes": {
+          "description": "The number of nodes of the cluster.",
+          "type": "integer"
+        },
+        "unavailable_operators": {
+          "description": "The cluster operators that aren't available.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "step": {
       "type": "object",
       "properties": {
@@ -10991,7 +12811,10 @@ func init() {
         "upgrade-agent",
         "download-boot-artifacts",
         "reboot-for-reclaim",
-        "verify-vips"
+        "verify-vips",
+        "rotate-agent-token",
+        "tpm-attestation",
+        "migrate-agent"
       ]
     },
     "steps": {
@@ -11052,67 +12875,166 @@ func init() {
         "manufacturer": {
           "type": "string"
         },
-        "product_name": {
+        "product_name": {
+          "type": "string"
+        },
+        "serial_number": {
+          "type": "string"
+        },
+        "virtual": {
+          "description": "Whether the machine appears to be a virtual machine or not",
+          "type": "boolean"
+        }
+      }
+    },
+    "tang_connectivity_request": {
+      "type": "object",
+      "required": [
+        "tang_servers"
+      ],
+      "properties": {
+        "tang_servers": {
+          "description": "JSON-formatted string containing additional information regarding tang's configuration",
+          "type": "string"
+        }
+      }
+    },
+    "tang_connectivity_response": {
+      "type": "object",
+      "properties": {
+        "is_success": {
+          "description": "Tang check result.",
+          "type": "boolean"
+        },
+        "tang_server_response": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "properties": {
+              "payload": {
+                "description": "Tang response payload.",
+                "type": "string"
+              },
+              "signatures": {
+                "type": "array",
+                "items": {
+                  "type": "object",
+                  "properties": {
+                    "protected": {
+                      "type": "string"
+                    },
+                    "signature": {
+                      "type": "string"
+                    }
+                  },
+                  "x-go-name": "TangServerSignatures"
+                }
+              },
+              "tang_url": {
+                "description": "Tang URL.",
+                "type": "string"
+              }
+            },
+            "x-go-name": "TangServerResponse"
+          }
+        }
+      }
+    },
+    "tpm-attestation-policy": {
+      "description": "The TPM attestation required from the hosts that register to an infra-env.",
+      "type": "object",
+      "properties": {
+        "ek_certificates": {
+          "description": "PEM-encoded X.509 certificate bundle. The endorsement key certificates of the hosts must be in the bundle, or be issued by a certificate authority in the bundle. An empty bundle disables the attestation.",
+          "type": "string"
+        },
+        "pcr_policies": {
+          "description": "The PCR values of the hosts must match one of the policies. All the PCR values are accepted when there is no policy.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/tpm-pcr-policy"
+          }
+        }
+      }
+    },
+    "tpm-attestation-request": {
+      "type": "object",
+      "properties": {
+        "credential_blob": {
+          "description": "Base64 encoded TPM2B_ID_OBJECT to activate with the attestation and endorsement keys.",
+          "type": "string"
+        },
+        "encrypted_secret": {
+          "description": "Base64 encoded TPM2B_ENCRYPTED_SECRET to activate with the attestation and endorsement keys.",
+          "type": "string"
+        },
+        "nonce": {
+          "description": "Base64 encoded nonce that the quote must include as its qualifying data.",
+          "type": "string"
+        },
+        "pcr_selection": {
+          "description": "The PCRs of the SHA-256 bank to quote.",
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
+        }
+      }
+    },
+    "tpm-attestation-response": {
+      "type": "object",
+      "properties": {
+        "pcrs": {
+          "description": "The hex encoded values of the quoted PCRs, by PCR index.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "quote": {
+          "description": "Base64 encoded attestation structure (TPMS_ATTEST) of the quote.",
           "type": "string"
         },
-        "serial_number": {
+        "secret": {
+          "description": "Base64 encoded secret of the activated credential.",
           "type": "string"
         },
-        "virtual": {
-          "description": "Whether the machine appears to be a virtual machine or not",
-          "type": "boolean"
+        "signature": {
+          "description": "Base64 encoded signature (TPMT_SIGNATURE) of the quote by the attestation key.",
+          "type": "string"
         }
       }
     },
-    "tang_connectivity_request": {
+    "tpm-endorsement": {
+      "description": "The TPM keys that a host attests with.",
       "type": "object",
-      "required": [
-        "tang_servers"
-      ],
       "properties": {
-        "tang_servers": {
-          "description": "JSON-formatted string containing additional information regarding tang's configuration",
+        "ak_public": {
+          "description": "Base64 encoded public area (TPMT_PUBLIC) of the attestation key created by the TPM.",
+          "type": "string"
+        },
+        "ek_certificate": {
+          "description": "PEM-encoded X.509 certificate of the endorsement key of the TPM.",
           "type": "string"
         }
       }
     },
-    "tang_connectivity_response": {
+    "tpm-pcr-policy": {
+      "description": "The expected values of some PCRs of the SHA-256 bank of the TPM.",
       "type": "object",
+      "required": [
+        "pcrs"
+      ],
       "properties": {
-        "is_success": {
-          "description": "Tang check result.",
-          "type": "boolean"
+        "name": {
+          "description": "Name of the policy, reported when the host matches it.",
+          "type": "string"
         },
-        "tang_server_response": {
-          "type": "array",
-          "items": {
-            "type": "object",
-            "properties": {
-              "payload": {
-                "description": "Tang response payload.",
-                "type": "string"
-              },
-              "signatures": {
-                "type": "array",
-                "items": {
-                  "type": "object",
-                  "properties": {
-                    "protected": {
-                      "type": "string"
-                    },
-                    "signature": {
-                      "type": "string"
-                    }
-                  },
-                  "x-go-name": "TangServerSignatures"
-                }
-              },
-              "tang_url": {
-                "description": "Tang URL.",
-                "type": "string"
-              }
-            },
-            "x-go-name": "TangServerResponse"
+        "pcrs": {
+          "description": "The hex encoded SHA-256 values of the PCRs, by PCR index.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
           }
         }
       }
@@ -11634,6 +13556,18 @@ func init() {
       "description": "Agent-driven installation",
       "name": "Assisted installation"
     },
+    {
+      "description": "Rotation and revocation of the tokens the agents authenticate with.",
+      "name": "agent_tokens"
+    },
+    {
+      "description": "Audit log of the calls that modify the resources of the service.",
+      "name": "audit"
+    },
+    {
+      "description": "Access control to the resources of the service.",
+      "name": "authz"
+    },
     {
       "description": "Events related to a cluster installation.",
       "name": "events"
@@ -11654,6 +13588,14 @@ func init() {
       "description": "Information regarding supported operators.",
       "name": "operators"
     },
+    {
+      "description": "Limits of the resources the tenants can create, and their usage.",
+      "name": "quotas"
+    },
+    {
+      "description": "API tokens used by automation to access the service.",
+      "name": "tokens"
+    },
     {
       "description": "Information regarding versions.",
       "name": "versions"
@@ -11679,11 +13621,262 @@ func init() {
       "name": "Apache 2.0",
       "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
     },
-    "version": "1.0.0"
-  },
-  "host": "api.openshift.com",
-  "basePath": "/api/assisted-install",
-  "paths": {
+    "version": "1.0.0"
+  },
+  "host": "api.openshift.com",
+  "basePath": "/api/assisted-install",
+  "paths": {
+    "/v2/api-tokens": {
+      "get": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Lists the API tokens of the user, the secrets of the tokens are not returned.",
+        "tags": [
+          "tokens"
+        ],
+        "operationId": "v2ListAPITokens",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/api-tokens"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Creates an API token that makes requests on behalf of the user, limited to the given resources and actions.",
+        "tags": [
+          "tokens"
+        ],
+        "operationId": "v2CreateAPIToken",
+        "parameters": [
+          {
+            "description": "The parameters of the token to create.",
+            "name": "new-api-token-params",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/api-token-create-params"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/api-token"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/api-tokens/{api_token_id}": {
+      "delete": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Revokes an API token, the requests made with the token are rejected from now on.",
+        "tags": [
+          "tokens"
+        ],
+        "operationId": "v2RevokeAPIToken",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The API token to revoke.",
+            "name": "api_token_id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success."
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/audit-records": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin"
+            ]
+          }
+        ],
+        "description": "Lists the audit records of the calls that modified the resources of the service, the most recent first.",
+        "tags": [
+          "audit"
+        ],
+        "operationId": "v2ListAuditRecords",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only the records of the calls received at or after this time.",
+            "name": "start_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only the records of the calls received before this time.",
+            "name": "end_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only the records of the calls made by this user.",
+            "name": "actor",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "Only the records of the calls made on this cluster.",
+            "name": "cluster_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "Only the records of the calls made on this infra-env.",
+            "name": "infra_env_id",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "The maximum number of records to return, 1000 by default.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "The number of records to skip before returning the rest.",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/audit-records"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/v2/clusters": {
       "get": {
         "security": [
@@ -14867,6 +17060,60 @@ func init() {
             "description": "A comma-separated list of event categories.",
             "name": "categories",
             "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Also return events that were archived to object storage by the events retention policy.",
+            "name": "include_archived",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "Retrieved events names.",
+            "name": "names",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only retrieve the events that occurred at or after this time.",
+            "name": "start_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only retrieve the events that occurred before this time.",
+            "name": "end_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "Only retrieve the events caused by this request.",
+            "name": "request_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "A JSON object that the properties of the retrieved events must contain.",
+            "name": "props",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Full-text search over the messages of the retrieved events.",
+            "name": "search",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Cursor returned in the Next-Cursor header of the previous page. Used instead of offset, it can't be combined with include_archived.",
+            "name": "cursor",
+            "in": "query"
           }
         ],
         "responses": {
@@ -14881,6 +17128,10 @@ func init() {
                 "type": "integer",
                 "description": "Count of events retrieved."
               },
+              "Next-Cursor": {
+                "type": "string",
+                "description": "Cursor to pass to retrieve the next page of events, empty when there are no more events."
+              },
               "Severity-Count-Critical": {
                 "minimum": 0,
                 "type": "integer",
@@ -14921,11 +17172,279 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
-          "405": {
-            "description": "Method Not Allowed.",
+          "405": {
+            "description": "Method Not Allowed.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "agentAuth": []
+          }
+        ],
+        "description": "Add new assisted installer event.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "v2TriggerEvent",
+        "parameters": [
+          {
+            "description": "The event to be created.",
+            "name": "trigger-event-params",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/event"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success."
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "405": {
+            "description": "Method Not Allowed.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Cluster cannot accept new agents due to its current state.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "501": {
+            "description": "Not implemented.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "503": {
+            "description": "Unavailable.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/events/aggregations": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Counts the events of a cluster or an infra-env per time bucket, optionally grouped by name and severity.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "v2AggregateEvents",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The cluster to aggregate events for.",
+            "name": "cluster_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The infra-env to aggregate events for.",
+            "name": "infra_env_id",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "Only count the events with these names.",
+            "name": "names",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "info",
+                "warning",
+                "error",
+                "critical"
+              ],
+              "type": "string"
+            },
+            "description": "Only count the events with these severities.",
+            "name": "severities",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "description": "A comma-separated list of event categories.",
+            "name": "categories",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only count the events that occurred at or after this time.",
+            "name": "start_time",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only count the events that occurred before this time.",
+            "name": "end_time",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "minute",
+              "hour",
+              "day"
+            ],
+            "type": "string",
+            "description": "Size of the time buckets, defaults to hour.",
+            "name": "interval",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "name",
+                "severity"
+              ],
+              "type": "string"
+            },
+            "description": "Fields to group the events by in each time bucket.",
+            "name": "group_by",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/event-aggregation-list"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
+          }
+        }
+      }
+    },
+    "/v2/events/rate-limits": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin"
+            ]
+          }
+        ],
+        "description": "Lists the effective event rate limits.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "v2GetEventRateLimits",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/event-rate-limits"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
           },
           "500": {
             "description": "Error.",
@@ -14935,31 +17454,36 @@ func init() {
           }
         }
       },
-      "post": {
+      "put": {
         "security": [
           {
-            "agentAuth": []
+            "userAuth": [
+              "admin"
+            ]
           }
         ],
-        "description": "Add new assisted installer event.",
+        "description": "Replaces the event rate limits overridden through the API.",
         "tags": [
           "events"
         ],
-        "operationId": "v2TriggerEvent",
+        "operationId": "v2UpdateEventRateLimits",
         "parameters": [
           {
-            "description": "The event to be created.",
-            "name": "trigger-event-params",
+            "description": "The event rate limits to apply on top of the configured ones.",
+            "name": "rate-limits",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/event"
+              "$ref": "#/definitions/event-rate-limits"
             }
           }
         ],
         "responses": {
-          "201": {
-            "description": "Success."
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/event-rate-limits"
+            }
           },
           "400": {
             "description": "Error.",
@@ -14979,41 +17503,11 @@ func init() {
               "$ref": "#/definitions/infra_error"
             }
           },
-          "404": {
-            "description": "Error.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          },
-          "405": {
-            "description": "Method Not Allowed.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          },
-          "409": {
-            "description": "Cluster cannot accept new agents due to its current state.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          },
           "500": {
             "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
-          },
-          "501": {
-            "description": "Not implemented.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          },
-          "503": {
-            "description": "Unavailable.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
           }
         }
       }
@@ -15500,6 +17994,80 @@ func init() {
         }
       }
     },
+    "/v2/infra-envs/{infra_env_id}/agent-tokens/rotate": {
+      "post": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Rotates the agent tokens of the infra-env. The previous tokens remain valid during the overlap, and the registered hosts receive a new token with their next steps.",
+        "tags": [
+          "agent_tokens"
+        ],
+        "operationId": "v2RotateAgentTokens",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The infra-env whose agent tokens are rotated.",
+            "name": "infra_env_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "The parameters of the rotation.",
+            "name": "rotate-params",
+            "in": "body",
+            "required": false,
+            "schema": {
+              "$ref": "#/definitions/agent-tokens-rotate-params"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/agent-tokens-rotation"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/v2/infra-envs/{infra_env_id}/downloads/files": {
       "get": {
         "security": [
@@ -15789,6 +18357,12 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
+          "429": {
+            "description": "Too many requests.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -16656,35 +19230,105 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
-          "405": {
-            "description": "Method Not Allowed.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          },
-          "409": {
-            "description": "Conflict.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          },
+          "405": {
+            "description": "Method Not Allowed.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Conflict.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "501": {
+            "description": "Not implemented.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "503": {
+            "description": "Unavailable.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/infra-envs/{infra_env_id}/hosts/{host_id}/agent-token/revoke": {
+      "post": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Revokes the agent tokens of a host, the host can no longer authenticate, and rotates the agent tokens of its infra-env, the other hosts get tokens of their own.",
+        "tags": [
+          "agent_tokens"
+        ],
+        "operationId": "v2RevokeHostAgentToken",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The infra-env of the host.",
+            "name": "infra_env_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "The host whose agent tokens are revoked.",
+            "name": "host_id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Success."
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
-          },
-          "501": {
-            "description": "Not implemented.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          },
-          "503": {
-            "description": "Unavailable.",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
           }
         }
       }
@@ -17504,7 +20148,287 @@ func init() {
             }
           },
           "500": {
-            "description": "Internal server error",
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/operators/bundles/{id}": {
+      "get": {
+        "description": "Retrieves an array of operator properties for the specified bundle when some features are activated.",
+        "tags": [
+          "operators"
+        ],
+        "summary": "Get operator properties for a bundle",
+        "operationId": "V2GetBundle",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Identifier of the bundle, for example, ` + "`" + `virtualization` + "`" + ` or ` + "`" + `openshift-ai` + "`" + `.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "SNO"
+              ],
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Array of feature IDs that affect bundle composition (e.g., [\"SNO\"] for Single Node OpenShift).",
+            "name": "feature_ids",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/bundle"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "404": {
+            "description": "Bundle not found",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/quotas": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Lists the usage of the resources by the organization and the user of the caller, and the limits of their quotas.",
+        "tags": [
+          "quotas"
+        ],
+        "operationId": "v2ListQuotas",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The organization to report, only the admins can report another organization.",
+            "name": "org_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The user to report, only the admins can report another user.",
+            "name": "user_name",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/quota-usages"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/release-sources": {
+      "get": {
+        "security": [
+          {
+            "userAuth": [
+              "admin",
+              "read-only-admin",
+              "user"
+            ]
+          }
+        ],
+        "description": "Retrieves openshift release sources configuration.",
+        "tags": [
+          "versions"
+        ],
+        "operationId": "v2ListReleaseSources",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/release-sources"
+            }
+          }
+        }
+      }
+    },
+    "/v2/role-bindings": {
+      "get": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Lists the role bindings of the resources the user administers.",
+        "tags": [
+          "authz"
+        ],
+        "operationId": "v2ListRoleBindings",
+        "parameters": [
+          {
+            "enum": [
+              "cluster",
+              "infra-env",
+              "tenant"
+            ],
+            "type": "string",
+            "description": "Only list the role bindings of this kind of resource.",
+            "name": "scope",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only list the role bindings of this resource.",
+            "name": "resource_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only list the role bindings granted to this user or group.",
+            "name": "subject",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/role-bindings"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Grants a role on a cluster, an infra-env or a tenant to a user or a group.",
+        "tags": [
+          "authz"
+        ],
+        "operationId": "v2CreateRoleBinding",
+        "parameters": [
+          {
+            "description": "The role binding to create.",
+            "name": "role-binding",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/role-binding"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/role-binding"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Unauthorized.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "403": {
+            "description": "Forbidden.",
+            "schema": {
+              "$ref": "#/definitions/infra_error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -17512,57 +20436,52 @@ func init() {
         }
       }
     },
-    "/v2/operators/bundles/{id}": {
-      "get": {
-        "description": "Retrieves an array of operator properties for the specified bundle when some features are activated.",
+    "/v2/role-bindings/{role_binding_id}": {
+      "delete": {
+        "security": [
+          {
+            "userAuth": []
+          }
+        ],
+        "description": "Revokes a role binding.",
         "tags": [
-          "operators"
+          "authz"
         ],
-        "summary": "Get operator properties for a bundle",
-        "operationId": "V2GetBundle",
+        "operationId": "v2DeleteRoleBinding",
         "parameters": [
           {
             "type": "string",
-            "description": "Identifier of the bundle, for example, ` + "`" + `virtualization` + "`" + ` or ` + "`" + `openshift-ai` + "`" + `.",
-            "name": "id",
+            "format": "uuid",
+            "description": "The role binding to be deleted.",
+            "name": "role_binding_id",
             "in": "path",
             "required": true
-          },
-          {
-            "type": "array",
-            "items": {
-              "enum": [
-                "SNO"
-              ],
-              "type": "string"
-            },
-            "collectionFormat": "multi",
-            "description": "Array of feature IDs that affect bundle composition (e.g., [\"SNO\"] for Single Node OpenShift).",
-            "name": "feature_ids",
-            "in": "query"
           }
         ],
         "responses": {
-          "200": {
-            "description": "Success",
+          "204": {
+            "description": "Success."
+          },
+          "401": {
+            "description": "Unauthorized.",
             "schema": {
-              "$ref": "#/definitions/bundle"
+              "$ref": "#/definitions/infra_error"
             }
           },
-          "400": {
-            "description": "Error.",
+          "403": {
+            "description": "Forbidden.",
             "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/infra_error"
             }
           },
           "404": {
-            "description": "Bundle not found",
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
           },
           "500": {
-            "description": "Internal server error",
+            "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -17570,32 +20489,6 @@ func init() {
         }
       }
     },
-    "/v2/release-sources": {
-      "get": {
-        "security": [
-          {
-            "userAuth": [
-              "admin",
-              "read-only-admin",
-              "user"
-            ]
-          }
-        ],
-        "description": "Retrieves openshift release sources configuration.",
-        "tags": [
-          "versions"
-        ],
-        "operationId": "v2ListReleaseSources",
-        "responses": {
-          "200": {
-            "description": "Success.",
-            "schema": {
-              "$ref": "#/definitions/release-sources"
-            }
-          }
-        }
-      }
-    },
     "/v2/support-levels/architectures": {
       "get": {
         "security": [
@@ -18127,30 +21020,228 @@ func init() {
           "description": "Tang response payload.",
           "type": "string"
         },
-        "signatures": {
+        "signatures": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/TangConnectivityResponseTangServerResponseItems0SignaturesItems0"
+          }
+        },
+        "tang_url": {
+          "description": "Tang URL.",
+          "type": "string"
+        }
+      },
+      "x-go-name": "TangServerResponse"
+    },
+    "TangConnectivityResponseTangServerResponseItems0SignaturesItems0": {
+      "type": "object",
+      "properties": {
+        "protected": {
+          "type": "string"
+        },
+        "signature": {
+          "type": "string"
+        }
+      },
+      "x-go-name": "TangServerSignatures"
+    },
+    "agent-tokens-rotate-params": {
+      "type": "object",
+      "properties": {
+        "overlap_seconds": {
+          "description": "How long the previous agent tokens remain valid after the rotation, in seconds. Defaults to the overlap configured in the service, 0 revokes them right away.",
+          "type": "integer",
+          "minimum": 0,
+          "x-nullable": true
+        }
+      }
+    },
+    "agent-tokens-rotation": {
+      "type": "object",
+      "properties": {
+        "hosts": {
+          "description": "The number of hosts that will receive a new agent token with their next steps.",
+          "type": "integer"
+        },
+        "infra_env_id": {
+          "description": "The infra-env whose agent tokens were rotated.",
+          "type": "string",
+          "format": "uuid"
+        },
+        "previous_tokens_expire_at": {
+          "description": "The time after which the previous agent tokens are no longer accepted.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "rotated_at": {
+          "description": "The time of the rotation, the agent tokens issued before it are the previous tokens.",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "api-token": {
+      "description": "A token used by automation to access the API on behalf of its owner.",
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "actions": {
+          "description": "Actions the token can perform on the resources, any of read, update and delete.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"type:text[]\"",
+          "x-go-type": {
+            "hints": {
+              "noValidation": true
+            },
+            "import": {
+              "package": "github.com/lib/pq"
+            },
+            "type": "StringArray"
+          }
+        },
+        "cluster_ids": {
+          "description": "Clusters the token can access, the token can access all the resources of its owner when both cluster_ids and infra_env_ids are empty.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"type:text[]\"",
+          "x-go-type": {
+            "hints": {
+              "noValidation": true
+            },
+            "import": {
+              "package": "github.com/lib/pq"
+            },
+            "type": "StringArray"
+          }
+        },
+        "created_at": {
+          "description": "Time the token was created.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+        },
+        "expires_at": {
+          "description": "Time after which the token is rejected.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+        },
+        "id": {
+          "description": "Unique identifier of the token.",
+          "type": "string",
+          "format": "uuid",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "infra_env_ids": {
+          "description": "Infra-envs the token can access, in addition to the infra-envs bound to its clusters.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"type:text[]\"",
+          "x-go-type": {
+            "hints": {
+              "noValidation": true
+            },
+            "import": {
+              "package": "github.com/lib/pq"
+            },
+            "type": "StringArray"
+          }
+        },
+        "last_used_at": {
+          "description": "Last time the token was used, updated at most once a minute.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\"",
+          "x-nullable": true
+        },
+        "name": {
+          "description": "Name of the token, e.g. the pipeline that uses it.",
+          "type": "string"
+        },
+        "org_id": {
+          "description": "Organization of the user that created the token.",
+          "type": "string"
+        },
+        "revoked_at": {
+          "description": "Time the token was revoked.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\"",
+          "x-nullable": true
+        },
+        "token": {
+          "description": "The secret of the token, only returned when the token is created.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "user_name": {
+          "description": "User that created the token, the requests made with the token are made on its behalf.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "api-token-create-params": {
+      "description": "The parameters of a new API token.",
+      "type": "object",
+      "required": [
+        "name",
+        "actions"
+      ],
+      "properties": {
+        "actions": {
+          "description": "Actions the token can perform on the resources.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": [
+              "read",
+              "update",
+              "delete"
+            ]
+          }
+        },
+        "cluster_ids": {
+          "description": "Clusters the token can access, the token can access all the resources of its owner when both cluster_ids and infra_env_ids are empty.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "uuid"
+          }
+        },
+        "expires_at": {
+          "description": "Time after which the token is rejected, defaults to the default lifetime of the tokens.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "infra_env_ids": {
+          "description": "Infra-envs the token can access, in addition to the infra-envs bound to its clusters.",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/TangConnectivityResponseTangServerResponseItems0SignaturesItems0"
+            "type": "string",
+            "format": "uuid"
           }
         },
-        "tang_url": {
-          "description": "Tang URL.",
+        "name": {
+          "description": "Name of the token, e.g. the pipeline that uses it.",
           "type": "string"
         }
-      },
-      "x-go-name": "TangServerResponse"
+      }
     },
-    "TangConnectivityResponseTangServerResponseItems0SignaturesItems0": {
-      "type": "object",
-      "properties": {
-        "protected": {
-          "type": "string"
-        },
-        "signature": {
-          "type": "string"
-        }
-      },
-      "x-go-name": "TangServerSignatures"
+    "api-tokens": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/api-token"
+      }
     },
     "api_vip": {
       "description": "The virtual IP used to reach the OpenShift cluster's API.",
@@ -18252,6 +21343,99 @@ func init() {
         "MULTIARCH_RELEASE_IMAGE"
       ]
     },
+    "audit-record": {
+      "description": "A call to the API that modified the resources of the service.",
+      "type": "object",
+      "properties": {
+        "actor": {
+          "description": "User that made the call, empty when the call wasn't authenticated.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "api_token_id": {
+          "description": "API token the call was made with, if any.",
+          "type": "string"
+        },
+        "cluster_id": {
+          "description": "Cluster the call was made on.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "duration_ms": {
+          "description": "Duration of the call in milliseconds.",
+          "type": "integer"
+        },
+        "host_id": {
+          "description": "Host the call was made on.",
+          "type": "string"
+        },
+        "id": {
+          "description": "Unique identifier of the record.",
+          "type": "string",
+          "format": "uuid",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "infra_env_id": {
+          "description": "Infra-env the call was made on.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "method": {
+          "description": "HTTP method of the call.",
+          "type": "string"
+        },
+        "operation": {
+          "description": "Operation of the API that was called, e.g. v2RegisterCluster.",
+          "type": "string"
+        },
+        "org_id": {
+          "description": "Organization of the user that made the call.",
+          "type": "string"
+        },
+        "params": {
+          "description": "Parameters of the call in JSON format, with the secrets redacted.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
+        "path": {
+          "description": "Path of the call.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
+        "request_id": {
+          "description": "Identifier of the request, also found in the logs and the events of the call.",
+          "type": "string"
+        },
+        "role": {
+          "description": "Role of the user that made the call.",
+          "type": "string"
+        },
+        "source_ip": {
+          "description": "Address the call came from, the first address of the X-Forwarded-For header when set.",
+          "type": "string"
+        },
+        "status_code": {
+          "description": "HTTP status code of the response.",
+          "type": "integer"
+        },
+        "time": {
+          "description": "Time the call was received.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone;index\""
+        },
+        "user_agent": {
+          "description": "User agent of the client that made the call.",
+          "type": "string"
+        }
+      }
+    },
+    "audit-records": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/audit-record"
+      }
+    },
     "bind-host-params": {
       "required": [
         "cluster_id"
@@ -18263,6 +21447,61 @@ func init() {
         }
       }
     },
+    "bmc-inventory": {
+      "description": "The firmware, boot and RAID details of a host reported by its BareMetalHost.",
+      "type": "object",
+      "properties": {
+        "bios_date": {
+          "description": "The release date of the BIOS.",
+          "type": "string"
+        },
+        "bios_vendor": {
+          "type": "string"
+        },
+        "bios_version": {
+          "type": "string"
+        },
+        "boot_mode": {
+          "description": "The boot mode of the host: UEFI, UEFISecureBoot or legacy.",
+          "type": "string"
+        },
+        "firmware_settings": {
+          "description": "The current firmware settings of the host, by name.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "raid_volumes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/bmc-raid-volume"
+          }
+        }
+      }
+    },
+    "bmc-raid-volume": {
+      "description": "A RAID volume of a host, as configured through its BMC.",
+      "type": "object",
+      "properties": {
+        "level": {
+          "description": "The RAID level of the volume, like 0, 1, 5, 6, 1+0, 5+0 or 6+0.",
+          "type": "string"
+        },
+        "physical_disks": {
+          "description": "The number of physical disks of the volume.",
+          "type": "integer"
+        },
+        "size_gibibytes": {
+          "description": "The size of the volume in GiB, 0 when it uses all the space of its disks.",
+          "type": "integer"
+        },
+        "software": {
+          "description": "Whether the volume is a software RAID volume.",
+          "type": "boolean"
+        }
+      }
+    },
     "boot": {
       "type": "object",
       "properties": {
@@ -18724,6 +21963,11 @@ func init() {
           "x-go-custom-tag": "gorm:\"foreignkey:ClusterID;references:ID\"",
           "x-nullable": true
         },
+        "spoke_health": {
+          "description": "JSON formatted health of the installed cluster, collected periodically after the installation when the spoke health monitoring is enabled.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
         "ssh_public_key": {
           "description": "SSH public key for debugging OpenShift nodes.",
           "type": "string"
@@ -19157,6 +22401,7 @@ func init() {
         "sufficient-masters-count",
         "dns-domain-defined",
         "pull-secret-set",
+        "pull-secret-valid",
         "ntp-server-configured",
         "lso-requirements-satisfied",
         "ocs-requirements-satisfied",
@@ -19190,7 +22435,8 @@ func init() {
         "oadp-requirements-satisfied",
         "metallb-requirements-satisfied",
         "loki-requirements-satisfied",
-        "openshift-logging-requirements-satisfied"
+        "openshift-logging-requirements-satisfied",
+        "approval-rules-satisfied"
       ]
     },
     "cluster_default_config": {
@@ -19945,7 +23191,7 @@ func init() {
         "event_time": {
           "type": "string",
           "format": "date-time",
-          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone;primaryKey\""
         },
         "host_id": {
           "description": "Unique identifier of the host this event relates to.",
@@ -19987,15 +23233,102 @@ func init() {
             "error",
             "critical"
           ]
+        },
+        "trace_id": {
+          "description": "Identifier of the OpenTelemetry trace of the request that caused this event to occur.",
+          "type": "string"
+        }
+      }
+    },
+    "event-aggregation": {
+      "description": "Number of events in a time bucket, grouped by the requested fields.",
+      "type": "object",
+      "required": [
+        "bucket_start",
+        "count"
+      ],
+      "properties": {
+        "bucket_start": {
+          "description": "Start of the time bucket.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "count": {
+          "description": "Number of events in the bucket.",
+          "type": "integer"
+        },
+        "name": {
+          "description": "Name of the events, when grouped by name.",
+          "type": "string"
+        },
+        "severity": {
+          "description": "Severity of the events, when grouped by severity.",
+          "type": "string"
         }
       }
     },
+    "event-aggregation-list": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/event-aggregation"
+      }
+    },
     "event-list": {
       "type": "array",
       "items": {
         "$ref": "#/definitions/event"
       }
     },
+    "event-rate-limit": {
+      "description": "Rate limiting policy applied to the events with a given name.",
+      "type": "object",
+      "required": [
+        "event_name"
+      ],
+      "properties": {
+        "burst": {
+          "description": "Number of events that can be emitted at once before the per minute rate applies.",
+          "type": "integer",
+          "minimum": 0
+        },
+        "event_name": {
+          "description": "Name of the event the policy applies to.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "interval": {
+          "description": "Minimum duration between two events with the same name for the same resource, for example 1h.",
+          "type": "string"
+        },
+        "rate_per_minute": {
+          "description": "Number of events per minute allowed for the same resource once the burst is consumed.",
+          "type": "number",
+          "minimum": 0
+        },
+        "sample_rate": {
+          "description": "Fraction of the info events that are kept, between 0 and 1. Defaults to 1, 0 discards all of them.",
+          "type": "number",
+          "maximum": 1,
+          "minimum": 0,
+          "x-nullable": true
+        },
+        "source": {
+          "description": "Where the policy comes from.",
+          "type": "string",
+          "enum": [
+            "default",
+            "config",
+            "api"
+          ]
+        }
+      }
+    },
+    "event-rate-limits": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/event-rate-limit"
+      }
+    },
     "feature": {
       "type": "object",
       "required": [
@@ -20127,6 +23460,38 @@ func init() {
         "Done"
       ]
     },
+    "firmware-requirements": {
+      "description": "The firmware configuration required from the hosts with a BareMetalHost that register to an infra-env.",
+      "type": "object",
+      "properties": {
+        "bios_settings": {
+          "description": "The firmware settings that the host must have, by name.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "bios_versions": {
+          "description": "The BIOS version of the host must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "raid_levels": {
+          "description": "The RAID levels of the volumes that the host must have, one volume for each level.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "secure_boot": {
+          "description": "Whether secure boot must be enabled or disabled, any state is accepted when not set.",
+          "type": "boolean",
+          "x-nullable": true
+        }
+      }
+    },
     "free-addresses-list": {
       "type": "array",
       "items": {
@@ -20203,6 +23568,11 @@ func init() {
           "type": "string",
           "x-go-custom-tag": "gorm:\"type:text\""
         },
+        "bmc_inventory": {
+          "description": "JSON formatted string of the firmware, boot and RAID details of the host reported by its BareMetalHost.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
         "bootstrap": {
           "type": "boolean"
         },
@@ -20447,7 +23817,8 @@ func init() {
             "disabled-unbound",
             "discovering-unbound",
             "reclaiming",
-            "reclaiming-rebooting"
+            "reclaiming-rebooting",
+            "pending-attestation"
           ]
         },
         "status_info": {
@@ -20495,6 +23866,92 @@ func init() {
         }
       }
     },
+    "host-approval-policy": {
+      "description": "The rules that decide whether the hosts that register to an infra-env are approved or quarantined.",
+      "type": "object",
+      "properties": {
+        "default_action": {
+          "description": "What happens to the hosts that don't match any rule, manual leaves them to the user like without rules.",
+          "type": "string",
+          "enum": [
+            "approve",
+            "quarantine",
+            "manual"
+          ]
+        },
+        "rules": {
+          "description": "The first rule that matches the host decides.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/host-approval-rule"
+          }
+        }
+      }
+    },
+    "host-approval-rule": {
+      "description": "A rule that approves or quarantines the hosts that match all its criteria, a rule without criteria matches all the hosts.",
+      "type": "object",
+      "required": [
+        "action"
+      ],
+      "properties": {
+        "action": {
+          "description": "What happens to the hosts that match the rule.",
+          "type": "string",
+          "enum": [
+            "approve",
+            "quarantine"
+          ]
+        },
+        "mac_ranges": {
+          "description": "The host must have a MAC address in one of the ranges: a single MAC address, an inclusive range of two MAC addresses separated by a dash, or a prefix of up to five octets like an OUI.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "manufacturers": {
+          "description": "The system manufacturer of the host reported by its SMBIOS, e.g. Dell Inc., must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "min_cpu_cores": {
+          "description": "The host must have at least this number of CPU cores.",
+          "type": "integer",
+          "minimum": 0
+        },
+        "min_memory_mib": {
+          "description": "The host must have at least this amount of physical memory, in MiB.",
+          "type": "integer",
+          "minimum": 0
+        },
+        "name": {
+          "description": "Name of the rule, reported with the decisions it makes.",
+          "type": "string"
+        },
+        "product_names": {
+          "description": "The product name of the host must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "serial_numbers": {
+          "description": "The serial number of the host must match one of the shell patterns.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "virtual": {
+          "description": "The host must be a virtual machine when true, a physical machine when false.",
+          "type": "boolean",
+          "x-nullable": true
+        }
+      }
+    },
     "host-create-params": {
       "type": "object",
       "required": [
@@ -20507,6 +23964,9 @@ func init() {
         "host_id": {
           "type": "string",
           "format": "uuid"
+        },
+        "tpm_endorsement": {
+          "$ref": "#/definitions/tpm-endorsement"
         }
       }
     },
@@ -20767,7 +24227,11 @@ func init() {
         "oadp-requirements-satisfied",
         "metallb-requirements-satisfied",
         "loki-requirements-satisfied",
-        "openshift-logging-requirements-satisfied"
+        "openshift-logging-requirements-satisfied",
+        "approval-rules-satisfied",
+        "secure-boot-requirements-satisfied",
+        "firmware-requirements-satisfied",
+        "raid-requirements-satisfied"
       ]
     },
     "host_network": {
@@ -21052,10 +24516,22 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
         },
+        "firmware_requirements": {
+          "description": "JSON formatted firmware requirements of the hosts with a BareMetalHost that register to the infra-env.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\"",
+          "x-nullable": true
+        },
         "generator_version": {
           "description": "Image generator version.",
           "type": "string"
         },
+        "host_approval_policy": {
+          "description": "JSON formatted host approval policy of the hosts that register to the infra-env.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\"",
+          "x-nullable": true
+        },
         "href": {
           "description": "Self link.",
           "type": "string"
@@ -21130,6 +24606,12 @@ func init() {
           "description": "static network configuration string in the format expected by discovery ignition generation.",
           "type": "string"
         },
+        "tpm_attestation_policy": {
+          "description": "JSON formatted TPM attestation policy required from the hosts that register to the infra-env.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\"",
+          "x-nullable": true
+        },
         "type": {
           "$ref": "#/definitions/image_type"
         },
@@ -21190,6 +24672,12 @@ func init() {
           ],
           "x-nullable": false
         },
+        "firmware_requirements": {
+          "$ref": "#/definitions/firmware-requirements"
+        },
+        "host_approval_policy": {
+          "$ref": "#/definitions/host-approval-policy"
+        },
         "ignition_config_override": {
           "description": "JSON formatted string containing the user overrides for the initial ignition config.",
           "type": "string"
@@ -21243,6 +24731,9 @@ func init() {
           "items": {
             "$ref": "#/definitions/host_static_network_config"
           }
+        },
+        "tpm_attestation_policy": {
+          "$ref": "#/definitions/tpm-attestation-policy"
         }
       }
     },
@@ -21266,6 +24757,12 @@ func init() {
           "maxLength": 65535,
           "x-nullable": true
         },
+        "firmware_requirements": {
+          "$ref": "#/definitions/firmware-requirements"
+        },
+        "host_approval_policy": {
+          "$ref": "#/definitions/host-approval-policy"
+        },
         "ignition_config_override": {
           "description": "JSON formatted string containing the user overrides for the initial ignition config.",
           "type": "string"
@@ -21316,6 +24813,9 @@ func init() {
           "items": {
             "$ref": "#/definitions/host_static_network_config"
           }
+        },
+        "tpm_attestation_policy": {
+          "$ref": "#/definitions/tpm-attestation-policy"
         }
       }
     },
@@ -21941,6 +25441,50 @@ func init() {
         "meminfo"
       ]
     },
+    "migrate_agent_request": {
+      "description": "Information sent to the agent for migrating a host to the infra-env of a peer service, without rebooting it.",
+      "type": "object",
+      "required": [
+        "service_base_url",
+        "infra_env_id",
+        "host_fs_mount_dir"
+      ],
+      "properties": {
+        "host_fs_mount_dir": {
+          "description": "The base directory on the host that contains the configuration of the agent, it is\nupdated so the agent reaches the peer service after it is restarted.",
+          "type": "string"
+        },
+        "infra_env_id": {
+          "description": "The infra-env of the peer service that the host belongs to from now on.",
+          "type": "string",
+          "format": "uuid"
+        },
+        "service_base_url": {
+          "description": "The URL of the peer service that the agent must reach from now on.",
+          "type": "string"
+        },
+        "token": {
+          "description": "The agent authentication token issued by the peer service, empty when the agent keeps its current credentials.",
+          "type": "string"
+        }
+      }
+    },
+    "migrate_agent_response": {
+      "type": "object",
+      "properties": {
+        "result": {
+          "$ref": "#/definitions/migrate_agent_result"
+        }
+      }
+    },
+    "migrate_agent_result": {
+      "description": "Agent migration result.",
+      "type": "string",
+      "enum": [
+        "success",
+        "failure"
+      ]
+    },
     "monitored-operator": {
       "type": "object",
       "properties": {
@@ -22481,6 +26025,59 @@ func init() {
       },
       "x-go-custom-tag": "gorm:\"embedded;embeddedPrefix:proxy_\""
     },
+    "quota-usage": {
+      "description": "The usage of a resource by a tenant, and the limit of its quota.",
+      "type": "object",
+      "required": [
+        "scope",
+        "tenant",
+        "resource",
+        "usage"
+      ],
+      "properties": {
+        "limit": {
+          "description": "The maximum usage allowed, unlimited when not set.",
+          "type": "integer",
+          "x-nullable": true
+        },
+        "period": {
+          "description": "The period over which the ISO downloads are counted, e.g. 24h0m0s.",
+          "type": "string"
+        },
+        "resource": {
+          "description": "The resource the quota limits.",
+          "type": "string",
+          "enum": [
+            "clusters",
+            "infra-envs",
+            "hosts",
+            "iso-downloads"
+          ]
+        },
+        "scope": {
+          "description": "Whether the quota applies to an organization or to a user.",
+          "type": "string",
+          "enum": [
+            "org",
+            "user"
+          ]
+        },
+        "tenant": {
+          "description": "The ID of the organization or the name of the user.",
+          "type": "string"
+        },
+        "usage": {
+          "description": "The number of resources of the tenant, or of ISO downloads during the period.",
+          "type": "integer"
+        }
+      }
+    },
+    "quota-usages": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/quota-usage"
+      }
+    },
     "reboot_for_reclaim_request": {
       "description": "Information sent to the agent for rebooting a host into discovery.",
       "type": "object",
@@ -22620,6 +26217,102 @@ func init() {
         "$ref": "#/definitions/release-source"
       }
     },
+    "role-binding": {
+      "description": "Grants a role on a cluster, an infra-env or a whole tenant to a user or a group of the OIDC identity provider.",
+      "type": "object",
+      "required": [
+        "subject_kind",
+        "subject",
+        "role",
+        "scope",
+        "resource_id"
+      ],
+      "properties": {
+        "created_at": {
+          "description": "The time the role binding was created.",
+          "type": "string",
+          "format": "date-time",
+          "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
+        },
+        "created_by": {
+          "description": "Name of the user that created the role binding.",
+          "type": "string"
+        },
+        "id": {
+          "description": "Unique identifier of the role binding.",
+          "type": "string",
+          "format": "uuid",
+          "x-go-custom-tag": "gorm:\"primaryKey\""
+        },
+        "resource_id": {
+          "description": "ID of the cluster or of the infra-env, or name of the tenant, the role is granted on.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "role": {
+          "description": "Viewers can read the resources, editors can also modify them and admins can also manage the role bindings of the resources.",
+          "type": "string",
+          "enum": [
+            "viewer",
+            "editor",
+            "admin"
+          ]
+        },
+        "scope": {
+          "description": "Kind of the resource the role is granted on.",
+          "type": "string",
+          "enum": [
+            "cluster",
+            "infra-env",
+            "tenant"
+          ]
+        },
+        "subject": {
+          "description": "Name of the user or of the group the role is granted to.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "subject_kind": {
+          "description": "Whether the subject is a user name or a group name.",
+          "type": "string",
+          "enum": [
+            "user",
+            "group"
+          ]
+        }
+      }
+    },
+    "role-bindings": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/role-binding"
+      }
+    },
+    "rotate_agent_token_request": {
+      "type": "object",
+      "properties": {
+        "token": {
+          "description": "The new agent authentication token the agent must use from now on.",
+          "type": "string"
+        }
+      }
+    },
+    "rotate_agent_token_response": {
+      "type": "object",
+      "properties": {
+        "result": {
+          "$ref": "#/definitions/rotate_agent_token_result"
+        }
+      }
+    },
+    "rotate_agent_token_result": {
+      "description": "Agent token rotation result.",
+      "type": "string",
+      "enum": [
+        "success",
+        "failure"
+      ]
+    },
     "route": {
       "type": "object",
       "properties": {
@@ -22683,6 +26376,75 @@ func init() {
         "unreachable"
       ]
     },
+    "spoke-health": {
+      "description": "The health of an installed cluster, collected periodically by the service after the installation.",
+      "type": "object",
+      "properties": {
+        "checked_at": {
+          "description": "The time that the health was collected.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "cluster_version": {
+          "description": "The current version of the cluster.",
+          "type": "string"
+        },
+        "cluster_version_available": {
+          "description": "Whether the cluster version operator reports the cluster as available.",
+          "type": "boolean"
+        },
+        "cluster_version_failing": {
+          "description": "Whether the cluster version operator reports a failure.",
+          "type": "boolean"
+        },
+        "cluster_version_progressing": {
+          "description": "Whether the cluster is being upgraded.",
+          "type": "boolean"
+        },
+        "degraded_operators": {
+          "description": "The cluster operators that are degraded.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "message": {
+          "description": "What isn't healthy in the cluster.",
+          "type": "string"
+        },
+        "not_ready_nodes": {
+          "description": "The nodes of the cluster that aren't ready.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "ready_nodes": {
+          "description": "The number of nodes of the cluster that are ready.",
+          "type": "integer"
+        },
+        "status": {
+          "description": "healthy when the cluster version, the cluster operators and the nodes are healthy, degraded when one of them is not, and unavailable when the cluster can't be reached.",
+          "type": "string",
+          "enum": [
+            "healthy",
+            "degraded",
+            "unavailable"
+          ]
+        },
+        "total_nodes": {
+          "description": "The number of nodes of the cluster.",
+          "type": "integer"
+        },
+        "unavailable_operators": {
+          "description": "The cluster operators that aren't available.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "step": {
       "type": "object",
       "properties": {
@@ -22741,7 +26503,10 @@ func init() {
         "upgrade-agent",
         "download-boot-artifacts",
         "reboot-for-reclaim",
-        "verify-vips"
+        "verify-vips",
+        "rotate-agent-token",
+        "tpm-attestation",
+        "migrate-agent"
       ]
     },
     "steps": {
@@ -22841,6 +26606,105 @@ func init() {
         }
       }
     },
+    "tpm-attestation-policy": {
+      "description": "The TPM attestation required from the hosts that register to an infra-env.",
+      "type": "object",
+      "properties": {
+        "ek_certificates": {
+          "description": "PEM-encoded X.509 certificate bundle. The endorsement key certificates of the hosts must be in the bundle, or be issued by a certificate authority in the bundle. An empty bundle disables the attestation.",
+          "type": "string"
+        },
+        "pcr_policies": {
+          "description": "The PCR values of the hosts must match one of the policies. All the PCR values are accepted when there is no policy.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/tpm-pcr-policy"
+          }
+        }
+      }
+    },
+    "tpm-attestation-request": {
+      "type": "object",
+      "properties": {
+        "credential_blob": {
+          "description": "Base64 encoded TPM2B_ID_OBJECT to activate with the attestation and endorsement keys.",
+          "type": "string"
+        },
+        "encrypted_secret": {
+          "description": "Base64 encoded TPM2B_ENCRYPTED_SECRET to activate with the attestation and endorsement keys.",
+          "type": "string"
+        },
+        "nonce": {
+          "description": "Base64 encoded nonce that the quote must include as its qualifying data.",
+          "type": "string"
+        },
+        "pcr_selection": {
+          "description": "The PCRs of the SHA-256 bank to quote.",
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
+        }
+      }
+    },
+    "tpm-attestation-response": {
+      "type": "object",
+      "properties": {
+        "pcrs": {
+          "description": "The hex encoded values of the quoted PCRs, by PCR index.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "quote": {
+          "description": "Base64 encoded attestation structure (TPMS_ATTEST) of the quote.",
+          "type": "string"
+        },
+        "secret": {
+          "description": "Base64 encoded secret of the activated credential.",
+          "type": "string"
+        },
+        "signature": {
+          "description": "Base64 encoded signature (TPMT_SIGNATURE) of the quote by the attestation key.",
+          "type": "string"
+        }
+      }
+    },
+    "tpm-endorsement": {
+      "description": "The TPM keys that a host attests with.",
+      "type": "object",
+      "properties": {
+        "ak_public": {
+          "description": "Base64 encoded public area (TPMT_PUBLIC) of the attestation key created by the TPM.",
+          "type": "string"
+        },
+        "ek_certificate": {
+          "description": "PEM-encoded X.509 certificate of the endorsement key of the TPM.",
+          "type": "string"
+        }
+      }
+    },
+    "tpm-pcr-policy": {
+      "description": "The expected values of some PCRs of the SHA-256 bank of the TPM.",
+      "type": "object",
+      "required": [
+        "pcrs"
+      ],
+      "properties": {
+        "name": {
+          "description": "Name of the policy, reported when the host matches it.",
+          "type": "string"
+        },
+        "pcrs": {
+          "description": "The hex encoded SHA-256 values of the PCRs, by PCR index.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "update-manifest-params": {
       "type": "object",
       "required": [
@@ -23358,6 +27222,18 @@ func init() {
       "description": "Agent-driven installation",
       "name": "Assisted installation"
     },
+    {
+      "description": "Rotation and revocation of the tokens the agents authenticate with.",
+      "name": "agent_tokens"
+    },
+    {
+      "description": "Audit log of the calls that modify the resources of the service.",
+      "name": "audit"
+    },
+    {
+      "description": "Access control to the resources of the service.",
+      "name": "authz"
+    },
     {
       "description": "Events related to a cluster installation.",
       "name": "events"
@@ -23378,6 +27254,14 @@ func init() {
       "description": "Information regarding supported operators.",
       "name": "operators"
     },
+    {
+      "description": "Limits of the resources the tenants can create, and their usage.",
+      "name": "quotas"
+    },
+    {
+      "description": "API tokens used by automation to access the service.",
+      "name": "tokens"
+    },
     {
       "description": "Information regarding versions.",
       "name": "versions"
@@ -19,11 +19,16 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"github.com/openshift/assisted-service/restapi/operations/agent_tokens"
+	"github.com/openshift/assisted-service/restapi/operations/audit"
+	"github.com/openshift/assisted-service/restapi/operations/authz"
 	"github.com/openshift/assisted-service/restapi/operations/events"
 	"github.com/openshift/assisted-service/restapi/operations/installer"
 	"github.com/openshift/assisted-service/restapi/operations/managed_domains"
 	"github.com/openshift/assisted-service/restapi/operations/manifests"
 	"github.com/openshift/assisted-service/restapi/operations/operators"
+	"github.com/openshift/assisted-service/restapi/operations/quotas"
+	"github.com/openshift/assisted-service/restapi/operations/tokens"
 	"github.com/openshift/assisted-service/restapi/operations/versions"
 )
 
@@ -105,12 +110,21 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerUpdateInfraEnvHandler: installer.UpdateInfraEnvHandlerFunc(func(params installer.UpdateInfraEnvParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.UpdateInfraEnv has not yet been implemented")
 		}),
+		EventsV2AggregateEventsHandler: events.V2AggregateEventsHandlerFunc(func(params events.V2AggregateEventsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation events.V2AggregateEvents has not yet been implemented")
+		}),
 		InstallerV2CancelInstallationHandler: installer.V2CancelInstallationHandlerFunc(func(params installer.V2CancelInstallationParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2CancelInstallation has not yet been implemented")
 		}),
+		TokensV2CreateAPITokenHandler: tokens.V2CreateAPITokenHandlerFunc(func(params tokens.V2CreateAPITokenParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation tokens.V2CreateAPIToken has not yet been implemented")
+		}),
 		ManifestsV2CreateClusterManifestHandler: manifests.V2CreateClusterManifestHandlerFunc(func(params manifests.V2CreateClusterManifestParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation manifests.V2CreateClusterManifest has not yet been implemented")
 		}),
+		AuthzV2CreateRoleBindingHandler: authz.V2CreateRoleBindingHandlerFunc(func(params authz.V2CreateRoleBindingParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation authz.V2CreateRoleBinding has not yet been implemented")
+		}),
 		ManifestsV2DeleteClusterManifestHandler: manifests.V2DeleteClusterManifestHandlerFunc(func(params manifests.V2DeleteClusterManifestParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation manifests.V2DeleteClusterManifest has not yet been implemented")
 		}),
@@ -141,6 +155,12 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerV2GetPresignedForClusterFilesHandler: installer.V2GetPresignedForClusterFilesHandlerFunc(func(params installer.V2GetPresignedForClusterFilesParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2GetPresignedForClusterFiles has not yet been implemented")
 		}),
+		TokensV2ListAPITokensHandler: tokens.V2ListAPITokensHandlerFunc(func(params tokens.V2ListAPITokensParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation tokens.V2ListAPITokens has not yet been implemented")
+		}),
+		AuditV2ListAuditRecordsHandler: audit.V2ListAuditRecordsHandlerFunc(func(params audit.V2ListAuditRecordsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation audit.V2ListAuditRecords has not yet been implemented")
+		}),
 		OperatorsV2ListBundlesHandler: operators.V2ListBundlesHandlerFunc(func(params operators.V2ListBundlesParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation operators.V2ListBundles has not yet been implemented")
 		}),
@@ -174,6 +194,9 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerV2CompleteInstallationHandler: installer.V2CompleteInstallationHandlerFunc(func(params installer.V2CompleteInstallationParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2CompleteInstallation has not yet been implemented")
 		}),
+		AuthzV2DeleteRoleBindingHandler: authz.V2DeleteRoleBindingHandlerFunc(func(params authz.V2DeleteRoleBindingParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation authz.V2DeleteRoleBinding has not yet been implemented")
+		}),
 		InstallerV2DeregisterClusterHandler: installer.V2DeregisterClusterHandlerFunc(func(params installer.V2DeregisterClusterParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2DeregisterCluster has not yet been implemented")
 		}),
@@ -195,6 +218,9 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerV2GetClusterInstallConfigHandler: installer.V2GetClusterInstallConfigHandlerFunc(func(params installer.V2GetClusterInstallConfigParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2GetClusterInstallConfig has not yet been implemented")
 		}),
+		EventsV2GetEventRateLimitsHandler: events.V2GetEventRateLimitsHandlerFunc(func(params events.V2GetEventRateLimitsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation events.V2GetEventRateLimits has not yet been implemented")
+		}),
 		InstallerV2GetHostHandler: installer.V2GetHostHandlerFunc(func(params installer.V2GetHostParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2GetHost has not yet been implemented")
 		}),
@@ -231,9 +257,15 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerV2ListHostsHandler: installer.V2ListHostsHandlerFunc(func(params installer.V2ListHostsParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2ListHosts has not yet been implemented")
 		}),
+		QuotasV2ListQuotasHandler: quotas.V2ListQuotasHandlerFunc(func(params quotas.V2ListQuotasParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation quotas.V2ListQuotas has not yet been implemented")
+		}),
 		VersionsV2ListReleaseSourcesHandler: versions.V2ListReleaseSourcesHandlerFunc(func(params versions.V2ListReleaseSourcesParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation versions.V2ListReleaseSources has not yet been implemented")
 		}),
+		AuthzV2ListRoleBindingsHandler: authz.V2ListRoleBindingsHandlerFunc(func(params authz.V2ListRoleBindingsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation authz.V2ListRoleBindings has not yet been implemented")
+		}),
 		VersionsV2ListSupportedOpenshiftVersionsHandler: versions.V2ListSupportedOpenshiftVersionsHandlerFunc(func(params versions.V2ListSupportedOpenshiftVersionsParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation versions.V2ListSupportedOpenshiftVersions has not yet been implemented")
 		}),
@@ -261,6 +293,15 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerV2ResetHostValidationHandler: installer.V2ResetHostValidationHandlerFunc(func(params installer.V2ResetHostValidationParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2ResetHostValidation has not yet been implemented")
 		}),
+		TokensV2RevokeAPITokenHandler: tokens.V2RevokeAPITokenHandlerFunc(func(params tokens.V2RevokeAPITokenParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation tokens.V2RevokeAPIToken has not yet been implemented")
+		}),
+		AgentTokensV2RevokeHostAgentTokenHandler: agent_tokens.V2RevokeHostAgentTokenHandlerFunc(func(params agent_tokens.V2RevokeHostAgentTokenParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation agent_tokens.V2RevokeHostAgentToken has not yet been implemented")
+		}),
+		AgentTokensV2RotateAgentTokensHandler: agent_tokens.V2RotateAgentTokensHandlerFunc(func(params agent_tokens.V2RotateAgentTokensParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation agent_tokens.V2RotateAgentTokens has not yet been implemented")
+		}),
 		InstallerV2SetIgnoredValidationsHandler: installer.V2SetIgnoredValidationsHandlerFunc(func(params installer.V2SetIgnoredValidationsParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2SetIgnoredValidations has not yet been implemented")
 		}),
@@ -276,6 +317,9 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerV2UpdateClusterLogsProgressHandler: installer.V2UpdateClusterLogsProgressHandlerFunc(func(params installer.V2UpdateClusterLogsProgressParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2UpdateClusterLogsProgress has not yet been implemented")
 		}),
+		EventsV2UpdateEventRateLimitsHandler: events.V2UpdateEventRateLimitsHandlerFunc(func(params events.V2UpdateEventRateLimitsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation events.V2UpdateEventRateLimits has not yet been implemented")
+		}),
 		InstallerV2UpdateHostHandler: installer.V2UpdateHostHandlerFunc(func(params installer.V2UpdateHostParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation installer.V2UpdateHost has not yet been implemented")
 		}),
@@ -426,10 +470,16 @@ type AssistedInstallAPI struct {
 	InstallerUnbindHostHandler installer.UnbindHostHandler
 	// InstallerUpdateInfraEnvHandler sets the operation handler for the update infra env operation
 	InstallerUpdateInfraEnvHandler installer.UpdateInfraEnvHandler
+	// EventsV2AggregateEventsHandler sets the operation handler for the v2 aggregate events operation
+	EventsV2AggregateEventsHandler events.V2AggregateEventsHandler
 	// InstallerV2CancelInstallationHandler sets the operation handler for the v2 cancel installation operation
 	InstallerV2CancelInstallationHandler installer.V2CancelInstallationHandler
+	// TokensV2CreateAPITokenHandler sets the operation handler for the v2 create api token operation
+	TokensV2CreateAPITokenHandler tokens.V2CreateAPITokenHandler
 	// ManifestsV2CreateClusterManifestHandler sets the operation handler for the v2 create cluster manifest operation
 	ManifestsV2CreateClusterManifestHandler manifests.V2CreateClusterManifestHandler
+	// AuthzV2CreateRoleBindingHandler sets the operation handler for the v2 create role binding operation
+	AuthzV2CreateRoleBindingHandler authz.V2CreateRoleBindingHandler
 	// ManifestsV2DeleteClusterManifestHandler sets the operation handler for the v2 delete cluster manifest operation
 	ManifestsV2DeleteClusterManifestHandler manifests.V2DeleteClusterManifestHandler
 	// InstallerV2DownloadClusterCredentialsHandler sets the operation handler for the v2 download cluster credentials operation
@@ -450,6 +500,10 @@ type AssistedInstallAPI struct {
 	InstallerV2GetPresignedForClusterCredentialsHandler installer.V2GetPresignedForClusterCredentialsHandler
 	// InstallerV2GetPresignedForClusterFilesHandler sets the operation handler for the v2 get presigned for cluster files operation
 	InstallerV2GetPresignedForClusterFilesHandler installer.V2GetPresignedForClusterFilesHandler
+	// TokensV2ListAPITokensHandler sets the operation handler for the v2 list api tokens operation
+	TokensV2ListAPITokensHandler tokens.V2ListAPITokensHandler
+	// AuditV2ListAuditRecordsHandler sets the operation handler for the v2 list audit records operation
+	AuditV2ListAuditRecordsHandler audit.V2ListAuditRecordsHandler
 	// OperatorsV2ListBundlesHandler sets the operation handler for the v2 list bundles operation
 	OperatorsV2ListBundlesHandler operators.V2ListBundlesHandler
 	// ManifestsV2ListClusterManifestsHandler sets the operation handler for the v2 list cluster manifests operation
@@ -472,6 +526,8 @@ type AssistedInstallAPI struct {
 	InstallerV2UploadLogsHandler installer.V2UploadLogsHandler
 	// InstallerV2CompleteInstallationHandler sets the operation handler for the v2 complete installation operation
 	InstallerV2CompleteInstallationHandler installer.V2CompleteInstallationHandler
+	// AuthzV2DeleteRoleBindingHandler sets the operation handler for the v2 delete role binding operation
+	AuthzV2DeleteRoleBindingHandler authz.V2DeleteRoleBindingHandler
 	// InstallerV2DeregisterClusterHandler sets the operation handler for the v2 deregister cluster operation
 	InstallerV2DeregisterClusterHandler installer.V2DeregisterClusterHandler
 	// InstallerV2DeregisterHostHandler sets the operation handler for the v2 deregister host operation
@@ -486,6 +542,8 @@ type AssistedInstallAPI struct {
 	InstallerV2GetClusterHandler installer.V2GetClusterHandler
 	// InstallerV2GetClusterInstallConfigHandler sets the operation handler for the v2 get cluster install config operation
 	InstallerV2GetClusterInstallConfigHandler installer.V2GetClusterInstallConfigHandler
+	// EventsV2GetEventRateLimitsHandler sets the operation handler for the v2 get event rate limits operation
+	EventsV2GetEventRateLimitsHandler events.V2GetEventRateLimitsHandler
 	// InstallerV2GetHostHandler sets the operation handler for the v2 get host operation
 	InstallerV2GetHostHandler installer.V2GetHostHandler
 	// InstallerV2GetHostIgnitionHandler sets the operation handler for the v2 get host ignition operation
@@ -510,8 +568,12 @@ type AssistedInstallAPI struct {
 	EventsV2ListEventsHandler events.V2ListEventsHandler
 	// InstallerV2ListHostsHandler sets the operation handler for the v2 list hosts operation
 	InstallerV2ListHostsHandler installer.V2ListHostsHandler
+	// QuotasV2ListQuotasHandler sets the operation handler for the v2 list quotas operation
+	QuotasV2ListQuotasHandler quotas.V2ListQuotasHandler
 	// VersionsV2ListReleaseSourcesHandler sets the operation handler for the v2 list release sources operation
 	VersionsV2ListReleaseSourcesHandler versions.V2ListReleaseSourcesHandler
+	// AuthzV2ListRoleBindingsHandler sets the operation handler for the v2 list role bindings operation
+	AuthzV2ListRoleBindingsHandler authz.V2ListRoleBindingsHandler
 	// VersionsV2ListSupportedOpenshiftVersionsHandler sets the operation handler for the v2 list supported openshift versions operation
 	VersionsV2ListSupportedOpenshiftVersionsHandler versions.V2ListSupportedOpenshiftVersionsHandler
 	// InstallerV2PostStepReplyHandler sets the operation handler for the v2 post step reply operation
@@ -530,6 +592,12 @@ type AssistedInstallAPI struct {
 	InstallerV2ResetHostHandler installer.V2ResetHostHandler
 	// InstallerV2ResetHostValidationHandler sets the operation handler for the v2 reset host validation operation
 	InstallerV2ResetHostValidationHandler installer.V2ResetHostValidationHandler
+	// TokensV2RevokeAPITokenHandler sets the operation handler for the v2 revoke api token operation
+	TokensV2RevokeAPITokenHandler tokens.V2RevokeAPITokenHandler
+	// AgentTokensV2RevokeHostAgentTokenHandler sets the operation handler for the v2 revoke host agent token operation
+	AgentTokensV2RevokeHostAgentTokenHandler agent_tokens.V2RevokeHostAgentTokenHandler
+	// AgentTokensV2RotateAgentTokensHandler sets the operation handler for the v2 rotate agent tokens operation
+	AgentTokensV2RotateAgentTokensHandler agent_tokens.V2RotateAgentTokensHandler
 	// InstallerV2SetIgnoredValidationsHandler sets the operation handler for the v2 set ignored validations operation
 	InstallerV2SetIgnoredValidationsHandler installer.V2SetIgnoredValidationsHandler
 	// EventsV2TriggerEventHandler sets the operation handler for the v2 trigger event operation
@@ -540,6 +608,8 @@ type AssistedInstallAPI struct {
 	InstallerV2UpdateClusterInstallConfigHandler installer.V2UpdateClusterInstallConfigHandler
 	// InstallerV2UpdateClusterLogsProgressHandler sets the operation handler for the v2 update cluster logs progress operation
 	InstallerV2UpdateClusterLogsProgressHandler installer.V2UpdateClusterLogsProgressHandler
+	// EventsV2UpdateEventRateLimitsHandler sets the operation handler for the v2 update event rate limits operation
+	EventsV2UpdateEventRateLimitsHandler events.V2UpdateEventRateLimitsHandler
 	// InstallerV2UpdateHostHandler sets the operation handler for the v2 update host operation
 	InstallerV2UpdateHostHandler installer.V2UpdateHostHandler
 	// InstallerV2UpdateHostIgnitionHandler sets the operation handler for the v2 update host ignition operation
@@ -708,12 +778,21 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerUpdateInfraEnvHandler == nil {
 		unregistered = append(unregistered, "installer.UpdateInfraEnvHandler")
 	}
+	if o.EventsV2AggregateEventsHandler == nil {
+		unregistered = append(unregistered, "events.V2AggregateEventsHandler")
+	}
 	if o.InstallerV2CancelInstallationHandler == nil {
 		unregistered = append(unregistered, "installer.V2CancelInstallationHandler")
 	}
+	if o.TokensV2CreateAPITokenHandler == nil {
+		unregistered = append(unregistered, "tokens.V2CreateAPITokenHandler")
+	}
 	if o.ManifestsV2CreateClusterManifestHandler == nil {
 		unregistered = append(unregistered, "manifests.V2CreateClusterManifestHandler")
 	}
+	if o.AuthzV2CreateRoleBindingHandler == nil {
+		unregistered = append(unregistered, "authz.V2CreateRoleBindingHandler")
+	}
 	if o.ManifestsV2DeleteClusterManifestHandler == nil {
 		unregistered = append(unregistered, "manifests.V2DeleteClusterManifestHandler")
 	}
@@ -744,6 +823,12 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerV2GetPresignedForClusterFilesHandler == nil {
 		unregistered = append(unregistered, "installer.V2GetPresignedForClusterFilesHandler")
 	}
+	if o.TokensV2ListAPITokensHandler == nil {
+		unregistered = append(unregistered, "tokens.V2ListAPITokensHandler")
+	}
+	if o.AuditV2ListAuditRecordsHandler == nil {
+		unregistered = append(unregistered, "audit.V2ListAuditRecordsHandler")
+	}
 	if o.OperatorsV2ListBundlesHandler == nil {
 		unregistered = append(unregistered, "operators.V2ListBundlesHandler")
 	}
@@ -777,6 +862,9 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerV2CompleteInstallationHandler == nil {
 		unregistered = append(unregistered, "installer.V2CompleteInstallationHandler")
 	}
+	if o.AuthzV2DeleteRoleBindingHandler == nil {
+		unregistered = append(unregistered, "authz.V2DeleteRoleBindingHandler")
+	}
 	if o.InstallerV2DeregisterClusterHandler == nil {
 		unregistered = append(unregistered, "installer.V2DeregisterClusterHandler")
 	}
@@ -798,6 +886,9 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerV2GetClusterInstallConfigHandler == nil {
 		unregistered = append(unregistered, "installer.V2GetClusterInstallConfigHandler")
 	}
+	if o.EventsV2GetEventRateLimitsHandler == nil {
+		unregistered = append(unregistered, "events.V2GetEventRateLimitsHandler")
+	}
 	if o.InstallerV2GetHostHandler == nil {
 		unregistered = append(unregistered, "installer.V2GetHostHandler")
 	}
@@ -834,9 +925,15 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerV2ListHostsHandler == nil {
 		unregistered = append(unregistered, "installer.V2ListHostsHandler")
 	}
+	if o.QuotasV2ListQuotasHandler == nil {
+		unregistered = append(unregistered, "quotas.V2ListQuotasHandler")
+	}
 	if o.VersionsV2ListReleaseSourcesHandler == nil {
 		unregistered = append(unregistered, "versions.V2ListReleaseSourcesHandler")
 	}
+	if o.AuthzV2ListRoleBindingsHandler == nil {
+		unregistered = append(unregistered, "authz.V2ListRoleBindingsHandler")
+	}
 	if o.VersionsV2ListSupportedOpenshiftVersionsHandler == nil {
 		unregistered = append(unregistered, "versions.V2ListSupportedOpenshiftVersionsHandler")
 	}
@@ -864,6 +961,15 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerV2ResetHostValidationHandler == nil {
 		unregistered = append(unregistered, "installer.V2ResetHostValidationHandler")
 	}
+	if o.TokensV2RevokeAPITokenHandler == nil {
+		unregistered = append(unregistered, "tokens.V2RevokeAPITokenHandler")
+	}
+	if o.AgentTokensV2RevokeHostAgentTokenHandler == nil {
+		unregistered = append(unregistered, "agent_tokens.V2RevokeHostAgentTokenHandler")
+	}
+	if o.AgentTokensV2RotateAgentTokensHandler == nil {
+		unregistered = append(unregistered, "agent_tokens.V2RotateAgentTokensHandler")
+	}
 	if o.InstallerV2SetIgnoredValidationsHandler == nil {
 		unregistered = append(unregistered, "installer.V2SetIgnoredValidationsHandler")
 	}
@@ -879,6 +985,9 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerV2UpdateClusterLogsProgressHandler == nil {
 		unregistered = append(unregistered, "installer.V2UpdateClusterLogsProgressHandler")
 	}
+	if o.EventsV2UpdateEventRateLimitsHandler == nil {
+		unregistered = append(unregistered, "events.V2UpdateEventRateLimitsHandler")
+	}
 	if o.InstallerV2UpdateHostHandler == nil {
 		unregistered = append(unregistered, "installer.V2UpdateHostHandler")
 	}
@@ -1090,6 +1199,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
 	o.handlers["PATCH"]["/v2/infra-envs/{infra_env_id}"] = installer.NewUpdateInfraEnv(o.context, o.InstallerUpdateInfraEnvHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/events/aggregations"] = events.NewV2AggregateEvents(o.context, o.EventsV2AggregateEventsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -1097,7 +1210,15 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/v2/api-tokens"] = tokens.NewV2CreateAPIToken(o.context, o.TokensV2CreateAPITokenHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/v2/clusters/{cluster_id}/manifests"] = manifests.NewV2CreateClusterManifest(o.context, o.ManifestsV2CreateClusterManifestHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/v2/role-bindings"] = authz.NewV2CreateRoleBinding(o.context, o.AuthzV2CreateRoleBindingHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -1141,6 +1262,14 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/v2/api-tokens"] = tokens.NewV2ListAPITokens(o.context, o.TokensV2ListAPITokensHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/audit-records"] = audit.NewV2ListAuditRecords(o.context, o.AuditV2ListAuditRecordsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/v2/operators/bundles"] = operators.NewV2ListBundles(o.context, o.OperatorsV2ListBundlesHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -1185,6 +1314,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
+	o.handlers["DELETE"]["/v2/role-bindings/{role_binding_id}"] = authz.NewV2DeleteRoleBinding(o.context, o.AuthzV2DeleteRoleBindingHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
 	o.handlers["DELETE"]["/v2/clusters/{cluster_id}"] = installer.NewV2DeregisterCluster(o.context, o.InstallerV2DeregisterClusterHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
@@ -1213,6 +1346,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/v2/events/rate-limits"] = events.NewV2GetEventRateLimits(o.context, o.EventsV2GetEventRateLimitsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/v2/infra-envs/{infra_env_id}/hosts/{host_id}"] = installer.NewV2GetHost(o.context, o.InstallerV2GetHostHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -1261,10 +1398,18 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/v2/quotas"] = quotas.NewV2ListQuotas(o.context, o.QuotasV2ListQuotasHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/v2/release-sources"] = versions.NewV2ListReleaseSources(o.context, o.VersionsV2ListReleaseSourcesHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/v2/role-bindings"] = authz.NewV2ListRoleBindings(o.context, o.AuthzV2ListRoleBindingsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/v2/openshift-versions"] = versions.NewV2ListSupportedOpenshiftVersions(o.context, o.VersionsV2ListSupportedOpenshiftVersionsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -1298,6 +1443,18 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
 	o.handlers["PATCH"]["/v2/infra-envs/{infra_env_id}/hosts/{host_id}/actions/reset-validation/{validation_id}"] = installer.NewV2ResetHostValidation(o.context, o.InstallerV2ResetHostValidationHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/v2/api-tokens/{api_token_id}"] = tokens.NewV2RevokeAPIToken(o.context, o.TokensV2RevokeAPITokenHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/v2/infra-envs/{infra_env_id}/hosts/{host_id}/agent-token/revoke"] = agent_tokens.NewV2RevokeHostAgentToken(o.context, o.AgentTokensV2RevokeHostAgentTokenHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/v2/infra-envs/{infra_env_id}/agent-tokens/rotate"] = agent_tokens.NewV2RotateAgentTokens(o.context, o.AgentTokensV2RotateAgentTokensHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -1318,6 +1475,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/v2/clusters/{cluster_id}/logs-progress"] = installer.NewV2UpdateClusterLogsProgress(o.context, o.InstallerV2UpdateClusterLogsProgressHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/v2/events/rate-limits"] = events.NewV2UpdateEventRateLimits(o.context, o.EventsV2UpdateEventRateLimitsHandler)
 	if o.handlers["PATCH"] == nil {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
@@ -53,10 +53,18 @@ type V2ListEventsParams struct {
 	  In: query
 	*/
 	ClusterLevel *bool
+	/*Cursor returned in the Next-Cursor header of the previous page. Used instead of offset, it can't be combined with include_archived.
+	  In: query
+	*/
+	Cursor *string
 	/*Deleted hosts flag.
 	  In: query
 	*/
 	DeletedHosts *bool
+	/*Only retrieve the events that occurred before this time.
+	  In: query
+	*/
+	EndTime *strfmt.DateTime
 	/*A host in the specified cluster to return events for (DEPRECATED. Use `host_ids` instead).
 	  In: query
 	*/
@@ -65,6 +73,10 @@ type V2ListEventsParams struct {
 	  In: query
 	*/
 	HostIds []strfmt.UUID
+	/*Also return events that were archived to object storage by the events retention policy.
+	  In: query
+	*/
+	IncludeArchived *bool
 	/*The infra-env to return events for.
 	  In: query
 	*/
@@ -77,6 +89,10 @@ type V2ListEventsParams struct {
 	  In: query
 	*/
 	Message *string
+	/*Retrieved events names.
+	  In: query
+	*/
+	Names []string
 	/*Number of records to skip before starting to return the records.
 	  In: query
 	*/
@@ -86,10 +102,26 @@ type V2ListEventsParams struct {
 	  Default: "ascending"
 	*/
 	Order *string
+	/*A JSON object that the properties of the retrieved events must contain.
+	  In: query
+	*/
+	Props *string
+	/*Only retrieve the events caused by this request.
+	  In: query
+	*/
+	RequestID *strfmt.UUID
+	/*Full-text search over the messages of the retrieved events.
+	  In: query
+	*/
+	Search *string
 	/*Retrieved events severities.
 	  In: query
 	*/
 	Severities []string
+	/*Only retrieve the events that occurred at or after this time.
+	  In: query
+	*/
+	StartTime *strfmt.DateTime
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -118,11 +150,21 @@ func (o *V2ListEventsParams) BindRequest(r *http.Request, route *middleware.Matc
 		res = append(res, err)
 	}
 
+	qCursor, qhkCursor, _ := qs.GetOK("cursor")
+	if err := o.bindCursor(qCursor, qhkCursor, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qDeletedHosts, qhkDeletedHosts, _ := qs.GetOK("deleted_hosts")
 	if err := o.bindDeletedHosts(qDeletedHosts, qhkDeletedHosts, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qEndTime, qhkEndTime, _ := qs.GetOK("end_time")
+	if err := o.bindEndTime(qEndTime, qhkEndTime, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qHostID, qhkHostID, _ := qs.GetOK("host_id")
 	if err := o.bindHostID(qHostID, qhkHostID, route.Formats); err != nil {
 		res = append(res, err)
@@ -133,6 +175,11 @@ func (o *V2ListEventsParams) BindRequest(r *http.Request, route *middleware.Matc
 		res = append(res, err)
 	}
 
+	qIncludeArchived, qhkIncludeArchived, _ := qs.GetOK("include_archived")
+	if err := o.bindIncludeArchived(qIncludeArchived, qhkIncludeArchived, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qInfraEnvID, qhkInfraEnvID, _ := qs.GetOK("infra_env_id")
 	if err := o.bindInfraEnvID(qInfraEnvID, qhkInfraEnvID, route.Formats); err != nil {
 		res = append(res, err)
@@ -148,6 +195,11 @@ func (o *V2ListEventsParams) BindRequest(r *http.Request, route *middleware.Matc
 		res = append(res, err)
 	}
 
+	qNames, qhkNames, _ := qs.GetOK("names")
+	if err := o.bindNames(qNames, qhkNames, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qOffset, qhkOffset, _ := qs.GetOK("offset")
 	if err := o.bindOffset(qOffset, qhkOffset, route.Formats); err != nil {
 		res = append(res, err)
@@ -158,10 +210,30 @@ func (o *V2ListEventsParams) BindRequest(r *http.Request, route *middleware.Matc
 		res = append(res, err)
 	}
 
+	qProps, qhkProps, _ := qs.GetOK("props")
+	if err := o.bindProps(qProps, qhkProps, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qRequestID, qhkRequestID, _ := qs.GetOK("request_id")
+	if err := o.bindRequestID(qRequestID, qhkRequestID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qSearch, qhkSearch, _ := qs.GetOK("search")
+	if err := o.bindSearch(qSearch, qhkSearch, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qSeverities, qhkSeverities, _ := qs.GetOK("severities")
 	if err := o.bindSeverities(qSeverities, qhkSeverities, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qStartTime, qhkStartTime, _ := qs.GetOK("start_time")
+	if err := o.bindStartTime(qStartTime, qhkStartTime, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -255,6 +327,24 @@ func (o *V2ListEventsParams) bindClusterLevel(rawData []string, hasKey bool, for
 	return nil
 }
 
+// bindCursor binds and validates parameter Cursor from query.
+func (o *V2ListEventsParams) bindCursor(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Cursor = &raw
+
+	return nil
+}
+
 // bindDeletedHosts binds and validates parameter DeletedHosts from query.
 func (o *V2ListEventsParams) bindDeletedHosts(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -278,6 +368,43 @@ func (o *V2ListEventsParams) bindDeletedHosts(rawData []string, hasKey bool, for
 	return nil
 }
 
+// bindEndTime binds and validates parameter EndTime from query.
+func (o *V2ListEventsParams) bindEndTime(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("end_time", "query", "strfmt.DateTime", raw)
+	}
+	o.EndTime = (value.(*strfmt.DateTime))
+
+	if err := o.validateEndTime(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateEndTime carries on validations for parameter EndTime
+func (o *V2ListEventsParams) validateEndTime(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("end_time", "query", "date-time", o.EndTime.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
 // bindHostID binds and validates parameter HostID from query.
 func (o *V2ListEventsParams) bindHostID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -350,6 +477,29 @@ func (o *V2ListEventsParams) bindHostIds(rawData []string, hasKey bool, formats
 	return nil
 }
 
+// bindIncludeArchived binds and validates parameter IncludeArchived from query.
+func (o *V2ListEventsParams) bindIncludeArchived(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("include_archived", "query", "bool", raw)
+	}
+	o.IncludeArchived = &value
+
+	return nil
+}
+
 // bindInfraEnvID binds and validates parameter InfraEnvID from query.
 func (o *V2ListEventsParams) bindInfraEnvID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -428,6 +578,33 @@ func (o *V2ListEventsParams) bindMessage(rawData []string, hasKey bool, formats
 	return nil
 }
 
+// bindNames binds and validates array parameter Names from query.
+//
+// Arrays are parsed according to CollectionFormat: "" (defaults to "csv" when empty).
+func (o *V2ListEventsParams) bindNames(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var qvNames string
+	if len(rawData) > 0 {
+		qvNames = rawData[len(rawData)-1]
+	}
+
+	// CollectionFormat:
+	namesIC := swag.SplitByFormat(qvNames, "")
+	if len(namesIC) == 0 {
+		return nil
+	}
+
+	var namesIR []string
+	for _, namesIV := range namesIC {
+		namesI := namesIV
+
+		namesIR = append(namesIR, namesI)
+	}
+
+	o.Names = namesIR
+
+	return nil
+}
+
 // bindOffset binds and validates parameter Offset from query.
 func (o *V2ListEventsParams) bindOffset(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -484,6 +661,79 @@ func (o *V2ListEventsParams) validateOrder(formats strfmt.Registry) error {
 	return nil
 }
 
+// bindProps binds and validates parameter Props from query.
+func (o *V2ListEventsParams) bindProps(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Props = &raw
+
+	return nil
+}
+
+// bindRequestID binds and validates parameter RequestID from query.
+func (o *V2ListEventsParams) bindRequestID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: uuid
+	value, err := formats.Parse("uuid", raw)
+	if err != nil {
+		return errors.InvalidType("request_id", "query", "strfmt.UUID", raw)
+	}
+	o.RequestID = (value.(*strfmt.UUID))
+
+	if err := o.validateRequestID(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateRequestID carries on validations for parameter RequestID
+func (o *V2ListEventsParams) validateRequestID(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("request_id", "query", "uuid", o.RequestID.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
+// bindSearch binds and validates parameter Search from query.
+func (o *V2ListEventsParams) bindSearch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Search = &raw
+
+	return nil
+}
+
 // bindSeverities binds and validates array parameter Severities from query.
 //
 // Arrays are parsed according to CollectionFormat: "" (defaults to "csv" when empty).
@@ -514,3 +764,40 @@ func (o *V2ListEventsParams) bindSeverities(rawData []string, hasKey bool, forma
 
 	return nil
 }
+
+// bindStartTime binds and validates parameter StartTime from query.
+func (o *V2ListEventsParams) bindStartTime(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("start_time", "query", "strfmt.DateTime", raw)
+	}
+	o.StartTime = (value.(*strfmt.DateTime))
+
+	if err := o.validateStartTime(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateStartTime carries on validations for parameter StartTime
+func (o *V2ListEventsParams) validateStartTime(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("start_time", "query", "date-time", o.StartTime.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
@@ -28,6 +28,10 @@ type V2ListEventsOK struct {
 	  Minimum: 0
 	*/
 	EventCount int64 `json:"Event-Count"`
+	/*Cursor to pass to retrieve the next page of events, empty when there are no more events.
+
+	 */
+	NextCursor string `json:"Next-Cursor"`
 	/*Count of events with severity 'critical'.
 
 	  Minimum: 0
@@ -72,6 +76,17 @@ func (o *V2ListEventsOK) SetEventCount(eventCount int64) {
 	o.EventCount = eventCount
 }
 
+// WithNextCursor adds the nextCursor to the v2 list events o k response
+func (o *V2ListEventsOK) WithNextCursor(nextCursor string) *V2ListEventsOK {
+	o.NextCursor = nextCursor
+	return o
+}
+
+// SetNextCursor sets the nextCursor to the v2 list events o k response
+func (o *V2ListEventsOK) SetNextCursor(nextCursor string) {
+	o.NextCursor = nextCursor
+}
+
 // WithSeverityCountCritical adds the severityCountCritical to the v2 list events o k response
 func (o *V2ListEventsOK) WithSeverityCountCritical(severityCountCritical int64) *V2ListEventsOK {
 	o.SeverityCountCritical = severityCountCritical
@@ -137,6 +152,13 @@ func (o *V2ListEventsOK) WriteResponse(rw http.ResponseWriter, producer runtime.
 		rw.Header().Set("Event-Count", eventCount)
 	}
 
+	// response header Next-Cursor
+
+	nextCursor := o.NextCursor
+	if nextCursor != "" {
+		rw.Header().Set("Next-Cursor", nextCursor)
+	}
+
 	// response header Severity-Count-Critical
 
 	severityCountCritical := swag.FormatInt64(o.SeverityCountCritical)
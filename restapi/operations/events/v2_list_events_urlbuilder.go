@@ -16,18 +16,26 @@ import (
 
 // V2ListEventsURL generates an URL for the v2 list events operation
 type V2ListEventsURL struct {
-	Categories   []string
-	ClusterID    *strfmt.UUID
-	ClusterLevel *bool
-	DeletedHosts *bool
-	HostID       *strfmt.UUID
-	HostIds      []strfmt.UUID
-	InfraEnvID   *strfmt.UUID
-	Limit        *int64
-	Message      *string
-	Offset       *int64
-	Order        *string
-	Severities   []string
+	Categories      []string
+	ClusterID       *strfmt.UUID
+	ClusterLevel    *bool
+	Cursor          *string
+	DeletedHosts    *bool
+	EndTime         *strfmt.DateTime
+	HostID          *strfmt.UUID
+	HostIds         []strfmt.UUID
+	IncludeArchived *bool
+	InfraEnvID      *strfmt.UUID
+	Limit           *int64
+	Message         *string
+	Names           []string
+	Offset          *int64
+	Order           *string
+	Props           *string
+	RequestID       *strfmt.UUID
+	Search          *string
+	Severities      []string
+	StartTime       *strfmt.DateTime
 
 	_basePath string
 	// avoid unkeyed usage
@@ -96,6 +104,14 @@ func (o *V2ListEventsURL) Build() (*url.URL, error) {
 		qs.Set("cluster_level", clusterLevelQ)
 	}
 
+	var cursorQ string
+	if o.Cursor != nil {
+		cursorQ = *o.Cursor
+	}
+	if cursorQ != "" {
+		qs.Set("cursor", cursorQ)
+	}
+
 	var deletedHostsQ string
 	if o.DeletedHosts != nil {
 		deletedHostsQ = swag.FormatBool(*o.DeletedHosts)
@@ -104,6 +120,14 @@ func (o *V2ListEventsURL) Build() (*url.URL, error) {
 		qs.Set("deleted_hosts", deletedHostsQ)
 	}
 
+	var endTimeQ string
+	if o.EndTime != nil {
+		endTimeQ = o.EndTime.String()
+	}
+	if endTimeQ != "" {
+		qs.Set("end_time", endTimeQ)
+	}
+
 	var hostIDQ string
 	if o.HostID != nil {
 		hostIDQ = o.HostID.String()
@@ -129,6 +153,14 @@ func (o *V2ListEventsURL) Build() (*url.URL, error) {
 		}
 	}
 
+	var includeArchivedQ string
+	if o.IncludeArchived != nil {
+		includeArchivedQ = swag.FormatBool(*o.IncludeArchived)
+	}
+	if includeArchivedQ != "" {
+		qs.Set("include_archived", includeArchivedQ)
+	}
+
 	var infraEnvIDQ string
 	if o.InfraEnvID != nil {
 		infraEnvIDQ = o.InfraEnvID.String()
@@ -153,6 +185,23 @@ func (o *V2ListEventsURL) Build() (*url.URL, error) {
 		qs.Set("message", messageQ)
 	}
 
+	var namesIR []string
+	for _, namesI := range o.Names {
+		namesIS := namesI
+		if namesIS != "" {
+			namesIR = append(namesIR, namesIS)
+		}
+	}
+
+	names := swag.JoinByFormat(namesIR, "")
+
+	if len(names) > 0 {
+		qsv := names[0]
+		if qsv != "" {
+			qs.Set("names", qsv)
+		}
+	}
+
 	var offsetQ string
 	if o.Offset != nil {
 		offsetQ = swag.FormatInt64(*o.Offset)
@@ -169,6 +218,30 @@ func (o *V2ListEventsURL) Build() (*url.URL, error) {
 		qs.Set("order", orderQ)
 	}
 
+	var propsQ string
+	if o.Props != nil {
+		propsQ = *o.Props
+	}
+	if propsQ != "" {
+		qs.Set("props", propsQ)
+	}
+
+	var requestIDQ string
+	if o.RequestID != nil {
+		requestIDQ = o.RequestID.String()
+	}
+	if requestIDQ != "" {
+		qs.Set("request_id", requestIDQ)
+	}
+
+	var searchQ string
+	if o.Search != nil {
+		searchQ = *o.Search
+	}
+	if searchQ != "" {
+		qs.Set("search", searchQ)
+	}
+
 	var severitiesIR []string
 	for _, severitiesI := range o.Severities {
 		severitiesIS := severitiesI
@@ -186,6 +259,14 @@ func (o *V2ListEventsURL) Build() (*url.URL, error) {
 		}
 	}
 
+	var startTimeQ string
+	if o.StartTime != nil {
+		startTimeQ = o.StartTime.String()
+	}
+	if startTimeQ != "" {
+		qs.Set("start_time", startTimeQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
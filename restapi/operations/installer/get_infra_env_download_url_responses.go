@@ -283,6 +283,51 @@ func (o *GetInfraEnvDownloadURLMethodNotAllowed) WriteResponse(rw http.ResponseW
 	}
 }
 
+// GetInfraEnvDownloadURLTooManyRequestsCode is the HTTP code returned for type GetInfraEnvDownloadURLTooManyRequests
+const GetInfraEnvDownloadURLTooManyRequestsCode int = 429
+
+/*
+GetInfraEnvDownloadURLTooManyRequests Too many requests.
+
+swagger:response getInfraEnvDownloadUrlTooManyRequests
+*/
+type GetInfraEnvDownloadURLTooManyRequests struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewGetInfraEnvDownloadURLTooManyRequests creates GetInfraEnvDownloadURLTooManyRequests with default headers values
+func NewGetInfraEnvDownloadURLTooManyRequests() *GetInfraEnvDownloadURLTooManyRequests {
+
+	return &GetInfraEnvDownloadURLTooManyRequests{}
+}
+
+// WithPayload adds the payload to the get infra env download Url too many requests response
+func (o *GetInfraEnvDownloadURLTooManyRequests) WithPayload(payload *models.Error) *GetInfraEnvDownloadURLTooManyRequests {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get infra env download Url too many requests response
+func (o *GetInfraEnvDownloadURLTooManyRequests) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetInfraEnvDownloadURLTooManyRequests) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(429)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // GetInfraEnvDownloadURLInternalServerErrorCode is the HTTP code returned for type GetInfraEnvDownloadURLInternalServerError
 const GetInfraEnvDownloadURLInternalServerErrorCode int = 500
 